@@ -17,6 +17,17 @@ var (
 
 // Config is the configuration struct
 type Config struct {
+	Admin struct {
+		UserIDs []string `envconfig:"ADMIN_USER_IDS"`
+	}
+	Archive struct {
+		MaxSize int64 `envconfig:"ARCHIVE_MAX_SIZE" default:"268435456"`
+	}
+	Attachment struct {
+		StorePath    string   `envconfig:"ATTACHMENT_STORE_PATH" default:"attachments"`
+		MaxSize      int64    `envconfig:"ATTACHMENT_MAX_SIZE" default:"10485760"`
+		AllowedTypes []string `envconfig:"ATTACHMENT_ALLOWED_TYPES" default:"application/pdf,image/jpeg,image/png,image/webp"`
+	}
 	CORS struct {
 		AllowedOrigins []string `envconfig:"CORS_ALLOWED_ORIGINS"`
 	}
@@ -29,9 +40,17 @@ type Config struct {
 		ConnLimit int    `envconfig:"DB_CONN_LIMIT"`
 	}
 	JWT struct {
-		Expiration  time.Duration `envconfig:"JWT_EXPIRATION" default:"120m"`
-		Secret      string        `envconfig:"JWT_SECRET"`
-		TokenCookie string        `envconfig:"JWT_TOKEN_COOKIE" default:"__b_a_T"`
+		Expiration     time.Duration     `envconfig:"JWT_EXPIRATION" default:"120m"`
+		Secret         string            `envconfig:"JWT_SECRET"`
+		TokenCookie    string            `envconfig:"JWT_TOKEN_COOKIE" default:"__b_a_T"`
+		SigningMethod  string            `envconfig:"JWT_SIGNING_METHOD" default:"HS256"`
+		PrivateKeyFile string            `envconfig:"JWT_PRIVATE_KEY_FILE"`
+		KeyID          string            `envconfig:"JWT_KEY_ID"`
+		PublicKeyFiles map[string]string `envconfig:"JWT_PUBLIC_KEY_FILES"`
+	}
+	Purge struct {
+		Retention time.Duration `envconfig:"PURGE_RETENTION" default:"2160h"`
+		Interval  time.Duration `envconfig:"PURGE_INTERVAL" default:"24h"`
 	}
 	Server struct {
 		Port           int           `envconfig:"SERVER_PORT" default:"8080"`
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"fmt"
 
 	// required MySQL import
@@ -74,6 +75,16 @@ func (m *MySQL) Select(dest interface{}, query string, args ...interface{}) (err
 	return m.DB.Select(dest, query, args...)
 }
 
+// Queryx queries records whose columns are not known in advance
+func (m *MySQL) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	return m.DB.Queryx(query, args...)
+}
+
+// Exec executes a query without returning any rows
+func (m *MySQL) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return m.DB.Exec(query, args...)
+}
+
 // In performs queries with IN clause
 func (m *MySQL) In(query string, params ...interface{}) (string, []interface{}, error) {
 	return sqlx.In(query, params...)
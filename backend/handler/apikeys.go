@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// APIKey is the handler interface for API Keys
+type APIKey interface {
+	Startup()
+	Shutdown()
+	HandleCreateAPIKey(w http.ResponseWriter, r *http.Request)
+	HandleGetAPIKeyByFilter(w http.ResponseWriter, r *http.Request)
+	HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request)
+}
+
+// APIKeyImpl is the handler implementation for API Keys
+type APIKeyImpl struct {
+	Service service.APIKey `inject:"apiKeyService"`
+}
+
+// Startup performs startup functions
+func (h *APIKeyImpl) Startup() {
+	logger.Trace("API Key Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *APIKeyImpl) Shutdown() {
+	logger.Trace("API Key Handler shutting down...")
+}
+
+// HandleCreateAPIKey handles the request
+func (h *APIKeyImpl) HandleCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var input model.APIKeyInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	apiKey, plaintext, err := h.Service.Create(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	// the plaintext key is only ever shown in this response
+	output := apiKey.ToOutput()
+	output.Key = plaintext
+
+	response.RespondWithJSON(w, http.StatusCreated, output)
+}
+
+// HandleGetAPIKeyByFilter handles the request
+func (h *APIKeyImpl) HandleGetAPIKeyByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.APIKeyFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	apiKeys, pageInfo, err := h.Service.GetByFilter(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.APIKeyOutput, 0)
+	for _, apiKey := range apiKeys {
+		outputs = append(outputs, apiKey.ToOutput())
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleRevokeAPIKey handles the request
+func (h *APIKeyImpl) HandleRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	apiKey, err := h.Service.Revoke(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, apiKey.ToOutput())
+}
@@ -0,0 +1,248 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	"github.com/kerti/balances/backend/handler/response"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type apiKeyHandlerTestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	handler      handler.APIKey
+	mockSvc      *mock_service.MockAPIKey
+	testUserID   uuid.UUID
+	testAPIKeyID uuid.UUID
+}
+
+func TestAPIKeyHandler(t *testing.T) {
+	suite.Run(t, new(apiKeyHandlerTestSuite))
+}
+
+func (t *apiKeyHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockAPIKey(t.ctrl)
+	t.handler = &handler.APIKeyImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testAPIKeyID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *apiKeyHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *apiKeyHandlerTestSuite) getNewRequestWithContext(method, path string, input any, routeVarId nuuid.NUUID) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	var req *http.Request
+
+	if method == http.MethodPost {
+		jsonBody, err := json.Marshal(input)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		req = httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	// set ID route var
+	if routeVarId.Valid {
+		req = mux.SetURLVars(req, map[string]string{
+			"id": routeVarId.UUID.String(),
+		})
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *apiKeyHandlerTestSuite) getNewAPIKey() (model.APIKey, string) {
+	apiKey, plaintext, err := model.NewAPIKeyFromInput(model.APIKeyInput{Name: "Spreadsheet Sync"}, t.testUserID)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	apiKey.ID = t.testAPIKeyID
+	return apiKey, plaintext
+}
+
+func (t *apiKeyHandlerTestSuite) parseOutputToAPIKey(rr *httptest.ResponseRecorder) (actual *model.APIKeyOutput, fail *failure.Failure) {
+	// read the response
+	var response response.BaseResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+
+	if response.Data != nil {
+		// marshal the data to JSON
+		jsonBytes, err := json.Marshal(*response.Data)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		// unmarshal back to the expected object
+		err = json.Unmarshal(jsonBytes, &actual)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		return actual, nil
+	}
+
+	if response.Error != nil {
+		return nil, response.Error
+	}
+
+	return actual, nil
+}
+
+func (t *apiKeyHandlerTestSuite) TestCreate_Normal() {
+	input := model.APIKeyInput{Name: "Spreadsheet Sync"}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/apiKeys", input, nuuid.NUUID{})
+
+	apiKey, plaintext := t.getNewAPIKey()
+	t.mockSvc.EXPECT().Create(input, t.testUserID).Return(&apiKey, &plaintext, nil)
+
+	t.handler.HandleCreateAPIKey(rr, req)
+
+	actual, err := t.parseOutputToAPIKey(rr)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), apiKey.ID, actual.ID)
+	assert.NotNil(t.T(), actual.Key)
+	assert.Equal(t.T(), plaintext, *actual.Key)
+	assert.NotContains(t.T(), rr.Body.String(), apiKey.KeyHash)
+}
+
+func (t *apiKeyHandlerTestSuite) TestCreate_FailedParsingRequestPayload() {
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/apiKeys", "test", nuuid.NUUID{})
+
+	t.handler.HandleCreateAPIKey(rr, req)
+
+	actual, err := t.parseOutputToAPIKey(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, err.Code)
+	assert.Contains(t.T(), err.Message, "cannot unmarshal")
+}
+
+func (t *apiKeyHandlerTestSuite) TestCreate_ServiceFailedCreating() {
+	errMsg := "service failed creating API key"
+	input := model.APIKeyInput{Name: "Spreadsheet Sync"}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/apiKeys", input, nuuid.NUUID{})
+
+	t.mockSvc.EXPECT().Create(input, t.testUserID).Return(nil, nil, errors.New(errMsg))
+
+	t.handler.HandleCreateAPIKey(rr, req)
+
+	actual, err := t.parseOutputToAPIKey(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), http.StatusInternalServerError, rr.Result().StatusCode)
+	assert.Contains(t.T(), err.Message, errMsg)
+}
+
+func (t *apiKeyHandlerTestSuite) TestGetByFilter_Normal() {
+	input := model.APIKeyFilterInput{}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/apiKeys/search", input, nuuid.NUUID{})
+
+	apiKey, _ := t.getNewAPIKey()
+	t.mockSvc.EXPECT().GetByFilter(input, t.testUserID).
+		Return([]model.APIKey{apiKey}, model.PageInfoOutput{Page: 1, PageSize: 10, TotalCount: 1, PageCount: 1}, nil)
+
+	t.handler.HandleGetAPIKeyByFilter(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), apiKey.ID.String())
+	assert.NotContains(t.T(), rr.Body.String(), apiKey.KeyHash)
+	assert.NotContains(t.T(), rr.Body.String(), `"key"`)
+}
+
+func (t *apiKeyHandlerTestSuite) TestGetByFilter_ServiceFailedResolving() {
+	errMsg := "failed resolving API keys by filter"
+	input := model.APIKeyFilterInput{}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/apiKeys/search", input, nuuid.NUUID{})
+
+	t.mockSvc.EXPECT().GetByFilter(input, t.testUserID).
+		Return([]model.APIKey{}, model.PageInfoOutput{}, errors.New(errMsg))
+
+	t.handler.HandleGetAPIKeyByFilter(rr, req)
+
+	_, err := t.parseOutputToAPIKey(rr)
+
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), http.StatusInternalServerError, rr.Result().StatusCode)
+	assert.Contains(t.T(), err.Message, errMsg)
+}
+
+func (t *apiKeyHandlerTestSuite) TestRevoke_Normal() {
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/apiKeys/"+t.testAPIKeyID.String(), nil, nuuid.From(t.testAPIKeyID))
+
+	apiKey, _ := t.getNewAPIKey()
+	apiKey.Revoke(t.testUserID)
+	t.mockSvc.EXPECT().Revoke(t.testAPIKeyID, t.testUserID).Return(&apiKey, nil)
+
+	t.handler.HandleRevokeAPIKey(rr, req)
+
+	actual, err := t.parseOutputToAPIKey(rr)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), t.testAPIKeyID, actual.ID)
+	assert.True(t.T(), actual.Revoked.Valid)
+	assert.Nil(t.T(), actual.Key)
+}
+
+func (t *apiKeyHandlerTestSuite) TestRevoke_FailedParsingID() {
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/apiKeys/"+t.testAPIKeyID.String(), nil, nuuid.NUUID{})
+
+	t.handler.HandleRevokeAPIKey(rr, req)
+
+	actual, err := t.parseOutputToAPIKey(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, err.Code)
+}
+
+func (t *apiKeyHandlerTestSuite) TestRevoke_ServiceFailedRevoking() {
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/apiKeys/"+t.testAPIKeyID.String(), nil, nuuid.From(t.testAPIKeyID))
+
+	t.mockSvc.EXPECT().Revoke(t.testAPIKeyID, t.testUserID).Return(nil, failure.EntityNotFound("revoke", "API Key"))
+
+	t.handler.HandleRevokeAPIKey(rr, req)
+
+	actual, err := t.parseOutputToAPIKey(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), http.StatusNotFound, rr.Result().StatusCode)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/config"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Archive is the handler interface for Archives
+type Archive interface {
+	Startup()
+	Shutdown()
+	HandleExport(w http.ResponseWriter, r *http.Request)
+	HandleRestore(w http.ResponseWriter, r *http.Request)
+}
+
+// ArchiveImpl is the handler implementation for Archives
+type ArchiveImpl struct {
+	Service service.Archive `inject:"archiveService"`
+	MaxSize int64
+}
+
+// Startup performs startup functions
+func (h *ArchiveImpl) Startup() {
+	logger.Trace("Archive Handler starting up...")
+	if h.MaxSize == 0 {
+		h.MaxSize = config.Get().Archive.MaxSize
+	}
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *ArchiveImpl) Shutdown() {
+	logger.Trace("Archive Handler shutting down...")
+}
+
+// HandleExport handles the request
+func (h *ArchiveImpl) HandleExport(w http.ResponseWriter, r *http.Request) {
+	format := model.ArchiveFormatJSON
+	if param := r.URL.Query().Get("format"); param != "" {
+		format = model.ArchiveFormat(param)
+	}
+
+	contentType, ok := model.ArchiveFormatMap[format]
+	if !ok {
+		response.RespondWithError(w, failure.BadRequestFromString(fmt.Sprintf("unsupported archive format: %s", format)))
+		return
+	}
+
+	download := &archiveDownload{
+		w:           w,
+		contentType: contentType,
+		fileName:    fmt.Sprintf("balances-%s.%s", time.Now().Format("20060102-150405"), format),
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	err := h.Service.Export(download, format, *userID)
+	if err != nil && !download.started {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	// the status is already sent, so a failure from here on can only be logged
+	if err != nil {
+		logger.ErrNoStack("Failed writing archive: %v", err)
+	}
+}
+
+// HandleRestore handles the request
+func (h *ArchiveImpl) HandleRestore(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.MaxSize))
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		response.RespondWithError(w, failure.BadRequestFromString(fmt.Sprintf("the archive must not be larger than %d bytes", h.MaxSize)))
+		return
+	}
+
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	result, err := h.Service.Restore(data, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, result)
+}
+
+// archiveDownload sends the headers of an archive download along with its first bytes, so that an export
+// failing before it writes anything can still be answered with an error
+type archiveDownload struct {
+	w           http.ResponseWriter
+	contentType string
+	fileName    string
+	started     bool
+}
+
+func (d *archiveDownload) Write(p []byte) (int, error) {
+	if !d.started {
+		d.w.Header().Set("Content-Type", d.contentType)
+		d.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", d.fileName))
+		d.w.WriteHeader(http.StatusOK)
+		d.started = true
+	}
+
+	return d.w.Write(p)
+}
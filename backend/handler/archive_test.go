@@ -0,0 +1,197 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type archiveHandlerTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	handler    handler.Archive
+	mockSvc    *mock_service.MockArchive
+	testUserID uuid.UUID
+}
+
+func TestArchiveHandler(t *testing.T) {
+	suite.Run(t, new(archiveHandlerTestSuite))
+}
+
+func (t *archiveHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockArchive(t.ctrl)
+	t.handler = &handler.ArchiveImpl{
+		Service: t.mockSvc,
+		MaxSize: 1024,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *archiveHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *archiveHandlerTestSuite) getNewRequestWithContext(method, path string, body io.Reader) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	req := httptest.NewRequest(method, path, body)
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *archiveHandlerTestSuite) getTestArchive() *model.Archive {
+	archive := model.NewArchive()
+	archive.Users = append(archive.Users, model.User{ID: t.testUserID, Username: "jdoe", Password: "secret"})
+	return &archive
+}
+
+func (t *archiveHandlerTestSuite) expectExport(format model.ArchiveFormat) {
+	t.mockSvc.EXPECT().Export(gomock.Any(), format, t.testUserID).
+		DoAndReturn(func(w io.Writer, format model.ArchiveFormat, userID uuid.UUID) error {
+			output := t.getTestArchive().ToOutput()
+			return output.Write(w, format)
+		})
+}
+
+func (t *archiveHandlerTestSuite) TestExport_JSON() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/export", nil)
+
+	t.expectExport(model.ArchiveFormatJSON)
+
+	t.handler.HandleExport(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), "application/json", rr.Header().Get("Content-Type"))
+	assert.Contains(t.T(), rr.Header().Get("Content-Disposition"), ".json")
+
+	var output model.ArchiveOutput
+	err := json.Unmarshal(rr.Body.Bytes(), &output)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), model.ArchiveVersion, output.Version)
+	assert.Equal(t.T(), "jdoe", output.Users[0].Username)
+	assert.NotContains(t.T(), rr.Body.String(), "secret")
+}
+
+func (t *archiveHandlerTestSuite) TestExport_ZIP() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/export?format=zip", nil)
+
+	t.expectExport(model.ArchiveFormatZIP)
+
+	t.handler.HandleExport(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), "application/zip", rr.Header().Get("Content-Type"))
+
+	zipReader, err := zip.NewReader(bytes.NewReader(rr.Body.Bytes()), int64(rr.Body.Len()))
+	assert.NoError(t.T(), err)
+
+	files := make([]string, 0)
+	for _, file := range zipReader.File {
+		files = append(files, file.Name)
+	}
+	assert.Contains(t.T(), files, "manifest.json")
+	assert.Contains(t.T(), files, "users.csv")
+	assert.Contains(t.T(), files, "property_values.csv")
+}
+
+func (t *archiveHandlerTestSuite) TestExport_UnsupportedFormat() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/export?format=xml", nil)
+
+	t.handler.HandleExport(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *archiveHandlerTestSuite) TestExport_ServiceFailed() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/export", nil)
+
+	t.mockSvc.EXPECT().Export(gomock.Any(), model.ArchiveFormatJSON, t.testUserID).Return(errors.New("failed exporting"))
+
+	t.handler.HandleExport(rr, req)
+
+	assert.Equal(t.T(), http.StatusInternalServerError, rr.Result().StatusCode)
+	assert.Empty(t.T(), rr.Header().Get("Content-Disposition"))
+}
+
+func (t *archiveHandlerTestSuite) TestExport_Forbidden() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/export", nil)
+
+	t.mockSvc.EXPECT().Export(gomock.Any(), model.ArchiveFormatJSON, t.testUserID).
+		Return(failure.Forbidden("export", "Archive", "admin only"))
+
+	t.handler.HandleExport(rr, req)
+
+	assert.Equal(t.T(), http.StatusForbidden, rr.Result().StatusCode)
+}
+
+func (t *archiveHandlerTestSuite) TestExport_FailedWhileWriting() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/export", nil)
+
+	t.mockSvc.EXPECT().Export(gomock.Any(), model.ArchiveFormatJSON, t.testUserID).
+		DoAndReturn(func(w io.Writer, format model.ArchiveFormat, userID uuid.UUID) error {
+			_, _ = w.Write([]byte(`{"version":1`))
+			return errors.New("failed exporting")
+		})
+
+	t.handler.HandleExport(rr, req)
+
+	// the status was sent along with the first bytes, so the archive is merely cut short
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), `{"version":1`, rr.Body.String())
+}
+
+func (t *archiveHandlerTestSuite) TestRestore_Normal() {
+	data := []byte(`{"version":1}`)
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/import/archive", bytes.NewReader(data))
+
+	result := model.ArchiveRestoreResult{Version: 1, BankAccounts: 2}
+	t.mockSvc.EXPECT().Restore(data, t.testUserID).Return(&result, nil)
+
+	t.handler.HandleRestore(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"bankAccounts":2`)
+}
+
+func (t *archiveHandlerTestSuite) TestRestore_Forbidden() {
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/import/archive", bytes.NewReader([]byte("{}")))
+
+	t.mockSvc.EXPECT().Restore(gomock.Any(), t.testUserID).
+		Return(nil, failure.Forbidden("restore", "Archive", "admin only"))
+
+	t.handler.HandleRestore(rr, req)
+
+	assert.Equal(t.T(), http.StatusForbidden, rr.Result().StatusCode)
+}
+
+func (t *archiveHandlerTestSuite) TestRestore_TooLarge() {
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/import/archive", bytes.NewReader(bytes.Repeat([]byte("x"), 1025)))
+
+	t.handler.HandleRestore(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), "1024 bytes")
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// AttachmentFormField is the multipart form field an Attachment's file is uploaded in
+const AttachmentFormField = "file"
+
+// Attachment is the handler interface for Attachments
+type Attachment interface {
+	Startup()
+	Shutdown()
+	HandleCreateAttachment(w http.ResponseWriter, r *http.Request)
+	HandleGetAttachmentByID(w http.ResponseWriter, r *http.Request)
+	HandleGetAttachmentByFilter(w http.ResponseWriter, r *http.Request)
+	HandleGetEntityAttachments(w http.ResponseWriter, r *http.Request)
+	HandleDownloadAttachment(w http.ResponseWriter, r *http.Request)
+	HandleDeleteAttachment(w http.ResponseWriter, r *http.Request)
+}
+
+// AttachmentImpl is the handler implementation for Attachments
+type AttachmentImpl struct {
+	Service service.Attachment `inject:"attachmentService"`
+}
+
+// Startup performs startup functions
+func (h *AttachmentImpl) Startup() {
+	logger.Trace("Attachment Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *AttachmentImpl) Shutdown() {
+	logger.Trace("Attachment Handler shutting down...")
+}
+
+// HandleCreateAttachment handles the request. The file is streamed from a multipart form to the service,
+// which enforces the size limit, so the request is never buffered as a whole.
+func (h *AttachmentImpl) HandleCreateAttachment(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			response.RespondWithError(w, failure.BadRequestFromString("file is required"))
+			return
+		}
+
+		if err != nil {
+			response.RespondWithError(w, failure.BadRequest(err))
+			return
+		}
+
+		if part.FormName() != AttachmentFormField {
+			continue
+		}
+
+		input := model.AttachmentInput{
+			EntityType: getEntityTypeFromRequest(r),
+			SubjectID:  id,
+			FileName:   part.FileName(),
+			Content:    part,
+		}
+
+		userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+		attachment, err := h.Service.Create(input, *userID)
+		if err != nil {
+			response.RespondWithError(w, err)
+			return
+		}
+
+		response.RespondWithJSON(w, http.StatusCreated, attachment.ToOutput())
+		return
+	}
+}
+
+// HandleGetAttachmentByID handles the request
+func (h *AttachmentImpl) HandleGetAttachmentByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	attachment, err := h.Service.GetByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, attachment.ToOutput())
+}
+
+// HandleGetAttachmentByFilter handles the request
+func (h *AttachmentImpl) HandleGetAttachmentByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.AttachmentFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	attachments, pageInfo, err := h.Service.GetByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.AttachmentOutput, 0)
+	for _, attachment := range attachments {
+		output := attachment.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleGetEntityAttachments handles the request
+func (h *AttachmentImpl) HandleGetEntityAttachments(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	attachments, err := h.Service.GetByEntity(getEntityTypeFromRequest(r), id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.AttachmentOutput, 0)
+	for _, attachment := range attachments {
+		output := attachment.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, outputs)
+}
+
+// HandleDownloadAttachment handles the request
+func (h *AttachmentImpl) HandleDownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	download, err := h.Service.Download(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	attachment := download.Attachment
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("ETag", strconv.Quote(attachment.Checksum))
+	w.WriteHeader(http.StatusOK)
+
+	// the status is already sent, so a failure from here on can only be logged
+	_, err = w.Write(download.Data)
+	if err != nil {
+		logger.ErrNoStack("Failed writing attachment: %v", err)
+	}
+}
+
+// HandleDeleteAttachment handles the request
+func (h *AttachmentImpl) HandleDeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	attachment, err := h.Service.Delete(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, attachment.ToOutput())
+}
@@ -0,0 +1,237 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const attachmentHandlerTestContent = "%PDF-1.4\n%test statement\n"
+
+type attachmentHandlerTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	handler           handler.Attachment
+	mockSvc           *mock_service.MockAttachment
+	testUserID        uuid.UUID
+	testAttachmentID  uuid.UUID
+	testBankAccountID uuid.UUID
+}
+
+func TestAttachmentHandler(t *testing.T) {
+	suite.Run(t, new(attachmentHandlerTestSuite))
+}
+
+func (t *attachmentHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockAttachment(t.ctrl)
+	t.handler = &handler.AttachmentImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testAttachmentID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *attachmentHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *attachmentHandlerTestSuite) getNewRequestWithContext(method, path string, body io.Reader, contentType string, routeVars map[string]string) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	req := httptest.NewRequest(method, path, body)
+
+	// set route vars
+	if routeVars != nil {
+		req = mux.SetURLVars(req, routeVars)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *attachmentHandlerTestSuite) getMultipartBody(fieldName, fileName, content string) (body *bytes.Buffer, contentType string) {
+	body = new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile(fieldName, fileName)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+
+	_, err = part.Write([]byte(content))
+	if err != nil {
+		t.T().Fatal(err)
+	}
+
+	err = writer.Close()
+	if err != nil {
+		t.T().Fatal(err)
+	}
+
+	return body, writer.FormDataContentType()
+}
+
+func (t *attachmentHandlerTestSuite) getEntityRouteVars() map[string]string {
+	return map[string]string{
+		"entityType": string(model.EntityTypeBankAccount),
+		"id":         t.testBankAccountID.String(),
+	}
+}
+
+func (t *attachmentHandlerTestSuite) getNewAttachment() model.Attachment {
+	return model.Attachment{
+		ID:          t.testAttachmentID,
+		EntityType:  model.EntityTypeBankAccount,
+		SubjectID:   t.testBankAccountID,
+		FileName:    "statement.pdf",
+		ContentType: "application/pdf",
+		Size:        int64(len(attachmentHandlerTestContent)),
+		Checksum:    model.GetAttachmentChecksum([]byte(attachmentHandlerTestContent)),
+		Created:     time.Now(),
+		CreatedBy:   t.testUserID,
+	}
+}
+
+func (t *attachmentHandlerTestSuite) TestCreate_Normal() {
+	body, contentType := t.getMultipartBody(handler.AttachmentFormField, "statement.pdf", attachmentHandlerTestContent)
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/attachments/entities/bankAccount/"+t.testBankAccountID.String(), body, contentType, t.getEntityRouteVars())
+
+	expectedResult := t.getNewAttachment()
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).
+		DoAndReturn(func(input model.AttachmentInput, userID uuid.UUID) (*model.Attachment, error) {
+			content, err := io.ReadAll(input.Content)
+			assert.Nil(t.T(), err)
+			assert.Equal(t.T(), attachmentHandlerTestContent, string(content))
+			assert.Equal(t.T(), model.EntityTypeBankAccount, input.EntityType)
+			assert.Equal(t.T(), t.testBankAccountID, input.SubjectID)
+			assert.Equal(t.T(), "statement.pdf", input.FileName)
+			return &expectedResult, nil
+		})
+
+	t.handler.HandleCreateAttachment(rr, req)
+
+	var resBody struct {
+		Data model.AttachmentOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &resBody)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, resBody.Data.ID)
+	assert.Equal(t.T(), expectedResult.Checksum, resBody.Data.Checksum)
+}
+
+func (t *attachmentHandlerTestSuite) TestCreate_NoFile() {
+	body, contentType := t.getMultipartBody("other", "statement.pdf", attachmentHandlerTestContent)
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/attachments/entities/bankAccount/"+t.testBankAccountID.String(), body, contentType, t.getEntityRouteVars())
+
+	t.handler.HandleCreateAttachment(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *attachmentHandlerTestSuite) TestCreate_NotMultipart() {
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/attachments/entities/bankAccount/"+t.testBankAccountID.String(), bytes.NewBufferString("{}"), "application/json", t.getEntityRouteVars())
+
+	t.handler.HandleCreateAttachment(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *attachmentHandlerTestSuite) TestGetEntityAttachments_Normal() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/attachments/entities/bankAccount/"+t.testBankAccountID.String(), nil, "application/json", t.getEntityRouteVars())
+
+	t.mockSvc.EXPECT().GetByEntity(model.EntityTypeBankAccount, t.testBankAccountID).Return([]model.Attachment{t.getNewAttachment()}, nil)
+
+	t.handler.HandleGetEntityAttachments(rr, req)
+
+	var resBody struct {
+		Data []model.AttachmentOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &resBody)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), resBody.Data, 1)
+}
+
+func (t *attachmentHandlerTestSuite) TestDownload_Normal() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/attachments/"+t.testAttachmentID.String()+"/download", nil, "application/json", map[string]string{"id": t.testAttachmentID.String()})
+
+	attachment := t.getNewAttachment()
+	t.mockSvc.EXPECT().Download(t.testAttachmentID).Return(&model.AttachmentDownload{
+		Attachment: attachment,
+		Data:       []byte(attachmentHandlerTestContent),
+	}, nil)
+
+	t.handler.HandleDownloadAttachment(rr, req)
+
+	res := rr.Result()
+	assert.Equal(t.T(), http.StatusOK, res.StatusCode)
+	assert.Equal(t.T(), "application/pdf", res.Header.Get("Content-Type"))
+	assert.Equal(t.T(), `attachment; filename="statement.pdf"`, res.Header.Get("Content-Disposition"))
+	assert.Equal(t.T(), strconv.Itoa(len(attachmentHandlerTestContent)), res.Header.Get("Content-Length"))
+	assert.Equal(t.T(), strconv.Quote(attachment.Checksum), res.Header.Get("ETag"))
+	assert.Equal(t.T(), attachmentHandlerTestContent, rr.Body.String())
+}
+
+func (t *attachmentHandlerTestSuite) TestDownload_NotFound() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/attachments/"+t.testAttachmentID.String()+"/download", nil, "application/json", map[string]string{"id": t.testAttachmentID.String()})
+
+	t.mockSvc.EXPECT().Download(t.testAttachmentID).Return(nil, failure.EntityNotFound("download", "Attachment"))
+
+	t.handler.HandleDownloadAttachment(rr, req)
+
+	assert.Equal(t.T(), http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func (t *attachmentHandlerTestSuite) TestDelete_Normal() {
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/attachments/"+t.testAttachmentID.String(), nil, "application/json", map[string]string{"id": t.testAttachmentID.String()})
+
+	attachment := t.getNewAttachment()
+	_ = attachment.Delete(t.testUserID)
+	t.mockSvc.EXPECT().Delete(t.testAttachmentID, t.testUserID).Return(&attachment, nil)
+
+	t.handler.HandleDeleteAttachment(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+}
+
+func (t *attachmentHandlerTestSuite) TestDelete_NotUploader() {
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/attachments/"+t.testAttachmentID.String(), nil, "application/json", map[string]string{"id": t.testAttachmentID.String()})
+
+	t.mockSvc.EXPECT().Delete(t.testAttachmentID, t.testUserID).Return(nil, failure.Forbidden("delete", "Attachment", "uploader only"))
+
+	t.handler.HandleDeleteAttachment(rr, req)
+
+	assert.Equal(t.T(), http.StatusForbidden, rr.Result().StatusCode)
+}
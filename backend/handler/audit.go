@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// AuditLog is the handler interface for Audit Logs
+type AuditLog interface {
+	Startup()
+	Shutdown()
+	HandleGetAuditLogByFilter(w http.ResponseWriter, r *http.Request)
+}
+
+// AuditLogImpl is the handler implementation for Audit Logs
+type AuditLogImpl struct {
+	Service service.AuditLog `inject:"auditLogService"`
+}
+
+// Startup performs startup functions
+func (h *AuditLogImpl) Startup() {
+	logger.Trace("Audit Log Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *AuditLogImpl) Shutdown() {
+	logger.Trace("Audit Log Handler shutting down...")
+}
+
+// HandleGetAuditLogByFilter handles the request
+func (h *AuditLogImpl) HandleGetAuditLogByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.AuditLogFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	auditLogs, pageInfo, err := h.Service.GetByFilter(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.AuditLogOutput, 0)
+	for _, auditLog := range auditLogs {
+		outputs = append(outputs, auditLog.ToOutput())
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
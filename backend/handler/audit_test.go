@@ -0,0 +1,138 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler"
+	"github.com/kerti/balances/backend/handler/response"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type auditLogHandlerTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	handler       handler.AuditLog
+	mockSvc       *mock_service.MockAuditLog
+	testUserID    uuid.UUID
+	testSubjectID uuid.UUID
+}
+
+func TestAuditLogHandler(t *testing.T) {
+	suite.Run(t, new(auditLogHandlerTestSuite))
+}
+
+func (t *auditLogHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockAuditLog(t.ctrl)
+	t.handler = &handler.AuditLogImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testSubjectID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *auditLogHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *auditLogHandlerTestSuite) getNewRequestWithContext(body []byte) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	req := httptest.NewRequest(http.MethodPost, "/audit/search", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *auditLogHandlerTestSuite) parseResponse(rr *httptest.ResponseRecorder) response.BaseResponse {
+	var response response.BaseResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	return response
+}
+
+func (t *auditLogHandlerTestSuite) TestGetByFilter_Normal() {
+	input := model.AuditLogFilterInput{
+		SubjectIDs: &[]uuid.UUID{t.testSubjectID},
+	}
+	body, _ := json.Marshal(input)
+	rr, req := t.getNewRequestWithContext(body)
+
+	auditLog, _ := model.NewAuditLog(
+		model.EntityTypeVehicle,
+		t.testSubjectID,
+		model.AuditActionUpdate,
+		t.testUserID,
+		map[string]string{"name": "Old Car"},
+		map[string]string{"name": "New Car"})
+	t.mockSvc.EXPECT().GetByFilter(gomock.Any(), t.testUserID).
+		Return([]model.AuditLog{auditLog}, model.PageInfoOutput{Page: 1, PageSize: 10, TotalCount: 1, PageCount: 1}, nil)
+
+	t.handler.HandleGetAuditLogByFilter(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), t.testSubjectID.String())
+	assert.Contains(t.T(), rr.Body.String(), `"before":{"name":"Old Car"}`)
+	assert.Contains(t.T(), rr.Body.String(), `"after":{"name":"New Car"}`)
+}
+
+func (t *auditLogHandlerTestSuite) TestGetByFilter_FailedParsingInput() {
+	rr, req := t.getNewRequestWithContext([]byte("{"))
+
+	t.handler.HandleGetAuditLogByFilter(rr, req)
+
+	response := t.parseResponse(rr)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+	assert.NotNil(t.T(), response.Error)
+}
+
+func (t *auditLogHandlerTestSuite) TestGetByFilter_ServiceFailedResolving() {
+	errMsg := "failed resolving audit logs by filter"
+	body, _ := json.Marshal(model.AuditLogFilterInput{})
+	rr, req := t.getNewRequestWithContext(body)
+
+	t.mockSvc.EXPECT().GetByFilter(gomock.Any(), t.testUserID).
+		Return([]model.AuditLog{}, model.PageInfoOutput{}, errors.New(errMsg))
+
+	t.handler.HandleGetAuditLogByFilter(rr, req)
+
+	response := t.parseResponse(rr)
+
+	assert.Equal(t.T(), http.StatusInternalServerError, rr.Result().StatusCode)
+	assert.NotNil(t.T(), response.Error)
+	assert.Contains(t.T(), response.Error.Message, errMsg)
+}
+
+func (t *auditLogHandlerTestSuite) TestGetByFilter_Forbidden() {
+	body, _ := json.Marshal(model.AuditLogFilterInput{})
+	rr, req := t.getNewRequestWithContext(body)
+
+	t.mockSvc.EXPECT().GetByFilter(gomock.Any(), t.testUserID).
+		Return([]model.AuditLog{}, model.PageInfoOutput{}, failure.Forbidden("search", "Audit Log", "admin only"))
+
+	t.handler.HandleGetAuditLogByFilter(rr, req)
+
+	assert.Equal(t.T(), http.StatusForbidden, rr.Result().StatusCode)
+}
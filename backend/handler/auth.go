@@ -18,6 +18,7 @@ type Auth interface {
 	Shutdown()
 	HandleAuthLogin(w http.ResponseWriter, r *http.Request)
 	HandleGetToken(w http.ResponseWriter, r *http.Request)
+	HandleGetJWKS(w http.ResponseWriter, r *http.Request)
 }
 
 // AuthImpl handles all requests related to authentication and authorization
@@ -74,3 +75,8 @@ func (h *AuthImpl) HandleGetToken(w http.ResponseWriter, r *http.Request) {
 	}
 	response.RespondWithJSON(w, http.StatusOK, responseObject)
 }
+
+// HandleGetJWKS publishes the public keys used to verify tokens as a JSON Web Key Set
+func (h *AuthImpl) HandleGetJWKS(w http.ResponseWriter, r *http.Request) {
+	response.RespondWithRawJSON(w, http.StatusOK, h.Service.GetJWKS())
+}
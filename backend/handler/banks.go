@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kerti/balances/backend/handler/response"
@@ -24,11 +25,20 @@ type BankAccount interface {
 	HandleGetBankAccountByFilter(w http.ResponseWriter, r *http.Request)
 	HandleUpdateBankAccount(w http.ResponseWriter, r *http.Request)
 	HandleDeleteBankAccount(w http.ResponseWriter, r *http.Request)
+	HandleRestoreBankAccount(w http.ResponseWriter, r *http.Request)
 	HandleCreateBankAccountBalance(w http.ResponseWriter, r *http.Request)
+	HandleImportBankAccountBalances(w http.ResponseWriter, r *http.Request)
+	HandleImportBankStatement(w http.ResponseWriter, r *http.Request)
 	HandleGetBankAccountBalanceByID(w http.ResponseWriter, r *http.Request)
 	HandleGetBankAccountBalanceByFilter(w http.ResponseWriter, r *http.Request)
 	HandleUpdateBankAccountBalance(w http.ResponseWriter, r *http.Request)
 	HandleDeleteBankAccountBalance(w http.ResponseWriter, r *http.Request)
+	HandleCreateBankAccountCashFlow(w http.ResponseWriter, r *http.Request)
+	HandleGetBankAccountCashFlowByID(w http.ResponseWriter, r *http.Request)
+	HandleGetBankAccountCashFlowByFilter(w http.ResponseWriter, r *http.Request)
+	HandleUpdateBankAccountCashFlow(w http.ResponseWriter, r *http.Request)
+	HandleDeleteBankAccountCashFlow(w http.ResponseWriter, r *http.Request)
+	HandleGetBankAccountReturns(w http.ResponseWriter, r *http.Request)
 }
 
 // BankAccountImpl is the handler implementation for Bank Accounts
@@ -112,6 +122,17 @@ func (h *BankAccountImpl) HandleGetBankAccountByFilter(w http.ResponseWriter, r
 		return
 	}
 
+	if input.Aggregation != nil {
+		aggregateRows, err := h.Service.AggregateByFilter(input)
+		if err != nil {
+			response.RespondWithError(w, err)
+			return
+		}
+
+		response.RespondWithJSON(w, http.StatusOK, model.AggregationOutput{Items: aggregateRows})
+		return
+	}
+
 	bankAccounts, pageInfo, err := h.Service.GetByFilter(input)
 	if err != nil {
 		response.RespondWithError(w, err)
@@ -176,6 +197,23 @@ func (h *BankAccountImpl) HandleDeleteBankAccount(w http.ResponseWriter, r *http
 	response.RespondWithJSON(w, http.StatusOK, bankAccount.ToOutput())
 }
 
+// HandleRestoreBankAccount handles the request
+func (h *BankAccountImpl) HandleRestoreBankAccount(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	bankAccount, err := h.Service.Restore(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, bankAccount.ToOutput())
+}
+
 // HandleCreateBankAccountBalance handles the request
 func (h *BankAccountImpl) HandleCreateBankAccountBalance(w http.ResponseWriter, r *http.Request) {
 	input, err := h.getBalanceInputFromRequest(w, r)
@@ -193,6 +231,49 @@ func (h *BankAccountImpl) HandleCreateBankAccountBalance(w http.ResponseWriter,
 	response.RespondWithJSON(w, http.StatusCreated, bankAccountBalance.ToOutput())
 }
 
+// HandleImportBankAccountBalances handles the request
+func (h *BankAccountImpl) HandleImportBankAccountBalances(w http.ResponseWriter, r *http.Request) {
+	var input model.BankAccountBalanceImportInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	result, err := h.Service.ImportBalances(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, getImportStatus(result), result.ToOutput())
+}
+
+// HandleImportBankStatement handles the request
+func (h *BankAccountImpl) HandleImportBankStatement(w http.ResponseWriter, r *http.Request) {
+	var input model.StatementImportInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	result, err := h.Service.ImportStatement(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if result.DryRun {
+		status = http.StatusOK
+	}
+
+	response.RespondWithJSON(w, status, result.ToOutput())
+}
+
 // HandleGetBankAccountBalanceByID handles the request
 func (h *BankAccountImpl) HandleGetBankAccountBalanceByID(w http.ResponseWriter, r *http.Request) {
 	id, err := getIDFromRequest(w, r)
@@ -282,6 +363,150 @@ func (h *BankAccountImpl) HandleDeleteBankAccountBalance(w http.ResponseWriter,
 	response.RespondWithJSON(w, http.StatusCreated, bankAccountBalance.ToOutput())
 }
 
+// HandleCreateBankAccountCashFlow handles the request
+func (h *BankAccountImpl) HandleCreateBankAccountCashFlow(w http.ResponseWriter, r *http.Request) {
+	input, err := h.getCashFlowInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	bankAccountCashFlow, err := h.Service.CreateCashFlow(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, bankAccountCashFlow.ToOutput())
+}
+
+// HandleGetBankAccountCashFlowByID handles the request
+func (h *BankAccountImpl) HandleGetBankAccountCashFlowByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	bankAccountCashFlow, err := h.Service.GetCashFlowByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, bankAccountCashFlow.ToOutput())
+}
+
+// HandleGetBankAccountCashFlowByFilter handles the request
+func (h *BankAccountImpl) HandleGetBankAccountCashFlowByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.BankAccountCashFlowFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	bankAccountCashFlows, pageInfo, err := h.Service.GetCashFlowsByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.BankAccountCashFlowOutput, 0)
+	for _, bankAccountCashFlow := range bankAccountCashFlows {
+		output := bankAccountCashFlow.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleUpdateBankAccountCashFlow handles the request
+func (h *BankAccountImpl) HandleUpdateBankAccountCashFlow(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	input, err := h.getCashFlowInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	if input.ID.String() != id.String() {
+		response.RespondWithError(w, failure.BadRequestFromString("id mismatch"))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	bankAccountCashFlow, err := h.Service.UpdateCashFlow(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, bankAccountCashFlow.ToOutput())
+}
+
+// HandleDeleteBankAccountCashFlow handles the request
+func (h *BankAccountImpl) HandleDeleteBankAccountCashFlow(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	bankAccountCashFlow, err := h.Service.DeleteCashFlow(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, bankAccountCashFlow.ToOutput())
+}
+
+// HandleGetBankAccountReturns handles the request. The period starts at the end of the from date, which is
+// required, and ends at the end of the to date, which defaults to today.
+func (h *BankAccountImpl) HandleGetBankAccountReturns(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Get("from") == "" {
+		response.RespondWithError(w, failure.BadRequestFromString("the from date is required"))
+		return
+	}
+
+	from, err := model.ParseReportDate(query.Get("from"))
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	to, err := model.ParseReportDate(time.Now().Format("2006-01-02"))
+	if param := query.Get("to"); param != "" {
+		to, err = model.ParseReportDate(param)
+	}
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	returns, err := h.Service.GetReturns(id, from, to)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, returns.ToOutput())
+}
+
 func (h *BankAccountImpl) getInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.BankAccountInput, err error) {
 	err = json.NewDecoder(r.Body).Decode(&input)
 	if err != nil {
@@ -299,3 +524,12 @@ func (h *BankAccountImpl) getBalanceInputFromRequest(w http.ResponseWriter, r *h
 
 	return
 }
+
+func (h *BankAccountImpl) getCashFlowInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.BankAccountCashFlowInput, err error) {
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+	}
+
+	return
+}
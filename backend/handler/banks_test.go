@@ -22,6 +22,7 @@ import (
 	"github.com/kerti/balances/backend/util/cachetime"
 	"github.com/kerti/balances/backend/util/ctxprops"
 	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
 	"github.com/kerti/balances/backend/util/nuuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -624,6 +625,44 @@ func (t *bankAccountHandlerTestSuite) TestGetByFilter_Normal() {
 	assert.Equal(t.T(), 1, pageInfo.Page)
 }
 
+func (t *bankAccountHandlerTestSuite) TestGetByFilter_Aggregation() {
+	field := "lastBalance"
+	input := model.BankAccountFilterInput{}
+	input.Aggregation = &filter.AggregationInput{
+		GroupBy: []string{"bankName"},
+		Aggregates: []filter.AggregateInput{
+			{Function: filter.AggregateFunctionSum, Field: &field},
+		},
+	}
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/search",
+		input,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	expectedRows := []model.AggregateRow{
+		{
+			Group:  map[string]interface{}{"bankName": "ACME"},
+			Values: map[string]interface{}{"sumLastBalance": float64(1500)},
+		},
+	}
+
+	t.mockSvc.EXPECT().AggregateByFilter(input).Return(expectedRows, nil)
+
+	t.handler.HandleGetBankAccountByFilter(rr, req)
+
+	var output struct {
+		Data model.AggregationOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &output)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedRows, output.Data.Items)
+}
+
 func (t *bankAccountHandlerTestSuite) TestGetByFilter_FailedParsingRequestPayload() {
 	input := "test"
 	rr, req := t.getNewRequestWithContext(
@@ -883,6 +922,79 @@ func (t *bankAccountHandlerTestSuite) TestDelete_ServiceFailedDeleting() {
 	assert.Nil(t.T(), err.Operation)
 }
 
+func (t *bankAccountHandlerTestSuite) TestRestore_Normal() {
+	input := t.getNewBankAccountInput(nuuid.From(t.testBankAccountID))
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/"+t.testBankAccountID.String()+"/restore",
+		nil,
+		nil,
+		nuuid.From(t.testBankAccountID),
+	)
+
+	restoredBankAccount := model.NewBankAccountFromInput(input, t.testUserID)
+	restoredBankAccount.ID = t.testBankAccountID
+
+	t.mockSvc.EXPECT().Restore(t.testBankAccountID, t.testUserID).Return(&restoredBankAccount, nil)
+
+	t.handler.HandleRestoreBankAccount(rr, req)
+
+	actual, err := t.parseOutputToBankAccount(rr)
+
+	assert.NotNil(t.T(), actual)
+	assert.Equal(t.T(), t.testBankAccountID, actual.ID)
+	assert.Nil(t.T(), err)
+}
+
+func (t *bankAccountHandlerTestSuite) TestRestore_FailedParsingID() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/"+t.testBankAccountID.String()+"/restore",
+		nil,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.handler.HandleRestoreBankAccount(rr, req)
+
+	actual, err := t.parseOutputToBankAccount(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, err.Code)
+	// TODO: specify this
+	assert.Nil(t.T(), err.Entity)
+	assert.Contains(t.T(), err.Message, "invalid UUID length")
+	// TODO: specify this
+	assert.Nil(t.T(), err.Operation)
+}
+
+func (t *bankAccountHandlerTestSuite) TestRestore_ServiceFailedRestoring() {
+	errMsg := "service failed restoring bankAccount"
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/"+t.testBankAccountID.String()+"/restore",
+		nil,
+		nil,
+		nuuid.From(t.testBankAccountID),
+	)
+
+	t.mockSvc.EXPECT().Restore(t.testBankAccountID, t.testUserID).Return(nil, errors.New(errMsg))
+
+	t.handler.HandleRestoreBankAccount(rr, req)
+
+	actual, err := t.parseOutputToBankAccount(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeInternalError, err.Code)
+	// TODO: specify this
+	assert.Nil(t.T(), err.Entity)
+	assert.Contains(t.T(), err.Message, errMsg)
+	// TODO: specify this
+	assert.Nil(t.T(), err.Operation)
+}
+
 func (t *bankAccountHandlerTestSuite) TestCreateBalance_Normal() {
 	input := t.getNewBankAccountBalanceInput(nuuid.NUUID{Valid: false}, nuuid.NUUID{Valid: false})
 	rr, req := t.getNewRequestWithContext(
@@ -968,6 +1080,138 @@ func (t *bankAccountHandlerTestSuite) TestCreateBalance_ServiceFailedCreatingBal
 	assert.Nil(t.T(), err.Operation)
 }
 
+func (t *bankAccountHandlerTestSuite) TestImportBalances_Normal() {
+	input := model.BankAccountBalanceImportInput{
+		ImportInput: model.ImportInput{
+			CSV:     "date,balance\n2024-01-31,100\n",
+			Mapping: model.ImportColumnMapping{Date: "date", Amount: "balance"},
+		},
+		BankAccountID: t.testBankAccountID,
+	}
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/balances/import",
+		input,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportBalances(input, t.testUserID).
+		Return(&model.ImportResult{Rows: 1, Imported: 1, Errors: []model.ImportRowError{}}, nil)
+
+	t.handler.HandleImportBankAccountBalances(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"imported":1`)
+	assert.Contains(t.T(), rr.Body.String(), `"valid":true`)
+}
+
+func (t *bankAccountHandlerTestSuite) TestImportBalances_DryRun() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/balances/import",
+		model.BankAccountBalanceImportInput{ImportInput: model.ImportInput{DryRun: true}},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportBalances(gomock.Any(), t.testUserID).
+		Return(&model.ImportResult{DryRun: true, Rows: 2, Errors: []model.ImportRowError{}}, nil)
+
+	t.handler.HandleImportBankAccountBalances(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"dryRun":true`)
+}
+
+func (t *bankAccountHandlerTestSuite) TestImportBalances_RowErrors() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/balances/import",
+		model.BankAccountBalanceImportInput{},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportBalances(gomock.Any(), t.testUserID).
+		Return(&model.ImportResult{Rows: 1, Errors: []model.ImportRowError{{Line: 2, Message: "invalid amount"}}}, nil)
+
+	t.handler.HandleImportBankAccountBalances(rr, req)
+
+	assert.Equal(t.T(), http.StatusUnprocessableEntity, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"line":2`)
+	assert.Contains(t.T(), rr.Body.String(), `"valid":false`)
+}
+
+func (t *bankAccountHandlerTestSuite) TestImportBalances_FailedParsingRequestPayload() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/balances/import",
+		"test",
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.handler.HandleImportBankAccountBalances(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *bankAccountHandlerTestSuite) TestImportStatement_Normal() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/balances/statements",
+		model.StatementImportInput{Content: "<OFX></OFX>"},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportStatement(gomock.Any(), t.testUserID).
+		Return(&model.StatementImportResult{
+			Accounts: []model.StatementAccountResult{
+				{AccountNumber: "1234567890", BankAccountID: &t.testBankAccountID, Balances: 2, Imported: 2},
+			},
+		}, nil)
+
+	t.handler.HandleImportBankStatement(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"imported":2`)
+	assert.Contains(t.T(), rr.Body.String(), t.testBankAccountID.String())
+}
+
+func (t *bankAccountHandlerTestSuite) TestImportStatement_DryRun() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/balances/statements",
+		model.StatementImportInput{Content: "<OFX></OFX>", DryRun: true},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportStatement(gomock.Any(), t.testUserID).
+		Return(&model.StatementImportResult{DryRun: true, Accounts: []model.StatementAccountResult{}}, nil)
+
+	t.handler.HandleImportBankStatement(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"dryRun":true`)
+}
+
+func (t *bankAccountHandlerTestSuite) TestImportStatement_FailedParsingRequestPayload() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/balances/statements",
+		"test",
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.handler.HandleImportBankStatement(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
 func (t *bankAccountHandlerTestSuite) TestGetBalanceByID_Normal() {
 	rr, req := t.getNewRequestWithContext(
 		http.MethodGet,
@@ -1341,3 +1585,175 @@ func (t *bankAccountHandlerTestSuite) TestDeleteBalance_ServiceFailedDeleting()
 	// TODO: specify this
 	assert.Nil(t.T(), err.Operation)
 }
+
+func (t *bankAccountHandlerTestSuite) getNewBankAccountCashFlowInput(id nuuid.NUUID) model.BankAccountCashFlowInput {
+	input := model.BankAccountCashFlowInput{
+		BankAccountID: t.testBankAccountID,
+		Date:          cachetime.CacheTime(time.Now()),
+		Type:          model.BankAccountCashFlowTypeDeposit,
+		Amount:        float64(1000),
+		Note:          "monthly contribution",
+	}
+
+	if id.Valid {
+		input.ID = id.UUID
+	}
+
+	return input
+}
+
+func (t *bankAccountHandlerTestSuite) TestCreateCashFlow_Normal() {
+	input := t.getNewBankAccountCashFlowInput(nuuid.NUUID{Valid: false})
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/cashFlows",
+		input,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	expectedResult := model.NewBankAccountCashFlowFromInput(input, input.BankAccountID, t.testUserID)
+
+	t.mockSvc.EXPECT().CreateCashFlow(gomock.Any(), t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateBankAccountCashFlow(rr, req)
+
+	var body struct {
+		Data model.BankAccountCashFlowOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.Equal(t.T(), model.BankAccountCashFlowTypeDeposit, body.Data.Type)
+	assert.Equal(t.T(), float64(1000), body.Data.Amount)
+}
+
+func (t *bankAccountHandlerTestSuite) TestCreateCashFlow_ServiceFailedValidation() {
+	input := t.getNewBankAccountCashFlowInput(nuuid.NUUID{Valid: false})
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/cashFlows",
+		input,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().CreateCashFlow(gomock.Any(), t.testUserID).
+		Return(nil, failure.BadRequestFromString("cash flow amount must be greater than zero"))
+
+	t.handler.HandleCreateBankAccountCashFlow(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *bankAccountHandlerTestSuite) TestGetCashFlowByFilter_Normal() {
+	input := model.BankAccountCashFlowFilterInput{BankAccountIDs: &[]uuid.UUID{t.testBankAccountID}}
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/bankAccounts/cashFlows/search",
+		input,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	cashFlow := model.NewBankAccountCashFlowFromInput(t.getNewBankAccountCashFlowInput(nuuid.NUUID{Valid: false}), t.testBankAccountID, t.testUserID)
+
+	t.mockSvc.EXPECT().GetCashFlowsByFilter(gomock.Any()).
+		Return([]model.BankAccountCashFlow{cashFlow}, model.PageInfoOutput{Page: 1, PageSize: 10, TotalCount: 1, PageCount: 1}, nil)
+
+	t.handler.HandleGetBankAccountCashFlowByFilter(rr, req)
+
+	var body struct {
+		Data struct {
+			Items    []model.BankAccountCashFlowOutput `json:"items"`
+			PageInfo model.PageInfoOutput              `json:"pageInfo"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data.Items, 1)
+	assert.Equal(t.T(), 1, body.Data.PageInfo.TotalCount)
+}
+
+func (t *bankAccountHandlerTestSuite) TestUpdateCashFlow_IDMismatch() {
+	id, _ := uuid.NewV7()
+	otherID, _ := uuid.NewV7()
+	input := t.getNewBankAccountCashFlowInput(nuuid.From(otherID))
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPatch,
+		"/bankAccounts/cashFlows/"+id.String(),
+		input,
+		nil,
+		nuuid.From(id),
+	)
+
+	t.handler.HandleUpdateBankAccountCashFlow(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *bankAccountHandlerTestSuite) TestDeleteCashFlow_Normal() {
+	id, _ := uuid.NewV7()
+	rr, req := t.getNewRequestWithContext(
+		http.MethodDelete,
+		"/bankAccounts/cashFlows/"+id.String(),
+		nil,
+		nil,
+		nuuid.From(id),
+	)
+
+	cashFlow := model.NewBankAccountCashFlowFromInput(t.getNewBankAccountCashFlowInput(nuuid.From(id)), t.testBankAccountID, t.testUserID)
+	cashFlow.Delete(t.testUserID)
+
+	t.mockSvc.EXPECT().DeleteCashFlow(id, t.testUserID).Return(&cashFlow, nil)
+
+	t.handler.HandleDeleteBankAccountCashFlow(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+}
+
+func (t *bankAccountHandlerTestSuite) TestGetReturns_Normal() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodGet,
+		"/bankAccounts/"+t.testBankAccountID.String()+"/returns",
+		nil,
+		&map[string]string{"from": "2023-01-01", "to": "2023-12-31"},
+		nuuid.From(t.testBankAccountID),
+	)
+
+	from := time.Date(2023, 1, 1, 23, 59, 59, 999999999, time.Local)
+	to := time.Date(2023, 12, 31, 23, 59, 59, 999999999, time.Local)
+	returns := model.NewBankAccountReturns(t.testBankAccountID, from, to, []model.BankAccountBalance{}, []model.BankAccountCashFlow{})
+
+	t.mockSvc.EXPECT().GetReturns(t.testBankAccountID, from, to).Return(&returns, nil)
+
+	t.handler.HandleGetBankAccountReturns(rr, req)
+
+	var body struct {
+		Data model.BankAccountReturnsOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), t.testBankAccountID, body.Data.BankAccountID)
+	assert.False(t.T(), body.Data.TimeWeightedReturn.Valid)
+}
+
+func (t *bankAccountHandlerTestSuite) TestGetReturns_MissingFromDate() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodGet,
+		"/bankAccounts/"+t.testBankAccountID.String()+"/returns",
+		nil,
+		nil,
+		nuuid.From(t.testBankAccountID),
+	)
+
+	t.handler.HandleGetBankAccountReturns(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
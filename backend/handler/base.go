@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/util/failure"
 )
 
@@ -19,3 +20,22 @@ func getIDFromRequest(w http.ResponseWriter, r *http.Request) (id uuid.UUID, err
 
 	return
 }
+
+// getEntityTypeFromRequest gets the type of entity a request refers to, which is checked by the service
+func getEntityTypeFromRequest(r *http.Request) model.EntityType {
+	return model.EntityType(mux.Vars(r)["entityType"])
+}
+
+// getImportStatus determines the status of a response to an import: created when its rows were imported,
+// unprocessable when any of its rows is invalid and OK for a valid dry run
+func getImportStatus(result *model.ImportResult) int {
+	if !result.IsValid() {
+		return http.StatusUnprocessableEntity
+	}
+
+	if result.DryRun {
+		return http.StatusOK
+	}
+
+	return http.StatusCreated
+}
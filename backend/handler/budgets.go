@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Budget is the handler interface for Budgets
+type Budget interface {
+	Startup()
+	Shutdown()
+	HandleCreateBudget(w http.ResponseWriter, r *http.Request)
+	HandleGetBudgetByID(w http.ResponseWriter, r *http.Request)
+	HandleGetBudgetByFilter(w http.ResponseWriter, r *http.Request)
+	HandleUpdateBudget(w http.ResponseWriter, r *http.Request)
+	HandleDeleteBudget(w http.ResponseWriter, r *http.Request)
+	HandleGetBudgetReport(w http.ResponseWriter, r *http.Request)
+}
+
+// BudgetImpl is the handler implementation for Budgets
+type BudgetImpl struct {
+	Service service.Budget `inject:"budgetService"`
+}
+
+// Startup performs startup functions
+func (h *BudgetImpl) Startup() {
+	logger.Trace("Budget Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *BudgetImpl) Shutdown() {
+	logger.Trace("Budget Handler shutting down...")
+}
+
+// HandleCreateBudget handles the request
+func (h *BudgetImpl) HandleCreateBudget(w http.ResponseWriter, r *http.Request) {
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	budget, err := h.Service.Create(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, budget.ToOutput())
+}
+
+// HandleGetBudgetByID handles the request
+func (h *BudgetImpl) HandleGetBudgetByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	budget, err := h.Service.GetByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, budget.ToOutput())
+}
+
+// HandleGetBudgetByFilter handles the request
+func (h *BudgetImpl) HandleGetBudgetByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.BudgetFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	budgets, pageInfo, err := h.Service.GetByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.BudgetOutput, 0)
+	for _, budget := range budgets {
+		output := budget.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleUpdateBudget handles the request
+func (h *BudgetImpl) HandleUpdateBudget(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	if input.ID.String() != id.String() {
+		response.RespondWithError(w, failure.BadRequestFromString("id mismatch"))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	budget, err := h.Service.Update(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, budget.ToOutput())
+}
+
+// HandleDeleteBudget handles the request
+func (h *BudgetImpl) HandleDeleteBudget(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	budget, err := h.Service.Delete(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, budget.ToOutput())
+}
+
+// HandleGetBudgetReport handles the request. The month is given as YYYY-MM and defaults to the current one.
+func (h *BudgetImpl) HandleGetBudgetReport(w http.ResponseWriter, r *http.Request) {
+	month := time.Now().Format(model.BudgetMonthFormat)
+	if param := r.URL.Query().Get("month"); param != "" {
+		month = param
+	}
+
+	report, err := h.Service.GetReport(month)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, report.ToOutput())
+}
+
+func (h *BudgetImpl) getInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.BudgetInput, err error) {
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+	}
+
+	return
+}
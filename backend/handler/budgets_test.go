@@ -0,0 +1,206 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type budgetHandlerTestSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	handler        handler.Budget
+	mockSvc        *mock_service.MockBudget
+	testUserID     uuid.UUID
+	testCategoryID uuid.UUID
+}
+
+func TestBudgetHandler(t *testing.T) {
+	suite.Run(t, new(budgetHandlerTestSuite))
+}
+
+func (t *budgetHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockBudget(t.ctrl)
+	t.handler = &handler.BudgetImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testCategoryID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *budgetHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *budgetHandlerTestSuite) getNewRequestWithContext(method, path string, input any, routeVarId nuuid.NUUID) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	var req *http.Request
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		jsonBody, err := json.Marshal(input)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		req = httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	// set ID route var
+	if routeVarId.Valid {
+		req = mux.SetURLVars(req, map[string]string{
+			"id": routeVarId.UUID.String(),
+		})
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *budgetHandlerTestSuite) getNewBudgetInput() model.BudgetInput {
+	return model.BudgetInput{
+		CategoryID: t.testCategoryID,
+		Month:      "2024-03",
+		Amount:     float64(400),
+	}
+}
+
+func (t *budgetHandlerTestSuite) getNewBudget() model.Budget {
+	return model.NewBudgetFromInput(t.getNewBudgetInput(), t.testUserID)
+}
+
+func (t *budgetHandlerTestSuite) TestCreate_Normal() {
+	input := t.getNewBudgetInput()
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/budgets", input, nuuid.NUUID{Valid: false})
+
+	expectedResult := t.getNewBudget()
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateBudget(rr, req)
+
+	var body struct {
+		Data model.BudgetOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.Equal(t.T(), "2024-03", body.Data.Month)
+}
+
+func (t *budgetHandlerTestSuite) TestCreate_AlreadyBudgeted() {
+	input := t.getNewBudgetInput()
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/budgets", input, nuuid.NUUID{Valid: false})
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).
+		Return(nil, failure.OperationNotPermitted("create", "Budget", "the Category already has a budget for the month"))
+
+	t.handler.HandleCreateBudget(rr, req)
+
+	assert.Equal(t.T(), http.StatusConflict, rr.Result().StatusCode)
+}
+
+func (t *budgetHandlerTestSuite) TestGetByFilter_Normal() {
+	input := model.BudgetFilterInput{CategoryIDs: &[]uuid.UUID{t.testCategoryID}}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/budgets/search", input, nuuid.NUUID{Valid: false})
+
+	t.mockSvc.EXPECT().GetByFilter(gomock.Any()).
+		Return([]model.Budget{t.getNewBudget()}, model.PageInfoOutput{Page: 1, PageSize: 10, TotalCount: 1, PageCount: 1}, nil)
+
+	t.handler.HandleGetBudgetByFilter(rr, req)
+
+	var body struct {
+		Data struct {
+			Items    []model.BudgetOutput `json:"items"`
+			PageInfo model.PageInfoOutput `json:"pageInfo"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data.Items, 1)
+	assert.Equal(t.T(), 1, body.Data.PageInfo.TotalCount)
+}
+
+func (t *budgetHandlerTestSuite) TestDelete_Normal() {
+	budget := t.getNewBudget()
+	budget.Delete(t.testUserID)
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/budgets/"+budget.ID.String(), nil, nuuid.From(budget.ID))
+
+	t.mockSvc.EXPECT().Delete(budget.ID, t.testUserID).Return(&budget, nil)
+
+	t.handler.HandleDeleteBudget(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+}
+
+func (t *budgetHandlerTestSuite) TestGetReport_Normal() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/reports/budgets?month=2024-03", nil, nuuid.NUUID{Valid: false})
+
+	budget := t.getNewBudget()
+	report := model.BudgetReport{
+		Month: budget.Month,
+		Items: []model.BudgetReportItem{{
+			Budget:       budget,
+			CategoryName: "Groceries",
+			Actual:       float64(150),
+			Transactions: 3,
+		}},
+		Unbudgeted: float64(20),
+	}
+
+	t.mockSvc.EXPECT().GetReport("2024-03").Return(&report, nil)
+
+	t.handler.HandleGetBudgetReport(rr, req)
+
+	var body struct {
+		Data model.BudgetReportOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), "2024-03", body.Data.Month)
+	assert.Len(t.T(), body.Data.Items, 1)
+	assert.Equal(t.T(), float64(250), body.Data.Items[0].Remaining)
+	assert.Equal(t.T(), float64(400), body.Data.TotalPlanned)
+	assert.Equal(t.T(), float64(150), body.Data.TotalActual)
+}
+
+func (t *budgetHandlerTestSuite) TestGetReport_InvalidMonth() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/reports/budgets?month=March", nil, nuuid.NUUID{Valid: false})
+
+	t.mockSvc.EXPECT().GetReport("March").Return(nil, failure.BadRequestFromString("invalid month: March"))
+
+	t.handler.HandleGetBudgetReport(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
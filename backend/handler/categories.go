@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Category is the handler interface for Categories
+type Category interface {
+	Startup()
+	Shutdown()
+	HandleCreateCategory(w http.ResponseWriter, r *http.Request)
+	HandleGetCategoryByID(w http.ResponseWriter, r *http.Request)
+	HandleGetCategoryByFilter(w http.ResponseWriter, r *http.Request)
+	HandleGetCategoryTree(w http.ResponseWriter, r *http.Request)
+	HandleUpdateCategory(w http.ResponseWriter, r *http.Request)
+	HandleDeleteCategory(w http.ResponseWriter, r *http.Request)
+	HandleCreateCategoryRule(w http.ResponseWriter, r *http.Request)
+	HandleGetCategoryRuleByID(w http.ResponseWriter, r *http.Request)
+	HandleGetCategoryRuleByFilter(w http.ResponseWriter, r *http.Request)
+	HandleUpdateCategoryRule(w http.ResponseWriter, r *http.Request)
+	HandleDeleteCategoryRule(w http.ResponseWriter, r *http.Request)
+	HandleApplyCategoryRules(w http.ResponseWriter, r *http.Request)
+}
+
+// CategoryImpl is the handler implementation for Categories
+type CategoryImpl struct {
+	Service service.Category `inject:"categoryService"`
+}
+
+// Startup performs startup functions
+func (h *CategoryImpl) Startup() {
+	logger.Trace("Category Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *CategoryImpl) Shutdown() {
+	logger.Trace("Category Handler shutting down...")
+}
+
+// HandleCreateCategory handles the request
+func (h *CategoryImpl) HandleCreateCategory(w http.ResponseWriter, r *http.Request) {
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	category, err := h.Service.Create(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, category.ToOutput())
+}
+
+// HandleGetCategoryByID handles the request
+func (h *CategoryImpl) HandleGetCategoryByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	category, err := h.Service.GetByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, category.ToOutput())
+}
+
+// HandleGetCategoryByFilter handles the request
+func (h *CategoryImpl) HandleGetCategoryByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.CategoryFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	categories, pageInfo, err := h.Service.GetByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.CategoryOutput, 0)
+	for _, category := range categories {
+		output := category.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleGetCategoryTree handles the request
+func (h *CategoryImpl) HandleGetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	tree, err := h.Service.GetTree()
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, tree)
+}
+
+// HandleUpdateCategory handles the request
+func (h *CategoryImpl) HandleUpdateCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	if input.ID.String() != id.String() {
+		response.RespondWithError(w, failure.BadRequestFromString("id mismatch"))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	category, err := h.Service.Update(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, category.ToOutput())
+}
+
+// HandleDeleteCategory handles the request
+func (h *CategoryImpl) HandleDeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	category, err := h.Service.Delete(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, category.ToOutput())
+}
+
+// HandleCreateCategoryRule handles the request
+func (h *CategoryImpl) HandleCreateCategoryRule(w http.ResponseWriter, r *http.Request) {
+	input, err := h.getRuleInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	categoryRule, err := h.Service.CreateRule(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, categoryRule.ToOutput())
+}
+
+// HandleGetCategoryRuleByID handles the request
+func (h *CategoryImpl) HandleGetCategoryRuleByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	categoryRule, err := h.Service.GetRuleByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, categoryRule.ToOutput())
+}
+
+// HandleGetCategoryRuleByFilter handles the request
+func (h *CategoryImpl) HandleGetCategoryRuleByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.CategoryRuleFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	categoryRules, pageInfo, err := h.Service.GetRulesByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.CategoryRuleOutput, 0)
+	for _, categoryRule := range categoryRules {
+		output := categoryRule.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleUpdateCategoryRule handles the request
+func (h *CategoryImpl) HandleUpdateCategoryRule(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	input, err := h.getRuleInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	if input.ID.String() != id.String() {
+		response.RespondWithError(w, failure.BadRequestFromString("id mismatch"))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	categoryRule, err := h.Service.UpdateRule(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, categoryRule.ToOutput())
+}
+
+// HandleDeleteCategoryRule handles the request
+func (h *CategoryImpl) HandleDeleteCategoryRule(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	categoryRule, err := h.Service.DeleteRule(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, categoryRule.ToOutput())
+}
+
+// HandleApplyCategoryRules handles the request
+func (h *CategoryImpl) HandleApplyCategoryRules(w http.ResponseWriter, r *http.Request) {
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	transactions, err := h.Service.ApplyRules(*userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	output := model.CategorizationOutput{
+		Categorized:  len(transactions),
+		Transactions: make([]model.TransactionOutput, 0),
+	}
+	for _, transaction := range transactions {
+		output.Transactions = append(output.Transactions, transaction.ToOutput())
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, output)
+}
+
+func (h *CategoryImpl) getInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.CategoryInput, err error) {
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+	}
+
+	return
+}
+
+func (h *CategoryImpl) getRuleInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.CategoryRuleInput, err error) {
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+	}
+
+	return
+}
@@ -0,0 +1,259 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type categoryHandlerTestSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	handler        handler.Category
+	mockSvc        *mock_service.MockCategory
+	testUserID     uuid.UUID
+	testCategoryID uuid.UUID
+}
+
+func TestCategoryHandler(t *testing.T) {
+	suite.Run(t, new(categoryHandlerTestSuite))
+}
+
+func (t *categoryHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockCategory(t.ctrl)
+	t.handler = &handler.CategoryImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testCategoryID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *categoryHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *categoryHandlerTestSuite) getNewRequestWithContext(method, path string, input any, routeVarId nuuid.NUUID) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	var req *http.Request
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		jsonBody, err := json.Marshal(input)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		req = httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	// set ID route var
+	if routeVarId.Valid {
+		req = mux.SetURLVars(req, map[string]string{
+			"id": routeVarId.UUID.String(),
+		})
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *categoryHandlerTestSuite) getNewCategory() model.Category {
+	return model.NewCategoryFromInput(model.CategoryInput{Name: "Groceries"}, t.testUserID)
+}
+
+func (t *categoryHandlerTestSuite) getNewCategoryRule() model.CategoryRule {
+	return model.NewCategoryRuleFromInput(model.CategoryRuleInput{
+		CategoryID: t.testCategoryID,
+		Pattern:    "*grocer*",
+	}, t.testUserID)
+}
+
+func (t *categoryHandlerTestSuite) TestCreate_Normal() {
+	input := model.CategoryInput{Name: "Groceries"}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/categories", input, nuuid.NUUID{Valid: false})
+
+	expectedResult := t.getNewCategory()
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateCategory(rr, req)
+
+	var body struct {
+		Data model.CategoryOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.Equal(t.T(), "Groceries", body.Data.Name)
+}
+
+func (t *categoryHandlerTestSuite) TestCreate_DuplicateName() {
+	input := model.CategoryInput{Name: "Groceries"}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/categories", input, nuuid.NUUID{Valid: false})
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).
+		Return(nil, failure.OperationNotPermitted("create", "Category", "a category with the same name already exists"))
+
+	t.handler.HandleCreateCategory(rr, req)
+
+	assert.Equal(t.T(), http.StatusConflict, rr.Result().StatusCode)
+}
+
+func (t *categoryHandlerTestSuite) TestGetTree_Normal() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/categories", nil, nuuid.NUUID{Valid: false})
+
+	parent := t.getNewCategory()
+	parent.Name = "Household"
+	child := t.getNewCategory()
+	child.ParentID = nuuid.From(parent.ID)
+
+	t.mockSvc.EXPECT().GetTree().Return(model.NewCategoryTree([]model.Category{parent, child}), nil)
+
+	t.handler.HandleGetCategoryTree(rr, req)
+
+	var body struct {
+		Data []model.CategoryTreeOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data, 1)
+	assert.Len(t.T(), body.Data[0].Children, 1)
+	assert.Equal(t.T(), "Groceries", body.Data[0].Children[0].Name)
+}
+
+func (t *categoryHandlerTestSuite) TestUpdate_IDMismatch() {
+	id, _ := uuid.NewV7()
+	input := model.CategoryInput{ID: t.testCategoryID, Name: "Groceries"}
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, "/categories/"+id.String(), input, nuuid.From(id))
+
+	t.handler.HandleUpdateCategory(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *categoryHandlerTestSuite) TestDelete_HasChildren() {
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/categories/"+t.testCategoryID.String(), nil, nuuid.From(t.testCategoryID))
+
+	t.mockSvc.EXPECT().Delete(t.testCategoryID, t.testUserID).
+		Return(nil, failure.OperationNotPermitted("delete", "Category", "the Category still has categories below it"))
+
+	t.handler.HandleDeleteCategory(rr, req)
+
+	assert.Equal(t.T(), http.StatusConflict, rr.Result().StatusCode)
+}
+
+func (t *categoryHandlerTestSuite) TestCreateRule_Normal() {
+	input := model.CategoryRuleInput{CategoryID: t.testCategoryID, Pattern: "*grocer*"}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/categories/rules", input, nuuid.NUUID{Valid: false})
+
+	expectedResult := t.getNewCategoryRule()
+
+	t.mockSvc.EXPECT().CreateRule(gomock.Any(), t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateCategoryRule(rr, req)
+
+	var body struct {
+		Data model.CategoryRuleOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.Equal(t.T(), t.testCategoryID, body.Data.CategoryID)
+}
+
+func (t *categoryHandlerTestSuite) TestGetRuleByFilter_Normal() {
+	input := model.CategoryRuleFilterInput{CategoryIDs: &[]uuid.UUID{t.testCategoryID}}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/categories/rules/search", input, nuuid.NUUID{Valid: false})
+
+	t.mockSvc.EXPECT().GetRulesByFilter(gomock.Any()).
+		Return([]model.CategoryRule{t.getNewCategoryRule()}, model.PageInfoOutput{Page: 1, PageSize: 10, TotalCount: 1, PageCount: 1}, nil)
+
+	t.handler.HandleGetCategoryRuleByFilter(rr, req)
+
+	var body struct {
+		Data struct {
+			Items    []model.CategoryRuleOutput `json:"items"`
+			PageInfo model.PageInfoOutput       `json:"pageInfo"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data.Items, 1)
+	assert.Equal(t.T(), 1, body.Data.PageInfo.TotalCount)
+}
+
+func (t *categoryHandlerTestSuite) TestDeleteRule_NotFound() {
+	id, _ := uuid.NewV7()
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/categories/rules/"+id.String(), nil, nuuid.From(id))
+
+	t.mockSvc.EXPECT().DeleteRule(id, t.testUserID).Return(nil, failure.EntityNotFound("delete rule", "Category Rule"))
+
+	t.handler.HandleDeleteCategoryRule(rr, req)
+
+	assert.Equal(t.T(), http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func (t *categoryHandlerTestSuite) TestApplyRules_Normal() {
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/categories/rules/apply", nil, nuuid.NUUID{Valid: false})
+
+	transactionID, _ := uuid.NewV7()
+	transactions := []model.Transaction{{
+		ID:        transactionID,
+		Date:      time.Now(),
+		Direction: model.TransactionDirectionDebit,
+		Amount:    float64(50),
+		Payee:     "Corner Grocery Store",
+		Category:  "Groceries",
+		Created:   time.Now(),
+		CreatedBy: t.testUserID,
+	}}
+
+	t.mockSvc.EXPECT().ApplyRules(t.testUserID).Return(transactions, nil)
+
+	t.handler.HandleApplyCategoryRules(rr, req)
+
+	var body struct {
+		Data model.CategorizationOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), 1, body.Data.Categorized)
+	assert.Len(t.T(), body.Data.Transactions, 1)
+	assert.Equal(t.T(), "Groceries", body.Data.Transactions[0].Category)
+}
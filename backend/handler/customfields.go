@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// CustomField is the handler interface for Custom Fields
+type CustomField interface {
+	Startup()
+	Shutdown()
+	HandleCreateCustomField(w http.ResponseWriter, r *http.Request)
+	HandleGetCustomFieldByID(w http.ResponseWriter, r *http.Request)
+	HandleGetCustomFieldByFilter(w http.ResponseWriter, r *http.Request)
+	HandleUpdateCustomField(w http.ResponseWriter, r *http.Request)
+	HandleDeleteCustomField(w http.ResponseWriter, r *http.Request)
+	HandleGetCustomFieldValues(w http.ResponseWriter, r *http.Request)
+	HandleSetCustomFieldValues(w http.ResponseWriter, r *http.Request)
+}
+
+// CustomFieldImpl is the handler implementation for Custom Fields
+type CustomFieldImpl struct {
+	Service service.CustomField `inject:"customFieldService"`
+}
+
+// Startup performs startup functions
+func (h *CustomFieldImpl) Startup() {
+	logger.Trace("Custom Field Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *CustomFieldImpl) Shutdown() {
+	logger.Trace("Custom Field Handler shutting down...")
+}
+
+// HandleCreateCustomField handles the request
+func (h *CustomFieldImpl) HandleCreateCustomField(w http.ResponseWriter, r *http.Request) {
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	customField, err := h.Service.Create(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, customField.ToOutput())
+}
+
+// HandleGetCustomFieldByID handles the request
+func (h *CustomFieldImpl) HandleGetCustomFieldByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	customField, err := h.Service.GetByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, customField.ToOutput())
+}
+
+// HandleGetCustomFieldByFilter handles the request
+func (h *CustomFieldImpl) HandleGetCustomFieldByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.CustomFieldFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	customFields, pageInfo, err := h.Service.GetByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.CustomFieldOutput, 0)
+	for _, customField := range customFields {
+		output := customField.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleUpdateCustomField handles the request
+func (h *CustomFieldImpl) HandleUpdateCustomField(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	if input.ID.String() != id.String() {
+		response.RespondWithError(w, failure.BadRequestFromString("id mismatch"))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	customField, err := h.Service.Update(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, customField.ToOutput())
+}
+
+// HandleDeleteCustomField handles the request
+func (h *CustomFieldImpl) HandleDeleteCustomField(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	customField, err := h.Service.Delete(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, customField.ToOutput())
+}
+
+// HandleGetCustomFieldValues handles the request
+func (h *CustomFieldImpl) HandleGetCustomFieldValues(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	values, err := h.Service.GetValues(getEntityTypeFromRequest(r), id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, values.ToOutput())
+}
+
+// HandleSetCustomFieldValues handles the request
+func (h *CustomFieldImpl) HandleSetCustomFieldValues(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	var input model.CustomFieldValuesInput
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	values, err := h.Service.SetValues(getEntityTypeFromRequest(r), id, input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, values.ToOutput())
+}
+
+func (h *CustomFieldImpl) getInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.CustomFieldInput, err error) {
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+	}
+
+	return
+}
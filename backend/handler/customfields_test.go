@@ -0,0 +1,174 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type customFieldHandlerTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	handler       handler.CustomField
+	mockSvc       *mock_service.MockCustomField
+	testUserID    uuid.UUID
+	testVehicleID uuid.UUID
+}
+
+func TestCustomFieldHandler(t *testing.T) {
+	suite.Run(t, new(customFieldHandlerTestSuite))
+}
+
+func (t *customFieldHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockCustomField(t.ctrl)
+	t.handler = &handler.CustomFieldImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testVehicleID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *customFieldHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *customFieldHandlerTestSuite) getNewRequestWithContext(method, path string, input any, routeVars map[string]string) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	var req *http.Request
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		jsonBody, err := json.Marshal(input)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		req = httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	// set route vars
+	if routeVars != nil {
+		req = mux.SetURLVars(req, routeVars)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *customFieldHandlerTestSuite) getNewCustomFieldInput() model.CustomFieldInput {
+	return model.CustomFieldInput{
+		EntityType: model.EntityTypeVehicle,
+		Name:       "Insurance Expiry",
+		Type:       model.CustomFieldTypeDate,
+	}
+}
+
+func (t *customFieldHandlerTestSuite) getNewCustomField() model.CustomField {
+	return model.NewCustomFieldFromInput(t.getNewCustomFieldInput(), t.testUserID)
+}
+
+func (t *customFieldHandlerTestSuite) TestCreate_Normal() {
+	input := t.getNewCustomFieldInput()
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/customFields", input, nil)
+
+	expectedResult := t.getNewCustomField()
+
+	t.mockSvc.EXPECT().Create(input, t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateCustomField(rr, req)
+
+	var body struct {
+		Data model.CustomFieldOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.Equal(t.T(), model.CustomFieldTypeDate, body.Data.Type)
+}
+
+func (t *customFieldHandlerTestSuite) TestUpdate_IDMismatch() {
+	customField := t.getNewCustomField()
+	input := t.getNewCustomFieldInput()
+	input.ID, _ = uuid.NewV7()
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, "/customFields/"+customField.ID.String(), input, map[string]string{"id": customField.ID.String()})
+
+	t.handler.HandleUpdateCustomField(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *customFieldHandlerTestSuite) TestGetValues_Normal() {
+	customField := t.getNewCustomField()
+	path := "/customFields/values/vehicle/" + t.testVehicleID.String()
+	rr, req := t.getNewRequestWithContext(http.MethodGet, path, nil, map[string]string{
+		"entityType": string(model.EntityTypeVehicle),
+		"id":         t.testVehicleID.String(),
+	})
+
+	values := model.CustomFieldValues{
+		EntityType:   model.EntityTypeVehicle,
+		SubjectID:    t.testVehicleID,
+		CustomFields: []model.CustomField{customField},
+		Values: []model.CustomFieldValue{
+			{CustomFieldID: customField.ID, SubjectID: t.testVehicleID, Value: "2026-12-31"},
+		},
+	}
+
+	t.mockSvc.EXPECT().GetValues(model.EntityTypeVehicle, t.testVehicleID).Return(&values, nil)
+
+	t.handler.HandleGetCustomFieldValues(rr, req)
+
+	var body struct {
+		Data model.CustomFieldValuesOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data.Values, 1)
+	assert.Equal(t.T(), "Insurance Expiry", body.Data.Values[0].Name)
+	assert.Equal(t.T(), "2026-12-31", body.Data.Values[0].Value)
+}
+
+func (t *customFieldHandlerTestSuite) TestSetValues_EntityNotFound() {
+	input := model.CustomFieldValuesInput{Values: []model.CustomFieldValueInput{}}
+	path := "/customFields/values/vehicle/" + t.testVehicleID.String()
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, path, input, map[string]string{
+		"entityType": string(model.EntityTypeVehicle),
+		"id":         t.testVehicleID.String(),
+	})
+
+	t.mockSvc.EXPECT().
+		SetValues(model.EntityTypeVehicle, t.testVehicleID, input).
+		Return(nil, failure.EntityNotFound("set custom field values", "Vehicle"))
+
+	t.handler.HandleSetCustomFieldValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusNotFound, rr.Result().StatusCode)
+}
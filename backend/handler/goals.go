@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Goal is the handler interface for Goals
+type Goal interface {
+	Startup()
+	Shutdown()
+	HandleCreateGoal(w http.ResponseWriter, r *http.Request)
+	HandleGetGoalByID(w http.ResponseWriter, r *http.Request)
+	HandleGetGoalByFilter(w http.ResponseWriter, r *http.Request)
+	HandleUpdateGoal(w http.ResponseWriter, r *http.Request)
+	HandleDeleteGoal(w http.ResponseWriter, r *http.Request)
+	HandleGetGoalProgress(w http.ResponseWriter, r *http.Request)
+	HandleGetBankAccountGoals(w http.ResponseWriter, r *http.Request)
+}
+
+// GoalImpl is the handler implementation for Goals
+type GoalImpl struct {
+	Service service.Goal `inject:"goalService"`
+}
+
+// Startup performs startup functions
+func (h *GoalImpl) Startup() {
+	logger.Trace("Goal Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *GoalImpl) Shutdown() {
+	logger.Trace("Goal Handler shutting down...")
+}
+
+// HandleCreateGoal handles the request
+func (h *GoalImpl) HandleCreateGoal(w http.ResponseWriter, r *http.Request) {
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	goal, err := h.Service.Create(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, goal.ToOutput())
+}
+
+// HandleGetGoalByID handles the request
+func (h *GoalImpl) HandleGetGoalByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	goal, err := h.Service.GetByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, goal.ToOutput())
+}
+
+// HandleGetGoalByFilter handles the request
+func (h *GoalImpl) HandleGetGoalByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.GoalFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	goals, pageInfo, err := h.Service.GetByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.GoalOutput, 0)
+	for _, goal := range goals {
+		output := goal.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleUpdateGoal handles the request
+func (h *GoalImpl) HandleUpdateGoal(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	if input.ID.String() != id.String() {
+		response.RespondWithError(w, failure.BadRequestFromString("id mismatch"))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	goal, err := h.Service.Update(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, goal.ToOutput())
+}
+
+// HandleDeleteGoal handles the request
+func (h *GoalImpl) HandleDeleteGoal(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	goal, err := h.Service.Delete(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, goal.ToOutput())
+}
+
+// HandleGetGoalProgress handles the request
+func (h *GoalImpl) HandleGetGoalProgress(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	progress, err := h.Service.GetProgress(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, progress.ToOutput())
+}
+
+// HandleGetBankAccountGoals handles the request
+func (h *GoalImpl) HandleGetBankAccountGoals(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	progresses, err := h.Service.GetProgressByBankAccountID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.GoalProgressOutput, 0)
+	for _, progress := range progresses {
+		outputs = append(outputs, progress.ToOutput())
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, outputs)
+}
+
+func (h *GoalImpl) getInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.GoalInput, err error) {
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+	}
+
+	return
+}
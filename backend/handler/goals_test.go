@@ -0,0 +1,199 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type goalHandlerTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	handler           handler.Goal
+	mockSvc           *mock_service.MockGoal
+	testUserID        uuid.UUID
+	testBankAccountID uuid.UUID
+}
+
+func TestGoalHandler(t *testing.T) {
+	suite.Run(t, new(goalHandlerTestSuite))
+}
+
+func (t *goalHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockGoal(t.ctrl)
+	t.handler = &handler.GoalImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *goalHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *goalHandlerTestSuite) getNewRequestWithContext(method, path string, input any, routeVarId nuuid.NUUID) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	var req *http.Request
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		jsonBody, err := json.Marshal(input)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		req = httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	// set ID route var
+	if routeVarId.Valid {
+		req = mux.SetURLVars(req, map[string]string{
+			"id": routeVarId.UUID.String(),
+		})
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *goalHandlerTestSuite) getNewGoalInput() model.GoalInput {
+	return model.GoalInput{
+		Name:           "Down Payment",
+		TargetAmount:   float64(50000),
+		TargetDate:     cachetime.CacheTime(time.Now().AddDate(2, 0, 0)),
+		BankAccountIDs: []uuid.UUID{t.testBankAccountID},
+	}
+}
+
+func (t *goalHandlerTestSuite) getNewGoal() model.Goal {
+	return model.NewGoalFromInput(t.getNewGoalInput(), t.testUserID)
+}
+
+func (t *goalHandlerTestSuite) TestCreate_Normal() {
+	input := t.getNewGoalInput()
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/goals", input, nuuid.NUUID{Valid: false})
+
+	expectedResult := t.getNewGoal()
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateGoal(rr, req)
+
+	var body struct {
+		Data model.GoalOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.Equal(t.T(), []uuid.UUID{t.testBankAccountID}, body.Data.BankAccountIDs)
+}
+
+func (t *goalHandlerTestSuite) TestCreate_BankAccountNotFound() {
+	input := t.getNewGoalInput()
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/goals", input, nuuid.NUUID{Valid: false})
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).Return(nil, failure.EntityNotFound("create", "Bank Account"))
+
+	t.handler.HandleCreateGoal(rr, req)
+
+	assert.Equal(t.T(), http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func (t *goalHandlerTestSuite) TestUpdate_IDMismatch() {
+	goal := t.getNewGoal()
+	input := t.getNewGoalInput()
+	input.ID, _ = uuid.NewV7()
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, "/goals/"+goal.ID.String(), input, nuuid.From(goal.ID))
+
+	t.handler.HandleUpdateGoal(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *goalHandlerTestSuite) TestGetProgress_Normal() {
+	goal := t.getNewGoal()
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/goals/"+goal.ID.String()+"/progress", nil, nuuid.From(goal.ID))
+
+	progress := model.GoalProgress{
+		Goal:        goal,
+		AsOf:        time.Now(),
+		Current:     float64(20000),
+		SavingsRate: float64(1500),
+	}
+
+	t.mockSvc.EXPECT().GetProgress(goal.ID).Return(&progress, nil)
+
+	t.handler.HandleGetGoalProgress(rr, req)
+
+	var body struct {
+		Data model.GoalProgressOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), goal.ID, body.Data.Goal.ID)
+	assert.Equal(t.T(), float64(30000), body.Data.Remaining)
+	assert.Equal(t.T(), float64(40), body.Data.Percent)
+	assert.False(t.T(), body.Data.OnTrack)
+}
+
+func (t *goalHandlerTestSuite) TestGetBankAccountGoals_Normal() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/bankAccounts/"+t.testBankAccountID.String()+"/goals", nil, nuuid.From(t.testBankAccountID))
+
+	progresses := []model.GoalProgress{{Goal: t.getNewGoal(), AsOf: time.Now(), Current: float64(50000)}}
+
+	t.mockSvc.EXPECT().GetProgressByBankAccountID(t.testBankAccountID).Return(progresses, nil)
+
+	t.handler.HandleGetBankAccountGoals(rr, req)
+
+	var body struct {
+		Data []model.GoalProgressOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data, 1)
+	assert.True(t.T(), body.Data[0].Achieved)
+}
+
+func (t *goalHandlerTestSuite) TestGetBankAccountGoals_BankAccountNotFound() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/bankAccounts/"+t.testBankAccountID.String()+"/goals", nil, nuuid.From(t.testBankAccountID))
+
+	t.mockSvc.EXPECT().GetProgressByBankAccountID(t.testBankAccountID).Return(nil, failure.EntityNotFound("get goals", "Bank Account"))
+
+	t.handler.HandleGetBankAccountGoals(rr, req)
+
+	assert.Equal(t.T(), http.StatusNotFound, rr.Result().StatusCode)
+}
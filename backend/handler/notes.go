@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Note is the handler interface for Notes
+type Note interface {
+	Startup()
+	Shutdown()
+	HandleCreateNote(w http.ResponseWriter, r *http.Request)
+	HandleGetNoteByID(w http.ResponseWriter, r *http.Request)
+	HandleGetNoteByFilter(w http.ResponseWriter, r *http.Request)
+	HandleUpdateNote(w http.ResponseWriter, r *http.Request)
+	HandleDeleteNote(w http.ResponseWriter, r *http.Request)
+	HandleGetEntityNotes(w http.ResponseWriter, r *http.Request)
+}
+
+// NoteImpl is the handler implementation for Notes
+type NoteImpl struct {
+	Service service.Note `inject:"noteService"`
+}
+
+// Startup performs startup functions
+func (h *NoteImpl) Startup() {
+	logger.Trace("Note Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *NoteImpl) Shutdown() {
+	logger.Trace("Note Handler shutting down...")
+}
+
+// HandleCreateNote handles the request
+func (h *NoteImpl) HandleCreateNote(w http.ResponseWriter, r *http.Request) {
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	note, err := h.Service.Create(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, note.ToOutput())
+}
+
+// HandleGetNoteByID handles the request
+func (h *NoteImpl) HandleGetNoteByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	note, err := h.Service.GetByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, note.ToOutput())
+}
+
+// HandleGetNoteByFilter handles the request
+func (h *NoteImpl) HandleGetNoteByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.NoteFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	notes, pageInfo, err := h.Service.GetByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.NoteOutput, 0)
+	for _, note := range notes {
+		output := note.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleUpdateNote handles the request
+func (h *NoteImpl) HandleUpdateNote(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	if input.ID.String() != id.String() {
+		response.RespondWithError(w, failure.BadRequestFromString("id mismatch"))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	note, err := h.Service.Update(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, note.ToOutput())
+}
+
+// HandleDeleteNote handles the request
+func (h *NoteImpl) HandleDeleteNote(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	note, err := h.Service.Delete(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, note.ToOutput())
+}
+
+// HandleGetEntityNotes handles the request
+func (h *NoteImpl) HandleGetEntityNotes(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	notes, err := h.Service.GetByEntity(getEntityTypeFromRequest(r), id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.NoteOutput, 0)
+	for _, note := range notes {
+		output := note.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, outputs)
+}
+
+func (h *NoteImpl) getInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.NoteInput, err error) {
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+	}
+
+	return
+}
@@ -0,0 +1,215 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type noteHandlerTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	handler           handler.Note
+	mockSvc           *mock_service.MockNote
+	testUserID        uuid.UUID
+	testBankAccountID uuid.UUID
+}
+
+func TestNoteHandler(t *testing.T) {
+	suite.Run(t, new(noteHandlerTestSuite))
+}
+
+func (t *noteHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockNote(t.ctrl)
+	t.handler = &handler.NoteImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *noteHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *noteHandlerTestSuite) getNewRequestWithContext(method, path string, input any, routeVars map[string]string) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	var req *http.Request
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		jsonBody, err := json.Marshal(input)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		req = httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	// set route vars
+	if routeVars != nil {
+		req = mux.SetURLVars(req, routeVars)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *noteHandlerTestSuite) getNewNoteInput() model.NoteInput {
+	return model.NoteInput{
+		EntityType: model.EntityTypeBankAccount,
+		SubjectID:  t.testBankAccountID,
+		Content:    "Rate raised to 4.5% after renewal",
+	}
+}
+
+func (t *noteHandlerTestSuite) getNewNote() model.Note {
+	return model.NewNoteFromInput(t.getNewNoteInput(), t.testUserID)
+}
+
+func (t *noteHandlerTestSuite) TestCreate_Normal() {
+	input := t.getNewNoteInput()
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/notes", input, nil)
+
+	expectedResult := t.getNewNote()
+
+	t.mockSvc.EXPECT().Create(input, t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateNote(rr, req)
+
+	var body struct {
+		Data model.NoteOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.Equal(t.T(), expectedResult.Content, body.Data.Content)
+}
+
+func (t *noteHandlerTestSuite) TestCreate_NotNotable() {
+	input := t.getNewNoteInput()
+	input.EntityType = model.EntityTypeGoal
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/notes", input, nil)
+
+	t.mockSvc.EXPECT().Create(input, t.testUserID).Return(nil, model.CheckNotableEntityType(model.EntityTypeGoal))
+
+	t.handler.HandleCreateNote(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *noteHandlerTestSuite) TestGetByFilter_Normal() {
+	keyword := "renewal"
+	input := model.NoteFilterInput{}
+	input.Keyword = &keyword
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/notes/search", input, nil)
+
+	t.mockSvc.EXPECT().GetByFilter(gomock.Any()).Return([]model.Note{t.getNewNote()}, model.PageInfoOutput{TotalCount: 1}, nil)
+
+	t.handler.HandleGetNoteByFilter(rr, req)
+
+	var body struct {
+		Data struct {
+			Items    []model.NoteOutput   `json:"items"`
+			PageInfo model.PageInfoOutput `json:"pageInfo"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data.Items, 1)
+	assert.Equal(t.T(), 1, body.Data.PageInfo.TotalCount)
+}
+
+func (t *noteHandlerTestSuite) TestUpdate_IDMismatch() {
+	note := t.getNewNote()
+	otherID, _ := uuid.NewV7()
+	input := model.NoteInput{ID: otherID, Content: "Rate raised"}
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, "/notes/"+note.ID.String(), input, map[string]string{"id": note.ID.String()})
+
+	t.handler.HandleUpdateNote(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *noteHandlerTestSuite) TestUpdate_NotAuthor() {
+	note := t.getNewNote()
+	input := model.NoteInput{ID: note.ID, Content: "Rate raised"}
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, "/notes/"+note.ID.String(), input, map[string]string{"id": note.ID.String()})
+
+	t.mockSvc.EXPECT().Update(input, t.testUserID).Return(nil, failure.Forbidden("update", "Note", "author only"))
+
+	t.handler.HandleUpdateNote(rr, req)
+
+	assert.Equal(t.T(), http.StatusForbidden, rr.Result().StatusCode)
+}
+
+func (t *noteHandlerTestSuite) TestDelete_NotFound() {
+	note := t.getNewNote()
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/notes/"+note.ID.String(), nil, map[string]string{"id": note.ID.String()})
+
+	t.mockSvc.EXPECT().Delete(note.ID, t.testUserID).Return(nil, failure.EntityNotFound("delete", "Note"))
+
+	t.handler.HandleDeleteNote(rr, req)
+
+	assert.Equal(t.T(), http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func (t *noteHandlerTestSuite) TestDelete_NotAuthor() {
+	note := t.getNewNote()
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/notes/"+note.ID.String(), nil, map[string]string{"id": note.ID.String()})
+
+	t.mockSvc.EXPECT().Delete(note.ID, t.testUserID).Return(nil, failure.Forbidden("delete", "Note", "author only"))
+
+	t.handler.HandleDeleteNote(rr, req)
+
+	assert.Equal(t.T(), http.StatusForbidden, rr.Result().StatusCode)
+}
+
+func (t *noteHandlerTestSuite) TestGetEntityNotes_Normal() {
+	path := "/notes/entities/bankAccount/" + t.testBankAccountID.String()
+	rr, req := t.getNewRequestWithContext(http.MethodGet, path, nil, map[string]string{
+		"entityType": string(model.EntityTypeBankAccount),
+		"id":         t.testBankAccountID.String(),
+	})
+
+	t.mockSvc.EXPECT().GetByEntity(model.EntityTypeBankAccount, t.testBankAccountID).Return([]model.Note{t.getNewNote()}, nil)
+
+	t.handler.HandleGetEntityNotes(rr, req)
+
+	var body struct {
+		Data []model.NoteOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data, 1)
+}
@@ -23,7 +23,9 @@ type Property interface {
 	HandleGetPropertyByFilter(w http.ResponseWriter, r *http.Request)
 	HandleUpdateProperty(w http.ResponseWriter, r *http.Request)
 	HandleDeleteProperty(w http.ResponseWriter, r *http.Request)
+	HandleRestoreProperty(w http.ResponseWriter, r *http.Request)
 	HandleCreatePropertyValue(w http.ResponseWriter, r *http.Request)
+	HandleImportPropertyValues(w http.ResponseWriter, r *http.Request)
 	HandleGetPropertyValueByID(w http.ResponseWriter, r *http.Request)
 	HandleGetPropertyValueByFilter(w http.ResponseWriter, r *http.Request)
 	HandleUpdatePropertyValue(w http.ResponseWriter, r *http.Request)
@@ -111,6 +113,17 @@ func (h *PropertyImpl) HandleGetPropertyByFilter(w http.ResponseWriter, r *http.
 		return
 	}
 
+	if input.Aggregation != nil {
+		aggregateRows, err := h.Service.AggregateByFilter(input)
+		if err != nil {
+			response.RespondWithError(w, err)
+			return
+		}
+
+		response.RespondWithJSON(w, http.StatusOK, model.AggregationOutput{Items: aggregateRows})
+		return
+	}
+
 	properties, pageInfo, err := h.Service.GetByFilter(input)
 	if err != nil {
 		response.RespondWithError(w, err)
@@ -175,6 +188,23 @@ func (h *PropertyImpl) HandleDeleteProperty(w http.ResponseWriter, r *http.Reque
 	response.RespondWithJSON(w, http.StatusOK, property.ToOutput())
 }
 
+// HandleRestoreProperty handles the request
+func (h *PropertyImpl) HandleRestoreProperty(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	property, err := h.Service.Restore(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, property.ToOutput())
+}
+
 // HandleCreatePropertyValue handles the request
 func (h *PropertyImpl) HandleCreatePropertyValue(w http.ResponseWriter, r *http.Request) {
 	input, err := h.getValueInputFromRequest(w, r)
@@ -192,6 +222,25 @@ func (h *PropertyImpl) HandleCreatePropertyValue(w http.ResponseWriter, r *http.
 	response.RespondWithJSON(w, http.StatusCreated, propertyValue.ToOutput())
 }
 
+// HandleImportPropertyValues handles the request
+func (h *PropertyImpl) HandleImportPropertyValues(w http.ResponseWriter, r *http.Request) {
+	var input model.PropertyValueImportInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	result, err := h.Service.ImportValues(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, getImportStatus(result), result.ToOutput())
+}
+
 // HandleGetPropertyValueByID handles the request
 func (h *PropertyImpl) HandleGetPropertyValueByID(w http.ResponseWriter, r *http.Request) {
 	id, err := getIDFromRequest(w, r)
@@ -903,6 +903,79 @@ func (t *propertyHandlerTestSuite) TestDelete_ServiceFailedDeleting() {
 	assert.Nil(t.T(), err.Operation)
 }
 
+func (t *propertyHandlerTestSuite) TestRestore_Normal() {
+	input := t.getNewPropertyInput(nuuid.From(t.testPropertyID))
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/properties/"+t.testPropertyID.String()+"/restore",
+		nil,
+		nil,
+		nuuid.From(t.testPropertyID),
+	)
+
+	restoredProperty := model.NewPropertyFromInput(input, t.testUserID)
+	restoredProperty.ID = t.testPropertyID
+
+	t.mockSvc.EXPECT().Restore(t.testPropertyID, t.testUserID).Return(&restoredProperty, nil)
+
+	t.handler.HandleRestoreProperty(rr, req)
+
+	actual, err := t.parseOutputToProperty(rr)
+
+	assert.NotNil(t.T(), actual)
+	assert.Equal(t.T(), t.testPropertyID, actual.ID)
+	assert.Nil(t.T(), err)
+}
+
+func (t *propertyHandlerTestSuite) TestRestore_FailedParsingID() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/properties/"+t.testPropertyID.String()+"/restore",
+		nil,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.handler.HandleRestoreProperty(rr, req)
+
+	actual, err := t.parseOutputToProperty(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, err.Code)
+	// TODO: specify this
+	assert.Nil(t.T(), err.Entity)
+	assert.Contains(t.T(), err.Message, "invalid UUID length")
+	// TODO: specify this
+	assert.Nil(t.T(), err.Operation)
+}
+
+func (t *propertyHandlerTestSuite) TestRestore_ServiceFailedRestoring() {
+	errMsg := "service failed restoring property"
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/properties/"+t.testPropertyID.String()+"/restore",
+		nil,
+		nil,
+		nuuid.From(t.testPropertyID),
+	)
+
+	t.mockSvc.EXPECT().Restore(t.testPropertyID, t.testUserID).Return(nil, errors.New(errMsg))
+
+	t.handler.HandleRestoreProperty(rr, req)
+
+	actual, err := t.parseOutputToProperty(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeInternalError, err.Code)
+	// TODO: specify this
+	assert.Nil(t.T(), err.Entity)
+	assert.Contains(t.T(), err.Message, errMsg)
+	// TODO: specify this
+	assert.Nil(t.T(), err.Operation)
+}
+
 func (t *propertyHandlerTestSuite) TestCreateValue_Normal() {
 	input := t.getNewPropertyValueInput(nuuid.NUUID{Valid: false}, nuuid.NUUID{Valid: false})
 	rr, req := t.getNewRequestWithContext(
@@ -988,6 +1061,100 @@ func (t *propertyHandlerTestSuite) TestCreateValue_ServiceFailedCreatingValue()
 	assert.Nil(t.T(), err.Operation)
 }
 
+func (t *propertyHandlerTestSuite) TestImportValues_Normal() {
+	input := model.PropertyValueImportInput{
+		ImportInput: model.ImportInput{
+			CSV:     "date,value\n2024-01-31,1000\n",
+			Mapping: model.ImportColumnMapping{Date: "date", Amount: "value"},
+		},
+		PropertyID: t.testPropertyID,
+	}
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/properties/values/import",
+		input,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportValues(input, t.testUserID).
+		Return(&model.ImportResult{Rows: 1, Imported: 1, Errors: []model.ImportRowError{}}, nil)
+
+	t.handler.HandleImportPropertyValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"imported":1`)
+	assert.Contains(t.T(), rr.Body.String(), `"valid":true`)
+}
+
+func (t *propertyHandlerTestSuite) TestImportValues_DryRun() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/properties/values/import",
+		model.PropertyValueImportInput{ImportInput: model.ImportInput{DryRun: true}},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportValues(gomock.Any(), t.testUserID).
+		Return(&model.ImportResult{DryRun: true, Rows: 2, Errors: []model.ImportRowError{}}, nil)
+
+	t.handler.HandleImportPropertyValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"dryRun":true`)
+}
+
+func (t *propertyHandlerTestSuite) TestImportValues_RowErrors() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/properties/values/import",
+		model.PropertyValueImportInput{},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportValues(gomock.Any(), t.testUserID).
+		Return(&model.ImportResult{Rows: 1, Errors: []model.ImportRowError{{Line: 2, Message: "invalid amount"}}}, nil)
+
+	t.handler.HandleImportPropertyValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusUnprocessableEntity, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"line":2`)
+	assert.Contains(t.T(), rr.Body.String(), `"valid":false`)
+}
+
+func (t *propertyHandlerTestSuite) TestImportValues_FailedParsingRequestPayload() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/properties/values/import",
+		"test",
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.handler.HandleImportPropertyValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *propertyHandlerTestSuite) TestImportValues_ServiceFailedImporting() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/properties/values/import",
+		model.PropertyValueImportInput{},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportValues(gomock.Any(), t.testUserID).
+		Return(nil, failure.OperationNotPermitted("import values", "Property", "the Property has been sold"))
+
+	t.handler.HandleImportPropertyValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusConflict, rr.Result().StatusCode)
+}
+
 func (t *propertyHandlerTestSuite) TestGetValueByID_Normal() {
 	rr, req := t.getNewRequestWithContext(
 		http.MethodGet,
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Purge is the handler interface for Purges
+type Purge interface {
+	Startup()
+	Shutdown()
+	HandlePurge(w http.ResponseWriter, r *http.Request)
+}
+
+// PurgeImpl is the handler implementation for Purges
+type PurgeImpl struct {
+	Service service.Purge `inject:"purgeService"`
+}
+
+// Startup performs startup functions
+func (h *PurgeImpl) Startup() {
+	logger.Trace("Purge Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *PurgeImpl) Shutdown() {
+	logger.Trace("Purge Handler shutting down...")
+}
+
+// HandlePurge handles the request
+func (h *PurgeImpl) HandlePurge(w http.ResponseWriter, r *http.Request) {
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	summary, err := h.Service.Purge(*userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, summary.ToOutput())
+}
@@ -0,0 +1,98 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler"
+	"github.com/kerti/balances/backend/handler/response"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type purgeHandlerTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	handler    handler.Purge
+	mockSvc    *mock_service.MockPurge
+	testUserID uuid.UUID
+}
+
+func TestPurgeHandler(t *testing.T) {
+	suite.Run(t, new(purgeHandlerTestSuite))
+}
+
+func (t *purgeHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockPurge(t.ctrl)
+	t.handler = &handler.PurgeImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *purgeHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *purgeHandlerTestSuite) getNewRequestWithContext() (recorder *httptest.ResponseRecorder, request *http.Request) {
+	req := httptest.NewRequest(http.MethodPost, "/admin/purge", nil)
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *purgeHandlerTestSuite) parseResponse(rr *httptest.ResponseRecorder) response.BaseResponse {
+	var response response.BaseResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	return response
+}
+
+func (t *purgeHandlerTestSuite) TestPurge_Normal() {
+	rr, req := t.getNewRequestWithContext()
+
+	summary := model.NewPurgeSummary(time.Now().AddDate(0, 0, -90), t.testUserID)
+	summary.VehicleValues = 3
+	t.mockSvc.EXPECT().Purge(t.testUserID).Return(&summary, nil)
+
+	t.handler.HandlePurge(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"vehicleValues":3`)
+	assert.Contains(t.T(), rr.Body.String(), `"total":3`)
+}
+
+func (t *purgeHandlerTestSuite) TestPurge_Forbidden() {
+	rr, req := t.getNewRequestWithContext()
+
+	t.mockSvc.EXPECT().Purge(t.testUserID).
+		Return(nil, failure.Forbidden("purge", "Deleted Records", "admin only"))
+
+	t.handler.HandlePurge(rr, req)
+
+	response := t.parseResponse(rr)
+
+	assert.Equal(t.T(), http.StatusForbidden, rr.Result().StatusCode)
+	assert.NotNil(t.T(), response.Error)
+	assert.Equal(t.T(), failure.CodeForbidden, response.Error.Code)
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+	"github.com/kerti/balances/backend/util/pdf"
+	"github.com/kerti/balances/backend/util/xlsx"
+)
+
+// Report is the handler interface for Reports
+type Report interface {
+	Startup()
+	Shutdown()
+	HandleGetNetWorthXLSX(w http.ResponseWriter, r *http.Request)
+	HandleGetNetWorthPDF(w http.ResponseWriter, r *http.Request)
+	HandleGetAssetChange(w http.ResponseWriter, r *http.Request)
+}
+
+// ReportImpl is the handler implementation for Reports
+type ReportImpl struct {
+	Service service.Report `inject:"reportService"`
+}
+
+// Startup performs startup functions
+func (h *ReportImpl) Startup() {
+	logger.Trace("Report Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *ReportImpl) Shutdown() {
+	logger.Trace("Report Handler shutting down...")
+}
+
+// HandleGetNetWorthXLSX handles the request
+func (h *ReportImpl) HandleGetNetWorthXLSX(w http.ResponseWriter, r *http.Request) {
+	report, err := h.Service.GetNetWorth()
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	workbook := report.ToWorkbook()
+	fileName := fmt.Sprintf("networth-%s.xlsx", report.Generated.Format("20060102-150405"))
+
+	w.Header().Set("Content-Type", xlsx.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.WriteHeader(http.StatusOK)
+
+	// the status is already sent, so a failure from here on can only be logged
+	err = workbook.Write(w)
+	if err != nil {
+		logger.ErrNoStack("Failed writing report: %v", err)
+	}
+}
+
+// HandleGetNetWorthPDF handles the request
+func (h *ReportImpl) HandleGetNetWorthPDF(w http.ResponseWriter, r *http.Request) {
+	asOf, err := model.ParseReportDate(time.Now().Format("2006-01-02"))
+	if param := r.URL.Query().Get("asOf"); param != "" {
+		asOf, err = model.ParseReportDate(param)
+	}
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	statement, err := h.Service.GetNetWorthStatement(asOf)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	document := statement.ToPDF()
+	fileName := fmt.Sprintf("networth-%s.pdf", statement.AsOf.Format("20060102"))
+
+	w.Header().Set("Content-Type", pdf.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	w.WriteHeader(http.StatusOK)
+
+	// the status is already sent, so a failure from here on can only be logged
+	err = document.Write(w)
+	if err != nil {
+		logger.ErrNoStack("Failed writing report: %v", err)
+	}
+}
+
+// HandleGetAssetChange handles the request
+func (h *ReportImpl) HandleGetAssetChange(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("from") == "" || query.Get("to") == "" {
+		response.RespondWithError(w, failure.BadRequestFromString("both from and to dates are required"))
+		return
+	}
+
+	from, err := model.ParseReportDate(query.Get("from"))
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	to, err := model.ParseReportDate(query.Get("to"))
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	report, err := h.Service.GetAssetChange(from, to)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, report.ToOutput())
+}
@@ -0,0 +1,179 @@
+package handler_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/pdf"
+	"github.com/kerti/balances/backend/util/xlsx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type reportHandlerTestSuite struct {
+	suite.Suite
+	ctrl    *gomock.Controller
+	handler handler.Report
+	mockSvc *mock_service.MockReport
+}
+
+func TestReportHandler(t *testing.T) {
+	suite.Run(t, new(reportHandlerTestSuite))
+}
+
+func (t *reportHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockReport(t.ctrl)
+	t.handler = &handler.ReportImpl{
+		Service: t.mockSvc,
+	}
+	t.handler.Startup()
+}
+
+func (t *reportHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *reportHandlerTestSuite) TestGetNetWorthXLSX_Normal() {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/reports/networth.xlsx", nil)
+
+	report := model.NewNetWorthReport(
+		[]model.BankAccount{{AccountName: "Savings", LastBalance: 1500}},
+		[]model.Vehicle{},
+		[]model.Property{})
+	t.mockSvc.EXPECT().GetNetWorth().Return(&report, nil)
+
+	t.handler.HandleGetNetWorthXLSX(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), xlsx.ContentType, rr.Header().Get("Content-Type"))
+	assert.Contains(t.T(), rr.Header().Get("Content-Disposition"), ".xlsx")
+
+	body := rr.Body.Bytes()
+	reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.Nil(t.T(), err)
+
+	names := make([]string, 0)
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	assert.Contains(t.T(), names, "xl/workbook.xml")
+	assert.Contains(t.T(), names, "xl/worksheets/sheet5.xml")
+}
+
+func (t *reportHandlerTestSuite) TestGetNetWorthXLSX_ServiceError() {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/reports/networth.xlsx", nil)
+
+	t.mockSvc.EXPECT().GetNetWorth().Return(nil, errors.New("service error"))
+
+	t.handler.HandleGetNetWorthXLSX(rr, req)
+
+	assert.Equal(t.T(), http.StatusInternalServerError, rr.Result().StatusCode)
+}
+
+func (t *reportHandlerTestSuite) TestGetNetWorthPDF_Normal() {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/reports/networth.pdf?asOf=2024-02-29", nil)
+
+	asOf := time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.Local)
+	statement := model.NetWorthStatement{
+		AsOf:      asOf,
+		Generated: time.Now(),
+		Assets: []model.NetWorthStatementAsset{
+			{AssetClass: model.EntityTypeBankAccount, Name: "Savings", Value: 1500, ValueDate: asOf, Counted: true},
+		},
+	}
+	t.mockSvc.EXPECT().GetNetWorthStatement(asOf).Return(&statement, nil)
+
+	t.handler.HandleGetNetWorthPDF(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), pdf.ContentType, rr.Header().Get("Content-Type"))
+	assert.Contains(t.T(), rr.Header().Get("Content-Disposition"), "networth-20240229.pdf")
+	assert.True(t.T(), bytes.HasPrefix(rr.Body.Bytes(), []byte("%PDF-")))
+	assert.Contains(t.T(), rr.Body.String(), "(1,500.00) Tj")
+}
+
+func (t *reportHandlerTestSuite) TestGetNetWorthPDF_InvalidDate() {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/reports/networth.pdf?asOf=yesterday", nil)
+
+	t.handler.HandleGetNetWorthPDF(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *reportHandlerTestSuite) TestGetNetWorthPDF_ServiceError() {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/reports/networth.pdf", nil)
+
+	t.mockSvc.EXPECT().GetNetWorthStatement(gomock.Any()).Return(nil, errors.New("service error"))
+
+	t.handler.HandleGetNetWorthPDF(rr, req)
+
+	assert.Equal(t.T(), http.StatusInternalServerError, rr.Result().StatusCode)
+}
+
+func (t *reportHandlerTestSuite) TestGetAssetChange_Normal() {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/reports/changes?from=2024-01-31&to=2024-02-29", nil)
+
+	from := time.Date(2024, 1, 31, 23, 59, 59, 999999999, time.Local)
+	to := time.Date(2024, 2, 29, 23, 59, 59, 999999999, time.Local)
+	report := model.AssetChangeReport{
+		From: from,
+		To:   to,
+		Assets: []model.AssetChange{
+			{AssetClass: model.EntityTypeBankAccount, Name: "Savings", ToValue: null.FloatFrom(1500), Change: 1500, Created: true},
+		},
+	}
+	t.mockSvc.EXPECT().GetAssetChange(from, to).Return(&report, nil)
+
+	t.handler.HandleGetAssetChange(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+
+	var body struct {
+		Data model.AssetChangeReportOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), body.Data.Assets, 1)
+	assert.False(t.T(), body.Data.Assets[0].FromValue.Valid)
+	assert.True(t.T(), body.Data.Assets[0].Created)
+}
+
+func (t *reportHandlerTestSuite) TestGetAssetChange_MissingDate() {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/reports/changes?from=2024-01-31", nil)
+
+	t.handler.HandleGetAssetChange(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *reportHandlerTestSuite) TestGetAssetChange_ServiceError() {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/reports/changes?from=1706659200000&to=1709164800000", nil)
+
+	t.mockSvc.EXPECT().GetAssetChange(gomock.Any(), gomock.Any()).Return(nil, failure.BadRequestFromString("invalid period"))
+
+	t.handler.HandleGetAssetChange(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
@@ -10,6 +10,7 @@ import (
 var failureStatusMap = map[failure.Code]int{
 	failure.CodeBadRequest:            http.StatusBadRequest,
 	failure.CodeUnauthorized:          http.StatusUnauthorized,
+	failure.CodeForbidden:             http.StatusForbidden,
 	failure.CodeInternalError:         http.StatusInternalServerError,
 	failure.CodeUnimplemented:         http.StatusNotImplemented,
 	failure.CodeEntityNotFound:        http.StatusNotFound,
@@ -38,6 +39,12 @@ func RespondWithJSON(w http.ResponseWriter, code int, jsonPayload interface{}) {
 	respond(w, code, BaseResponse{Data: &jsonPayload})
 }
 
+// RespondWithRawJSON sends a response containing a JSON object without wrapping it in a BaseResponse,
+// for payloads whose format is dictated by an external standard
+func RespondWithRawJSON(w http.ResponseWriter, code int, jsonPayload interface{}) {
+	respond(w, code, jsonPayload)
+}
+
 // RespondWithError sends a response with an error message
 func RespondWithError(w http.ResponseWriter, err error) {
 	errAsFailure, ok := err.(*failure.Failure)
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Search is the handler interface for full-text Searches
+type Search interface {
+	Startup()
+	Shutdown()
+	HandleSearch(w http.ResponseWriter, r *http.Request)
+}
+
+// SearchImpl is the handler implementation for full-text Searches
+type SearchImpl struct {
+	Service service.Search `inject:"searchService"`
+}
+
+// Startup performs startup functions
+func (h *SearchImpl) Startup() {
+	logger.Trace("Search Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *SearchImpl) Shutdown() {
+	logger.Trace("Search Handler shutting down...")
+}
+
+// HandleSearch handles the request
+func (h *SearchImpl) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	var input model.SearchInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	results, err := h.Service.Search(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.SearchResultOutput, 0)
+	for _, result := range results {
+		outputs = append(outputs, result.ToOutput())
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, outputs)
+}
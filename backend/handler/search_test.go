@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler"
+	"github.com/kerti/balances/backend/handler/response"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type searchHandlerTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	handler    handler.Search
+	mockSvc    *mock_service.MockSearch
+	testUserID uuid.UUID
+}
+
+func TestSearchHandler(t *testing.T) {
+	suite.Run(t, new(searchHandlerTestSuite))
+}
+
+func (t *searchHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockSearch(t.ctrl)
+	t.handler = &handler.SearchImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *searchHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *searchHandlerTestSuite) getNewRequestWithContext(body []byte) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	req := httptest.NewRequest(http.MethodPost, "/search", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *searchHandlerTestSuite) parseResponse(rr *httptest.ResponseRecorder) response.BaseResponse {
+	var response response.BaseResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	return response
+}
+
+func (t *searchHandlerTestSuite) TestSearch_Normal() {
+	input := model.SearchInput{Keyword: "acme"}
+	body, _ := json.Marshal(input)
+	rr, req := t.getNewRequestWithContext(body)
+
+	id, _ := uuid.NewV7()
+	t.mockSvc.EXPECT().Search(input).Return([]model.SearchResult{
+		{EntityType: model.EntityTypeVehicle, ID: id, Name: "ACME Van", Status: "in_use", Relevance: 1.5},
+	}, nil)
+
+	t.handler.HandleSearch(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), id.String())
+	assert.Contains(t.T(), rr.Body.String(), `"entityType":"vehicle"`)
+	assert.Contains(t.T(), rr.Body.String(), `"relevance":1.5`)
+}
+
+func (t *searchHandlerTestSuite) TestSearch_FailedParsingInput() {
+	rr, req := t.getNewRequestWithContext([]byte("{"))
+
+	t.handler.HandleSearch(rr, req)
+
+	response := t.parseResponse(rr)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+	assert.NotNil(t.T(), response.Error)
+}
+
+func (t *searchHandlerTestSuite) TestSearch_InvalidKeyword() {
+	body, _ := json.Marshal(model.SearchInput{})
+	rr, req := t.getNewRequestWithContext(body)
+
+	t.mockSvc.EXPECT().Search(gomock.Any()).
+		Return(nil, failure.BadRequestFromString("search keyword must contain at least one word"))
+
+	t.handler.HandleSearch(rr, req)
+
+	response := t.parseResponse(rr)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+	assert.NotNil(t.T(), response.Error)
+}
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Tag is the handler interface for Tags
+type Tag interface {
+	Startup()
+	Shutdown()
+	HandleCreateTag(w http.ResponseWriter, r *http.Request)
+	HandleGetTagByID(w http.ResponseWriter, r *http.Request)
+	HandleGetTagByFilter(w http.ResponseWriter, r *http.Request)
+	HandleUpdateTag(w http.ResponseWriter, r *http.Request)
+	HandleDeleteTag(w http.ResponseWriter, r *http.Request)
+	HandleGetEntityTags(w http.ResponseWriter, r *http.Request)
+	HandleSetEntityTags(w http.ResponseWriter, r *http.Request)
+}
+
+// TagImpl is the handler implementation for Tags
+type TagImpl struct {
+	Service service.Tag `inject:"tagService"`
+}
+
+// Startup performs startup functions
+func (h *TagImpl) Startup() {
+	logger.Trace("Tag Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *TagImpl) Shutdown() {
+	logger.Trace("Tag Handler shutting down...")
+}
+
+// HandleCreateTag handles the request
+func (h *TagImpl) HandleCreateTag(w http.ResponseWriter, r *http.Request) {
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	tag, err := h.Service.Create(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, tag.ToOutput())
+}
+
+// HandleGetTagByID handles the request
+func (h *TagImpl) HandleGetTagByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	tag, err := h.Service.GetByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, tag.ToOutput())
+}
+
+// HandleGetTagByFilter handles the request
+func (h *TagImpl) HandleGetTagByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.TagFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	tags, pageInfo, err := h.Service.GetByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.TagOutput, 0)
+	for _, tag := range tags {
+		output := tag.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleUpdateTag handles the request
+func (h *TagImpl) HandleUpdateTag(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	if input.ID.String() != id.String() {
+		response.RespondWithError(w, failure.BadRequestFromString("id mismatch"))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	tag, err := h.Service.Update(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, tag.ToOutput())
+}
+
+// HandleDeleteTag handles the request
+func (h *TagImpl) HandleDeleteTag(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	tag, err := h.Service.Delete(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, tag.ToOutput())
+}
+
+// HandleGetEntityTags handles the request
+func (h *TagImpl) HandleGetEntityTags(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	entityTags, err := h.Service.GetEntityTags(getEntityTypeFromRequest(r), id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, entityTags.ToOutput())
+}
+
+// HandleSetEntityTags handles the request
+func (h *TagImpl) HandleSetEntityTags(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	var input model.EntityTagsInput
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	entityTags, err := h.Service.SetEntityTags(getEntityTypeFromRequest(r), id, input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, entityTags.ToOutput())
+}
+
+func (h *TagImpl) getInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.TagInput, err error) {
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+	}
+
+	return
+}
@@ -0,0 +1,194 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type tagHandlerTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	handler           handler.Tag
+	mockSvc           *mock_service.MockTag
+	testUserID        uuid.UUID
+	testBankAccountID uuid.UUID
+}
+
+func TestTagHandler(t *testing.T) {
+	suite.Run(t, new(tagHandlerTestSuite))
+}
+
+func (t *tagHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockTag(t.ctrl)
+	t.handler = &handler.TagImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *tagHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *tagHandlerTestSuite) getNewRequestWithContext(method, path string, input any, routeVars map[string]string) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	var req *http.Request
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		jsonBody, err := json.Marshal(input)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		req = httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	// set route vars
+	if routeVars != nil {
+		req = mux.SetURLVars(req, routeVars)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *tagHandlerTestSuite) getNewTag() model.Tag {
+	return model.NewTagFromInput(model.TagInput{Name: "Joint"}, t.testUserID)
+}
+
+func (t *tagHandlerTestSuite) TestCreate_Normal() {
+	input := model.TagInput{Name: "Joint"}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/tags", input, nil)
+
+	expectedResult := t.getNewTag()
+
+	t.mockSvc.EXPECT().Create(input, t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateTag(rr, req)
+
+	var body struct {
+		Data model.TagOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.Equal(t.T(), "Joint", body.Data.Name)
+}
+
+func (t *tagHandlerTestSuite) TestCreate_NameTaken() {
+	input := model.TagInput{Name: "Joint"}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/tags", input, nil)
+
+	t.mockSvc.EXPECT().Create(input, t.testUserID).Return(nil, failure.OperationNotPermitted("create", "Tag", "a tag with the same name already exists"))
+
+	t.handler.HandleCreateTag(rr, req)
+
+	assert.Equal(t.T(), http.StatusConflict, rr.Result().StatusCode)
+}
+
+func (t *tagHandlerTestSuite) TestUpdate_IDMismatch() {
+	tag := t.getNewTag()
+	otherID, _ := uuid.NewV7()
+	input := model.TagInput{ID: otherID, Name: "Joint"}
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, "/tags/"+tag.ID.String(), input, map[string]string{"id": tag.ID.String()})
+
+	t.handler.HandleUpdateTag(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *tagHandlerTestSuite) TestGetEntityTags_Normal() {
+	path := "/tags/entities/bankAccount/" + t.testBankAccountID.String()
+	rr, req := t.getNewRequestWithContext(http.MethodGet, path, nil, map[string]string{
+		"entityType": string(model.EntityTypeBankAccount),
+		"id":         t.testBankAccountID.String(),
+	})
+
+	entityTags := model.EntityTags{
+		EntityType: model.EntityTypeBankAccount,
+		SubjectID:  t.testBankAccountID,
+		Tags:       []model.Tag{t.getNewTag()},
+	}
+
+	t.mockSvc.EXPECT().GetEntityTags(model.EntityTypeBankAccount, t.testBankAccountID).Return(&entityTags, nil)
+
+	t.handler.HandleGetEntityTags(rr, req)
+
+	var body struct {
+		Data model.EntityTagsOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), t.testBankAccountID, body.Data.SubjectID)
+	assert.Len(t.T(), body.Data.Tags, 1)
+}
+
+func (t *tagHandlerTestSuite) TestSetEntityTags_Normal() {
+	tag := t.getNewTag()
+	input := model.EntityTagsInput{TagIDs: []uuid.UUID{tag.ID}}
+	path := "/tags/entities/bankAccount/" + t.testBankAccountID.String()
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, path, input, map[string]string{
+		"entityType": string(model.EntityTypeBankAccount),
+		"id":         t.testBankAccountID.String(),
+	})
+
+	entityTags := model.EntityTags{
+		EntityType: model.EntityTypeBankAccount,
+		SubjectID:  t.testBankAccountID,
+		Tags:       []model.Tag{tag},
+	}
+
+	t.mockSvc.EXPECT().SetEntityTags(model.EntityTypeBankAccount, t.testBankAccountID, input).Return(&entityTags, nil)
+
+	t.handler.HandleSetEntityTags(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+}
+
+func (t *tagHandlerTestSuite) TestSetEntityTags_NotTaggable() {
+	input := model.EntityTagsInput{TagIDs: []uuid.UUID{}}
+	path := "/tags/entities/goal/" + t.testBankAccountID.String()
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, path, input, map[string]string{
+		"entityType": string(model.EntityTypeGoal),
+		"id":         t.testBankAccountID.String(),
+	})
+
+	t.mockSvc.EXPECT().
+		SetEntityTags(model.EntityTypeGoal, t.testBankAccountID, input).
+		Return(nil, model.CheckTaggableEntityType(model.EntityTypeGoal))
+
+	t.handler.HandleSetEntityTags(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Transaction is the handler interface for Transactions
+type Transaction interface {
+	Startup()
+	Shutdown()
+	HandleCreateTransaction(w http.ResponseWriter, r *http.Request)
+	HandleGetTransactionByID(w http.ResponseWriter, r *http.Request)
+	HandleGetTransactionByFilter(w http.ResponseWriter, r *http.Request)
+	HandleUpdateTransaction(w http.ResponseWriter, r *http.Request)
+	HandleDeleteTransaction(w http.ResponseWriter, r *http.Request)
+	HandleGetBankAccountReconciliation(w http.ResponseWriter, r *http.Request)
+}
+
+// TransactionImpl is the handler implementation for Transactions
+type TransactionImpl struct {
+	Service service.Transaction `inject:"transactionService"`
+}
+
+// Startup performs startup functions
+func (h *TransactionImpl) Startup() {
+	logger.Trace("Transaction Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *TransactionImpl) Shutdown() {
+	logger.Trace("Transaction Handler shutting down...")
+}
+
+// HandleCreateTransaction handles the request
+func (h *TransactionImpl) HandleCreateTransaction(w http.ResponseWriter, r *http.Request) {
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	transaction, err := h.Service.Create(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, transaction.ToOutput())
+}
+
+// HandleGetTransactionByID handles the request
+func (h *TransactionImpl) HandleGetTransactionByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	transaction, err := h.Service.GetByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, transaction.ToOutput())
+}
+
+// HandleGetTransactionByFilter handles the request
+func (h *TransactionImpl) HandleGetTransactionByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.TransactionFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	transactions, pageInfo, err := h.Service.GetByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.TransactionOutput, 0)
+	for _, transaction := range transactions {
+		output := transaction.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleUpdateTransaction handles the request
+func (h *TransactionImpl) HandleUpdateTransaction(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	input, err := h.getInputFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	if input.ID.String() != id.String() {
+		response.RespondWithError(w, failure.BadRequestFromString("id mismatch"))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	transaction, err := h.Service.Update(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, transaction.ToOutput())
+}
+
+// HandleDeleteTransaction handles the request
+func (h *TransactionImpl) HandleDeleteTransaction(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	transaction, err := h.Service.Delete(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, transaction.ToOutput())
+}
+
+// HandleGetBankAccountReconciliation handles the request. The period starts at the end of the from date,
+// which is required, and ends at the end of the to date, which defaults to today.
+func (h *TransactionImpl) HandleGetBankAccountReconciliation(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	query := r.URL.Query()
+	if query.Get("from") == "" {
+		response.RespondWithError(w, failure.BadRequestFromString("the from date is required"))
+		return
+	}
+
+	from, err := model.ParseReportDate(query.Get("from"))
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	to, err := model.ParseReportDate(time.Now().Format("2006-01-02"))
+	if param := query.Get("to"); param != "" {
+		to, err = model.ParseReportDate(param)
+	}
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	reconciliation, err := h.Service.Reconcile(id, from, to)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, reconciliation.ToOutput())
+}
+
+func (h *TransactionImpl) getInputFromRequest(w http.ResponseWriter, r *http.Request) (input model.TransactionInput, err error) {
+	err = json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+	}
+
+	return
+}
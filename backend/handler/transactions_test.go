@@ -0,0 +1,253 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type transactionHandlerTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	handler           handler.Transaction
+	mockSvc           *mock_service.MockTransaction
+	testUserID        uuid.UUID
+	testBankAccountID uuid.UUID
+	testTransactionID uuid.UUID
+}
+
+func TestTransactionHandler(t *testing.T) {
+	suite.Run(t, new(transactionHandlerTestSuite))
+}
+
+func (t *transactionHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockTransaction(t.ctrl)
+	t.handler = &handler.TransactionImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.testTransactionID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *transactionHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *transactionHandlerTestSuite) getNewRequestWithContext(method, path string, input any, routeVarId nuuid.NUUID) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	var req *http.Request
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		jsonBody, err := json.Marshal(input)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		req = httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	// set ID route var
+	if routeVarId.Valid {
+		req = mux.SetURLVars(req, map[string]string{
+			"id": routeVarId.UUID.String(),
+		})
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *transactionHandlerTestSuite) getNewTransactionInput(id nuuid.NUUID) model.TransactionInput {
+	input := model.TransactionInput{
+		BankAccountID: t.testBankAccountID,
+		Date:          cachetime.CacheTime(time.Now()),
+		Direction:     model.TransactionDirectionDebit,
+		Amount:        float64(250),
+		Payee:         "Grocery Store",
+		Category:      "groceries",
+	}
+
+	if id.Valid {
+		input.ID = id.UUID
+	}
+
+	return input
+}
+
+func (t *transactionHandlerTestSuite) TestCreate_Normal() {
+	input := t.getNewTransactionInput(nuuid.NUUID{Valid: false})
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/transactions", input, nuuid.NUUID{Valid: false})
+
+	expectedResult := model.NewTransactionFromInput(input, input.BankAccountID, t.testUserID)
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateTransaction(rr, req)
+
+	var body struct {
+		Data model.TransactionOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.Equal(t.T(), model.TransactionDirectionDebit, body.Data.Direction)
+	assert.Equal(t.T(), "Grocery Store", body.Data.Payee)
+}
+
+func (t *transactionHandlerTestSuite) TestCreate_ServiceFailedValidation() {
+	input := t.getNewTransactionInput(nuuid.NUUID{Valid: false})
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/transactions", input, nuuid.NUUID{Valid: false})
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).
+		Return(nil, failure.BadRequestFromString("transaction amount must be greater than zero"))
+
+	t.handler.HandleCreateTransaction(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *transactionHandlerTestSuite) TestGetByID_NotFound() {
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/transactions/"+t.testTransactionID.String(), nil, nuuid.From(t.testTransactionID))
+
+	t.mockSvc.EXPECT().GetByID(t.testTransactionID).Return(nil, failure.EntityNotFound("get by ID", "Transaction"))
+
+	t.handler.HandleGetTransactionByID(rr, req)
+
+	assert.Equal(t.T(), http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func (t *transactionHandlerTestSuite) TestGetByFilter_Normal() {
+	input := model.TransactionFilterInput{Categories: &[]string{"groceries"}}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/transactions/search", input, nuuid.NUUID{Valid: false})
+
+	transaction := model.NewTransactionFromInput(t.getNewTransactionInput(nuuid.NUUID{Valid: false}), t.testBankAccountID, t.testUserID)
+
+	t.mockSvc.EXPECT().GetByFilter(gomock.Any()).
+		Return([]model.Transaction{transaction}, model.PageInfoOutput{Page: 1, PageSize: 10, TotalCount: 1, PageCount: 1}, nil)
+
+	t.handler.HandleGetTransactionByFilter(rr, req)
+
+	var body struct {
+		Data struct {
+			Items    []model.TransactionOutput `json:"items"`
+			PageInfo model.PageInfoOutput      `json:"pageInfo"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data.Items, 1)
+	assert.Equal(t.T(), 1, body.Data.PageInfo.TotalCount)
+}
+
+func (t *transactionHandlerTestSuite) TestUpdate_IDMismatch() {
+	otherID, _ := uuid.NewV7()
+	input := t.getNewTransactionInput(nuuid.From(otherID))
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, "/transactions/"+t.testTransactionID.String(), input, nuuid.From(t.testTransactionID))
+
+	t.handler.HandleUpdateTransaction(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *transactionHandlerTestSuite) TestUpdate_Normal() {
+	input := t.getNewTransactionInput(nuuid.From(t.testTransactionID))
+	rr, req := t.getNewRequestWithContext(http.MethodPatch, "/transactions/"+t.testTransactionID.String(), input, nuuid.From(t.testTransactionID))
+
+	transaction := model.NewTransactionFromInput(input, t.testBankAccountID, t.testUserID)
+	transaction.ID = t.testTransactionID
+
+	t.mockSvc.EXPECT().Update(gomock.Any(), t.testUserID).Return(&transaction, nil)
+
+	t.handler.HandleUpdateTransaction(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+}
+
+func (t *transactionHandlerTestSuite) TestDelete_Normal() {
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/transactions/"+t.testTransactionID.String(), nil, nuuid.From(t.testTransactionID))
+
+	transaction := model.NewTransactionFromInput(t.getNewTransactionInput(nuuid.From(t.testTransactionID)), t.testBankAccountID, t.testUserID)
+	transaction.Delete(t.testUserID)
+
+	t.mockSvc.EXPECT().Delete(t.testTransactionID, t.testUserID).Return(&transaction, nil)
+
+	t.handler.HandleDeleteTransaction(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+}
+
+func (t *transactionHandlerTestSuite) TestGetReconciliation_Normal() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodGet,
+		"/bankAccounts/"+t.testBankAccountID.String()+"/reconciliation?from=2023-01-01&to=2023-03-31",
+		nil,
+		nuuid.From(t.testBankAccountID),
+	)
+
+	from := time.Date(2023, 1, 1, 23, 59, 59, 999999999, time.Local)
+	to := time.Date(2023, 3, 31, 23, 59, 59, 999999999, time.Local)
+	reconciliation := model.NewReconciliation(t.testBankAccountID, from, to, []model.BankAccountBalance{}, []model.Transaction{})
+
+	t.mockSvc.EXPECT().Reconcile(t.testBankAccountID, from, to).Return(&reconciliation, nil)
+
+	t.handler.HandleGetBankAccountReconciliation(rr, req)
+
+	var body struct {
+		Data model.ReconciliationOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Equal(t.T(), t.testBankAccountID, body.Data.BankAccountID)
+	assert.True(t.T(), body.Data.Reconciled)
+	assert.Len(t.T(), body.Data.Periods, 0)
+	assert.Contains(t.T(), rr.Body.String(), `"requestedStartDate":`)
+	assert.Contains(t.T(), rr.Body.String(), `"requestedEndDate":`)
+}
+
+func (t *transactionHandlerTestSuite) TestGetReconciliation_MissingFromDate() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodGet,
+		"/bankAccounts/"+t.testBankAccountID.String()+"/reconciliation",
+		nil,
+		nuuid.From(t.testBankAccountID),
+	)
+
+	t.handler.HandleGetBankAccountReconciliation(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
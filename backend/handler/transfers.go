@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Transfer is the handler interface for Transfers
+type Transfer interface {
+	Startup()
+	Shutdown()
+	HandleCreateTransfer(w http.ResponseWriter, r *http.Request)
+	HandleGetTransferByID(w http.ResponseWriter, r *http.Request)
+	HandleGetTransferByFilter(w http.ResponseWriter, r *http.Request)
+	HandleDeleteTransfer(w http.ResponseWriter, r *http.Request)
+}
+
+// TransferImpl is the handler implementation for Transfers
+type TransferImpl struct {
+	Service service.Transfer `inject:"transferService"`
+}
+
+// Startup performs startup functions
+func (h *TransferImpl) Startup() {
+	logger.Trace("Transfer Handler starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (h *TransferImpl) Shutdown() {
+	logger.Trace("Transfer Handler shutting down...")
+}
+
+// HandleCreateTransfer handles the request
+func (h *TransferImpl) HandleCreateTransfer(w http.ResponseWriter, r *http.Request) {
+	var input model.TransferInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	transfer, err := h.Service.Create(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, transfer.ToOutput())
+}
+
+// HandleGetTransferByID handles the request
+func (h *TransferImpl) HandleGetTransferByID(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	transfer, err := h.Service.GetByID(id)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, transfer.ToOutput())
+}
+
+// HandleGetTransferByFilter handles the request
+func (h *TransferImpl) HandleGetTransferByFilter(w http.ResponseWriter, r *http.Request) {
+	var input model.TransferFilterInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	transfers, pageInfo, err := h.Service.GetByFilter(input)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	outputs := make([]model.TransferOutput, 0)
+	for _, transfer := range transfers {
+		output := transfer.ToOutput()
+		outputs = append(outputs, output)
+	}
+
+	pageOutput := model.PageOutput{
+		Items:    outputs,
+		PageInfo: pageInfo,
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, pageOutput)
+}
+
+// HandleDeleteTransfer handles the request
+func (h *TransferImpl) HandleDeleteTransfer(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	transfer, err := h.Service.Delete(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusCreated, transfer.ToOutput())
+}
@@ -0,0 +1,201 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/kerti/balances/backend/handler"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type transferHandlerTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	handler           handler.Transfer
+	mockSvc           *mock_service.MockTransfer
+	testUserID        uuid.UUID
+	testFromAccountID uuid.UUID
+	testToAccountID   uuid.UUID
+}
+
+func TestTransferHandler(t *testing.T) {
+	suite.Run(t, new(transferHandlerTestSuite))
+}
+
+func (t *transferHandlerTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockSvc = mock_service.NewMockTransfer(t.ctrl)
+	t.handler = &handler.TransferImpl{
+		Service: t.mockSvc,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testFromAccountID, _ = uuid.NewV7()
+	t.testToAccountID, _ = uuid.NewV7()
+	t.handler.Startup()
+}
+
+func (t *transferHandlerTestSuite) TearDownTest() {
+	t.handler.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *transferHandlerTestSuite) getNewRequestWithContext(method, path string, input any, routeVarId nuuid.NUUID) (recorder *httptest.ResponseRecorder, request *http.Request) {
+	var req *http.Request
+
+	if method == http.MethodPost || method == http.MethodPatch {
+		jsonBody, err := json.Marshal(input)
+		if err != nil {
+			t.T().Fatal(err)
+		}
+		req = httptest.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+
+	// set ID route var
+	if routeVarId.Valid {
+		req = mux.SetURLVars(req, map[string]string{
+			"id": routeVarId.UUID.String(),
+		})
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	// add context with user ID
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, ctxprops.PropUserID, &t.testUserID)
+
+	request = req.WithContext(ctx)
+	recorder = httptest.NewRecorder()
+
+	return
+}
+
+func (t *transferHandlerTestSuite) getNewTransferInput() model.TransferInput {
+	return model.TransferInput{
+		FromBankAccountID: t.testFromAccountID,
+		ToBankAccountID:   t.testToAccountID,
+		Date:              cachetime.CacheTime(time.Now()),
+		Amount:            float64(250),
+		Note:              "monthly savings",
+	}
+}
+
+func (t *transferHandlerTestSuite) getNewTransfer() model.Transfer {
+	return model.NewTransferFromInput(
+		t.getNewTransferInput(),
+		model.BankAccount{ID: t.testFromAccountID, AccountName: "Checking", LastBalance: float64(1000)},
+		model.BankAccount{ID: t.testToAccountID, AccountName: "Savings", LastBalance: float64(500)},
+		t.testUserID)
+}
+
+func (t *transferHandlerTestSuite) TestCreate_Normal() {
+	input := t.getNewTransferInput()
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/transfers", input, nuuid.NUUID{Valid: false})
+
+	expectedResult := t.getNewTransfer()
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).Return(&expectedResult, nil)
+
+	t.handler.HandleCreateTransfer(rr, req)
+
+	var body struct {
+		Data model.TransferOutput `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Equal(t.T(), expectedResult.ID, body.Data.ID)
+	assert.NotNil(t.T(), body.Data.Balances)
+	assert.Len(t.T(), *body.Data.Balances, 2)
+	assert.NotNil(t.T(), body.Data.Transactions)
+	assert.Len(t.T(), *body.Data.Transactions, 2)
+}
+
+func (t *transferHandlerTestSuite) TestCreate_ServiceFailedValidation() {
+	input := t.getNewTransferInput()
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/transfers", input, nuuid.NUUID{Valid: false})
+
+	t.mockSvc.EXPECT().Create(gomock.Any(), t.testUserID).
+		Return(nil, failure.BadRequestFromString("cannot transfer from a Bank Account to itself"))
+
+	t.handler.HandleCreateTransfer(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *transferHandlerTestSuite) TestGetByID_NotFound() {
+	id, _ := uuid.NewV7()
+	rr, req := t.getNewRequestWithContext(http.MethodGet, "/transfers/"+id.String(), nil, nuuid.From(id))
+
+	t.mockSvc.EXPECT().GetByID(id).Return(nil, failure.EntityNotFound("get by ID", "Transfer"))
+
+	t.handler.HandleGetTransferByID(rr, req)
+
+	assert.Equal(t.T(), http.StatusNotFound, rr.Result().StatusCode)
+}
+
+func (t *transferHandlerTestSuite) TestGetByFilter_Normal() {
+	input := model.TransferFilterInput{BankAccountIDs: &[]uuid.UUID{t.testFromAccountID}}
+	rr, req := t.getNewRequestWithContext(http.MethodPost, "/transfers/search", input, nuuid.NUUID{Valid: false})
+
+	transfer := t.getNewTransfer()
+
+	t.mockSvc.EXPECT().GetByFilter(gomock.Any()).
+		Return([]model.Transfer{transfer}, model.PageInfoOutput{Page: 1, PageSize: 10, TotalCount: 1, PageCount: 1}, nil)
+
+	t.handler.HandleGetTransferByFilter(rr, req)
+
+	var body struct {
+		Data struct {
+			Items    []model.TransferOutput `json:"items"`
+			PageInfo model.PageInfoOutput   `json:"pageInfo"`
+		} `json:"data"`
+	}
+	err := json.Unmarshal(rr.Body.Bytes(), &body)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Len(t.T(), body.Data.Items, 1)
+	assert.Equal(t.T(), 1, body.Data.PageInfo.TotalCount)
+}
+
+func (t *transferHandlerTestSuite) TestDelete_Normal() {
+	transfer := t.getNewTransfer()
+	transfer.Delete(t.testUserID)
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/transfers/"+transfer.ID.String(), nil, nuuid.From(transfer.ID))
+
+	t.mockSvc.EXPECT().Delete(transfer.ID, t.testUserID).Return(&transfer, nil)
+
+	t.handler.HandleDeleteTransfer(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+}
+
+func (t *transferHandlerTestSuite) TestDelete_NewerBalance() {
+	id, _ := uuid.NewV7()
+	rr, req := t.getNewRequestWithContext(http.MethodDelete, "/transfers/"+id.String(), nil, nuuid.From(id))
+
+	t.mockSvc.EXPECT().Delete(id, t.testUserID).
+		Return(nil, failure.OperationNotPermitted("delete", "Transfer", "a newer balance has been recorded on Checking"))
+
+	t.handler.HandleDeleteTransfer(rr, req)
+
+	assert.Equal(t.T(), http.StatusConflict, rr.Result().StatusCode)
+}
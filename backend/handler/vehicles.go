@@ -23,7 +23,9 @@ type Vehicle interface {
 	HandleGetVehicleByFilter(w http.ResponseWriter, r *http.Request)
 	HandleUpdateVehicle(w http.ResponseWriter, r *http.Request)
 	HandleDeleteVehicle(w http.ResponseWriter, r *http.Request)
+	HandleRestoreVehicle(w http.ResponseWriter, r *http.Request)
 	HandleCreateVehicleValue(w http.ResponseWriter, r *http.Request)
+	HandleImportVehicleValues(w http.ResponseWriter, r *http.Request)
 	HandleGetVehicleValueByID(w http.ResponseWriter, r *http.Request)
 	HandleGetVehicleValueByFilter(w http.ResponseWriter, r *http.Request)
 	HandleUpdateVehicleValue(w http.ResponseWriter, r *http.Request)
@@ -111,6 +113,17 @@ func (h *VehicleImpl) HandleGetVehicleByFilter(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	if input.Aggregation != nil {
+		aggregateRows, err := h.Service.AggregateByFilter(input)
+		if err != nil {
+			response.RespondWithError(w, err)
+			return
+		}
+
+		response.RespondWithJSON(w, http.StatusOK, model.AggregationOutput{Items: aggregateRows})
+		return
+	}
+
 	vehicles, pageInfo, err := h.Service.GetByFilter(input)
 	if err != nil {
 		response.RespondWithError(w, err)
@@ -175,6 +188,23 @@ func (h *VehicleImpl) HandleDeleteVehicle(w http.ResponseWriter, r *http.Request
 	response.RespondWithJSON(w, http.StatusOK, vehicle.ToOutput())
 }
 
+// HandleRestoreVehicle handles the request
+func (h *VehicleImpl) HandleRestoreVehicle(w http.ResponseWriter, r *http.Request) {
+	id, err := getIDFromRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	vehicle, err := h.Service.Restore(id, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, http.StatusOK, vehicle.ToOutput())
+}
+
 // HandleCreateVehicleValue handles the request
 func (h *VehicleImpl) HandleCreateVehicleValue(w http.ResponseWriter, r *http.Request) {
 	input, err := h.getValueInputFromRequest(w, r)
@@ -192,6 +222,25 @@ func (h *VehicleImpl) HandleCreateVehicleValue(w http.ResponseWriter, r *http.Re
 	response.RespondWithJSON(w, http.StatusCreated, vehicleValue.ToOutput())
 }
 
+// HandleImportVehicleValues handles the request
+func (h *VehicleImpl) HandleImportVehicleValues(w http.ResponseWriter, r *http.Request) {
+	var input model.VehicleValueImportInput
+	err := json.NewDecoder(r.Body).Decode(&input)
+	if err != nil {
+		response.RespondWithError(w, failure.BadRequest(err))
+		return
+	}
+
+	userID := (r.Context().Value(ctxprops.PropUserID)).(*uuid.UUID)
+	result, err := h.Service.ImportValues(input, *userID)
+	if err != nil {
+		response.RespondWithError(w, err)
+		return
+	}
+
+	response.RespondWithJSON(w, getImportStatus(result), result.ToOutput())
+}
+
 // HandleGetVehicleValueByID handles the request
 func (h *VehicleImpl) HandleGetVehicleValueByID(w http.ResponseWriter, r *http.Request) {
 	id, err := getIDFromRequest(w, r)
@@ -900,6 +900,79 @@ func (t *vehicleHandlerTestSuite) TestDelete_ServiceFailedDeleting() {
 	assert.Nil(t.T(), err.Operation)
 }
 
+func (t *vehicleHandlerTestSuite) TestRestore_Normal() {
+	input := t.getNewVehicleInput(nuuid.From(t.testVehicleID))
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/vehicles/"+t.testVehicleID.String()+"/restore",
+		nil,
+		nil,
+		nuuid.From(t.testVehicleID),
+	)
+
+	restoredVehicle := model.NewVehicleFromInput(input, t.testUserID)
+	restoredVehicle.ID = t.testVehicleID
+
+	t.mockSvc.EXPECT().Restore(t.testVehicleID, t.testUserID).Return(&restoredVehicle, nil)
+
+	t.handler.HandleRestoreVehicle(rr, req)
+
+	actual, err := t.parseOutputToVehicle(rr)
+
+	assert.NotNil(t.T(), actual)
+	assert.Equal(t.T(), t.testVehicleID, actual.ID)
+	assert.Nil(t.T(), err)
+}
+
+func (t *vehicleHandlerTestSuite) TestRestore_FailedParsingID() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/vehicles/"+t.testVehicleID.String()+"/restore",
+		nil,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.handler.HandleRestoreVehicle(rr, req)
+
+	actual, err := t.parseOutputToVehicle(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, err.Code)
+	// TODO: specify this
+	assert.Nil(t.T(), err.Entity)
+	assert.Contains(t.T(), err.Message, "invalid UUID length")
+	// TODO: specify this
+	assert.Nil(t.T(), err.Operation)
+}
+
+func (t *vehicleHandlerTestSuite) TestRestore_ServiceFailedRestoring() {
+	errMsg := "service failed restoring vehicle"
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/vehicles/"+t.testVehicleID.String()+"/restore",
+		nil,
+		nil,
+		nuuid.From(t.testVehicleID),
+	)
+
+	t.mockSvc.EXPECT().Restore(t.testVehicleID, t.testUserID).Return(nil, errors.New(errMsg))
+
+	t.handler.HandleRestoreVehicle(rr, req)
+
+	actual, err := t.parseOutputToVehicle(rr)
+
+	assert.Nil(t.T(), actual)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeInternalError, err.Code)
+	// TODO: specify this
+	assert.Nil(t.T(), err.Entity)
+	assert.Contains(t.T(), err.Message, errMsg)
+	// TODO: specify this
+	assert.Nil(t.T(), err.Operation)
+}
+
 func (t *vehicleHandlerTestSuite) TestCreateValue_Normal() {
 	input := t.getNewVehicleValueInput(nuuid.NUUID{Valid: false}, nuuid.NUUID{Valid: false})
 	rr, req := t.getNewRequestWithContext(
@@ -985,6 +1058,100 @@ func (t *vehicleHandlerTestSuite) TestCreateValue_ServiceFailedCreatingValue() {
 	assert.Nil(t.T(), err.Operation)
 }
 
+func (t *vehicleHandlerTestSuite) TestImportValues_Normal() {
+	input := model.VehicleValueImportInput{
+		ImportInput: model.ImportInput{
+			CSV:     "date,value\n2024-01-31,1000\n",
+			Mapping: model.ImportColumnMapping{Date: "date", Amount: "value"},
+		},
+		VehicleID: t.testVehicleID,
+	}
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/vehicles/values/import",
+		input,
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportValues(input, t.testUserID).
+		Return(&model.ImportResult{Rows: 1, Imported: 1, Errors: []model.ImportRowError{}}, nil)
+
+	t.handler.HandleImportVehicleValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusCreated, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"imported":1`)
+	assert.Contains(t.T(), rr.Body.String(), `"valid":true`)
+}
+
+func (t *vehicleHandlerTestSuite) TestImportValues_DryRun() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/vehicles/values/import",
+		model.VehicleValueImportInput{ImportInput: model.ImportInput{DryRun: true}},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportValues(gomock.Any(), t.testUserID).
+		Return(&model.ImportResult{DryRun: true, Rows: 2, Errors: []model.ImportRowError{}}, nil)
+
+	t.handler.HandleImportVehicleValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusOK, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"dryRun":true`)
+}
+
+func (t *vehicleHandlerTestSuite) TestImportValues_RowErrors() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/vehicles/values/import",
+		model.VehicleValueImportInput{},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportValues(gomock.Any(), t.testUserID).
+		Return(&model.ImportResult{Rows: 1, Errors: []model.ImportRowError{{Line: 2, Message: "invalid amount"}}}, nil)
+
+	t.handler.HandleImportVehicleValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusUnprocessableEntity, rr.Result().StatusCode)
+	assert.Contains(t.T(), rr.Body.String(), `"line":2`)
+	assert.Contains(t.T(), rr.Body.String(), `"valid":false`)
+}
+
+func (t *vehicleHandlerTestSuite) TestImportValues_FailedParsingRequestPayload() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/vehicles/values/import",
+		"test",
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.handler.HandleImportVehicleValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusBadRequest, rr.Result().StatusCode)
+}
+
+func (t *vehicleHandlerTestSuite) TestImportValues_ServiceFailedImporting() {
+	rr, req := t.getNewRequestWithContext(
+		http.MethodPost,
+		"/vehicles/values/import",
+		model.VehicleValueImportInput{},
+		nil,
+		nuuid.NUUID{Valid: false},
+	)
+
+	t.mockSvc.EXPECT().ImportValues(gomock.Any(), t.testUserID).
+		Return(nil, failure.OperationNotPermitted("import values", "Vehicle", "the Vehicle has been sold"))
+
+	t.handler.HandleImportVehicleValues(rr, req)
+
+	assert.Equal(t.T(), http.StatusConflict, rr.Result().StatusCode)
+}
+
 func (t *vehicleHandlerTestSuite) TestGetValueByID_Normal() {
 	rr, req := t.getNewRequestWithContext(
 		http.MethodGet,
@@ -13,6 +13,7 @@ import (
 	"github.com/kerti/balances/backend/repository"
 	"github.com/kerti/balances/backend/server"
 	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/storage"
 	"github.com/kerti/balances/backend/util/logger"
 )
 
@@ -30,26 +31,73 @@ func main() {
 	var db database.MySQL
 	container.RegisterService("mysql", &db)
 
+	// Prepare containers - storage
+	container.RegisterService("blobStore", new(storage.LocalBlobStore))
+
 	// Prepare containers - repositories
+	container.RegisterService("apiKeyRepository", new(repository.APIKeyMySQLRepo))
+	container.RegisterService("archiveRepository", new(repository.ArchiveMySQLRepo))
+	container.RegisterService("auditLogRepository", new(repository.AuditLogMySQLRepo))
 	container.RegisterService("bankAccountRepository", new(repository.BankAccountMySQLRepo))
 	container.RegisterService("userRepository", new(repository.UserMySQLRepo))
 	container.RegisterService("vehicleRepository", new(repository.VehicleMySQLRepo))
 	container.RegisterService("propertyRepository", new(repository.PropertyMySQLRepo))
+	container.RegisterService("purgeRepository", new(repository.PurgeMySQLRepo))
+	container.RegisterService("searchRepository", new(repository.SearchMySQLRepo))
+	container.RegisterService("transactionRepository", new(repository.TransactionMySQLRepo))
+	container.RegisterService("transferRepository", new(repository.TransferMySQLRepo))
+	container.RegisterService("categoryRepository", new(repository.CategoryMySQLRepo))
+	container.RegisterService("budgetRepository", new(repository.BudgetMySQLRepo))
+	container.RegisterService("goalRepository", new(repository.GoalMySQLRepo))
+	container.RegisterService("tagRepository", new(repository.TagMySQLRepo))
+	container.RegisterService("customFieldRepository", new(repository.CustomFieldMySQLRepo))
+	container.RegisterService("attachmentRepository", new(repository.AttachmentMySQLRepo))
+	container.RegisterService("noteRepository", new(repository.NoteMySQLRepo))
 
 	// Prepare containers - services
+	container.RegisterService("apiKeyService", new(service.APIKeyImpl))
+	container.RegisterService("archiveService", new(service.ArchiveImpl))
+	container.RegisterService("auditLogService", new(service.AuditLogImpl))
 	container.RegisterService("authService", new(service.AuthImpl))
 	container.RegisterService("bankAccountService", new(service.BankAccountImpl))
 	container.RegisterService("userService", new(service.UserImpl))
 	container.RegisterService("vehicleService", new(service.VehicleImpl))
 	container.RegisterService("propertyService", new(service.PropertyImpl))
+	container.RegisterService("purgeService", new(service.PurgeImpl))
+	container.RegisterService("reportService", new(service.ReportImpl))
+	container.RegisterService("searchService", new(service.SearchImpl))
+	container.RegisterService("transactionService", new(service.TransactionImpl))
+	container.RegisterService("transferService", new(service.TransferImpl))
+	container.RegisterService("categoryService", new(service.CategoryImpl))
+	container.RegisterService("budgetService", new(service.BudgetImpl))
+	container.RegisterService("goalService", new(service.GoalImpl))
+	container.RegisterService("tagService", new(service.TagImpl))
+	container.RegisterService("customFieldService", new(service.CustomFieldImpl))
+	container.RegisterService("attachmentService", new(service.AttachmentImpl))
+	container.RegisterService("noteService", new(service.NoteImpl))
 
 	// Prepare containers - handlers
+	container.RegisterService("apiKeyHandler", new(handler.APIKeyImpl))
+	container.RegisterService("archiveHandler", new(handler.ArchiveImpl))
+	container.RegisterService("auditLogHandler", new(handler.AuditLogImpl))
 	container.RegisterService("authHandler", new(handler.AuthImpl))
 	container.RegisterService("bankAccountHandler", new(handler.BankAccountImpl))
 	container.RegisterService("healthHandler", new(handler.HealthImpl))
 	container.RegisterService("userHandler", new(handler.UserImpl))
 	container.RegisterService("vehicleHandler", new(handler.VehicleImpl))
 	container.RegisterService("propertyHandler", new(handler.PropertyImpl))
+	container.RegisterService("purgeHandler", new(handler.PurgeImpl))
+	container.RegisterService("reportHandler", new(handler.ReportImpl))
+	container.RegisterService("searchHandler", new(handler.SearchImpl))
+	container.RegisterService("transactionHandler", new(handler.TransactionImpl))
+	container.RegisterService("transferHandler", new(handler.TransferImpl))
+	container.RegisterService("categoryHandler", new(handler.CategoryImpl))
+	container.RegisterService("budgetHandler", new(handler.BudgetImpl))
+	container.RegisterService("goalHandler", new(handler.GoalImpl))
+	container.RegisterService("tagHandler", new(handler.TagImpl))
+	container.RegisterService("customFieldHandler", new(handler.CustomFieldImpl))
+	container.RegisterService("attachmentHandler", new(handler.AttachmentImpl))
+	container.RegisterService("noteHandler", new(handler.NoteImpl))
 
 	// Prepare containers - HTTP server
 	var s server.Server
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS `api_keys` (
+  `entity_id` CHAR(36) NOT NULL,
+  `user_entity_id` CHAR(36) NOT NULL,
+  `name` VARCHAR(255) NOT NULL,
+  `key_prefix` CHAR(8) NOT NULL,
+  `key_hash` CHAR(64) NOT NULL,
+  `scopes` VARCHAR(255) NOT NULL,
+  `expires` TIMESTAMP NULL DEFAULT NULL,
+  `last_used` TIMESTAMP NULL DEFAULT NULL,
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `revoked` TIMESTAMP NULL DEFAULT NULL,
+  `revoked_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  UNIQUE KEY `api_keys_idx_1` (`key_prefix`),
+  CONSTRAINT `fk_ak_user_entity_id` FOREIGN KEY (`user_entity_id`)
+    REFERENCES `users`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION,
+  INDEX `api_keys_idx_2` (`name`),
+  INDEX `api_keys_idx_3` (`created`),
+  INDEX `api_keys_idx_4` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `audit_logs` (
+  `entity_id` CHAR(36) NOT NULL,
+  `entity_type` VARCHAR(64) NOT NULL,
+  `subject_entity_id` CHAR(36) NOT NULL,
+  `action` ENUM('create', 'update', 'delete') NOT NULL,
+  `actor_entity_id` CHAR(36) NOT NULL,
+  `snapshot_before` JSON NULL DEFAULT NULL,
+  `snapshot_after` JSON NULL DEFAULT NULL,
+  `created` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`entity_id`),
+  INDEX `audit_logs_idx_1` (`entity_type`, `subject_entity_id`),
+  INDEX `audit_logs_idx_2` (`subject_entity_id`),
+  INDEX `audit_logs_idx_3` (`actor_entity_id`),
+  INDEX `audit_logs_idx_4` (`action`),
+  INDEX `audit_logs_idx_5` (`created`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
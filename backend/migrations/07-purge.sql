@@ -0,0 +1,2 @@
+ALTER TABLE `audit_logs`
+  MODIFY COLUMN `action` ENUM('create', 'update', 'delete', 'restore', 'purge') NOT NULL;
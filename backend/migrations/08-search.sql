@@ -0,0 +1,8 @@
+ALTER TABLE `bank_accounts`
+  ADD FULLTEXT INDEX `bank_account_ftidx_1` (`account_name`, `bank_name`, `account_holder_name`, `account_number`);
+
+ALTER TABLE `vehicles`
+  ADD FULLTEXT INDEX `vehicles_ftidx_1` (`name`, `make`, `model`, `license_plate_number`);
+
+ALTER TABLE `properties`
+  ADD FULLTEXT INDEX `properties_ftidx_1` (`name`, `address`, `tax_identifier`);
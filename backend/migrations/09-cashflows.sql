@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS `bank_account_cash_flows` (
+  `entity_id` CHAR(36) NOT NULL,
+  `bank_account_entity_id` CHAR(36) NOT NULL,
+  `date` TIMESTAMP NOT NULL,
+  `type` ENUM('deposit', 'withdrawal') NOT NULL,
+  `amount` DECIMAL(18,2) NOT NULL,
+  `note` VARCHAR(255) NOT NULL DEFAULT '',
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  CONSTRAINT `fk_bacf_bank_account_entity_id` FOREIGN KEY (`bank_account_entity_id`)
+    REFERENCES `bank_accounts`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION,
+  INDEX `bank_account_cash_flows_idx_1` (`date`),
+  INDEX `bank_account_cash_flows_idx_2` (`type`),
+  INDEX `bank_account_cash_flows_idx_3` (`created`),
+  INDEX `bank_account_cash_flows_idx_4` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS `transactions` (
+  `entity_id` CHAR(36) NOT NULL,
+  `bank_account_entity_id` CHAR(36) NOT NULL,
+  `date` TIMESTAMP NOT NULL,
+  `direction` ENUM('credit', 'debit') NOT NULL,
+  `amount` DECIMAL(18,2) NOT NULL,
+  `payee` VARCHAR(255) NOT NULL DEFAULT '',
+  `memo` VARCHAR(255) NOT NULL DEFAULT '',
+  `category` VARCHAR(255) NOT NULL DEFAULT '',
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  CONSTRAINT `fk_tx_bank_account_entity_id` FOREIGN KEY (`bank_account_entity_id`)
+    REFERENCES `bank_accounts`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION,
+  INDEX `transactions_idx_1` (`date`),
+  INDEX `transactions_idx_2` (`direction`),
+  INDEX `transactions_idx_3` (`category`),
+  INDEX `transactions_idx_4` (`created`),
+  INDEX `transactions_idx_5` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS `transfers` (
+  `entity_id` CHAR(36) NOT NULL,
+  `from_bank_account_entity_id` CHAR(36) NOT NULL,
+  `to_bank_account_entity_id` CHAR(36) NOT NULL,
+  `date` TIMESTAMP NOT NULL,
+  `amount` DECIMAL(18,2) NOT NULL,
+  `note` VARCHAR(255) NOT NULL DEFAULT '',
+  `from_balance_entity_id` CHAR(36) NOT NULL,
+  `to_balance_entity_id` CHAR(36) NOT NULL,
+  `from_transaction_entity_id` CHAR(36) NOT NULL,
+  `to_transaction_entity_id` CHAR(36) NOT NULL,
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  CONSTRAINT `fk_tf_from_bank_account_entity_id` FOREIGN KEY (`from_bank_account_entity_id`)
+    REFERENCES `bank_accounts`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION,
+  CONSTRAINT `fk_tf_to_bank_account_entity_id` FOREIGN KEY (`to_bank_account_entity_id`)
+    REFERENCES `bank_accounts`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION,
+  INDEX `transfers_idx_1` (`date`),
+  INDEX `transfers_idx_2` (`from_transaction_entity_id`),
+  INDEX `transfers_idx_3` (`to_transaction_entity_id`),
+  INDEX `transfers_idx_4` (`created`),
+  INDEX `transfers_idx_5` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
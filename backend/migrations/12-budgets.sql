@@ -0,0 +1,61 @@
+CREATE TABLE IF NOT EXISTS `categories` (
+  `entity_id` CHAR(36) NOT NULL,
+  `parent_entity_id` CHAR(36) NULL DEFAULT NULL,
+  `name` VARCHAR(255) NOT NULL,
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  INDEX `categories_idx_1` (`parent_entity_id`),
+  INDEX `categories_idx_2` (`name`),
+  INDEX `categories_idx_3` (`created`),
+  INDEX `categories_idx_4` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `category_rules` (
+  `entity_id` CHAR(36) NOT NULL,
+  `category_entity_id` CHAR(36) NOT NULL,
+  `pattern` VARCHAR(255) NOT NULL,
+  `priority` INT NOT NULL DEFAULT 0,
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  CONSTRAINT `fk_cr_category_entity_id` FOREIGN KEY (`category_entity_id`)
+    REFERENCES `categories`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION,
+  INDEX `category_rules_idx_1` (`priority`),
+  INDEX `category_rules_idx_2` (`created`),
+  INDEX `category_rules_idx_3` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `budgets` (
+  `entity_id` CHAR(36) NOT NULL,
+  `category_entity_id` CHAR(36) NOT NULL,
+  `month` TIMESTAMP NOT NULL,
+  `amount` DECIMAL(18,2) NOT NULL,
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  CONSTRAINT `fk_bg_category_entity_id` FOREIGN KEY (`category_entity_id`)
+    REFERENCES `categories`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION,
+  INDEX `budgets_idx_1` (`month`),
+  INDEX `budgets_idx_2` (`created`),
+  INDEX `budgets_idx_3` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS `goals` (
+  `entity_id` CHAR(36) NOT NULL,
+  `name` VARCHAR(255) NOT NULL,
+  `target_amount` DECIMAL(18,2) NOT NULL,
+  `target_date` TIMESTAMP NOT NULL,
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  INDEX `goals_idx_1` (`name`),
+  INDEX `goals_idx_2` (`target_date`),
+  INDEX `goals_idx_3` (`created`),
+  INDEX `goals_idx_4` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `goal_bank_accounts` (
+  `goal_entity_id` CHAR(36) NOT NULL,
+  `bank_account_entity_id` CHAR(36) NOT NULL,
+  PRIMARY KEY (`goal_entity_id`, `bank_account_entity_id`),
+  CONSTRAINT `fk_gba_goal_entity_id` FOREIGN KEY (`goal_entity_id`)
+    REFERENCES `goals`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE,
+  CONSTRAINT `fk_gba_bank_account_entity_id` FOREIGN KEY (`bank_account_entity_id`)
+    REFERENCES `bank_accounts`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE,
+  INDEX `goal_bank_accounts_idx_1` (`bank_account_entity_id`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
@@ -0,0 +1,59 @@
+CREATE TABLE IF NOT EXISTS `tags` (
+  `entity_id` CHAR(36) NOT NULL,
+  `name` VARCHAR(255) NOT NULL,
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  INDEX `tags_idx_1` (`name`),
+  INDEX `tags_idx_2` (`created`),
+  INDEX `tags_idx_3` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `entity_tags` (
+  `tag_entity_id` CHAR(36) NOT NULL,
+  `entity_type` VARCHAR(50) NOT NULL,
+  `subject_entity_id` CHAR(36) NOT NULL,
+  PRIMARY KEY (`tag_entity_id`, `entity_type`, `subject_entity_id`),
+  CONSTRAINT `fk_et_tag_entity_id` FOREIGN KEY (`tag_entity_id`)
+    REFERENCES `tags`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE,
+  INDEX `entity_tags_idx_1` (`entity_type`, `subject_entity_id`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `custom_fields` (
+  `entity_id` CHAR(36) NOT NULL,
+  `entity_type` VARCHAR(50) NOT NULL,
+  `name` VARCHAR(255) NOT NULL,
+  `field_type` ENUM('text', 'number', 'date', 'boolean') NOT NULL DEFAULT 'text',
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  INDEX `custom_fields_idx_1` (`entity_type`, `name`),
+  INDEX `custom_fields_idx_2` (`created`),
+  INDEX `custom_fields_idx_3` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
+
+CREATE TABLE IF NOT EXISTS `custom_field_values` (
+  `custom_field_entity_id` CHAR(36) NOT NULL,
+  `subject_entity_id` CHAR(36) NOT NULL,
+  `value` TEXT NOT NULL,
+  PRIMARY KEY (`custom_field_entity_id`, `subject_entity_id`),
+  CONSTRAINT `fk_cfv_custom_field_entity_id` FOREIGN KEY (`custom_field_entity_id`)
+    REFERENCES `custom_fields`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE,
+  INDEX `custom_field_values_idx_1` (`subject_entity_id`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
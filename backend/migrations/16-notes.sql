@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `notes` (
+  `entity_id` CHAR(36) NOT NULL,
+  `entity_type` VARCHAR(50) NOT NULL,
+  `subject_entity_id` CHAR(36) NOT NULL,
+  `content` TEXT NOT NULL,
+  `created` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `created_by` CHAR(36) NOT NULL,
+  `updated` TIMESTAMP NULL DEFAULT NULL,
+  `updated_by` CHAR(36) NULL DEFAULT NULL,
+  `deleted` TIMESTAMP NULL DEFAULT NULL,
+  `deleted_by` CHAR(36) NULL DEFAULT NULL,
+  PRIMARY KEY (`entity_id`),
+  INDEX `notes_idx_1` (`entity_type`, `subject_entity_id`),
+  INDEX `notes_idx_2` (`created`),
+  INDEX `notes_idx_3` (`created_by`)
+) ENGINE=InnoDB
+DEFAULT CHARSET=utf8;
@@ -0,0 +1,27 @@
+ALTER TABLE `bank_account_balances`
+  ADD COLUMN `deleted_by_cascade` TINYINT(1) NOT NULL DEFAULT 0 AFTER `deleted_by`;
+
+ALTER TABLE `vehicle_values`
+  ADD COLUMN `deleted_by_cascade` TINYINT(1) NOT NULL DEFAULT 0 AFTER `deleted_by`;
+
+ALTER TABLE `property_values`
+  ADD COLUMN `deleted_by_cascade` TINYINT(1) NOT NULL DEFAULT 0 AFTER `deleted_by`;
+
+-- history deleted before the marker existed can only be told apart by its deletion time and user
+UPDATE `bank_account_balances`
+  JOIN `bank_accounts` ON `bank_accounts`.`entity_id` = `bank_account_balances`.`bank_account_entity_id`
+  SET `bank_account_balances`.`deleted_by_cascade` = 1
+  WHERE `bank_account_balances`.`deleted` = `bank_accounts`.`deleted`
+    AND `bank_account_balances`.`deleted_by` = `bank_accounts`.`deleted_by`;
+
+UPDATE `vehicle_values`
+  JOIN `vehicles` ON `vehicles`.`entity_id` = `vehicle_values`.`vehicle_entity_id`
+  SET `vehicle_values`.`deleted_by_cascade` = 1
+  WHERE `vehicle_values`.`deleted` = `vehicles`.`deleted`
+    AND `vehicle_values`.`deleted_by` = `vehicles`.`deleted_by`;
+
+UPDATE `property_values`
+  JOIN `properties` ON `properties`.`entity_id` = `property_values`.`property_entity_id`
+  SET `property_values`.`deleted_by_cascade` = 1
+  WHERE `property_values`.`deleted` = `properties`.`deleted`
+    AND `property_values`.`deleted_by` = `properties`.`deleted_by`;
@@ -0,0 +1,13 @@
+ALTER TABLE `transactions`
+  ADD COLUMN `category_entity_id` CHAR(36) NULL DEFAULT NULL AFTER `category`,
+  ADD CONSTRAINT `fk_tx_category_entity_id` FOREIGN KEY (`category_entity_id`)
+    REFERENCES `categories`(`entity_id`)
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION,
+  ADD INDEX `transactions_idx_6` (`category_entity_id`);
+
+-- transactions filed before the link existed can only be told apart by the name of their category
+UPDATE `transactions`
+  JOIN `categories` ON LOWER(`categories`.`name`) = LOWER(TRIM(`transactions`.`category`))
+  SET `transactions`.`category_entity_id` = `categories`.`entity_id`
+  WHERE `categories`.`deleted` IS NULL;
@@ -6,6 +6,7 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,6 +37,21 @@ func (m *MockBankAccount) EXPECT() *MockBankAccountMockRecorder {
 	return m.recorder
 }
 
+// AggregateByFilter mocks base method.
+func (m *MockBankAccount) AggregateByFilter(filter filter.Filter) ([]model.AggregateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateByFilter", filter)
+	ret0, _ := ret[0].([]model.AggregateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateByFilter indicates an expected call of AggregateByFilter.
+func (mr *MockBankAccountMockRecorder) AggregateByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateByFilter", reflect.TypeOf((*MockBankAccount)(nil).AggregateByFilter), filter)
+}
+
 // Create mocks base method.
 func (m *MockBankAccount) Create(bankAccount model.BankAccount) error {
 	m.ctrl.T.Helper()
@@ -64,6 +80,20 @@ func (mr *MockBankAccountMockRecorder) CreateBalance(bankAccountBalance, bankAcc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBalance", reflect.TypeOf((*MockBankAccount)(nil).CreateBalance), bankAccountBalance, bankAccount)
 }
 
+// CreateCashFlow mocks base method.
+func (m *MockBankAccount) CreateCashFlow(bankAccountCashFlow model.BankAccountCashFlow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCashFlow", bankAccountCashFlow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCashFlow indicates an expected call of CreateCashFlow.
+func (mr *MockBankAccountMockRecorder) CreateCashFlow(bankAccountCashFlow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCashFlow", reflect.TypeOf((*MockBankAccount)(nil).CreateCashFlow), bankAccountCashFlow)
+}
+
 // ExistsBalanceByID mocks base method.
 func (m *MockBankAccount) ExistsBalanceByID(id uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +124,35 @@ func (mr *MockBankAccountMockRecorder) ExistsByID(id interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockBankAccount)(nil).ExistsByID), id)
 }
 
+// ExistsCashFlowByID mocks base method.
+func (m *MockBankAccount) ExistsCashFlowByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsCashFlowByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsCashFlowByID indicates an expected call of ExistsCashFlowByID.
+func (mr *MockBankAccountMockRecorder) ExistsCashFlowByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsCashFlowByID", reflect.TypeOf((*MockBankAccount)(nil).ExistsCashFlowByID), id)
+}
+
+// ImportBalances mocks base method.
+func (m *MockBankAccount) ImportBalances(bankAccountBalances []model.BankAccountBalance, bankAccount *model.BankAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBalances", bankAccountBalances, bankAccount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportBalances indicates an expected call of ImportBalances.
+func (mr *MockBankAccountMockRecorder) ImportBalances(bankAccountBalances, bankAccount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBalances", reflect.TypeOf((*MockBankAccount)(nil).ImportBalances), bankAccountBalances, bankAccount)
+}
+
 // ResolveBalancesByFilter mocks base method.
 func (m *MockBankAccount) ResolveBalancesByFilter(filter filter.Filter) ([]model.BankAccountBalance, model.PageInfoOutput, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +215,37 @@ func (mr *MockBankAccountMockRecorder) ResolveByIDs(ids interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockBankAccount)(nil).ResolveByIDs), ids)
 }
 
+// ResolveCashFlowsByFilter mocks base method.
+func (m *MockBankAccount) ResolveCashFlowsByFilter(filter filter.Filter) ([]model.BankAccountCashFlow, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCashFlowsByFilter", filter)
+	ret0, _ := ret[0].([]model.BankAccountCashFlow)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveCashFlowsByFilter indicates an expected call of ResolveCashFlowsByFilter.
+func (mr *MockBankAccountMockRecorder) ResolveCashFlowsByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCashFlowsByFilter", reflect.TypeOf((*MockBankAccount)(nil).ResolveCashFlowsByFilter), filter)
+}
+
+// ResolveCashFlowsByIDs mocks base method.
+func (m *MockBankAccount) ResolveCashFlowsByIDs(ids []uuid.UUID) ([]model.BankAccountCashFlow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveCashFlowsByIDs", ids)
+	ret0, _ := ret[0].([]model.BankAccountCashFlow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveCashFlowsByIDs indicates an expected call of ResolveCashFlowsByIDs.
+func (mr *MockBankAccountMockRecorder) ResolveCashFlowsByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveCashFlowsByIDs", reflect.TypeOf((*MockBankAccount)(nil).ResolveCashFlowsByIDs), ids)
+}
+
 // ResolveLastBalancesByBankAccountID mocks base method.
 func (m *MockBankAccount) ResolveLastBalancesByBankAccountID(id uuid.UUID, count int) ([]model.BankAccountBalance, error) {
 	m.ctrl.T.Helper()
@@ -223,45 +313,59 @@ func (mr *MockBankAccountMockRecorder) UpdateBalance(bankAccountBalance, bankAcc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockBankAccount)(nil).UpdateBalance), bankAccountBalance, bankAccount)
 }
 
-// MockUser is a mock of User interface.
-type MockUser struct {
+// UpdateCashFlow mocks base method.
+func (m *MockBankAccount) UpdateCashFlow(bankAccountCashFlow model.BankAccountCashFlow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCashFlow", bankAccountCashFlow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCashFlow indicates an expected call of UpdateCashFlow.
+func (mr *MockBankAccountMockRecorder) UpdateCashFlow(bankAccountCashFlow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCashFlow", reflect.TypeOf((*MockBankAccount)(nil).UpdateCashFlow), bankAccountCashFlow)
+}
+
+// MockTransaction is a mock of Transaction interface.
+type MockTransaction struct {
 	ctrl     *gomock.Controller
-	recorder *MockUserMockRecorder
+	recorder *MockTransactionMockRecorder
 }
 
-// MockUserMockRecorder is the mock recorder for MockUser.
-type MockUserMockRecorder struct {
-	mock *MockUser
+// MockTransactionMockRecorder is the mock recorder for MockTransaction.
+type MockTransactionMockRecorder struct {
+	mock *MockTransaction
 }
 
-// NewMockUser creates a new mock instance.
-func NewMockUser(ctrl *gomock.Controller) *MockUser {
-	mock := &MockUser{ctrl: ctrl}
-	mock.recorder = &MockUserMockRecorder{mock}
+// NewMockTransaction creates a new mock instance.
+func NewMockTransaction(ctrl *gomock.Controller) *MockTransaction {
+	mock := &MockTransaction{ctrl: ctrl}
+	mock.recorder = &MockTransactionMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUser) EXPECT() *MockUserMockRecorder {
+func (m *MockTransaction) EXPECT() *MockTransactionMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *MockUser) Create(user model.User) error {
+func (m *MockTransaction) Create(transaction model.Transaction) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", user)
+	ret := m.ctrl.Call(m, "Create", transaction)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockUserMockRecorder) Create(user interface{}) *gomock.Call {
+func (mr *MockTransactionMockRecorder) Create(transaction interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransaction)(nil).Create), transaction)
 }
 
 // ExistsByID mocks base method.
-func (m *MockUser) ExistsByID(id uuid.UUID) (bool, error) {
+func (m *MockTransaction) ExistsByID(id uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ExistsByID", id)
 	ret0, _ := ret[0].(bool)
@@ -270,358 +374,328 @@ func (m *MockUser) ExistsByID(id uuid.UUID) (bool, error) {
 }
 
 // ExistsByID indicates an expected call of ExistsByID.
-func (mr *MockUserMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+func (mr *MockTransactionMockRecorder) ExistsByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockUser)(nil).ExistsByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockTransaction)(nil).ExistsByID), id)
 }
 
 // ResolveByFilter mocks base method.
-func (m *MockUser) ResolveByFilter(filter filter.Filter) ([]model.User, model.PageInfoOutput, error) {
+func (m *MockTransaction) ResolveByFilter(filter filter.Filter) ([]model.Transaction, model.PageInfoOutput, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
-	ret0, _ := ret[0].([]model.User)
+	ret0, _ := ret[0].([]model.Transaction)
 	ret1, _ := ret[1].(model.PageInfoOutput)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // ResolveByFilter indicates an expected call of ResolveByFilter.
-func (mr *MockUserMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+func (mr *MockTransactionMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockUser)(nil).ResolveByFilter), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockTransaction)(nil).ResolveByFilter), filter)
 }
 
 // ResolveByIDs mocks base method.
-func (m *MockUser) ResolveByIDs(ids []uuid.UUID) ([]model.User, error) {
+func (m *MockTransaction) ResolveByIDs(ids []uuid.UUID) ([]model.Transaction, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
-	ret0, _ := ret[0].([]model.User)
+	ret0, _ := ret[0].([]model.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ResolveByIDs indicates an expected call of ResolveByIDs.
-func (mr *MockUserMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockUser)(nil).ResolveByIDs), ids)
-}
-
-// ResolveByIdentity mocks base method.
-func (m *MockUser) ResolveByIdentity(identity string) (model.User, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveByIdentity", identity)
-	ret0, _ := ret[0].(model.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// ResolveByIdentity indicates an expected call of ResolveByIdentity.
-func (mr *MockUserMockRecorder) ResolveByIdentity(identity interface{}) *gomock.Call {
+func (mr *MockTransactionMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIdentity", reflect.TypeOf((*MockUser)(nil).ResolveByIdentity), identity)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockTransaction)(nil).ResolveByIDs), ids)
 }
 
 // Shutdown mocks base method.
-func (m *MockUser) Shutdown() {
+func (m *MockTransaction) Shutdown() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Shutdown")
 }
 
 // Shutdown indicates an expected call of Shutdown.
-func (mr *MockUserMockRecorder) Shutdown() *gomock.Call {
+func (mr *MockTransactionMockRecorder) Shutdown() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockUser)(nil).Shutdown))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockTransaction)(nil).Shutdown))
 }
 
 // Startup mocks base method.
-func (m *MockUser) Startup() {
+func (m *MockTransaction) Startup() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Startup")
 }
 
 // Startup indicates an expected call of Startup.
-func (mr *MockUserMockRecorder) Startup() *gomock.Call {
+func (mr *MockTransactionMockRecorder) Startup() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockUser)(nil).Startup))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockTransaction)(nil).Startup))
 }
 
 // Update mocks base method.
-func (m *MockUser) Update(user model.User) error {
+func (m *MockTransaction) Update(transaction model.Transaction) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", user)
+	ret := m.ctrl.Call(m, "Update", transaction)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserMockRecorder) Update(user interface{}) *gomock.Call {
+func (mr *MockTransactionMockRecorder) Update(transaction interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTransaction)(nil).Update), transaction)
 }
 
-// MockVehicle is a mock of Vehicle interface.
-type MockVehicle struct {
+// MockTransfer is a mock of Transfer interface.
+type MockTransfer struct {
 	ctrl     *gomock.Controller
-	recorder *MockVehicleMockRecorder
+	recorder *MockTransferMockRecorder
 }
 
-// MockVehicleMockRecorder is the mock recorder for MockVehicle.
-type MockVehicleMockRecorder struct {
-	mock *MockVehicle
+// MockTransferMockRecorder is the mock recorder for MockTransfer.
+type MockTransferMockRecorder struct {
+	mock *MockTransfer
 }
 
-// NewMockVehicle creates a new mock instance.
-func NewMockVehicle(ctrl *gomock.Controller) *MockVehicle {
-	mock := &MockVehicle{ctrl: ctrl}
-	mock.recorder = &MockVehicleMockRecorder{mock}
+// NewMockTransfer creates a new mock instance.
+func NewMockTransfer(ctrl *gomock.Controller) *MockTransfer {
+	mock := &MockTransfer{ctrl: ctrl}
+	mock.recorder = &MockTransferMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockVehicle) EXPECT() *MockVehicleMockRecorder {
+func (m *MockTransfer) EXPECT() *MockTransferMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *MockVehicle) Create(vehicle model.Vehicle) error {
+func (m *MockTransfer) Create(transfer model.Transfer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", vehicle)
+	ret := m.ctrl.Call(m, "Create", transfer)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockVehicleMockRecorder) Create(vehicle interface{}) *gomock.Call {
+func (mr *MockTransferMockRecorder) Create(transfer interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVehicle)(nil).Create), vehicle)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransfer)(nil).Create), transfer)
 }
 
-// CreateValue mocks base method.
-func (m *MockVehicle) CreateValue(vehicleValue model.VehicleValue, vehicle *model.Vehicle) error {
+// Delete mocks base method.
+func (m *MockTransfer) Delete(transfer model.Transfer, userID uuid.UUID) (model.Transfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateValue", vehicleValue, vehicle)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Delete", transfer, userID)
+	ret0, _ := ret[0].(model.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// CreateValue indicates an expected call of CreateValue.
-func (mr *MockVehicleMockRecorder) CreateValue(vehicleValue, vehicle interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockTransferMockRecorder) Delete(transfer, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateValue", reflect.TypeOf((*MockVehicle)(nil).CreateValue), vehicleValue, vehicle)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTransfer)(nil).Delete), transfer, userID)
 }
 
-// ExistsByID mocks base method.
-func (m *MockVehicle) ExistsByID(id uuid.UUID) (bool, error) {
+// ExistsByBalanceID mocks base method.
+func (m *MockTransfer) ExistsByBalanceID(balanceID uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret := m.ctrl.Call(m, "ExistsByBalanceID", balanceID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ExistsByID indicates an expected call of ExistsByID.
-func (mr *MockVehicleMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+// ExistsByBalanceID indicates an expected call of ExistsByBalanceID.
+func (mr *MockTransferMockRecorder) ExistsByBalanceID(balanceID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockVehicle)(nil).ExistsByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByBalanceID", reflect.TypeOf((*MockTransfer)(nil).ExistsByBalanceID), balanceID)
 }
 
-// ExistsValueByID mocks base method.
-func (m *MockVehicle) ExistsValueByID(id uuid.UUID) (bool, error) {
+// ExistsByBankAccountID mocks base method.
+func (m *MockTransfer) ExistsByBankAccountID(bankAccountID uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExistsValueByID", id)
+	ret := m.ctrl.Call(m, "ExistsByBankAccountID", bankAccountID)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ExistsValueByID indicates an expected call of ExistsValueByID.
-func (mr *MockVehicleMockRecorder) ExistsValueByID(id interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsValueByID", reflect.TypeOf((*MockVehicle)(nil).ExistsValueByID), id)
-}
-
-// ResolveByFilter mocks base method.
-func (m *MockVehicle) ResolveByFilter(filter filter.Filter) ([]model.Vehicle, model.PageInfoOutput, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
-	ret0, _ := ret[0].([]model.Vehicle)
-	ret1, _ := ret[1].(model.PageInfoOutput)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
-}
-
-// ResolveByFilter indicates an expected call of ResolveByFilter.
-func (mr *MockVehicleMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+// ExistsByBankAccountID indicates an expected call of ExistsByBankAccountID.
+func (mr *MockTransferMockRecorder) ExistsByBankAccountID(bankAccountID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockVehicle)(nil).ResolveByFilter), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByBankAccountID", reflect.TypeOf((*MockTransfer)(nil).ExistsByBankAccountID), bankAccountID)
 }
 
-// ResolveByIDs mocks base method.
-func (m *MockVehicle) ResolveByIDs(ids []uuid.UUID) ([]model.Vehicle, error) {
+// ExistsByID mocks base method.
+func (m *MockTransfer) ExistsByID(id uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
-	ret0, _ := ret[0].([]model.Vehicle)
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ResolveByIDs indicates an expected call of ResolveByIDs.
-func (mr *MockVehicleMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockTransferMockRecorder) ExistsByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockVehicle)(nil).ResolveByIDs), ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockTransfer)(nil).ExistsByID), id)
 }
 
-// ResolveLastValuesByVehicleID mocks base method.
-func (m *MockVehicle) ResolveLastValuesByVehicleID(id uuid.UUID, count int) ([]model.VehicleValue, error) {
+// ExistsByTransactionID mocks base method.
+func (m *MockTransfer) ExistsByTransactionID(transactionID uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveLastValuesByVehicleID", id, count)
-	ret0, _ := ret[0].([]model.VehicleValue)
+	ret := m.ctrl.Call(m, "ExistsByTransactionID", transactionID)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ResolveLastValuesByVehicleID indicates an expected call of ResolveLastValuesByVehicleID.
-func (mr *MockVehicleMockRecorder) ResolveLastValuesByVehicleID(id, count interface{}) *gomock.Call {
+// ExistsByTransactionID indicates an expected call of ExistsByTransactionID.
+func (mr *MockTransferMockRecorder) ExistsByTransactionID(transactionID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLastValuesByVehicleID", reflect.TypeOf((*MockVehicle)(nil).ResolveLastValuesByVehicleID), id, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByTransactionID", reflect.TypeOf((*MockTransfer)(nil).ExistsByTransactionID), transactionID)
 }
 
-// ResolveValuesByFilter mocks base method.
-func (m *MockVehicle) ResolveValuesByFilter(filter filter.Filter) ([]model.VehicleValue, model.PageInfoOutput, error) {
+// ResolveByFilter mocks base method.
+func (m *MockTransfer) ResolveByFilter(filter filter.Filter) ([]model.Transfer, model.PageInfoOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveValuesByFilter", filter)
-	ret0, _ := ret[0].([]model.VehicleValue)
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.Transfer)
 	ret1, _ := ret[1].(model.PageInfoOutput)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// ResolveValuesByFilter indicates an expected call of ResolveValuesByFilter.
-func (mr *MockVehicleMockRecorder) ResolveValuesByFilter(filter interface{}) *gomock.Call {
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockTransferMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveValuesByFilter", reflect.TypeOf((*MockVehicle)(nil).ResolveValuesByFilter), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockTransfer)(nil).ResolveByFilter), filter)
 }
 
-// ResolveValuesByIDs mocks base method.
-func (m *MockVehicle) ResolveValuesByIDs(ids []uuid.UUID) ([]model.VehicleValue, error) {
+// ResolveByIDs mocks base method.
+func (m *MockTransfer) ResolveByIDs(ids []uuid.UUID) ([]model.Transfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveValuesByIDs", ids)
-	ret0, _ := ret[0].([]model.VehicleValue)
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ResolveValuesByIDs indicates an expected call of ResolveValuesByIDs.
-func (mr *MockVehicleMockRecorder) ResolveValuesByIDs(ids interface{}) *gomock.Call {
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockTransferMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveValuesByIDs", reflect.TypeOf((*MockVehicle)(nil).ResolveValuesByIDs), ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockTransfer)(nil).ResolveByIDs), ids)
 }
 
 // Shutdown mocks base method.
-func (m *MockVehicle) Shutdown() {
+func (m *MockTransfer) Shutdown() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Shutdown")
 }
 
 // Shutdown indicates an expected call of Shutdown.
-func (mr *MockVehicleMockRecorder) Shutdown() *gomock.Call {
+func (mr *MockTransferMockRecorder) Shutdown() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockVehicle)(nil).Shutdown))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockTransfer)(nil).Shutdown))
 }
 
 // Startup mocks base method.
-func (m *MockVehicle) Startup() {
+func (m *MockTransfer) Startup() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Startup")
 }
 
 // Startup indicates an expected call of Startup.
-func (mr *MockVehicleMockRecorder) Startup() *gomock.Call {
+func (mr *MockTransferMockRecorder) Startup() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockVehicle)(nil).Startup))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockTransfer)(nil).Startup))
 }
 
 // Update mocks base method.
-func (m *MockVehicle) Update(vehicle model.Vehicle) error {
+func (m *MockTransfer) Update(transfer model.Transfer) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", vehicle)
+	ret := m.ctrl.Call(m, "Update", transfer)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockVehicleMockRecorder) Update(vehicle interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicle)(nil).Update), vehicle)
-}
-
-// UpdateValue mocks base method.
-func (m *MockVehicle) UpdateValue(vehicleValue model.VehicleValue, vehicle *model.Vehicle) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateValue", vehicleValue, vehicle)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// UpdateValue indicates an expected call of UpdateValue.
-func (mr *MockVehicleMockRecorder) UpdateValue(vehicleValue, vehicle interface{}) *gomock.Call {
+func (mr *MockTransferMockRecorder) Update(transfer interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValue", reflect.TypeOf((*MockVehicle)(nil).UpdateValue), vehicleValue, vehicle)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTransfer)(nil).Update), transfer)
 }
 
-// MockProperty is a mock of Property interface.
-type MockProperty struct {
+// MockCategory is a mock of Category interface.
+type MockCategory struct {
 	ctrl     *gomock.Controller
-	recorder *MockPropertyMockRecorder
+	recorder *MockCategoryMockRecorder
 }
 
-// MockPropertyMockRecorder is the mock recorder for MockProperty.
-type MockPropertyMockRecorder struct {
-	mock *MockProperty
+// MockCategoryMockRecorder is the mock recorder for MockCategory.
+type MockCategoryMockRecorder struct {
+	mock *MockCategory
 }
 
-// NewMockProperty creates a new mock instance.
-func NewMockProperty(ctrl *gomock.Controller) *MockProperty {
-	mock := &MockProperty{ctrl: ctrl}
-	mock.recorder = &MockPropertyMockRecorder{mock}
+// NewMockCategory creates a new mock instance.
+func NewMockCategory(ctrl *gomock.Controller) *MockCategory {
+	mock := &MockCategory{ctrl: ctrl}
+	mock.recorder = &MockCategoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockProperty) EXPECT() *MockPropertyMockRecorder {
+func (m *MockCategory) EXPECT() *MockCategoryMockRecorder {
 	return m.recorder
 }
 
+// Categorize mocks base method.
+func (m *MockCategory) Categorize(transactions []model.Transaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Categorize", transactions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Categorize indicates an expected call of Categorize.
+func (mr *MockCategoryMockRecorder) Categorize(transactions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Categorize", reflect.TypeOf((*MockCategory)(nil).Categorize), transactions)
+}
+
 // Create mocks base method.
-func (m *MockProperty) Create(vehicle model.Property) error {
+func (m *MockCategory) Create(category model.Category) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", vehicle)
+	ret := m.ctrl.Call(m, "Create", category)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockPropertyMockRecorder) Create(vehicle interface{}) *gomock.Call {
+func (mr *MockCategoryMockRecorder) Create(category interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProperty)(nil).Create), vehicle)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategory)(nil).Create), category)
 }
 
-// CreateValue mocks base method.
-func (m *MockProperty) CreateValue(vehicleValue model.PropertyValue, vehicle *model.Property) error {
+// CreateRule mocks base method.
+func (m *MockCategory) CreateRule(categoryRule model.CategoryRule) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateValue", vehicleValue, vehicle)
+	ret := m.ctrl.Call(m, "CreateRule", categoryRule)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateValue indicates an expected call of CreateValue.
-func (mr *MockPropertyMockRecorder) CreateValue(vehicleValue, vehicle interface{}) *gomock.Call {
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockCategoryMockRecorder) CreateRule(categoryRule interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateValue", reflect.TypeOf((*MockProperty)(nil).CreateValue), vehicleValue, vehicle)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockCategory)(nil).CreateRule), categoryRule)
 }
 
 // ExistsByID mocks base method.
-func (m *MockProperty) ExistsByID(id uuid.UUID) (bool, error) {
+func (m *MockCategory) ExistsByID(id uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ExistsByID", id)
 	ret0, _ := ret[0].(bool)
@@ -630,151 +704,2021 @@ func (m *MockProperty) ExistsByID(id uuid.UUID) (bool, error) {
 }
 
 // ExistsByID indicates an expected call of ExistsByID.
-func (mr *MockPropertyMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+func (mr *MockCategoryMockRecorder) ExistsByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockProperty)(nil).ExistsByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockCategory)(nil).ExistsByID), id)
 }
 
-// ExistsValueByID mocks base method.
-func (m *MockProperty) ExistsValueByID(id uuid.UUID) (bool, error) {
+// ExistsRuleByID mocks base method.
+func (m *MockCategory) ExistsRuleByID(id uuid.UUID) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ExistsValueByID", id)
+	ret := m.ctrl.Call(m, "ExistsRuleByID", id)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ExistsValueByID indicates an expected call of ExistsValueByID.
-func (mr *MockPropertyMockRecorder) ExistsValueByID(id interface{}) *gomock.Call {
+// ExistsRuleByID indicates an expected call of ExistsRuleByID.
+func (mr *MockCategoryMockRecorder) ExistsRuleByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsValueByID", reflect.TypeOf((*MockProperty)(nil).ExistsValueByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsRuleByID", reflect.TypeOf((*MockCategory)(nil).ExistsRuleByID), id)
 }
 
 // ResolveByFilter mocks base method.
-func (m *MockProperty) ResolveByFilter(filter filter.Filter) ([]model.Property, model.PageInfoOutput, error) {
+func (m *MockCategory) ResolveByFilter(filter filter.Filter) ([]model.Category, model.PageInfoOutput, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
-	ret0, _ := ret[0].([]model.Property)
+	ret0, _ := ret[0].([]model.Category)
 	ret1, _ := ret[1].(model.PageInfoOutput)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // ResolveByFilter indicates an expected call of ResolveByFilter.
-func (mr *MockPropertyMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+func (mr *MockCategoryMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockProperty)(nil).ResolveByFilter), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockCategory)(nil).ResolveByFilter), filter)
 }
 
 // ResolveByIDs mocks base method.
-func (m *MockProperty) ResolveByIDs(ids []uuid.UUID) ([]model.Property, error) {
+func (m *MockCategory) ResolveByIDs(ids []uuid.UUID) ([]model.Category, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
-	ret0, _ := ret[0].([]model.Property)
+	ret0, _ := ret[0].([]model.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ResolveByIDs indicates an expected call of ResolveByIDs.
-func (mr *MockPropertyMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+func (mr *MockCategoryMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockProperty)(nil).ResolveByIDs), ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockCategory)(nil).ResolveByIDs), ids)
 }
 
-// ResolveLastValuesByPropertyID mocks base method.
-func (m *MockProperty) ResolveLastValuesByPropertyID(id uuid.UUID, count int) ([]model.PropertyValue, error) {
+// ResolveRulesByFilter mocks base method.
+func (m *MockCategory) ResolveRulesByFilter(filter filter.Filter) ([]model.CategoryRule, model.PageInfoOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveLastValuesByPropertyID", id, count)
-	ret0, _ := ret[0].([]model.PropertyValue)
+	ret := m.ctrl.Call(m, "ResolveRulesByFilter", filter)
+	ret0, _ := ret[0].([]model.CategoryRule)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveRulesByFilter indicates an expected call of ResolveRulesByFilter.
+func (mr *MockCategoryMockRecorder) ResolveRulesByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRulesByFilter", reflect.TypeOf((*MockCategory)(nil).ResolveRulesByFilter), filter)
+}
+
+// ResolveRulesByIDs mocks base method.
+func (m *MockCategory) ResolveRulesByIDs(ids []uuid.UUID) ([]model.CategoryRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveRulesByIDs", ids)
+	ret0, _ := ret[0].([]model.CategoryRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ResolveLastValuesByPropertyID indicates an expected call of ResolveLastValuesByPropertyID.
-func (mr *MockPropertyMockRecorder) ResolveLastValuesByPropertyID(id, count interface{}) *gomock.Call {
+// ResolveRulesByIDs indicates an expected call of ResolveRulesByIDs.
+func (mr *MockCategoryMockRecorder) ResolveRulesByIDs(ids interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLastValuesByPropertyID", reflect.TypeOf((*MockProperty)(nil).ResolveLastValuesByPropertyID), id, count)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveRulesByIDs", reflect.TypeOf((*MockCategory)(nil).ResolveRulesByIDs), ids)
 }
 
-// ResolveValuesByFilter mocks base method.
-func (m *MockProperty) ResolveValuesByFilter(filter filter.Filter) ([]model.PropertyValue, model.PageInfoOutput, error) {
+// Shutdown mocks base method.
+func (m *MockCategory) Shutdown() {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveValuesByFilter", filter)
-	ret0, _ := ret[0].([]model.PropertyValue)
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockCategoryMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockCategory)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockCategory) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockCategoryMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockCategory)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockCategory) Update(category model.Category) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", category)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCategoryMockRecorder) Update(category interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategory)(nil).Update), category)
+}
+
+// UpdateRule mocks base method.
+func (m *MockCategory) UpdateRule(categoryRule model.CategoryRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRule", categoryRule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockCategoryMockRecorder) UpdateRule(categoryRule interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockCategory)(nil).UpdateRule), categoryRule)
+}
+
+// MockBudget is a mock of Budget interface.
+type MockBudget struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetMockRecorder
+}
+
+// MockBudgetMockRecorder is the mock recorder for MockBudget.
+type MockBudgetMockRecorder struct {
+	mock *MockBudget
+}
+
+// NewMockBudget creates a new mock instance.
+func NewMockBudget(ctrl *gomock.Controller) *MockBudget {
+	mock := &MockBudget{ctrl: ctrl}
+	mock.recorder = &MockBudgetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudget) EXPECT() *MockBudgetMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBudget) Create(budget model.Budget) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", budget)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBudgetMockRecorder) Create(budget interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBudget)(nil).Create), budget)
+}
+
+// ExistsByID mocks base method.
+func (m *MockBudget) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockBudgetMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockBudget)(nil).ExistsByID), id)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockBudget) ResolveByFilter(filter filter.Filter) ([]model.Budget, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.Budget)
 	ret1, _ := ret[1].(model.PageInfoOutput)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// ResolveValuesByFilter indicates an expected call of ResolveValuesByFilter.
-func (mr *MockPropertyMockRecorder) ResolveValuesByFilter(filter interface{}) *gomock.Call {
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockBudgetMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveValuesByFilter", reflect.TypeOf((*MockProperty)(nil).ResolveValuesByFilter), filter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockBudget)(nil).ResolveByFilter), filter)
 }
 
-// ResolveValuesByIDs mocks base method.
-func (m *MockProperty) ResolveValuesByIDs(ids []uuid.UUID) ([]model.PropertyValue, error) {
+// ResolveByIDs mocks base method.
+func (m *MockBudget) ResolveByIDs(ids []uuid.UUID) ([]model.Budget, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveValuesByIDs", ids)
-	ret0, _ := ret[0].([]model.PropertyValue)
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.Budget)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ResolveValuesByIDs indicates an expected call of ResolveValuesByIDs.
-func (mr *MockPropertyMockRecorder) ResolveValuesByIDs(ids interface{}) *gomock.Call {
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockBudgetMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveValuesByIDs", reflect.TypeOf((*MockProperty)(nil).ResolveValuesByIDs), ids)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockBudget)(nil).ResolveByIDs), ids)
 }
 
 // Shutdown mocks base method.
-func (m *MockProperty) Shutdown() {
+func (m *MockBudget) Shutdown() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Shutdown")
 }
 
 // Shutdown indicates an expected call of Shutdown.
-func (mr *MockPropertyMockRecorder) Shutdown() *gomock.Call {
+func (mr *MockBudgetMockRecorder) Shutdown() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockProperty)(nil).Shutdown))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockBudget)(nil).Shutdown))
 }
 
 // Startup mocks base method.
-func (m *MockProperty) Startup() {
+func (m *MockBudget) Startup() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Startup")
 }
 
 // Startup indicates an expected call of Startup.
-func (mr *MockPropertyMockRecorder) Startup() *gomock.Call {
+func (mr *MockBudgetMockRecorder) Startup() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockProperty)(nil).Startup))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockBudget)(nil).Startup))
 }
 
 // Update mocks base method.
-func (m *MockProperty) Update(vehicle model.Property) error {
+func (m *MockBudget) Update(budget model.Budget) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", vehicle)
+	ret := m.ctrl.Call(m, "Update", budget)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockPropertyMockRecorder) Update(vehicle interface{}) *gomock.Call {
+func (mr *MockBudgetMockRecorder) Update(budget interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProperty)(nil).Update), vehicle)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBudget)(nil).Update), budget)
 }
 
-// UpdateValue mocks base method.
-func (m *MockProperty) UpdateValue(vehicleValue model.PropertyValue, vehicle *model.Property) error {
+// MockGoal is a mock of Goal interface.
+type MockGoal struct {
+	ctrl     *gomock.Controller
+	recorder *MockGoalMockRecorder
+}
+
+// MockGoalMockRecorder is the mock recorder for MockGoal.
+type MockGoalMockRecorder struct {
+	mock *MockGoal
+}
+
+// NewMockGoal creates a new mock instance.
+func NewMockGoal(ctrl *gomock.Controller) *MockGoal {
+	mock := &MockGoal{ctrl: ctrl}
+	mock.recorder = &MockGoalMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGoal) EXPECT() *MockGoalMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGoal) Create(goal model.Goal) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateValue", vehicleValue, vehicle)
+	ret := m.ctrl.Call(m, "Create", goal)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateValue indicates an expected call of UpdateValue.
-func (mr *MockPropertyMockRecorder) UpdateValue(vehicleValue, vehicle interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockGoalMockRecorder) Create(goal interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValue", reflect.TypeOf((*MockProperty)(nil).UpdateValue), vehicleValue, vehicle)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGoal)(nil).Create), goal)
+}
+
+// ExistsByID mocks base method.
+func (m *MockGoal) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockGoalMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockGoal)(nil).ExistsByID), id)
+}
+
+// ResolveByBankAccountID mocks base method.
+func (m *MockGoal) ResolveByBankAccountID(id uuid.UUID) ([]model.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByBankAccountID", id)
+	ret0, _ := ret[0].([]model.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByBankAccountID indicates an expected call of ResolveByBankAccountID.
+func (mr *MockGoalMockRecorder) ResolveByBankAccountID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByBankAccountID", reflect.TypeOf((*MockGoal)(nil).ResolveByBankAccountID), id)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockGoal) ResolveByFilter(filter filter.Filter) ([]model.Goal, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.Goal)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockGoalMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockGoal)(nil).ResolveByFilter), filter)
+}
+
+// ResolveByIDs mocks base method.
+func (m *MockGoal) ResolveByIDs(ids []uuid.UUID) ([]model.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockGoalMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockGoal)(nil).ResolveByIDs), ids)
+}
+
+// Shutdown mocks base method.
+func (m *MockGoal) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockGoalMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockGoal)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockGoal) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockGoalMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockGoal)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockGoal) Update(goal model.Goal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", goal)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGoalMockRecorder) Update(goal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGoal)(nil).Update), goal)
+}
+
+// MockTag is a mock of Tag interface.
+type MockTag struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagMockRecorder
+}
+
+// MockTagMockRecorder is the mock recorder for MockTag.
+type MockTagMockRecorder struct {
+	mock *MockTag
+}
+
+// NewMockTag creates a new mock instance.
+func NewMockTag(ctrl *gomock.Controller) *MockTag {
+	mock := &MockTag{ctrl: ctrl}
+	mock.recorder = &MockTagMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTag) EXPECT() *MockTagMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTag) Create(tag model.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagMockRecorder) Create(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTag)(nil).Create), tag)
+}
+
+// ExistsByID mocks base method.
+func (m *MockTag) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockTagMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockTag)(nil).ExistsByID), id)
+}
+
+// ResolveByEntity mocks base method.
+func (m *MockTag) ResolveByEntity(entityType model.EntityType, subjectID uuid.UUID) ([]model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByEntity", entityType, subjectID)
+	ret0, _ := ret[0].([]model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByEntity indicates an expected call of ResolveByEntity.
+func (mr *MockTagMockRecorder) ResolveByEntity(entityType, subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByEntity", reflect.TypeOf((*MockTag)(nil).ResolveByEntity), entityType, subjectID)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockTag) ResolveByFilter(filter filter.Filter) ([]model.Tag, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.Tag)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockTagMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockTag)(nil).ResolveByFilter), filter)
+}
+
+// ResolveByIDs mocks base method.
+func (m *MockTag) ResolveByIDs(ids []uuid.UUID) ([]model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockTagMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockTag)(nil).ResolveByIDs), ids)
+}
+
+// SetEntityTags mocks base method.
+func (m *MockTag) SetEntityTags(entityType model.EntityType, subjectID uuid.UUID, tagIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEntityTags", entityType, subjectID, tagIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEntityTags indicates an expected call of SetEntityTags.
+func (mr *MockTagMockRecorder) SetEntityTags(entityType, subjectID, tagIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEntityTags", reflect.TypeOf((*MockTag)(nil).SetEntityTags), entityType, subjectID, tagIDs)
+}
+
+// Shutdown mocks base method.
+func (m *MockTag) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockTagMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockTag)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockTag) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockTagMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockTag)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockTag) Update(tag model.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagMockRecorder) Update(tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTag)(nil).Update), tag)
+}
+
+// MockCustomField is a mock of CustomField interface.
+type MockCustomField struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldMockRecorder
+}
+
+// MockCustomFieldMockRecorder is the mock recorder for MockCustomField.
+type MockCustomFieldMockRecorder struct {
+	mock *MockCustomField
+}
+
+// NewMockCustomField creates a new mock instance.
+func NewMockCustomField(ctrl *gomock.Controller) *MockCustomField {
+	mock := &MockCustomField{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomField) EXPECT() *MockCustomFieldMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomField) Create(customField model.CustomField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", customField)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomFieldMockRecorder) Create(customField interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomField)(nil).Create), customField)
+}
+
+// ExistsByID mocks base method.
+func (m *MockCustomField) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockCustomFieldMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockCustomField)(nil).ExistsByID), id)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockCustomField) ResolveByFilter(filter filter.Filter) ([]model.CustomField, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.CustomField)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockCustomFieldMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockCustomField)(nil).ResolveByFilter), filter)
+}
+
+// ResolveByIDs mocks base method.
+func (m *MockCustomField) ResolveByIDs(ids []uuid.UUID) ([]model.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockCustomFieldMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockCustomField)(nil).ResolveByIDs), ids)
+}
+
+// ResolveValuesBySubjectID mocks base method.
+func (m *MockCustomField) ResolveValuesBySubjectID(subjectID uuid.UUID) ([]model.CustomFieldValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveValuesBySubjectID", subjectID)
+	ret0, _ := ret[0].([]model.CustomFieldValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveValuesBySubjectID indicates an expected call of ResolveValuesBySubjectID.
+func (mr *MockCustomFieldMockRecorder) ResolveValuesBySubjectID(subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveValuesBySubjectID", reflect.TypeOf((*MockCustomField)(nil).ResolveValuesBySubjectID), subjectID)
+}
+
+// SetValues mocks base method.
+func (m *MockCustomField) SetValues(entityType model.EntityType, subjectID uuid.UUID, values []model.CustomFieldValue) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetValues", entityType, subjectID, values)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetValues indicates an expected call of SetValues.
+func (mr *MockCustomFieldMockRecorder) SetValues(entityType, subjectID, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValues", reflect.TypeOf((*MockCustomField)(nil).SetValues), entityType, subjectID, values)
+}
+
+// Shutdown mocks base method.
+func (m *MockCustomField) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockCustomFieldMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockCustomField)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockCustomField) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockCustomFieldMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockCustomField)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockCustomField) Update(customField model.CustomField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", customField)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomFieldMockRecorder) Update(customField interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomField)(nil).Update), customField)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachment) Create(attachment model.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentMockRecorder) Create(attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachment)(nil).Create), attachment)
+}
+
+// ExistsByID mocks base method.
+func (m *MockAttachment) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockAttachmentMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockAttachment)(nil).ExistsByID), id)
+}
+
+// ResolveByEntity mocks base method.
+func (m *MockAttachment) ResolveByEntity(entityType model.EntityType, subjectID uuid.UUID) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByEntity", entityType, subjectID)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByEntity indicates an expected call of ResolveByEntity.
+func (mr *MockAttachmentMockRecorder) ResolveByEntity(entityType, subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByEntity", reflect.TypeOf((*MockAttachment)(nil).ResolveByEntity), entityType, subjectID)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockAttachment) ResolveByFilter(filter filter.Filter) ([]model.Attachment, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockAttachmentMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockAttachment)(nil).ResolveByFilter), filter)
+}
+
+// ResolveByIDs mocks base method.
+func (m *MockAttachment) ResolveByIDs(ids []uuid.UUID) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockAttachmentMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockAttachment)(nil).ResolveByIDs), ids)
+}
+
+// Shutdown mocks base method.
+func (m *MockAttachment) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockAttachmentMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockAttachment)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockAttachment) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockAttachmentMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockAttachment)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockAttachment) Update(attachment model.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", attachment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAttachmentMockRecorder) Update(attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAttachment)(nil).Update), attachment)
+}
+
+// MockNote is a mock of Note interface.
+type MockNote struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteMockRecorder
+}
+
+// MockNoteMockRecorder is the mock recorder for MockNote.
+type MockNoteMockRecorder struct {
+	mock *MockNote
+}
+
+// NewMockNote creates a new mock instance.
+func NewMockNote(ctrl *gomock.Controller) *MockNote {
+	mock := &MockNote{ctrl: ctrl}
+	mock.recorder = &MockNoteMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNote) EXPECT() *MockNoteMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockNote) Create(note model.Note) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNoteMockRecorder) Create(note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNote)(nil).Create), note)
+}
+
+// ExistsByID mocks base method.
+func (m *MockNote) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockNoteMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockNote)(nil).ExistsByID), id)
+}
+
+// ResolveByEntity mocks base method.
+func (m *MockNote) ResolveByEntity(entityType model.EntityType, subjectID uuid.UUID) ([]model.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByEntity", entityType, subjectID)
+	ret0, _ := ret[0].([]model.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByEntity indicates an expected call of ResolveByEntity.
+func (mr *MockNoteMockRecorder) ResolveByEntity(entityType, subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByEntity", reflect.TypeOf((*MockNote)(nil).ResolveByEntity), entityType, subjectID)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockNote) ResolveByFilter(filter filter.Filter) ([]model.Note, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.Note)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockNoteMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockNote)(nil).ResolveByFilter), filter)
+}
+
+// ResolveByIDs mocks base method.
+func (m *MockNote) ResolveByIDs(ids []uuid.UUID) ([]model.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockNoteMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockNote)(nil).ResolveByIDs), ids)
+}
+
+// ResolveBySubjectIDs mocks base method.
+func (m *MockNote) ResolveBySubjectIDs(subjectIDs []uuid.UUID) ([]model.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveBySubjectIDs", subjectIDs)
+	ret0, _ := ret[0].([]model.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveBySubjectIDs indicates an expected call of ResolveBySubjectIDs.
+func (mr *MockNoteMockRecorder) ResolveBySubjectIDs(subjectIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveBySubjectIDs", reflect.TypeOf((*MockNote)(nil).ResolveBySubjectIDs), subjectIDs)
+}
+
+// Shutdown mocks base method.
+func (m *MockNote) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockNoteMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockNote)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockNote) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockNoteMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockNote)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockNote) Update(note model.Note) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockNoteMockRecorder) Update(note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockNote)(nil).Update), note)
+}
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUser) Create(user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserMockRecorder) Create(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), user)
+}
+
+// ExistsByID mocks base method.
+func (m *MockUser) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockUserMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockUser)(nil).ExistsByID), id)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockUser) ResolveByFilter(filter filter.Filter) ([]model.User, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.User)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockUserMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockUser)(nil).ResolveByFilter), filter)
+}
+
+// ResolveByIDs mocks base method.
+func (m *MockUser) ResolveByIDs(ids []uuid.UUID) ([]model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockUserMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockUser)(nil).ResolveByIDs), ids)
+}
+
+// ResolveByIdentity mocks base method.
+func (m *MockUser) ResolveByIdentity(identity string) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIdentity", identity)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIdentity indicates an expected call of ResolveByIdentity.
+func (mr *MockUserMockRecorder) ResolveByIdentity(identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIdentity", reflect.TypeOf((*MockUser)(nil).ResolveByIdentity), identity)
+}
+
+// Shutdown mocks base method.
+func (m *MockUser) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockUserMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockUser)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockUser) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockUserMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockUser)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockUser) Update(user model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserMockRecorder) Update(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), user)
+}
+
+// MockVehicle is a mock of Vehicle interface.
+type MockVehicle struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleMockRecorder
+}
+
+// MockVehicleMockRecorder is the mock recorder for MockVehicle.
+type MockVehicleMockRecorder struct {
+	mock *MockVehicle
+}
+
+// NewMockVehicle creates a new mock instance.
+func NewMockVehicle(ctrl *gomock.Controller) *MockVehicle {
+	mock := &MockVehicle{ctrl: ctrl}
+	mock.recorder = &MockVehicleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicle) EXPECT() *MockVehicleMockRecorder {
+	return m.recorder
+}
+
+// AggregateByFilter mocks base method.
+func (m *MockVehicle) AggregateByFilter(filter filter.Filter) ([]model.AggregateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateByFilter", filter)
+	ret0, _ := ret[0].([]model.AggregateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateByFilter indicates an expected call of AggregateByFilter.
+func (mr *MockVehicleMockRecorder) AggregateByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateByFilter", reflect.TypeOf((*MockVehicle)(nil).AggregateByFilter), filter)
+}
+
+// Create mocks base method.
+func (m *MockVehicle) Create(vehicle model.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVehicleMockRecorder) Create(vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVehicle)(nil).Create), vehicle)
+}
+
+// CreateValue mocks base method.
+func (m *MockVehicle) CreateValue(vehicleValue model.VehicleValue, vehicle *model.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateValue", vehicleValue, vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateValue indicates an expected call of CreateValue.
+func (mr *MockVehicleMockRecorder) CreateValue(vehicleValue, vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateValue", reflect.TypeOf((*MockVehicle)(nil).CreateValue), vehicleValue, vehicle)
+}
+
+// ExistsByID mocks base method.
+func (m *MockVehicle) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockVehicleMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockVehicle)(nil).ExistsByID), id)
+}
+
+// ExistsValueByID mocks base method.
+func (m *MockVehicle) ExistsValueByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsValueByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsValueByID indicates an expected call of ExistsValueByID.
+func (mr *MockVehicleMockRecorder) ExistsValueByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsValueByID", reflect.TypeOf((*MockVehicle)(nil).ExistsValueByID), id)
+}
+
+// ImportValues mocks base method.
+func (m *MockVehicle) ImportValues(vehicleValues []model.VehicleValue, vehicle *model.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportValues", vehicleValues, vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportValues indicates an expected call of ImportValues.
+func (mr *MockVehicleMockRecorder) ImportValues(vehicleValues, vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportValues", reflect.TypeOf((*MockVehicle)(nil).ImportValues), vehicleValues, vehicle)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockVehicle) ResolveByFilter(filter filter.Filter) ([]model.Vehicle, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.Vehicle)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockVehicleMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockVehicle)(nil).ResolveByFilter), filter)
+}
+
+// ResolveByIDs mocks base method.
+func (m *MockVehicle) ResolveByIDs(ids []uuid.UUID) ([]model.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockVehicleMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockVehicle)(nil).ResolveByIDs), ids)
+}
+
+// ResolveLastValuesByVehicleID mocks base method.
+func (m *MockVehicle) ResolveLastValuesByVehicleID(id uuid.UUID, count int) ([]model.VehicleValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLastValuesByVehicleID", id, count)
+	ret0, _ := ret[0].([]model.VehicleValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLastValuesByVehicleID indicates an expected call of ResolveLastValuesByVehicleID.
+func (mr *MockVehicleMockRecorder) ResolveLastValuesByVehicleID(id, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLastValuesByVehicleID", reflect.TypeOf((*MockVehicle)(nil).ResolveLastValuesByVehicleID), id, count)
+}
+
+// ResolveValuesByFilter mocks base method.
+func (m *MockVehicle) ResolveValuesByFilter(filter filter.Filter) ([]model.VehicleValue, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveValuesByFilter", filter)
+	ret0, _ := ret[0].([]model.VehicleValue)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveValuesByFilter indicates an expected call of ResolveValuesByFilter.
+func (mr *MockVehicleMockRecorder) ResolveValuesByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveValuesByFilter", reflect.TypeOf((*MockVehicle)(nil).ResolveValuesByFilter), filter)
+}
+
+// ResolveValuesByIDs mocks base method.
+func (m *MockVehicle) ResolveValuesByIDs(ids []uuid.UUID) ([]model.VehicleValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveValuesByIDs", ids)
+	ret0, _ := ret[0].([]model.VehicleValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveValuesByIDs indicates an expected call of ResolveValuesByIDs.
+func (mr *MockVehicleMockRecorder) ResolveValuesByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveValuesByIDs", reflect.TypeOf((*MockVehicle)(nil).ResolveValuesByIDs), ids)
+}
+
+// Shutdown mocks base method.
+func (m *MockVehicle) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockVehicleMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockVehicle)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockVehicle) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockVehicleMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockVehicle)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockVehicle) Update(vehicle model.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVehicleMockRecorder) Update(vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicle)(nil).Update), vehicle)
+}
+
+// UpdateValue mocks base method.
+func (m *MockVehicle) UpdateValue(vehicleValue model.VehicleValue, vehicle *model.Vehicle) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateValue", vehicleValue, vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateValue indicates an expected call of UpdateValue.
+func (mr *MockVehicleMockRecorder) UpdateValue(vehicleValue, vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValue", reflect.TypeOf((*MockVehicle)(nil).UpdateValue), vehicleValue, vehicle)
+}
+
+// MockProperty is a mock of Property interface.
+type MockProperty struct {
+	ctrl     *gomock.Controller
+	recorder *MockPropertyMockRecorder
+}
+
+// MockPropertyMockRecorder is the mock recorder for MockProperty.
+type MockPropertyMockRecorder struct {
+	mock *MockProperty
+}
+
+// NewMockProperty creates a new mock instance.
+func NewMockProperty(ctrl *gomock.Controller) *MockProperty {
+	mock := &MockProperty{ctrl: ctrl}
+	mock.recorder = &MockPropertyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProperty) EXPECT() *MockPropertyMockRecorder {
+	return m.recorder
+}
+
+// AggregateByFilter mocks base method.
+func (m *MockProperty) AggregateByFilter(filter filter.Filter) ([]model.AggregateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateByFilter", filter)
+	ret0, _ := ret[0].([]model.AggregateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateByFilter indicates an expected call of AggregateByFilter.
+func (mr *MockPropertyMockRecorder) AggregateByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateByFilter", reflect.TypeOf((*MockProperty)(nil).AggregateByFilter), filter)
+}
+
+// Create mocks base method.
+func (m *MockProperty) Create(vehicle model.Property) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPropertyMockRecorder) Create(vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProperty)(nil).Create), vehicle)
+}
+
+// CreateValue mocks base method.
+func (m *MockProperty) CreateValue(vehicleValue model.PropertyValue, vehicle *model.Property) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateValue", vehicleValue, vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateValue indicates an expected call of CreateValue.
+func (mr *MockPropertyMockRecorder) CreateValue(vehicleValue, vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateValue", reflect.TypeOf((*MockProperty)(nil).CreateValue), vehicleValue, vehicle)
+}
+
+// ExistsByID mocks base method.
+func (m *MockProperty) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockPropertyMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockProperty)(nil).ExistsByID), id)
+}
+
+// ExistsValueByID mocks base method.
+func (m *MockProperty) ExistsValueByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsValueByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsValueByID indicates an expected call of ExistsValueByID.
+func (mr *MockPropertyMockRecorder) ExistsValueByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsValueByID", reflect.TypeOf((*MockProperty)(nil).ExistsValueByID), id)
+}
+
+// ImportValues mocks base method.
+func (m *MockProperty) ImportValues(propertyValues []model.PropertyValue, property *model.Property) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportValues", propertyValues, property)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportValues indicates an expected call of ImportValues.
+func (mr *MockPropertyMockRecorder) ImportValues(propertyValues, property interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportValues", reflect.TypeOf((*MockProperty)(nil).ImportValues), propertyValues, property)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockProperty) ResolveByFilter(filter filter.Filter) ([]model.Property, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.Property)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockPropertyMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockProperty)(nil).ResolveByFilter), filter)
+}
+
+// ResolveByIDs mocks base method.
+func (m *MockProperty) ResolveByIDs(ids []uuid.UUID) ([]model.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockPropertyMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockProperty)(nil).ResolveByIDs), ids)
+}
+
+// ResolveLastValuesByPropertyID mocks base method.
+func (m *MockProperty) ResolveLastValuesByPropertyID(id uuid.UUID, count int) ([]model.PropertyValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveLastValuesByPropertyID", id, count)
+	ret0, _ := ret[0].([]model.PropertyValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveLastValuesByPropertyID indicates an expected call of ResolveLastValuesByPropertyID.
+func (mr *MockPropertyMockRecorder) ResolveLastValuesByPropertyID(id, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLastValuesByPropertyID", reflect.TypeOf((*MockProperty)(nil).ResolveLastValuesByPropertyID), id, count)
+}
+
+// ResolveValuesByFilter mocks base method.
+func (m *MockProperty) ResolveValuesByFilter(filter filter.Filter) ([]model.PropertyValue, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveValuesByFilter", filter)
+	ret0, _ := ret[0].([]model.PropertyValue)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveValuesByFilter indicates an expected call of ResolveValuesByFilter.
+func (mr *MockPropertyMockRecorder) ResolveValuesByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveValuesByFilter", reflect.TypeOf((*MockProperty)(nil).ResolveValuesByFilter), filter)
+}
+
+// ResolveValuesByIDs mocks base method.
+func (m *MockProperty) ResolveValuesByIDs(ids []uuid.UUID) ([]model.PropertyValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveValuesByIDs", ids)
+	ret0, _ := ret[0].([]model.PropertyValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveValuesByIDs indicates an expected call of ResolveValuesByIDs.
+func (mr *MockPropertyMockRecorder) ResolveValuesByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveValuesByIDs", reflect.TypeOf((*MockProperty)(nil).ResolveValuesByIDs), ids)
+}
+
+// Shutdown mocks base method.
+func (m *MockProperty) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockPropertyMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockProperty)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockProperty) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockPropertyMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockProperty)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockProperty) Update(vehicle model.Property) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPropertyMockRecorder) Update(vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProperty)(nil).Update), vehicle)
+}
+
+// UpdateValue mocks base method.
+func (m *MockProperty) UpdateValue(vehicleValue model.PropertyValue, vehicle *model.Property) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateValue", vehicleValue, vehicle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateValue indicates an expected call of UpdateValue.
+func (mr *MockPropertyMockRecorder) UpdateValue(vehicleValue, vehicle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValue", reflect.TypeOf((*MockProperty)(nil).UpdateValue), vehicleValue, vehicle)
+}
+
+// MockAPIKey is a mock of APIKey interface.
+type MockAPIKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyMockRecorder
+}
+
+// MockAPIKeyMockRecorder is the mock recorder for MockAPIKey.
+type MockAPIKeyMockRecorder struct {
+	mock *MockAPIKey
+}
+
+// NewMockAPIKey creates a new mock instance.
+func NewMockAPIKey(ctrl *gomock.Controller) *MockAPIKey {
+	mock := &MockAPIKey{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKey) EXPECT() *MockAPIKeyMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKey) Create(apiKey model.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", apiKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyMockRecorder) Create(apiKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKey)(nil).Create), apiKey)
+}
+
+// ExistsByID mocks base method.
+func (m *MockAPIKey) ExistsByID(id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExistsByID", id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExistsByID indicates an expected call of ExistsByID.
+func (mr *MockAPIKeyMockRecorder) ExistsByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExistsByID", reflect.TypeOf((*MockAPIKey)(nil).ExistsByID), id)
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockAPIKey) ResolveByFilter(filter filter.Filter) ([]model.APIKey, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.APIKey)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockAPIKeyMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockAPIKey)(nil).ResolveByFilter), filter)
+}
+
+// ResolveByIDs mocks base method.
+func (m *MockAPIKey) ResolveByIDs(ids []uuid.UUID) ([]model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByIDs", ids)
+	ret0, _ := ret[0].([]model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByIDs indicates an expected call of ResolveByIDs.
+func (mr *MockAPIKeyMockRecorder) ResolveByIDs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByIDs", reflect.TypeOf((*MockAPIKey)(nil).ResolveByIDs), ids)
+}
+
+// ResolveByPrefix mocks base method.
+func (m *MockAPIKey) ResolveByPrefix(prefix string) (model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByPrefix", prefix)
+	ret0, _ := ret[0].(model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveByPrefix indicates an expected call of ResolveByPrefix.
+func (mr *MockAPIKeyMockRecorder) ResolveByPrefix(prefix interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByPrefix", reflect.TypeOf((*MockAPIKey)(nil).ResolveByPrefix), prefix)
+}
+
+// Shutdown mocks base method.
+func (m *MockAPIKey) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockAPIKeyMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockAPIKey)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockAPIKey) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockAPIKeyMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockAPIKey)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockAPIKey) Update(apiKey model.APIKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", apiKey)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAPIKeyMockRecorder) Update(apiKey interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAPIKey)(nil).Update), apiKey)
+}
+
+// UpdateLastUsed mocks base method.
+func (m *MockAPIKey) UpdateLastUsed(id uuid.UUID, lastUsed time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsed", id, lastUsed)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsed indicates an expected call of UpdateLastUsed.
+func (mr *MockAPIKeyMockRecorder) UpdateLastUsed(id, lastUsed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsed", reflect.TypeOf((*MockAPIKey)(nil).UpdateLastUsed), id, lastUsed)
+}
+
+// MockAuditLog is a mock of AuditLog interface.
+type MockAuditLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogMockRecorder
+}
+
+// MockAuditLogMockRecorder is the mock recorder for MockAuditLog.
+type MockAuditLogMockRecorder struct {
+	mock *MockAuditLog
+}
+
+// NewMockAuditLog creates a new mock instance.
+func NewMockAuditLog(ctrl *gomock.Controller) *MockAuditLog {
+	mock := &MockAuditLog{ctrl: ctrl}
+	mock.recorder = &MockAuditLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLog) EXPECT() *MockAuditLogMockRecorder {
+	return m.recorder
+}
+
+// ResolveByFilter mocks base method.
+func (m *MockAuditLog) ResolveByFilter(filter filter.Filter) ([]model.AuditLog, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveByFilter", filter)
+	ret0, _ := ret[0].([]model.AuditLog)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ResolveByFilter indicates an expected call of ResolveByFilter.
+func (mr *MockAuditLogMockRecorder) ResolveByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveByFilter", reflect.TypeOf((*MockAuditLog)(nil).ResolveByFilter), filter)
+}
+
+// Shutdown mocks base method.
+func (m *MockAuditLog) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockAuditLogMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockAuditLog)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockAuditLog) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockAuditLogMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockAuditLog)(nil).Startup))
+}
+
+// MockPurge is a mock of Purge interface.
+type MockPurge struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurgeMockRecorder
+}
+
+// MockPurgeMockRecorder is the mock recorder for MockPurge.
+type MockPurgeMockRecorder struct {
+	mock *MockPurge
+}
+
+// NewMockPurge creates a new mock instance.
+func NewMockPurge(ctrl *gomock.Controller) *MockPurge {
+	mock := &MockPurge{ctrl: ctrl}
+	mock.recorder = &MockPurgeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurge) EXPECT() *MockPurgeMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *MockPurge) Purge(summary model.PurgeSummary) (model.PurgeSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", summary)
+	ret0, _ := ret[0].(model.PurgeSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockPurgeMockRecorder) Purge(summary interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockPurge)(nil).Purge), summary)
+}
+
+// Shutdown mocks base method.
+func (m *MockPurge) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockPurgeMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockPurge)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockPurge) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockPurgeMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockPurge)(nil).Startup))
+}
+
+// MockSearch is a mock of Search interface.
+type MockSearch struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchMockRecorder
+}
+
+// MockSearchMockRecorder is the mock recorder for MockSearch.
+type MockSearchMockRecorder struct {
+	mock *MockSearch
+}
+
+// NewMockSearch creates a new mock instance.
+func NewMockSearch(ctrl *gomock.Controller) *MockSearch {
+	mock := &MockSearch{ctrl: ctrl}
+	mock.recorder = &MockSearchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearch) EXPECT() *MockSearchMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearch) Search(search model.Search) ([]model.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", search)
+	ret0, _ := ret[0].([]model.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchMockRecorder) Search(search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearch)(nil).Search), search)
+}
+
+// Shutdown mocks base method.
+func (m *MockSearch) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockSearchMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockSearch)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockSearch) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockSearchMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockSearch)(nil).Startup))
+}
+
+// MockArchive is a mock of Archive interface.
+type MockArchive struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveMockRecorder
+}
+
+// MockArchiveMockRecorder is the mock recorder for MockArchive.
+type MockArchiveMockRecorder struct {
+	mock *MockArchive
+}
+
+// NewMockArchive creates a new mock instance.
+func NewMockArchive(ctrl *gomock.Controller) *MockArchive {
+	mock := &MockArchive{ctrl: ctrl}
+	mock.recorder = &MockArchiveMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchive) EXPECT() *MockArchiveMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockArchive) Export(write func(model.Archive) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", write)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockArchiveMockRecorder) Export(write interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockArchive)(nil).Export), write)
+}
+
+// IsEmpty mocks base method.
+func (m *MockArchive) IsEmpty() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEmpty")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEmpty indicates an expected call of IsEmpty.
+func (mr *MockArchiveMockRecorder) IsEmpty() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEmpty", reflect.TypeOf((*MockArchive)(nil).IsEmpty))
+}
+
+// Restore mocks base method.
+func (m *MockArchive) Restore(archive model.Archive, result model.ArchiveRestoreResult, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", archive, result, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArchiveMockRecorder) Restore(archive, result, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArchive)(nil).Restore), archive, result, userID)
+}
+
+// Shutdown mocks base method.
+func (m *MockArchive) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockArchiveMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockArchive)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockArchive) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockArchiveMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockArchive)(nil).Startup))
 }
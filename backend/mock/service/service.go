@@ -5,6 +5,7 @@
 package mock_service
 
 import (
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -12,6 +13,7 @@ import (
 	uuid "github.com/google/uuid"
 	model "github.com/kerti/balances/backend/model"
 	cachetime "github.com/kerti/balances/backend/util/cachetime"
+	jwtkeys "github.com/kerti/balances/backend/util/jwtkeys"
 )
 
 // MockAuth is a mock of Auth interface.
@@ -67,6 +69,35 @@ func (mr *MockAuthMockRecorder) Authorize(bearer interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockAuth)(nil).Authorize), bearer)
 }
 
+// AuthorizeAPIKey mocks base method.
+func (m *MockAuth) AuthorizeAPIKey(key string, scope model.APIKeyScope) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeAPIKey", key, scope)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthorizeAPIKey indicates an expected call of AuthorizeAPIKey.
+func (mr *MockAuthMockRecorder) AuthorizeAPIKey(key, scope interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeAPIKey", reflect.TypeOf((*MockAuth)(nil).AuthorizeAPIKey), key, scope)
+}
+
+// GetJWKS mocks base method.
+func (m *MockAuth) GetJWKS() jwtkeys.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS")
+	ret0, _ := ret[0].(jwtkeys.JSONWebKeySet)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockAuthMockRecorder) GetJWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockAuth)(nil).GetJWKS))
+}
+
 // GetToken mocks base method.
 func (m *MockAuth) GetToken(user model.User) (*string, *time.Time, error) {
 	m.ctrl.T.Helper()
@@ -130,6 +161,21 @@ func (m *MockBankAccount) EXPECT() *MockBankAccountMockRecorder {
 	return m.recorder
 }
 
+// AggregateByFilter mocks base method.
+func (m *MockBankAccount) AggregateByFilter(input model.BankAccountFilterInput) ([]model.AggregateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateByFilter", input)
+	ret0, _ := ret[0].([]model.AggregateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateByFilter indicates an expected call of AggregateByFilter.
+func (mr *MockBankAccountMockRecorder) AggregateByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateByFilter", reflect.TypeOf((*MockBankAccount)(nil).AggregateByFilter), input)
+}
+
 // Create mocks base method.
 func (m *MockBankAccount) Create(input model.BankAccountInput, userID uuid.UUID) (*model.BankAccount, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +206,21 @@ func (mr *MockBankAccountMockRecorder) CreateBalance(input, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBalance", reflect.TypeOf((*MockBankAccount)(nil).CreateBalance), input, userID)
 }
 
+// CreateCashFlow mocks base method.
+func (m *MockBankAccount) CreateCashFlow(input model.BankAccountCashFlowInput, userID uuid.UUID) (*model.BankAccountCashFlow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCashFlow", input, userID)
+	ret0, _ := ret[0].(*model.BankAccountCashFlow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCashFlow indicates an expected call of CreateCashFlow.
+func (mr *MockBankAccountMockRecorder) CreateCashFlow(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCashFlow", reflect.TypeOf((*MockBankAccount)(nil).CreateCashFlow), input, userID)
+}
+
 // Delete mocks base method.
 func (m *MockBankAccount) Delete(id, userID uuid.UUID) (*model.BankAccount, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +251,21 @@ func (mr *MockBankAccountMockRecorder) DeleteBalance(id, userID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBalance", reflect.TypeOf((*MockBankAccount)(nil).DeleteBalance), id, userID)
 }
 
+// DeleteCashFlow mocks base method.
+func (m *MockBankAccount) DeleteCashFlow(id, userID uuid.UUID) (*model.BankAccountCashFlow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCashFlow", id, userID)
+	ret0, _ := ret[0].(*model.BankAccountCashFlow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCashFlow indicates an expected call of DeleteCashFlow.
+func (mr *MockBankAccountMockRecorder) DeleteCashFlow(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCashFlow", reflect.TypeOf((*MockBankAccount)(nil).DeleteCashFlow), id, userID)
+}
+
 // GetBalanceByID mocks base method.
 func (m *MockBankAccount) GetBalanceByID(id uuid.UUID) (*model.BankAccountBalance, error) {
 	m.ctrl.T.Helper()
@@ -252,6 +328,97 @@ func (mr *MockBankAccountMockRecorder) GetByID(id, withBalances, balanceStartDat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBankAccount)(nil).GetByID), id, withBalances, balanceStartDate, balanceEndDate, pageSize)
 }
 
+// GetCashFlowByID mocks base method.
+func (m *MockBankAccount) GetCashFlowByID(id uuid.UUID) (*model.BankAccountCashFlow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashFlowByID", id)
+	ret0, _ := ret[0].(*model.BankAccountCashFlow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCashFlowByID indicates an expected call of GetCashFlowByID.
+func (mr *MockBankAccountMockRecorder) GetCashFlowByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashFlowByID", reflect.TypeOf((*MockBankAccount)(nil).GetCashFlowByID), id)
+}
+
+// GetCashFlowsByFilter mocks base method.
+func (m *MockBankAccount) GetCashFlowsByFilter(input model.BankAccountCashFlowFilterInput) ([]model.BankAccountCashFlow, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCashFlowsByFilter", input)
+	ret0, _ := ret[0].([]model.BankAccountCashFlow)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCashFlowsByFilter indicates an expected call of GetCashFlowsByFilter.
+func (mr *MockBankAccountMockRecorder) GetCashFlowsByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCashFlowsByFilter", reflect.TypeOf((*MockBankAccount)(nil).GetCashFlowsByFilter), input)
+}
+
+// GetReturns mocks base method.
+func (m *MockBankAccount) GetReturns(id uuid.UUID, start, end time.Time) (*model.BankAccountReturns, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReturns", id, start, end)
+	ret0, _ := ret[0].(*model.BankAccountReturns)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReturns indicates an expected call of GetReturns.
+func (mr *MockBankAccountMockRecorder) GetReturns(id, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReturns", reflect.TypeOf((*MockBankAccount)(nil).GetReturns), id, start, end)
+}
+
+// ImportBalances mocks base method.
+func (m *MockBankAccount) ImportBalances(input model.BankAccountBalanceImportInput, userID uuid.UUID) (*model.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBalances", input, userID)
+	ret0, _ := ret[0].(*model.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBalances indicates an expected call of ImportBalances.
+func (mr *MockBankAccountMockRecorder) ImportBalances(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBalances", reflect.TypeOf((*MockBankAccount)(nil).ImportBalances), input, userID)
+}
+
+// ImportStatement mocks base method.
+func (m *MockBankAccount) ImportStatement(input model.StatementImportInput, userID uuid.UUID) (*model.StatementImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportStatement", input, userID)
+	ret0, _ := ret[0].(*model.StatementImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportStatement indicates an expected call of ImportStatement.
+func (mr *MockBankAccountMockRecorder) ImportStatement(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportStatement", reflect.TypeOf((*MockBankAccount)(nil).ImportStatement), input, userID)
+}
+
+// Restore mocks base method.
+func (m *MockBankAccount) Restore(id, userID uuid.UUID) (*model.BankAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id, userID)
+	ret0, _ := ret[0].(*model.BankAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBankAccountMockRecorder) Restore(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBankAccount)(nil).Restore), id, userID)
+}
+
 // Shutdown mocks base method.
 func (m *MockBankAccount) Shutdown() {
 	m.ctrl.T.Helper()
@@ -306,508 +473,2410 @@ func (mr *MockBankAccountMockRecorder) UpdateBalance(input, userID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockBankAccount)(nil).UpdateBalance), input, userID)
 }
 
-// MockUser is a mock of User interface.
-type MockUser struct {
+// UpdateCashFlow mocks base method.
+func (m *MockBankAccount) UpdateCashFlow(input model.BankAccountCashFlowInput, userID uuid.UUID) (*model.BankAccountCashFlow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCashFlow", input, userID)
+	ret0, _ := ret[0].(*model.BankAccountCashFlow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCashFlow indicates an expected call of UpdateCashFlow.
+func (mr *MockBankAccountMockRecorder) UpdateCashFlow(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCashFlow", reflect.TypeOf((*MockBankAccount)(nil).UpdateCashFlow), input, userID)
+}
+
+// MockTransaction is a mock of Transaction interface.
+type MockTransaction struct {
 	ctrl     *gomock.Controller
-	recorder *MockUserMockRecorder
+	recorder *MockTransactionMockRecorder
 }
 
-// MockUserMockRecorder is the mock recorder for MockUser.
-type MockUserMockRecorder struct {
-	mock *MockUser
+// MockTransactionMockRecorder is the mock recorder for MockTransaction.
+type MockTransactionMockRecorder struct {
+	mock *MockTransaction
 }
 
-// NewMockUser creates a new mock instance.
-func NewMockUser(ctrl *gomock.Controller) *MockUser {
-	mock := &MockUser{ctrl: ctrl}
-	mock.recorder = &MockUserMockRecorder{mock}
+// NewMockTransaction creates a new mock instance.
+func NewMockTransaction(ctrl *gomock.Controller) *MockTransaction {
+	mock := &MockTransaction{ctrl: ctrl}
+	mock.recorder = &MockTransactionMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUser) EXPECT() *MockUserMockRecorder {
+func (m *MockTransaction) EXPECT() *MockTransactionMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *MockUser) Create(input model.UserInput, userID uuid.UUID) (*model.User, error) {
+func (m *MockTransaction) Create(input model.TransactionInput, userID uuid.UUID) (*model.Transaction, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", input, userID)
-	ret0, _ := ret[0].(*model.User)
+	ret0, _ := ret[0].(*model.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockUserMockRecorder) Create(input, userID interface{}) *gomock.Call {
+func (mr *MockTransactionMockRecorder) Create(input, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), input, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransaction)(nil).Create), input, userID)
+}
+
+// Delete mocks base method.
+func (m *MockTransaction) Delete(id, userID uuid.UUID) (*model.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTransactionMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTransaction)(nil).Delete), id, userID)
 }
 
 // GetByFilter mocks base method.
-func (m *MockUser) GetByFilter(input model.UserFilterInput) ([]model.User, model.PageInfoOutput, error) {
+func (m *MockTransaction) GetByFilter(input model.TransactionFilterInput) ([]model.Transaction, model.PageInfoOutput, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByFilter", input)
-	ret0, _ := ret[0].([]model.User)
+	ret0, _ := ret[0].([]model.Transaction)
 	ret1, _ := ret[1].(model.PageInfoOutput)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetByFilter indicates an expected call of GetByFilter.
-func (mr *MockUserMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+func (mr *MockTransactionMockRecorder) GetByFilter(input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockUser)(nil).GetByFilter), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockTransaction)(nil).GetByFilter), input)
 }
 
 // GetByID mocks base method.
-func (m *MockUser) GetByID(id uuid.UUID) (*model.User, error) {
+func (m *MockTransaction) GetByID(id uuid.UUID) (*model.Transaction, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByID", id)
-	ret0, _ := ret[0].(*model.User)
+	ret0, _ := ret[0].(*model.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockUserMockRecorder) GetByID(id interface{}) *gomock.Call {
+func (mr *MockTransactionMockRecorder) GetByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUser)(nil).GetByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTransaction)(nil).GetByID), id)
+}
+
+// Reconcile mocks base method.
+func (m *MockTransaction) Reconcile(bankAccountID uuid.UUID, start, end time.Time) (*model.Reconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", bankAccountID, start, end)
+	ret0, _ := ret[0].(*model.Reconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockTransactionMockRecorder) Reconcile(bankAccountID, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockTransaction)(nil).Reconcile), bankAccountID, start, end)
 }
 
 // Shutdown mocks base method.
-func (m *MockUser) Shutdown() {
+func (m *MockTransaction) Shutdown() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Shutdown")
 }
 
 // Shutdown indicates an expected call of Shutdown.
-func (mr *MockUserMockRecorder) Shutdown() *gomock.Call {
+func (mr *MockTransactionMockRecorder) Shutdown() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockUser)(nil).Shutdown))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockTransaction)(nil).Shutdown))
 }
 
 // Startup mocks base method.
-func (m *MockUser) Startup() {
+func (m *MockTransaction) Startup() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Startup")
 }
 
 // Startup indicates an expected call of Startup.
-func (mr *MockUserMockRecorder) Startup() *gomock.Call {
+func (mr *MockTransactionMockRecorder) Startup() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockUser)(nil).Startup))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockTransaction)(nil).Startup))
 }
 
 // Update mocks base method.
-func (m *MockUser) Update(input model.UserInput, userID uuid.UUID) (*model.User, error) {
+func (m *MockTransaction) Update(input model.TransactionInput, userID uuid.UUID) (*model.Transaction, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", input, userID)
-	ret0, _ := ret[0].(*model.User)
+	ret0, _ := ret[0].(*model.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockUserMockRecorder) Update(input, userID interface{}) *gomock.Call {
+func (mr *MockTransactionMockRecorder) Update(input, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), input, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTransaction)(nil).Update), input, userID)
 }
 
-// MockVehicle is a mock of Vehicle interface.
-type MockVehicle struct {
+// MockTransfer is a mock of Transfer interface.
+type MockTransfer struct {
 	ctrl     *gomock.Controller
-	recorder *MockVehicleMockRecorder
+	recorder *MockTransferMockRecorder
 }
 
-// MockVehicleMockRecorder is the mock recorder for MockVehicle.
-type MockVehicleMockRecorder struct {
-	mock *MockVehicle
+// MockTransferMockRecorder is the mock recorder for MockTransfer.
+type MockTransferMockRecorder struct {
+	mock *MockTransfer
 }
 
-// NewMockVehicle creates a new mock instance.
-func NewMockVehicle(ctrl *gomock.Controller) *MockVehicle {
-	mock := &MockVehicle{ctrl: ctrl}
-	mock.recorder = &MockVehicleMockRecorder{mock}
+// NewMockTransfer creates a new mock instance.
+func NewMockTransfer(ctrl *gomock.Controller) *MockTransfer {
+	mock := &MockTransfer{ctrl: ctrl}
+	mock.recorder = &MockTransferMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockVehicle) EXPECT() *MockVehicleMockRecorder {
+func (m *MockTransfer) EXPECT() *MockTransferMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *MockVehicle) Create(input model.VehicleInput, userID uuid.UUID) (*model.Vehicle, error) {
+func (m *MockTransfer) Create(input model.TransferInput, userID uuid.UUID) (*model.Transfer, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Create", input, userID)
-	ret0, _ := ret[0].(*model.Vehicle)
+	ret0, _ := ret[0].(*model.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockVehicleMockRecorder) Create(input, userID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVehicle)(nil).Create), input, userID)
-}
-
-// CreateValue mocks base method.
-func (m *MockVehicle) CreateValue(input model.VehicleValueInput, userID uuid.UUID) (*model.VehicleValue, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateValue", input, userID)
-	ret0, _ := ret[0].(*model.VehicleValue)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// CreateValue indicates an expected call of CreateValue.
-func (mr *MockVehicleMockRecorder) CreateValue(input, userID interface{}) *gomock.Call {
+func (mr *MockTransferMockRecorder) Create(input, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateValue", reflect.TypeOf((*MockVehicle)(nil).CreateValue), input, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransfer)(nil).Create), input, userID)
 }
 
 // Delete mocks base method.
-func (m *MockVehicle) Delete(id, userID uuid.UUID) (*model.Vehicle, error) {
+func (m *MockTransfer) Delete(id, userID uuid.UUID) (*model.Transfer, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", id, userID)
-	ret0, _ := ret[0].(*model.Vehicle)
+	ret0, _ := ret[0].(*model.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockVehicleMockRecorder) Delete(id, userID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicle)(nil).Delete), id, userID)
-}
-
-// DeleteValue mocks base method.
-func (m *MockVehicle) DeleteValue(id, userID uuid.UUID) (*model.VehicleValue, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteValue", id, userID)
-	ret0, _ := ret[0].(*model.VehicleValue)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// DeleteValue indicates an expected call of DeleteValue.
-func (mr *MockVehicleMockRecorder) DeleteValue(id, userID interface{}) *gomock.Call {
+func (mr *MockTransferMockRecorder) Delete(id, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteValue", reflect.TypeOf((*MockVehicle)(nil).DeleteValue), id, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTransfer)(nil).Delete), id, userID)
 }
 
 // GetByFilter mocks base method.
-func (m *MockVehicle) GetByFilter(input model.VehicleFilterInput) ([]model.Vehicle, model.PageInfoOutput, error) {
+func (m *MockTransfer) GetByFilter(input model.TransferFilterInput) ([]model.Transfer, model.PageInfoOutput, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetByFilter", input)
-	ret0, _ := ret[0].([]model.Vehicle)
+	ret0, _ := ret[0].([]model.Transfer)
 	ret1, _ := ret[1].(model.PageInfoOutput)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetByFilter indicates an expected call of GetByFilter.
-func (mr *MockVehicleMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+func (mr *MockTransferMockRecorder) GetByFilter(input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockVehicle)(nil).GetByFilter), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockTransfer)(nil).GetByFilter), input)
 }
 
 // GetByID mocks base method.
-func (m *MockVehicle) GetByID(id uuid.UUID, withValues bool, valueStartDate, valueEndDate cachetime.NCacheTime, pageSize *int) (*model.Vehicle, error) {
+func (m *MockTransfer) GetByID(id uuid.UUID) (*model.Transfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", id, withValues, valueStartDate, valueEndDate, pageSize)
-	ret0, _ := ret[0].(*model.Vehicle)
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockVehicleMockRecorder) GetByID(id, withValues, valueStartDate, valueEndDate, pageSize interface{}) *gomock.Call {
+func (mr *MockTransferMockRecorder) GetByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVehicle)(nil).GetByID), id, withValues, valueStartDate, valueEndDate, pageSize)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTransfer)(nil).GetByID), id)
 }
 
-// GetValueByID mocks base method.
-func (m *MockVehicle) GetValueByID(id uuid.UUID) (*model.VehicleValue, error) {
+// Shutdown mocks base method.
+func (m *MockTransfer) Shutdown() {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetValueByID", id)
-	ret0, _ := ret[0].(*model.VehicleValue)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	m.ctrl.Call(m, "Shutdown")
 }
 
-// GetValueByID indicates an expected call of GetValueByID.
-func (mr *MockVehicleMockRecorder) GetValueByID(id interface{}) *gomock.Call {
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockTransferMockRecorder) Shutdown() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValueByID", reflect.TypeOf((*MockVehicle)(nil).GetValueByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockTransfer)(nil).Shutdown))
 }
 
-// GetValuesByFilter mocks base method.
-func (m *MockVehicle) GetValuesByFilter(input model.VehicleValueFilterInput) ([]model.VehicleValue, model.PageInfoOutput, error) {
+// Startup mocks base method.
+func (m *MockTransfer) Startup() {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetValuesByFilter", input)
-	ret0, _ := ret[0].([]model.VehicleValue)
-	ret1, _ := ret[1].(model.PageInfoOutput)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	m.ctrl.Call(m, "Startup")
 }
 
-// GetValuesByFilter indicates an expected call of GetValuesByFilter.
-func (mr *MockVehicleMockRecorder) GetValuesByFilter(input interface{}) *gomock.Call {
+// Startup indicates an expected call of Startup.
+func (mr *MockTransferMockRecorder) Startup() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuesByFilter", reflect.TypeOf((*MockVehicle)(nil).GetValuesByFilter), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockTransfer)(nil).Startup))
 }
 
-// Shutdown mocks base method.
-func (m *MockVehicle) Shutdown() {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Shutdown")
+// MockCategory is a mock of Category interface.
+type MockCategory struct {
+	ctrl     *gomock.Controller
+	recorder *MockCategoryMockRecorder
 }
 
-// Shutdown indicates an expected call of Shutdown.
-func (mr *MockVehicleMockRecorder) Shutdown() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockVehicle)(nil).Shutdown))
+// MockCategoryMockRecorder is the mock recorder for MockCategory.
+type MockCategoryMockRecorder struct {
+	mock *MockCategory
 }
 
-// Startup mocks base method.
-func (m *MockVehicle) Startup() {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Startup")
+// NewMockCategory creates a new mock instance.
+func NewMockCategory(ctrl *gomock.Controller) *MockCategory {
+	mock := &MockCategory{ctrl: ctrl}
+	mock.recorder = &MockCategoryMockRecorder{mock}
+	return mock
 }
 
-// Startup indicates an expected call of Startup.
-func (mr *MockVehicleMockRecorder) Startup() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockVehicle)(nil).Startup))
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCategory) EXPECT() *MockCategoryMockRecorder {
+	return m.recorder
 }
 
-// Update mocks base method.
-func (m *MockVehicle) Update(input model.VehicleInput, userID uuid.UUID) (*model.Vehicle, error) {
+// ApplyRules mocks base method.
+func (m *MockCategory) ApplyRules(userID uuid.UUID) ([]model.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", input, userID)
-	ret0, _ := ret[0].(*model.Vehicle)
+	ret := m.ctrl.Call(m, "ApplyRules", userID)
+	ret0, _ := ret[0].([]model.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockVehicleMockRecorder) Update(input, userID interface{}) *gomock.Call {
+// ApplyRules indicates an expected call of ApplyRules.
+func (mr *MockCategoryMockRecorder) ApplyRules(userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicle)(nil).Update), input, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyRules", reflect.TypeOf((*MockCategory)(nil).ApplyRules), userID)
 }
 
-// UpdateValue mocks base method.
-func (m *MockVehicle) UpdateValue(input model.VehicleValueInput, userID uuid.UUID) (*model.VehicleValue, error) {
+// Create mocks base method.
+func (m *MockCategory) Create(input model.CategoryInput, userID uuid.UUID) (*model.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateValue", input, userID)
-	ret0, _ := ret[0].(*model.VehicleValue)
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateValue indicates an expected call of UpdateValue.
-func (mr *MockVehicleMockRecorder) UpdateValue(input, userID interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockCategoryMockRecorder) Create(input, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValue", reflect.TypeOf((*MockVehicle)(nil).UpdateValue), input, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCategory)(nil).Create), input, userID)
 }
 
-// MockProperty is a mock of Property interface.
-type MockProperty struct {
-	ctrl     *gomock.Controller
-	recorder *MockPropertyMockRecorder
+// CreateRule mocks base method.
+func (m *MockCategory) CreateRule(input model.CategoryRuleInput, userID uuid.UUID) (*model.CategoryRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRule", input, userID)
+	ret0, _ := ret[0].(*model.CategoryRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockPropertyMockRecorder is the mock recorder for MockProperty.
-type MockPropertyMockRecorder struct {
-	mock *MockProperty
+// CreateRule indicates an expected call of CreateRule.
+func (mr *MockCategoryMockRecorder) CreateRule(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRule", reflect.TypeOf((*MockCategory)(nil).CreateRule), input, userID)
 }
 
-// NewMockProperty creates a new mock instance.
-func NewMockProperty(ctrl *gomock.Controller) *MockProperty {
-	mock := &MockProperty{ctrl: ctrl}
-	mock.recorder = &MockPropertyMockRecorder{mock}
-	return mock
+// Delete mocks base method.
+func (m *MockCategory) Delete(id, userID uuid.UUID) (*model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockProperty) EXPECT() *MockPropertyMockRecorder {
-	return m.recorder
+// Delete indicates an expected call of Delete.
+func (mr *MockCategoryMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCategory)(nil).Delete), id, userID)
 }
 
-// Create mocks base method.
-func (m *MockProperty) Create(input model.PropertyInput, userID uuid.UUID) (*model.Property, error) {
+// DeleteRule mocks base method.
+func (m *MockCategory) DeleteRule(id, userID uuid.UUID) (*model.CategoryRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", input, userID)
-	ret0, _ := ret[0].(*model.Property)
+	ret := m.ctrl.Call(m, "DeleteRule", id, userID)
+	ret0, _ := ret[0].(*model.CategoryRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Create indicates an expected call of Create.
-func (mr *MockPropertyMockRecorder) Create(input, userID interface{}) *gomock.Call {
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockCategoryMockRecorder) DeleteRule(id, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProperty)(nil).Create), input, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockCategory)(nil).DeleteRule), id, userID)
 }
 
-// CreateValue mocks base method.
-func (m *MockProperty) CreateValue(input model.PropertyValueInput, userID uuid.UUID) (*model.PropertyValue, error) {
+// GetByFilter mocks base method.
+func (m *MockCategory) GetByFilter(input model.CategoryFilterInput) ([]model.Category, model.PageInfoOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateValue", input, userID)
-	ret0, _ := ret[0].(*model.PropertyValue)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.Category)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// CreateValue indicates an expected call of CreateValue.
-func (mr *MockPropertyMockRecorder) CreateValue(input, userID interface{}) *gomock.Call {
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockCategoryMockRecorder) GetByFilter(input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateValue", reflect.TypeOf((*MockProperty)(nil).CreateValue), input, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockCategory)(nil).GetByFilter), input)
 }
 
-// Delete mocks base method.
-func (m *MockProperty) Delete(id, userID uuid.UUID) (*model.Property, error) {
+// GetByID mocks base method.
+func (m *MockCategory) GetByID(id uuid.UUID) (*model.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id, userID)
-	ret0, _ := ret[0].(*model.Property)
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockPropertyMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCategoryMockRecorder) GetByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProperty)(nil).Delete), id, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCategory)(nil).GetByID), id)
 }
 
-// DeleteValue mocks base method.
-func (m *MockProperty) DeleteValue(id, userID uuid.UUID) (*model.PropertyValue, error) {
+// GetRuleByID mocks base method.
+func (m *MockCategory) GetRuleByID(id uuid.UUID) (*model.CategoryRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteValue", id, userID)
-	ret0, _ := ret[0].(*model.PropertyValue)
+	ret := m.ctrl.Call(m, "GetRuleByID", id)
+	ret0, _ := ret[0].(*model.CategoryRule)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteValue indicates an expected call of DeleteValue.
-func (mr *MockPropertyMockRecorder) DeleteValue(id, userID interface{}) *gomock.Call {
+// GetRuleByID indicates an expected call of GetRuleByID.
+func (mr *MockCategoryMockRecorder) GetRuleByID(id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteValue", reflect.TypeOf((*MockProperty)(nil).DeleteValue), id, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRuleByID", reflect.TypeOf((*MockCategory)(nil).GetRuleByID), id)
 }
 
-// GetByFilter mocks base method.
-func (m *MockProperty) GetByFilter(input model.PropertyFilterInput) ([]model.Property, model.PageInfoOutput, error) {
+// GetRulesByFilter mocks base method.
+func (m *MockCategory) GetRulesByFilter(input model.CategoryRuleFilterInput) ([]model.CategoryRule, model.PageInfoOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByFilter", input)
-	ret0, _ := ret[0].([]model.Property)
+	ret := m.ctrl.Call(m, "GetRulesByFilter", input)
+	ret0, _ := ret[0].([]model.CategoryRule)
 	ret1, _ := ret[1].(model.PageInfoOutput)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// GetByFilter indicates an expected call of GetByFilter.
-func (mr *MockPropertyMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+// GetRulesByFilter indicates an expected call of GetRulesByFilter.
+func (mr *MockCategoryMockRecorder) GetRulesByFilter(input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockProperty)(nil).GetByFilter), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRulesByFilter", reflect.TypeOf((*MockCategory)(nil).GetRulesByFilter), input)
 }
 
-// GetByID mocks base method.
-func (m *MockProperty) GetByID(id uuid.UUID, withValues bool, valueStartDate, valueEndDate cachetime.NCacheTime, pageSize *int) (*model.Property, error) {
+// GetTree mocks base method.
+func (m *MockCategory) GetTree() ([]model.CategoryTreeOutput, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", id, withValues, valueStartDate, valueEndDate, pageSize)
-	ret0, _ := ret[0].(*model.Property)
+	ret := m.ctrl.Call(m, "GetTree")
+	ret0, _ := ret[0].([]model.CategoryTreeOutput)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByID indicates an expected call of GetByID.
-func (mr *MockPropertyMockRecorder) GetByID(id, withValues, valueStartDate, valueEndDate, pageSize interface{}) *gomock.Call {
+// GetTree indicates an expected call of GetTree.
+func (mr *MockCategoryMockRecorder) GetTree() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProperty)(nil).GetByID), id, withValues, valueStartDate, valueEndDate, pageSize)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTree", reflect.TypeOf((*MockCategory)(nil).GetTree))
 }
 
-// GetValueByID mocks base method.
-func (m *MockProperty) GetValueByID(id uuid.UUID) (*model.PropertyValue, error) {
+// Shutdown mocks base method.
+func (m *MockCategory) Shutdown() {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetValueByID", id)
-	ret0, _ := ret[0].(*model.PropertyValue)
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockCategoryMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockCategory)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockCategory) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockCategoryMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockCategory)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockCategory) Update(input model.CategoryInput, userID uuid.UUID) (*model.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", input, userID)
+	ret0, _ := ret[0].(*model.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetValueByID indicates an expected call of GetValueByID.
-func (mr *MockPropertyMockRecorder) GetValueByID(id interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockCategoryMockRecorder) Update(input, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValueByID", reflect.TypeOf((*MockProperty)(nil).GetValueByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCategory)(nil).Update), input, userID)
 }
 
-// GetValuesByFilter mocks base method.
-func (m *MockProperty) GetValuesByFilter(input model.PropertyValueFilterInput) ([]model.PropertyValue, model.PageInfoOutput, error) {
+// UpdateRule mocks base method.
+func (m *MockCategory) UpdateRule(input model.CategoryRuleInput, userID uuid.UUID) (*model.CategoryRule, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetValuesByFilter", input)
-	ret0, _ := ret[0].([]model.PropertyValue)
+	ret := m.ctrl.Call(m, "UpdateRule", input, userID)
+	ret0, _ := ret[0].(*model.CategoryRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRule indicates an expected call of UpdateRule.
+func (mr *MockCategoryMockRecorder) UpdateRule(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRule", reflect.TypeOf((*MockCategory)(nil).UpdateRule), input, userID)
+}
+
+// MockBudget is a mock of Budget interface.
+type MockBudget struct {
+	ctrl     *gomock.Controller
+	recorder *MockBudgetMockRecorder
+}
+
+// MockBudgetMockRecorder is the mock recorder for MockBudget.
+type MockBudgetMockRecorder struct {
+	mock *MockBudget
+}
+
+// NewMockBudget creates a new mock instance.
+func NewMockBudget(ctrl *gomock.Controller) *MockBudget {
+	mock := &MockBudget{ctrl: ctrl}
+	mock.recorder = &MockBudgetMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBudget) EXPECT() *MockBudgetMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockBudget) Create(input model.BudgetInput, userID uuid.UUID) (*model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockBudgetMockRecorder) Create(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBudget)(nil).Create), input, userID)
+}
+
+// Delete mocks base method.
+func (m *MockBudget) Delete(id, userID uuid.UUID) (*model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBudgetMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBudget)(nil).Delete), id, userID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockBudget) GetByFilter(input model.BudgetFilterInput) ([]model.Budget, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.Budget)
 	ret1, _ := ret[1].(model.PageInfoOutput)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// GetValuesByFilter indicates an expected call of GetValuesByFilter.
-func (mr *MockPropertyMockRecorder) GetValuesByFilter(input interface{}) *gomock.Call {
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockBudgetMockRecorder) GetByFilter(input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuesByFilter", reflect.TypeOf((*MockProperty)(nil).GetValuesByFilter), input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockBudget)(nil).GetByFilter), input)
+}
+
+// GetByID mocks base method.
+func (m *MockBudget) GetByID(id uuid.UUID) (*model.Budget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Budget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockBudgetMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockBudget)(nil).GetByID), id)
+}
+
+// GetReport mocks base method.
+func (m *MockBudget) GetReport(month string) (*model.BudgetReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", month)
+	ret0, _ := ret[0].(*model.BudgetReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockBudgetMockRecorder) GetReport(month interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockBudget)(nil).GetReport), month)
 }
 
 // Shutdown mocks base method.
-func (m *MockProperty) Shutdown() {
+func (m *MockBudget) Shutdown() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Shutdown")
 }
 
 // Shutdown indicates an expected call of Shutdown.
-func (mr *MockPropertyMockRecorder) Shutdown() *gomock.Call {
+func (mr *MockBudgetMockRecorder) Shutdown() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockProperty)(nil).Shutdown))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockBudget)(nil).Shutdown))
 }
 
 // Startup mocks base method.
-func (m *MockProperty) Startup() {
+func (m *MockBudget) Startup() {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "Startup")
 }
 
 // Startup indicates an expected call of Startup.
-func (mr *MockPropertyMockRecorder) Startup() *gomock.Call {
+func (mr *MockBudgetMockRecorder) Startup() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockProperty)(nil).Startup))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockBudget)(nil).Startup))
 }
 
 // Update mocks base method.
-func (m *MockProperty) Update(input model.PropertyInput, userID uuid.UUID) (*model.Property, error) {
+func (m *MockBudget) Update(input model.BudgetInput, userID uuid.UUID) (*model.Budget, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Update", input, userID)
-	ret0, _ := ret[0].(*model.Property)
+	ret0, _ := ret[0].(*model.Budget)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockPropertyMockRecorder) Update(input, userID interface{}) *gomock.Call {
+func (mr *MockBudgetMockRecorder) Update(input, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProperty)(nil).Update), input, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBudget)(nil).Update), input, userID)
 }
 
-// UpdateValue mocks base method.
-func (m *MockProperty) UpdateValue(input model.PropertyValueInput, userID uuid.UUID) (*model.PropertyValue, error) {
+// MockGoal is a mock of Goal interface.
+type MockGoal struct {
+	ctrl     *gomock.Controller
+	recorder *MockGoalMockRecorder
+}
+
+// MockGoalMockRecorder is the mock recorder for MockGoal.
+type MockGoalMockRecorder struct {
+	mock *MockGoal
+}
+
+// NewMockGoal creates a new mock instance.
+func NewMockGoal(ctrl *gomock.Controller) *MockGoal {
+	mock := &MockGoal{ctrl: ctrl}
+	mock.recorder = &MockGoalMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGoal) EXPECT() *MockGoalMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockGoal) Create(input model.GoalInput, userID uuid.UUID) (*model.Goal, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateValue", input, userID)
-	ret0, _ := ret[0].(*model.PropertyValue)
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.Goal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateValue indicates an expected call of UpdateValue.
-func (mr *MockPropertyMockRecorder) UpdateValue(input, userID interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockGoalMockRecorder) Create(input, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValue", reflect.TypeOf((*MockProperty)(nil).UpdateValue), input, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockGoal)(nil).Create), input, userID)
+}
+
+// Delete mocks base method.
+func (m *MockGoal) Delete(id, userID uuid.UUID) (*model.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockGoalMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockGoal)(nil).Delete), id, userID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockGoal) GetByFilter(input model.GoalFilterInput) ([]model.Goal, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.Goal)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockGoalMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockGoal)(nil).GetByFilter), input)
+}
+
+// GetByID mocks base method.
+func (m *MockGoal) GetByID(id uuid.UUID) (*model.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockGoalMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockGoal)(nil).GetByID), id)
+}
+
+// GetProgress mocks base method.
+func (m *MockGoal) GetProgress(id uuid.UUID) (*model.GoalProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgress", id)
+	ret0, _ := ret[0].(*model.GoalProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgress indicates an expected call of GetProgress.
+func (mr *MockGoalMockRecorder) GetProgress(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgress", reflect.TypeOf((*MockGoal)(nil).GetProgress), id)
+}
+
+// GetProgressByBankAccountID mocks base method.
+func (m *MockGoal) GetProgressByBankAccountID(id uuid.UUID) ([]model.GoalProgress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgressByBankAccountID", id)
+	ret0, _ := ret[0].([]model.GoalProgress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgressByBankAccountID indicates an expected call of GetProgressByBankAccountID.
+func (mr *MockGoalMockRecorder) GetProgressByBankAccountID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgressByBankAccountID", reflect.TypeOf((*MockGoal)(nil).GetProgressByBankAccountID), id)
+}
+
+// Shutdown mocks base method.
+func (m *MockGoal) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockGoalMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockGoal)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockGoal) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockGoalMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockGoal)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockGoal) Update(input model.GoalInput, userID uuid.UUID) (*model.Goal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", input, userID)
+	ret0, _ := ret[0].(*model.Goal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockGoalMockRecorder) Update(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGoal)(nil).Update), input, userID)
+}
+
+// MockTag is a mock of Tag interface.
+type MockTag struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagMockRecorder
+}
+
+// MockTagMockRecorder is the mock recorder for MockTag.
+type MockTagMockRecorder struct {
+	mock *MockTag
+}
+
+// NewMockTag creates a new mock instance.
+func NewMockTag(ctrl *gomock.Controller) *MockTag {
+	mock := &MockTag{ctrl: ctrl}
+	mock.recorder = &MockTagMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTag) EXPECT() *MockTagMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTag) Create(input model.TagInput, userID uuid.UUID) (*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagMockRecorder) Create(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTag)(nil).Create), input, userID)
+}
+
+// Delete mocks base method.
+func (m *MockTag) Delete(id, userID uuid.UUID) (*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTag)(nil).Delete), id, userID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockTag) GetByFilter(input model.TagFilterInput) ([]model.Tag, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.Tag)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockTagMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockTag)(nil).GetByFilter), input)
+}
+
+// GetByID mocks base method.
+func (m *MockTag) GetByID(id uuid.UUID) (*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockTagMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockTag)(nil).GetByID), id)
+}
+
+// GetEntityTags mocks base method.
+func (m *MockTag) GetEntityTags(entityType model.EntityType, subjectID uuid.UUID) (*model.EntityTags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntityTags", entityType, subjectID)
+	ret0, _ := ret[0].(*model.EntityTags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntityTags indicates an expected call of GetEntityTags.
+func (mr *MockTagMockRecorder) GetEntityTags(entityType, subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityTags", reflect.TypeOf((*MockTag)(nil).GetEntityTags), entityType, subjectID)
+}
+
+// SetEntityTags mocks base method.
+func (m *MockTag) SetEntityTags(entityType model.EntityType, subjectID uuid.UUID, input model.EntityTagsInput) (*model.EntityTags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEntityTags", entityType, subjectID, input)
+	ret0, _ := ret[0].(*model.EntityTags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetEntityTags indicates an expected call of SetEntityTags.
+func (mr *MockTagMockRecorder) SetEntityTags(entityType, subjectID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEntityTags", reflect.TypeOf((*MockTag)(nil).SetEntityTags), entityType, subjectID, input)
+}
+
+// Shutdown mocks base method.
+func (m *MockTag) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockTagMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockTag)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockTag) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockTagMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockTag)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockTag) Update(input model.TagInput, userID uuid.UUID) (*model.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", input, userID)
+	ret0, _ := ret[0].(*model.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagMockRecorder) Update(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTag)(nil).Update), input, userID)
+}
+
+// MockCustomField is a mock of CustomField interface.
+type MockCustomField struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomFieldMockRecorder
+}
+
+// MockCustomFieldMockRecorder is the mock recorder for MockCustomField.
+type MockCustomFieldMockRecorder struct {
+	mock *MockCustomField
+}
+
+// NewMockCustomField creates a new mock instance.
+func NewMockCustomField(ctrl *gomock.Controller) *MockCustomField {
+	mock := &MockCustomField{ctrl: ctrl}
+	mock.recorder = &MockCustomFieldMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomField) EXPECT() *MockCustomFieldMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomField) Create(input model.CustomFieldInput, userID uuid.UUID) (*model.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomFieldMockRecorder) Create(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomField)(nil).Create), input, userID)
+}
+
+// Delete mocks base method.
+func (m *MockCustomField) Delete(id, userID uuid.UUID) (*model.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomFieldMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomField)(nil).Delete), id, userID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockCustomField) GetByFilter(input model.CustomFieldFilterInput) ([]model.CustomField, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.CustomField)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockCustomFieldMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockCustomField)(nil).GetByFilter), input)
+}
+
+// GetByID mocks base method.
+func (m *MockCustomField) GetByID(id uuid.UUID) (*model.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCustomFieldMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCustomField)(nil).GetByID), id)
+}
+
+// GetValues mocks base method.
+func (m *MockCustomField) GetValues(entityType model.EntityType, subjectID uuid.UUID) (*model.CustomFieldValues, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValues", entityType, subjectID)
+	ret0, _ := ret[0].(*model.CustomFieldValues)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValues indicates an expected call of GetValues.
+func (mr *MockCustomFieldMockRecorder) GetValues(entityType, subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValues", reflect.TypeOf((*MockCustomField)(nil).GetValues), entityType, subjectID)
+}
+
+// SetValues mocks base method.
+func (m *MockCustomField) SetValues(entityType model.EntityType, subjectID uuid.UUID, input model.CustomFieldValuesInput) (*model.CustomFieldValues, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetValues", entityType, subjectID, input)
+	ret0, _ := ret[0].(*model.CustomFieldValues)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetValues indicates an expected call of SetValues.
+func (mr *MockCustomFieldMockRecorder) SetValues(entityType, subjectID, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetValues", reflect.TypeOf((*MockCustomField)(nil).SetValues), entityType, subjectID, input)
+}
+
+// Shutdown mocks base method.
+func (m *MockCustomField) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockCustomFieldMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockCustomField)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockCustomField) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockCustomFieldMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockCustomField)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockCustomField) Update(input model.CustomFieldInput, userID uuid.UUID) (*model.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", input, userID)
+	ret0, _ := ret[0].(*model.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomFieldMockRecorder) Update(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomField)(nil).Update), input, userID)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachment) Create(input model.AttachmentInput, userID uuid.UUID) (*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentMockRecorder) Create(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachment)(nil).Create), input, userID)
+}
+
+// Delete mocks base method.
+func (m *MockAttachment) Delete(id, userID uuid.UUID) (*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachment)(nil).Delete), id, userID)
+}
+
+// Download mocks base method.
+func (m *MockAttachment) Download(id uuid.UUID) (*model.AttachmentDownload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", id)
+	ret0, _ := ret[0].(*model.AttachmentDownload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockAttachmentMockRecorder) Download(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockAttachment)(nil).Download), id)
+}
+
+// GetByEntity mocks base method.
+func (m *MockAttachment) GetByEntity(entityType model.EntityType, subjectID uuid.UUID) ([]model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEntity", entityType, subjectID)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEntity indicates an expected call of GetByEntity.
+func (mr *MockAttachmentMockRecorder) GetByEntity(entityType, subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEntity", reflect.TypeOf((*MockAttachment)(nil).GetByEntity), entityType, subjectID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockAttachment) GetByFilter(input model.AttachmentFilterInput) ([]model.Attachment, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.Attachment)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockAttachmentMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockAttachment)(nil).GetByFilter), input)
+}
+
+// GetByID mocks base method.
+func (m *MockAttachment) GetByID(id uuid.UUID) (*model.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAttachmentMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAttachment)(nil).GetByID), id)
+}
+
+// Shutdown mocks base method.
+func (m *MockAttachment) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockAttachmentMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockAttachment)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockAttachment) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockAttachmentMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockAttachment)(nil).Startup))
+}
+
+// MockNote is a mock of Note interface.
+type MockNote struct {
+	ctrl     *gomock.Controller
+	recorder *MockNoteMockRecorder
+}
+
+// MockNoteMockRecorder is the mock recorder for MockNote.
+type MockNoteMockRecorder struct {
+	mock *MockNote
+}
+
+// NewMockNote creates a new mock instance.
+func NewMockNote(ctrl *gomock.Controller) *MockNote {
+	mock := &MockNote{ctrl: ctrl}
+	mock.recorder = &MockNoteMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNote) EXPECT() *MockNoteMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockNote) Create(input model.NoteInput, userID uuid.UUID) (*model.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNoteMockRecorder) Create(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNote)(nil).Create), input, userID)
+}
+
+// Delete mocks base method.
+func (m *MockNote) Delete(id, userID uuid.UUID) (*model.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockNoteMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNote)(nil).Delete), id, userID)
+}
+
+// GetByEntity mocks base method.
+func (m *MockNote) GetByEntity(entityType model.EntityType, subjectID uuid.UUID) ([]model.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEntity", entityType, subjectID)
+	ret0, _ := ret[0].([]model.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEntity indicates an expected call of GetByEntity.
+func (mr *MockNoteMockRecorder) GetByEntity(entityType, subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEntity", reflect.TypeOf((*MockNote)(nil).GetByEntity), entityType, subjectID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockNote) GetByFilter(input model.NoteFilterInput) ([]model.Note, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.Note)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockNoteMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockNote)(nil).GetByFilter), input)
+}
+
+// GetByID mocks base method.
+func (m *MockNote) GetByID(id uuid.UUID) (*model.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockNoteMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockNote)(nil).GetByID), id)
+}
+
+// Shutdown mocks base method.
+func (m *MockNote) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockNoteMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockNote)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockNote) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockNoteMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockNote)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockNote) Update(input model.NoteInput, userID uuid.UUID) (*model.Note, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", input, userID)
+	ret0, _ := ret[0].(*model.Note)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockNoteMockRecorder) Update(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockNote)(nil).Update), input, userID)
+}
+
+// MockUser is a mock of User interface.
+type MockUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserMockRecorder
+}
+
+// MockUserMockRecorder is the mock recorder for MockUser.
+type MockUserMockRecorder struct {
+	mock *MockUser
+}
+
+// NewMockUser creates a new mock instance.
+func NewMockUser(ctrl *gomock.Controller) *MockUser {
+	mock := &MockUser{ctrl: ctrl}
+	mock.recorder = &MockUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser) EXPECT() *MockUserMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUser) Create(input model.UserInput, userID uuid.UUID) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserMockRecorder) Create(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUser)(nil).Create), input, userID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockUser) GetByFilter(input model.UserFilterInput) ([]model.User, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.User)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockUserMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockUser)(nil).GetByFilter), input)
+}
+
+// GetByID mocks base method.
+func (m *MockUser) GetByID(id uuid.UUID) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserMockRecorder) GetByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUser)(nil).GetByID), id)
+}
+
+// Shutdown mocks base method.
+func (m *MockUser) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockUserMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockUser)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockUser) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockUserMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockUser)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockUser) Update(input model.UserInput, userID uuid.UUID) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", input, userID)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserMockRecorder) Update(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUser)(nil).Update), input, userID)
+}
+
+// MockVehicle is a mock of Vehicle interface.
+type MockVehicle struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleMockRecorder
+}
+
+// MockVehicleMockRecorder is the mock recorder for MockVehicle.
+type MockVehicleMockRecorder struct {
+	mock *MockVehicle
+}
+
+// NewMockVehicle creates a new mock instance.
+func NewMockVehicle(ctrl *gomock.Controller) *MockVehicle {
+	mock := &MockVehicle{ctrl: ctrl}
+	mock.recorder = &MockVehicleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicle) EXPECT() *MockVehicleMockRecorder {
+	return m.recorder
+}
+
+// AggregateByFilter mocks base method.
+func (m *MockVehicle) AggregateByFilter(input model.VehicleFilterInput) ([]model.AggregateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateByFilter", input)
+	ret0, _ := ret[0].([]model.AggregateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateByFilter indicates an expected call of AggregateByFilter.
+func (mr *MockVehicleMockRecorder) AggregateByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateByFilter", reflect.TypeOf((*MockVehicle)(nil).AggregateByFilter), input)
+}
+
+// Create mocks base method.
+func (m *MockVehicle) Create(input model.VehicleInput, userID uuid.UUID) (*model.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVehicleMockRecorder) Create(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVehicle)(nil).Create), input, userID)
+}
+
+// CreateValue mocks base method.
+func (m *MockVehicle) CreateValue(input model.VehicleValueInput, userID uuid.UUID) (*model.VehicleValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateValue", input, userID)
+	ret0, _ := ret[0].(*model.VehicleValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateValue indicates an expected call of CreateValue.
+func (mr *MockVehicleMockRecorder) CreateValue(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateValue", reflect.TypeOf((*MockVehicle)(nil).CreateValue), input, userID)
+}
+
+// Delete mocks base method.
+func (m *MockVehicle) Delete(id, userID uuid.UUID) (*model.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVehicleMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicle)(nil).Delete), id, userID)
+}
+
+// DeleteValue mocks base method.
+func (m *MockVehicle) DeleteValue(id, userID uuid.UUID) (*model.VehicleValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteValue", id, userID)
+	ret0, _ := ret[0].(*model.VehicleValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteValue indicates an expected call of DeleteValue.
+func (mr *MockVehicleMockRecorder) DeleteValue(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteValue", reflect.TypeOf((*MockVehicle)(nil).DeleteValue), id, userID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockVehicle) GetByFilter(input model.VehicleFilterInput) ([]model.Vehicle, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.Vehicle)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockVehicleMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockVehicle)(nil).GetByFilter), input)
+}
+
+// GetByID mocks base method.
+func (m *MockVehicle) GetByID(id uuid.UUID, withValues bool, valueStartDate, valueEndDate cachetime.NCacheTime, pageSize *int) (*model.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id, withValues, valueStartDate, valueEndDate, pageSize)
+	ret0, _ := ret[0].(*model.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockVehicleMockRecorder) GetByID(id, withValues, valueStartDate, valueEndDate, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVehicle)(nil).GetByID), id, withValues, valueStartDate, valueEndDate, pageSize)
+}
+
+// GetValueByID mocks base method.
+func (m *MockVehicle) GetValueByID(id uuid.UUID) (*model.VehicleValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValueByID", id)
+	ret0, _ := ret[0].(*model.VehicleValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValueByID indicates an expected call of GetValueByID.
+func (mr *MockVehicleMockRecorder) GetValueByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValueByID", reflect.TypeOf((*MockVehicle)(nil).GetValueByID), id)
+}
+
+// GetValuesByFilter mocks base method.
+func (m *MockVehicle) GetValuesByFilter(input model.VehicleValueFilterInput) ([]model.VehicleValue, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValuesByFilter", input)
+	ret0, _ := ret[0].([]model.VehicleValue)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetValuesByFilter indicates an expected call of GetValuesByFilter.
+func (mr *MockVehicleMockRecorder) GetValuesByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuesByFilter", reflect.TypeOf((*MockVehicle)(nil).GetValuesByFilter), input)
+}
+
+// ImportValues mocks base method.
+func (m *MockVehicle) ImportValues(input model.VehicleValueImportInput, userID uuid.UUID) (*model.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportValues", input, userID)
+	ret0, _ := ret[0].(*model.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportValues indicates an expected call of ImportValues.
+func (mr *MockVehicleMockRecorder) ImportValues(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportValues", reflect.TypeOf((*MockVehicle)(nil).ImportValues), input, userID)
+}
+
+// Restore mocks base method.
+func (m *MockVehicle) Restore(id, userID uuid.UUID) (*model.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id, userID)
+	ret0, _ := ret[0].(*model.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockVehicleMockRecorder) Restore(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockVehicle)(nil).Restore), id, userID)
+}
+
+// Shutdown mocks base method.
+func (m *MockVehicle) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockVehicleMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockVehicle)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockVehicle) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockVehicleMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockVehicle)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockVehicle) Update(input model.VehicleInput, userID uuid.UUID) (*model.Vehicle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", input, userID)
+	ret0, _ := ret[0].(*model.Vehicle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVehicleMockRecorder) Update(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicle)(nil).Update), input, userID)
+}
+
+// UpdateValue mocks base method.
+func (m *MockVehicle) UpdateValue(input model.VehicleValueInput, userID uuid.UUID) (*model.VehicleValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateValue", input, userID)
+	ret0, _ := ret[0].(*model.VehicleValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateValue indicates an expected call of UpdateValue.
+func (mr *MockVehicleMockRecorder) UpdateValue(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValue", reflect.TypeOf((*MockVehicle)(nil).UpdateValue), input, userID)
+}
+
+// MockProperty is a mock of Property interface.
+type MockProperty struct {
+	ctrl     *gomock.Controller
+	recorder *MockPropertyMockRecorder
+}
+
+// MockPropertyMockRecorder is the mock recorder for MockProperty.
+type MockPropertyMockRecorder struct {
+	mock *MockProperty
+}
+
+// NewMockProperty creates a new mock instance.
+func NewMockProperty(ctrl *gomock.Controller) *MockProperty {
+	mock := &MockProperty{ctrl: ctrl}
+	mock.recorder = &MockPropertyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProperty) EXPECT() *MockPropertyMockRecorder {
+	return m.recorder
+}
+
+// AggregateByFilter mocks base method.
+func (m *MockProperty) AggregateByFilter(input model.PropertyFilterInput) ([]model.AggregateRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateByFilter", input)
+	ret0, _ := ret[0].([]model.AggregateRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateByFilter indicates an expected call of AggregateByFilter.
+func (mr *MockPropertyMockRecorder) AggregateByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateByFilter", reflect.TypeOf((*MockProperty)(nil).AggregateByFilter), input)
+}
+
+// Create mocks base method.
+func (m *MockProperty) Create(input model.PropertyInput, userID uuid.UUID) (*model.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPropertyMockRecorder) Create(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockProperty)(nil).Create), input, userID)
+}
+
+// CreateValue mocks base method.
+func (m *MockProperty) CreateValue(input model.PropertyValueInput, userID uuid.UUID) (*model.PropertyValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateValue", input, userID)
+	ret0, _ := ret[0].(*model.PropertyValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateValue indicates an expected call of CreateValue.
+func (mr *MockPropertyMockRecorder) CreateValue(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateValue", reflect.TypeOf((*MockProperty)(nil).CreateValue), input, userID)
+}
+
+// Delete mocks base method.
+func (m *MockProperty) Delete(id, userID uuid.UUID) (*model.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id, userID)
+	ret0, _ := ret[0].(*model.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPropertyMockRecorder) Delete(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProperty)(nil).Delete), id, userID)
+}
+
+// DeleteValue mocks base method.
+func (m *MockProperty) DeleteValue(id, userID uuid.UUID) (*model.PropertyValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteValue", id, userID)
+	ret0, _ := ret[0].(*model.PropertyValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteValue indicates an expected call of DeleteValue.
+func (mr *MockPropertyMockRecorder) DeleteValue(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteValue", reflect.TypeOf((*MockProperty)(nil).DeleteValue), id, userID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockProperty) GetByFilter(input model.PropertyFilterInput) ([]model.Property, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input)
+	ret0, _ := ret[0].([]model.Property)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockPropertyMockRecorder) GetByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockProperty)(nil).GetByFilter), input)
+}
+
+// GetByID mocks base method.
+func (m *MockProperty) GetByID(id uuid.UUID, withValues bool, valueStartDate, valueEndDate cachetime.NCacheTime, pageSize *int) (*model.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id, withValues, valueStartDate, valueEndDate, pageSize)
+	ret0, _ := ret[0].(*model.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPropertyMockRecorder) GetByID(id, withValues, valueStartDate, valueEndDate, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockProperty)(nil).GetByID), id, withValues, valueStartDate, valueEndDate, pageSize)
+}
+
+// GetValueByID mocks base method.
+func (m *MockProperty) GetValueByID(id uuid.UUID) (*model.PropertyValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValueByID", id)
+	ret0, _ := ret[0].(*model.PropertyValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValueByID indicates an expected call of GetValueByID.
+func (mr *MockPropertyMockRecorder) GetValueByID(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValueByID", reflect.TypeOf((*MockProperty)(nil).GetValueByID), id)
+}
+
+// GetValuesByFilter mocks base method.
+func (m *MockProperty) GetValuesByFilter(input model.PropertyValueFilterInput) ([]model.PropertyValue, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValuesByFilter", input)
+	ret0, _ := ret[0].([]model.PropertyValue)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetValuesByFilter indicates an expected call of GetValuesByFilter.
+func (mr *MockPropertyMockRecorder) GetValuesByFilter(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValuesByFilter", reflect.TypeOf((*MockProperty)(nil).GetValuesByFilter), input)
+}
+
+// ImportValues mocks base method.
+func (m *MockProperty) ImportValues(input model.PropertyValueImportInput, userID uuid.UUID) (*model.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportValues", input, userID)
+	ret0, _ := ret[0].(*model.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportValues indicates an expected call of ImportValues.
+func (mr *MockPropertyMockRecorder) ImportValues(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportValues", reflect.TypeOf((*MockProperty)(nil).ImportValues), input, userID)
+}
+
+// Restore mocks base method.
+func (m *MockProperty) Restore(id, userID uuid.UUID) (*model.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", id, userID)
+	ret0, _ := ret[0].(*model.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockPropertyMockRecorder) Restore(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockProperty)(nil).Restore), id, userID)
+}
+
+// Shutdown mocks base method.
+func (m *MockProperty) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockPropertyMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockProperty)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockProperty) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockPropertyMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockProperty)(nil).Startup))
+}
+
+// Update mocks base method.
+func (m *MockProperty) Update(input model.PropertyInput, userID uuid.UUID) (*model.Property, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", input, userID)
+	ret0, _ := ret[0].(*model.Property)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPropertyMockRecorder) Update(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProperty)(nil).Update), input, userID)
+}
+
+// UpdateValue mocks base method.
+func (m *MockProperty) UpdateValue(input model.PropertyValueInput, userID uuid.UUID) (*model.PropertyValue, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateValue", input, userID)
+	ret0, _ := ret[0].(*model.PropertyValue)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateValue indicates an expected call of UpdateValue.
+func (mr *MockPropertyMockRecorder) UpdateValue(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateValue", reflect.TypeOf((*MockProperty)(nil).UpdateValue), input, userID)
+}
+
+// MockAPIKey is a mock of APIKey interface.
+type MockAPIKey struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeyMockRecorder
+}
+
+// MockAPIKeyMockRecorder is the mock recorder for MockAPIKey.
+type MockAPIKeyMockRecorder struct {
+	mock *MockAPIKey
+}
+
+// NewMockAPIKey creates a new mock instance.
+func NewMockAPIKey(ctrl *gomock.Controller) *MockAPIKey {
+	mock := &MockAPIKey{ctrl: ctrl}
+	mock.recorder = &MockAPIKeyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKey) EXPECT() *MockAPIKeyMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPIKey) Create(input model.APIKeyInput, userID uuid.UUID) (*model.APIKey, *string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", input, userID)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(*string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPIKeyMockRecorder) Create(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIKey)(nil).Create), input, userID)
+}
+
+// GetByFilter mocks base method.
+func (m *MockAPIKey) GetByFilter(input model.APIKeyFilterInput, userID uuid.UUID) ([]model.APIKey, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input, userID)
+	ret0, _ := ret[0].([]model.APIKey)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockAPIKeyMockRecorder) GetByFilter(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockAPIKey)(nil).GetByFilter), input, userID)
+}
+
+// Revoke mocks base method.
+func (m *MockAPIKey) Revoke(id, userID uuid.UUID) (*model.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", id, userID)
+	ret0, _ := ret[0].(*model.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockAPIKeyMockRecorder) Revoke(id, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockAPIKey)(nil).Revoke), id, userID)
+}
+
+// Shutdown mocks base method.
+func (m *MockAPIKey) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockAPIKeyMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockAPIKey)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockAPIKey) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockAPIKeyMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockAPIKey)(nil).Startup))
+}
+
+// MockAuditLog is a mock of AuditLog interface.
+type MockAuditLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditLogMockRecorder
+}
+
+// MockAuditLogMockRecorder is the mock recorder for MockAuditLog.
+type MockAuditLogMockRecorder struct {
+	mock *MockAuditLog
+}
+
+// NewMockAuditLog creates a new mock instance.
+func NewMockAuditLog(ctrl *gomock.Controller) *MockAuditLog {
+	mock := &MockAuditLog{ctrl: ctrl}
+	mock.recorder = &MockAuditLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditLog) EXPECT() *MockAuditLogMockRecorder {
+	return m.recorder
+}
+
+// GetByFilter mocks base method.
+func (m *MockAuditLog) GetByFilter(input model.AuditLogFilterInput, userID uuid.UUID) ([]model.AuditLog, model.PageInfoOutput, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFilter", input, userID)
+	ret0, _ := ret[0].([]model.AuditLog)
+	ret1, _ := ret[1].(model.PageInfoOutput)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByFilter indicates an expected call of GetByFilter.
+func (mr *MockAuditLogMockRecorder) GetByFilter(input, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFilter", reflect.TypeOf((*MockAuditLog)(nil).GetByFilter), input, userID)
+}
+
+// Shutdown mocks base method.
+func (m *MockAuditLog) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockAuditLogMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockAuditLog)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockAuditLog) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockAuditLogMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockAuditLog)(nil).Startup))
+}
+
+// MockPurge is a mock of Purge interface.
+type MockPurge struct {
+	ctrl     *gomock.Controller
+	recorder *MockPurgeMockRecorder
+}
+
+// MockPurgeMockRecorder is the mock recorder for MockPurge.
+type MockPurgeMockRecorder struct {
+	mock *MockPurge
+}
+
+// NewMockPurge creates a new mock instance.
+func NewMockPurge(ctrl *gomock.Controller) *MockPurge {
+	mock := &MockPurge{ctrl: ctrl}
+	mock.recorder = &MockPurgeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPurge) EXPECT() *MockPurgeMockRecorder {
+	return m.recorder
+}
+
+// Purge mocks base method.
+func (m *MockPurge) Purge(userID uuid.UUID) (*model.PurgeSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", userID)
+	ret0, _ := ret[0].(*model.PurgeSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockPurgeMockRecorder) Purge(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockPurge)(nil).Purge), userID)
+}
+
+// Shutdown mocks base method.
+func (m *MockPurge) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockPurgeMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockPurge)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockPurge) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockPurgeMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockPurge)(nil).Startup))
+}
+
+// MockSearch is a mock of Search interface.
+type MockSearch struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchMockRecorder
+}
+
+// MockSearchMockRecorder is the mock recorder for MockSearch.
+type MockSearchMockRecorder struct {
+	mock *MockSearch
+}
+
+// NewMockSearch creates a new mock instance.
+func NewMockSearch(ctrl *gomock.Controller) *MockSearch {
+	mock := &MockSearch{ctrl: ctrl}
+	mock.recorder = &MockSearchMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearch) EXPECT() *MockSearchMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearch) Search(input model.SearchInput) ([]model.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", input)
+	ret0, _ := ret[0].([]model.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchMockRecorder) Search(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearch)(nil).Search), input)
+}
+
+// Shutdown mocks base method.
+func (m *MockSearch) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockSearchMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockSearch)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockSearch) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockSearchMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockSearch)(nil).Startup))
+}
+
+// MockArchive is a mock of Archive interface.
+type MockArchive struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveMockRecorder
+}
+
+// MockArchiveMockRecorder is the mock recorder for MockArchive.
+type MockArchiveMockRecorder struct {
+	mock *MockArchive
+}
+
+// NewMockArchive creates a new mock instance.
+func NewMockArchive(ctrl *gomock.Controller) *MockArchive {
+	mock := &MockArchive{ctrl: ctrl}
+	mock.recorder = &MockArchiveMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchive) EXPECT() *MockArchiveMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockArchive) Export(w io.Writer, format model.ArchiveFormat, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", w, format, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockArchiveMockRecorder) Export(w, format, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockArchive)(nil).Export), w, format, userID)
+}
+
+// Restore mocks base method.
+func (m *MockArchive) Restore(data []byte, userID uuid.UUID) (*model.ArchiveRestoreResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", data, userID)
+	ret0, _ := ret[0].(*model.ArchiveRestoreResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArchiveMockRecorder) Restore(data, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArchive)(nil).Restore), data, userID)
+}
+
+// Shutdown mocks base method.
+func (m *MockArchive) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockArchiveMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockArchive)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockArchive) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockArchiveMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockArchive)(nil).Startup))
+}
+
+// MockReport is a mock of Report interface.
+type MockReport struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportMockRecorder
+}
+
+// MockReportMockRecorder is the mock recorder for MockReport.
+type MockReportMockRecorder struct {
+	mock *MockReport
+}
+
+// NewMockReport creates a new mock instance.
+func NewMockReport(ctrl *gomock.Controller) *MockReport {
+	mock := &MockReport{ctrl: ctrl}
+	mock.recorder = &MockReportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReport) EXPECT() *MockReportMockRecorder {
+	return m.recorder
+}
+
+// GetAssetChange mocks base method.
+func (m *MockReport) GetAssetChange(from, to time.Time) (*model.AssetChangeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssetChange", from, to)
+	ret0, _ := ret[0].(*model.AssetChangeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssetChange indicates an expected call of GetAssetChange.
+func (mr *MockReportMockRecorder) GetAssetChange(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssetChange", reflect.TypeOf((*MockReport)(nil).GetAssetChange), from, to)
+}
+
+// GetNetWorth mocks base method.
+func (m *MockReport) GetNetWorth() (*model.NetWorthReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetWorth")
+	ret0, _ := ret[0].(*model.NetWorthReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetWorth indicates an expected call of GetNetWorth.
+func (mr *MockReportMockRecorder) GetNetWorth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetWorth", reflect.TypeOf((*MockReport)(nil).GetNetWorth))
+}
+
+// GetNetWorthStatement mocks base method.
+func (m *MockReport) GetNetWorthStatement(asOf time.Time) (*model.NetWorthStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetWorthStatement", asOf)
+	ret0, _ := ret[0].(*model.NetWorthStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetWorthStatement indicates an expected call of GetNetWorthStatement.
+func (mr *MockReportMockRecorder) GetNetWorthStatement(asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetWorthStatement", reflect.TypeOf((*MockReport)(nil).GetNetWorthStatement), asOf)
+}
+
+// Shutdown mocks base method.
+func (m *MockReport) Shutdown() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Shutdown")
+}
+
+// Shutdown indicates an expected call of Shutdown.
+func (mr *MockReportMockRecorder) Shutdown() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Shutdown", reflect.TypeOf((*MockReport)(nil).Shutdown))
+}
+
+// Startup mocks base method.
+func (m *MockReport) Startup() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Startup")
+}
+
+// Startup indicates an expected call of Startup.
+func (mr *MockReportMockRecorder) Startup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Startup", reflect.TypeOf((*MockReport)(nil).Startup))
 }
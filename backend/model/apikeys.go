@@ -0,0 +1,347 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// APIKeyScope indicates what an API Key is allowed to do
+type APIKeyScope string
+
+const (
+	// APIKeyScopeReadOnly allows an API Key to read and search all entities
+	APIKeyScopeReadOnly APIKeyScope = "read-only"
+	// APIKeyScopeBalancesWrite allows an API Key to read all entities and to write balances, values, cash flows, transactions and transfers
+	APIKeyScopeBalancesWrite APIKeyScope = "balances:write"
+	// APIKeyScopeFullAccess allows an API Key to do everything its owner can do
+	APIKeyScopeFullAccess APIKeyScope = "full-access"
+)
+
+const (
+	// APIKeyTokenPrefix is the fixed prefix of every plaintext API Key
+	APIKeyTokenPrefix = "bal"
+	// apiKeyPrefixBytes is the number of random bytes used for the lookup prefix
+	apiKeyPrefixBytes = 4
+	// apiKeySecretBytes is the number of random bytes used for the secret part
+	apiKeySecretBytes = 32
+)
+
+const (
+	// APIKeyColumnID represents the corresponding column in API Key table
+	APIKeyColumnID filter.Field = "api_keys.entity_id"
+	// APIKeyColumnUserID represents the corresponding column in API Key table
+	APIKeyColumnUserID filter.Field = "api_keys.user_entity_id"
+	// APIKeyColumnName represents the corresponding column in API Key table
+	APIKeyColumnName filter.Field = "api_keys.name"
+	// APIKeyColumnPrefix represents the corresponding column in API Key table
+	APIKeyColumnPrefix filter.Field = "api_keys.key_prefix"
+	// APIKeyColumnKeyHash represents the corresponding column in API Key table
+	APIKeyColumnKeyHash filter.Field = "api_keys.key_hash"
+	// APIKeyColumnScopes represents the corresponding column in API Key table
+	APIKeyColumnScopes filter.Field = "api_keys.scopes"
+	// APIKeyColumnExpires represents the corresponding column in API Key table
+	APIKeyColumnExpires filter.Field = "api_keys.expires"
+	// APIKeyColumnLastUsed represents the corresponding column in API Key table
+	APIKeyColumnLastUsed filter.Field = "api_keys.last_used"
+	// APIKeyColumnCreated represents the corresponding column in API Key table
+	APIKeyColumnCreated filter.Field = "api_keys.created"
+	// APIKeyColumnCreatedBy represents the corresponding column in API Key table
+	APIKeyColumnCreatedBy filter.Field = "api_keys.created_by"
+	// APIKeyColumnRevoked represents the corresponding column in API Key table
+	APIKeyColumnRevoked filter.Field = "api_keys.revoked"
+	// APIKeyColumnRevokedBy represents the corresponding column in API Key table
+	APIKeyColumnRevokedBy filter.Field = "api_keys.revoked_by"
+)
+
+// APIKeyFields is the whitelist of fields API Keys can be queried and sorted by, keyed by their names in the API
+var APIKeyFields = map[string]filter.Field{
+	"id":        APIKeyColumnID,
+	"userId":    APIKeyColumnUserID,
+	"name":      APIKeyColumnName,
+	"prefix":    APIKeyColumnPrefix,
+	"expires":   APIKeyColumnExpires,
+	"lastUsed":  APIKeyColumnLastUsed,
+	"created":   APIKeyColumnCreated,
+	"revoked":   APIKeyColumnRevoked,
+	"createdBy": APIKeyColumnCreatedBy,
+	"revokedBy": APIKeyColumnRevokedBy,
+}
+
+// IsValid checks whether the scope is one of the known scopes
+func (s APIKeyScope) IsValid() bool {
+	switch s {
+	case APIKeyScopeReadOnly, APIKeyScopeBalancesWrite, APIKeyScopeFullAccess:
+		return true
+	}
+	return false
+}
+
+// Permits checks whether this scope covers the required scope
+func (s APIKeyScope) Permits(required APIKeyScope) bool {
+	switch s {
+	case APIKeyScopeFullAccess:
+		return true
+	case APIKeyScopeBalancesWrite:
+		return required == APIKeyScopeBalancesWrite || required == APIKeyScopeReadOnly
+	case APIKeyScopeReadOnly:
+		return required == APIKeyScopeReadOnly
+	}
+	return false
+}
+
+// APIKeyScopes is a list of API Key scopes, stored as a comma-separated string
+type APIKeyScopes []APIKeyScope
+
+// Scan implements the Scanner interface.
+func (s *APIKeyScopes) Scan(value interface{}) error {
+	var str string
+	switch x := value.(type) {
+	case []byte:
+		str = string(x)
+	case string:
+		str = x
+	case nil:
+		*s = APIKeyScopes{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan type %T into model.APIKeyScopes: %v", value, value)
+	}
+
+	scopes := APIKeyScopes{}
+	for _, scope := range strings.Split(str, ",") {
+		if len(scope) > 0 {
+			scopes = append(scopes, APIKeyScope(scope))
+		}
+	}
+	*s = scopes
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (s APIKeyScopes) Value() (driver.Value, error) {
+	scopes := make([]string, 0)
+	for _, scope := range s {
+		scopes = append(scopes, string(scope))
+	}
+	return strings.Join(scopes, ","), nil
+}
+
+// Permits checks whether any of the scopes covers the required scope
+func (s APIKeyScopes) Permits(required APIKeyScope) bool {
+	for _, scope := range s {
+		if scope.Permits(required) {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey represents a long-lived API Key belonging to a User
+type APIKey struct {
+	ID        uuid.UUID    `db:"entity_id" validate:"min=36,max=36"`
+	UserID    uuid.UUID    `db:"user_entity_id" validate:"min=36,max=36"`
+	Name      string       `db:"name" validate:"max=255"`
+	Prefix    string       `db:"key_prefix"`
+	KeyHash   string       `db:"key_hash"`
+	Scopes    APIKeyScopes `db:"scopes"`
+	Expires   null.Time    `db:"expires"`
+	LastUsed  null.Time    `db:"last_used"`
+	Created   time.Time    `db:"created"`
+	CreatedBy uuid.UUID    `db:"created_by" validate:"min=36,max=36"`
+	Revoked   null.Time    `db:"revoked"`
+	RevokedBy nuuid.NUUID  `db:"revoked_by" validate:"min=36,max=36"`
+}
+
+// NewAPIKeyFromInput creates a new API Key from its input object. The plaintext key is
+// returned alongside the API Key and is never stored, so it can only be shown once.
+func NewAPIKeyFromInput(input APIKeyInput, userID uuid.UUID) (k APIKey, plaintext string, err error) {
+	scopes := APIKeyScopes{}
+	for _, scope := range input.Scopes {
+		if !scope.IsValid() {
+			return k, "", failure.BadRequestFromString(fmt.Sprintf("invalid API key scope: %s", scope))
+		}
+		scopes = append(scopes, scope)
+	}
+
+	if len(scopes) == 0 {
+		scopes = append(scopes, APIKeyScopeReadOnly)
+	}
+
+	if len(strings.TrimSpace(input.Name)) == 0 {
+		return k, "", failure.BadRequestFromString("API key name is required")
+	}
+
+	now := time.Now()
+	if input.Expires.Valid && !input.Expires.Time.After(now) {
+		return k, "", failure.BadRequestFromString("API key expiration must be in the future")
+	}
+
+	prefixBytes := make([]byte, apiKeyPrefixBytes)
+	if _, err = rand.Read(prefixBytes); err != nil {
+		return k, "", err
+	}
+
+	secretBytes := make([]byte, apiKeySecretBytes)
+	if _, err = rand.Read(secretBytes); err != nil {
+		return k, "", err
+	}
+
+	prefix := hex.EncodeToString(prefixBytes)
+	plaintext = fmt.Sprintf("%s_%s_%s", APIKeyTokenPrefix, prefix, base64.RawURLEncoding.EncodeToString(secretBytes))
+	newUUID, _ := uuid.NewV7()
+
+	k = APIKey{
+		ID:        newUUID,
+		UserID:    userID,
+		Name:      input.Name,
+		Prefix:    prefix,
+		KeyHash:   HashAPIKey(plaintext),
+		Scopes:    scopes,
+		Expires:   null.Time(input.Expires),
+		Created:   now,
+		CreatedBy: userID,
+	}
+
+	return k, plaintext, nil
+}
+
+// HashAPIKey produces the stored hash of a plaintext API Key
+func HashAPIKey(plaintext string) string {
+	hash := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(hash[:])
+}
+
+// GetAPIKeyPrefix extracts the lookup prefix from a plaintext API Key
+func GetAPIKeyPrefix(plaintext string) (string, error) {
+	parts := strings.SplitN(plaintext, "_", 3)
+	if len(parts) != 3 || parts[0] != APIKeyTokenPrefix || len(parts[1]) != apiKeyPrefixBytes*2 || len(parts[2]) == 0 {
+		return "", failure.Unauthorized("malformed API key")
+	}
+	return parts[1], nil
+}
+
+// CompareKey compares a plaintext API Key against the stored hash
+func (k *APIKey) CompareKey(plaintext string) bool {
+	return subtle.ConstantTimeCompare([]byte(k.KeyHash), []byte(HashAPIKey(plaintext))) == 1
+}
+
+// IsActive checks whether the API Key is neither revoked nor expired
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.Revoked.Valid || k.RevokedBy.Valid {
+		return false
+	}
+	if k.Expires.Valid && !k.Expires.Time.After(now) {
+		return false
+	}
+	return true
+}
+
+// Revoke performs a revocation on an API Key
+func (k *APIKey) Revoke(userID uuid.UUID) error {
+	if k.Revoked.Valid || k.RevokedBy.Valid {
+		return failure.OperationNotPermitted("revoke", "API Key", "already revoked")
+	}
+
+	now := time.Now()
+
+	k.Revoked = null.TimeFrom(now)
+	k.RevokedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// ToOutput converts an API Key to its JSON-compatible object representation
+func (k *APIKey) ToOutput() APIKeyOutput {
+	return APIKeyOutput{
+		ID:        k.ID,
+		UserID:    k.UserID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		Expires:   cachetime.NCacheTime(k.Expires),
+		LastUsed:  cachetime.NCacheTime(k.LastUsed),
+		Created:   cachetime.CacheTime(k.Created),
+		CreatedBy: k.CreatedBy,
+		Revoked:   cachetime.NCacheTime(k.Revoked),
+		RevokedBy: k.RevokedBy,
+	}
+}
+
+// APIKeyInput represents an input struct for API Key entity
+type APIKeyInput struct {
+	Name    string               `json:"name"`
+	Scopes  []APIKeyScope        `json:"scopes"`
+	Expires cachetime.NCacheTime `json:"expires,omitempty"`
+}
+
+// APIKeyOutput is the JSON-compatible object representation of API Key
+type APIKeyOutput struct {
+	ID        uuid.UUID            `json:"id"`
+	UserID    uuid.UUID            `json:"userId"`
+	Name      string               `json:"name"`
+	Prefix    string               `json:"prefix"`
+	Key       *string              `json:"key,omitempty"`
+	Scopes    APIKeyScopes         `json:"scopes"`
+	Expires   cachetime.NCacheTime `json:"expires,omitempty"`
+	LastUsed  cachetime.NCacheTime `json:"lastUsed,omitempty"`
+	Created   cachetime.CacheTime  `json:"created"`
+	CreatedBy uuid.UUID            `json:"createdBy"`
+	Revoked   cachetime.NCacheTime `json:"revoked,omitempty"`
+	RevokedBy nuuid.NUUID          `json:"revokedBy,omitempty"`
+}
+
+// APIKeyFilterInput is the filter input object for API Keys
+type APIKeyFilterInput struct {
+	filter.BaseFilterInput
+	UserIDs *[]uuid.UUID `json:"userIds,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *APIKeyFilterInput) ToFilter() filter.Filter {
+	theFilter := filter.Filter{
+		TableName:      "api_keys",
+		DeletedColumn:  "revoked",
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.UserIDs != nil {
+		if len(*f.UserIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: APIKeyColumnUserID,
+				Operand2: *f.UserIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	keywordFields := []filter.Field{
+		APIKeyColumnName,
+		APIKeyColumnPrefix,
+	}
+	keywordClause := f.BaseFilterInput.GetKeywordFilter(keywordFields, false)
+	if keywordClause != nil {
+		theFilter.AddClause(*keywordClause, filter.OperatorAnd)
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(APIKeyFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, APIKeyFields)
+	}
+
+	return theFilter
+}
@@ -0,0 +1,1964 @@
+package model
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// ArchiveVersion is the version of the archive format written by this instance, which is also the latest
+// version it can restore. Version 2 added Bank Account Cash Flows, version 3 added Transactions and
+// version 4 added Transfers, version 5 added Categories, Category Rules and Budgets, version 6 added Goals and
+// version 7 added Tags and Custom Fields, version 8 added Notes, version 9 added Attachments along with
+// their content, version 10 marked the history deleted along with its asset and version 11 linked Transactions
+// to their Categories by ID.
+const ArchiveVersion = 11
+
+// ArchiveFormat indicates how an archive is encoded
+type ArchiveFormat string
+
+const (
+	// ArchiveFormatJSON indicates an archive encoded as a single JSON document
+	ArchiveFormatJSON ArchiveFormat = "json"
+	// ArchiveFormatZIP indicates an archive encoded as a ZIP file holding a manifest and one CSV file per table
+	ArchiveFormatZIP ArchiveFormat = "zip"
+)
+
+// ArchiveFormatMap is the map of archive formats to their content types
+var ArchiveFormatMap = map[ArchiveFormat]string{
+	ArchiveFormatJSON: "application/json",
+	ArchiveFormatZIP:  "application/zip",
+}
+
+// archiveManifestFile is the name of the file describing the archive within a ZIP archive
+const archiveManifestFile = "manifest.json"
+
+// archiveAttachmentDir is the directory holding the content of each Attachment within a ZIP archive, in a
+// file named after its ID
+const archiveAttachmentDir = "attachments/"
+
+// Archive holds every record of an instance, including soft-deleted ones, so that it can be restored elsewhere
+type Archive struct {
+	Version              int
+	Exported             time.Time
+	Users                []User
+	BankAccounts         []BankAccount
+	BankAccountBalances  []BankAccountBalance
+	BankAccountCashFlows []BankAccountCashFlow
+	Transactions         []Transaction
+	Transfers            []Transfer
+	Categories           []Category
+	CategoryRules        []CategoryRule
+	Budgets              []Budget
+	Goals                []Goal
+	GoalBankAccounts     []GoalBankAccount
+	Vehicles             []Vehicle
+	VehicleValues        []VehicleValue
+	Properties           []Property
+	PropertyValues       []PropertyValue
+	Tags                 []Tag
+	EntityTags           []EntityTag
+	CustomFields         []CustomField
+	CustomFieldValues    []CustomFieldValue
+	Notes                []Note
+	Attachments          []Attachment
+	// AttachmentContents holds the content of every Attachment, keyed by its ID
+	AttachmentContents map[uuid.UUID][]byte
+}
+
+// NewArchive creates a new, empty Archive of the current version
+func NewArchive() Archive {
+	return Archive{
+		Version:              ArchiveVersion,
+		Exported:             time.Now(),
+		Users:                make([]User, 0),
+		BankAccounts:         make([]BankAccount, 0),
+		BankAccountBalances:  make([]BankAccountBalance, 0),
+		BankAccountCashFlows: make([]BankAccountCashFlow, 0),
+		Transactions:         make([]Transaction, 0),
+		Transfers:            make([]Transfer, 0),
+		Categories:           make([]Category, 0),
+		CategoryRules:        make([]CategoryRule, 0),
+		Budgets:              make([]Budget, 0),
+		Goals:                make([]Goal, 0),
+		GoalBankAccounts:     make([]GoalBankAccount, 0),
+		Vehicles:             make([]Vehicle, 0),
+		VehicleValues:        make([]VehicleValue, 0),
+		Properties:           make([]Property, 0),
+		PropertyValues:       make([]PropertyValue, 0),
+		Tags:                 make([]Tag, 0),
+		EntityTags:           make([]EntityTag, 0),
+		CustomFields:         make([]CustomField, 0),
+		CustomFieldValues:    make([]CustomFieldValue, 0),
+		Notes:                make([]Note, 0),
+		Attachments:          make([]Attachment, 0),
+		AttachmentContents:   make(map[uuid.UUID][]byte),
+	}
+}
+
+// NewUserFromArchive creates a User restored from an archive. Archives never hold passwords, so the User
+// is given a random one and has to have it reset before signing in.
+func NewUserFromArchive(user User) User {
+	user.Password = uuid.NewString()
+	user.hashPassword()
+	return user
+}
+
+// Validate checks that every record of the archive has a unique ID and that every balance, cash flow,
+// transaction, transfer and value belongs to an asset held by the archive, as does every category rule and
+// budget to a category, every link of a goal to both the goal and a bank account, every tag and custom
+// field value to both the tag or custom field and an asset of its type, every note to the record it is
+// written on and every attachment to the record it is attached to, along with content matching its checksum
+func (a *Archive) Validate() error {
+	if a.Version < 1 || a.Version > ArchiveVersion {
+		return failure.BadRequestFromString(fmt.Sprintf("unsupported archive version: %d", a.Version))
+	}
+
+	ids := make(map[uuid.UUID]bool)
+	unique := func(id uuid.UUID, entity string) error {
+		if ids[id] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds more than one record with ID %s (%s)", id, entity))
+		}
+		ids[id] = true
+		return nil
+	}
+
+	for _, user := range a.Users {
+		if err := unique(user.ID, "User"); err != nil {
+			return err
+		}
+	}
+
+	bankAccountIDs := make(map[uuid.UUID]bool)
+	for _, bankAccount := range a.BankAccounts {
+		if err := unique(bankAccount.ID, "Bank Account"); err != nil {
+			return err
+		}
+		bankAccountIDs[bankAccount.ID] = true
+	}
+	bankAccountBalanceIDs := make(map[uuid.UUID]bool)
+	for _, bankAccountBalance := range a.BankAccountBalances {
+		if err := unique(bankAccountBalance.ID, "Bank Account Balance"); err != nil {
+			return err
+		}
+		if !bankAccountIDs[bankAccountBalance.BankAccountID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Bank Account Balance %s of a missing Bank Account", bankAccountBalance.ID))
+		}
+		bankAccountBalanceIDs[bankAccountBalance.ID] = true
+	}
+	for _, bankAccountCashFlow := range a.BankAccountCashFlows {
+		if err := unique(bankAccountCashFlow.ID, "Bank Account Cash Flow"); err != nil {
+			return err
+		}
+		if !bankAccountIDs[bankAccountCashFlow.BankAccountID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Bank Account Cash Flow %s of a missing Bank Account", bankAccountCashFlow.ID))
+		}
+	}
+	for _, transaction := range a.Transactions {
+		if err := unique(transaction.ID, "Transaction"); err != nil {
+			return err
+		}
+		if !bankAccountIDs[transaction.BankAccountID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Transaction %s of a missing Bank Account", transaction.ID))
+		}
+	}
+	for _, transfer := range a.Transfers {
+		if err := unique(transfer.ID, "Transfer"); err != nil {
+			return err
+		}
+		if !bankAccountIDs[transfer.FromBankAccountID] || !bankAccountIDs[transfer.ToBankAccountID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Transfer %s of a missing Bank Account", transfer.ID))
+		}
+	}
+
+	categoryIDs := make(map[uuid.UUID]bool)
+	for _, category := range a.Categories {
+		if err := unique(category.ID, "Category"); err != nil {
+			return err
+		}
+		categoryIDs[category.ID] = true
+	}
+	for _, categoryRule := range a.CategoryRules {
+		if err := unique(categoryRule.ID, "Category Rule"); err != nil {
+			return err
+		}
+		if !categoryIDs[categoryRule.CategoryID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Category Rule %s of a missing Category", categoryRule.ID))
+		}
+	}
+	for _, budget := range a.Budgets {
+		if err := unique(budget.ID, "Budget"); err != nil {
+			return err
+		}
+		if !categoryIDs[budget.CategoryID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Budget %s of a missing Category", budget.ID))
+		}
+	}
+	for _, transaction := range a.Transactions {
+		if transaction.CategoryID.Valid && !categoryIDs[transaction.CategoryID.UUID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Transaction %s of a missing Category", transaction.ID))
+		}
+	}
+
+	goalIDs := make(map[uuid.UUID]bool)
+	for _, goal := range a.Goals {
+		if err := unique(goal.ID, "Goal"); err != nil {
+			return err
+		}
+		goalIDs[goal.ID] = true
+	}
+	for _, goalBankAccount := range a.GoalBankAccounts {
+		if !goalIDs[goalBankAccount.GoalID] || !bankAccountIDs[goalBankAccount.BankAccountID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds a link of Goal %s to Bank Account %s, either of which is missing", goalBankAccount.GoalID, goalBankAccount.BankAccountID))
+		}
+	}
+
+	vehicleIDs := make(map[uuid.UUID]bool)
+	for _, vehicle := range a.Vehicles {
+		if err := unique(vehicle.ID, "Vehicle"); err != nil {
+			return err
+		}
+		vehicleIDs[vehicle.ID] = true
+	}
+	vehicleValueIDs := make(map[uuid.UUID]bool)
+	for _, vehicleValue := range a.VehicleValues {
+		if err := unique(vehicleValue.ID, "Vehicle Value"); err != nil {
+			return err
+		}
+		if !vehicleIDs[vehicleValue.VehicleID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Vehicle Value %s of a missing Vehicle", vehicleValue.ID))
+		}
+		vehicleValueIDs[vehicleValue.ID] = true
+	}
+
+	propertyIDs := make(map[uuid.UUID]bool)
+	for _, property := range a.Properties {
+		if err := unique(property.ID, "Property"); err != nil {
+			return err
+		}
+		propertyIDs[property.ID] = true
+	}
+	propertyValueIDs := make(map[uuid.UUID]bool)
+	for _, propertyValue := range a.PropertyValues {
+		if err := unique(propertyValue.ID, "Property Value"); err != nil {
+			return err
+		}
+		if !propertyIDs[propertyValue.PropertyID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Property Value %s of a missing Property", propertyValue.ID))
+		}
+		propertyValueIDs[propertyValue.ID] = true
+	}
+
+	subjectIDs := map[EntityType]map[uuid.UUID]bool{
+		EntityTypeBankAccount: bankAccountIDs,
+		EntityTypeVehicle:     vehicleIDs,
+		EntityTypeProperty:    propertyIDs,
+	}
+
+	tagIDs := make(map[uuid.UUID]bool)
+	for _, tag := range a.Tags {
+		if err := unique(tag.ID, "Tag"); err != nil {
+			return err
+		}
+		tagIDs[tag.ID] = true
+	}
+	for _, entityTag := range a.EntityTags {
+		if !tagIDs[entityTag.TagID] || !subjectIDs[entityTag.EntityType][entityTag.SubjectID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Tag %s put on %s %s, either of which is missing", entityTag.TagID, entityTag.EntityType, entityTag.SubjectID))
+		}
+	}
+
+	customFieldTypes := make(map[uuid.UUID]EntityType)
+	for _, customField := range a.CustomFields {
+		if err := unique(customField.ID, "Custom Field"); err != nil {
+			return err
+		}
+		customFieldTypes[customField.ID] = customField.EntityType
+	}
+	for _, customFieldValue := range a.CustomFieldValues {
+		entityType, ok := customFieldTypes[customFieldValue.CustomFieldID]
+		if !ok || !subjectIDs[entityType][customFieldValue.SubjectID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds a value of Custom Field %s for %s, either of which is missing", customFieldValue.CustomFieldID, customFieldValue.SubjectID))
+		}
+	}
+
+	recordIDs := map[EntityType]map[uuid.UUID]bool{
+		EntityTypeBankAccount:        bankAccountIDs,
+		EntityTypeBankAccountBalance: bankAccountBalanceIDs,
+		EntityTypeVehicle:            vehicleIDs,
+		EntityTypeVehicleValue:       vehicleValueIDs,
+		EntityTypeProperty:           propertyIDs,
+		EntityTypePropertyValue:      propertyValueIDs,
+	}
+
+	for _, note := range a.Notes {
+		if err := unique(note.ID, "Note"); err != nil {
+			return err
+		}
+		if !recordIDs[note.EntityType][note.SubjectID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Note %s written on a missing %s %s", note.ID, note.EntityType, note.SubjectID))
+		}
+	}
+
+	for _, attachment := range a.Attachments {
+		if err := unique(attachment.ID, "Attachment"); err != nil {
+			return err
+		}
+		if !recordIDs[attachment.EntityType][attachment.SubjectID] {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Attachment %s attached to a missing %s %s", attachment.ID, attachment.EntityType, attachment.SubjectID))
+		}
+		content, ok := a.AttachmentContents[attachment.ID]
+		if !ok || int64(len(content)) != attachment.Size || GetAttachmentChecksum(content) != attachment.Checksum {
+			return failure.BadRequestFromString(fmt.Sprintf("archive holds Attachment %s without content matching its checksum", attachment.ID))
+		}
+	}
+
+	return nil
+}
+
+// ToOutput converts an Archive to its portable object representation, leaving out User passwords
+func (a *Archive) ToOutput() ArchiveOutput {
+	output := ArchiveOutput{
+		Version:              a.Version,
+		Exported:             a.Exported,
+		Users:                make([]ArchiveUserOutput, 0, len(a.Users)),
+		BankAccounts:         make([]ArchiveBankAccountOutput, 0, len(a.BankAccounts)),
+		BankAccountBalances:  make([]ArchiveBankAccountBalanceOutput, 0, len(a.BankAccountBalances)),
+		BankAccountCashFlows: make([]ArchiveBankAccountCashFlowOutput, 0, len(a.BankAccountCashFlows)),
+		Transactions:         make([]ArchiveTransactionOutput, 0, len(a.Transactions)),
+		Transfers:            make([]ArchiveTransferOutput, 0, len(a.Transfers)),
+		Categories:           make([]ArchiveCategoryOutput, 0, len(a.Categories)),
+		CategoryRules:        make([]ArchiveCategoryRuleOutput, 0, len(a.CategoryRules)),
+		Budgets:              make([]ArchiveBudgetOutput, 0, len(a.Budgets)),
+		Goals:                make([]ArchiveGoalOutput, 0, len(a.Goals)),
+		GoalBankAccounts:     make([]ArchiveGoalBankAccountOutput, 0, len(a.GoalBankAccounts)),
+		Vehicles:             make([]ArchiveVehicleOutput, 0, len(a.Vehicles)),
+		VehicleValues:        make([]ArchiveVehicleValueOutput, 0, len(a.VehicleValues)),
+		Properties:           make([]ArchivePropertyOutput, 0, len(a.Properties)),
+		PropertyValues:       make([]ArchivePropertyValueOutput, 0, len(a.PropertyValues)),
+		Tags:                 make([]ArchiveTagOutput, 0, len(a.Tags)),
+		EntityTags:           make([]ArchiveEntityTagOutput, 0, len(a.EntityTags)),
+		CustomFields:         make([]ArchiveCustomFieldOutput, 0, len(a.CustomFields)),
+		CustomFieldValues:    make([]ArchiveCustomFieldValueOutput, 0, len(a.CustomFieldValues)),
+		Notes:                make([]ArchiveNoteOutput, 0, len(a.Notes)),
+		Attachments:          make([]ArchiveAttachmentOutput, 0, len(a.Attachments)),
+		AttachmentContents:   make(map[uuid.UUID][]byte, len(a.AttachmentContents)),
+	}
+
+	for _, u := range a.Users {
+		output.Users = append(output.Users, ArchiveUserOutput{
+			ID:        u.ID,
+			Username:  u.Username,
+			Email:     u.Email,
+			Name:      u.Name,
+			Created:   u.Created,
+			CreatedBy: u.CreatedBy,
+			Updated:   u.Updated,
+			UpdatedBy: u.UpdatedBy,
+		})
+	}
+
+	for _, b := range a.BankAccounts {
+		output.BankAccounts = append(output.BankAccounts, ArchiveBankAccountOutput{
+			ID:                b.ID,
+			AccountName:       b.AccountName,
+			BankName:          b.BankName,
+			AccountHolderName: b.AccountHolderName,
+			AccountNumber:     b.AccountNumber,
+			LastBalance:       b.LastBalance,
+			LastBalanceDate:   b.LastBalanceDate,
+			Status:            b.Status,
+			Created:           b.Created,
+			CreatedBy:         b.CreatedBy,
+			Updated:           b.Updated,
+			UpdatedBy:         b.UpdatedBy,
+			Deleted:           b.Deleted,
+			DeletedBy:         b.DeletedBy,
+		})
+	}
+
+	for _, bb := range a.BankAccountBalances {
+		output.BankAccountBalances = append(output.BankAccountBalances, ArchiveBankAccountBalanceOutput{
+			ID:               bb.ID,
+			BankAccountID:    bb.BankAccountID,
+			Date:             bb.Date,
+			Balance:          bb.Balance,
+			Created:          bb.Created,
+			CreatedBy:        bb.CreatedBy,
+			Updated:          bb.Updated,
+			UpdatedBy:        bb.UpdatedBy,
+			Deleted:          bb.Deleted,
+			DeletedBy:        bb.DeletedBy,
+			DeletedByCascade: bb.DeletedByCascade,
+		})
+	}
+
+	for _, cf := range a.BankAccountCashFlows {
+		output.BankAccountCashFlows = append(output.BankAccountCashFlows, ArchiveBankAccountCashFlowOutput{
+			ID:            cf.ID,
+			BankAccountID: cf.BankAccountID,
+			Date:          cf.Date,
+			Type:          cf.Type,
+			Amount:        cf.Amount,
+			Note:          cf.Note,
+			Created:       cf.Created,
+			CreatedBy:     cf.CreatedBy,
+			Updated:       cf.Updated,
+			UpdatedBy:     cf.UpdatedBy,
+			Deleted:       cf.Deleted,
+			DeletedBy:     cf.DeletedBy,
+		})
+	}
+
+	for _, t := range a.Transactions {
+		output.Transactions = append(output.Transactions, ArchiveTransactionOutput{
+			ID:            t.ID,
+			BankAccountID: t.BankAccountID,
+			Date:          t.Date,
+			Direction:     t.Direction,
+			Amount:        t.Amount,
+			Payee:         t.Payee,
+			Memo:          t.Memo,
+			Category:      t.Category,
+			CategoryID:    t.CategoryID,
+			Created:       t.Created,
+			CreatedBy:     t.CreatedBy,
+			Updated:       t.Updated,
+			UpdatedBy:     t.UpdatedBy,
+			Deleted:       t.Deleted,
+			DeletedBy:     t.DeletedBy,
+		})
+	}
+
+	for _, t := range a.Transfers {
+		output.Transfers = append(output.Transfers, ArchiveTransferOutput{
+			ID:                t.ID,
+			FromBankAccountID: t.FromBankAccountID,
+			ToBankAccountID:   t.ToBankAccountID,
+			Date:              t.Date,
+			Amount:            t.Amount,
+			Note:              t.Note,
+			FromBalanceID:     t.FromBalanceID,
+			ToBalanceID:       t.ToBalanceID,
+			FromTransactionID: t.FromTransactionID,
+			ToTransactionID:   t.ToTransactionID,
+			Created:           t.Created,
+			CreatedBy:         t.CreatedBy,
+			Updated:           t.Updated,
+			UpdatedBy:         t.UpdatedBy,
+			Deleted:           t.Deleted,
+			DeletedBy:         t.DeletedBy,
+		})
+	}
+
+	for _, c := range a.Categories {
+		output.Categories = append(output.Categories, ArchiveCategoryOutput{
+			ID:        c.ID,
+			ParentID:  c.ParentID,
+			Name:      c.Name,
+			Created:   c.Created,
+			CreatedBy: c.CreatedBy,
+			Updated:   c.Updated,
+			UpdatedBy: c.UpdatedBy,
+			Deleted:   c.Deleted,
+			DeletedBy: c.DeletedBy,
+		})
+	}
+
+	for _, r := range a.CategoryRules {
+		output.CategoryRules = append(output.CategoryRules, ArchiveCategoryRuleOutput{
+			ID:         r.ID,
+			CategoryID: r.CategoryID,
+			Pattern:    r.Pattern,
+			Priority:   r.Priority,
+			Created:    r.Created,
+			CreatedBy:  r.CreatedBy,
+			Updated:    r.Updated,
+			UpdatedBy:  r.UpdatedBy,
+			Deleted:    r.Deleted,
+			DeletedBy:  r.DeletedBy,
+		})
+	}
+
+	for _, b := range a.Budgets {
+		output.Budgets = append(output.Budgets, ArchiveBudgetOutput{
+			ID:         b.ID,
+			CategoryID: b.CategoryID,
+			Month:      b.Month,
+			Amount:     b.Amount,
+			Created:    b.Created,
+			CreatedBy:  b.CreatedBy,
+			Updated:    b.Updated,
+			UpdatedBy:  b.UpdatedBy,
+			Deleted:    b.Deleted,
+			DeletedBy:  b.DeletedBy,
+		})
+	}
+
+	for _, g := range a.Goals {
+		output.Goals = append(output.Goals, ArchiveGoalOutput{
+			ID:           g.ID,
+			Name:         g.Name,
+			TargetAmount: g.TargetAmount,
+			TargetDate:   g.TargetDate,
+			Created:      g.Created,
+			CreatedBy:    g.CreatedBy,
+			Updated:      g.Updated,
+			UpdatedBy:    g.UpdatedBy,
+			Deleted:      g.Deleted,
+			DeletedBy:    g.DeletedBy,
+		})
+	}
+
+	for _, gba := range a.GoalBankAccounts {
+		output.GoalBankAccounts = append(output.GoalBankAccounts, ArchiveGoalBankAccountOutput{
+			GoalID:        gba.GoalID,
+			BankAccountID: gba.BankAccountID,
+		})
+	}
+
+	for _, v := range a.Vehicles {
+		output.Vehicles = append(output.Vehicles, ArchiveVehicleOutput{
+			ID:                        v.ID,
+			Name:                      v.Name,
+			Make:                      v.Make,
+			Model:                     v.Model,
+			Year:                      v.Year,
+			Type:                      v.Type,
+			TitleHolder:               v.TitleHolder,
+			LicensePlateNumber:        v.LicensePlateNumber,
+			PurchaseDate:              v.PurchaseDate,
+			InitialValue:              v.InitialValue,
+			InitialValueDate:          v.InitialValueDate,
+			CurrentValue:              v.CurrentValue,
+			CurrentValueDate:          v.CurrentValueDate,
+			AnnualDepreciationPercent: v.AnnualDepreciationPercent,
+			Status:                    v.Status,
+			Created:                   v.Created,
+			CreatedBy:                 v.CreatedBy,
+			Updated:                   v.Updated,
+			UpdatedBy:                 v.UpdatedBy,
+			Deleted:                   v.Deleted,
+			DeletedBy:                 v.DeletedBy,
+		})
+	}
+
+	for _, vv := range a.VehicleValues {
+		output.VehicleValues = append(output.VehicleValues, ArchiveVehicleValueOutput{
+			ID:               vv.ID,
+			VehicleID:        vv.VehicleID,
+			Date:             vv.Date,
+			Value:            vv.Value,
+			Created:          vv.Created,
+			CreatedBy:        vv.CreatedBy,
+			Updated:          vv.Updated,
+			UpdatedBy:        vv.UpdatedBy,
+			Deleted:          vv.Deleted,
+			DeletedBy:        vv.DeletedBy,
+			DeletedByCascade: vv.DeletedByCascade,
+		})
+	}
+
+	for _, p := range a.Properties {
+		output.Properties = append(output.Properties, ArchivePropertyOutput{
+			ID:                        p.ID,
+			Name:                      p.Name,
+			Address:                   p.Address,
+			TotalArea:                 p.TotalArea,
+			BuildingArea:              p.BuildingArea,
+			AreaUnit:                  p.AreaUnit,
+			Type:                      p.Type,
+			TitleHolder:               p.TitleHolder,
+			TaxIdentifier:             p.TaxIdentifier,
+			PurchaseDate:              p.PurchaseDate,
+			InitialValue:              p.InitialValue,
+			InitialValueDate:          p.InitialValueDate,
+			CurrentValue:              p.CurrentValue,
+			CurrentValueDate:          p.CurrentValueDate,
+			AnnualAppreciationPercent: p.AnnualAppreciationPercent,
+			Status:                    p.Status,
+			Created:                   p.Created,
+			CreatedBy:                 p.CreatedBy,
+			Updated:                   p.Updated,
+			UpdatedBy:                 p.UpdatedBy,
+			Deleted:                   p.Deleted,
+			DeletedBy:                 p.DeletedBy,
+		})
+	}
+
+	for _, pv := range a.PropertyValues {
+		output.PropertyValues = append(output.PropertyValues, ArchivePropertyValueOutput{
+			ID:               pv.ID,
+			PropertyID:       pv.PropertyID,
+			Date:             pv.Date,
+			Value:            pv.Value,
+			Created:          pv.Created,
+			CreatedBy:        pv.CreatedBy,
+			Updated:          pv.Updated,
+			UpdatedBy:        pv.UpdatedBy,
+			Deleted:          pv.Deleted,
+			DeletedBy:        pv.DeletedBy,
+			DeletedByCascade: pv.DeletedByCascade,
+		})
+	}
+
+	for _, t := range a.Tags {
+		output.Tags = append(output.Tags, ArchiveTagOutput{
+			ID:        t.ID,
+			Name:      t.Name,
+			Created:   t.Created,
+			CreatedBy: t.CreatedBy,
+			Updated:   t.Updated,
+			UpdatedBy: t.UpdatedBy,
+			Deleted:   t.Deleted,
+			DeletedBy: t.DeletedBy,
+		})
+	}
+
+	for _, et := range a.EntityTags {
+		output.EntityTags = append(output.EntityTags, ArchiveEntityTagOutput{
+			TagID:      et.TagID,
+			EntityType: et.EntityType,
+			SubjectID:  et.SubjectID,
+		})
+	}
+
+	for _, cf := range a.CustomFields {
+		output.CustomFields = append(output.CustomFields, ArchiveCustomFieldOutput{
+			ID:         cf.ID,
+			EntityType: cf.EntityType,
+			Name:       cf.Name,
+			Type:       cf.Type,
+			Created:    cf.Created,
+			CreatedBy:  cf.CreatedBy,
+			Updated:    cf.Updated,
+			UpdatedBy:  cf.UpdatedBy,
+			Deleted:    cf.Deleted,
+			DeletedBy:  cf.DeletedBy,
+		})
+	}
+
+	for _, cfv := range a.CustomFieldValues {
+		output.CustomFieldValues = append(output.CustomFieldValues, ArchiveCustomFieldValueOutput{
+			CustomFieldID: cfv.CustomFieldID,
+			SubjectID:     cfv.SubjectID,
+			Value:         cfv.Value,
+		})
+	}
+
+	for _, n := range a.Notes {
+		output.Notes = append(output.Notes, ArchiveNoteOutput{
+			ID:         n.ID,
+			EntityType: n.EntityType,
+			SubjectID:  n.SubjectID,
+			Content:    n.Content,
+			Created:    n.Created,
+			CreatedBy:  n.CreatedBy,
+			Updated:    n.Updated,
+			UpdatedBy:  n.UpdatedBy,
+			Deleted:    n.Deleted,
+			DeletedBy:  n.DeletedBy,
+		})
+	}
+
+	for _, att := range a.Attachments {
+		output.Attachments = append(output.Attachments, ArchiveAttachmentOutput{
+			ID:          att.ID,
+			EntityType:  att.EntityType,
+			SubjectID:   att.SubjectID,
+			FileName:    att.FileName,
+			ContentType: att.ContentType,
+			Size:        att.Size,
+			Checksum:    att.Checksum,
+			Created:     att.Created,
+			CreatedBy:   att.CreatedBy,
+			Updated:     att.Updated,
+			UpdatedBy:   att.UpdatedBy,
+			Deleted:     att.Deleted,
+			DeletedBy:   att.DeletedBy,
+		})
+	}
+
+	for id, content := range a.AttachmentContents {
+		output.AttachmentContents[id] = content
+	}
+
+	return output
+}
+
+// ArchiveOutput is the portable object representation of Archive. Unlike other outputs it is read back when
+// an archive is restored, and its timestamps are written as RFC 3339 so that it does not depend on this API.
+type ArchiveOutput struct {
+	Version              int                                `json:"version"`
+	Exported             time.Time                          `json:"exported"`
+	Users                []ArchiveUserOutput                `json:"users"`
+	BankAccounts         []ArchiveBankAccountOutput         `json:"bankAccounts"`
+	BankAccountBalances  []ArchiveBankAccountBalanceOutput  `json:"bankAccountBalances"`
+	BankAccountCashFlows []ArchiveBankAccountCashFlowOutput `json:"bankAccountCashFlows"`
+	Transactions         []ArchiveTransactionOutput         `json:"transactions"`
+	Transfers            []ArchiveTransferOutput            `json:"transfers"`
+	Categories           []ArchiveCategoryOutput            `json:"categories"`
+	CategoryRules        []ArchiveCategoryRuleOutput        `json:"categoryRules"`
+	Budgets              []ArchiveBudgetOutput              `json:"budgets"`
+	Goals                []ArchiveGoalOutput                `json:"goals"`
+	GoalBankAccounts     []ArchiveGoalBankAccountOutput     `json:"goalBankAccounts"`
+	Vehicles             []ArchiveVehicleOutput             `json:"vehicles"`
+	VehicleValues        []ArchiveVehicleValueOutput        `json:"vehicleValues"`
+	Properties           []ArchivePropertyOutput            `json:"properties"`
+	PropertyValues       []ArchivePropertyValueOutput       `json:"propertyValues"`
+	Tags                 []ArchiveTagOutput                 `json:"tags"`
+	EntityTags           []ArchiveEntityTagOutput           `json:"entityTags"`
+	CustomFields         []ArchiveCustomFieldOutput         `json:"customFields"`
+	CustomFieldValues    []ArchiveCustomFieldValueOutput    `json:"customFieldValues"`
+	Notes                []ArchiveNoteOutput                `json:"notes"`
+	Attachments          []ArchiveAttachmentOutput          `json:"attachments"`
+	// AttachmentContents holds the content of every Attachment keyed by its ID, which ZIP archives hold as
+	// files of their own rather than in a CSV file
+	AttachmentContents map[uuid.UUID][]byte `json:"attachmentContents"`
+}
+
+// ArchiveUserOutput is the portable object representation of User
+type ArchiveUserOutput struct {
+	ID        uuid.UUID   `json:"id"`
+	Username  string      `json:"username"`
+	Email     string      `json:"email"`
+	Name      string      `json:"name"`
+	Created   time.Time   `json:"created"`
+	CreatedBy uuid.UUID   `json:"createdBy"`
+	Updated   null.Time   `json:"updated"`
+	UpdatedBy nuuid.NUUID `json:"updatedBy"`
+}
+
+// ArchiveBankAccountOutput is the portable object representation of Bank Account
+type ArchiveBankAccountOutput struct {
+	ID                uuid.UUID         `json:"id"`
+	AccountName       string            `json:"accountName"`
+	BankName          string            `json:"bankName"`
+	AccountHolderName string            `json:"accountHolderName"`
+	AccountNumber     string            `json:"accountNumber"`
+	LastBalance       float64           `json:"lastBalance"`
+	LastBalanceDate   time.Time         `json:"lastBalanceDate"`
+	Status            BankAccountStatus `json:"status"`
+	Created           time.Time         `json:"created"`
+	CreatedBy         uuid.UUID         `json:"createdBy"`
+	Updated           null.Time         `json:"updated"`
+	UpdatedBy         nuuid.NUUID       `json:"updatedBy"`
+	Deleted           null.Time         `json:"deleted"`
+	DeletedBy         nuuid.NUUID       `json:"deletedBy"`
+}
+
+// ArchiveBankAccountBalanceOutput is the portable object representation of Bank Account Balance
+type ArchiveBankAccountBalanceOutput struct {
+	ID               uuid.UUID   `json:"id"`
+	BankAccountID    uuid.UUID   `json:"bankAccountId"`
+	Date             time.Time   `json:"date"`
+	Balance          float64     `json:"balance"`
+	Created          time.Time   `json:"created"`
+	CreatedBy        uuid.UUID   `json:"createdBy"`
+	Updated          null.Time   `json:"updated"`
+	UpdatedBy        nuuid.NUUID `json:"updatedBy"`
+	Deleted          null.Time   `json:"deleted"`
+	DeletedBy        nuuid.NUUID `json:"deletedBy"`
+	DeletedByCascade bool        `json:"deletedByCascade" since:"10"`
+}
+
+// ArchiveBankAccountCashFlowOutput is the portable object representation of Bank Account Cash Flow
+type ArchiveBankAccountCashFlowOutput struct {
+	ID            uuid.UUID               `json:"id"`
+	BankAccountID uuid.UUID               `json:"bankAccountId"`
+	Date          time.Time               `json:"date"`
+	Type          BankAccountCashFlowType `json:"type"`
+	Amount        float64                 `json:"amount"`
+	Note          string                  `json:"note"`
+	Created       time.Time               `json:"created"`
+	CreatedBy     uuid.UUID               `json:"createdBy"`
+	Updated       null.Time               `json:"updated"`
+	UpdatedBy     nuuid.NUUID             `json:"updatedBy"`
+	Deleted       null.Time               `json:"deleted"`
+	DeletedBy     nuuid.NUUID             `json:"deletedBy"`
+}
+
+// ArchiveTransactionOutput is the portable object representation of Transaction
+type ArchiveTransactionOutput struct {
+	ID            uuid.UUID            `json:"id"`
+	BankAccountID uuid.UUID            `json:"bankAccountId"`
+	Date          time.Time            `json:"date"`
+	Direction     TransactionDirection `json:"direction"`
+	Amount        float64              `json:"amount"`
+	Payee         string               `json:"payee"`
+	Memo          string               `json:"memo"`
+	Category      string               `json:"category"`
+	CategoryID    nuuid.NUUID          `json:"categoryId" since:"11"`
+	Created       time.Time            `json:"created"`
+	CreatedBy     uuid.UUID            `json:"createdBy"`
+	Updated       null.Time            `json:"updated"`
+	UpdatedBy     nuuid.NUUID          `json:"updatedBy"`
+	Deleted       null.Time            `json:"deleted"`
+	DeletedBy     nuuid.NUUID          `json:"deletedBy"`
+}
+
+// ArchiveTransferOutput is the portable object representation of Transfer
+type ArchiveTransferOutput struct {
+	ID                uuid.UUID   `json:"id"`
+	FromBankAccountID uuid.UUID   `json:"fromBankAccountId"`
+	ToBankAccountID   uuid.UUID   `json:"toBankAccountId"`
+	Date              time.Time   `json:"date"`
+	Amount            float64     `json:"amount"`
+	Note              string      `json:"note"`
+	FromBalanceID     uuid.UUID   `json:"fromBalanceId"`
+	ToBalanceID       uuid.UUID   `json:"toBalanceId"`
+	FromTransactionID uuid.UUID   `json:"fromTransactionId"`
+	ToTransactionID   uuid.UUID   `json:"toTransactionId"`
+	Created           time.Time   `json:"created"`
+	CreatedBy         uuid.UUID   `json:"createdBy"`
+	Updated           null.Time   `json:"updated"`
+	UpdatedBy         nuuid.NUUID `json:"updatedBy"`
+	Deleted           null.Time   `json:"deleted"`
+	DeletedBy         nuuid.NUUID `json:"deletedBy"`
+}
+
+// ArchiveCategoryOutput is the portable object representation of Category
+type ArchiveCategoryOutput struct {
+	ID        uuid.UUID   `json:"id"`
+	ParentID  nuuid.NUUID `json:"parentId"`
+	Name      string      `json:"name"`
+	Created   time.Time   `json:"created"`
+	CreatedBy uuid.UUID   `json:"createdBy"`
+	Updated   null.Time   `json:"updated"`
+	UpdatedBy nuuid.NUUID `json:"updatedBy"`
+	Deleted   null.Time   `json:"deleted"`
+	DeletedBy nuuid.NUUID `json:"deletedBy"`
+}
+
+// ArchiveCategoryRuleOutput is the portable object representation of Category Rule
+type ArchiveCategoryRuleOutput struct {
+	ID         uuid.UUID   `json:"id"`
+	CategoryID uuid.UUID   `json:"categoryId"`
+	Pattern    string      `json:"pattern"`
+	Priority   int         `json:"priority"`
+	Created    time.Time   `json:"created"`
+	CreatedBy  uuid.UUID   `json:"createdBy"`
+	Updated    null.Time   `json:"updated"`
+	UpdatedBy  nuuid.NUUID `json:"updatedBy"`
+	Deleted    null.Time   `json:"deleted"`
+	DeletedBy  nuuid.NUUID `json:"deletedBy"`
+}
+
+// ArchiveBudgetOutput is the portable object representation of Budget
+type ArchiveBudgetOutput struct {
+	ID         uuid.UUID   `json:"id"`
+	CategoryID uuid.UUID   `json:"categoryId"`
+	Month      time.Time   `json:"month"`
+	Amount     float64     `json:"amount"`
+	Created    time.Time   `json:"created"`
+	CreatedBy  uuid.UUID   `json:"createdBy"`
+	Updated    null.Time   `json:"updated"`
+	UpdatedBy  nuuid.NUUID `json:"updatedBy"`
+	Deleted    null.Time   `json:"deleted"`
+	DeletedBy  nuuid.NUUID `json:"deletedBy"`
+}
+
+// ArchiveGoalOutput is the portable object representation of Goal
+type ArchiveGoalOutput struct {
+	ID           uuid.UUID   `json:"id"`
+	Name         string      `json:"name"`
+	TargetAmount float64     `json:"targetAmount"`
+	TargetDate   time.Time   `json:"targetDate"`
+	Created      time.Time   `json:"created"`
+	CreatedBy    uuid.UUID   `json:"createdBy"`
+	Updated      null.Time   `json:"updated"`
+	UpdatedBy    nuuid.NUUID `json:"updatedBy"`
+	Deleted      null.Time   `json:"deleted"`
+	DeletedBy    nuuid.NUUID `json:"deletedBy"`
+}
+
+// ArchiveGoalBankAccountOutput is the portable object representation of Goal Bank Account
+type ArchiveGoalBankAccountOutput struct {
+	GoalID        uuid.UUID `json:"goalId"`
+	BankAccountID uuid.UUID `json:"bankAccountId"`
+}
+
+// ArchiveVehicleOutput is the portable object representation of Vehicle
+type ArchiveVehicleOutput struct {
+	ID                        uuid.UUID     `json:"id"`
+	Name                      string        `json:"name"`
+	Make                      string        `json:"make"`
+	Model                     string        `json:"model"`
+	Year                      int           `json:"year"`
+	Type                      VehicleType   `json:"type"`
+	TitleHolder               string        `json:"titleHolder"`
+	LicensePlateNumber        string        `json:"licensePlateNumber"`
+	PurchaseDate              time.Time     `json:"purchaseDate"`
+	InitialValue              float64       `json:"initialValue"`
+	InitialValueDate          time.Time     `json:"initialValueDate"`
+	CurrentValue              float64       `json:"currentValue"`
+	CurrentValueDate          time.Time     `json:"currentValueDate"`
+	AnnualDepreciationPercent float64       `json:"annualDepreciationPercent"`
+	Status                    VehicleStatus `json:"status"`
+	Created                   time.Time     `json:"created"`
+	CreatedBy                 uuid.UUID     `json:"createdBy"`
+	Updated                   null.Time     `json:"updated"`
+	UpdatedBy                 nuuid.NUUID   `json:"updatedBy"`
+	Deleted                   null.Time     `json:"deleted"`
+	DeletedBy                 nuuid.NUUID   `json:"deletedBy"`
+}
+
+// ArchiveVehicleValueOutput is the portable object representation of Vehicle Value
+type ArchiveVehicleValueOutput struct {
+	ID               uuid.UUID   `json:"id"`
+	VehicleID        uuid.UUID   `json:"vehicleId"`
+	Date             time.Time   `json:"date"`
+	Value            float64     `json:"value"`
+	Created          time.Time   `json:"created"`
+	CreatedBy        uuid.UUID   `json:"createdBy"`
+	Updated          null.Time   `json:"updated"`
+	UpdatedBy        nuuid.NUUID `json:"updatedBy"`
+	Deleted          null.Time   `json:"deleted"`
+	DeletedBy        nuuid.NUUID `json:"deletedBy"`
+	DeletedByCascade bool        `json:"deletedByCascade" since:"10"`
+}
+
+// ArchivePropertyOutput is the portable object representation of Property
+type ArchivePropertyOutput struct {
+	ID                        uuid.UUID        `json:"id"`
+	Name                      string           `json:"name"`
+	Address                   string           `json:"address"`
+	TotalArea                 float64          `json:"totalArea"`
+	BuildingArea              float64          `json:"buildingArea"`
+	AreaUnit                  PropertyAreaUnit `json:"areaUnit"`
+	Type                      PropertyType     `json:"type"`
+	TitleHolder               string           `json:"titleHolder"`
+	TaxIdentifier             string           `json:"taxIdentifier"`
+	PurchaseDate              time.Time        `json:"purchaseDate"`
+	InitialValue              float64          `json:"initialValue"`
+	InitialValueDate          time.Time        `json:"initialValueDate"`
+	CurrentValue              float64          `json:"currentValue"`
+	CurrentValueDate          time.Time        `json:"currentValueDate"`
+	AnnualAppreciationPercent float64          `json:"annualAppreciationPercent"`
+	Status                    PropertyStatus   `json:"status"`
+	Created                   time.Time        `json:"created"`
+	CreatedBy                 uuid.UUID        `json:"createdBy"`
+	Updated                   null.Time        `json:"updated"`
+	UpdatedBy                 nuuid.NUUID      `json:"updatedBy"`
+	Deleted                   null.Time        `json:"deleted"`
+	DeletedBy                 nuuid.NUUID      `json:"deletedBy"`
+}
+
+// ArchivePropertyValueOutput is the portable object representation of Property Value
+type ArchivePropertyValueOutput struct {
+	ID               uuid.UUID   `json:"id"`
+	PropertyID       uuid.UUID   `json:"propertyId"`
+	Date             time.Time   `json:"date"`
+	Value            float64     `json:"value"`
+	Created          time.Time   `json:"created"`
+	CreatedBy        uuid.UUID   `json:"createdBy"`
+	Updated          null.Time   `json:"updated"`
+	UpdatedBy        nuuid.NUUID `json:"updatedBy"`
+	Deleted          null.Time   `json:"deleted"`
+	DeletedBy        nuuid.NUUID `json:"deletedBy"`
+	DeletedByCascade bool        `json:"deletedByCascade" since:"10"`
+}
+
+// ArchiveTagOutput is the portable object representation of Tag
+type ArchiveTagOutput struct {
+	ID        uuid.UUID   `json:"id"`
+	Name      string      `json:"name"`
+	Created   time.Time   `json:"created"`
+	CreatedBy uuid.UUID   `json:"createdBy"`
+	Updated   null.Time   `json:"updated"`
+	UpdatedBy nuuid.NUUID `json:"updatedBy"`
+	Deleted   null.Time   `json:"deleted"`
+	DeletedBy nuuid.NUUID `json:"deletedBy"`
+}
+
+// ArchiveEntityTagOutput is the portable object representation of Entity Tag
+type ArchiveEntityTagOutput struct {
+	TagID      uuid.UUID  `json:"tagId"`
+	EntityType EntityType `json:"entityType"`
+	SubjectID  uuid.UUID  `json:"subjectId"`
+}
+
+// ArchiveCustomFieldOutput is the portable object representation of Custom Field
+type ArchiveCustomFieldOutput struct {
+	ID         uuid.UUID       `json:"id"`
+	EntityType EntityType      `json:"entityType"`
+	Name       string          `json:"name"`
+	Type       CustomFieldType `json:"type"`
+	Created    time.Time       `json:"created"`
+	CreatedBy  uuid.UUID       `json:"createdBy"`
+	Updated    null.Time       `json:"updated"`
+	UpdatedBy  nuuid.NUUID     `json:"updatedBy"`
+	Deleted    null.Time       `json:"deleted"`
+	DeletedBy  nuuid.NUUID     `json:"deletedBy"`
+}
+
+// ArchiveCustomFieldValueOutput is the portable object representation of Custom Field Value
+type ArchiveCustomFieldValueOutput struct {
+	CustomFieldID uuid.UUID `json:"customFieldId"`
+	SubjectID     uuid.UUID `json:"subjectId"`
+	Value         string    `json:"value"`
+}
+
+// ArchiveNoteOutput is the portable object representation of Note
+type ArchiveNoteOutput struct {
+	ID         uuid.UUID   `json:"id"`
+	EntityType EntityType  `json:"entityType"`
+	SubjectID  uuid.UUID   `json:"subjectId"`
+	Content    string      `json:"content"`
+	Created    time.Time   `json:"created"`
+	CreatedBy  uuid.UUID   `json:"createdBy"`
+	Updated    null.Time   `json:"updated"`
+	UpdatedBy  nuuid.NUUID `json:"updatedBy"`
+	Deleted    null.Time   `json:"deleted"`
+	DeletedBy  nuuid.NUUID `json:"deletedBy"`
+}
+
+// ArchiveAttachmentOutput is the portable object representation of Attachment
+type ArchiveAttachmentOutput struct {
+	ID          uuid.UUID   `json:"id"`
+	EntityType  EntityType  `json:"entityType"`
+	SubjectID   uuid.UUID   `json:"subjectId"`
+	FileName    string      `json:"fileName"`
+	ContentType string      `json:"contentType"`
+	Size        int64       `json:"size"`
+	Checksum    string      `json:"checksum"`
+	Created     time.Time   `json:"created"`
+	CreatedBy   uuid.UUID   `json:"createdBy"`
+	Updated     null.Time   `json:"updated"`
+	UpdatedBy   nuuid.NUUID `json:"updatedBy"`
+	Deleted     null.Time   `json:"deleted"`
+	DeletedBy   nuuid.NUUID `json:"deletedBy"`
+}
+
+// ToArchive converts the portable representation of an Archive back to an Archive
+func (o *ArchiveOutput) ToArchive() Archive {
+	archive := NewArchive()
+	archive.Version = o.Version
+	archive.Exported = o.Exported
+
+	for _, u := range o.Users {
+		archive.Users = append(archive.Users, User{
+			ID:        u.ID,
+			Username:  u.Username,
+			Email:     u.Email,
+			Name:      u.Name,
+			Created:   u.Created,
+			CreatedBy: u.CreatedBy,
+			Updated:   u.Updated,
+			UpdatedBy: u.UpdatedBy,
+		})
+	}
+
+	for _, b := range o.BankAccounts {
+		archive.BankAccounts = append(archive.BankAccounts, BankAccount{
+			ID:                b.ID,
+			AccountName:       b.AccountName,
+			BankName:          b.BankName,
+			AccountHolderName: b.AccountHolderName,
+			AccountNumber:     b.AccountNumber,
+			LastBalance:       b.LastBalance,
+			LastBalanceDate:   b.LastBalanceDate,
+			Status:            b.Status,
+			Created:           b.Created,
+			CreatedBy:         b.CreatedBy,
+			Updated:           b.Updated,
+			UpdatedBy:         b.UpdatedBy,
+			Deleted:           b.Deleted,
+			DeletedBy:         b.DeletedBy,
+		})
+	}
+
+	for _, bb := range o.BankAccountBalances {
+		archive.BankAccountBalances = append(archive.BankAccountBalances, BankAccountBalance{
+			ID:               bb.ID,
+			BankAccountID:    bb.BankAccountID,
+			Date:             bb.Date,
+			Balance:          bb.Balance,
+			Created:          bb.Created,
+			CreatedBy:        bb.CreatedBy,
+			Updated:          bb.Updated,
+			UpdatedBy:        bb.UpdatedBy,
+			Deleted:          bb.Deleted,
+			DeletedBy:        bb.DeletedBy,
+			DeletedByCascade: bb.DeletedByCascade,
+		})
+	}
+
+	for _, cf := range o.BankAccountCashFlows {
+		archive.BankAccountCashFlows = append(archive.BankAccountCashFlows, BankAccountCashFlow{
+			ID:            cf.ID,
+			BankAccountID: cf.BankAccountID,
+			Date:          cf.Date,
+			Type:          cf.Type,
+			Amount:        cf.Amount,
+			Note:          cf.Note,
+			Created:       cf.Created,
+			CreatedBy:     cf.CreatedBy,
+			Updated:       cf.Updated,
+			UpdatedBy:     cf.UpdatedBy,
+			Deleted:       cf.Deleted,
+			DeletedBy:     cf.DeletedBy,
+		})
+	}
+
+	for _, t := range o.Transactions {
+		archive.Transactions = append(archive.Transactions, Transaction{
+			ID:            t.ID,
+			BankAccountID: t.BankAccountID,
+			Date:          t.Date,
+			Direction:     t.Direction,
+			Amount:        t.Amount,
+			Payee:         t.Payee,
+			Memo:          t.Memo,
+			Category:      t.Category,
+			CategoryID:    t.CategoryID,
+			Created:       t.Created,
+			CreatedBy:     t.CreatedBy,
+			Updated:       t.Updated,
+			UpdatedBy:     t.UpdatedBy,
+			Deleted:       t.Deleted,
+			DeletedBy:     t.DeletedBy,
+		})
+	}
+
+	for _, t := range o.Transfers {
+		archive.Transfers = append(archive.Transfers, Transfer{
+			ID:                t.ID,
+			FromBankAccountID: t.FromBankAccountID,
+			ToBankAccountID:   t.ToBankAccountID,
+			Date:              t.Date,
+			Amount:            t.Amount,
+			Note:              t.Note,
+			FromBalanceID:     t.FromBalanceID,
+			ToBalanceID:       t.ToBalanceID,
+			FromTransactionID: t.FromTransactionID,
+			ToTransactionID:   t.ToTransactionID,
+			Created:           t.Created,
+			CreatedBy:         t.CreatedBy,
+			Updated:           t.Updated,
+			UpdatedBy:         t.UpdatedBy,
+			Deleted:           t.Deleted,
+			DeletedBy:         t.DeletedBy,
+		})
+	}
+
+	for _, c := range o.Categories {
+		archive.Categories = append(archive.Categories, Category{
+			ID:        c.ID,
+			ParentID:  c.ParentID,
+			Name:      c.Name,
+			Created:   c.Created,
+			CreatedBy: c.CreatedBy,
+			Updated:   c.Updated,
+			UpdatedBy: c.UpdatedBy,
+			Deleted:   c.Deleted,
+			DeletedBy: c.DeletedBy,
+		})
+	}
+
+	for _, r := range o.CategoryRules {
+		archive.CategoryRules = append(archive.CategoryRules, CategoryRule{
+			ID:         r.ID,
+			CategoryID: r.CategoryID,
+			Pattern:    r.Pattern,
+			Priority:   r.Priority,
+			Created:    r.Created,
+			CreatedBy:  r.CreatedBy,
+			Updated:    r.Updated,
+			UpdatedBy:  r.UpdatedBy,
+			Deleted:    r.Deleted,
+			DeletedBy:  r.DeletedBy,
+		})
+	}
+
+	for _, b := range o.Budgets {
+		archive.Budgets = append(archive.Budgets, Budget{
+			ID:         b.ID,
+			CategoryID: b.CategoryID,
+			Month:      b.Month,
+			Amount:     b.Amount,
+			Created:    b.Created,
+			CreatedBy:  b.CreatedBy,
+			Updated:    b.Updated,
+			UpdatedBy:  b.UpdatedBy,
+			Deleted:    b.Deleted,
+			DeletedBy:  b.DeletedBy,
+		})
+	}
+
+	for _, g := range o.Goals {
+		archive.Goals = append(archive.Goals, Goal{
+			ID:           g.ID,
+			Name:         g.Name,
+			TargetAmount: g.TargetAmount,
+			TargetDate:   g.TargetDate,
+			Created:      g.Created,
+			CreatedBy:    g.CreatedBy,
+			Updated:      g.Updated,
+			UpdatedBy:    g.UpdatedBy,
+			Deleted:      g.Deleted,
+			DeletedBy:    g.DeletedBy,
+		})
+	}
+
+	for _, gba := range o.GoalBankAccounts {
+		archive.GoalBankAccounts = append(archive.GoalBankAccounts, GoalBankAccount{
+			GoalID:        gba.GoalID,
+			BankAccountID: gba.BankAccountID,
+		})
+	}
+
+	for _, v := range o.Vehicles {
+		archive.Vehicles = append(archive.Vehicles, Vehicle{
+			ID:                        v.ID,
+			Name:                      v.Name,
+			Make:                      v.Make,
+			Model:                     v.Model,
+			Year:                      v.Year,
+			Type:                      v.Type,
+			TitleHolder:               v.TitleHolder,
+			LicensePlateNumber:        v.LicensePlateNumber,
+			PurchaseDate:              v.PurchaseDate,
+			InitialValue:              v.InitialValue,
+			InitialValueDate:          v.InitialValueDate,
+			CurrentValue:              v.CurrentValue,
+			CurrentValueDate:          v.CurrentValueDate,
+			AnnualDepreciationPercent: v.AnnualDepreciationPercent,
+			Status:                    v.Status,
+			Created:                   v.Created,
+			CreatedBy:                 v.CreatedBy,
+			Updated:                   v.Updated,
+			UpdatedBy:                 v.UpdatedBy,
+			Deleted:                   v.Deleted,
+			DeletedBy:                 v.DeletedBy,
+		})
+	}
+
+	for _, vv := range o.VehicleValues {
+		archive.VehicleValues = append(archive.VehicleValues, VehicleValue{
+			ID:               vv.ID,
+			VehicleID:        vv.VehicleID,
+			Date:             vv.Date,
+			Value:            vv.Value,
+			Created:          vv.Created,
+			CreatedBy:        vv.CreatedBy,
+			Updated:          vv.Updated,
+			UpdatedBy:        vv.UpdatedBy,
+			Deleted:          vv.Deleted,
+			DeletedBy:        vv.DeletedBy,
+			DeletedByCascade: vv.DeletedByCascade,
+		})
+	}
+
+	for _, p := range o.Properties {
+		archive.Properties = append(archive.Properties, Property{
+			ID:                        p.ID,
+			Name:                      p.Name,
+			Address:                   p.Address,
+			TotalArea:                 p.TotalArea,
+			BuildingArea:              p.BuildingArea,
+			AreaUnit:                  p.AreaUnit,
+			Type:                      p.Type,
+			TitleHolder:               p.TitleHolder,
+			TaxIdentifier:             p.TaxIdentifier,
+			PurchaseDate:              p.PurchaseDate,
+			InitialValue:              p.InitialValue,
+			InitialValueDate:          p.InitialValueDate,
+			CurrentValue:              p.CurrentValue,
+			CurrentValueDate:          p.CurrentValueDate,
+			AnnualAppreciationPercent: p.AnnualAppreciationPercent,
+			Status:                    p.Status,
+			Created:                   p.Created,
+			CreatedBy:                 p.CreatedBy,
+			Updated:                   p.Updated,
+			UpdatedBy:                 p.UpdatedBy,
+			Deleted:                   p.Deleted,
+			DeletedBy:                 p.DeletedBy,
+		})
+	}
+
+	for _, pv := range o.PropertyValues {
+		archive.PropertyValues = append(archive.PropertyValues, PropertyValue{
+			ID:               pv.ID,
+			PropertyID:       pv.PropertyID,
+			Date:             pv.Date,
+			Value:            pv.Value,
+			Created:          pv.Created,
+			CreatedBy:        pv.CreatedBy,
+			Updated:          pv.Updated,
+			UpdatedBy:        pv.UpdatedBy,
+			Deleted:          pv.Deleted,
+			DeletedBy:        pv.DeletedBy,
+			DeletedByCascade: pv.DeletedByCascade,
+		})
+	}
+
+	for _, t := range o.Tags {
+		archive.Tags = append(archive.Tags, Tag{
+			ID:        t.ID,
+			Name:      t.Name,
+			Created:   t.Created,
+			CreatedBy: t.CreatedBy,
+			Updated:   t.Updated,
+			UpdatedBy: t.UpdatedBy,
+			Deleted:   t.Deleted,
+			DeletedBy: t.DeletedBy,
+		})
+	}
+
+	for _, et := range o.EntityTags {
+		archive.EntityTags = append(archive.EntityTags, EntityTag{
+			TagID:      et.TagID,
+			EntityType: et.EntityType,
+			SubjectID:  et.SubjectID,
+		})
+	}
+
+	for _, cf := range o.CustomFields {
+		archive.CustomFields = append(archive.CustomFields, CustomField{
+			ID:         cf.ID,
+			EntityType: cf.EntityType,
+			Name:       cf.Name,
+			Type:       cf.Type,
+			Created:    cf.Created,
+			CreatedBy:  cf.CreatedBy,
+			Updated:    cf.Updated,
+			UpdatedBy:  cf.UpdatedBy,
+			Deleted:    cf.Deleted,
+			DeletedBy:  cf.DeletedBy,
+		})
+	}
+
+	for _, cfv := range o.CustomFieldValues {
+		archive.CustomFieldValues = append(archive.CustomFieldValues, CustomFieldValue{
+			CustomFieldID: cfv.CustomFieldID,
+			SubjectID:     cfv.SubjectID,
+			Value:         cfv.Value,
+		})
+	}
+
+	for _, n := range o.Notes {
+		archive.Notes = append(archive.Notes, Note{
+			ID:         n.ID,
+			EntityType: n.EntityType,
+			SubjectID:  n.SubjectID,
+			Content:    n.Content,
+			Created:    n.Created,
+			CreatedBy:  n.CreatedBy,
+			Updated:    n.Updated,
+			UpdatedBy:  n.UpdatedBy,
+			Deleted:    n.Deleted,
+			DeletedBy:  n.DeletedBy,
+		})
+	}
+
+	for _, att := range o.Attachments {
+		archive.Attachments = append(archive.Attachments, Attachment{
+			ID:          att.ID,
+			EntityType:  att.EntityType,
+			SubjectID:   att.SubjectID,
+			FileName:    att.FileName,
+			ContentType: att.ContentType,
+			Size:        att.Size,
+			Checksum:    att.Checksum,
+			Created:     att.Created,
+			CreatedBy:   att.CreatedBy,
+			Updated:     att.Updated,
+			UpdatedBy:   att.UpdatedBy,
+			Deleted:     att.Deleted,
+			DeletedBy:   att.DeletedBy,
+		})
+	}
+
+	for id, content := range o.AttachmentContents {
+		archive.AttachmentContents[id] = content
+	}
+
+	if archive.Version < 10 {
+		archive.markDeletedByCascade()
+	}
+
+	if archive.Version < 11 {
+		archive.linkTransactionCategories()
+	}
+
+	return archive
+}
+
+// markDeletedByCascade marks the history deleted along with its asset in archives written before the marker
+// existed, which can only tell it apart by its deletion time and user
+func (a *Archive) markDeletedByCascade() {
+	bankAccounts := make(map[uuid.UUID]BankAccount, len(a.BankAccounts))
+	for _, b := range a.BankAccounts {
+		bankAccounts[b.ID] = b
+	}
+	for idx, bb := range a.BankAccountBalances {
+		b := bankAccounts[bb.BankAccountID]
+		a.BankAccountBalances[idx].DeletedByCascade = isDeletedTogether(bb.Deleted, bb.DeletedBy, b.Deleted, b.DeletedBy)
+	}
+
+	vehicles := make(map[uuid.UUID]Vehicle, len(a.Vehicles))
+	for _, v := range a.Vehicles {
+		vehicles[v.ID] = v
+	}
+	for idx, vv := range a.VehicleValues {
+		v := vehicles[vv.VehicleID]
+		a.VehicleValues[idx].DeletedByCascade = isDeletedTogether(vv.Deleted, vv.DeletedBy, v.Deleted, v.DeletedBy)
+	}
+
+	properties := make(map[uuid.UUID]Property, len(a.Properties))
+	for _, p := range a.Properties {
+		properties[p.ID] = p
+	}
+	for idx, pv := range a.PropertyValues {
+		p := properties[pv.PropertyID]
+		a.PropertyValues[idx].DeletedByCascade = isDeletedTogether(pv.Deleted, pv.DeletedBy, p.Deleted, p.DeletedBy)
+	}
+}
+
+// linkTransactionCategories links the Transactions of archives written before the link existed to the
+// Categories going by their category names, which are the only evidence of it
+func (a *Archive) linkTransactionCategories() {
+	categories := make([]Category, 0, len(a.Categories))
+	for _, c := range a.Categories {
+		if !c.Deleted.Valid {
+			categories = append(categories, c)
+		}
+	}
+	for idx, t := range a.Transactions {
+		if category := FindCategoryByName(categories, t.Category); category != nil {
+			a.Transactions[idx].CategoryID = nuuid.From(category.ID)
+		}
+	}
+}
+
+// archiveTables lists the tables of an archive in order, each with its CSV file within a ZIP archive, its field
+// within a JSON archive, the records it holds and the archive version that introduced it, as archives of earlier
+// versions do not hold it
+func (o *ArchiveOutput) archiveTables() []struct {
+	file    string
+	field   string
+	records interface{}
+	since   int
+} {
+	return []struct {
+		file    string
+		field   string
+		records interface{}
+		since   int
+	}{
+		{"users.csv", "users", &o.Users, 1},
+		{"bank_accounts.csv", "bankAccounts", &o.BankAccounts, 1},
+		{"bank_account_balances.csv", "bankAccountBalances", &o.BankAccountBalances, 1},
+		{"bank_account_cash_flows.csv", "bankAccountCashFlows", &o.BankAccountCashFlows, 2},
+		{"transactions.csv", "transactions", &o.Transactions, 3},
+		{"transfers.csv", "transfers", &o.Transfers, 4},
+		{"categories.csv", "categories", &o.Categories, 5},
+		{"category_rules.csv", "categoryRules", &o.CategoryRules, 5},
+		{"budgets.csv", "budgets", &o.Budgets, 5},
+		{"goals.csv", "goals", &o.Goals, 6},
+		{"goal_bank_accounts.csv", "goalBankAccounts", &o.GoalBankAccounts, 6},
+		{"vehicles.csv", "vehicles", &o.Vehicles, 1},
+		{"vehicle_values.csv", "vehicleValues", &o.VehicleValues, 1},
+		{"properties.csv", "properties", &o.Properties, 1},
+		{"property_values.csv", "propertyValues", &o.PropertyValues, 1},
+		{"tags.csv", "tags", &o.Tags, 7},
+		{"entity_tags.csv", "entityTags", &o.EntityTags, 7},
+		{"custom_fields.csv", "customFields", &o.CustomFields, 7},
+		{"custom_field_values.csv", "customFieldValues", &o.CustomFieldValues, 7},
+		{"notes.csv", "notes", &o.Notes, 8},
+		{"attachments.csv", "attachments", &o.Attachments, 9},
+	}
+}
+
+// archiveManifest describes a ZIP archive
+type archiveManifest struct {
+	Version  int       `json:"version"`
+	Exported time.Time `json:"exported"`
+}
+
+// Write encodes the archive in a format
+func (o *ArchiveOutput) Write(w io.Writer, format ArchiveFormat) error {
+	writer, err := newArchiveWriter(w, format, archiveManifest{Version: o.Version, Exported: o.Exported})
+	if err != nil {
+		return err
+	}
+
+	for _, table := range o.archiveTables() {
+		err = writer.writeTable(table.file, table.field, reflect.ValueOf(table.records).Elem())
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, attachment := range o.Attachments {
+		err = writer.WriteAttachmentContent(attachment.ID, bytes.NewReader(o.AttachmentContents[attachment.ID]))
+		if err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// ArchiveWriter encodes an archive in a format while it is being read, one table at a time in the order archives
+// list them followed by the content of one Attachment at a time, so that an export never holds more than a
+// single table in memory. A JSON archive is written as the same document ArchiveOutput encodes to.
+type ArchiveWriter struct {
+	format    ArchiveFormat
+	buffer    *bufio.Writer
+	zipWriter *zip.Writer
+	tables    int
+	contents  int
+}
+
+// NewArchiveWriter starts writing an archive of the current version in a format
+func NewArchiveWriter(w io.Writer, format ArchiveFormat, exported time.Time) (*ArchiveWriter, error) {
+	return newArchiveWriter(w, format, archiveManifest{Version: ArchiveVersion, Exported: exported})
+}
+
+func newArchiveWriter(w io.Writer, format ArchiveFormat, manifest archiveManifest) (*ArchiveWriter, error) {
+	writer := &ArchiveWriter{format: format}
+
+	if format == ArchiveFormatZIP {
+		writer.zipWriter = zip.NewWriter(w)
+		file, err := writer.zipWriter.Create(archiveManifestFile)
+		if err != nil {
+			return nil, err
+		}
+		return writer, json.NewEncoder(file).Encode(manifest)
+	}
+
+	// the manifest fields open the JSON document, which is left open for the tables to follow
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	writer.buffer = bufio.NewWriter(w)
+	_, err = writer.buffer.Write(bytes.TrimSuffix(data, []byte("}")))
+	return writer, err
+}
+
+// WriteTable writes the next table of the archive, taking its records from an Archive holding that table
+func (w *ArchiveWriter) WriteTable(archive Archive) error {
+	output := archive.ToOutput()
+	tables := output.archiveTables()
+	if w.tables >= len(tables) {
+		return errors.New("archive has no more tables")
+	}
+
+	table := tables[w.tables]
+	return w.writeTable(table.file, table.field, reflect.ValueOf(table.records).Elem())
+}
+
+func (w *ArchiveWriter) writeTable(file, field string, records reflect.Value) error {
+	w.tables++
+
+	if w.zipWriter != nil {
+		zipFile, err := w.zipWriter.Create(file)
+		if err != nil {
+			return err
+		}
+		return writeArchiveCSV(zipFile, records)
+	}
+
+	data, err := json.Marshal(records.Interface())
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w.buffer, `,"%s":%s`, field, data)
+	return err
+}
+
+// WriteAttachmentContent writes the content of an Attachment, once every table has been written
+func (w *ArchiveWriter) WriteAttachmentContent(id uuid.UUID, content io.Reader) error {
+	if w.tables < len((&ArchiveOutput{}).archiveTables()) {
+		return errors.New("archive tables must be written before the content of attachments")
+	}
+
+	if w.zipWriter != nil {
+		file, err := w.zipWriter.Create(archiveAttachmentDir + id.String())
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(file, content)
+		return err
+	}
+
+	separator := ","
+	if w.contents == 0 {
+		separator = `,"attachmentContents":{`
+	}
+	w.contents++
+
+	_, err := fmt.Fprintf(w.buffer, `%s"%s":"`, separator, id)
+	if err != nil {
+		return err
+	}
+
+	// the content is encoded the same way JSON encodes a byte slice
+	encoder := base64.NewEncoder(base64.StdEncoding, w.buffer)
+	_, err = io.Copy(encoder, content)
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = w.buffer.WriteString(`"`)
+	return err
+}
+
+// Close finishes writing the archive, which has to hold every table by then
+func (w *ArchiveWriter) Close() error {
+	if w.tables < len((&ArchiveOutput{}).archiveTables()) {
+		return errors.New("archive is missing tables")
+	}
+
+	if w.zipWriter != nil {
+		return w.zipWriter.Close()
+	}
+
+	closing := "}}\n"
+	if w.contents == 0 {
+		closing = `,"attachmentContents":{}}` + "\n"
+	}
+
+	_, err := w.buffer.WriteString(closing)
+	if err != nil {
+		return err
+	}
+
+	return w.buffer.Flush()
+}
+
+// ReadArchive decodes an archive in either format, telling them apart by their content
+func ReadArchive(data []byte) (output ArchiveOutput, err error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		err = output.readZIP(data)
+	} else {
+		err = json.Unmarshal(data, &output)
+	}
+	if err != nil {
+		return output, failure.BadRequest(err)
+	}
+
+	return
+}
+
+func (o *ArchiveOutput) readZIP(data []byte) error {
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	manifestFile, err := zipReader.Open(archiveManifestFile)
+	if err != nil {
+		return fmt.Errorf("archive has no %s", archiveManifestFile)
+	}
+	defer manifestFile.Close()
+
+	var manifest archiveManifest
+	err = json.NewDecoder(manifestFile).Decode(&manifest)
+	if err != nil {
+		return err
+	}
+	o.Version = manifest.Version
+	o.Exported = manifest.Exported
+
+	for _, table := range o.archiveTables() {
+		if o.Version < table.since {
+			continue
+		}
+
+		file, err := zipReader.Open(table.file)
+		if err != nil {
+			return fmt.Errorf("archive has no %s", table.file)
+		}
+
+		err = readArchiveCSV(file, reflect.ValueOf(table.records).Elem(), o.Version)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", table.file, err)
+		}
+	}
+
+	o.AttachmentContents = make(map[uuid.UUID][]byte, len(o.Attachments))
+	for _, attachment := range o.Attachments {
+		name := archiveAttachmentDir + attachment.ID.String()
+		file, err := zipReader.Open(name)
+		if err != nil {
+			return fmt.Errorf("archive has no %s", name)
+		}
+
+		content, err := io.ReadAll(file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		o.AttachmentContents[attachment.ID] = content
+	}
+
+	return nil
+}
+
+// getArchiveColumns returns the names of the columns of a record, which are the names of its JSON fields
+func getArchiveColumns(recordType reflect.Type) []string {
+	columns := make([]string, 0, recordType.NumField())
+	for idx := 0; idx < recordType.NumField(); idx++ {
+		name, _, _ := strings.Cut(recordType.Field(idx).Tag.Get("json"), ",")
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// writeArchiveCSV writes a slice of records as CSV, headed by the names of their columns
+func writeArchiveCSV(w io.Writer, records reflect.Value) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write(getArchiveColumns(records.Type().Elem()))
+	if err != nil {
+		return err
+	}
+
+	for idx := 0; idx < records.Len(); idx++ {
+		record := records.Index(idx)
+		row := make([]string, 0, record.NumField())
+		for field := 0; field < record.NumField(); field++ {
+			row = append(row, formatArchiveField(record.Field(field).Interface()))
+		}
+
+		err = writer.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// readArchiveCSV reads CSV into a slice of records, matching columns to fields by their names. Columns added
+// after the version of the archive may be missing, leaving their fields empty.
+func readArchiveCSV(r io.Reader, records reflect.Value, version int) error {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return err
+	}
+
+	recordType := records.Type().Elem()
+	indexes := make([]int, 0, recordType.NumField())
+	for field, column := range getArchiveColumns(recordType) {
+		idx := -1
+		for headerIdx, name := range header {
+			if name == column {
+				idx = headerIdx
+			}
+		}
+		since, _ := strconv.Atoi(recordType.Field(field).Tag.Get("since"))
+		if idx < 0 && version >= since {
+			return fmt.Errorf("missing column %s", column)
+		}
+		indexes = append(indexes, idx)
+	}
+
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		record := reflect.New(recordType).Elem()
+		for field, idx := range indexes {
+			if idx < 0 {
+				continue
+			}
+
+			err = parseArchiveField(record.Field(field), row[idx])
+			if err != nil {
+				line, _ := reader.FieldPos(idx)
+				return fmt.Errorf("line %d, column %s: %w", line, header[idx], err)
+			}
+		}
+
+		records.Set(reflect.Append(records, record))
+	}
+}
+
+func formatArchiveField(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case null.Time:
+		if !v.Valid {
+			return ""
+		}
+		return v.Time.UTC().Format(time.RFC3339Nano)
+	case uuid.UUID:
+		return v.String()
+	case nuuid.NUUID:
+		if !v.Valid {
+			return ""
+		}
+		return v.UUID.String()
+	}
+
+	field := reflect.ValueOf(value)
+	switch field.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool())
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64)
+	default:
+		return field.String()
+	}
+}
+
+func parseArchiveField(field reflect.Value, value string) error {
+	switch field.Interface().(type) {
+	case time.Time:
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(parsed))
+		return nil
+	case null.Time:
+		if value == "" {
+			return nil
+		}
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(null.TimeFrom(parsed)))
+		return nil
+	case uuid.UUID:
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(parsed))
+		return nil
+	case nuuid.NUUID:
+		if value == "" {
+			return nil
+		}
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(nuuid.From(parsed)))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		field.SetString(value)
+	}
+
+	return nil
+}
+
+// ArchiveRestoreResult describes the records restored from an archive
+type ArchiveRestoreResult struct {
+	ID                   uuid.UUID `json:"id"`
+	Version              int       `json:"version"`
+	Users                int       `json:"users"`
+	SkippedUsers         int       `json:"skippedUsers"`
+	BankAccounts         int       `json:"bankAccounts"`
+	BankAccountBalances  int       `json:"bankAccountBalances"`
+	BankAccountCashFlows int       `json:"bankAccountCashFlows"`
+	Transactions         int       `json:"transactions"`
+	Transfers            int       `json:"transfers"`
+	Categories           int       `json:"categories"`
+	CategoryRules        int       `json:"categoryRules"`
+	Budgets              int       `json:"budgets"`
+	Goals                int       `json:"goals"`
+	Vehicles             int       `json:"vehicles"`
+	VehicleValues        int       `json:"vehicleValues"`
+	Properties           int       `json:"properties"`
+	PropertyValues       int       `json:"propertyValues"`
+	Tags                 int       `json:"tags"`
+	EntityTags           int       `json:"entityTags"`
+	CustomFields         int       `json:"customFields"`
+	CustomFieldValues    int       `json:"customFieldValues"`
+	Notes                int       `json:"notes"`
+	Attachments          int       `json:"attachments"`
+}
+
+// NewArchiveRestoreResult creates a new Archive Restore Result counting the records of an archive
+func NewArchiveRestoreResult(archive Archive, skippedUsers int) ArchiveRestoreResult {
+	newUUID, _ := uuid.NewV7()
+
+	return ArchiveRestoreResult{
+		ID:                   newUUID,
+		Version:              archive.Version,
+		Users:                len(archive.Users),
+		SkippedUsers:         skippedUsers,
+		BankAccounts:         len(archive.BankAccounts),
+		BankAccountBalances:  len(archive.BankAccountBalances),
+		BankAccountCashFlows: len(archive.BankAccountCashFlows),
+		Transactions:         len(archive.Transactions),
+		Transfers:            len(archive.Transfers),
+		Categories:           len(archive.Categories),
+		CategoryRules:        len(archive.CategoryRules),
+		Budgets:              len(archive.Budgets),
+		Goals:                len(archive.Goals),
+		Vehicles:             len(archive.Vehicles),
+		VehicleValues:        len(archive.VehicleValues),
+		Properties:           len(archive.Properties),
+		PropertyValues:       len(archive.PropertyValues),
+		Tags:                 len(archive.Tags),
+		EntityTags:           len(archive.EntityTags),
+		CustomFields:         len(archive.CustomFields),
+		CustomFieldValues:    len(archive.CustomFieldValues),
+		Notes:                len(archive.Notes),
+		Attachments:          len(archive.Attachments),
+	}
+}
@@ -0,0 +1,323 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// AttachableEntityTypes are the types of entity that files can be attached to
+var AttachableEntityTypes = []EntityType{
+	EntityTypeBankAccount,
+	EntityTypeBankAccountBalance,
+	EntityTypeVehicle,
+	EntityTypeVehicleValue,
+	EntityTypeProperty,
+	EntityTypePropertyValue,
+}
+
+const (
+	// AttachmentColumnID represents the corresponding column in Attachments table
+	AttachmentColumnID filter.Field = "attachments.entity_id"
+	// AttachmentColumnEntityType represents the corresponding column in Attachments table
+	AttachmentColumnEntityType filter.Field = "attachments.entity_type"
+	// AttachmentColumnSubjectID represents the corresponding column in Attachments table
+	AttachmentColumnSubjectID filter.Field = "attachments.subject_entity_id"
+	// AttachmentColumnFileName represents the corresponding column in Attachments table
+	AttachmentColumnFileName filter.Field = "attachments.file_name"
+	// AttachmentColumnContentType represents the corresponding column in Attachments table
+	AttachmentColumnContentType filter.Field = "attachments.content_type"
+	// AttachmentColumnSize represents the corresponding column in Attachments table
+	AttachmentColumnSize filter.Field = "attachments.size"
+	// AttachmentColumnChecksum represents the corresponding column in Attachments table
+	AttachmentColumnChecksum filter.Field = "attachments.checksum"
+	// AttachmentColumnCreated represents the corresponding column in Attachments table
+	AttachmentColumnCreated filter.Field = "attachments.created"
+	// AttachmentColumnCreatedBy represents the corresponding column in Attachments table
+	AttachmentColumnCreatedBy filter.Field = "attachments.created_by"
+	// AttachmentColumnUpdated represents the corresponding column in Attachments table
+	AttachmentColumnUpdated filter.Field = "attachments.updated"
+	// AttachmentColumnUpdatedBy represents the corresponding column in Attachments table
+	AttachmentColumnUpdatedBy filter.Field = "attachments.updated_by"
+	// AttachmentColumnDeleted represents the corresponding column in Attachments table
+	AttachmentColumnDeleted filter.Field = "attachments.deleted"
+	// AttachmentColumnDeletedBy represents the corresponding column in Attachments table
+	AttachmentColumnDeletedBy filter.Field = "attachments.deleted_by"
+)
+
+// AttachmentFields is the whitelist of fields Attachments can be queried and sorted by, keyed by their names in the API
+var AttachmentFields = map[string]filter.Field{
+	"id":          AttachmentColumnID,
+	"entityType":  AttachmentColumnEntityType,
+	"subjectId":   AttachmentColumnSubjectID,
+	"fileName":    AttachmentColumnFileName,
+	"contentType": AttachmentColumnContentType,
+	"size":        AttachmentColumnSize,
+	"checksum":    AttachmentColumnChecksum,
+	"created":     AttachmentColumnCreated,
+	"updated":     AttachmentColumnUpdated,
+	"deleted":     AttachmentColumnDeleted,
+	"createdBy":   AttachmentColumnCreatedBy,
+	"updatedBy":   AttachmentColumnUpdatedBy,
+	"deletedBy":   AttachmentColumnDeletedBy,
+}
+
+// Attachment is a file attached to an entity as proof behind it, such as a statement, vehicle papers or an
+// appraisal report. Its content is kept in the blob store under its ID, along with its SHA-256 checksum.
+type Attachment struct {
+	ID          uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	EntityType  EntityType  `db:"entity_type"`
+	SubjectID   uuid.UUID   `db:"subject_entity_id" validate:"min=36,max=36"`
+	FileName    string      `db:"file_name" validate:"max=255"`
+	ContentType string      `db:"content_type" validate:"max=255"`
+	Size        int64       `db:"size"`
+	Checksum    string      `db:"checksum" validate:"min=64,max=64"`
+	Created     time.Time   `db:"created"`
+	CreatedBy   uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
+	Updated     null.Time   `db:"updated"`
+	UpdatedBy   nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
+	Deleted     null.Time   `db:"deleted"`
+	DeletedBy   nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+}
+
+// NewAttachmentFromInput creates a new Attachment from its input object, which must have been validated,
+// and the content read from it
+func NewAttachmentFromInput(input AttachmentInput, content AttachmentContent, userID uuid.UUID) (a Attachment) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+
+	a = Attachment{
+		ID:          newUUID,
+		EntityType:  input.EntityType,
+		SubjectID:   input.SubjectID,
+		FileName:    cleanAttachmentFileName(input.FileName),
+		ContentType: content.ContentType,
+		Size:        int64(len(content.Data)),
+		Checksum:    GetAttachmentChecksum(content.Data),
+		Created:     now,
+		CreatedBy:   userID,
+	}
+
+	return
+}
+
+// Delete performs a delete on an Attachment. Its content is kept until the Attachment is purged.
+func (a *Attachment) Delete(userID uuid.UUID) error {
+	if a.Deleted.Valid || a.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Attachment", "already deleted")
+	}
+
+	now := time.Now()
+
+	a.Deleted = null.TimeFrom(now)
+	a.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// VerifyContent makes sure that the content read from the blob store is the one that was attached
+func (a *Attachment) VerifyContent(data []byte) error {
+	if int64(len(data)) != a.Size || GetAttachmentChecksum(data) != a.Checksum {
+		return failure.InternalError(
+			"download",
+			"Attachment",
+			fmt.Errorf("content of attachment %s does not match its checksum", a.ID))
+	}
+
+	return nil
+}
+
+// ToOutput converts an Attachment to its JSON-compatible object representation
+func (a *Attachment) ToOutput() AttachmentOutput {
+	return AttachmentOutput{
+		ID:          a.ID,
+		EntityType:  a.EntityType,
+		SubjectID:   a.SubjectID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		Created:     cachetime.CacheTime(a.Created),
+		CreatedBy:   a.CreatedBy,
+		Updated:     cachetime.NCacheTime(a.Updated),
+		UpdatedBy:   a.UpdatedBy,
+		Deleted:     cachetime.NCacheTime(a.Deleted),
+		DeletedBy:   a.DeletedBy,
+	}
+}
+
+// AttachmentInput represents an input struct for uploading an Attachment, whose content is streamed
+// from the request rather than decoded from JSON
+type AttachmentInput struct {
+	EntityType EntityType
+	SubjectID  uuid.UUID
+	FileName   string
+	Content    io.Reader
+}
+
+// Validate checks an Attachment input before its content is read
+func (i *AttachmentInput) Validate() error {
+	err := CheckAttachableEntityType(i.EntityType)
+	if err != nil {
+		return err
+	}
+
+	fileName := cleanAttachmentFileName(i.FileName)
+
+	if len(fileName) == 0 {
+		return failure.BadRequestFromString("file name is required")
+	}
+
+	if len(fileName) > 255 {
+		return failure.BadRequestFromString("file name must be at most 255 characters")
+	}
+
+	if i.Content == nil {
+		return failure.BadRequestFromString("file is required")
+	}
+
+	return nil
+}
+
+// ReadContent reads the content of an Attachment input, making sure that it is not larger than the maximum size
+// and that its type, as detected from the content itself, is one of the allowed types
+func (i *AttachmentInput) ReadContent(maxSize int64, allowedTypes []string) (content AttachmentContent, err error) {
+	content.Data, err = io.ReadAll(io.LimitReader(i.Content, maxSize+1))
+	if err != nil {
+		return content, failure.BadRequest(err)
+	}
+
+	if len(content.Data) == 0 {
+		return content, failure.BadRequestFromString("file is empty")
+	}
+
+	if int64(len(content.Data)) > maxSize {
+		return content, failure.BadRequestFromString(fmt.Sprintf("file must be at most %d bytes", maxSize))
+	}
+
+	content.ContentType, _, _ = strings.Cut(http.DetectContentType(content.Data), ";")
+	if !slices.Contains(allowedTypes, content.ContentType) {
+		return content, failure.BadRequestFromString(fmt.Sprintf("file type %s is not allowed", content.ContentType))
+	}
+
+	return content, nil
+}
+
+// AttachmentContent is the content of an Attachment along with its detected type
+type AttachmentContent struct {
+	ContentType string
+	Data        []byte
+}
+
+// AttachmentDownload is an Attachment along with its verified content
+type AttachmentDownload struct {
+	Attachment Attachment
+	Data       []byte
+}
+
+// AttachmentOutput is the JSON-compatible object representation of Attachment
+type AttachmentOutput struct {
+	ID          uuid.UUID            `json:"id"`
+	EntityType  EntityType           `json:"entityType"`
+	SubjectID   uuid.UUID            `json:"subjectId"`
+	FileName    string               `json:"fileName"`
+	ContentType string               `json:"contentType"`
+	Size        int64                `json:"size"`
+	Checksum    string               `json:"checksum"`
+	Created     cachetime.CacheTime  `json:"created"`
+	CreatedBy   uuid.UUID            `json:"createdBy"`
+	Updated     cachetime.NCacheTime `json:"updated,omitempty"`
+	UpdatedBy   nuuid.NUUID          `json:"updatedBy,omitempty"`
+	Deleted     cachetime.NCacheTime `json:"deleted,omitempty"`
+	DeletedBy   nuuid.NUUID          `json:"deletedBy,omitempty"`
+}
+
+// GetAttachmentChecksum computes the hex-encoded SHA-256 checksum of an Attachment's content
+func GetAttachmentChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// CheckAttachableEntityType makes sure that files can be attached to entities of a type
+func CheckAttachableEntityType(entityType EntityType) error {
+	if !slices.Contains(AttachableEntityTypes, entityType) {
+		return failure.BadRequestFromString(fmt.Sprintf("files cannot be attached to entity type %s", entityType))
+	}
+	return nil
+}
+
+// cleanAttachmentFileName strips any directories a client may have sent along with the name of a file
+func cleanAttachmentFileName(fileName string) string {
+	fileName = strings.TrimSpace(strings.ReplaceAll(fileName, "\\", "/"))
+	if fileName == "" {
+		return ""
+	}
+
+	fileName = filepath.Base(filepath.FromSlash(fileName))
+	if fileName == "." || fileName == "/" || fileName == ".." {
+		return ""
+	}
+
+	return fileName
+}
+
+// AttachmentFilterInput is the filter input object for Attachments
+type AttachmentFilterInput struct {
+	filter.BaseFilterInput
+	EntityTypes *[]EntityType `json:"entityTypes,omitempty"`
+	SubjectIDs  *[]uuid.UUID  `json:"subjectIds,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *AttachmentFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		AttachmentColumnFileName,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "attachments",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.EntityTypes != nil {
+		if len(*f.EntityTypes) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: AttachmentColumnEntityType,
+				Operand2: *f.EntityTypes,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.SubjectIDs != nil {
+		if len(*f.SubjectIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: AttachmentColumnSubjectID,
+				Operand2: *f.SubjectIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(AttachmentFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, AttachmentFields)
+	}
+
+	return theFilter
+}
@@ -0,0 +1,310 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// EntityType identifies the kind of entity a record refers to
+type EntityType string
+
+const (
+	// EntityTypeUser indicates a User
+	EntityTypeUser EntityType = "user"
+	// EntityTypeBankAccount indicates a Bank Account
+	EntityTypeBankAccount EntityType = "bankAccount"
+	// EntityTypeBankAccountBalance indicates a Bank Account Balance
+	EntityTypeBankAccountBalance EntityType = "bankAccountBalance"
+	// EntityTypeBankAccountCashFlow indicates a Bank Account Cash Flow
+	EntityTypeBankAccountCashFlow EntityType = "bankAccountCashFlow"
+	// EntityTypeTransaction indicates a Transaction
+	EntityTypeTransaction EntityType = "transaction"
+	// EntityTypeTransfer indicates a Transfer
+	EntityTypeTransfer EntityType = "transfer"
+	// EntityTypeCategory indicates a Category
+	EntityTypeCategory EntityType = "category"
+	// EntityTypeCategoryRule indicates a Category Rule
+	EntityTypeCategoryRule EntityType = "categoryRule"
+	// EntityTypeBudget indicates a Budget
+	EntityTypeBudget EntityType = "budget"
+	// EntityTypeGoal indicates a Goal
+	EntityTypeGoal EntityType = "goal"
+	// EntityTypeTag indicates a Tag
+	EntityTypeTag EntityType = "tag"
+	// EntityTypeCustomField indicates a Custom Field
+	EntityTypeCustomField EntityType = "customField"
+	// EntityTypeAttachment indicates an Attachment
+	EntityTypeAttachment EntityType = "attachment"
+	// EntityTypeNote indicates a Note
+	EntityTypeNote EntityType = "note"
+	// EntityTypeVehicle indicates a Vehicle
+	EntityTypeVehicle EntityType = "vehicle"
+	// EntityTypeVehicleValue indicates a Vehicle Value
+	EntityTypeVehicleValue EntityType = "vehicleValue"
+	// EntityTypeProperty indicates a Property
+	EntityTypeProperty EntityType = "property"
+	// EntityTypePropertyValue indicates a Property Value
+	EntityTypePropertyValue EntityType = "propertyValue"
+	// EntityTypePurge indicates a Purge of soft-deleted records
+	EntityTypePurge EntityType = "purge"
+	// EntityTypeArchive indicates an Archive restored into the instance
+	EntityTypeArchive EntityType = "archive"
+)
+
+// AuditAction indicates the kind of change recorded in an Audit Log
+type AuditAction string
+
+const (
+	// AuditActionCreate indicates that an entity was created
+	AuditActionCreate AuditAction = "create"
+	// AuditActionUpdate indicates that an entity was updated
+	AuditActionUpdate AuditAction = "update"
+	// AuditActionDelete indicates that an entity was soft-deleted
+	AuditActionDelete AuditAction = "delete"
+	// AuditActionRestore indicates that a soft-deleted entity was restored
+	AuditActionRestore AuditAction = "restore"
+	// AuditActionPurge indicates that soft-deleted records were permanently removed
+	AuditActionPurge AuditAction = "purge"
+)
+
+const (
+	// AuditLogColumnID represents the corresponding column in Audit Log table
+	AuditLogColumnID filter.Field = "audit_logs.entity_id"
+	// AuditLogColumnEntityType represents the corresponding column in Audit Log table
+	AuditLogColumnEntityType filter.Field = "audit_logs.entity_type"
+	// AuditLogColumnSubjectID represents the corresponding column in Audit Log table
+	AuditLogColumnSubjectID filter.Field = "audit_logs.subject_entity_id"
+	// AuditLogColumnAction represents the corresponding column in Audit Log table
+	AuditLogColumnAction filter.Field = "audit_logs.action"
+	// AuditLogColumnActorID represents the corresponding column in Audit Log table
+	AuditLogColumnActorID filter.Field = "audit_logs.actor_entity_id"
+	// AuditLogColumnSnapshotBefore represents the corresponding column in Audit Log table
+	AuditLogColumnSnapshotBefore filter.Field = "audit_logs.snapshot_before"
+	// AuditLogColumnSnapshotAfter represents the corresponding column in Audit Log table
+	AuditLogColumnSnapshotAfter filter.Field = "audit_logs.snapshot_after"
+	// AuditLogColumnCreated represents the corresponding column in Audit Log table
+	AuditLogColumnCreated filter.Field = "audit_logs.created"
+)
+
+// AuditLogFields is the whitelist of fields Audit Logs can be queried and sorted by, keyed by their names in the API
+var AuditLogFields = map[string]filter.Field{
+	"id":         AuditLogColumnID,
+	"entityType": AuditLogColumnEntityType,
+	"subjectId":  AuditLogColumnSubjectID,
+	"action":     AuditLogColumnAction,
+	"actorId":    AuditLogColumnActorID,
+	"created":    AuditLogColumnCreated,
+}
+
+// AuditLog represents a single recorded change to an entity
+type AuditLog struct {
+	ID             uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	EntityType     EntityType  `db:"entity_type"`
+	SubjectID      uuid.UUID   `db:"subject_entity_id" validate:"min=36,max=36"`
+	Action         AuditAction `db:"action"`
+	ActorID        uuid.UUID   `db:"actor_entity_id" validate:"min=36,max=36"`
+	SnapshotBefore null.String `db:"snapshot_before"`
+	SnapshotAfter  null.String `db:"snapshot_after"`
+	Created        time.Time   `db:"created"`
+}
+
+// NewAuditLog creates a new Audit Log of a change. The before and after snapshots are stored as
+// the JSON representation of the given objects, and may be nil for creations.
+func NewAuditLog(entityType EntityType, subjectID uuid.UUID, action AuditAction, actorID uuid.UUID, before, after interface{}) (a AuditLog, err error) {
+	snapshotBefore, err := getAuditSnapshot(before)
+	if err != nil {
+		return
+	}
+
+	snapshotAfter, err := getAuditSnapshot(after)
+	if err != nil {
+		return
+	}
+
+	newUUID, _ := uuid.NewV7()
+
+	a = AuditLog{
+		ID:             newUUID,
+		EntityType:     entityType,
+		SubjectID:      subjectID,
+		Action:         action,
+		ActorID:        actorID,
+		SnapshotBefore: snapshotBefore,
+		SnapshotAfter:  snapshotAfter,
+		Created:        time.Now(),
+	}
+
+	return
+}
+
+// GetAuditAction determines the action and actor of an update from whether the entity was deleted
+// before the update and from its lifecycle columns after the update
+func GetAuditAction(wasDeleted bool, createdBy uuid.UUID, updatedBy, deletedBy nuuid.NUUID) (AuditAction, uuid.UUID) {
+	if !wasDeleted && deletedBy.Valid {
+		return AuditActionDelete, deletedBy.UUID
+	}
+
+	if wasDeleted && !deletedBy.Valid && updatedBy.Valid {
+		return AuditActionRestore, updatedBy.UUID
+	}
+
+	if updatedBy.Valid {
+		return AuditActionUpdate, updatedBy.UUID
+	}
+
+	return AuditActionUpdate, createdBy
+}
+
+func getAuditSnapshot(object interface{}) (null.String, error) {
+	if object == nil {
+		return null.String{}, nil
+	}
+
+	snapshot, err := json.Marshal(object)
+	if err != nil {
+		return null.String{}, err
+	}
+
+	return null.StringFrom(string(snapshot)), nil
+}
+
+// ToOutput converts an Audit Log to its JSON-compatible object representation
+func (a *AuditLog) ToOutput() AuditLogOutput {
+	o := AuditLogOutput{
+		ID:         a.ID,
+		EntityType: a.EntityType,
+		SubjectID:  a.SubjectID,
+		Action:     a.Action,
+		ActorID:    a.ActorID,
+		Created:    cachetime.CacheTime(a.Created),
+	}
+
+	if a.SnapshotBefore.Valid {
+		before := json.RawMessage(a.SnapshotBefore.String)
+		o.Before = &before
+	}
+
+	if a.SnapshotAfter.Valid {
+		after := json.RawMessage(a.SnapshotAfter.String)
+		o.After = &after
+	}
+
+	return o
+}
+
+// AuditLogOutput is the JSON-compatible object representation of Audit Log
+type AuditLogOutput struct {
+	ID         uuid.UUID           `json:"id"`
+	EntityType EntityType          `json:"entityType"`
+	SubjectID  uuid.UUID           `json:"subjectId"`
+	Action     AuditAction         `json:"action"`
+	ActorID    uuid.UUID           `json:"actorId"`
+	Before     *json.RawMessage    `json:"before"`
+	After      *json.RawMessage    `json:"after"`
+	Created    cachetime.CacheTime `json:"created"`
+}
+
+// AuditLogFilterInput is the filter input object for Audit Logs
+type AuditLogFilterInput struct {
+	filter.BaseFilterInput
+	EntityTypes *[]EntityType        `json:"entityTypes,omitempty"`
+	SubjectIDs  *[]uuid.UUID         `json:"subjectIds,omitempty"`
+	Actions     *[]AuditAction       `json:"actions,omitempty"`
+	ActorIDs    *[]uuid.UUID         `json:"actorIds,omitempty"`
+	StartDate   cachetime.NCacheTime `json:"startDate,omitempty"`
+	EndDate     cachetime.NCacheTime `json:"endDate,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *AuditLogFilterInput) ToFilter() filter.Filter {
+	theFilter := filter.Filter{
+		TableName:      "audit_logs",
+		IncludeDeleted: true,
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.EntityTypes != nil {
+		if len(*f.EntityTypes) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: AuditLogColumnEntityType,
+				Operand2: *f.EntityTypes,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.SubjectIDs != nil {
+		if len(*f.SubjectIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: AuditLogColumnSubjectID,
+				Operand2: *f.SubjectIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.Actions != nil {
+		if len(*f.Actions) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: AuditLogColumnAction,
+				Operand2: *f.Actions,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.ActorIDs != nil {
+		if len(*f.ActorIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: AuditLogColumnActorID,
+				Operand2: *f.ActorIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.StartDate.Valid {
+		theFilter.AddClause(filter.Clause{
+			Operand1: AuditLogColumnCreated,
+			Operand2: f.StartDate.Time,
+			Operator: filter.OperatorGreaterThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.EndDate.Valid {
+		theFilter.AddClause(filter.Clause{
+			Operand1: AuditLogColumnCreated,
+			Operand2: f.EndDate.Time,
+			Operator: filter.OperatorLessThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	keywordFields := []filter.Field{
+		AuditLogColumnSnapshotBefore,
+		AuditLogColumnSnapshotAfter,
+	}
+	keywordClause := f.BaseFilterInput.GetKeywordFilter(keywordFields, false)
+	if keywordClause != nil {
+		theFilter.AddClause(*keywordClause, filter.OperatorAnd)
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(AuditLogFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, AuditLogFields)
+	}
+	if theFilter.Err == nil && len(theFilter.Sorts) == 0 {
+		// newest entries first unless requested otherwise
+		theFilter.Sorts = []filter.Sort{
+			{Field: AuditLogColumnCreated, Direction: filter.SortDirectionDesc},
+			{Field: AuditLogColumnID, Direction: filter.SortDirectionDesc},
+		}
+	}
+
+	return theFilter
+}
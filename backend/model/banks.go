@@ -1,6 +1,7 @@
 package model
 
 import (
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -52,6 +53,24 @@ const (
 	BankAccountColumnDeletedBy filter.Field = "bank_accounts.deleted_by"
 )
 
+// BankAccountFields is the whitelist of fields Bank Accounts can be queried and sorted by, keyed by their names in the API
+var BankAccountFields = map[string]filter.Field{
+	"id":                BankAccountColumnID,
+	"accountName":       BankAccountColumnAccountName,
+	"bankName":          BankAccountColumnBankName,
+	"accountHolderName": BankAccountColumnAccountHolderName,
+	"accountNumber":     BankAccountColumnAccountNumber,
+	"lastBalance":       BankAccountColumnLastBalance,
+	"lastBalanceDate":   BankAccountColumnLastBalanceDate,
+	"status":            BankAccountColumnStatus,
+	"created":           BankAccountColumnCreated,
+	"updated":           BankAccountColumnUpdated,
+	"deleted":           BankAccountColumnDeleted,
+	"createdBy":         BankAccountColumnCreatedBy,
+	"updatedBy":         BankAccountColumnUpdatedBy,
+	"deletedBy":         BankAccountColumnDeletedBy,
+}
+
 const (
 	// BankAccountBalanceColumnID represents the corresponding column in Bank Account Balances table
 	BankAccountBalanceColumnID filter.Field = "bank_account_balances.entity_id"
@@ -75,6 +94,20 @@ const (
 	BankAccountBalanceColumnDeletedBy filter.Field = "bank_account_balances.deleted_by"
 )
 
+// BankAccountBalanceFields is the whitelist of fields Bank Account Balances can be queried and sorted by, keyed by their names in the API
+var BankAccountBalanceFields = map[string]filter.Field{
+	"id":            BankAccountBalanceColumnID,
+	"bankAccountId": BankAccountBalanceColumnBankAccountID,
+	"date":          BankAccountBalanceColumnDate,
+	"balance":       BankAccountBalanceColumnBalance,
+	"created":       BankAccountBalanceColumnCreated,
+	"updated":       BankAccountBalanceColumnUpdated,
+	"deleted":       BankAccountBalanceColumnDeleted,
+	"createdBy":     BankAccountBalanceColumnCreatedBy,
+	"updatedBy":     BankAccountBalanceColumnUpdatedBy,
+	"deletedBy":     BankAccountBalanceColumnDeletedBy,
+}
+
 // BankAccount represents a Bank Account object
 type BankAccount struct {
 	ID                uuid.UUID            `db:"entity_id" validate:"min=36,max=36"`
@@ -92,6 +125,7 @@ type BankAccount struct {
 	Deleted           null.Time            `db:"deleted"`
 	DeletedBy         nuuid.NUUID          `db:"deleted_by" validate:"min=36,max=36"`
 	Balances          []BankAccountBalance `db:"-"`
+	Notes             []Note               `db:"-"`
 }
 
 // NewBankAccountFromInput creates a new Bank Account from its input object
@@ -138,6 +172,24 @@ func (b *BankAccount) AttachBalances(balances []BankAccountBalance, clearBeforeA
 	}
 }
 
+// AttachNotes attaches the Notes written on a Bank Account and on its attached Balances
+func (b *BankAccount) AttachNotes(notes []Note) {
+	b.Notes = getSubjectNotes(notes, EntityTypeBankAccount, b.ID)
+	for i := range b.Balances {
+		b.Balances[i].AttachNotes(notes)
+	}
+}
+
+// GetNoteSubjectIDs lists the IDs of a Bank Account and its attached Balances, which Notes can be written on
+func (b *BankAccount) GetNoteSubjectIDs() []uuid.UUID {
+	ids := []uuid.UUID{b.ID}
+	for _, balance := range b.Balances {
+		ids = append(ids, balance.ID)
+	}
+
+	return ids
+}
+
 // Update performs an update on a Bank Account
 func (b *BankAccount) Update(input BankAccountInput, userID uuid.UUID) error {
 	if b.Deleted.Valid || b.DeletedBy.Valid {
@@ -157,7 +209,8 @@ func (b *BankAccount) Update(input BankAccountInput, userID uuid.UUID) error {
 	return nil
 }
 
-// Delete performs a delete on a Bank Account
+// Delete performs a delete on a Bank Account. Its balances are deleted along with it and marked as deleted by
+// its cascade, which is what allows Restore to tell them apart from balances deleted on their own.
 func (b *BankAccount) Delete(userID uuid.UUID) error {
 	if b.Deleted.Valid || b.DeletedBy.Valid {
 		return failure.OperationNotPermitted("delete", "Bank Account", "already deleted")
@@ -170,10 +223,11 @@ func (b *BankAccount) Delete(userID uuid.UUID) error {
 
 	deletedBalances := make([]BankAccountBalance, 0)
 	for _, balance := range b.Balances {
-		err := balance.Delete(userID)
+		err := balance.delete(userID, now)
 		if err != nil {
 			return err
 		}
+		balance.DeletedByCascade = true
 
 		deletedBalances = append(deletedBalances, balance)
 	}
@@ -183,6 +237,34 @@ func (b *BankAccount) Delete(userID uuid.UUID) error {
 	return nil
 }
 
+// Restore reverts the delete of a Bank Account along with the balances deleted by that same delete.
+// Balances that had been deleted on their own beforehand are left deleted.
+func (b *BankAccount) Restore(userID uuid.UUID) error {
+	if !b.Deleted.Valid && !b.DeletedBy.Valid {
+		return failure.OperationNotPermitted("restore", "Bank Account", "not deleted")
+	}
+
+	now := time.Now()
+
+	restoredBalances := make([]BankAccountBalance, 0)
+	for _, balance := range b.Balances {
+		if !balance.DeletedByCascade {
+			continue
+		}
+
+		balance.restore(userID, now)
+		restoredBalances = append(restoredBalances, balance)
+	}
+
+	b.Deleted = null.Time{}
+	b.DeletedBy = nuuid.NUUID{}
+	b.Updated = null.TimeFrom(now)
+	b.UpdatedBy = nuuid.From(userID)
+	b.Balances = restoredBalances
+
+	return nil
+}
+
 // SetNewBalance sets a new balance and balance date on a Bank Account
 func (b *BankAccount) SetNewBalance(input BankAccountBalanceInput, userID uuid.UUID) error {
 	now := time.Now()
@@ -222,6 +304,7 @@ func (b *BankAccount) ToOutput() BankAccountOutput {
 	}
 
 	o.Balances = bbOutput
+	o.Notes = getNoteOutputs(b.Notes)
 
 	return o
 }
@@ -255,20 +338,23 @@ type BankAccountOutput struct {
 	Deleted           cachetime.NCacheTime       `json:"deleted,omitempty"`
 	DeletedBy         nuuid.NUUID                `json:"deletedBy,omitempty"`
 	Balances          []BankAccountBalanceOutput `json:"balances"`
+	Notes             []NoteOutput               `json:"notes,omitempty"`
 }
 
 // BankAccountBalance represents a snapshot of a Bank Account's balance at a given time
 type BankAccountBalance struct {
-	ID            uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
-	BankAccountID uuid.UUID   `db:"bank_account_entity_id" validate:"min=36,max=36"`
-	Date          time.Time   `db:"date"`
-	Balance       float64     `db:"balance"`
-	Created       time.Time   `db:"created"`
-	CreatedBy     uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
-	Updated       null.Time   `db:"updated"`
-	UpdatedBy     nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
-	Deleted       null.Time   `db:"deleted"`
-	DeletedBy     nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+	ID               uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	BankAccountID    uuid.UUID   `db:"bank_account_entity_id" validate:"min=36,max=36"`
+	Date             time.Time   `db:"date"`
+	Balance          float64     `db:"balance"`
+	Created          time.Time   `db:"created"`
+	CreatedBy        uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
+	Updated          null.Time   `db:"updated"`
+	UpdatedBy        nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
+	Deleted          null.Time   `db:"deleted"`
+	DeletedBy        nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+	DeletedByCascade bool        `db:"deleted_by_cascade"`
+	Notes            []Note      `db:"-"`
 }
 
 // NewBankAccountBalanceFromInput creates a new Bank Account Balance from its input object
@@ -306,18 +392,33 @@ func (bb *BankAccountBalance) Update(input BankAccountBalanceInput, userID uuid.
 
 // Delete performs a delete on a Bank Account Balance
 func (bb *BankAccountBalance) Delete(userID uuid.UUID) error {
+	return bb.delete(userID, time.Now())
+}
+
+func (bb *BankAccountBalance) delete(userID uuid.UUID, now time.Time) error {
 	if bb.Deleted.Valid || bb.DeletedBy.Valid {
 		return failure.OperationNotPermitted("delete", "Bank Account Balance", "already deleted")
 	}
 
-	now := time.Now()
-
 	bb.Deleted = null.TimeFrom(now)
 	bb.DeletedBy = nuuid.From(userID)
 
 	return nil
 }
 
+func (bb *BankAccountBalance) restore(userID uuid.UUID, now time.Time) {
+	bb.Deleted = null.Time{}
+	bb.DeletedBy = nuuid.NUUID{}
+	bb.DeletedByCascade = false
+	bb.Updated = null.TimeFrom(now)
+	bb.UpdatedBy = nuuid.From(userID)
+}
+
+// AttachNotes attaches the Notes written on a Bank Account Balance
+func (bb *BankAccountBalance) AttachNotes(notes []Note) {
+	bb.Notes = getSubjectNotes(notes, EntityTypeBankAccountBalance, bb.ID)
+}
+
 // ToOutput converts a Bank Account Balance to its JSON-compatible object representation
 func (bb *BankAccountBalance) ToOutput() BankAccountBalanceOutput {
 	return BankAccountBalanceOutput{
@@ -331,7 +432,40 @@ func (bb *BankAccountBalance) ToOutput() BankAccountBalanceOutput {
 		UpdatedBy:     bb.UpdatedBy,
 		Deleted:       cachetime.NCacheTime(bb.Deleted),
 		DeletedBy:     bb.DeletedBy,
+		Notes:         getNoteOutputs(bb.Notes),
+	}
+}
+
+// NarrowBalancesToPeriod picks the live balances of a Bank Account that span a period, sorted by date. They
+// start at the last balance on or before the start, or the first balance after it when there is none, and
+// end at the last balance on or before the end. Nothing is picked when no balance precedes the end.
+func NarrowBalancesToPeriod(bankAccountID uuid.UUID, start, end time.Time, balances []BankAccountBalance) []BankAccountBalance {
+	sorted := make([]BankAccountBalance, 0, len(balances))
+	for _, balance := range balances {
+		if balance.BankAccountID == bankAccountID && !balance.Deleted.Valid && !balance.Date.After(end) {
+			sorted = append(sorted, balance)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+
+	first := -1
+	for idx, balance := range sorted {
+		if balance.Date.After(start) {
+			if first < 0 {
+				first = idx
+			}
+			break
+		}
+		first = idx
 	}
+
+	if first < 0 {
+		return []BankAccountBalance{}
+	}
+
+	return sorted[first:]
 }
 
 // BankAccountBalanceInput represents an input struct for Bank Account Balance entity
@@ -342,6 +476,21 @@ type BankAccountBalanceInput struct {
 	Balance       float64             `json:"balance"`
 }
 
+// BankAccountBalanceImportInput is the input object for importing Bank Account Balances from CSV
+type BankAccountBalanceImportInput struct {
+	ImportInput
+	BankAccountID uuid.UUID `json:"bankAccountId"`
+}
+
+// ToBalanceInput converts a row of the import to the input object for a single Bank Account Balance
+func (i *BankAccountBalanceImportInput) ToBalanceInput(row ImportRow) BankAccountBalanceInput {
+	return BankAccountBalanceInput{
+		BankAccountID: i.BankAccountID,
+		Date:          cachetime.CacheTime(row.Date),
+		Balance:       row.Amount,
+	}
+}
+
 // BankAccountBalanceOutput is the JSON-compatible object representation of Bank Account Balance
 type BankAccountBalanceOutput struct {
 	ID            uuid.UUID            `json:"id"`
@@ -354,11 +503,15 @@ type BankAccountBalanceOutput struct {
 	UpdatedBy     nuuid.NUUID          `json:"updatedBy,omitempty"`
 	Deleted       cachetime.NCacheTime `json:"deleted,omitempty"`
 	DeletedBy     nuuid.NUUID          `json:"deletedBy,omitempty"`
+	Notes         []NoteOutput         `json:"notes,omitempty"`
 }
 
 // BankAccountFilterInput is the filter input object for Bank Accounts
 type BankAccountFilterInput struct {
 	filter.BaseFilterInput
+	AccountNumbers *[]string                `json:"accountNumbers,omitempty"`
+	Tags           *[]string                `json:"tags,omitempty"`
+	Aggregation    *filter.AggregationInput `json:"aggregation,omitempty"`
 }
 
 // ToFilter converts this entity-specific filter into a generic filter.Filter object
@@ -370,12 +523,38 @@ func (f *BankAccountFilterInput) ToFilter() filter.Filter {
 		BankAccountColumnAccountHolderName,
 	}
 
-	return filter.Filter{
+	theFilter := filter.Filter{
 		TableName:      "bank_accounts",
 		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
 		IncludeDeleted: f.GetIncludeDeleted(),
 		Pagination:     f.BaseFilterInput.GetPagination(),
 	}
+
+	if f.AccountNumbers != nil {
+		if len(*f.AccountNumbers) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: BankAccountColumnAccountNumber,
+				Operand2: *f.AccountNumbers,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.Tags != nil {
+		if len(*f.Tags) > 0 {
+			theFilter.AddClause(newTagClause(BankAccountColumnID, EntityTypeBankAccount, *f.Tags), filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(BankAccountFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, BankAccountFields)
+	}
+	if theFilter.Err == nil {
+		theFilter.Aggregation, theFilter.Err = f.Aggregation.ToAggregation(BankAccountFields)
+	}
+
+	return theFilter
 }
 
 // BankAccountBalanceFilterInput is the filter input object for Bank Account Balances
@@ -438,5 +617,13 @@ func (f *BankAccountBalanceFilterInput) ToFilter() filter.Filter {
 		}, filter.OperatorAnd)
 	}
 
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(BankAccountBalanceFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, BankAccountBalanceFields)
+	}
+	if theFilter.Err == nil {
+		theFilter.Pagination, theFilter.Err = f.BaseFilterInput.GetKeysetPagination(BankAccountBalanceColumnDate, BankAccountBalanceColumnID)
+	}
+
 	return theFilter
 }
@@ -1,11 +1,19 @@
 package model
 
+import (
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
 // PageInfoOutput represents information related to a particular page output
+// Pages requested with cursor pagination carry cursors to their neighbouring pages instead of page counts
 type PageInfoOutput struct {
-	Page       int `json:"page"`
-	PageSize   int `json:"pageSize"`
-	TotalCount int `json:"totalCount"`
-	PageCount  int `json:"pageCount"`
+	Page       int     `json:"page"`
+	PageSize   int     `json:"pageSize"`
+	TotalCount int     `json:"totalCount"`
+	PageCount  int     `json:"pageCount"`
+	NextCursor *string `json:"nextCursor,omitempty"`
+	PrevCursor *string `json:"prevCursor,omitempty"`
 }
 
 // PageOutput is a wrapper for any output that requires pagination information
@@ -13,3 +21,23 @@ type PageOutput struct {
 	Items    interface{}    `json:"items"`
 	PageInfo PageInfoOutput `json:"pageInfo"`
 }
+
+// AggregateRow represents a single group of an aggregation, with its fields and aggregates keyed by their names in the API
+type AggregateRow struct {
+	Group  map[string]interface{} `json:"group"`
+	Values map[string]interface{} `json:"values"`
+}
+
+// AggregationOutput is a wrapper for the groups produced by an aggregation
+type AggregationOutput struct {
+	Items []AggregateRow `json:"items"`
+}
+
+// isDeletedTogether checks whether a child entity was soft-deleted at the same time and by the same user as its
+// parent, the only evidence of a cascading delete in records written before it was marked explicitly
+func isDeletedTogether(deleted null.Time, deletedBy nuuid.NUUID, parentDeleted null.Time, parentDeletedBy nuuid.NUUID) bool {
+	return deleted.Valid &&
+		parentDeleted.Valid &&
+		deleted.Time.Equal(parentDeleted.Time) &&
+		deletedBy == parentDeletedBy
+}
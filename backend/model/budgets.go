@@ -0,0 +1,371 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// BudgetMonthFormat is the format months are given in by the API
+const BudgetMonthFormat = "2006-01"
+
+const (
+	// BudgetColumnID represents the corresponding column in Budgets table
+	BudgetColumnID filter.Field = "budgets.entity_id"
+	// BudgetColumnCategoryID represents the corresponding column in Budgets table
+	BudgetColumnCategoryID filter.Field = "budgets.category_entity_id"
+	// BudgetColumnMonth represents the corresponding column in Budgets table
+	BudgetColumnMonth filter.Field = "budgets.month"
+	// BudgetColumnAmount represents the corresponding column in Budgets table
+	BudgetColumnAmount filter.Field = "budgets.amount"
+	// BudgetColumnCreated represents the corresponding column in Budgets table
+	BudgetColumnCreated filter.Field = "budgets.created"
+	// BudgetColumnCreatedBy represents the corresponding column in Budgets table
+	BudgetColumnCreatedBy filter.Field = "budgets.created_by"
+	// BudgetColumnUpdated represents the corresponding column in Budgets table
+	BudgetColumnUpdated filter.Field = "budgets.updated"
+	// BudgetColumnUpdatedBy represents the corresponding column in Budgets table
+	BudgetColumnUpdatedBy filter.Field = "budgets.updated_by"
+	// BudgetColumnDeleted represents the corresponding column in Budgets table
+	BudgetColumnDeleted filter.Field = "budgets.deleted"
+	// BudgetColumnDeletedBy represents the corresponding column in Budgets table
+	BudgetColumnDeletedBy filter.Field = "budgets.deleted_by"
+)
+
+// BudgetFields is the whitelist of fields Budgets can be queried and sorted by, keyed by their names in the API
+var BudgetFields = map[string]filter.Field{
+	"id":         BudgetColumnID,
+	"categoryId": BudgetColumnCategoryID,
+	"month":      BudgetColumnMonth,
+	"amount":     BudgetColumnAmount,
+	"created":    BudgetColumnCreated,
+	"updated":    BudgetColumnUpdated,
+	"deleted":    BudgetColumnDeleted,
+	"createdBy":  BudgetColumnCreatedBy,
+	"updatedBy":  BudgetColumnUpdatedBy,
+	"deletedBy":  BudgetColumnDeletedBy,
+}
+
+// Budget is the amount planned to be spent on a Category in a month. A Category has at most one Budget
+// per month, and its spending covers that of its subcategories unless they have a Budget of their own.
+type Budget struct {
+	ID         uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	CategoryID uuid.UUID   `db:"category_entity_id" validate:"min=36,max=36"`
+	Month      time.Time   `db:"month"`
+	Amount     float64     `db:"amount" validate:"min=0"`
+	Created    time.Time   `db:"created"`
+	CreatedBy  uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
+	Updated    null.Time   `db:"updated"`
+	UpdatedBy  nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
+	Deleted    null.Time   `db:"deleted"`
+	DeletedBy  nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+}
+
+// ParseBudgetMonth parses a month given as YYYY-MM into the start of its first day in local time
+func ParseBudgetMonth(value string) (time.Time, error) {
+	month, err := time.ParseInLocation(BudgetMonthFormat, value, time.Local)
+	if err != nil {
+		return time.Time{}, failure.BadRequestFromString(fmt.Sprintf("invalid month: %s", value))
+	}
+	return month, nil
+}
+
+// NewBudgetFromInput creates a new Budget from its input object, which must have been validated
+func NewBudgetFromInput(input BudgetInput, userID uuid.UUID) (b Budget) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+	month, _ := ParseBudgetMonth(input.Month)
+
+	b = Budget{
+		ID:         newUUID,
+		CategoryID: input.CategoryID,
+		Month:      month,
+		Amount:     input.Amount,
+		Created:    now,
+		CreatedBy:  userID,
+	}
+
+	return
+}
+
+// Update performs an update on a Budget, whose input must have been validated
+func (b *Budget) Update(input BudgetInput, userID uuid.UUID) error {
+	if b.Deleted.Valid || b.DeletedBy.Valid {
+		return failure.OperationNotPermitted("update", "Budget", "already deleted")
+	}
+
+	now := time.Now()
+	month, _ := ParseBudgetMonth(input.Month)
+
+	b.CategoryID = input.CategoryID
+	b.Month = month
+	b.Amount = input.Amount
+	b.Updated = null.TimeFrom(now)
+	b.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Delete performs a delete on a Budget
+func (b *Budget) Delete(userID uuid.UUID) error {
+	if b.Deleted.Valid || b.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Budget", "already deleted")
+	}
+
+	now := time.Now()
+
+	b.Deleted = null.TimeFrom(now)
+	b.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// ToOutput converts a Budget to its JSON-compatible object representation
+func (b *Budget) ToOutput() BudgetOutput {
+	return BudgetOutput{
+		ID:         b.ID,
+		CategoryID: b.CategoryID,
+		Month:      b.Month.Format(BudgetMonthFormat),
+		Amount:     b.Amount,
+		Created:    cachetime.CacheTime(b.Created),
+		CreatedBy:  b.CreatedBy,
+		Updated:    cachetime.NCacheTime(b.Updated),
+		UpdatedBy:  b.UpdatedBy,
+		Deleted:    cachetime.NCacheTime(b.Deleted),
+		DeletedBy:  b.DeletedBy,
+	}
+}
+
+// BudgetInput represents an input struct for Budget entity
+type BudgetInput struct {
+	ID         uuid.UUID `json:"id"`
+	CategoryID uuid.UUID `json:"categoryId"`
+	Month      string    `json:"month"`
+	Amount     float64   `json:"amount"`
+}
+
+// Validate checks a Budget input before it is stored
+func (i *BudgetInput) Validate() error {
+	if _, err := ParseBudgetMonth(i.Month); err != nil {
+		return err
+	}
+
+	if i.Amount <= 0 {
+		return failure.BadRequestFromString("budget amount must be greater than zero")
+	}
+
+	return nil
+}
+
+// BudgetOutput is the JSON-compatible object representation of Budget
+type BudgetOutput struct {
+	ID         uuid.UUID            `json:"id"`
+	CategoryID uuid.UUID            `json:"categoryId"`
+	Month      string               `json:"month"`
+	Amount     float64              `json:"amount"`
+	Created    cachetime.CacheTime  `json:"created"`
+	CreatedBy  uuid.UUID            `json:"createdBy"`
+	Updated    cachetime.NCacheTime `json:"updated,omitempty"`
+	UpdatedBy  nuuid.NUUID          `json:"updatedBy,omitempty"`
+	Deleted    cachetime.NCacheTime `json:"deleted,omitempty"`
+	DeletedBy  nuuid.NUUID          `json:"deletedBy,omitempty"`
+}
+
+// BudgetFilterInput is the filter input object for Budgets. Months are given as YYYY-MM.
+type BudgetFilterInput struct {
+	filter.BaseFilterInput
+	CategoryIDs *[]uuid.UUID `json:"categoryIds,omitempty"`
+	StartMonth  *string      `json:"startMonth,omitempty"`
+	EndMonth    *string      `json:"endMonth,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *BudgetFilterInput) ToFilter() filter.Filter {
+	theFilter := filter.Filter{
+		TableName:      "budgets",
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.CategoryIDs != nil {
+		if len(*f.CategoryIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: BudgetColumnCategoryID,
+				Operand2: *f.CategoryIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.StartMonth != nil {
+		month, err := ParseBudgetMonth(*f.StartMonth)
+		if err != nil {
+			theFilter.Err = err
+			return theFilter
+		}
+		theFilter.AddClause(filter.Clause{
+			Operand1: BudgetColumnMonth,
+			Operand2: month,
+			Operator: filter.OperatorGreaterThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.EndMonth != nil {
+		month, err := ParseBudgetMonth(*f.EndMonth)
+		if err != nil {
+			theFilter.Err = err
+			return theFilter
+		}
+		theFilter.AddClause(filter.Clause{
+			Operand1: BudgetColumnMonth,
+			Operand2: month,
+			Operator: filter.OperatorLessThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(BudgetFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, BudgetFields)
+	}
+
+	return theFilter
+}
+
+// BudgetReport compares the spending of a month against its Budgets
+type BudgetReport struct {
+	Month      time.Time
+	Items      []BudgetReportItem
+	Unbudgeted float64
+}
+
+// BudgetReportItem compares the spending on the Category of a Budget against the amount planned for it
+type BudgetReportItem struct {
+	Budget       Budget
+	CategoryName string
+	Actual       float64
+	Transactions int
+}
+
+// NewBudgetReport works out the spending of a month against its Budgets. Spending is what was debited less
+// what was credited back, and each Transaction counts towards the Budget of the Category it is linked to by ID
+// or, failing that, of the closest Category above it that has one. Debits that do not fall under any Budget are
+// reported as unbudgeted, while credits outside of a Budget are income rather than spending and are left out,
+// as are Transfers since they only move money between accounts.
+func NewBudgetReport(month time.Time, budgets []Budget, categories []Category, transactions []Transaction) BudgetReport {
+	report := BudgetReport{
+		Month: month,
+		Items: make([]BudgetReportItem, 0, len(budgets)),
+	}
+
+	categoriesByID := make(map[uuid.UUID]Category)
+	for _, category := range categories {
+		categoriesByID[category.ID] = category
+	}
+
+	itemIndexes := make(map[uuid.UUID]int)
+	for _, budget := range budgets {
+		itemIndexes[budget.CategoryID] = len(report.Items)
+		report.Items = append(report.Items, BudgetReportItem{
+			Budget:       budget,
+			CategoryName: categoriesByID[budget.CategoryID].Name,
+		})
+	}
+
+	for _, transaction := range transactions {
+		if transaction.Deleted.Valid || transaction.Category == TransferCategory {
+			continue
+		}
+
+		spent := -transaction.SignedAmount()
+
+		index, budgeted := -1, false
+		categoryID := transaction.CategoryID.UUID
+		_, known := categoriesByID[categoryID]
+		// the number of steps is bounded so that a tree that is already broken cannot loop forever
+		for steps := 0; known && steps <= len(categories); steps++ {
+			if index, budgeted = itemIndexes[categoryID]; budgeted {
+				break
+			}
+			parent := categoriesByID[categoryID].ParentID
+			if !parent.Valid {
+				break
+			}
+			categoryID = parent.UUID
+		}
+
+		if budgeted {
+			report.Items[index].Actual += spent
+			report.Items[index].Transactions++
+		} else if spent > 0 {
+			report.Unbudgeted += spent
+		}
+	}
+
+	for i := range report.Items {
+		report.Items[i].Actual = roundToCents(report.Items[i].Actual)
+	}
+	report.Unbudgeted = roundToCents(report.Unbudgeted)
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return report.Items[i].CategoryName < report.Items[j].CategoryName
+	})
+
+	return report
+}
+
+// ToOutput converts a Budget Report to its JSON-compatible object representation
+func (r *BudgetReport) ToOutput() BudgetReportOutput {
+	output := BudgetReportOutput{
+		Month:      r.Month.Format(BudgetMonthFormat),
+		Items:      make([]BudgetReportItemOutput, 0, len(r.Items)),
+		Unbudgeted: r.Unbudgeted,
+	}
+
+	for _, item := range r.Items {
+		output.Items = append(output.Items, BudgetReportItemOutput{
+			BudgetID:     item.Budget.ID,
+			CategoryID:   item.Budget.CategoryID,
+			CategoryName: item.CategoryName,
+			Planned:      item.Budget.Amount,
+			Actual:       item.Actual,
+			Remaining:    roundToCents(item.Budget.Amount - item.Actual),
+			Transactions: item.Transactions,
+		})
+		output.TotalPlanned += item.Budget.Amount
+		output.TotalActual += item.Actual
+	}
+
+	output.TotalPlanned = roundToCents(output.TotalPlanned)
+	output.TotalActual = roundToCents(output.TotalActual)
+	output.TotalRemaining = roundToCents(output.TotalPlanned - output.TotalActual)
+
+	return output
+}
+
+// BudgetReportOutput is the JSON-compatible object representation of Budget Report
+type BudgetReportOutput struct {
+	Month          string                   `json:"month"`
+	Items          []BudgetReportItemOutput `json:"items"`
+	TotalPlanned   float64                  `json:"totalPlanned"`
+	TotalActual    float64                  `json:"totalActual"`
+	TotalRemaining float64                  `json:"totalRemaining"`
+	Unbudgeted     float64                  `json:"unbudgeted"`
+}
+
+// BudgetReportItemOutput is the JSON-compatible object representation of Budget Report Item
+type BudgetReportItemOutput struct {
+	BudgetID     uuid.UUID `json:"budgetId"`
+	CategoryID   uuid.UUID `json:"categoryId"`
+	CategoryName string    `json:"categoryName"`
+	Planned      float64   `json:"planned"`
+	Actual       float64   `json:"actual"`
+	Remaining    float64   `json:"remaining"`
+	Transactions int       `json:"transactions"`
+}
@@ -0,0 +1,411 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/kerti/balances/backend/util/returns"
+)
+
+// BankAccountCashFlowType indicates the direction of a Bank Account Cash Flow
+type BankAccountCashFlowType string
+
+const (
+	// BankAccountCashFlowTypeDeposit indicates money put into a Bank Account
+	BankAccountCashFlowTypeDeposit BankAccountCashFlowType = "deposit"
+	// BankAccountCashFlowTypeWithdrawal indicates money taken out of a Bank Account
+	BankAccountCashFlowTypeWithdrawal BankAccountCashFlowType = "withdrawal"
+)
+
+// IsValid checks whether a Bank Account Cash Flow type is one of the supported types
+func (t BankAccountCashFlowType) IsValid() bool {
+	return t == BankAccountCashFlowTypeDeposit || t == BankAccountCashFlowTypeWithdrawal
+}
+
+const (
+	// BankAccountCashFlowColumnID represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnID filter.Field = "bank_account_cash_flows.entity_id"
+	// BankAccountCashFlowColumnBankAccountID represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnBankAccountID filter.Field = "bank_account_cash_flows.bank_account_entity_id"
+	// BankAccountCashFlowColumnDate represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnDate filter.Field = "bank_account_cash_flows.date"
+	// BankAccountCashFlowColumnType represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnType filter.Field = "bank_account_cash_flows.type"
+	// BankAccountCashFlowColumnAmount represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnAmount filter.Field = "bank_account_cash_flows.amount"
+	// BankAccountCashFlowColumnNote represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnNote filter.Field = "bank_account_cash_flows.note"
+	// BankAccountCashFlowColumnCreated represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnCreated filter.Field = "bank_account_cash_flows.created"
+	// BankAccountCashFlowColumnCreatedBy represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnCreatedBy filter.Field = "bank_account_cash_flows.created_by"
+	// BankAccountCashFlowColumnUpdated represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnUpdated filter.Field = "bank_account_cash_flows.updated"
+	// BankAccountCashFlowColumnUpdatedBy represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnUpdatedBy filter.Field = "bank_account_cash_flows.updated_by"
+	// BankAccountCashFlowColumnDeleted represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnDeleted filter.Field = "bank_account_cash_flows.deleted"
+	// BankAccountCashFlowColumnDeletedBy represents the corresponding column in Bank Account Cash Flows table
+	BankAccountCashFlowColumnDeletedBy filter.Field = "bank_account_cash_flows.deleted_by"
+)
+
+// BankAccountCashFlowFields is the whitelist of fields Bank Account Cash Flows can be queried and sorted by, keyed by their names in the API
+var BankAccountCashFlowFields = map[string]filter.Field{
+	"id":            BankAccountCashFlowColumnID,
+	"bankAccountId": BankAccountCashFlowColumnBankAccountID,
+	"date":          BankAccountCashFlowColumnDate,
+	"type":          BankAccountCashFlowColumnType,
+	"amount":        BankAccountCashFlowColumnAmount,
+	"note":          BankAccountCashFlowColumnNote,
+	"created":       BankAccountCashFlowColumnCreated,
+	"updated":       BankAccountCashFlowColumnUpdated,
+	"deleted":       BankAccountCashFlowColumnDeleted,
+	"createdBy":     BankAccountCashFlowColumnCreatedBy,
+	"updatedBy":     BankAccountCashFlowColumnUpdatedBy,
+	"deletedBy":     BankAccountCashFlowColumnDeletedBy,
+}
+
+// BankAccountCashFlow represents money deposited into or withdrawn from a Bank Account, which tells new
+// money apart from growth when calculating the returns of the account
+type BankAccountCashFlow struct {
+	ID            uuid.UUID               `db:"entity_id" validate:"min=36,max=36"`
+	BankAccountID uuid.UUID               `db:"bank_account_entity_id" validate:"min=36,max=36"`
+	Date          time.Time               `db:"date"`
+	Type          BankAccountCashFlowType `db:"type"`
+	Amount        float64                 `db:"amount" validate:"min=0"`
+	Note          string                  `db:"note" validate:"max=255"`
+	Created       time.Time               `db:"created"`
+	CreatedBy     uuid.UUID               `db:"created_by" validate:"min=36,max=36"`
+	Updated       null.Time               `db:"updated"`
+	UpdatedBy     nuuid.NUUID             `db:"updated_by" validate:"min=36,max=36"`
+	Deleted       null.Time               `db:"deleted"`
+	DeletedBy     nuuid.NUUID             `db:"deleted_by" validate:"min=36,max=36"`
+}
+
+// NewBankAccountCashFlowFromInput creates a new Bank Account Cash Flow from its input object
+func NewBankAccountCashFlowFromInput(input BankAccountCashFlowInput, bankAccountID uuid.UUID, userID uuid.UUID) (cf BankAccountCashFlow) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+
+	cf = BankAccountCashFlow{
+		ID:            newUUID,
+		BankAccountID: bankAccountID,
+		Date:          input.Date.Time(),
+		Type:          input.Type,
+		Amount:        input.Amount,
+		Note:          strings.TrimSpace(input.Note),
+		Created:       now,
+		CreatedBy:     userID,
+	}
+
+	return
+}
+
+// Update performs an update on a Bank Account Cash Flow
+func (cf *BankAccountCashFlow) Update(input BankAccountCashFlowInput, userID uuid.UUID) error {
+	if cf.Deleted.Valid || cf.DeletedBy.Valid {
+		return failure.OperationNotPermitted("update", "Bank Account Cash Flow", "already deleted")
+	}
+
+	now := time.Now()
+
+	cf.Date = input.Date.Time()
+	cf.Type = input.Type
+	cf.Amount = input.Amount
+	cf.Note = strings.TrimSpace(input.Note)
+	cf.Updated = null.TimeFrom(now)
+	cf.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Delete performs a delete on a Bank Account Cash Flow
+func (cf *BankAccountCashFlow) Delete(userID uuid.UUID) error {
+	if cf.Deleted.Valid || cf.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Bank Account Cash Flow", "already deleted")
+	}
+
+	now := time.Now()
+
+	cf.Deleted = null.TimeFrom(now)
+	cf.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// SignedAmount returns the amount of a Bank Account Cash Flow as seen from the account, positive for deposits
+// and negative for withdrawals
+func (cf *BankAccountCashFlow) SignedAmount() float64 {
+	if cf.Type == BankAccountCashFlowTypeWithdrawal {
+		return -cf.Amount
+	}
+	return cf.Amount
+}
+
+// ToOutput converts a Bank Account Cash Flow to its JSON-compatible object representation
+func (cf *BankAccountCashFlow) ToOutput() BankAccountCashFlowOutput {
+	return BankAccountCashFlowOutput{
+		ID:            cf.ID,
+		BankAccountID: cf.BankAccountID,
+		Date:          cachetime.CacheTime(cf.Date),
+		Type:          cf.Type,
+		Amount:        cf.Amount,
+		Note:          cf.Note,
+		Created:       cachetime.CacheTime(cf.Created),
+		CreatedBy:     cf.CreatedBy,
+		Updated:       cachetime.NCacheTime(cf.Updated),
+		UpdatedBy:     cf.UpdatedBy,
+		Deleted:       cachetime.NCacheTime(cf.Deleted),
+		DeletedBy:     cf.DeletedBy,
+	}
+}
+
+// BankAccountCashFlowInput represents an input struct for Bank Account Cash Flow entity
+type BankAccountCashFlowInput struct {
+	ID            uuid.UUID               `json:"id"`
+	BankAccountID uuid.UUID               `json:"bankAccountId"`
+	Date          cachetime.CacheTime     `json:"date"`
+	Type          BankAccountCashFlowType `json:"type"`
+	Amount        float64                 `json:"amount"`
+	Note          string                  `json:"note"`
+}
+
+// Validate checks a Bank Account Cash Flow input before it is stored. The amount is always positive, its
+// direction being given by the type.
+func (i *BankAccountCashFlowInput) Validate() error {
+	if !i.Type.IsValid() {
+		return failure.BadRequestFromString(fmt.Sprintf("invalid cash flow type: %s", i.Type))
+	}
+
+	if i.Amount <= 0 {
+		return failure.BadRequestFromString("cash flow amount must be greater than zero")
+	}
+
+	if len(strings.TrimSpace(i.Note)) > 255 {
+		return failure.BadRequestFromString("cash flow note must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// BankAccountCashFlowOutput is the JSON-compatible object representation of Bank Account Cash Flow
+type BankAccountCashFlowOutput struct {
+	ID            uuid.UUID               `json:"id"`
+	BankAccountID uuid.UUID               `json:"bankAccountId"`
+	Date          cachetime.CacheTime     `json:"date"`
+	Type          BankAccountCashFlowType `json:"type"`
+	Amount        float64                 `json:"amount"`
+	Note          string                  `json:"note"`
+	Created       cachetime.CacheTime     `json:"created"`
+	CreatedBy     uuid.UUID               `json:"createdBy"`
+	Updated       cachetime.NCacheTime    `json:"updated,omitempty"`
+	UpdatedBy     nuuid.NUUID             `json:"updatedBy,omitempty"`
+	Deleted       cachetime.NCacheTime    `json:"deleted,omitempty"`
+	DeletedBy     nuuid.NUUID             `json:"deletedBy,omitempty"`
+}
+
+// BankAccountCashFlowFilterInput is the filter input object for Bank Account Cash Flows
+type BankAccountCashFlowFilterInput struct {
+	filter.BaseFilterInput
+	BankAccountIDs *[]uuid.UUID               `json:"bankAccountIds,omitempty"`
+	StartDate      cachetime.NCacheTime       `json:"startDate,omitempty"`
+	EndDate        cachetime.NCacheTime       `json:"endDate,omitempty"`
+	Types          *[]BankAccountCashFlowType `json:"types,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *BankAccountCashFlowFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		BankAccountCashFlowColumnNote,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "bank_account_cash_flows",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.BankAccountIDs != nil {
+		if len(*f.BankAccountIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: BankAccountCashFlowColumnBankAccountID,
+				Operand2: *f.BankAccountIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.StartDate.Valid {
+		theFilter.AddClause(filter.Clause{
+			Operand1: BankAccountCashFlowColumnDate,
+			Operand2: f.StartDate.Time,
+			Operator: filter.OperatorGreaterThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.EndDate.Valid {
+		theFilter.AddClause(filter.Clause{
+			Operand1: BankAccountCashFlowColumnDate,
+			Operand2: f.EndDate.Time,
+			Operator: filter.OperatorLessThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.Types != nil {
+		if len(*f.Types) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: BankAccountCashFlowColumnType,
+				Operand2: *f.Types,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(BankAccountCashFlowFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, BankAccountCashFlowFields)
+	}
+	if theFilter.Err == nil {
+		theFilter.Pagination, theFilter.Err = f.BaseFilterInput.GetKeysetPagination(BankAccountCashFlowColumnDate, BankAccountCashFlowColumnID)
+	}
+
+	return theFilter
+}
+
+// BankAccountReturns holds the performance of a Bank Account over a period. The period is narrowed to the
+// recorded balances, starting at the last balance on or before the requested start, or the first balance
+// after it when there is none, and ending at the last balance on or before the requested end. Cash flows
+// after the start of the period up to its end are treated as external, so they do not count as growth.
+type BankAccountReturns struct {
+	BankAccountID uuid.UUID
+	StartDate     time.Time
+	EndDate       time.Time
+	StartBalance  null.Float
+	EndBalance    null.Float
+	Deposits      float64
+	Withdrawals   float64
+	Growth        null.Float
+	// TimeWeightedReturn is the return over the period in percent, unaffected by when cash flows happened
+	TimeWeightedReturn null.Float
+	// MoneyWeightedReturn is the annual internal rate of return in percent, affected by when cash flows happened
+	MoneyWeightedReturn null.Float
+	CashFlows           []BankAccountCashFlow
+}
+
+// NewBankAccountReturns calculates the returns of a Bank Account over a period from its balances up to the
+// end of the period and its cash flows within it
+func NewBankAccountReturns(bankAccountID uuid.UUID, start, end time.Time, balances []BankAccountBalance, cashFlows []BankAccountCashFlow) BankAccountReturns {
+	r := BankAccountReturns{
+		BankAccountID: bankAccountID,
+		StartDate:     start,
+		EndDate:       end,
+		CashFlows:     []BankAccountCashFlow{},
+	}
+
+	narrowed := NarrowBalancesToPeriod(bankAccountID, start, end, balances)
+	if len(narrowed) == 0 {
+		return r
+	}
+
+	valuations := make([]returns.Valuation, 0, len(narrowed))
+	for _, balance := range narrowed {
+		valuations = append(valuations, returns.Valuation{Date: balance.Date, Value: balance.Balance})
+	}
+
+	startValuation, endValuation := valuations[0], valuations[len(valuations)-1]
+	if start.Before(startValuation.Date) {
+		r.StartDate = startValuation.Date
+	}
+	r.EndDate = endValuation.Date
+	r.StartBalance = null.FloatFrom(startValuation.Value)
+	r.EndBalance = null.FloatFrom(endValuation.Value)
+
+	flows := make([]returns.CashFlow, 0)
+	for _, cashFlow := range cashFlows {
+		if cashFlow.BankAccountID != bankAccountID || cashFlow.Deleted.Valid {
+			continue
+		}
+		if !cashFlow.Date.After(startValuation.Date) || cashFlow.Date.After(endValuation.Date) {
+			continue
+		}
+
+		if cashFlow.Type == BankAccountCashFlowTypeWithdrawal {
+			r.Withdrawals += cashFlow.Amount
+		} else {
+			r.Deposits += cashFlow.Amount
+		}
+
+		r.CashFlows = append(r.CashFlows, cashFlow)
+		flows = append(flows, returns.CashFlow{Date: cashFlow.Date, Amount: cashFlow.SignedAmount()})
+	}
+
+	r.Growth = null.FloatFrom(endValuation.Value - startValuation.Value - r.Deposits + r.Withdrawals)
+
+	if twr, err := returns.TimeWeighted(valuations, flows); err == nil {
+		r.TimeWeightedReturn = null.FloatFrom(twr * 100)
+	}
+
+	// the internal rate of return is seen from the account holder, who puts in the starting balance and
+	// every deposit, and takes out every withdrawal and the ending balance
+	investorFlows := make([]returns.CashFlow, 0, len(flows)+2)
+	investorFlows = append(investorFlows, returns.CashFlow{Date: startValuation.Date, Amount: -startValuation.Value})
+	for _, flow := range flows {
+		investorFlows = append(investorFlows, returns.CashFlow{Date: flow.Date, Amount: -flow.Amount})
+	}
+	investorFlows = append(investorFlows, returns.CashFlow{Date: endValuation.Date, Amount: endValuation.Value})
+
+	if endValuation.Date.After(startValuation.Date) {
+		if mwr, err := returns.XIRR(investorFlows); err == nil {
+			r.MoneyWeightedReturn = null.FloatFrom(mwr * 100)
+		}
+	}
+
+	return r
+}
+
+// ToOutput converts Bank Account Returns to their JSON-compatible object representation
+func (r *BankAccountReturns) ToOutput() BankAccountReturnsOutput {
+	cashFlows := make([]BankAccountCashFlowOutput, 0, len(r.CashFlows))
+	for _, cashFlow := range r.CashFlows {
+		cashFlows = append(cashFlows, cashFlow.ToOutput())
+	}
+
+	return BankAccountReturnsOutput{
+		BankAccountID:       r.BankAccountID,
+		StartDate:           cachetime.CacheTime(r.StartDate),
+		EndDate:             cachetime.CacheTime(r.EndDate),
+		StartBalance:        r.StartBalance,
+		EndBalance:          r.EndBalance,
+		Deposits:            r.Deposits,
+		Withdrawals:         r.Withdrawals,
+		NetCashFlow:         r.Deposits - r.Withdrawals,
+		Growth:              r.Growth,
+		TimeWeightedReturn:  r.TimeWeightedReturn,
+		MoneyWeightedReturn: r.MoneyWeightedReturn,
+		CashFlows:           cashFlows,
+	}
+}
+
+// BankAccountReturnsOutput is the JSON-compatible object representation of Bank Account Returns
+type BankAccountReturnsOutput struct {
+	BankAccountID       uuid.UUID                   `json:"bankAccountId"`
+	StartDate           cachetime.CacheTime         `json:"startDate"`
+	EndDate             cachetime.CacheTime         `json:"endDate"`
+	StartBalance        null.Float                  `json:"startBalance"`
+	EndBalance          null.Float                  `json:"endBalance"`
+	Deposits            float64                     `json:"deposits"`
+	Withdrawals         float64                     `json:"withdrawals"`
+	NetCashFlow         float64                     `json:"netCashFlow"`
+	Growth              null.Float                  `json:"growth"`
+	TimeWeightedReturn  null.Float                  `json:"timeWeightedReturn"`
+	MoneyWeightedReturn null.Float                  `json:"moneyWeightedReturn"`
+	CashFlows           []BankAccountCashFlowOutput `json:"cashFlows"`
+}
@@ -0,0 +1,557 @@
+package model
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+const (
+	// CategoryColumnID represents the corresponding column in Categories table
+	CategoryColumnID filter.Field = "categories.entity_id"
+	// CategoryColumnParentID represents the corresponding column in Categories table
+	CategoryColumnParentID filter.Field = "categories.parent_entity_id"
+	// CategoryColumnName represents the corresponding column in Categories table
+	CategoryColumnName filter.Field = "categories.name"
+	// CategoryColumnCreated represents the corresponding column in Categories table
+	CategoryColumnCreated filter.Field = "categories.created"
+	// CategoryColumnCreatedBy represents the corresponding column in Categories table
+	CategoryColumnCreatedBy filter.Field = "categories.created_by"
+	// CategoryColumnUpdated represents the corresponding column in Categories table
+	CategoryColumnUpdated filter.Field = "categories.updated"
+	// CategoryColumnUpdatedBy represents the corresponding column in Categories table
+	CategoryColumnUpdatedBy filter.Field = "categories.updated_by"
+	// CategoryColumnDeleted represents the corresponding column in Categories table
+	CategoryColumnDeleted filter.Field = "categories.deleted"
+	// CategoryColumnDeletedBy represents the corresponding column in Categories table
+	CategoryColumnDeletedBy filter.Field = "categories.deleted_by"
+)
+
+// CategoryFields is the whitelist of fields Categories can be queried and sorted by, keyed by their names in the API
+var CategoryFields = map[string]filter.Field{
+	"id":        CategoryColumnID,
+	"parentId":  CategoryColumnParentID,
+	"name":      CategoryColumnName,
+	"created":   CategoryColumnCreated,
+	"updated":   CategoryColumnUpdated,
+	"deleted":   CategoryColumnDeleted,
+	"createdBy": CategoryColumnCreatedBy,
+	"updatedBy": CategoryColumnUpdatedBy,
+	"deletedBy": CategoryColumnDeletedBy,
+}
+
+const (
+	// CategoryRuleColumnID represents the corresponding column in Category Rules table
+	CategoryRuleColumnID filter.Field = "category_rules.entity_id"
+	// CategoryRuleColumnCategoryID represents the corresponding column in Category Rules table
+	CategoryRuleColumnCategoryID filter.Field = "category_rules.category_entity_id"
+	// CategoryRuleColumnPattern represents the corresponding column in Category Rules table
+	CategoryRuleColumnPattern filter.Field = "category_rules.pattern"
+	// CategoryRuleColumnPriority represents the corresponding column in Category Rules table
+	CategoryRuleColumnPriority filter.Field = "category_rules.priority"
+	// CategoryRuleColumnCreated represents the corresponding column in Category Rules table
+	CategoryRuleColumnCreated filter.Field = "category_rules.created"
+	// CategoryRuleColumnCreatedBy represents the corresponding column in Category Rules table
+	CategoryRuleColumnCreatedBy filter.Field = "category_rules.created_by"
+	// CategoryRuleColumnUpdated represents the corresponding column in Category Rules table
+	CategoryRuleColumnUpdated filter.Field = "category_rules.updated"
+	// CategoryRuleColumnUpdatedBy represents the corresponding column in Category Rules table
+	CategoryRuleColumnUpdatedBy filter.Field = "category_rules.updated_by"
+	// CategoryRuleColumnDeleted represents the corresponding column in Category Rules table
+	CategoryRuleColumnDeleted filter.Field = "category_rules.deleted"
+	// CategoryRuleColumnDeletedBy represents the corresponding column in Category Rules table
+	CategoryRuleColumnDeletedBy filter.Field = "category_rules.deleted_by"
+)
+
+// CategoryRuleFields is the whitelist of fields Category Rules can be queried and sorted by, keyed by their names in the API
+var CategoryRuleFields = map[string]filter.Field{
+	"id":         CategoryRuleColumnID,
+	"categoryId": CategoryRuleColumnCategoryID,
+	"pattern":    CategoryRuleColumnPattern,
+	"priority":   CategoryRuleColumnPriority,
+	"created":    CategoryRuleColumnCreated,
+	"updated":    CategoryRuleColumnUpdated,
+	"deleted":    CategoryRuleColumnDeleted,
+	"createdBy":  CategoryRuleColumnCreatedBy,
+	"updatedBy":  CategoryRuleColumnUpdatedBy,
+	"deletedBy":  CategoryRuleColumnDeletedBy,
+}
+
+// Category is a node in the tree of spending categories. Transactions are filed under a Category by its name,
+// which is therefore unique among the Categories that have not been deleted.
+type Category struct {
+	ID           uuid.UUID     `db:"entity_id" validate:"min=36,max=36"`
+	ParentID     nuuid.NUUID   `db:"parent_entity_id" validate:"min=36,max=36"`
+	Name         string        `db:"name" validate:"max=255"`
+	Created      time.Time     `db:"created"`
+	CreatedBy    uuid.UUID     `db:"created_by" validate:"min=36,max=36"`
+	Updated      null.Time     `db:"updated"`
+	UpdatedBy    nuuid.NUUID   `db:"updated_by" validate:"min=36,max=36"`
+	Deleted      null.Time     `db:"deleted"`
+	DeletedBy    nuuid.NUUID   `db:"deleted_by" validate:"min=36,max=36"`
+	Transactions []Transaction `db:"-"`
+}
+
+// NewCategoryFromInput creates a new Category from its input object
+func NewCategoryFromInput(input CategoryInput, userID uuid.UUID) (c Category) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+
+	c = Category{
+		ID:        newUUID,
+		ParentID:  input.ParentID,
+		Name:      strings.TrimSpace(input.Name),
+		Created:   now,
+		CreatedBy: userID,
+	}
+
+	return
+}
+
+// Update performs an update on a Category
+func (c *Category) Update(input CategoryInput, userID uuid.UUID) error {
+	if c.Deleted.Valid || c.DeletedBy.Valid {
+		return failure.OperationNotPermitted("update", "Category", "already deleted")
+	}
+
+	now := time.Now()
+
+	c.ParentID = input.ParentID
+	c.Name = strings.TrimSpace(input.Name)
+	c.Updated = null.TimeFrom(now)
+	c.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Delete performs a delete on a Category
+func (c *Category) Delete(userID uuid.UUID) error {
+	if c.Deleted.Valid || c.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Category", "already deleted")
+	}
+
+	now := time.Now()
+
+	c.Deleted = null.TimeFrom(now)
+	c.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// ToOutput converts a Category to its JSON-compatible object representation
+func (c *Category) ToOutput() CategoryOutput {
+	return CategoryOutput{
+		ID:        c.ID,
+		ParentID:  c.ParentID,
+		Name:      c.Name,
+		Created:   cachetime.CacheTime(c.Created),
+		CreatedBy: c.CreatedBy,
+		Updated:   cachetime.NCacheTime(c.Updated),
+		UpdatedBy: c.UpdatedBy,
+		Deleted:   cachetime.NCacheTime(c.Deleted),
+		DeletedBy: c.DeletedBy,
+	}
+}
+
+// CategoryInput represents an input struct for Category entity
+type CategoryInput struct {
+	ID       uuid.UUID   `json:"id"`
+	ParentID nuuid.NUUID `json:"parentId"`
+	Name     string      `json:"name"`
+}
+
+// Validate checks a Category input before it is stored
+func (i *CategoryInput) Validate() error {
+	name := strings.TrimSpace(i.Name)
+
+	if len(name) == 0 {
+		return failure.BadRequestFromString("category name is required")
+	}
+
+	if len(name) > 255 {
+		return failure.BadRequestFromString("category name must be at most 255 characters")
+	}
+
+	if normalizeCategoryName(name) == TransferCategory {
+		return failure.BadRequestFromString("category name " + TransferCategory + " is reserved for transfers")
+	}
+
+	if i.ParentID.Valid && i.ParentID.UUID == i.ID {
+		return failure.BadRequestFromString("a category cannot be its own parent")
+	}
+
+	return nil
+}
+
+// CategoryOutput is the JSON-compatible object representation of Category
+type CategoryOutput struct {
+	ID        uuid.UUID            `json:"id"`
+	ParentID  nuuid.NUUID          `json:"parentId,omitempty"`
+	Name      string               `json:"name"`
+	Created   cachetime.CacheTime  `json:"created"`
+	CreatedBy uuid.UUID            `json:"createdBy"`
+	Updated   cachetime.NCacheTime `json:"updated,omitempty"`
+	UpdatedBy nuuid.NUUID          `json:"updatedBy,omitempty"`
+	Deleted   cachetime.NCacheTime `json:"deleted,omitempty"`
+	DeletedBy nuuid.NUUID          `json:"deletedBy,omitempty"`
+}
+
+// CategoryTreeOutput is a Category along with the Categories below it
+type CategoryTreeOutput struct {
+	CategoryOutput
+	Children []CategoryTreeOutput `json:"children"`
+}
+
+// NewCategoryTree arranges Categories into a tree, sorted by name on each level. Categories whose parent is
+// not among them are placed at the root.
+func NewCategoryTree(categories []Category) []CategoryTreeOutput {
+	known := make(map[uuid.UUID]bool)
+	for _, category := range categories {
+		known[category.ID] = true
+	}
+
+	children := make(map[uuid.UUID][]Category)
+	roots := make([]Category, 0)
+	for _, category := range categories {
+		if category.ParentID.Valid && known[category.ParentID.UUID] {
+			children[category.ParentID.UUID] = append(children[category.ParentID.UUID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var build func(level []Category) []CategoryTreeOutput
+	build = func(level []Category) []CategoryTreeOutput {
+		sort.SliceStable(level, func(i, j int) bool {
+			return normalizeCategoryName(level[i].Name) < normalizeCategoryName(level[j].Name)
+		})
+
+		nodes := make([]CategoryTreeOutput, 0, len(level))
+		for _, category := range level {
+			nodes = append(nodes, CategoryTreeOutput{
+				CategoryOutput: category.ToOutput(),
+				Children:       build(children[category.ID]),
+			})
+		}
+		return nodes
+	}
+
+	return build(roots)
+}
+
+// IsCategoryAncestor checks whether a Category is the ancestor of another, walking up the tree formed by a set of Categories
+func IsCategoryAncestor(categories []Category, ancestorID uuid.UUID, id uuid.UUID) bool {
+	parents := make(map[uuid.UUID]nuuid.NUUID)
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+
+	// the number of steps is bounded so that a tree that is already broken cannot loop forever
+	for steps := 0; steps <= len(categories); steps++ {
+		parent, ok := parents[id]
+		if !ok || !parent.Valid {
+			return false
+		}
+		if parent.UUID == ancestorID {
+			return true
+		}
+		id = parent.UUID
+	}
+
+	return false
+}
+
+// FindCategoryByName finds the Category with a name among a set of Categories, regardless of case.
+// It returns nil when there is none.
+func FindCategoryByName(categories []Category, name string) *Category {
+	for _, category := range categories {
+		if normalizeCategoryName(category.Name) == normalizeCategoryName(name) {
+			return &category
+		}
+	}
+
+	return nil
+}
+
+// normalizeCategoryName brings a Category name to the form it is compared in, as names are not case sensitive
+func normalizeCategoryName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// CategoryFilterInput is the filter input object for Categories
+type CategoryFilterInput struct {
+	filter.BaseFilterInput
+	ParentIDs *[]uuid.UUID `json:"parentIds,omitempty"`
+	Names     *[]string    `json:"names,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *CategoryFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		CategoryColumnName,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "categories",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.ParentIDs != nil {
+		if len(*f.ParentIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: CategoryColumnParentID,
+				Operand2: *f.ParentIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.Names != nil {
+		if len(*f.Names) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: CategoryColumnName,
+				Operand2: *f.Names,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(CategoryFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, CategoryFields)
+	}
+
+	return theFilter
+}
+
+// CategoryRule files new Transactions under a Category when their payee matches its pattern. The pattern is
+// matched against the whole payee regardless of case, with * standing for any run of characters, so that
+// "*grocer*" matches "Corner Grocery Store". Rules with a lower priority are tried first.
+type CategoryRule struct {
+	ID         uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	CategoryID uuid.UUID   `db:"category_entity_id" validate:"min=36,max=36"`
+	Pattern    string      `db:"pattern" validate:"max=255"`
+	Priority   int         `db:"priority"`
+	Created    time.Time   `db:"created"`
+	CreatedBy  uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
+	Updated    null.Time   `db:"updated"`
+	UpdatedBy  nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
+	Deleted    null.Time   `db:"deleted"`
+	DeletedBy  nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+}
+
+// NewCategoryRuleFromInput creates a new Category Rule from its input object
+func NewCategoryRuleFromInput(input CategoryRuleInput, userID uuid.UUID) (r CategoryRule) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+
+	r = CategoryRule{
+		ID:         newUUID,
+		CategoryID: input.CategoryID,
+		Pattern:    strings.TrimSpace(input.Pattern),
+		Priority:   input.Priority,
+		Created:    now,
+		CreatedBy:  userID,
+	}
+
+	return
+}
+
+// Update performs an update on a Category Rule
+func (r *CategoryRule) Update(input CategoryRuleInput, userID uuid.UUID) error {
+	if r.Deleted.Valid || r.DeletedBy.Valid {
+		return failure.OperationNotPermitted("update", "Category Rule", "already deleted")
+	}
+
+	now := time.Now()
+
+	r.CategoryID = input.CategoryID
+	r.Pattern = strings.TrimSpace(input.Pattern)
+	r.Priority = input.Priority
+	r.Updated = null.TimeFrom(now)
+	r.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Delete performs a delete on a Category Rule
+func (r *CategoryRule) Delete(userID uuid.UUID) error {
+	if r.Deleted.Valid || r.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Category Rule", "already deleted")
+	}
+
+	now := time.Now()
+
+	r.Deleted = null.TimeFrom(now)
+	r.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Matches checks whether the pattern of a Category Rule matches a payee
+func (r *CategoryRule) Matches(payee string) bool {
+	return compileCategoryRulePattern(r.Pattern).MatchString(strings.TrimSpace(payee))
+}
+
+// ToOutput converts a Category Rule to its JSON-compatible object representation
+func (r *CategoryRule) ToOutput() CategoryRuleOutput {
+	return CategoryRuleOutput{
+		ID:         r.ID,
+		CategoryID: r.CategoryID,
+		Pattern:    r.Pattern,
+		Priority:   r.Priority,
+		Created:    cachetime.CacheTime(r.Created),
+		CreatedBy:  r.CreatedBy,
+		Updated:    cachetime.NCacheTime(r.Updated),
+		UpdatedBy:  r.UpdatedBy,
+		Deleted:    cachetime.NCacheTime(r.Deleted),
+		DeletedBy:  r.DeletedBy,
+	}
+}
+
+func compileCategoryRulePattern(pattern string) *regexp.Regexp {
+	expression := strings.ReplaceAll(regexp.QuoteMeta(strings.TrimSpace(pattern)), `\*`, ".*")
+	return regexp.MustCompile("(?is)^" + expression + "$")
+}
+
+// MatchCategoryRule finds the Category Rule that applies to a payee, trying rules by their priority and then
+// by the order they were created in. It returns nil when there is no payee or no rule matches it.
+func MatchCategoryRule(rules []CategoryRule, payee string) *CategoryRule {
+	if len(strings.TrimSpace(payee)) == 0 {
+		return nil
+	}
+
+	ordered := make([]CategoryRule, len(rules))
+	copy(ordered, rules)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority < ordered[j].Priority
+		}
+		return ordered[i].Created.Before(ordered[j].Created)
+	})
+
+	for _, rule := range ordered {
+		if rule.Deleted.Valid {
+			continue
+		}
+		if rule.Matches(payee) {
+			return &rule
+		}
+	}
+
+	return nil
+}
+
+// MatchCategory finds the Category a payee is filed under by the first Category Rule that matches it, skipping
+// rules whose Category is not among the given ones. It returns nil when no rule applies.
+func MatchCategory(rules []CategoryRule, categories []Category, payee string) *Category {
+	categoriesByID := make(map[uuid.UUID]Category)
+	for _, category := range categories {
+		categoriesByID[category.ID] = category
+	}
+
+	applicable := make([]CategoryRule, 0, len(rules))
+	for _, rule := range rules {
+		if _, ok := categoriesByID[rule.CategoryID]; ok {
+			applicable = append(applicable, rule)
+		}
+	}
+
+	rule := MatchCategoryRule(applicable, payee)
+	if rule == nil {
+		return nil
+	}
+
+	category := categoriesByID[rule.CategoryID]
+	return &category
+}
+
+// CategoryRuleInput represents an input struct for Category Rule entity
+type CategoryRuleInput struct {
+	ID         uuid.UUID `json:"id"`
+	CategoryID uuid.UUID `json:"categoryId"`
+	Pattern    string    `json:"pattern"`
+	Priority   int       `json:"priority"`
+}
+
+// Validate checks a Category Rule input before it is stored
+func (i *CategoryRuleInput) Validate() error {
+	pattern := strings.TrimSpace(i.Pattern)
+
+	if len(pattern) == 0 {
+		return failure.BadRequestFromString("category rule pattern is required")
+	}
+
+	if len(pattern) > 255 {
+		return failure.BadRequestFromString("category rule pattern must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// CategoryRuleOutput is the JSON-compatible object representation of Category Rule
+type CategoryRuleOutput struct {
+	ID         uuid.UUID            `json:"id"`
+	CategoryID uuid.UUID            `json:"categoryId"`
+	Pattern    string               `json:"pattern"`
+	Priority   int                  `json:"priority"`
+	Created    cachetime.CacheTime  `json:"created"`
+	CreatedBy  uuid.UUID            `json:"createdBy"`
+	Updated    cachetime.NCacheTime `json:"updated,omitempty"`
+	UpdatedBy  nuuid.NUUID          `json:"updatedBy,omitempty"`
+	Deleted    cachetime.NCacheTime `json:"deleted,omitempty"`
+	DeletedBy  nuuid.NUUID          `json:"deletedBy,omitempty"`
+}
+
+// CategoryRuleFilterInput is the filter input object for Category Rules
+type CategoryRuleFilterInput struct {
+	filter.BaseFilterInput
+	CategoryIDs *[]uuid.UUID `json:"categoryIds,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *CategoryRuleFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		CategoryRuleColumnPattern,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "category_rules",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.CategoryIDs != nil {
+		if len(*f.CategoryIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: CategoryRuleColumnCategoryID,
+				Operand2: *f.CategoryIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(CategoryRuleFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, CategoryRuleFields)
+	}
+
+	return theFilter
+}
+
+// CategorizationOutput describes the Transactions filed under a Category by applying Category Rules
+type CategorizationOutput struct {
+	Categorized  int                 `json:"categorized"`
+	Transactions []TransactionOutput `json:"transactions"`
+}
@@ -0,0 +1,412 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// CustomFieldDateFormat is the format in which the values of date Custom Fields are stored
+const CustomFieldDateFormat = "2006-01-02"
+
+// CustomFieldType indicates the kind of value a Custom Field holds
+type CustomFieldType string
+
+const (
+	// CustomFieldTypeText indicates a Custom Field holding free text
+	CustomFieldTypeText CustomFieldType = "text"
+	// CustomFieldTypeNumber indicates a Custom Field holding a number
+	CustomFieldTypeNumber CustomFieldType = "number"
+	// CustomFieldTypeDate indicates a Custom Field holding a date
+	CustomFieldTypeDate CustomFieldType = "date"
+	// CustomFieldTypeBoolean indicates a Custom Field holding either true or false
+	CustomFieldTypeBoolean CustomFieldType = "boolean"
+)
+
+// IsValid checks whether a Custom Field type is one of the supported types
+func (t CustomFieldType) IsValid() bool {
+	return t == CustomFieldTypeText ||
+		t == CustomFieldTypeNumber ||
+		t == CustomFieldTypeDate ||
+		t == CustomFieldTypeBoolean
+}
+
+// NormalizeValue checks that a value suits a Custom Field type and returns it in the form it is stored in
+func (t CustomFieldType) NormalizeValue(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch t {
+	case CustomFieldTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", failure.BadRequestFromString(fmt.Sprintf("invalid number: %s", value))
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case CustomFieldTypeDate:
+		date, err := time.Parse(CustomFieldDateFormat, value)
+		if err != nil {
+			return "", failure.BadRequestFromString(fmt.Sprintf("invalid date: %s", value))
+		}
+		return date.Format(CustomFieldDateFormat), nil
+	case CustomFieldTypeBoolean:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", failure.BadRequestFromString(fmt.Sprintf("invalid boolean: %s", value))
+		}
+		return strconv.FormatBool(boolean), nil
+	}
+
+	return value, nil
+}
+
+const (
+	// CustomFieldColumnID represents the corresponding column in Custom Fields table
+	CustomFieldColumnID filter.Field = "custom_fields.entity_id"
+	// CustomFieldColumnEntityType represents the corresponding column in Custom Fields table
+	CustomFieldColumnEntityType filter.Field = "custom_fields.entity_type"
+	// CustomFieldColumnName represents the corresponding column in Custom Fields table
+	CustomFieldColumnName filter.Field = "custom_fields.name"
+	// CustomFieldColumnType represents the corresponding column in Custom Fields table
+	CustomFieldColumnType filter.Field = "custom_fields.field_type"
+	// CustomFieldColumnCreated represents the corresponding column in Custom Fields table
+	CustomFieldColumnCreated filter.Field = "custom_fields.created"
+	// CustomFieldColumnCreatedBy represents the corresponding column in Custom Fields table
+	CustomFieldColumnCreatedBy filter.Field = "custom_fields.created_by"
+	// CustomFieldColumnUpdated represents the corresponding column in Custom Fields table
+	CustomFieldColumnUpdated filter.Field = "custom_fields.updated"
+	// CustomFieldColumnUpdatedBy represents the corresponding column in Custom Fields table
+	CustomFieldColumnUpdatedBy filter.Field = "custom_fields.updated_by"
+	// CustomFieldColumnDeleted represents the corresponding column in Custom Fields table
+	CustomFieldColumnDeleted filter.Field = "custom_fields.deleted"
+	// CustomFieldColumnDeletedBy represents the corresponding column in Custom Fields table
+	CustomFieldColumnDeletedBy filter.Field = "custom_fields.deleted_by"
+)
+
+// CustomFieldFields is the whitelist of fields Custom Fields can be queried and sorted by, keyed by their names in the API
+var CustomFieldFields = map[string]filter.Field{
+	"id":         CustomFieldColumnID,
+	"entityType": CustomFieldColumnEntityType,
+	"name":       CustomFieldColumnName,
+	"type":       CustomFieldColumnType,
+	"created":    CustomFieldColumnCreated,
+	"updated":    CustomFieldColumnUpdated,
+	"deleted":    CustomFieldColumnDeleted,
+	"createdBy":  CustomFieldColumnCreatedBy,
+	"updatedBy":  CustomFieldColumnUpdatedBy,
+	"deletedBy":  CustomFieldColumnDeletedBy,
+}
+
+// CustomField is a user-defined field that entities of a type can be given a value for
+type CustomField struct {
+	ID         uuid.UUID       `db:"entity_id" validate:"min=36,max=36"`
+	EntityType EntityType      `db:"entity_type"`
+	Name       string          `db:"name" validate:"max=255"`
+	Type       CustomFieldType `db:"field_type"`
+	Created    time.Time       `db:"created"`
+	CreatedBy  uuid.UUID       `db:"created_by" validate:"min=36,max=36"`
+	Updated    null.Time       `db:"updated"`
+	UpdatedBy  nuuid.NUUID     `db:"updated_by" validate:"min=36,max=36"`
+	Deleted    null.Time       `db:"deleted"`
+	DeletedBy  nuuid.NUUID     `db:"deleted_by" validate:"min=36,max=36"`
+}
+
+// NewCustomFieldFromInput creates a new Custom Field from its input object, which must have been validated
+func NewCustomFieldFromInput(input CustomFieldInput, userID uuid.UUID) (cf CustomField) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+
+	cf = CustomField{
+		ID:         newUUID,
+		EntityType: input.EntityType,
+		Name:       strings.TrimSpace(input.Name),
+		Type:       input.Type,
+		Created:    now,
+		CreatedBy:  userID,
+	}
+
+	return
+}
+
+// Update performs an update on a Custom Field, whose input must have been validated.
+// The type of entity and of value cannot be changed once values may have been stored.
+func (cf *CustomField) Update(input CustomFieldInput, userID uuid.UUID) error {
+	if cf.Deleted.Valid || cf.DeletedBy.Valid {
+		return failure.OperationNotPermitted("update", "Custom Field", "already deleted")
+	}
+
+	if input.EntityType != cf.EntityType {
+		return failure.OperationNotPermitted("update", "Custom Field", "the entity type cannot be changed")
+	}
+
+	if input.Type != cf.Type {
+		return failure.OperationNotPermitted("update", "Custom Field", "the field type cannot be changed")
+	}
+
+	now := time.Now()
+
+	cf.Name = strings.TrimSpace(input.Name)
+	cf.Updated = null.TimeFrom(now)
+	cf.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Delete performs a delete on a Custom Field, which hides every value stored for it
+func (cf *CustomField) Delete(userID uuid.UUID) error {
+	if cf.Deleted.Valid || cf.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Custom Field", "already deleted")
+	}
+
+	now := time.Now()
+
+	cf.Deleted = null.TimeFrom(now)
+	cf.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// ToOutput converts a Custom Field to its JSON-compatible object representation
+func (cf *CustomField) ToOutput() CustomFieldOutput {
+	return CustomFieldOutput{
+		ID:         cf.ID,
+		EntityType: cf.EntityType,
+		Name:       cf.Name,
+		Type:       cf.Type,
+		Created:    cachetime.CacheTime(cf.Created),
+		CreatedBy:  cf.CreatedBy,
+		Updated:    cachetime.NCacheTime(cf.Updated),
+		UpdatedBy:  cf.UpdatedBy,
+		Deleted:    cachetime.NCacheTime(cf.Deleted),
+		DeletedBy:  cf.DeletedBy,
+	}
+}
+
+// CustomFieldInput represents an input struct for Custom Field entity
+type CustomFieldInput struct {
+	ID         uuid.UUID       `json:"id"`
+	EntityType EntityType      `json:"entityType"`
+	Name       string          `json:"name"`
+	Type       CustomFieldType `json:"type"`
+}
+
+// Validate checks a Custom Field input before it is stored
+func (i *CustomFieldInput) Validate() error {
+	err := CheckTaggableEntityType(i.EntityType)
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSpace(i.Name)
+
+	if len(name) == 0 {
+		return failure.BadRequestFromString("custom field name is required")
+	}
+
+	if len(name) > 255 {
+		return failure.BadRequestFromString("custom field name must be at most 255 characters")
+	}
+
+	if !i.Type.IsValid() {
+		return failure.BadRequestFromString(fmt.Sprintf("invalid custom field type: %s", i.Type))
+	}
+
+	return nil
+}
+
+// CustomFieldOutput is the JSON-compatible object representation of Custom Field
+type CustomFieldOutput struct {
+	ID         uuid.UUID            `json:"id"`
+	EntityType EntityType           `json:"entityType"`
+	Name       string               `json:"name"`
+	Type       CustomFieldType      `json:"type"`
+	Created    cachetime.CacheTime  `json:"created"`
+	CreatedBy  uuid.UUID            `json:"createdBy"`
+	Updated    cachetime.NCacheTime `json:"updated,omitempty"`
+	UpdatedBy  nuuid.NUUID          `json:"updatedBy,omitempty"`
+	Deleted    cachetime.NCacheTime `json:"deleted,omitempty"`
+	DeletedBy  nuuid.NUUID          `json:"deletedBy,omitempty"`
+}
+
+// FindCustomFieldByName finds a Custom Field for a type of entity in a set of Custom Fields by its name,
+// which is not case sensitive
+func FindCustomFieldByName(customFields []CustomField, entityType EntityType, name string) *CustomField {
+	for _, customField := range customFields {
+		if customField.EntityType == entityType &&
+			strings.EqualFold(strings.TrimSpace(customField.Name), strings.TrimSpace(name)) {
+			return &customField
+		}
+	}
+
+	return nil
+}
+
+// CustomFieldValue is the value an entity has for a Custom Field
+type CustomFieldValue struct {
+	CustomFieldID uuid.UUID `db:"custom_field_entity_id" validate:"min=36,max=36"`
+	SubjectID     uuid.UUID `db:"subject_entity_id" validate:"min=36,max=36"`
+	Value         string    `db:"value"`
+}
+
+// CustomFieldValueInput represents an input struct for the value an entity has for a Custom Field.
+// A blank value removes the value the entity had for the Custom Field.
+type CustomFieldValueInput struct {
+	CustomFieldID uuid.UUID `json:"customFieldId"`
+	Value         string    `json:"value"`
+}
+
+// CustomFieldValuesInput represents an input struct for the values an entity has for its Custom Fields,
+// replacing those it had before
+type CustomFieldValuesInput struct {
+	Values []CustomFieldValueInput `json:"values"`
+}
+
+// Validate checks the Custom Field values of an entity, which may only be given once per Custom Field
+func (i *CustomFieldValuesInput) Validate() error {
+	customFieldIDs := make([]uuid.UUID, 0, len(i.Values))
+	for _, value := range i.Values {
+		if slices.Contains(customFieldIDs, value.CustomFieldID) {
+			return failure.BadRequestFromString(fmt.Sprintf("custom field %s is given more than once", value.CustomFieldID))
+		}
+		customFieldIDs = append(customFieldIDs, value.CustomFieldID)
+	}
+
+	return nil
+}
+
+// GetCustomFieldIDs returns the IDs of the Custom Fields given values for
+func (i *CustomFieldValuesInput) GetCustomFieldIDs() []uuid.UUID {
+	customFieldIDs := make([]uuid.UUID, 0, len(i.Values))
+	for _, value := range i.Values {
+		customFieldIDs = append(customFieldIDs, value.CustomFieldID)
+	}
+	return customFieldIDs
+}
+
+// NewCustomFieldValuesFromInput creates the values an entity has for its Custom Fields, checking each value
+// against the type of its Custom Field. The Custom Fields must all be for the entity's type.
+func NewCustomFieldValuesFromInput(input CustomFieldValuesInput, subjectID uuid.UUID, customFields []CustomField) ([]CustomFieldValue, error) {
+	fieldTypes := make(map[uuid.UUID]CustomFieldType)
+	for _, customField := range customFields {
+		fieldTypes[customField.ID] = customField.Type
+	}
+
+	values := make([]CustomFieldValue, 0, len(input.Values))
+	for _, valueInput := range input.Values {
+		if len(strings.TrimSpace(valueInput.Value)) == 0 {
+			continue
+		}
+
+		fieldType, ok := fieldTypes[valueInput.CustomFieldID]
+		if !ok {
+			return nil, failure.EntityNotFound("set values", "Custom Field")
+		}
+
+		value, err := fieldType.NormalizeValue(valueInput.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, CustomFieldValue{
+			CustomFieldID: valueInput.CustomFieldID,
+			SubjectID:     subjectID,
+			Value:         value,
+		})
+	}
+
+	return values, nil
+}
+
+// CustomFieldValueOutput is the JSON-compatible object representation of the value an entity has for a Custom Field
+type CustomFieldValueOutput struct {
+	CustomFieldID uuid.UUID       `json:"customFieldId"`
+	Name          string          `json:"name"`
+	Type          CustomFieldType `json:"type"`
+	Value         string          `json:"value"`
+}
+
+// CustomFieldValuesOutput is the JSON-compatible object representation of the values an entity has for its
+// Custom Fields
+type CustomFieldValuesOutput struct {
+	EntityType EntityType               `json:"entityType"`
+	SubjectID  uuid.UUID                `json:"subjectId"`
+	Values     []CustomFieldValueOutput `json:"values"`
+}
+
+// CustomFieldValues are the values an entity has for the Custom Fields of its type
+type CustomFieldValues struct {
+	EntityType   EntityType
+	SubjectID    uuid.UUID
+	CustomFields []CustomField
+	Values       []CustomFieldValue
+}
+
+// ToOutput converts the values an entity has for its Custom Fields to their JSON-compatible object representation.
+// Custom Fields the entity has no value for are left out.
+func (cfv *CustomFieldValues) ToOutput() CustomFieldValuesOutput {
+	output := CustomFieldValuesOutput{
+		EntityType: cfv.EntityType,
+		SubjectID:  cfv.SubjectID,
+		Values:     make([]CustomFieldValueOutput, 0, len(cfv.Values)),
+	}
+
+	for _, customField := range cfv.CustomFields {
+		for _, value := range cfv.Values {
+			if value.CustomFieldID == customField.ID {
+				output.Values = append(output.Values, CustomFieldValueOutput{
+					CustomFieldID: customField.ID,
+					Name:          customField.Name,
+					Type:          customField.Type,
+					Value:         value.Value,
+				})
+			}
+		}
+	}
+
+	return output
+}
+
+// CustomFieldFilterInput is the filter input object for Custom Fields
+type CustomFieldFilterInput struct {
+	filter.BaseFilterInput
+	EntityTypes *[]EntityType `json:"entityTypes,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *CustomFieldFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		CustomFieldColumnName,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "custom_fields",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.EntityTypes != nil {
+		if len(*f.EntityTypes) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: CustomFieldColumnEntityType,
+				Operand2: *f.EntityTypes,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(CustomFieldFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, CustomFieldFields)
+	}
+
+	return theFilter
+}
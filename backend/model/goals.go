@@ -0,0 +1,418 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+const (
+	// GoalSavingsRateMonths is the number of recent months the savings rate of a Goal is worked out over
+	GoalSavingsRateMonths = 3
+	// GoalHistoryMonths is the number of months, including the current one, the history of a Goal covers
+	GoalHistoryMonths = 12
+	// goalMaxProjectionYears is how far ahead a completion date is still projected, beyond which a Goal
+	// is considered out of reach at its current savings rate
+	goalMaxProjectionYears = 100
+)
+
+const (
+	// GoalColumnID represents the corresponding column in Goals table
+	GoalColumnID filter.Field = "goals.entity_id"
+	// GoalColumnName represents the corresponding column in Goals table
+	GoalColumnName filter.Field = "goals.name"
+	// GoalColumnTargetAmount represents the corresponding column in Goals table
+	GoalColumnTargetAmount filter.Field = "goals.target_amount"
+	// GoalColumnTargetDate represents the corresponding column in Goals table
+	GoalColumnTargetDate filter.Field = "goals.target_date"
+	// GoalColumnCreated represents the corresponding column in Goals table
+	GoalColumnCreated filter.Field = "goals.created"
+	// GoalColumnCreatedBy represents the corresponding column in Goals table
+	GoalColumnCreatedBy filter.Field = "goals.created_by"
+	// GoalColumnUpdated represents the corresponding column in Goals table
+	GoalColumnUpdated filter.Field = "goals.updated"
+	// GoalColumnUpdatedBy represents the corresponding column in Goals table
+	GoalColumnUpdatedBy filter.Field = "goals.updated_by"
+	// GoalColumnDeleted represents the corresponding column in Goals table
+	GoalColumnDeleted filter.Field = "goals.deleted"
+	// GoalColumnDeletedBy represents the corresponding column in Goals table
+	GoalColumnDeletedBy filter.Field = "goals.deleted_by"
+)
+
+// GoalFields is the whitelist of fields Goals can be queried and sorted by, keyed by their names in the API
+var GoalFields = map[string]filter.Field{
+	"id":           GoalColumnID,
+	"name":         GoalColumnName,
+	"targetAmount": GoalColumnTargetAmount,
+	"targetDate":   GoalColumnTargetDate,
+	"created":      GoalColumnCreated,
+	"updated":      GoalColumnUpdated,
+	"deleted":      GoalColumnDeleted,
+	"createdBy":    GoalColumnCreatedBy,
+	"updatedBy":    GoalColumnUpdatedBy,
+	"deletedBy":    GoalColumnDeletedBy,
+}
+
+// Goal is an amount to be saved by a date, funded by the Bank Accounts linked to it
+type Goal struct {
+	ID             uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	Name           string      `db:"name" validate:"max=255"`
+	TargetAmount   float64     `db:"target_amount" validate:"min=0"`
+	TargetDate     time.Time   `db:"target_date"`
+	Created        time.Time   `db:"created"`
+	CreatedBy      uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
+	Updated        null.Time   `db:"updated"`
+	UpdatedBy      nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
+	Deleted        null.Time   `db:"deleted"`
+	DeletedBy      nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+	BankAccountIDs []uuid.UUID `db:"-"`
+}
+
+// GoalBankAccount links a Goal to a Bank Account that funds it
+type GoalBankAccount struct {
+	GoalID        uuid.UUID `db:"goal_entity_id" validate:"min=36,max=36"`
+	BankAccountID uuid.UUID `db:"bank_account_entity_id" validate:"min=36,max=36"`
+}
+
+// NewGoalFromInput creates a new Goal from its input object, which must have been validated
+func NewGoalFromInput(input GoalInput, userID uuid.UUID) (g Goal) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+
+	g = Goal{
+		ID:             newUUID,
+		Name:           strings.TrimSpace(input.Name),
+		TargetAmount:   input.TargetAmount,
+		TargetDate:     input.TargetDate.Time(),
+		Created:        now,
+		CreatedBy:      userID,
+		BankAccountIDs: input.BankAccountIDs,
+	}
+
+	return
+}
+
+// AttachBankAccounts attaches the IDs of the Bank Accounts linked to a Goal
+func (g *Goal) AttachBankAccounts(links []GoalBankAccount, clearBeforeAttach bool) {
+	if clearBeforeAttach || g.BankAccountIDs == nil {
+		g.BankAccountIDs = []uuid.UUID{}
+	}
+
+	for _, link := range links {
+		if link.GoalID == g.ID {
+			g.BankAccountIDs = append(g.BankAccountIDs, link.BankAccountID)
+		}
+	}
+}
+
+// Update performs an update on a Goal, whose input must have been validated
+func (g *Goal) Update(input GoalInput, userID uuid.UUID) error {
+	if g.Deleted.Valid || g.DeletedBy.Valid {
+		return failure.OperationNotPermitted("update", "Goal", "already deleted")
+	}
+
+	now := time.Now()
+
+	g.Name = strings.TrimSpace(input.Name)
+	g.TargetAmount = input.TargetAmount
+	g.TargetDate = input.TargetDate.Time()
+	g.BankAccountIDs = input.BankAccountIDs
+	g.Updated = null.TimeFrom(now)
+	g.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Delete performs a delete on a Goal
+func (g *Goal) Delete(userID uuid.UUID) error {
+	if g.Deleted.Valid || g.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Goal", "already deleted")
+	}
+
+	now := time.Now()
+
+	g.Deleted = null.TimeFrom(now)
+	g.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// ToOutput converts a Goal to its JSON-compatible object representation
+func (g *Goal) ToOutput() GoalOutput {
+	bankAccountIDs := g.BankAccountIDs
+	if bankAccountIDs == nil {
+		bankAccountIDs = []uuid.UUID{}
+	}
+
+	return GoalOutput{
+		ID:             g.ID,
+		Name:           g.Name,
+		TargetAmount:   g.TargetAmount,
+		TargetDate:     cachetime.CacheTime(g.TargetDate),
+		BankAccountIDs: bankAccountIDs,
+		Created:        cachetime.CacheTime(g.Created),
+		CreatedBy:      g.CreatedBy,
+		Updated:        cachetime.NCacheTime(g.Updated),
+		UpdatedBy:      g.UpdatedBy,
+		Deleted:        cachetime.NCacheTime(g.Deleted),
+		DeletedBy:      g.DeletedBy,
+	}
+}
+
+// GoalInput represents an input struct for Goal entity
+type GoalInput struct {
+	ID             uuid.UUID           `json:"id"`
+	Name           string              `json:"name"`
+	TargetAmount   float64             `json:"targetAmount"`
+	TargetDate     cachetime.CacheTime `json:"targetDate"`
+	BankAccountIDs []uuid.UUID         `json:"bankAccountIds"`
+}
+
+// Validate checks a Goal input before it is stored, dropping Bank Accounts that are linked more than once
+func (i *GoalInput) Validate() error {
+	name := strings.TrimSpace(i.Name)
+
+	if len(name) == 0 {
+		return failure.BadRequestFromString("goal name is required")
+	}
+
+	if len(name) > 255 {
+		return failure.BadRequestFromString("goal name must be at most 255 characters")
+	}
+
+	if i.TargetAmount <= 0 {
+		return failure.BadRequestFromString("goal target amount must be greater than zero")
+	}
+
+	if i.TargetDate.Time().IsZero() {
+		return failure.BadRequestFromString("goal target date is required")
+	}
+
+	if len(i.BankAccountIDs) == 0 {
+		return failure.BadRequestFromString("a goal must be funded by at least one bank account")
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	bankAccountIDs := make([]uuid.UUID, 0, len(i.BankAccountIDs))
+	for _, id := range i.BankAccountIDs {
+		if !seen[id] {
+			seen[id] = true
+			bankAccountIDs = append(bankAccountIDs, id)
+		}
+	}
+	i.BankAccountIDs = bankAccountIDs
+
+	return nil
+}
+
+// GoalOutput is the JSON-compatible object representation of Goal
+type GoalOutput struct {
+	ID             uuid.UUID            `json:"id"`
+	Name           string               `json:"name"`
+	TargetAmount   float64              `json:"targetAmount"`
+	TargetDate     cachetime.CacheTime  `json:"targetDate"`
+	BankAccountIDs []uuid.UUID          `json:"bankAccountIds"`
+	Created        cachetime.CacheTime  `json:"created"`
+	CreatedBy      uuid.UUID            `json:"createdBy"`
+	Updated        cachetime.NCacheTime `json:"updated,omitempty"`
+	UpdatedBy      nuuid.NUUID          `json:"updatedBy,omitempty"`
+	Deleted        cachetime.NCacheTime `json:"deleted,omitempty"`
+	DeletedBy      nuuid.NUUID          `json:"deletedBy,omitempty"`
+}
+
+// GoalFilterInput is the filter input object for Goals
+type GoalFilterInput struct {
+	filter.BaseFilterInput
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *GoalFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		GoalColumnName,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "goals",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(GoalFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, GoalFields)
+	}
+
+	return theFilter
+}
+
+// GoalProgress is how far a Goal has come, worked out from the balances of the Bank Accounts funding it
+type GoalProgress struct {
+	Goal    Goal
+	AsOf    time.Time
+	Current float64
+	// SavingsRate is how much the linked Bank Accounts grew per month over the recent months
+	SavingsRate            float64
+	ProjectedDate          null.Time
+	RequiredMonthlySavings null.Float
+	History                []GoalHistoryEntry
+}
+
+// GoalHistoryEntry is the total balance of the Bank Accounts funding a Goal at the end of a month
+type GoalHistoryEntry struct {
+	Date    time.Time
+	Balance float64
+}
+
+// NewGoalProgress works out the progress of a Goal as of a moment from the Bank Accounts linked to it, along
+// with their balances. Deleted Bank Accounts and balances no longer count. The savings rate is the growth of
+// the accounts over the last GoalSavingsRateMonths months, where an account with no balance yet at the start
+// of that period grows from its first balance within it. The completion date is projected at that rate and
+// is left out when the accounts are not growing or the Goal would take too long to reach.
+func NewGoalProgress(goal Goal, bankAccounts []BankAccount, asOf time.Time) GoalProgress {
+	progress := GoalProgress{
+		Goal:    goal,
+		AsOf:    asOf,
+		History: make([]GoalHistoryEntry, 0, GoalHistoryMonths),
+	}
+
+	linked := make(map[uuid.UUID]bool)
+	for _, id := range goal.BankAccountIDs {
+		linked[id] = true
+	}
+
+	funding := make([]BankAccount, 0, len(bankAccounts))
+	for _, bankAccount := range bankAccounts {
+		if linked[bankAccount.ID] && !bankAccount.Deleted.Valid {
+			funding = append(funding, bankAccount)
+		}
+	}
+	report := NetWorthReport{BankAccounts: funding}
+	assets := report.assets()
+
+	windowStart := asOf.AddDate(0, -GoalSavingsRateMonths, 0)
+	growth := 0.0
+	for _, asset := range assets {
+		current, _, found := asset.valueAsOf(asOf)
+		if !found {
+			continue
+		}
+		progress.Current += current
+
+		baseline, _, found := asset.valueAsOf(windowStart)
+		if !found {
+			baseline = asset.firstValueAfter(windowStart)
+		}
+		growth += current - baseline
+	}
+	progress.Current = roundToCents(progress.Current)
+	progress.SavingsRate = roundToCents(growth / GoalSavingsRateMonths)
+
+	remaining := progress.Remaining()
+	if remaining > 0 && growth > 0 {
+		window := asOf.Sub(windowStart)
+		windows := remaining / growth
+		if windows*window.Hours() <= goalMaxProjectionYears*365*24 {
+			progress.ProjectedDate = null.TimeFrom(asOf.Add(time.Duration(windows * float64(window))))
+		}
+	}
+
+	if remaining > 0 {
+		months := 1.0
+		if goal.TargetDate.After(asOf) {
+			months = max(goal.TargetDate.Sub(asOf).Hours()/24/(365.25/12), 1)
+		}
+		progress.RequiredMonthlySavings = null.FloatFrom(roundToCents(remaining / months))
+	}
+
+	monthStart := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, asOf.Location())
+	for i := GoalHistoryMonths - 1; i >= 0; i-- {
+		date := monthStart.AddDate(0, 1-i, 0).Add(-time.Nanosecond)
+		if date.After(asOf) {
+			date = asOf
+		}
+
+		entry, found := GoalHistoryEntry{Date: date}, false
+		for _, asset := range assets {
+			if balance, _, ok := asset.valueAsOf(date); ok {
+				entry.Balance += balance
+				found = true
+			}
+		}
+
+		if found {
+			entry.Balance = roundToCents(entry.Balance)
+			progress.History = append(progress.History, entry)
+		}
+	}
+
+	return progress
+}
+
+// Remaining is the amount still to be saved to reach the Goal
+func (p *GoalProgress) Remaining() float64 {
+	return roundToCents(max(p.Goal.TargetAmount-p.Current, 0))
+}
+
+// IsAchieved checks whether the Goal has been reached
+func (p *GoalProgress) IsAchieved() bool {
+	return p.Current >= p.Goal.TargetAmount
+}
+
+// IsOnTrack checks whether the Goal has been reached, or is projected to be reached by its target date
+func (p *GoalProgress) IsOnTrack() bool {
+	return p.IsAchieved() || (p.ProjectedDate.Valid && !p.ProjectedDate.Time.After(p.Goal.TargetDate))
+}
+
+// ToOutput converts a Goal Progress to its JSON-compatible object representation
+func (p *GoalProgress) ToOutput() GoalProgressOutput {
+	output := GoalProgressOutput{
+		Goal:                   p.Goal.ToOutput(),
+		AsOf:                   cachetime.CacheTime(p.AsOf),
+		Current:                p.Current,
+		Remaining:              p.Remaining(),
+		Percent:                roundToCents(min(p.Current/p.Goal.TargetAmount*100, 100)),
+		Achieved:               p.IsAchieved(),
+		OnTrack:                p.IsOnTrack(),
+		SavingsRate:            p.SavingsRate,
+		ProjectedDate:          cachetime.NCacheTime(p.ProjectedDate),
+		RequiredMonthlySavings: p.RequiredMonthlySavings,
+		History:                make([]GoalHistoryEntryOutput, 0, len(p.History)),
+	}
+
+	for _, entry := range p.History {
+		output.History = append(output.History, GoalHistoryEntryOutput{
+			Date:    cachetime.CacheTime(entry.Date),
+			Balance: entry.Balance,
+		})
+	}
+
+	return output
+}
+
+// GoalProgressOutput is the JSON-compatible object representation of Goal Progress
+type GoalProgressOutput struct {
+	Goal                   GoalOutput               `json:"goal"`
+	AsOf                   cachetime.CacheTime      `json:"asOf"`
+	Current                float64                  `json:"current"`
+	Remaining              float64                  `json:"remaining"`
+	Percent                float64                  `json:"percent"`
+	Achieved               bool                     `json:"achieved"`
+	OnTrack                bool                     `json:"onTrack"`
+	SavingsRate            float64                  `json:"savingsRate"`
+	ProjectedDate          cachetime.NCacheTime     `json:"projectedDate,omitempty"`
+	RequiredMonthlySavings null.Float               `json:"requiredMonthlySavings,omitempty"`
+	History                []GoalHistoryEntryOutput `json:"history"`
+}
+
+// GoalHistoryEntryOutput is the JSON-compatible object representation of Goal History Entry
+type GoalHistoryEntryOutput struct {
+	Date    cachetime.CacheTime `json:"date"`
+	Balance float64             `json:"balance"`
+}
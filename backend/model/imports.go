@@ -0,0 +1,277 @@
+package model
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kerti/balances/backend/util/failure"
+)
+
+// MaxImportRows is the most data rows a single import may hold
+const MaxImportRows = 10000
+
+// ImportDateFormat represents a date format an import's date column may be written in
+type ImportDateFormat string
+
+const (
+	// ImportDateFormatISO represents dates written as 2024-01-31
+	ImportDateFormatISO ImportDateFormat = "YYYY-MM-DD"
+	// ImportDateFormatDayFirst represents dates written as 31/01/2024
+	ImportDateFormatDayFirst ImportDateFormat = "DD/MM/YYYY"
+	// ImportDateFormatMonthFirst represents dates written as 01/31/2024
+	ImportDateFormatMonthFirst ImportDateFormat = "MM/DD/YYYY"
+	// ImportDateFormatDotted represents dates written as 31.01.2024
+	ImportDateFormatDotted ImportDateFormat = "DD.MM.YYYY"
+)
+
+// ImportDateFormatMap is the map of import date formats to their layout for time parsing
+var ImportDateFormatMap = map[ImportDateFormat]string{
+	ImportDateFormatISO:        "2006-01-02",
+	ImportDateFormatDayFirst:   "02/01/2006",
+	ImportDateFormatMonthFirst: "01/02/2006",
+	ImportDateFormatDotted:     "02.01.2006",
+}
+
+// ImportColumnMapping maps the fields of an import to the names of the CSV columns holding them
+type ImportColumnMapping struct {
+	Date   string `json:"date"`
+	Amount string `json:"amount"`
+}
+
+// ImportInput is the input object for importing a history of dated amounts from CSV,
+// the first line of which must name its columns
+type ImportInput struct {
+	CSV        string              `json:"csv"`
+	Mapping    ImportColumnMapping `json:"mapping"`
+	DateFormat *ImportDateFormat   `json:"dateFormat,omitempty"`
+	Delimiter  *string             `json:"delimiter,omitempty"`
+	DryRun     bool                `json:"dryRun"`
+}
+
+// ImportRow represents a single valid data row of an import
+type ImportRow struct {
+	Line   int
+	Date   time.Time
+	Amount float64
+}
+
+// ImportRowError represents a problem with a single data row of an import
+type ImportRowError struct {
+	Line    int     `json:"line"`
+	Column  *string `json:"column,omitempty"`
+	Message string  `json:"message"`
+}
+
+// ParseRows reads and validates every data row of the CSV. Problems with the import as a whole are
+// returned as an error, while problems with individual rows are collected so they can all be reported at once.
+func (i *ImportInput) ParseRows() (rows []ImportRow, rowErrors []ImportRowError, err error) {
+	rows = make([]ImportRow, 0)
+	rowErrors = make([]ImportRowError, 0)
+
+	layout, err := i.getDateLayout()
+	if err != nil {
+		return
+	}
+
+	reader := csv.NewReader(strings.NewReader(i.CSV))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if i.Delimiter != nil {
+		delimiter, size := utf8.DecodeRuneInString(*i.Delimiter)
+		if size == 0 || size != len(*i.Delimiter) || delimiter == '"' || delimiter == '\r' || delimiter == '\n' {
+			return rows, rowErrors, failure.BadRequestFromString("import delimiter must be a single character")
+		}
+		reader.Comma = delimiter
+	}
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return rows, rowErrors, failure.BadRequestFromString("import CSV is empty")
+	}
+	if err != nil {
+		return rows, rowErrors, failure.BadRequest(err)
+	}
+
+	dateIdx, err := getImportColumnIndex(header, "date", i.Mapping.Date)
+	if err != nil {
+		return
+	}
+	amountIdx, err := getImportColumnIndex(header, "amount", i.Mapping.Amount)
+	if err != nil {
+		return
+	}
+
+	dataRows := 0
+	dates := make(map[time.Time]int)
+	for {
+		var record []string
+		record, err = reader.Read()
+		if err == io.EOF {
+			err = nil
+			break
+		}
+
+		dataRows++
+		if dataRows > MaxImportRows {
+			return rows, rowErrors, failure.BadRequestFromString(fmt.Sprintf("import holds more than %d rows", MaxImportRows))
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			err = nil
+			rowErrors = append(rowErrors, ImportRowError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return rows, rowErrors, failure.BadRequest(err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if len(record) != len(header) {
+			rowErrors = append(rowErrors, ImportRowError{
+				Line:    line,
+				Message: fmt.Sprintf("expected %d columns, found %d", len(header), len(record)),
+			})
+			continue
+		}
+
+		row := ImportRow{Line: line}
+		valid := true
+
+		row.Date, err = time.Parse(layout, strings.TrimSpace(record[dateIdx]))
+		if err != nil {
+			err = nil
+			valid = false
+			rowErrors = append(rowErrors, ImportRowError{
+				Line:    line,
+				Column:  &i.Mapping.Date,
+				Message: fmt.Sprintf("invalid date: %q", record[dateIdx]),
+			})
+		} else if firstLine, ok := dates[row.Date]; ok {
+			valid = false
+			rowErrors = append(rowErrors, ImportRowError{
+				Line:    line,
+				Column:  &i.Mapping.Date,
+				Message: fmt.Sprintf("duplicate date, already imported on line %d", firstLine),
+			})
+		} else {
+			dates[row.Date] = line
+		}
+
+		row.Amount, err = strconv.ParseFloat(strings.TrimSpace(record[amountIdx]), 64)
+		if err != nil {
+			err = nil
+			valid = false
+			rowErrors = append(rowErrors, ImportRowError{
+				Line:    line,
+				Column:  &i.Mapping.Amount,
+				Message: fmt.Sprintf("invalid amount: %q", record[amountIdx]),
+			})
+		}
+
+		if valid {
+			rows = append(rows, row)
+		}
+	}
+
+	if dataRows == 0 {
+		return rows, rowErrors, failure.BadRequestFromString("import CSV holds no data rows")
+	}
+
+	return
+}
+
+func (i *ImportInput) getDateLayout() (string, error) {
+	if i.DateFormat == nil {
+		return ImportDateFormatMap[ImportDateFormatISO], nil
+	}
+
+	layout, ok := ImportDateFormatMap[*i.DateFormat]
+	if !ok {
+		return "", failure.BadRequestFromString(fmt.Sprintf("unsupported import date format: %s", *i.DateFormat))
+	}
+
+	return layout, nil
+}
+
+// getImportColumnIndex finds the position of a mapped column in the header of an import
+func getImportColumnIndex(header []string, field, column string) (int, error) {
+	if column == "" {
+		return -1, failure.BadRequestFromString(fmt.Sprintf("import mapping requires a column for %s", field))
+	}
+
+	idx := slices.IndexFunc(header, func(name string) bool {
+		return strings.EqualFold(strings.TrimSpace(name), column)
+	})
+	if idx < 0 {
+		return -1, failure.BadRequestFromString(fmt.Sprintf("import CSV has no column named %s", column))
+	}
+
+	return idx, nil
+}
+
+// GetLatestImportRow returns the row with the latest date among the rows of an import
+func GetLatestImportRow(rows []ImportRow) (latest ImportRow) {
+	for idx, row := range rows {
+		if idx == 0 || row.Date.After(latest.Date) {
+			latest = row
+		}
+	}
+	return
+}
+
+// ImportResult describes the outcome of an import
+type ImportResult struct {
+	DryRun   bool
+	Rows     int
+	Imported int
+	Errors   []ImportRowError
+}
+
+// NewImportResult creates a new Import Result for the rows parsed from an import, none of which are imported yet
+func NewImportResult(dryRun bool, rows []ImportRow, rowErrors []ImportRowError) ImportResult {
+	// a row may have several errors, but is only counted once
+	invalidLines := make(map[int]bool)
+	for _, rowError := range rowErrors {
+		invalidLines[rowError.Line] = true
+	}
+
+	return ImportResult{
+		DryRun: dryRun,
+		Rows:   len(rows) + len(invalidLines),
+		Errors: rowErrors,
+	}
+}
+
+// IsValid checks whether every row of the import is valid, which is required for any of them to be imported
+func (r *ImportResult) IsValid() bool {
+	return len(r.Errors) == 0
+}
+
+// ToOutput converts an Import Result to its JSON-compatible object representation
+func (r *ImportResult) ToOutput() ImportResultOutput {
+	return ImportResultOutput{
+		DryRun:   r.DryRun,
+		Valid:    r.IsValid(),
+		Rows:     r.Rows,
+		Imported: r.Imported,
+		Errors:   r.Errors,
+	}
+}
+
+// ImportResultOutput is the JSON-compatible object representation of Import Result
+type ImportResultOutput struct {
+	DryRun   bool             `json:"dryRun"`
+	Valid    bool             `json:"valid"`
+	Rows     int              `json:"rows"`
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,264 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// NoteMaxLength is the most characters a Note may hold
+const NoteMaxLength = 4000
+
+// NotableEntityTypes are the types of entity that notes can be written on
+var NotableEntityTypes = []EntityType{
+	EntityTypeBankAccount,
+	EntityTypeBankAccountBalance,
+	EntityTypeVehicle,
+	EntityTypeVehicleValue,
+	EntityTypeProperty,
+	EntityTypePropertyValue,
+}
+
+const (
+	// NoteColumnID represents the corresponding column in Notes table
+	NoteColumnID filter.Field = "notes.entity_id"
+	// NoteColumnEntityType represents the corresponding column in Notes table
+	NoteColumnEntityType filter.Field = "notes.entity_type"
+	// NoteColumnSubjectID represents the corresponding column in Notes table
+	NoteColumnSubjectID filter.Field = "notes.subject_entity_id"
+	// NoteColumnContent represents the corresponding column in Notes table
+	NoteColumnContent filter.Field = "notes.content"
+	// NoteColumnCreated represents the corresponding column in Notes table
+	NoteColumnCreated filter.Field = "notes.created"
+	// NoteColumnCreatedBy represents the corresponding column in Notes table
+	NoteColumnCreatedBy filter.Field = "notes.created_by"
+	// NoteColumnUpdated represents the corresponding column in Notes table
+	NoteColumnUpdated filter.Field = "notes.updated"
+	// NoteColumnUpdatedBy represents the corresponding column in Notes table
+	NoteColumnUpdatedBy filter.Field = "notes.updated_by"
+	// NoteColumnDeleted represents the corresponding column in Notes table
+	NoteColumnDeleted filter.Field = "notes.deleted"
+	// NoteColumnDeletedBy represents the corresponding column in Notes table
+	NoteColumnDeletedBy filter.Field = "notes.deleted_by"
+)
+
+// NoteFields is the whitelist of fields Notes can be queried and sorted by, keyed by their names in the API
+var NoteFields = map[string]filter.Field{
+	"id":         NoteColumnID,
+	"entityType": NoteColumnEntityType,
+	"subjectId":  NoteColumnSubjectID,
+	"content":    NoteColumnContent,
+	"created":    NoteColumnCreated,
+	"updated":    NoteColumnUpdated,
+	"deleted":    NoteColumnDeleted,
+	"createdBy":  NoteColumnCreatedBy,
+	"updatedBy":  NoteColumnUpdatedBy,
+	"deletedBy":  NoteColumnDeletedBy,
+}
+
+// Note is a piece of free text written on an asset or one of its balances or values to record the reason
+// behind it, such as where a valuation came from. Its author and time are those of its creation.
+type Note struct {
+	ID         uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	EntityType EntityType  `db:"entity_type"`
+	SubjectID  uuid.UUID   `db:"subject_entity_id" validate:"min=36,max=36"`
+	Content    string      `db:"content"`
+	Created    time.Time   `db:"created"`
+	CreatedBy  uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
+	Updated    null.Time   `db:"updated"`
+	UpdatedBy  nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
+	Deleted    null.Time   `db:"deleted"`
+	DeletedBy  nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+}
+
+// NewNoteFromInput creates a new Note from its input object, which must have been validated
+func NewNoteFromInput(input NoteInput, userID uuid.UUID) (n Note) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+
+	n = Note{
+		ID:         newUUID,
+		EntityType: input.EntityType,
+		SubjectID:  input.SubjectID,
+		Content:    strings.TrimSpace(input.Content),
+		Created:    now,
+		CreatedBy:  userID,
+	}
+
+	return
+}
+
+// Update performs an update on a Note, whose input must have been validated. The entity it is written on
+// never changes.
+func (n *Note) Update(input NoteInput, userID uuid.UUID) error {
+	if n.Deleted.Valid || n.DeletedBy.Valid {
+		return failure.OperationNotPermitted("update", "Note", "already deleted")
+	}
+
+	now := time.Now()
+
+	n.Content = strings.TrimSpace(input.Content)
+	n.Updated = null.TimeFrom(now)
+	n.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Delete performs a delete on a Note
+func (n *Note) Delete(userID uuid.UUID) error {
+	if n.Deleted.Valid || n.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Note", "already deleted")
+	}
+
+	now := time.Now()
+
+	n.Deleted = null.TimeFrom(now)
+	n.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// ToOutput converts a Note to its JSON-compatible object representation
+func (n *Note) ToOutput() NoteOutput {
+	return NoteOutput{
+		ID:         n.ID,
+		EntityType: n.EntityType,
+		SubjectID:  n.SubjectID,
+		Content:    n.Content,
+		Created:    cachetime.CacheTime(n.Created),
+		CreatedBy:  n.CreatedBy,
+		Updated:    cachetime.NCacheTime(n.Updated),
+		UpdatedBy:  n.UpdatedBy,
+		Deleted:    cachetime.NCacheTime(n.Deleted),
+		DeletedBy:  n.DeletedBy,
+	}
+}
+
+// NoteInput represents an input struct for Note entity. The entity a Note is written on is only
+// read when it is created.
+type NoteInput struct {
+	ID         uuid.UUID  `json:"id"`
+	EntityType EntityType `json:"entityType"`
+	SubjectID  uuid.UUID  `json:"subjectId"`
+	Content    string     `json:"content"`
+}
+
+// Validate checks the content of a Note input
+func (i *NoteInput) Validate() error {
+	content := strings.TrimSpace(i.Content)
+
+	if len(content) == 0 {
+		return failure.BadRequestFromString("note content is required")
+	}
+
+	if utf8.RuneCountInString(content) > NoteMaxLength {
+		return failure.BadRequestFromString(fmt.Sprintf("note content must be at most %d characters", NoteMaxLength))
+	}
+
+	return nil
+}
+
+// NoteOutput is the JSON-compatible object representation of Note
+type NoteOutput struct {
+	ID         uuid.UUID            `json:"id"`
+	EntityType EntityType           `json:"entityType"`
+	SubjectID  uuid.UUID            `json:"subjectId"`
+	Content    string               `json:"content"`
+	Created    cachetime.CacheTime  `json:"created"`
+	CreatedBy  uuid.UUID            `json:"createdBy"`
+	Updated    cachetime.NCacheTime `json:"updated,omitempty"`
+	UpdatedBy  nuuid.NUUID          `json:"updatedBy,omitempty"`
+	Deleted    cachetime.NCacheTime `json:"deleted,omitempty"`
+	DeletedBy  nuuid.NUUID          `json:"deletedBy,omitempty"`
+}
+
+// CheckNotableEntityType makes sure that notes can be written on entities of a type
+func CheckNotableEntityType(entityType EntityType) error {
+	if !slices.Contains(NotableEntityTypes, entityType) {
+		return failure.BadRequestFromString(fmt.Sprintf("notes cannot be written on entity type %s", entityType))
+	}
+	return nil
+}
+
+// getSubjectNotes picks the Notes written on an entity out of a set of Notes
+func getSubjectNotes(notes []Note, entityType EntityType, subjectID uuid.UUID) []Note {
+	subjectNotes := make([]Note, 0)
+	for _, note := range notes {
+		if note.EntityType == entityType && note.SubjectID == subjectID {
+			subjectNotes = append(subjectNotes, note)
+		}
+	}
+
+	return subjectNotes
+}
+
+// getNoteOutputs converts a set of Notes to their outputs, keeping a set that was never resolved empty
+func getNoteOutputs(notes []Note) []NoteOutput {
+	if notes == nil {
+		return nil
+	}
+
+	outputs := make([]NoteOutput, 0, len(notes))
+	for _, note := range notes {
+		outputs = append(outputs, note.ToOutput())
+	}
+
+	return outputs
+}
+
+// NoteFilterInput is the filter input object for Notes
+type NoteFilterInput struct {
+	filter.BaseFilterInput
+	EntityTypes *[]EntityType `json:"entityTypes,omitempty"`
+	SubjectIDs  *[]uuid.UUID  `json:"subjectIds,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *NoteFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		NoteColumnContent,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "notes",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.EntityTypes != nil {
+		if len(*f.EntityTypes) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: NoteColumnEntityType,
+				Operand2: *f.EntityTypes,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.SubjectIDs != nil {
+		if len(*f.SubjectIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: NoteColumnSubjectID,
+				Operand2: *f.SubjectIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(NoteFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, NoteFields)
+	}
+
+	return theFilter
+}
@@ -89,6 +89,32 @@ const (
 	PropertyColumnDeletedBy filter.Field = "properties.deleted_by"
 )
 
+// PropertyFields is the whitelist of fields Properties can be queried and sorted by, keyed by their names in the API
+var PropertyFields = map[string]filter.Field{
+	"id":                        PropertyColumnID,
+	"name":                      PropertyColumnName,
+	"address":                   PropertyColumnAddress,
+	"totalArea":                 PropertyColumnTotalArea,
+	"buildingArea":              PropertyColumnBuildingArea,
+	"areaUnit":                  PropertyColumnAreaUnit,
+	"type":                      PropertyColumnType,
+	"titleHolder":               PropertyColumnTitleHolder,
+	"taxIdentifier":             PropertyColumnTaxIdentifier,
+	"purchaseDate":              PropertyColumnPurchaseDate,
+	"initialValue":              PropertyColumnInitialValue,
+	"initialValueDate":          PropertyColumnInitialValueDate,
+	"currentValue":              PropertyColumnCurrentValue,
+	"currentValueDate":          PropertyColumnCurrentvalueDate,
+	"annualAppreciationPercent": PropertyColumnAnnualAppreciationPercent,
+	"status":                    PropertyColumnStatus,
+	"created":                   PropertyColumnCreated,
+	"updated":                   PropertyColumnUpdated,
+	"deleted":                   PropertyColumnDeleted,
+	"createdBy":                 PropertyColumnCreatedBy,
+	"updatedBy":                 PropertyColumnUpdatedBy,
+	"deletedBy":                 PropertyColumnDeletedBy,
+}
+
 const (
 	// PropertyValueColumnID represents the corresponding column in the Property Value table
 	PropertyValueColumnID filter.Field = "property_values.entity_id"
@@ -112,6 +138,20 @@ const (
 	PropertyValueColumnDeletedBy filter.Field = "property_values.deleted_by"
 )
 
+// PropertyValueFields is the whitelist of fields Property Values can be queried and sorted by, keyed by their names in the API
+var PropertyValueFields = map[string]filter.Field{
+	"id":         PropertyValueColumnID,
+	"propertyId": PropertyValueColumnPropertyID,
+	"date":       PropertyValueColumnDate,
+	"value":      PropertyValueColumnValue,
+	"created":    PropertyValueColumnCreated,
+	"updated":    PropertyValueColumnUpdated,
+	"deleted":    PropertyValueColumnDeleted,
+	"createdBy":  PropertyValueColumnCreatedBy,
+	"updatedBy":  PropertyValueColumnUpdatedBy,
+	"deletedBy":  PropertyValueColumnDeletedBy,
+}
+
 // Property represents a Property object
 type Property struct {
 	ID                        uuid.UUID        `db:"entity_id" validate:"min=36,max=36"`
@@ -137,6 +177,7 @@ type Property struct {
 	Deleted                   null.Time        `db:"deleted"`
 	DeletedBy                 nuuid.NUUID      `db:"deleted_by" validate:"min=36,max=36"`
 	Values                    []PropertyValue  `db:"-"`
+	Notes                     []Note           `db:"-"`
 }
 
 // NewPropertyFromInput creates a new Property from its input object
@@ -201,6 +242,24 @@ func (p *Property) AttachValues(values []PropertyValue, clearBeforeAttach bool)
 	}
 }
 
+// AttachNotes attaches the Notes written on a Property and on its attached Values
+func (p *Property) AttachNotes(notes []Note) {
+	p.Notes = getSubjectNotes(notes, EntityTypeProperty, p.ID)
+	for i := range p.Values {
+		p.Values[i].AttachNotes(notes)
+	}
+}
+
+// GetNoteSubjectIDs lists the IDs of a Property and its attached Values, which Notes can be written on
+func (p *Property) GetNoteSubjectIDs() []uuid.UUID {
+	ids := []uuid.UUID{p.ID}
+	for _, value := range p.Values {
+		ids = append(ids, value.ID)
+	}
+
+	return ids
+}
+
 // Update performs an update on a Property
 func (p *Property) Update(input PropertyInput, userID uuid.UUID) error {
 	if p.Deleted.Valid || p.DeletedBy.Valid {
@@ -228,7 +287,8 @@ func (p *Property) Update(input PropertyInput, userID uuid.UUID) error {
 	return nil
 }
 
-// Delete performs a delete on a Property
+// Delete performs a delete on a Property. Its values are deleted along with it and marked as deleted by
+// its cascade, which is what allows Restore to tell them apart from values deleted on their own.
 func (p *Property) Delete(userID uuid.UUID) error {
 	if p.Deleted.Valid || p.DeletedBy.Valid {
 		return failure.OperationNotPermitted("delete", "Property", "already deleted")
@@ -241,10 +301,11 @@ func (p *Property) Delete(userID uuid.UUID) error {
 
 	deletedValues := make([]PropertyValue, 0)
 	for _, value := range p.Values {
-		err := value.Delete(userID)
+		err := value.delete(userID, now)
 		if err != nil {
 			return err
 		}
+		value.DeletedByCascade = true
 
 		deletedValues = append(deletedValues, value)
 	}
@@ -254,6 +315,34 @@ func (p *Property) Delete(userID uuid.UUID) error {
 	return nil
 }
 
+// Restore reverts the delete of a Property along with the values deleted by that same delete.
+// Values that had been deleted on their own beforehand are left deleted.
+func (p *Property) Restore(userID uuid.UUID) error {
+	if !p.Deleted.Valid && !p.DeletedBy.Valid {
+		return failure.OperationNotPermitted("restore", "Property", "not deleted")
+	}
+
+	now := time.Now()
+
+	restoredValues := make([]PropertyValue, 0)
+	for _, value := range p.Values {
+		if !value.DeletedByCascade {
+			continue
+		}
+
+		value.restore(userID, now)
+		restoredValues = append(restoredValues, value)
+	}
+
+	p.Deleted = null.Time{}
+	p.DeletedBy = nuuid.NUUID{}
+	p.Updated = null.TimeFrom(now)
+	p.UpdatedBy = nuuid.From(userID)
+	p.Values = restoredValues
+
+	return nil
+}
+
 // SetCurrentValue sets a new current value and current value date on a Property
 func (p *Property) SetCurrentValue(input PropertyValueInput, userID uuid.UUID) error {
 	now := time.Now()
@@ -301,6 +390,7 @@ func (p *Property) ToOutput() PropertyOutput {
 	}
 
 	o.Values = vvOutput
+	o.Notes = getNoteOutputs(p.Notes)
 
 	return o
 }
@@ -350,20 +440,23 @@ type PropertyOutput struct {
 	Deleted                   cachetime.NCacheTime  `json:"deleted,omitempty"`
 	DeletedBy                 nuuid.NUUID           `json:"deletedBy,omitempty"`
 	Values                    []PropertyValueOutput `json:"values"`
+	Notes                     []NoteOutput          `json:"notes,omitempty"`
 }
 
 // PropertyValue represents a snapshot of a Property's value at a given time
 type PropertyValue struct {
-	ID         uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
-	PropertyID uuid.UUID   `db:"property_entity_id" validate:"min=36,max=36"`
-	Date       time.Time   `db:"date"`
-	Value      float64     `db:"value" validate:"min=0"`
-	Created    time.Time   `db:"created"`
-	CreatedBy  uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
-	Updated    null.Time   `db:"updated"`
-	UpdatedBy  nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
-	Deleted    null.Time   `db:"deleted"`
-	DeletedBy  nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+	ID               uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	PropertyID       uuid.UUID   `db:"property_entity_id" validate:"min=36,max=36"`
+	Date             time.Time   `db:"date"`
+	Value            float64     `db:"value" validate:"min=0"`
+	Created          time.Time   `db:"created"`
+	CreatedBy        uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
+	Updated          null.Time   `db:"updated"`
+	UpdatedBy        nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
+	Deleted          null.Time   `db:"deleted"`
+	DeletedBy        nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+	DeletedByCascade bool        `db:"deleted_by_cascade"`
+	Notes            []Note      `db:"-"`
 }
 
 func NewPropertyValueFromInput(input PropertyValueInput, propertyID uuid.UUID, userID uuid.UUID) (pv PropertyValue) {
@@ -400,18 +493,33 @@ func (pv *PropertyValue) Update(input PropertyValueInput, userID uuid.UUID) erro
 
 // Delete performs a delete on a Property Value
 func (pv *PropertyValue) Delete(userID uuid.UUID) error {
+	return pv.delete(userID, time.Now())
+}
+
+func (pv *PropertyValue) delete(userID uuid.UUID, now time.Time) error {
 	if pv.Deleted.Valid || pv.DeletedBy.Valid {
 		return failure.OperationNotPermitted("delete", "Property Value", "already deleted")
 	}
 
-	now := time.Now()
-
 	pv.Deleted = null.TimeFrom(now)
 	pv.DeletedBy = nuuid.From(userID)
 
 	return nil
 }
 
+func (pv *PropertyValue) restore(userID uuid.UUID, now time.Time) {
+	pv.Deleted = null.Time{}
+	pv.DeletedBy = nuuid.NUUID{}
+	pv.DeletedByCascade = false
+	pv.Updated = null.TimeFrom(now)
+	pv.UpdatedBy = nuuid.From(userID)
+}
+
+// AttachNotes attaches the Notes written on a Property Value
+func (pv *PropertyValue) AttachNotes(notes []Note) {
+	pv.Notes = getSubjectNotes(notes, EntityTypePropertyValue, pv.ID)
+}
+
 // ToOutput converts a Property Value to its JSON-compatible object representation
 func (pv *PropertyValue) ToOutput() PropertyValueOutput {
 	return PropertyValueOutput{
@@ -425,6 +533,7 @@ func (pv *PropertyValue) ToOutput() PropertyValueOutput {
 		UpdatedBy:  pv.UpdatedBy,
 		Deleted:    cachetime.NCacheTime(pv.Deleted),
 		DeletedBy:  pv.DeletedBy,
+		Notes:      getNoteOutputs(pv.Notes),
 	}
 }
 
@@ -436,6 +545,21 @@ type PropertyValueInput struct {
 	Value      float64             `json:"value"`
 }
 
+// PropertyValueImportInput is the input object for importing Property Values from CSV
+type PropertyValueImportInput struct {
+	ImportInput
+	PropertyID uuid.UUID `json:"propertyId"`
+}
+
+// ToValueInput converts a row of the import to the input object for a single Property Value
+func (i *PropertyValueImportInput) ToValueInput(row ImportRow) PropertyValueInput {
+	return PropertyValueInput{
+		PropertyID: i.PropertyID,
+		Date:       cachetime.CacheTime(row.Date),
+		Value:      row.Amount,
+	}
+}
+
 // PropertyValueOutput is the JSON-compatible object representation of Property Value
 type PropertyValueOutput struct {
 	ID         uuid.UUID            `json:"id"`
@@ -448,11 +572,14 @@ type PropertyValueOutput struct {
 	UpdatedBy  nuuid.NUUID          `json:"updatedBy,omitempty"`
 	Deleted    cachetime.NCacheTime `json:"deleted,omitempty"`
 	DeletedBy  nuuid.NUUID          `json:"deletedBy,omitempty"`
+	Notes      []NoteOutput         `json:"notes,omitempty"`
 }
 
 // PropertyFilterInput is the filter input object for Propertys
 type PropertyFilterInput struct {
 	filter.BaseFilterInput
+	Tags        *[]string                `json:"tags,omitempty"`
+	Aggregation *filter.AggregationInput `json:"aggregation,omitempty"`
 }
 
 // ToFilter converts this entity-specific filter to a generic filter.Filter object
@@ -465,12 +592,28 @@ func (f *PropertyFilterInput) ToFilter() filter.Filter {
 		PropertyColumnTaxIdentifier,
 	}
 
-	return filter.Filter{
+	theFilter := filter.Filter{
 		TableName:      "properties",
 		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
 		IncludeDeleted: f.GetIncludeDeleted(),
 		Pagination:     f.BaseFilterInput.GetPagination(),
 	}
+
+	if f.Tags != nil {
+		if len(*f.Tags) > 0 {
+			theFilter.AddClause(newTagClause(PropertyColumnID, EntityTypeProperty, *f.Tags), filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(PropertyFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, PropertyFields)
+	}
+	if theFilter.Err == nil {
+		theFilter.Aggregation, theFilter.Err = f.Aggregation.ToAggregation(PropertyFields)
+	}
+
+	return theFilter
 }
 
 type PropertyValueFilterInput struct {
@@ -532,5 +675,13 @@ func (f *PropertyValueFilterInput) ToFilter() filter.Filter {
 		}, filter.OperatorAnd)
 	}
 
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(PropertyValueFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, PropertyValueFields)
+	}
+	if theFilter.Err == nil {
+		theFilter.Pagination, theFilter.Err = f.BaseFilterInput.GetKeysetPagination(PropertyValueColumnDate, PropertyValueColumnID)
+	}
+
 	return theFilter
 }
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/util/cachetime"
+)
+
+// PurgeSummary describes the soft-deleted records permanently removed by a single purge. AttachmentIDs lists the
+// purged Attachments, whose content is still to be removed from the blob store.
+type PurgeSummary struct {
+	ID                   uuid.UUID
+	Cutoff               time.Time
+	BankAccounts         int64
+	BankAccountBalances  int64
+	BankAccountCashFlows int64
+	Transactions         int64
+	Transfers            int64
+	Categories           int64
+	CategoryRules        int64
+	Budgets              int64
+	Goals                int64
+	Tags                 int64
+	EntityTags           int64
+	CustomFields         int64
+	CustomFieldValues    int64
+	Attachments          int64
+	AttachmentIDs        []uuid.UUID
+	Notes                int64
+	Vehicles             int64
+	VehicleValues        int64
+	Properties           int64
+	PropertyValues       int64
+	Purged               time.Time
+	PurgedBy             uuid.UUID
+}
+
+// NewPurgeSummary creates a new, empty Purge Summary for records deleted before the cutoff.
+// Scheduled purges are performed by the system, which is represented by the nil UUID.
+func NewPurgeSummary(cutoff time.Time, userID uuid.UUID) PurgeSummary {
+	newUUID, _ := uuid.NewV7()
+
+	return PurgeSummary{
+		ID:       newUUID,
+		Cutoff:   cutoff,
+		Purged:   time.Now(),
+		PurgedBy: userID,
+	}
+}
+
+// Total returns the total number of records purged
+func (p *PurgeSummary) Total() int64 {
+	return p.BankAccounts +
+		p.BankAccountBalances +
+		p.BankAccountCashFlows +
+		p.Transactions +
+		p.Transfers +
+		p.Categories +
+		p.CategoryRules +
+		p.Budgets +
+		p.Goals +
+		p.Tags +
+		p.EntityTags +
+		p.CustomFields +
+		p.CustomFieldValues +
+		p.Attachments +
+		p.Notes +
+		p.Vehicles +
+		p.VehicleValues +
+		p.Properties +
+		p.PropertyValues
+}
+
+// ToOutput converts a Purge Summary to its JSON-compatible object representation
+func (p *PurgeSummary) ToOutput() PurgeSummaryOutput {
+	return PurgeSummaryOutput{
+		ID:                   p.ID,
+		Cutoff:               cachetime.CacheTime(p.Cutoff),
+		BankAccounts:         p.BankAccounts,
+		BankAccountBalances:  p.BankAccountBalances,
+		BankAccountCashFlows: p.BankAccountCashFlows,
+		Transactions:         p.Transactions,
+		Transfers:            p.Transfers,
+		Categories:           p.Categories,
+		CategoryRules:        p.CategoryRules,
+		Budgets:              p.Budgets,
+		Goals:                p.Goals,
+		Tags:                 p.Tags,
+		EntityTags:           p.EntityTags,
+		CustomFields:         p.CustomFields,
+		CustomFieldValues:    p.CustomFieldValues,
+		Attachments:          p.Attachments,
+		Notes:                p.Notes,
+		Vehicles:             p.Vehicles,
+		VehicleValues:        p.VehicleValues,
+		Properties:           p.Properties,
+		PropertyValues:       p.PropertyValues,
+		Total:                p.Total(),
+		Purged:               cachetime.CacheTime(p.Purged),
+		PurgedBy:             p.PurgedBy,
+	}
+}
+
+// PurgeSummaryOutput is the JSON-compatible object representation of Purge Summary
+type PurgeSummaryOutput struct {
+	ID                   uuid.UUID           `json:"id"`
+	Cutoff               cachetime.CacheTime `json:"cutoff"`
+	BankAccounts         int64               `json:"bankAccounts"`
+	BankAccountBalances  int64               `json:"bankAccountBalances"`
+	BankAccountCashFlows int64               `json:"bankAccountCashFlows"`
+	Transactions         int64               `json:"transactions"`
+	Transfers            int64               `json:"transfers"`
+	Categories           int64               `json:"categories"`
+	CategoryRules        int64               `json:"categoryRules"`
+	Budgets              int64               `json:"budgets"`
+	Goals                int64               `json:"goals"`
+	Tags                 int64               `json:"tags"`
+	EntityTags           int64               `json:"entityTags"`
+	CustomFields         int64               `json:"customFields"`
+	CustomFieldValues    int64               `json:"customFieldValues"`
+	Attachments          int64               `json:"attachments"`
+	Notes                int64               `json:"notes"`
+	Vehicles             int64               `json:"vehicles"`
+	VehicleValues        int64               `json:"vehicleValues"`
+	Properties           int64               `json:"properties"`
+	PropertyValues       int64               `json:"propertyValues"`
+	Total                int64               `json:"total"`
+	Purged               cachetime.CacheTime `json:"purged"`
+	PurgedBy             uuid.UUID           `json:"purgedBy"`
+}
@@ -0,0 +1,775 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/pdf"
+	"github.com/kerti/balances/backend/util/xlsx"
+)
+
+// ReportAssetClassNames are the names asset classes are presented under in reports
+var ReportAssetClassNames = map[EntityType]string{
+	EntityTypeBankAccount: "Bank Accounts",
+	EntityTypeVehicle:     "Vehicles",
+	EntityTypeProperty:    "Properties",
+}
+
+// reportAssetClasses is the order asset classes are presented in
+var reportAssetClasses = []EntityType{
+	EntityTypeBankAccount,
+	EntityTypeVehicle,
+	EntityTypeProperty,
+}
+
+// NetWorthReport holds the assets of the instance, with their balance and value history attached
+type NetWorthReport struct {
+	Generated    time.Time
+	BankAccounts []BankAccount
+	Vehicles     []Vehicle
+	Properties   []Property
+}
+
+// NetWorthClassTotal is the total value of the assets of a single class that count towards net worth
+type NetWorthClassTotal struct {
+	AssetClass EntityType
+	Count      int
+	Total      float64
+}
+
+// NetWorthHistoryEntry is a single balance or value recorded for an asset
+type NetWorthHistoryEntry struct {
+	Date       time.Time
+	AssetClass EntityType
+	AssetID    uuid.UUID
+	AssetName  string
+	Value      float64
+}
+
+// NewNetWorthReport creates a new Net Worth Report from the assets of the instance
+func NewNetWorthReport(bankAccounts []BankAccount, vehicles []Vehicle, properties []Property) NetWorthReport {
+	return NetWorthReport{
+		Generated:    time.Now(),
+		BankAccounts: bankAccounts,
+		Vehicles:     vehicles,
+		Properties:   properties,
+	}
+}
+
+// Totals returns the total of each asset class. Sold vehicles and properties are no longer held,
+// so they count towards neither the totals nor the net worth.
+func (r *NetWorthReport) Totals() []NetWorthClassTotal {
+	totals := map[EntityType]*NetWorthClassTotal{}
+	for _, assetClass := range reportAssetClasses {
+		totals[assetClass] = &NetWorthClassTotal{AssetClass: assetClass}
+	}
+
+	for _, bankAccount := range r.BankAccounts {
+		totals[EntityTypeBankAccount].Count++
+		totals[EntityTypeBankAccount].Total += bankAccount.LastBalance
+	}
+
+	for _, vehicle := range r.Vehicles {
+		if vehicle.Status != VehicleStatusSold {
+			totals[EntityTypeVehicle].Count++
+			totals[EntityTypeVehicle].Total += vehicle.CurrentValue
+		}
+	}
+
+	for _, property := range r.Properties {
+		if property.Status != PropertyStatusSold {
+			totals[EntityTypeProperty].Count++
+			totals[EntityTypeProperty].Total += property.CurrentValue
+		}
+	}
+
+	result := make([]NetWorthClassTotal, 0, len(reportAssetClasses))
+	for _, assetClass := range reportAssetClasses {
+		result = append(result, *totals[assetClass])
+	}
+	return result
+}
+
+// NetWorth returns the total value of every asset held
+func (r *NetWorthReport) NetWorth() (netWorth float64) {
+	for _, total := range r.Totals() {
+		netWorth += total.Total
+	}
+	return
+}
+
+// History returns the balances and values recorded for every asset, oldest first
+func (r *NetWorthReport) History() []NetWorthHistoryEntry {
+	history := make([]NetWorthHistoryEntry, 0)
+
+	for _, bankAccount := range r.BankAccounts {
+		for _, balance := range bankAccount.Balances {
+			history = append(history, NetWorthHistoryEntry{
+				Date:       balance.Date,
+				AssetClass: EntityTypeBankAccount,
+				AssetID:    bankAccount.ID,
+				AssetName:  bankAccount.AccountName,
+				Value:      balance.Balance,
+			})
+		}
+	}
+
+	for _, vehicle := range r.Vehicles {
+		for _, value := range vehicle.Values {
+			history = append(history, NetWorthHistoryEntry{
+				Date:       value.Date,
+				AssetClass: EntityTypeVehicle,
+				AssetID:    vehicle.ID,
+				AssetName:  vehicle.Name,
+				Value:      value.Value,
+			})
+		}
+	}
+
+	for _, property := range r.Properties {
+		for _, value := range property.Values {
+			history = append(history, NetWorthHistoryEntry{
+				Date:       value.Date,
+				AssetClass: EntityTypeProperty,
+				AssetID:    property.ID,
+				AssetName:  property.Name,
+				Value:      value.Value,
+			})
+		}
+	}
+
+	// entries are already grouped by asset class, so a stable sort keeps that order within a date
+	sort.SliceStable(history, func(i, j int) bool {
+		if !history[i].Date.Equal(history[j].Date) {
+			return history[i].Date.Before(history[j].Date)
+		}
+		return history[i].AssetName < history[j].AssetName
+	})
+
+	return history
+}
+
+// ToWorkbook renders the report as a workbook with a summary sheet, a sheet per asset class and a history sheet
+func (r *NetWorthReport) ToWorkbook() *xlsx.Workbook {
+	workbook := xlsx.NewWorkbook()
+
+	summary := workbook.AddSheet("Summary")
+	summary.SetWidths(20, 10, 20)
+	summary.AddRow("Net Worth Report")
+	summary.AddRow("Generated", r.Generated)
+	summary.AddRow()
+	summary.AddHeader("Asset Class", "Count", "Total")
+	for _, total := range r.Totals() {
+		summary.AddRow(ReportAssetClassNames[total.AssetClass], total.Count, xlsx.Amount(total.Total))
+	}
+	summary.AddRow("Net Worth", nil, xlsx.Total(r.NetWorth()))
+	summary.AddRow()
+	summary.AddRow("Sold vehicles and properties are listed but not counted.")
+
+	bankAccounts := workbook.AddSheet(ReportAssetClassNames[EntityTypeBankAccount])
+	bankAccounts.SetWidths(25, 20, 25, 20, 10, 15, 15)
+	bankAccounts.AddHeader("Account Name", "Bank Name", "Account Holder", "Account Number", "Status", "Balance", "Balance Date")
+	for _, bankAccount := range r.BankAccounts {
+		bankAccounts.AddRow(
+			bankAccount.AccountName,
+			bankAccount.BankName,
+			bankAccount.AccountHolderName,
+			bankAccount.AccountNumber,
+			string(bankAccount.Status),
+			xlsx.Amount(bankAccount.LastBalance),
+			bankAccount.LastBalanceDate)
+	}
+
+	vehicles := workbook.AddSheet(ReportAssetClassNames[EntityTypeVehicle])
+	vehicles.SetWidths(25, 15, 15, 8, 10, 20, 15, 10, 15, 15, 15, 15)
+	vehicles.AddHeader("Name", "Make", "Model", "Year", "Type", "Title Holder", "License Plate", "Status",
+		"Purchase Date", "Initial Value", "Current Value", "Value Date")
+	for _, vehicle := range r.Vehicles {
+		vehicles.AddRow(
+			vehicle.Name,
+			vehicle.Make,
+			vehicle.Model,
+			vehicle.Year,
+			string(vehicle.Type),
+			vehicle.TitleHolder,
+			vehicle.LicensePlateNumber,
+			string(vehicle.Status),
+			vehicle.PurchaseDate,
+			xlsx.Amount(vehicle.InitialValue),
+			xlsx.Amount(vehicle.CurrentValue),
+			vehicle.CurrentValueDate)
+	}
+
+	properties := workbook.AddSheet(ReportAssetClassNames[EntityTypeProperty])
+	properties.SetWidths(25, 35, 12, 20, 12, 15, 15, 15, 15)
+	properties.AddHeader("Name", "Address", "Type", "Title Holder", "Status",
+		"Purchase Date", "Initial Value", "Current Value", "Value Date")
+	for _, property := range r.Properties {
+		properties.AddRow(
+			property.Name,
+			property.Address,
+			string(property.Type),
+			property.TitleHolder,
+			string(property.Status),
+			property.PurchaseDate,
+			xlsx.Amount(property.InitialValue),
+			xlsx.Amount(property.CurrentValue),
+			property.CurrentValueDate)
+	}
+
+	history := workbook.AddSheet("History")
+	history.SetWidths(15, 15, 25, 15)
+	history.AddHeader("Date", "Asset Class", "Asset", "Value")
+	for _, entry := range r.History() {
+		history.AddRow(entry.Date, ReportAssetClassNames[entry.AssetClass], entry.AssetName, xlsx.Amount(entry.Value))
+	}
+
+	return workbook
+}
+
+// ParseReportDate parses the date a report is made for, given either as a date (2006-01-02) or in Unix
+// milliseconds like every other time in the API, returning the end of that day
+func ParseReportDate(value string) (time.Time, error) {
+	var date time.Time
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		date = time.UnixMilli(millis)
+	} else {
+		date, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			return time.Time{}, failure.BadRequestFromString(fmt.Sprintf("invalid date: %s", value))
+		}
+	}
+
+	year, month, day := date.Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, date.Location()).Add(-time.Nanosecond), nil
+}
+
+// NetWorthStatement is a statement of the assets held as of a date, valued from their balance and value history
+type NetWorthStatement struct {
+	AsOf      time.Time
+	Generated time.Time
+	Assets    []NetWorthStatementAsset
+}
+
+// NetWorthStatementAsset is a single asset of a Net Worth Statement, valued at its last balance or value
+// recorded on or before the date of the statement
+type NetWorthStatementAsset struct {
+	AssetClass EntityType
+	AssetID    uuid.UUID
+	Name       string
+	Details    string
+	Status     string
+	Value      float64
+	ValueDate  time.Time
+	// Counted indicates whether the asset counts towards net worth, which sold assets do not
+	Counted bool
+}
+
+// reportAsset is an asset of any class along with its balance or value history, as reports value it
+type reportAsset struct {
+	assetClass EntityType
+	id         uuid.UUID
+	name       string
+	details    string
+	status     string
+	sold       bool
+	created    time.Time
+	deleted    null.Time
+	history    []reportValue
+}
+
+// reportValue is a single balance or value recorded for an asset
+type reportValue struct {
+	date    time.Time
+	value   float64
+	deleted null.Time
+}
+
+// assets lists the assets of every class, in the order reports present them
+func (r *NetWorthReport) assets() []reportAsset {
+	assets := make([]reportAsset, 0, len(r.BankAccounts)+len(r.Vehicles)+len(r.Properties))
+
+	for _, bankAccount := range r.BankAccounts {
+		asset := reportAsset{
+			assetClass: EntityTypeBankAccount,
+			id:         bankAccount.ID,
+			name:       bankAccount.AccountName,
+			details:    joinReportDetails(bankAccount.BankName, bankAccount.AccountNumber),
+			status:     string(bankAccount.Status),
+			created:    bankAccount.Created,
+			deleted:    bankAccount.Deleted,
+		}
+		for _, balance := range bankAccount.Balances {
+			asset.history = append(asset.history, reportValue{date: balance.Date, value: balance.Balance, deleted: balance.Deleted})
+		}
+		assets = append(assets, asset)
+	}
+
+	for _, vehicle := range r.Vehicles {
+		year := ""
+		if vehicle.Year > 0 {
+			year = strconv.Itoa(vehicle.Year)
+		}
+		asset := reportAsset{
+			assetClass: EntityTypeVehicle,
+			id:         vehicle.ID,
+			name:       vehicle.Name,
+			details:    joinReportDetails(strings.TrimSpace(year+" "+vehicle.Make+" "+vehicle.Model), vehicle.LicensePlateNumber),
+			status:     string(vehicle.Status),
+			sold:       vehicle.Status == VehicleStatusSold,
+			created:    vehicle.Created,
+			deleted:    vehicle.Deleted,
+		}
+		for _, value := range vehicle.Values {
+			asset.history = append(asset.history, reportValue{date: value.Date, value: value.Value, deleted: value.Deleted})
+		}
+		assets = append(assets, asset)
+	}
+
+	for _, property := range r.Properties {
+		asset := reportAsset{
+			assetClass: EntityTypeProperty,
+			id:         property.ID,
+			name:       property.Name,
+			details:    property.Address,
+			status:     string(property.Status),
+			sold:       property.Status == PropertyStatusSold,
+			created:    property.Created,
+			deleted:    property.Deleted,
+		}
+		for _, value := range property.Values {
+			asset.history = append(asset.history, reportValue{date: value.Date, value: value.Value, deleted: value.Deleted})
+		}
+		assets = append(assets, asset)
+	}
+
+	return assets
+}
+
+// valueAsOf returns the last balance or value recorded for the asset on or before a date, carrying it forward.
+// Balances and values deleted since still count, as they were recorded at the time.
+func (a *reportAsset) valueAsOf(asOf time.Time) (value float64, date time.Time, found bool) {
+	for _, entry := range a.history {
+		if entry.isDeletedBy(asOf) || entry.date.After(asOf) {
+			continue
+		}
+		if !found || !entry.date.Before(date) {
+			value, date, found = entry.value, entry.date, true
+		}
+	}
+	return
+}
+
+// firstValueAfter returns the first balance or value recorded for the asset after a date, or zero when there is none
+func (a *reportAsset) firstValueAfter(after time.Time) (value float64) {
+	var date time.Time
+	found := false
+	for _, entry := range a.history {
+		if entry.deleted.Valid || !entry.date.After(after) {
+			continue
+		}
+		if !found || entry.date.Before(date) {
+			value, date, found = entry.value, entry.date, true
+		}
+	}
+	return
+}
+
+// isSoldAsOf checks whether the asset was sold as of a date, going by the status recorded in the last of its
+// audit logs on or before that date and falling back to its current status when there is none
+func (a *reportAsset) isSoldAsOf(asOf time.Time, auditLogs []AuditLog) bool {
+	var last *AuditLog
+	for i, auditLog := range auditLogs {
+		if auditLog.SubjectID != a.id || auditLog.Created.After(asOf) {
+			continue
+		}
+		if last == nil || !auditLog.Created.Before(last.Created) {
+			last = &auditLogs[i]
+		}
+	}
+
+	if last == nil {
+		return a.sold
+	}
+
+	// vehicles and properties share the same status for sold assets
+	return getAuditLogStatus(last.SnapshotAfter) == string(VehicleStatusSold)
+}
+
+// isDeletedBy checks whether the asset was deleted on or before a date
+func (a *reportAsset) isDeletedBy(date time.Time) bool {
+	return a.deleted.Valid && !a.deleted.Time.After(date)
+}
+
+// isDeletedBy checks whether the balance or value was deleted on or before a date
+func (v reportValue) isDeletedBy(date time.Time) bool {
+	return v.deleted.Valid && !v.deleted.Time.After(date)
+}
+
+func joinReportDetails(details ...string) string {
+	nonEmpty := make([]string, 0, len(details))
+	for _, detail := range details {
+		if detail != "" {
+			nonEmpty = append(nonEmpty, detail)
+		}
+	}
+	return strings.Join(nonEmpty, " - ")
+}
+
+// NewNetWorthStatement values the assets of a report as of a date. Assets deleted by then, and assets
+// without any balance or value recorded by then, are left out. Whether a vehicle or property was sold
+// by then is taken from the last status its audit logs record on or before the date, as assets only
+// record their current status.
+func NewNetWorthStatement(report NetWorthReport, asOf time.Time, auditLogs []AuditLog) NetWorthStatement {
+	statement := NetWorthStatement{
+		AsOf:      asOf,
+		Generated: report.Generated,
+		Assets:    make([]NetWorthStatementAsset, 0),
+	}
+
+	for _, asset := range report.assets() {
+		if asset.isDeletedBy(asOf) {
+			continue
+		}
+
+		value, valueDate, found := asset.valueAsOf(asOf)
+		if !found {
+			continue
+		}
+
+		statement.Assets = append(statement.Assets, NetWorthStatementAsset{
+			AssetClass: asset.assetClass,
+			AssetID:    asset.id,
+			Name:       asset.name,
+			Details:    asset.details,
+			Status:     asset.status,
+			Value:      value,
+			ValueDate:  valueDate,
+			Counted:    !asset.isSoldAsOf(asOf, auditLogs),
+		})
+	}
+
+	return statement
+}
+
+// Totals returns the total of each asset class, counting only the assets that count towards net worth
+func (s *NetWorthStatement) Totals() []NetWorthClassTotal {
+	result := make([]NetWorthClassTotal, 0, len(reportAssetClasses))
+	for _, assetClass := range reportAssetClasses {
+		total := NetWorthClassTotal{AssetClass: assetClass}
+		for _, asset := range s.Assets {
+			if asset.AssetClass == assetClass && asset.Counted {
+				total.Count++
+				total.Total += asset.Value
+			}
+		}
+		result = append(result, total)
+	}
+	return result
+}
+
+// NetWorth returns the total value of the assets that count towards net worth
+func (s *NetWorthStatement) NetWorth() (netWorth float64) {
+	for _, total := range s.Totals() {
+		netWorth += total.Total
+	}
+	return
+}
+
+// the layout of Net Worth Statements, in points
+const (
+	statementMargin       = 50.0
+	statementLineHeight   = 14.0
+	statementFontSize     = 9.0
+	statementColName      = statementMargin
+	statementColDetails   = 195.0
+	statementColStatus    = 350.0
+	statementColValueDate = 410.0
+	statementColValue     = pdf.PageWidth - statementMargin
+)
+
+// statementLayout keeps track of where the next line of a Net Worth Statement goes, adding pages as they fill up
+type statementLayout struct {
+	document *pdf.Document
+	page     *pdf.Page
+	y        float64
+}
+
+func (l *statementLayout) newLine(height float64) *pdf.Page {
+	if l.page == nil || l.y-height < statementMargin+statementLineHeight {
+		l.page = l.document.AddPage()
+		l.y = pdf.PageHeight - statementMargin
+	}
+	l.y -= height
+	return l.page
+}
+
+func (l *statementLayout) rule() {
+	l.page.Line(statementMargin, l.y-4, pdf.PageWidth-statementMargin, l.y-4, 0.5)
+}
+
+// ToPDF renders the statement as a printable document, listing the assets of each class with their
+// totals, followed by the net worth
+func (s *NetWorthStatement) ToPDF() *pdf.Document {
+	layout := &statementLayout{document: pdf.NewDocument("Statement of Net Worth")}
+	asOf := s.AsOf.Format("2006-01-02")
+
+	page := layout.newLine(16)
+	page.Text(statementMargin, layout.y, pdf.FontBold, 16, "Statement of Net Worth")
+	page = layout.newLine(statementLineHeight + 4)
+	page.Text(statementMargin, layout.y, pdf.FontRegular, 10, "As of "+asOf)
+
+	totals := s.Totals()
+	for _, total := range totals {
+		layout.newLine(statementLineHeight * 2)
+		page = layout.newLine(statementLineHeight)
+		page.Text(statementMargin, layout.y, pdf.FontBold, 12, ReportAssetClassNames[total.AssetClass])
+
+		page = layout.newLine(statementLineHeight + 4)
+		page.Text(statementColName, layout.y, pdf.FontBold, statementFontSize, "Asset")
+		page.Text(statementColDetails, layout.y, pdf.FontBold, statementFontSize, "Details")
+		page.Text(statementColStatus, layout.y, pdf.FontBold, statementFontSize, "Status")
+		page.Text(statementColValueDate, layout.y, pdf.FontBold, statementFontSize, "Value Date")
+		page.TextRight(statementColValue, layout.y, pdf.FontBold, statementFontSize, "Value")
+		layout.rule()
+		layout.y -= 4
+
+		listed := 0
+		for _, asset := range s.Assets {
+			if asset.AssetClass != total.AssetClass {
+				continue
+			}
+			listed++
+
+			value := formatReportAmount(asset.Value)
+			if !asset.Counted {
+				value = "(" + value + ")"
+			}
+
+			page = layout.newLine(statementLineHeight)
+			page.Text(statementColName, layout.y, pdf.FontRegular, statementFontSize,
+				pdf.Truncate(pdf.FontRegular, statementFontSize, asset.Name, statementColDetails-statementColName-5))
+			page.Text(statementColDetails, layout.y, pdf.FontRegular, statementFontSize,
+				pdf.Truncate(pdf.FontRegular, statementFontSize, asset.Details, statementColStatus-statementColDetails-5))
+			page.Text(statementColStatus, layout.y, pdf.FontRegular, statementFontSize, strings.ReplaceAll(asset.Status, "_", " "))
+			page.Text(statementColValueDate, layout.y, pdf.FontRegular, statementFontSize, asset.ValueDate.Format("2006-01-02"))
+			page.TextRight(statementColValue, layout.y, pdf.FontRegular, statementFontSize, value)
+		}
+
+		if listed == 0 {
+			page = layout.newLine(statementLineHeight)
+			page.Text(statementColName, layout.y, pdf.FontRegular, statementFontSize, "None held as of "+asOf+".")
+		}
+
+		layout.rule()
+		page = layout.newLine(statementLineHeight + 4)
+		page.Text(statementColName, layout.y, pdf.FontBold, statementFontSize, "Total "+ReportAssetClassNames[total.AssetClass])
+		page.TextRight(statementColValue, layout.y, pdf.FontBold, statementFontSize, formatReportAmount(total.Total))
+	}
+
+	layout.newLine(statementLineHeight * 2)
+	page = layout.newLine(statementLineHeight)
+	page.Text(statementMargin, layout.y, pdf.FontBold, 12, "Liabilities")
+	page = layout.newLine(statementLineHeight + 4)
+	page.Text(statementColName, layout.y, pdf.FontRegular, statementFontSize, "No liabilities are recorded.")
+
+	layout.newLine(statementLineHeight * 2)
+	page = layout.newLine(statementLineHeight)
+	page.Text(statementMargin, layout.y, pdf.FontBold, 12, "Summary")
+	layout.newLine(4)
+	for _, total := range totals {
+		page = layout.newLine(statementLineHeight)
+		page.Text(statementColName, layout.y, pdf.FontRegular, statementFontSize, ReportAssetClassNames[total.AssetClass])
+		page.TextRight(statementColValue, layout.y, pdf.FontRegular, statementFontSize, formatReportAmount(total.Total))
+	}
+	page = layout.newLine(statementLineHeight)
+	page.Text(statementColName, layout.y, pdf.FontRegular, statementFontSize, "Total Liabilities")
+	page.TextRight(statementColValue, layout.y, pdf.FontRegular, statementFontSize, formatReportAmount(0))
+	layout.rule()
+	page = layout.newLine(statementLineHeight + 4)
+	page.Text(statementColName, layout.y, pdf.FontBold, 10, "Net Worth")
+	page.TextRight(statementColValue, layout.y, pdf.FontBold, 10, formatReportAmount(s.NetWorth()))
+
+	page = layout.newLine(statementLineHeight * 2)
+	page.Text(statementColName, layout.y, pdf.FontRegular, 8,
+		"Assets are valued at their last balance or value recorded on or before "+asOf+". Values in parentheses are sold assets, not counted.")
+
+	pages := layout.document.Pages()
+	for idx, page := range pages {
+		footer := fmt.Sprintf("Generated %s - Page %d of %d", s.Generated.Format("2006-01-02 15:04"), idx+1, len(pages))
+		page.Text(statementMargin, statementMargin/2, pdf.FontRegular, 8, footer)
+	}
+
+	return layout.document
+}
+
+// formatReportAmount formats an amount with two decimals and thousands separators
+func formatReportAmount(amount float64) string {
+	formatted := strconv.FormatFloat(math.Abs(amount), 'f', 2, 64)
+	whole, decimals := formatted[:len(formatted)-3], formatted[len(formatted)-3:]
+
+	var b strings.Builder
+	if amount < 0 && formatted != "0.00" {
+		b.WriteString("-")
+	}
+	for idx, digit := range whole {
+		if idx > 0 && (len(whole)-idx)%3 == 0 {
+			b.WriteString(",")
+		}
+		b.WriteRune(digit)
+	}
+	b.WriteString(decimals)
+
+	return b.String()
+}
+
+// AssetChangeReport compares the value of every asset between two dates
+type AssetChangeReport struct {
+	From   time.Time
+	To     time.Time
+	Assets []AssetChange
+}
+
+// AssetChange is the change in value of a single asset between the two dates of an Asset Change Report. Values
+// are carried forward from the last balance or value recorded on or before each date, and are null for an
+// asset without any recorded by then.
+type AssetChange struct {
+	AssetClass    EntityType
+	AssetID       uuid.UUID
+	Name          string
+	Status        string
+	FromValue     null.Float
+	ToValue       null.Float
+	Change        float64
+	ChangePercent null.Float
+	Created       bool
+	Sold          bool
+	Deleted       bool
+}
+
+// NewAssetChangeReport compares the assets of a report between two dates. Assets are flagged as created or
+// deleted when that happened after the first date and on or before the second, and as sold when they are
+// among the given assets whose sale was recorded within the same period. Assets deleted by the first date,
+// and assets created after the second one, are left out.
+func NewAssetChangeReport(report NetWorthReport, from, to time.Time, soldAssetIDs []uuid.UUID) AssetChangeReport {
+	changeReport := AssetChangeReport{
+		From:   from,
+		To:     to,
+		Assets: make([]AssetChange, 0),
+	}
+
+	for _, asset := range report.assets() {
+		if asset.isDeletedBy(from) || asset.created.After(to) {
+			continue
+		}
+
+		change := AssetChange{
+			AssetClass: asset.assetClass,
+			AssetID:    asset.id,
+			Name:       asset.name,
+			Status:     asset.status,
+			Created:    asset.created.After(from),
+			Sold:       slices.Contains(soldAssetIDs, asset.id),
+			Deleted:    asset.isDeletedBy(to),
+		}
+
+		if value, _, found := asset.valueAsOf(from); found {
+			change.FromValue = null.FloatFrom(value)
+		}
+		// an asset deleted by the second date is no longer held, whatever was last recorded for it
+		if value, _, found := asset.valueAsOf(to); found && !change.Deleted {
+			change.ToValue = null.FloatFrom(value)
+		}
+
+		// a missing value counts as nothing held, so that new assets show their whole value as the change
+		// and deleted ones the loss of it
+		change.Change = change.ToValue.Float64 - change.FromValue.Float64
+		if change.FromValue.Float64 != 0 {
+			change.ChangePercent = null.FloatFrom(change.Change / math.Abs(change.FromValue.Float64) * 100)
+		}
+
+		changeReport.Assets = append(changeReport.Assets, change)
+	}
+
+	return changeReport
+}
+
+// IsSaleAuditLog checks whether an Audit Log records a vehicle or property being marked as sold
+func IsSaleAuditLog(auditLog AuditLog) bool {
+	if auditLog.EntityType != EntityTypeVehicle && auditLog.EntityType != EntityTypeProperty {
+		return false
+	}
+
+	// vehicles and properties share the same status for sold assets
+	sold := string(VehicleStatusSold)
+	return getAuditLogStatus(auditLog.SnapshotAfter) == sold && getAuditLogStatus(auditLog.SnapshotBefore) != sold
+}
+
+// getAuditLogStatus reads the status out of an Audit Log snapshot, which is empty when it has none
+func getAuditLogStatus(snapshot null.String) string {
+	var status struct {
+		Status string `json:"status"`
+	}
+	if snapshot.Valid {
+		_ = json.Unmarshal([]byte(snapshot.String), &status)
+	}
+	return status.Status
+}
+
+// ToOutput converts an Asset Change Report to its JSON-compatible object representation
+func (r *AssetChangeReport) ToOutput() AssetChangeReportOutput {
+	assets := make([]AssetChangeOutput, 0, len(r.Assets))
+	for _, asset := range r.Assets {
+		assets = append(assets, AssetChangeOutput{
+			AssetClass:    asset.AssetClass,
+			AssetID:       asset.AssetID,
+			Name:          asset.Name,
+			Status:        asset.Status,
+			FromValue:     asset.FromValue,
+			ToValue:       asset.ToValue,
+			Change:        asset.Change,
+			ChangePercent: asset.ChangePercent,
+			Created:       asset.Created,
+			Sold:          asset.Sold,
+			Deleted:       asset.Deleted,
+		})
+	}
+
+	return AssetChangeReportOutput{
+		From:   cachetime.CacheTime(r.From),
+		To:     cachetime.CacheTime(r.To),
+		Assets: assets,
+	}
+}
+
+// AssetChangeReportOutput is the JSON-compatible object representation of Asset Change Report
+type AssetChangeReportOutput struct {
+	From   cachetime.CacheTime `json:"from"`
+	To     cachetime.CacheTime `json:"to"`
+	Assets []AssetChangeOutput `json:"assets"`
+}
+
+// AssetChangeOutput is the JSON-compatible object representation of Asset Change
+type AssetChangeOutput struct {
+	AssetClass    EntityType `json:"assetClass"`
+	AssetID       uuid.UUID  `json:"assetId"`
+	Name          string     `json:"name"`
+	Status        string     `json:"status"`
+	FromValue     null.Float `json:"fromValue"`
+	ToValue       null.Float `json:"toValue"`
+	Change        float64    `json:"change"`
+	ChangePercent null.Float `json:"changePercent"`
+	Created       bool       `json:"created"`
+	Sold          bool       `json:"sold"`
+	Deleted       bool       `json:"deleted"`
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/util/failure"
+)
+
+const (
+	// SearchDefaultLimit is the number of results returned when a search does not specify a limit
+	SearchDefaultLimit = 20
+	// SearchMaxLimit is the most results a single search may return
+	SearchMaxLimit = 100
+)
+
+// SearchEntityTypes are the types of entity covered by a search, in the order they are searched
+var SearchEntityTypes = []EntityType{
+	EntityTypeBankAccount,
+	EntityTypeVehicle,
+	EntityTypeProperty,
+}
+
+// Search represents a validated full-text search across assets
+type Search struct {
+	// Terms is the keyword in MySQL boolean full-text syntax, requiring every word as a prefix
+	Terms          string
+	EntityTypes    []EntityType
+	IncludeDeleted bool
+	Limit          int
+}
+
+// Includes checks whether the search covers a type of entity
+func (s *Search) Includes(entityType EntityType) bool {
+	return slices.Contains(s.EntityTypes, entityType)
+}
+
+// SearchInput is the input object for a full-text search across assets
+type SearchInput struct {
+	Keyword        string        `json:"keyword"`
+	EntityTypes    *[]EntityType `json:"entityTypes,omitempty"`
+	IncludeDeleted *bool         `json:"includeDeleted,omitempty"`
+	Limit          *int          `json:"limit,omitempty"`
+}
+
+// ToSearch validates the input and converts it to a Search
+func (i *SearchInput) ToSearch() (Search, error) {
+	search := Search{
+		Terms:       getSearchTerms(i.Keyword),
+		EntityTypes: SearchEntityTypes,
+		Limit:       SearchDefaultLimit,
+	}
+
+	if search.Terms == "" {
+		return search, failure.BadRequestFromString("search keyword must contain at least one word")
+	}
+
+	if i.EntityTypes != nil && len(*i.EntityTypes) > 0 {
+		search.EntityTypes = make([]EntityType, 0, len(*i.EntityTypes))
+		for _, entityType := range *i.EntityTypes {
+			if !slices.Contains(SearchEntityTypes, entityType) {
+				return search, failure.BadRequestFromString(fmt.Sprintf("unsupported search entity type: %s", entityType))
+			}
+			if !slices.Contains(search.EntityTypes, entityType) {
+				search.EntityTypes = append(search.EntityTypes, entityType)
+			}
+		}
+	}
+
+	if i.IncludeDeleted != nil {
+		search.IncludeDeleted = *i.IncludeDeleted
+	}
+
+	if i.Limit != nil {
+		if *i.Limit < 1 || *i.Limit > SearchMaxLimit {
+			return search, failure.BadRequestFromString(fmt.Sprintf("search limit must be between 1 and %d", SearchMaxLimit))
+		}
+		search.Limit = *i.Limit
+	}
+
+	return search, nil
+}
+
+// getSearchTerms turns a keyword into MySQL boolean full-text syntax, stripping everything but letters
+// and digits so that clients cannot inject boolean operators of their own
+func getSearchTerms(keyword string) string {
+	words := strings.FieldsFunc(keyword, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, "+"+word+"*")
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// SearchResult represents a single asset matching a search
+type SearchResult struct {
+	EntityType EntityType `db:"entity_type"`
+	ID         uuid.UUID  `db:"entity_id"`
+	Name       string     `db:"name"`
+	Detail     string     `db:"detail"`
+	Status     string     `db:"status"`
+	Deleted    bool       `db:"is_deleted"`
+	Relevance  float64    `db:"relevance"`
+}
+
+// ToOutput converts a Search Result to its JSON-compatible object representation
+func (r *SearchResult) ToOutput() SearchResultOutput {
+	return SearchResultOutput{
+		EntityType: r.EntityType,
+		ID:         r.ID,
+		Name:       r.Name,
+		Detail:     r.Detail,
+		Status:     r.Status,
+		Deleted:    r.Deleted,
+		Relevance:  r.Relevance,
+	}
+}
+
+// SearchResultOutput is the JSON-compatible object representation of Search Result
+type SearchResultOutput struct {
+	EntityType EntityType `json:"entityType"`
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Detail     string     `json:"detail"`
+	Status     string     `json:"status"`
+	Deleted    bool       `json:"deleted"`
+	Relevance  float64    `json:"relevance"`
+}
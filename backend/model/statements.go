@@ -0,0 +1,106 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/statement"
+)
+
+// StatementImportInput is the input object for importing Bank Account Balances from bank statement files
+type StatementImportInput struct {
+	// Content is the statement file as is, its format detected from the content when not specified
+	Content string            `json:"content"`
+	Format  *statement.Format `json:"format,omitempty"`
+	DryRun  bool              `json:"dryRun"`
+}
+
+// Parse reads the statements held in the statement file
+func (i *StatementImportInput) Parse() ([]statement.Statement, error) {
+	var format statement.Format
+	if i.Format != nil {
+		format = *i.Format
+	}
+	return statement.Parse(format, []byte(i.Content))
+}
+
+// GetStatementAccountNumbers returns the account numbers a set of statements may be recorded under, both as
+// written in the statements and with their separators stripped, to resolve the Bank Accounts they belong to
+func GetStatementAccountNumbers(statements []statement.Statement) []string {
+	accountNumbers := make([]string, 0, len(statements)*2)
+	for _, statement := range statements {
+		accountNumbers = append(accountNumbers, statement.AccountNumber)
+		if normalized := NormalizeAccountNumber(statement.AccountNumber); normalized != statement.AccountNumber {
+			accountNumbers = append(accountNumbers, normalized)
+		}
+	}
+	return accountNumbers
+}
+
+// NormalizeAccountNumber strips everything but letters and digits from an account number and upper cases it,
+// so that account numbers written with and without separators can be compared
+func NormalizeAccountNumber(accountNumber string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, accountNumber))
+}
+
+// NewBankAccountBalanceInputFromStatement creates the input object for a single Bank Account Balance
+// from a balance reported by a statement
+func NewBankAccountBalanceInputFromStatement(bankAccountID uuid.UUID, balance statement.Balance) BankAccountBalanceInput {
+	return BankAccountBalanceInput{
+		BankAccountID: bankAccountID,
+		Date:          cachetime.CacheTime(balance.Date),
+		Balance:       balance.Amount,
+	}
+}
+
+// StatementAccountResult describes the outcome of importing the statement of a single account
+type StatementAccountResult struct {
+	AccountNumber string     `json:"accountNumber"`
+	BankAccountID *uuid.UUID `json:"bankAccountId,omitempty"`
+	Balances      int        `json:"balances"`
+	Imported      int        `json:"imported"`
+	Skipped       int        `json:"skipped"`
+	Error         *string    `json:"error,omitempty"`
+}
+
+// SetError marks the statement of an account as not importable
+func (r *StatementAccountResult) SetError(message string) {
+	r.Error = &message
+}
+
+// StatementImportResult describes the outcome of importing a statement file
+type StatementImportResult struct {
+	DryRun   bool
+	Accounts []StatementAccountResult
+}
+
+// Imported counts the balances imported across every account of the statement file
+func (r *StatementImportResult) Imported() (imported int) {
+	for _, account := range r.Accounts {
+		imported += account.Imported
+	}
+	return
+}
+
+// ToOutput converts a Statement Import Result to its JSON-compatible object representation
+func (r *StatementImportResult) ToOutput() StatementImportResultOutput {
+	return StatementImportResultOutput{
+		DryRun:   r.DryRun,
+		Imported: r.Imported(),
+		Accounts: r.Accounts,
+	}
+}
+
+// StatementImportResultOutput is the JSON-compatible object representation of Statement Import Result
+type StatementImportResultOutput struct {
+	DryRun   bool                     `json:"dryRun"`
+	Imported int                      `json:"imported"`
+	Accounts []StatementAccountResult `json:"accounts"`
+}
@@ -0,0 +1,300 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// TaggableEntityTypes are the types of entity that can be tagged and given custom fields
+var TaggableEntityTypes = []EntityType{
+	EntityTypeBankAccount,
+	EntityTypeVehicle,
+	EntityTypeProperty,
+}
+
+// QuerySelectTaggedEntityIDs selects the IDs of the entities of a type tagged with any of a list of Tag names.
+// Its placeholders for the names are appended by newTagClause.
+const QuerySelectTaggedEntityIDs = `
+		SELECT entity_tags.subject_entity_id
+		FROM entity_tags
+		INNER JOIN tags ON tags.entity_id = entity_tags.tag_entity_id
+		WHERE entity_tags.entity_type = ? AND tags.deleted IS NULL AND tags.name IN `
+
+const (
+	// TagColumnID represents the corresponding column in Tags table
+	TagColumnID filter.Field = "tags.entity_id"
+	// TagColumnName represents the corresponding column in Tags table
+	TagColumnName filter.Field = "tags.name"
+	// TagColumnCreated represents the corresponding column in Tags table
+	TagColumnCreated filter.Field = "tags.created"
+	// TagColumnCreatedBy represents the corresponding column in Tags table
+	TagColumnCreatedBy filter.Field = "tags.created_by"
+	// TagColumnUpdated represents the corresponding column in Tags table
+	TagColumnUpdated filter.Field = "tags.updated"
+	// TagColumnUpdatedBy represents the corresponding column in Tags table
+	TagColumnUpdatedBy filter.Field = "tags.updated_by"
+	// TagColumnDeleted represents the corresponding column in Tags table
+	TagColumnDeleted filter.Field = "tags.deleted"
+	// TagColumnDeletedBy represents the corresponding column in Tags table
+	TagColumnDeletedBy filter.Field = "tags.deleted_by"
+)
+
+// TagFields is the whitelist of fields Tags can be queried and sorted by, keyed by their names in the API
+var TagFields = map[string]filter.Field{
+	"id":        TagColumnID,
+	"name":      TagColumnName,
+	"created":   TagColumnCreated,
+	"updated":   TagColumnUpdated,
+	"deleted":   TagColumnDeleted,
+	"createdBy": TagColumnCreatedBy,
+	"updatedBy": TagColumnUpdatedBy,
+	"deletedBy": TagColumnDeletedBy,
+}
+
+// Tag is a label that can be put on Bank Accounts, Vehicles and Properties to group them. Tag names are not
+// case sensitive.
+type Tag struct {
+	ID        uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	Name      string      `db:"name" validate:"max=255"`
+	Created   time.Time   `db:"created"`
+	CreatedBy uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
+	Updated   null.Time   `db:"updated"`
+	UpdatedBy nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
+	Deleted   null.Time   `db:"deleted"`
+	DeletedBy nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+}
+
+// EntityTag puts a Tag on an entity
+type EntityTag struct {
+	TagID      uuid.UUID  `db:"tag_entity_id" validate:"min=36,max=36"`
+	EntityType EntityType `db:"entity_type"`
+	SubjectID  uuid.UUID  `db:"subject_entity_id" validate:"min=36,max=36"`
+}
+
+// NewTagFromInput creates a new Tag from its input object, which must have been validated
+func NewTagFromInput(input TagInput, userID uuid.UUID) (t Tag) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+
+	t = Tag{
+		ID:        newUUID,
+		Name:      strings.TrimSpace(input.Name),
+		Created:   now,
+		CreatedBy: userID,
+	}
+
+	return
+}
+
+// Update performs an update on a Tag, whose input must have been validated
+func (t *Tag) Update(input TagInput, userID uuid.UUID) error {
+	if t.Deleted.Valid || t.DeletedBy.Valid {
+		return failure.OperationNotPermitted("update", "Tag", "already deleted")
+	}
+
+	now := time.Now()
+
+	t.Name = strings.TrimSpace(input.Name)
+	t.Updated = null.TimeFrom(now)
+	t.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Delete performs a delete on a Tag, which hides it on every entity it was put on
+func (t *Tag) Delete(userID uuid.UUID) error {
+	if t.Deleted.Valid || t.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Tag", "already deleted")
+	}
+
+	now := time.Now()
+
+	t.Deleted = null.TimeFrom(now)
+	t.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// ToOutput converts a Tag to its JSON-compatible object representation
+func (t *Tag) ToOutput() TagOutput {
+	return TagOutput{
+		ID:        t.ID,
+		Name:      t.Name,
+		Created:   cachetime.CacheTime(t.Created),
+		CreatedBy: t.CreatedBy,
+		Updated:   cachetime.NCacheTime(t.Updated),
+		UpdatedBy: t.UpdatedBy,
+		Deleted:   cachetime.NCacheTime(t.Deleted),
+		DeletedBy: t.DeletedBy,
+	}
+}
+
+// TagInput represents an input struct for Tag entity
+type TagInput struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// Validate checks a Tag input before it is stored
+func (i *TagInput) Validate() error {
+	name := strings.TrimSpace(i.Name)
+
+	if len(name) == 0 {
+		return failure.BadRequestFromString("tag name is required")
+	}
+
+	if len(name) > 255 {
+		return failure.BadRequestFromString("tag name must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// TagOutput is the JSON-compatible object representation of Tag
+type TagOutput struct {
+	ID        uuid.UUID            `json:"id"`
+	Name      string               `json:"name"`
+	Created   cachetime.CacheTime  `json:"created"`
+	CreatedBy uuid.UUID            `json:"createdBy"`
+	Updated   cachetime.NCacheTime `json:"updated,omitempty"`
+	UpdatedBy nuuid.NUUID          `json:"updatedBy,omitempty"`
+	Deleted   cachetime.NCacheTime `json:"deleted,omitempty"`
+	DeletedBy nuuid.NUUID          `json:"deletedBy,omitempty"`
+}
+
+// EntityTagsInput represents an input struct for the Tags put on an entity, replacing those it had before
+type EntityTagsInput struct {
+	TagIDs []uuid.UUID `json:"tagIds"`
+}
+
+// Validate checks the Tags to be put on an entity, dropping those that are given more than once
+func (i *EntityTagsInput) Validate() error {
+	tagIDs := make([]uuid.UUID, 0, len(i.TagIDs))
+	for _, id := range i.TagIDs {
+		if !slices.Contains(tagIDs, id) {
+			tagIDs = append(tagIDs, id)
+		}
+	}
+	i.TagIDs = tagIDs
+
+	return nil
+}
+
+// EntityTagsOutput is the JSON-compatible object representation of the Tags put on an entity
+type EntityTagsOutput struct {
+	EntityType EntityType  `json:"entityType"`
+	SubjectID  uuid.UUID   `json:"subjectId"`
+	Tags       []TagOutput `json:"tags"`
+}
+
+// EntityTags are the Tags put on an entity
+type EntityTags struct {
+	EntityType EntityType
+	SubjectID  uuid.UUID
+	Tags       []Tag
+}
+
+// ToOutput converts the Tags put on an entity to their JSON-compatible object representation
+func (et *EntityTags) ToOutput() EntityTagsOutput {
+	output := EntityTagsOutput{
+		EntityType: et.EntityType,
+		SubjectID:  et.SubjectID,
+		Tags:       make([]TagOutput, 0, len(et.Tags)),
+	}
+
+	for _, tag := range et.Tags {
+		output.Tags = append(output.Tags, tag.ToOutput())
+	}
+
+	return output
+}
+
+// FindTagByName finds a Tag in a set of Tags by its name
+func FindTagByName(tags []Tag, name string) *Tag {
+	for _, tag := range tags {
+		if normalizeTagName(tag.Name) == normalizeTagName(name) {
+			return &tag
+		}
+	}
+
+	return nil
+}
+
+// normalizeTagName brings a Tag name to the form it is compared in, as names are not case sensitive
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// CheckTaggableEntityType makes sure that entities of a type can be tagged and given custom fields
+func CheckTaggableEntityType(entityType EntityType) error {
+	if !slices.Contains(TaggableEntityTypes, entityType) {
+		return failure.BadRequestFromString(fmt.Sprintf("entity type %s cannot be tagged or given custom fields", entityType))
+	}
+	return nil
+}
+
+// newTagClause matches the entities of a type, by their ID field, that are tagged with any of a list of Tag names
+func newTagClause(idField filter.Field, entityType EntityType, names []string) filter.Clause {
+	args := make([]interface{}, 0, len(names)+1)
+	args = append(args, entityType)
+	for _, name := range names {
+		args = append(args, strings.TrimSpace(name))
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", ")
+
+	return filter.Clause{
+		Operand1: idField,
+		Operand2: filter.Subquery{
+			Query: QuerySelectTaggedEntityIDs + "(" + placeholders + ")",
+			Args:  args,
+		},
+		Operator: filter.OperatorIn,
+	}
+}
+
+// TagFilterInput is the filter input object for Tags
+type TagFilterInput struct {
+	filter.BaseFilterInput
+	Names *[]string `json:"names,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *TagFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		TagColumnName,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "tags",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.Names != nil {
+		if len(*f.Names) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: TagColumnName,
+				Operand2: *f.Names,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(TagFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, TagFields)
+	}
+
+	return theFilter
+}
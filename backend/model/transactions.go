@@ -0,0 +1,533 @@
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// TransactionDirection indicates whether a Transaction puts money into or takes money out of a Bank Account
+type TransactionDirection string
+
+const (
+	// TransactionDirectionCredit indicates money coming into a Bank Account
+	TransactionDirectionCredit TransactionDirection = "credit"
+	// TransactionDirectionDebit indicates money going out of a Bank Account
+	TransactionDirectionDebit TransactionDirection = "debit"
+)
+
+// IsValid checks whether a Transaction direction is one of the supported directions
+func (d TransactionDirection) IsValid() bool {
+	return d == TransactionDirectionCredit || d == TransactionDirectionDebit
+}
+
+const (
+	// TransactionColumnID represents the corresponding column in Transactions table
+	TransactionColumnID filter.Field = "transactions.entity_id"
+	// TransactionColumnBankAccountID represents the corresponding column in Transactions table
+	TransactionColumnBankAccountID filter.Field = "transactions.bank_account_entity_id"
+	// TransactionColumnDate represents the corresponding column in Transactions table
+	TransactionColumnDate filter.Field = "transactions.date"
+	// TransactionColumnDirection represents the corresponding column in Transactions table
+	TransactionColumnDirection filter.Field = "transactions.direction"
+	// TransactionColumnAmount represents the corresponding column in Transactions table
+	TransactionColumnAmount filter.Field = "transactions.amount"
+	// TransactionColumnPayee represents the corresponding column in Transactions table
+	TransactionColumnPayee filter.Field = "transactions.payee"
+	// TransactionColumnMemo represents the corresponding column in Transactions table
+	TransactionColumnMemo filter.Field = "transactions.memo"
+	// TransactionColumnCategory represents the corresponding column in Transactions table
+	TransactionColumnCategory filter.Field = "transactions.category"
+	// TransactionColumnCategoryID represents the corresponding column in Transactions table
+	TransactionColumnCategoryID filter.Field = "transactions.category_entity_id"
+	// TransactionColumnCreated represents the corresponding column in Transactions table
+	TransactionColumnCreated filter.Field = "transactions.created"
+	// TransactionColumnCreatedBy represents the corresponding column in Transactions table
+	TransactionColumnCreatedBy filter.Field = "transactions.created_by"
+	// TransactionColumnUpdated represents the corresponding column in Transactions table
+	TransactionColumnUpdated filter.Field = "transactions.updated"
+	// TransactionColumnUpdatedBy represents the corresponding column in Transactions table
+	TransactionColumnUpdatedBy filter.Field = "transactions.updated_by"
+	// TransactionColumnDeleted represents the corresponding column in Transactions table
+	TransactionColumnDeleted filter.Field = "transactions.deleted"
+	// TransactionColumnDeletedBy represents the corresponding column in Transactions table
+	TransactionColumnDeletedBy filter.Field = "transactions.deleted_by"
+)
+
+// TransactionFields is the whitelist of fields Transactions can be queried and sorted by, keyed by their names in the API
+var TransactionFields = map[string]filter.Field{
+	"id":            TransactionColumnID,
+	"bankAccountId": TransactionColumnBankAccountID,
+	"date":          TransactionColumnDate,
+	"direction":     TransactionColumnDirection,
+	"amount":        TransactionColumnAmount,
+	"payee":         TransactionColumnPayee,
+	"memo":          TransactionColumnMemo,
+	"category":      TransactionColumnCategory,
+	"categoryId":    TransactionColumnCategoryID,
+	"created":       TransactionColumnCreated,
+	"updated":       TransactionColumnUpdated,
+	"deleted":       TransactionColumnDeleted,
+	"createdBy":     TransactionColumnCreatedBy,
+	"updatedBy":     TransactionColumnUpdatedBy,
+	"deletedBy":     TransactionColumnDeletedBy,
+}
+
+// Transaction represents a single movement of money in the ledger of a Bank Account
+type Transaction struct {
+	ID            uuid.UUID            `db:"entity_id" validate:"min=36,max=36"`
+	BankAccountID uuid.UUID            `db:"bank_account_entity_id" validate:"min=36,max=36"`
+	Date          time.Time            `db:"date"`
+	Direction     TransactionDirection `db:"direction"`
+	Amount        float64              `db:"amount" validate:"min=0"`
+	Payee         string               `db:"payee" validate:"max=255"`
+	Memo          string               `db:"memo" validate:"max=255"`
+	Category      string               `db:"category" validate:"max=255"`
+	CategoryID    nuuid.NUUID          `db:"category_entity_id" validate:"min=36,max=36"`
+	Created       time.Time            `db:"created"`
+	CreatedBy     uuid.UUID            `db:"created_by" validate:"min=36,max=36"`
+	Updated       null.Time            `db:"updated"`
+	UpdatedBy     nuuid.NUUID          `db:"updated_by" validate:"min=36,max=36"`
+	Deleted       null.Time            `db:"deleted"`
+	DeletedBy     nuuid.NUUID          `db:"deleted_by" validate:"min=36,max=36"`
+}
+
+// NewTransactionFromInput creates a new Transaction from its input object
+func NewTransactionFromInput(input TransactionInput, bankAccountID uuid.UUID, userID uuid.UUID) (t Transaction) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+
+	t = Transaction{
+		ID:            newUUID,
+		BankAccountID: bankAccountID,
+		Date:          input.Date.Time(),
+		Direction:     input.Direction,
+		Amount:        input.Amount,
+		Payee:         strings.TrimSpace(input.Payee),
+		Memo:          strings.TrimSpace(input.Memo),
+		Category:      strings.TrimSpace(input.Category),
+		CategoryID:    input.CategoryID,
+		Created:       now,
+		CreatedBy:     userID,
+	}
+
+	return
+}
+
+// Update performs an update on a Transaction
+func (t *Transaction) Update(input TransactionInput, userID uuid.UUID) error {
+	if t.Deleted.Valid || t.DeletedBy.Valid {
+		return failure.OperationNotPermitted("update", "Transaction", "already deleted")
+	}
+
+	now := time.Now()
+
+	t.Date = input.Date.Time()
+	t.Direction = input.Direction
+	t.Amount = input.Amount
+	t.Payee = strings.TrimSpace(input.Payee)
+	t.Memo = strings.TrimSpace(input.Memo)
+	t.Category = strings.TrimSpace(input.Category)
+	t.CategoryID = input.CategoryID
+	t.Updated = null.TimeFrom(now)
+	t.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Categorize files a Transaction under a Category by both its ID and its current name, leaving the rest of
+// it as it is
+func (t *Transaction) Categorize(category Category, userID uuid.UUID) error {
+	if t.Deleted.Valid || t.DeletedBy.Valid {
+		return failure.OperationNotPermitted("categorize", "Transaction", "already deleted")
+	}
+
+	now := time.Now()
+
+	t.Category = category.Name
+	t.CategoryID = nuuid.From(category.ID)
+	t.Updated = null.TimeFrom(now)
+	t.UpdatedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// Delete performs a delete on a Transaction
+func (t *Transaction) Delete(userID uuid.UUID) error {
+	if t.Deleted.Valid || t.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Transaction", "already deleted")
+	}
+
+	now := time.Now()
+
+	t.Deleted = null.TimeFrom(now)
+	t.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// SignedAmount returns the amount of a Transaction as seen from the account, positive for credits and
+// negative for debits
+func (t *Transaction) SignedAmount() float64 {
+	if t.Direction == TransactionDirectionDebit {
+		return -t.Amount
+	}
+	return t.Amount
+}
+
+// ToOutput converts a Transaction to its JSON-compatible object representation
+func (t *Transaction) ToOutput() TransactionOutput {
+	return TransactionOutput{
+		ID:            t.ID,
+		BankAccountID: t.BankAccountID,
+		Date:          cachetime.CacheTime(t.Date),
+		Direction:     t.Direction,
+		Amount:        t.Amount,
+		Payee:         t.Payee,
+		Memo:          t.Memo,
+		Category:      t.Category,
+		CategoryID:    t.CategoryID,
+		Created:       cachetime.CacheTime(t.Created),
+		CreatedBy:     t.CreatedBy,
+		Updated:       cachetime.NCacheTime(t.Updated),
+		UpdatedBy:     t.UpdatedBy,
+		Deleted:       cachetime.NCacheTime(t.Deleted),
+		DeletedBy:     t.DeletedBy,
+	}
+}
+
+// TransactionInput represents an input struct for Transaction entity
+type TransactionInput struct {
+	ID            uuid.UUID            `json:"id"`
+	BankAccountID uuid.UUID            `json:"bankAccountId"`
+	Date          cachetime.CacheTime  `json:"date"`
+	Direction     TransactionDirection `json:"direction"`
+	Amount        float64              `json:"amount"`
+	Payee         string               `json:"payee"`
+	Memo          string               `json:"memo"`
+	Category      string               `json:"category"`
+	CategoryID    nuuid.NUUID          `json:"categoryId"`
+}
+
+// Validate checks a Transaction input before it is stored. The amount is always positive, its direction
+// being given separately.
+func (i *TransactionInput) Validate() error {
+	if !i.Direction.IsValid() {
+		return failure.BadRequestFromString(fmt.Sprintf("invalid transaction direction: %s", i.Direction))
+	}
+
+	if i.Amount <= 0 {
+		return failure.BadRequestFromString("transaction amount must be greater than zero")
+	}
+
+	if len(strings.TrimSpace(i.Payee)) > 255 {
+		return failure.BadRequestFromString("transaction payee must be at most 255 characters")
+	}
+
+	if len(strings.TrimSpace(i.Memo)) > 255 {
+		return failure.BadRequestFromString("transaction memo must be at most 255 characters")
+	}
+
+	if len(strings.TrimSpace(i.Category)) > 255 {
+		return failure.BadRequestFromString("transaction category must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// TransactionOutput is the JSON-compatible object representation of Transaction
+type TransactionOutput struct {
+	ID            uuid.UUID            `json:"id"`
+	BankAccountID uuid.UUID            `json:"bankAccountId"`
+	Date          cachetime.CacheTime  `json:"date"`
+	Direction     TransactionDirection `json:"direction"`
+	Amount        float64              `json:"amount"`
+	Payee         string               `json:"payee"`
+	Memo          string               `json:"memo"`
+	Category      string               `json:"category"`
+	CategoryID    nuuid.NUUID          `json:"categoryId,omitempty"`
+	Created       cachetime.CacheTime  `json:"created"`
+	CreatedBy     uuid.UUID            `json:"createdBy"`
+	Updated       cachetime.NCacheTime `json:"updated,omitempty"`
+	UpdatedBy     nuuid.NUUID          `json:"updatedBy,omitempty"`
+	Deleted       cachetime.NCacheTime `json:"deleted,omitempty"`
+	DeletedBy     nuuid.NUUID          `json:"deletedBy,omitempty"`
+}
+
+// TransactionFilterInput is the filter input object for Transactions
+type TransactionFilterInput struct {
+	filter.BaseFilterInput
+	BankAccountIDs *[]uuid.UUID            `json:"bankAccountIds,omitempty"`
+	StartDate      cachetime.NCacheTime    `json:"startDate,omitempty"`
+	EndDate        cachetime.NCacheTime    `json:"endDate,omitempty"`
+	Directions     *[]TransactionDirection `json:"directions,omitempty"`
+	Categories     *[]string               `json:"categories,omitempty"`
+	CategoryIDs    *[]uuid.UUID            `json:"categoryIds,omitempty"`
+	AmountMin      *float64                `json:"amountMin,omitempty"`
+	AmountMax      *float64                `json:"amountMax,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *TransactionFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		TransactionColumnPayee,
+		TransactionColumnMemo,
+		TransactionColumnCategory,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "transactions",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	if f.BankAccountIDs != nil {
+		if len(*f.BankAccountIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: TransactionColumnBankAccountID,
+				Operand2: *f.BankAccountIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.StartDate.Valid {
+		theFilter.AddClause(filter.Clause{
+			Operand1: TransactionColumnDate,
+			Operand2: f.StartDate.Time,
+			Operator: filter.OperatorGreaterThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.EndDate.Valid {
+		theFilter.AddClause(filter.Clause{
+			Operand1: TransactionColumnDate,
+			Operand2: f.EndDate.Time,
+			Operator: filter.OperatorLessThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.Directions != nil {
+		if len(*f.Directions) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: TransactionColumnDirection,
+				Operand2: *f.Directions,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.Categories != nil {
+		if len(*f.Categories) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: TransactionColumnCategory,
+				Operand2: *f.Categories,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.CategoryIDs != nil {
+		if len(*f.CategoryIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: TransactionColumnCategoryID,
+				Operand2: *f.CategoryIDs,
+				Operator: filter.OperatorIn,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.AmountMin != nil {
+		theFilter.AddClause(filter.Clause{
+			Operand1: TransactionColumnAmount,
+			Operand2: *f.AmountMin,
+			Operator: filter.OperatorGreaterThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.AmountMax != nil {
+		theFilter.AddClause(filter.Clause{
+			Operand1: TransactionColumnAmount,
+			Operand2: *f.AmountMax,
+			Operator: filter.OperatorLessThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(TransactionFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, TransactionFields)
+	}
+	if theFilter.Err == nil {
+		theFilter.Pagination, theFilter.Err = f.BaseFilterInput.GetKeysetPagination(TransactionColumnDate, TransactionColumnID)
+	}
+
+	return theFilter
+}
+
+// ReconciliationPeriod compares the change between two consecutive balances of a Bank Account with the
+// transactions recorded after the first balance up to the second one
+type ReconciliationPeriod struct {
+	StartBalanceID   uuid.UUID
+	StartDate        time.Time
+	StartBalance     float64
+	EndBalanceID     uuid.UUID
+	EndDate          time.Time
+	EndBalance       float64
+	BalanceChange    float64
+	TransactionTotal float64
+	TransactionCount int
+	// Difference is the part of the balance change not explained by transactions
+	Difference float64
+	Reconciled bool
+}
+
+// Reconciliation holds the result of reconciling the transactions of a Bank Account with its balances over
+// a period. The period is narrowed to the recorded balances in the same way as for returns, starting at the
+// last balance on or before the requested start, or the first balance after it when there is none, and
+// ending at the last balance on or before the requested end. Both the requested and the narrowed period are
+// kept, as the narrowed one may start before the requested one.
+type Reconciliation struct {
+	BankAccountID      uuid.UUID
+	RequestedStartDate time.Time
+	RequestedEndDate   time.Time
+	StartDate          time.Time
+	EndDate            time.Time
+	Periods            []ReconciliationPeriod
+	Reconciled         bool
+	UnreconciledCount  int
+	UnreconciledTotal  float64
+}
+
+// NewReconciliation reconciles the transactions of a Bank Account with its balances up to the end of the
+// period, checking every pair of consecutive balances. Amounts are compared to the cent.
+func NewReconciliation(bankAccountID uuid.UUID, start, end time.Time, balances []BankAccountBalance, transactions []Transaction) Reconciliation {
+	r := Reconciliation{
+		BankAccountID:      bankAccountID,
+		RequestedStartDate: start,
+		RequestedEndDate:   end,
+		StartDate:          start,
+		EndDate:            end,
+		Periods:            []ReconciliationPeriod{},
+		Reconciled:         true,
+	}
+
+	narrowed := NarrowBalancesToPeriod(bankAccountID, start, end, balances)
+	if len(narrowed) == 0 {
+		return r
+	}
+
+	r.StartDate = narrowed[0].Date
+	r.EndDate = narrowed[len(narrowed)-1].Date
+
+	for idx := 1; idx < len(narrowed); idx++ {
+		previous, next := narrowed[idx-1], narrowed[idx]
+		period := ReconciliationPeriod{
+			StartBalanceID: previous.ID,
+			StartDate:      previous.Date,
+			StartBalance:   previous.Balance,
+			EndBalanceID:   next.ID,
+			EndDate:        next.Date,
+			EndBalance:     next.Balance,
+			BalanceChange:  roundToCents(next.Balance - previous.Balance),
+		}
+
+		total := 0.0
+		for _, transaction := range transactions {
+			if transaction.BankAccountID != bankAccountID || transaction.Deleted.Valid {
+				continue
+			}
+			if !transaction.Date.After(previous.Date) || transaction.Date.After(next.Date) {
+				continue
+			}
+			total += transaction.SignedAmount()
+			period.TransactionCount++
+		}
+
+		period.TransactionTotal = roundToCents(total)
+		period.Difference = roundToCents(period.BalanceChange - period.TransactionTotal)
+		period.Reconciled = period.Difference == 0
+
+		if !period.Reconciled {
+			r.Reconciled = false
+			r.UnreconciledCount++
+			r.UnreconciledTotal = roundToCents(r.UnreconciledTotal + period.Difference)
+		}
+
+		r.Periods = append(r.Periods, period)
+	}
+
+	return r
+}
+
+// ToOutput converts a Reconciliation to its JSON-compatible object representation
+func (r *Reconciliation) ToOutput() ReconciliationOutput {
+	periods := make([]ReconciliationPeriodOutput, 0, len(r.Periods))
+	for _, period := range r.Periods {
+		periods = append(periods, ReconciliationPeriodOutput{
+			StartBalanceID:   period.StartBalanceID,
+			StartDate:        cachetime.CacheTime(period.StartDate),
+			StartBalance:     period.StartBalance,
+			EndBalanceID:     period.EndBalanceID,
+			EndDate:          cachetime.CacheTime(period.EndDate),
+			EndBalance:       period.EndBalance,
+			BalanceChange:    period.BalanceChange,
+			TransactionTotal: period.TransactionTotal,
+			TransactionCount: period.TransactionCount,
+			Difference:       period.Difference,
+			Reconciled:       period.Reconciled,
+		})
+	}
+
+	return ReconciliationOutput{
+		BankAccountID:      r.BankAccountID,
+		RequestedStartDate: cachetime.CacheTime(r.RequestedStartDate),
+		RequestedEndDate:   cachetime.CacheTime(r.RequestedEndDate),
+		StartDate:          cachetime.CacheTime(r.StartDate),
+		EndDate:            cachetime.CacheTime(r.EndDate),
+		Reconciled:         r.Reconciled,
+		UnreconciledCount:  r.UnreconciledCount,
+		UnreconciledTotal:  r.UnreconciledTotal,
+		Periods:            periods,
+	}
+}
+
+// ReconciliationOutput is the JSON-compatible object representation of Reconciliation
+type ReconciliationOutput struct {
+	BankAccountID      uuid.UUID                    `json:"bankAccountId"`
+	RequestedStartDate cachetime.CacheTime          `json:"requestedStartDate"`
+	RequestedEndDate   cachetime.CacheTime          `json:"requestedEndDate"`
+	StartDate          cachetime.CacheTime          `json:"startDate"`
+	EndDate            cachetime.CacheTime          `json:"endDate"`
+	Reconciled         bool                         `json:"reconciled"`
+	UnreconciledCount  int                          `json:"unreconciledCount"`
+	UnreconciledTotal  float64                      `json:"unreconciledTotal"`
+	Periods            []ReconciliationPeriodOutput `json:"periods"`
+}
+
+// ReconciliationPeriodOutput is the JSON-compatible object representation of Reconciliation Period
+type ReconciliationPeriodOutput struct {
+	StartBalanceID   uuid.UUID           `json:"startBalanceId"`
+	StartDate        cachetime.CacheTime `json:"startDate"`
+	StartBalance     float64             `json:"startBalance"`
+	EndBalanceID     uuid.UUID           `json:"endBalanceId"`
+	EndDate          cachetime.CacheTime `json:"endDate"`
+	EndBalance       float64             `json:"endBalance"`
+	BalanceChange    float64             `json:"balanceChange"`
+	TransactionTotal float64             `json:"transactionTotal"`
+	TransactionCount int                 `json:"transactionCount"`
+	Difference       float64             `json:"difference"`
+	Reconciled       bool                `json:"reconciled"`
+}
+
+func roundToCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,333 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// TransferCategory is the category given to the Transactions recorded by a Transfer
+const TransferCategory = "transfer"
+
+const (
+	// TransferColumnID represents the corresponding column in Transfers table
+	TransferColumnID filter.Field = "transfers.entity_id"
+	// TransferColumnFromBankAccountID represents the corresponding column in Transfers table
+	TransferColumnFromBankAccountID filter.Field = "transfers.from_bank_account_entity_id"
+	// TransferColumnToBankAccountID represents the corresponding column in Transfers table
+	TransferColumnToBankAccountID filter.Field = "transfers.to_bank_account_entity_id"
+	// TransferColumnDate represents the corresponding column in Transfers table
+	TransferColumnDate filter.Field = "transfers.date"
+	// TransferColumnAmount represents the corresponding column in Transfers table
+	TransferColumnAmount filter.Field = "transfers.amount"
+	// TransferColumnNote represents the corresponding column in Transfers table
+	TransferColumnNote filter.Field = "transfers.note"
+	// TransferColumnCreated represents the corresponding column in Transfers table
+	TransferColumnCreated filter.Field = "transfers.created"
+	// TransferColumnCreatedBy represents the corresponding column in Transfers table
+	TransferColumnCreatedBy filter.Field = "transfers.created_by"
+	// TransferColumnUpdated represents the corresponding column in Transfers table
+	TransferColumnUpdated filter.Field = "transfers.updated"
+	// TransferColumnUpdatedBy represents the corresponding column in Transfers table
+	TransferColumnUpdatedBy filter.Field = "transfers.updated_by"
+	// TransferColumnDeleted represents the corresponding column in Transfers table
+	TransferColumnDeleted filter.Field = "transfers.deleted"
+	// TransferColumnDeletedBy represents the corresponding column in Transfers table
+	TransferColumnDeletedBy filter.Field = "transfers.deleted_by"
+)
+
+// TransferFields is the whitelist of fields Transfers can be queried and sorted by, keyed by their names in the API
+var TransferFields = map[string]filter.Field{
+	"id":                TransferColumnID,
+	"fromBankAccountId": TransferColumnFromBankAccountID,
+	"toBankAccountId":   TransferColumnToBankAccountID,
+	"date":              TransferColumnDate,
+	"amount":            TransferColumnAmount,
+	"note":              TransferColumnNote,
+	"created":           TransferColumnCreated,
+	"updated":           TransferColumnUpdated,
+	"deleted":           TransferColumnDeleted,
+	"createdBy":         TransferColumnCreatedBy,
+	"updatedBy":         TransferColumnUpdatedBy,
+	"deletedBy":         TransferColumnDeletedBy,
+}
+
+// Transfer represents money moved from one Bank Account to another. A Transfer owns the matched entries
+// it writes on both sides, a new balance and a Transaction on each Bank Account, which are always created
+// and deleted together with it so that the two sides cannot drift apart.
+type Transfer struct {
+	ID                uuid.UUID            `db:"entity_id" validate:"min=36,max=36"`
+	FromBankAccountID uuid.UUID            `db:"from_bank_account_entity_id" validate:"min=36,max=36"`
+	ToBankAccountID   uuid.UUID            `db:"to_bank_account_entity_id" validate:"min=36,max=36"`
+	Date              time.Time            `db:"date"`
+	Amount            float64              `db:"amount" validate:"min=0"`
+	Note              string               `db:"note" validate:"max=255"`
+	FromBalanceID     uuid.UUID            `db:"from_balance_entity_id" validate:"min=36,max=36"`
+	ToBalanceID       uuid.UUID            `db:"to_balance_entity_id" validate:"min=36,max=36"`
+	FromTransactionID uuid.UUID            `db:"from_transaction_entity_id" validate:"min=36,max=36"`
+	ToTransactionID   uuid.UUID            `db:"to_transaction_entity_id" validate:"min=36,max=36"`
+	Created           time.Time            `db:"created"`
+	CreatedBy         uuid.UUID            `db:"created_by" validate:"min=36,max=36"`
+	Updated           null.Time            `db:"updated"`
+	UpdatedBy         nuuid.NUUID          `db:"updated_by" validate:"min=36,max=36"`
+	Deleted           null.Time            `db:"deleted"`
+	DeletedBy         nuuid.NUUID          `db:"deleted_by" validate:"min=36,max=36"`
+	BankAccounts      []BankAccount        `db:"-"`
+	Balances          []BankAccountBalance `db:"-"`
+	Transactions      []Transaction        `db:"-"`
+}
+
+// NewTransferFromInput creates a new Transfer from its input object along with its entries on both sides.
+// The new balance of each Bank Account follows on from its last balance, and becomes its last balance.
+func NewTransferFromInput(input TransferInput, from BankAccount, to BankAccount, userID uuid.UUID) (t Transfer) {
+	now := time.Now()
+	newUUID, _ := uuid.NewV7()
+	note := strings.TrimSpace(input.Note)
+
+	fromBalanceInput := BankAccountBalanceInput{
+		BankAccountID: from.ID,
+		Date:          input.Date,
+		Balance:       roundToCents(from.LastBalance - input.Amount),
+	}
+	toBalanceInput := BankAccountBalanceInput{
+		BankAccountID: to.ID,
+		Date:          input.Date,
+		Balance:       roundToCents(to.LastBalance + input.Amount),
+	}
+	fromBalance := NewBankAccountBalanceFromInput(fromBalanceInput, from.ID, userID)
+	toBalance := NewBankAccountBalanceFromInput(toBalanceInput, to.ID, userID)
+	from.SetNewBalance(fromBalanceInput, userID)
+	to.SetNewBalance(toBalanceInput, userID)
+
+	fromTransaction := NewTransactionFromInput(TransactionInput{
+		BankAccountID: from.ID,
+		Date:          input.Date,
+		Direction:     TransactionDirectionDebit,
+		Amount:        input.Amount,
+		Payee:         to.AccountName,
+		Memo:          note,
+		Category:      TransferCategory,
+	}, from.ID, userID)
+	toTransaction := NewTransactionFromInput(TransactionInput{
+		BankAccountID: to.ID,
+		Date:          input.Date,
+		Direction:     TransactionDirectionCredit,
+		Amount:        input.Amount,
+		Payee:         from.AccountName,
+		Memo:          note,
+		Category:      TransferCategory,
+	}, to.ID, userID)
+
+	t = Transfer{
+		ID:                newUUID,
+		FromBankAccountID: from.ID,
+		ToBankAccountID:   to.ID,
+		Date:              input.Date.Time(),
+		Amount:            input.Amount,
+		Note:              note,
+		FromBalanceID:     fromBalance.ID,
+		ToBalanceID:       toBalance.ID,
+		FromTransactionID: fromTransaction.ID,
+		ToTransactionID:   toTransaction.ID,
+		Created:           now,
+		CreatedBy:         userID,
+		BankAccounts:      []BankAccount{from, to},
+		Balances:          []BankAccountBalance{fromBalance, toBalance},
+		Transactions:      []Transaction{fromTransaction, toTransaction},
+	}
+
+	return
+}
+
+// Delete performs a delete on a Transfer, leaving its entries to be deleted along with it
+func (t *Transfer) Delete(userID uuid.UUID) error {
+	if t.Deleted.Valid || t.DeletedBy.Valid {
+		return failure.OperationNotPermitted("delete", "Transfer", "already deleted")
+	}
+
+	now := time.Now()
+
+	t.Deleted = null.TimeFrom(now)
+	t.DeletedBy = nuuid.From(userID)
+
+	return nil
+}
+
+// ToOutput converts a Transfer to its JSON-compatible object representation
+func (t *Transfer) ToOutput() TransferOutput {
+	o := TransferOutput{
+		ID:                t.ID,
+		FromBankAccountID: t.FromBankAccountID,
+		ToBankAccountID:   t.ToBankAccountID,
+		Date:              cachetime.CacheTime(t.Date),
+		Amount:            t.Amount,
+		Note:              t.Note,
+		FromBalanceID:     t.FromBalanceID,
+		ToBalanceID:       t.ToBalanceID,
+		FromTransactionID: t.FromTransactionID,
+		ToTransactionID:   t.ToTransactionID,
+		Created:           cachetime.CacheTime(t.Created),
+		CreatedBy:         t.CreatedBy,
+		Updated:           cachetime.NCacheTime(t.Updated),
+		UpdatedBy:         t.UpdatedBy,
+		Deleted:           cachetime.NCacheTime(t.Deleted),
+		DeletedBy:         t.DeletedBy,
+	}
+
+	if len(t.Balances) > 0 {
+		balances := make([]BankAccountBalanceOutput, 0, len(t.Balances))
+		for _, balance := range t.Balances {
+			balances = append(balances, balance.ToOutput())
+		}
+		o.Balances = &balances
+	}
+
+	if len(t.Transactions) > 0 {
+		transactions := make([]TransactionOutput, 0, len(t.Transactions))
+		for _, transaction := range t.Transactions {
+			transactions = append(transactions, transaction.ToOutput())
+		}
+		o.Transactions = &transactions
+	}
+
+	return o
+}
+
+// TransferInput represents an input struct for Transfer entity
+type TransferInput struct {
+	FromBankAccountID uuid.UUID           `json:"fromBankAccountId"`
+	ToBankAccountID   uuid.UUID           `json:"toBankAccountId"`
+	Date              cachetime.CacheTime `json:"date"`
+	Amount            float64             `json:"amount"`
+	Note              string              `json:"note"`
+}
+
+// Validate checks a Transfer input before it is carried out
+func (i *TransferInput) Validate() error {
+	if i.FromBankAccountID == i.ToBankAccountID {
+		return failure.BadRequestFromString("cannot transfer from a Bank Account to itself")
+	}
+
+	if i.Amount <= 0 {
+		return failure.BadRequestFromString("transfer amount must be greater than zero")
+	}
+
+	if len(strings.TrimSpace(i.Note)) > 255 {
+		return failure.BadRequestFromString("transfer note must be at most 255 characters")
+	}
+
+	return nil
+}
+
+// TransferOutput is the JSON-compatible object representation of Transfer
+type TransferOutput struct {
+	ID                uuid.UUID                   `json:"id"`
+	FromBankAccountID uuid.UUID                   `json:"fromBankAccountId"`
+	ToBankAccountID   uuid.UUID                   `json:"toBankAccountId"`
+	Date              cachetime.CacheTime         `json:"date"`
+	Amount            float64                     `json:"amount"`
+	Note              string                      `json:"note"`
+	FromBalanceID     uuid.UUID                   `json:"fromBalanceId"`
+	ToBalanceID       uuid.UUID                   `json:"toBalanceId"`
+	FromTransactionID uuid.UUID                   `json:"fromTransactionId"`
+	ToTransactionID   uuid.UUID                   `json:"toTransactionId"`
+	Created           cachetime.CacheTime         `json:"created"`
+	CreatedBy         uuid.UUID                   `json:"createdBy"`
+	Updated           cachetime.NCacheTime        `json:"updated,omitempty"`
+	UpdatedBy         nuuid.NUUID                 `json:"updatedBy,omitempty"`
+	Deleted           cachetime.NCacheTime        `json:"deleted,omitempty"`
+	DeletedBy         nuuid.NUUID                 `json:"deletedBy,omitempty"`
+	Balances          *[]BankAccountBalanceOutput `json:"balances,omitempty"`
+	Transactions      *[]TransactionOutput        `json:"transactions,omitempty"`
+}
+
+// TransferFilterInput is the filter input object for Transfers
+type TransferFilterInput struct {
+	filter.BaseFilterInput
+	BankAccountIDs *[]uuid.UUID         `json:"bankAccountIds,omitempty"`
+	StartDate      cachetime.NCacheTime `json:"startDate,omitempty"`
+	EndDate        cachetime.NCacheTime `json:"endDate,omitempty"`
+	AmountMin      *float64             `json:"amountMin,omitempty"`
+	AmountMax      *float64             `json:"amountMax,omitempty"`
+}
+
+// ToFilter converts this entity-specific filter into a generic filter.Filter object
+func (f *TransferFilterInput) ToFilter() filter.Filter {
+	keywordFields := []filter.Field{
+		TransferColumnNote,
+	}
+
+	theFilter := filter.Filter{
+		TableName:      "transfers",
+		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
+		IncludeDeleted: f.GetIncludeDeleted(),
+		Pagination:     f.BaseFilterInput.GetPagination(),
+	}
+
+	// a Transfer matches a Bank Account on either of its sides
+	if f.BankAccountIDs != nil {
+		if len(*f.BankAccountIDs) > 0 {
+			theFilter.AddClause(filter.Clause{
+				Operand1: filter.Clause{
+					Operand1: TransferColumnFromBankAccountID,
+					Operand2: *f.BankAccountIDs,
+					Operator: filter.OperatorIn,
+				},
+				Operand2: filter.Clause{
+					Operand1: TransferColumnToBankAccountID,
+					Operand2: *f.BankAccountIDs,
+					Operator: filter.OperatorIn,
+				},
+				Operator: filter.OperatorOr,
+			}, filter.OperatorAnd)
+		}
+	}
+
+	if f.StartDate.Valid {
+		theFilter.AddClause(filter.Clause{
+			Operand1: TransferColumnDate,
+			Operand2: f.StartDate.Time,
+			Operator: filter.OperatorGreaterThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.EndDate.Valid {
+		theFilter.AddClause(filter.Clause{
+			Operand1: TransferColumnDate,
+			Operand2: f.EndDate.Time,
+			Operator: filter.OperatorLessThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.AmountMin != nil {
+		theFilter.AddClause(filter.Clause{
+			Operand1: TransferColumnAmount,
+			Operand2: *f.AmountMin,
+			Operator: filter.OperatorGreaterThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	if f.AmountMax != nil {
+		theFilter.AddClause(filter.Clause{
+			Operand1: TransferColumnAmount,
+			Operand2: *f.AmountMax,
+			Operator: filter.OperatorLessThanEqual,
+		}, filter.OperatorAnd)
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(TransferFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, TransferFields)
+	}
+	if theFilter.Err == nil {
+		theFilter.Pagination, theFilter.Err = f.BaseFilterInput.GetKeysetPagination(TransferColumnDate, TransferColumnID)
+	}
+
+	return theFilter
+}
@@ -34,6 +34,18 @@ const (
 	UserColumnUpdatedBy filter.Field = "users.updated_by"
 )
 
+// UserFields is the whitelist of fields Users can be queried and sorted by, keyed by their names in the API
+var UserFields = map[string]filter.Field{
+	"id":        UserColumnID,
+	"username":  UserColumnUsername,
+	"email":     UserColumnEmail,
+	"name":      UserColumnName,
+	"created":   UserColumnCreated,
+	"updated":   UserColumnUpdated,
+	"createdBy": UserColumnCreatedBy,
+	"updatedBy": UserColumnUpdatedBy,
+}
+
 // User represents a User entity object
 type User struct {
 	ID        uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
@@ -183,5 +195,10 @@ func (f *UserFilterInput) ToFilter() filter.Filter {
 	keywordClause := f.BaseFilterInput.GetKeywordFilter(keywordFields, false)
 	theFilter.AddClause(*keywordClause, filter.OperatorAnd)
 
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(UserFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, UserFields)
+	}
+
 	return theFilter
 }
@@ -79,6 +79,31 @@ const (
 	VehicleColumnDeletedBy filter.Field = "vehicles.deleted_by"
 )
 
+// VehicleFields is the whitelist of fields Vehicles can be queried and sorted by, keyed by their names in the API
+var VehicleFields = map[string]filter.Field{
+	"id":                        VehicleColumnID,
+	"name":                      VehicleColumnName,
+	"make":                      VehicleColumnMake,
+	"model":                     VehicleColumnModel,
+	"year":                      VehicleColumnYear,
+	"type":                      VehicleColumnType,
+	"titleHolder":               VehicleColumnTitleHolder,
+	"licensePlateNumber":        VehicleColumnLicensePlateNumber,
+	"purchaseDate":              VehicleColumnPurchaseDate,
+	"initialValue":              VehicleColumnInitialValue,
+	"initialValueDate":          VehicleColumnInitialValueDate,
+	"currentValue":              VehicleColumnCurrentValue,
+	"currentValueDate":          VehicleColumnCurrentvalueDate,
+	"annualDepreciationPercent": VehicleColumnAnnualDepreciationPercent,
+	"status":                    VehicleColumnStatus,
+	"created":                   VehicleColumnCreated,
+	"updated":                   VehicleColumnUpdated,
+	"deleted":                   VehicleColumnDeleted,
+	"createdBy":                 VehicleColumnCreatedBy,
+	"updatedBy":                 VehicleColumnUpdatedBy,
+	"deletedBy":                 VehicleColumnDeletedBy,
+}
+
 const (
 	// VehicleValueColumnID represents the corresponding column in the Vehicle Value table
 	VehicleValueColumnID filter.Field = "vehicle_values.entity_id"
@@ -102,6 +127,20 @@ const (
 	VehicleValueColumnDeletedBy filter.Field = "vehicle_values.deleted_by"
 )
 
+// VehicleValueFields is the whitelist of fields Vehicle Values can be queried and sorted by, keyed by their names in the API
+var VehicleValueFields = map[string]filter.Field{
+	"id":        VehicleValueColumnID,
+	"vehicleId": VehicleValueColumnVehicleID,
+	"date":      VehicleValueColumnDate,
+	"value":     VehicleValueColumnValue,
+	"created":   VehicleValueColumnCreated,
+	"updated":   VehicleValueColumnUpdated,
+	"deleted":   VehicleValueColumnDeleted,
+	"createdBy": VehicleValueColumnCreatedBy,
+	"updatedBy": VehicleValueColumnUpdatedBy,
+	"deletedBy": VehicleValueColumnDeletedBy,
+}
+
 // Vehicle represents a Vehicle object
 type Vehicle struct {
 	ID                        uuid.UUID      `db:"entity_id" validate:"min=36,max=36"`
@@ -126,6 +165,7 @@ type Vehicle struct {
 	Deleted                   null.Time      `db:"deleted"`
 	DeletedBy                 nuuid.NUUID    `db:"deleted_by" validate:"min=36,max=36"`
 	Values                    []VehicleValue `db:"-"`
+	Notes                     []Note         `db:"-"`
 }
 
 // NewVehicleFromInput creates a new Vehicle from its input object
@@ -189,6 +229,24 @@ func (v *Vehicle) AttachValues(values []VehicleValue, clearBeforeAttach bool) {
 	}
 }
 
+// AttachNotes attaches the Notes written on a Vehicle and on its attached Values
+func (v *Vehicle) AttachNotes(notes []Note) {
+	v.Notes = getSubjectNotes(notes, EntityTypeVehicle, v.ID)
+	for i := range v.Values {
+		v.Values[i].AttachNotes(notes)
+	}
+}
+
+// GetNoteSubjectIDs lists the IDs of a Vehicle and its attached Values, which Notes can be written on
+func (v *Vehicle) GetNoteSubjectIDs() []uuid.UUID {
+	ids := []uuid.UUID{v.ID}
+	for _, value := range v.Values {
+		ids = append(ids, value.ID)
+	}
+
+	return ids
+}
+
 // Update performs an update on a Vehicle
 func (v *Vehicle) Update(input VehicleInput, userID uuid.UUID) error {
 	if v.Deleted.Valid || v.DeletedBy.Valid {
@@ -215,7 +273,8 @@ func (v *Vehicle) Update(input VehicleInput, userID uuid.UUID) error {
 	return nil
 }
 
-// Delete performs a delete on a Vehicle
+// Delete performs a delete on a Vehicle. Its values are deleted along with it and marked as deleted by
+// its cascade, which is what allows Restore to tell them apart from values deleted on their own.
 func (v *Vehicle) Delete(userID uuid.UUID) error {
 	if v.Deleted.Valid || v.DeletedBy.Valid {
 		return failure.OperationNotPermitted("delete", "Vehicle", "already deleted")
@@ -228,10 +287,11 @@ func (v *Vehicle) Delete(userID uuid.UUID) error {
 
 	deletedValues := make([]VehicleValue, 0)
 	for _, value := range v.Values {
-		err := value.Delete(userID)
+		err := value.delete(userID, now)
 		if err != nil {
 			return err
 		}
+		value.DeletedByCascade = true
 
 		deletedValues = append(deletedValues, value)
 	}
@@ -241,6 +301,34 @@ func (v *Vehicle) Delete(userID uuid.UUID) error {
 	return nil
 }
 
+// Restore reverts the delete of a Vehicle along with the values deleted by that same delete.
+// Values that had been deleted on their own beforehand are left deleted.
+func (v *Vehicle) Restore(userID uuid.UUID) error {
+	if !v.Deleted.Valid && !v.DeletedBy.Valid {
+		return failure.OperationNotPermitted("restore", "Vehicle", "not deleted")
+	}
+
+	now := time.Now()
+
+	restoredValues := make([]VehicleValue, 0)
+	for _, value := range v.Values {
+		if !value.DeletedByCascade {
+			continue
+		}
+
+		value.restore(userID, now)
+		restoredValues = append(restoredValues, value)
+	}
+
+	v.Deleted = null.Time{}
+	v.DeletedBy = nuuid.NUUID{}
+	v.Updated = null.TimeFrom(now)
+	v.UpdatedBy = nuuid.From(userID)
+	v.Values = restoredValues
+
+	return nil
+}
+
 // SetCurrentValue sets a new current value and current value date on a Vehicle
 func (v *Vehicle) SetCurrentValue(input VehicleValueInput, userID uuid.UUID) error {
 	now := time.Now()
@@ -287,6 +375,7 @@ func (v *Vehicle) ToOutput() VehicleOutput {
 	}
 
 	o.Values = vvOutput
+	o.Notes = getNoteOutputs(v.Notes)
 
 	return o
 }
@@ -334,20 +423,23 @@ type VehicleOutput struct {
 	Deleted                   cachetime.NCacheTime `json:"deleted,omitempty"`
 	DeletedBy                 nuuid.NUUID          `json:"deletedBy,omitempty"`
 	Values                    []VehicleValueOutput `json:"values"`
+	Notes                     []NoteOutput         `json:"notes,omitempty"`
 }
 
 // VehicleValue represents a snapshot of a Vehicle's value at a given time
 type VehicleValue struct {
-	ID        uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
-	VehicleID uuid.UUID   `db:"vehicle_entity_id" validate:"min=36,max=36"`
-	Date      time.Time   `db:"date"`
-	Value     float64     `db:"value" validate:"min=0"`
-	Created   time.Time   `db:"created"`
-	CreatedBy uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
-	Updated   null.Time   `db:"updated"`
-	UpdatedBy nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
-	Deleted   null.Time   `db:"deleted"`
-	DeletedBy nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+	ID               uuid.UUID   `db:"entity_id" validate:"min=36,max=36"`
+	VehicleID        uuid.UUID   `db:"vehicle_entity_id" validate:"min=36,max=36"`
+	Date             time.Time   `db:"date"`
+	Value            float64     `db:"value" validate:"min=0"`
+	Created          time.Time   `db:"created"`
+	CreatedBy        uuid.UUID   `db:"created_by" validate:"min=36,max=36"`
+	Updated          null.Time   `db:"updated"`
+	UpdatedBy        nuuid.NUUID `db:"updated_by" validate:"min=36,max=36"`
+	Deleted          null.Time   `db:"deleted"`
+	DeletedBy        nuuid.NUUID `db:"deleted_by" validate:"min=36,max=36"`
+	DeletedByCascade bool        `db:"deleted_by_cascade"`
+	Notes            []Note      `db:"-"`
 }
 
 func NewVehicleValueFromInput(input VehicleValueInput, vehicleID uuid.UUID, userID uuid.UUID) (vv VehicleValue) {
@@ -384,18 +476,33 @@ func (vv *VehicleValue) Update(input VehicleValueInput, userID uuid.UUID) error
 
 // Delete performs a delete on a Vehicle Value
 func (vv *VehicleValue) Delete(userID uuid.UUID) error {
+	return vv.delete(userID, time.Now())
+}
+
+func (vv *VehicleValue) delete(userID uuid.UUID, now time.Time) error {
 	if vv.Deleted.Valid || vv.DeletedBy.Valid {
 		return failure.OperationNotPermitted("delete", "Vehicle Value", "already deleted")
 	}
 
-	now := time.Now()
-
 	vv.Deleted = null.TimeFrom(now)
 	vv.DeletedBy = nuuid.From(userID)
 
 	return nil
 }
 
+func (vv *VehicleValue) restore(userID uuid.UUID, now time.Time) {
+	vv.Deleted = null.Time{}
+	vv.DeletedBy = nuuid.NUUID{}
+	vv.DeletedByCascade = false
+	vv.Updated = null.TimeFrom(now)
+	vv.UpdatedBy = nuuid.From(userID)
+}
+
+// AttachNotes attaches the Notes written on a Vehicle Value
+func (vv *VehicleValue) AttachNotes(notes []Note) {
+	vv.Notes = getSubjectNotes(notes, EntityTypeVehicleValue, vv.ID)
+}
+
 // ToOutput converts a Vehicle Value to its JSON-compatible object representation
 func (vv *VehicleValue) ToOutput() VehicleValueOutput {
 	return VehicleValueOutput{
@@ -409,6 +516,7 @@ func (vv *VehicleValue) ToOutput() VehicleValueOutput {
 		UpdatedBy: vv.UpdatedBy,
 		Deleted:   cachetime.NCacheTime(vv.Deleted),
 		DeletedBy: vv.DeletedBy,
+		Notes:     getNoteOutputs(vv.Notes),
 	}
 }
 
@@ -420,6 +528,21 @@ type VehicleValueInput struct {
 	Value     float64             `json:"value"`
 }
 
+// VehicleValueImportInput is the input object for importing Vehicle Values from CSV
+type VehicleValueImportInput struct {
+	ImportInput
+	VehicleID uuid.UUID `json:"vehicleId"`
+}
+
+// ToValueInput converts a row of the import to the input object for a single Vehicle Value
+func (i *VehicleValueImportInput) ToValueInput(row ImportRow) VehicleValueInput {
+	return VehicleValueInput{
+		VehicleID: i.VehicleID,
+		Date:      cachetime.CacheTime(row.Date),
+		Value:     row.Amount,
+	}
+}
+
 // VehicleValueOutput is the JSON-compatible object representation of Vehicle Value
 type VehicleValueOutput struct {
 	ID        uuid.UUID            `json:"id"`
@@ -432,11 +555,14 @@ type VehicleValueOutput struct {
 	UpdatedBy nuuid.NUUID          `json:"updatedBy,omitempty"`
 	Deleted   cachetime.NCacheTime `json:"deleted,omitempty"`
 	DeletedBy nuuid.NUUID          `json:"deletedBy,omitempty"`
+	Notes     []NoteOutput         `json:"notes,omitempty"`
 }
 
 // VehicleFilterInput is the filter input object for Vehicles
 type VehicleFilterInput struct {
 	filter.BaseFilterInput
+	Tags        *[]string                `json:"tags,omitempty"`
+	Aggregation *filter.AggregationInput `json:"aggregation,omitempty"`
 }
 
 // ToFilter converts this entity-specific filter to a generic filter.Filter object
@@ -450,12 +576,28 @@ func (f *VehicleFilterInput) ToFilter() filter.Filter {
 		VehicleColumnTitleHolder,
 	}
 
-	return filter.Filter{
+	theFilter := filter.Filter{
 		TableName:      "vehicles",
 		Clause:         f.BaseFilterInput.GetKeywordFilter(keywordFields, false),
 		IncludeDeleted: f.GetIncludeDeleted(),
 		Pagination:     f.BaseFilterInput.GetPagination(),
 	}
+
+	if f.Tags != nil {
+		if len(*f.Tags) > 0 {
+			theFilter.AddClause(newTagClause(VehicleColumnID, EntityTypeVehicle, *f.Tags), filter.OperatorAnd)
+		}
+	}
+
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(VehicleFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, VehicleFields)
+	}
+	if theFilter.Err == nil {
+		theFilter.Aggregation, theFilter.Err = f.Aggregation.ToAggregation(VehicleFields)
+	}
+
+	return theFilter
 }
 
 type VehicleValueFilterInput struct {
@@ -517,5 +659,13 @@ func (f *VehicleValueFilterInput) ToFilter() filter.Filter {
 		}, filter.OperatorAnd)
 	}
 
+	theFilter.Sorts, theFilter.Err = f.BaseFilterInput.GetSorts(VehicleValueFields)
+	if theFilter.Err == nil {
+		theFilter.Err = theFilter.AddExpression(f.Query, VehicleValueFields)
+	}
+	if theFilter.Err == nil {
+		theFilter.Pagination, theFilter.Err = f.BaseFilterInput.GetKeysetPagination(VehicleValueColumnDate, VehicleValueColumnID)
+	}
+
 	return theFilter
 }
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"strconv"
+
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// resolveAggregateByFilter groups the rows matching a filter and computes the filter's aggregates for every group
+func resolveAggregateByFilter(db *database.MySQL, aggregateFilter filter.Filter) (aggregateRows []model.AggregateRow, err error) {
+	aggregateRows = make([]model.AggregateRow, 0)
+
+	filterQueryString, err := aggregateFilter.ToQueryString()
+	if err != nil {
+		return
+	}
+
+	aggregation := aggregateFilter.Aggregation
+	query, args, err := db.In(
+		aggregation.ToSelectString()+aggregateFilter.TableName+filterQueryString+aggregation.ToGroupString(),
+		aggregateFilter.GetArgs(false)...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	rows, err := db.Queryx(query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var columns []interface{}
+		columns, err = rows.SliceScan()
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			return
+		}
+
+		aggregateRow := model.AggregateRow{
+			Group:  make(map[string]interface{}),
+			Values: make(map[string]interface{}),
+		}
+		for idx, group := range aggregation.Groups {
+			aggregateRow.Group[group.Name] = getAggregateGroupValue(columns[idx])
+		}
+		for idx, aggregate := range aggregation.Aggregates {
+			aggregateRow.Values[aggregate.Name] = getAggregateValue(columns[len(aggregation.Groups)+idx])
+		}
+		aggregateRows = append(aggregateRows, aggregateRow)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// getAggregateGroupValue converts a scanned group column to a value fit for JSON, as the driver returns text as bytes
+func getAggregateGroupValue(column interface{}) interface{} {
+	if bytes, ok := column.([]byte); ok {
+		return string(bytes)
+	}
+	return column
+}
+
+// getAggregateValue converts a scanned aggregate column to a value fit for JSON, as the driver returns
+// decimal sums and averages as bytes
+func getAggregateValue(column interface{}) interface{} {
+	bytes, ok := column.([]byte)
+	if !ok {
+		return column
+	}
+	if number, err := strconv.ParseFloat(string(bytes), 64); err == nil {
+		return number
+	}
+	return string(bytes)
+}
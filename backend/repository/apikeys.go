@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectAPIKey = `
+		SELECT
+			api_keys.entity_id,
+			api_keys.user_entity_id,
+			api_keys.name,
+			api_keys.key_prefix,
+			api_keys.key_hash,
+			api_keys.scopes,
+			api_keys.expires,
+			api_keys.last_used,
+			api_keys.created,
+			api_keys.created_by,
+			api_keys.revoked,
+			api_keys.revoked_by
+		FROM
+			api_keys `
+
+	QueryInsertAPIKey = `
+		INSERT INTO api_keys (
+			entity_id,
+			user_entity_id,
+			name,
+			key_prefix,
+			key_hash,
+			scopes,
+			expires,
+			last_used,
+			created,
+			created_by,
+			revoked,
+			revoked_by
+		) VALUES (
+			:entity_id,
+			:user_entity_id,
+			:name,
+			:key_prefix,
+			:key_hash,
+			:scopes,
+			:expires,
+			:last_used,
+			:created,
+			:created_by,
+			:revoked,
+			:revoked_by
+		)`
+
+	QueryUpdateAPIKey = `
+		UPDATE api_keys
+		SET
+			user_entity_id = :user_entity_id,
+			name = :name,
+			key_prefix = :key_prefix,
+			key_hash = :key_hash,
+			scopes = :scopes,
+			expires = :expires,
+			last_used = :last_used,
+			created = :created,
+			created_by = :created_by,
+			revoked = :revoked,
+			revoked_by = :revoked_by
+		WHERE entity_id = :entity_id`
+
+	QueryUpdateAPIKeyLastUsed = `
+		UPDATE api_keys
+		SET
+			last_used = ?
+		WHERE entity_id = ?`
+)
+
+// APIKeyMySQLRepo is the repository for API Keys implemented with MySQL backend
+type APIKeyMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *APIKeyMySQLRepo) Startup() {
+	logger.Trace("API Key repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *APIKeyMySQLRepo) Shutdown() {
+	logger.Trace("API Key repository shutting down...")
+}
+
+// ExistsByID checks the existence of an API Key by its ID
+func (r *APIKeyMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM api_keys WHERE api_keys.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves API Keys by their IDs
+func (r *APIKeyMySQLRepo) ResolveByIDs(ids []uuid.UUID) (apiKeys []model.APIKey, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectAPIKey+" WHERE api_keys.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&apiKeys, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByPrefix resolves an API Key by its lookup prefix
+func (r *APIKeyMySQLRepo) ResolveByPrefix(prefix string) (apiKey model.APIKey, err error) {
+	err = r.DB.Get(
+		&apiKey,
+		QuerySelectAPIKey+" WHERE api_keys.key_prefix = ? LIMIT 1",
+		prefix,
+	)
+
+	if err != nil {
+		logger.Warn("[apiKeyRepo] unsuccessful API key resolution using prefix: %s", prefix)
+	}
+
+	return
+}
+
+// ResolveByFilter resolves API Keys by a specified filter
+func (r *APIKeyMySQLRepo) ResolveByFilter(filter filter.Filter) (apiKeys []model.APIKey, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return apiKeys, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectAPIKey+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&apiKeys, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM api_keys "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// Create creates an API Key
+func (r *APIKeyMySQLRepo) Create(apiKey model.APIKey) error {
+	exists, err := r.ExistsByID(apiKey.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "API Key", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := r.DB.Prepare(QueryInsertAPIKey)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(apiKey)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return nil
+}
+
+// Update updates an API Key
+func (r *APIKeyMySQLRepo) Update(apiKey model.APIKey) error {
+	exists, err := r.ExistsByID(apiKey.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "API Key")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := r.DB.Prepare(QueryUpdateAPIKey)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(apiKey)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateLastUsed records the last time an API Key was used without touching any other column
+func (r *APIKeyMySQLRepo) UpdateLastUsed(id uuid.UUID, lastUsed time.Time) error {
+	_, err := r.DB.Exec(QueryUpdateAPIKeyLastUsed, lastUsed, id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return err
+}
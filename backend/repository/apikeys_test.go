@@ -0,0 +1,337 @@
+package repository_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	apiKeyStmtInsert = `INSERT INTO api_keys
+	( entity_id, user_entity_id, name, key_prefix, key_hash, scopes, expires, last_used, created, created_by, revoked, revoked_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	apiKeyStmtUpdate = `
+	UPDATE api_keys
+	SET user_entity_id = ?, name = ?, key_prefix = ?, key_hash = ?, scopes = ?, expires = ?, last_used = ?, created = ?, created_by = ?, revoked = ?, revoked_by = ?
+	WHERE entity_id = ?`
+)
+
+var (
+	apiKeyTestNow       = time.Now()
+	apiKeyTestID1, _    = uuid.NewV7()
+	apiKeyTestUserID, _ = uuid.NewV7()
+	apiKeyTestModel     = model.APIKey{
+		ID:        apiKeyTestID1,
+		UserID:    apiKeyTestUserID,
+		Name:      "Spreadsheet Sync",
+		Prefix:    "0a1b2c3d",
+		KeyHash:   "hash",
+		Scopes:    model.APIKeyScopes{model.APIKeyScopeReadOnly, model.APIKeyScopeBalancesWrite},
+		Created:   apiKeyTestNow,
+		CreatedBy: apiKeyTestUserID,
+	}
+)
+
+func getAPIKeyModelArgs(apiKey model.APIKey) []driver.Value {
+	return []driver.Value{
+		apiKey.ID,
+		apiKey.UserID,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.KeyHash,
+		"read-only,balances:write",
+		nil,
+		nil,
+		apiKey.Created,
+		apiKey.CreatedBy,
+		nil,
+		nil,
+	}
+}
+
+func TestAPIKeyRepository(t *testing.T) {
+
+	t.Run("create", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM api_keys WHERE api_keys.entity_id = ?").
+				WithArgs(apiKeyTestID1.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.
+				ExpectPrepare(apiKeyStmtInsert).
+				ExpectExec().
+				WithArgs(getAPIKeyModelArgs(apiKeyTestModel)...).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(apiKeyTestModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM api_keys WHERE api_keys.entity_id = ?").
+				WithArgs(apiKeyTestID1.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(apiKeyTestModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.IsType(t, &failure.Failure{}, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, err.(*failure.Failure).Code)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnExec", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM api_keys WHERE api_keys.entity_id = ?").
+				WithArgs(apiKeyTestID1.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.
+				ExpectPrepare(apiKeyStmtInsert).
+				ExpectExec().
+				WithArgs(getAPIKeyModelArgs(apiKeyTestModel)...).
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(apiKeyTestModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveByPrefix", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			result := sqlmock.NewRows([]string{"key_prefix", "scopes"}).AddRow(apiKeyTestModel.Prefix, "read-only,balances:write")
+			mock.
+				ExpectQuery(repository.QuerySelectAPIKey + " WHERE api_keys.key_prefix = ? LIMIT 1").
+				WithArgs(apiKeyTestModel.Prefix).
+				WillReturnRows(result)
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			apiKey, err := repo.ResolveByPrefix(apiKeyTestModel.Prefix)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Equal(t, apiKeyTestModel.Scopes, apiKey.Scopes)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("error", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectAPIKey + " WHERE api_keys.key_prefix = ? LIMIT 1").
+				WithArgs(apiKeyTestModel.Prefix).
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			_, err := repo.ResolveByPrefix(apiKeyTestModel.Prefix)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectAPIKey+" WHERE ((api_keys.user_entity_id IN (?))) AND api_keys.revoked IS NULL LIMIT ? OFFSET ?").
+				WithArgs(apiKeyTestUserID, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(apiKeyTestID1))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM api_keys WHERE ((api_keys.user_entity_id IN (?))) AND api_keys.revoked IS NULL").
+				WithArgs(apiKeyTestUserID).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.APIKeyFilterInput{}
+			testFilter.UserIDs = &[]uuid.UUID{apiKeyTestUserID}
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("update", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			args := getAPIKeyModelArgs(apiKeyTestModel)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM api_keys WHERE api_keys.entity_id = ?").
+				WithArgs(apiKeyTestID1.String()).
+				WillReturnRows(getExistsResult(true))
+
+			mock.
+				ExpectPrepare(apiKeyStmtUpdate).
+				ExpectExec().
+				WithArgs(append(args[1:], args[0])...).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(apiKeyTestModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("notFound", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM api_keys WHERE api_keys.entity_id = ?").
+				WithArgs(apiKeyTestID1.String()).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(apiKeyTestModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.IsType(t, &failure.Failure{}, err)
+			assert.Equal(t, failure.CodeEntityNotFound, err.(*failure.Failure).Code)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateLastUsed", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectExec(repository.QueryUpdateAPIKeyLastUsed).
+				WithArgs(apiKeyTestNow, apiKeyTestID1.String()).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.UpdateLastUsed(apiKeyTestID1, apiKeyTestNow)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("error", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectExec(repository.QueryUpdateAPIKeyLastUsed).
+				WithArgs(apiKeyTestNow, apiKeyTestID1.String()).
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.APIKeyMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.UpdateLastUsed(apiKeyTestID1, apiKeyTestNow)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
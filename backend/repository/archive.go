@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	// QueryCountArchiveRecords counts the records of every table an archive restores other than users, which
+	// are restored alongside existing ones, so that it has to list every such table
+	QueryCountArchiveRecords = `
+		SELECT
+			(SELECT COUNT(*) FROM bank_accounts) +
+			(SELECT COUNT(*) FROM bank_account_balances) +
+			(SELECT COUNT(*) FROM bank_account_cash_flows) +
+			(SELECT COUNT(*) FROM transactions) +
+			(SELECT COUNT(*) FROM transfers) +
+			(SELECT COUNT(*) FROM categories) +
+			(SELECT COUNT(*) FROM category_rules) +
+			(SELECT COUNT(*) FROM budgets) +
+			(SELECT COUNT(*) FROM goals) +
+			(SELECT COUNT(*) FROM goal_bank_accounts) +
+			(SELECT COUNT(*) FROM vehicles) +
+			(SELECT COUNT(*) FROM vehicle_values) +
+			(SELECT COUNT(*) FROM properties) +
+			(SELECT COUNT(*) FROM property_values) +
+			(SELECT COUNT(*) FROM tags) +
+			(SELECT COUNT(*) FROM entity_tags) +
+			(SELECT COUNT(*) FROM custom_fields) +
+			(SELECT COUNT(*) FROM custom_field_values) +
+			(SELECT COUNT(*) FROM notes) +
+			(SELECT COUNT(*) FROM attachments)`
+)
+
+// ArchiveMySQLRepo is the repository for exporting and restoring Archives implemented with MySQL backend
+type ArchiveMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *ArchiveMySQLRepo) Startup() {
+	logger.Trace("Archive repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *ArchiveMySQLRepo) Shutdown() {
+	logger.Trace("Archive repository shutting down...")
+}
+
+// Export reads every record of every table held by an archive, including soft-deleted ones, within a single
+// transaction so that the archive is consistent. Each table is handed over to be written as soon as it is read,
+// in the order archives list them, so that no more than one of them is held in memory at a time.
+func (r *ArchiveMySQLRepo) Export(write func(part model.Archive) error) error {
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		part := model.NewArchive()
+		steps := []struct {
+			dest  interface{}
+			query string
+		}{
+			{&part.Users, QuerySelectUser + " ORDER BY users.created"},
+			{&part.BankAccounts, QuerySelectBankAccount + " ORDER BY bank_accounts.created"},
+			{&part.BankAccountBalances, QuerySelectBankAccountBalance + " ORDER BY bank_account_balances.created"},
+			{&part.BankAccountCashFlows, QuerySelectBankAccountCashFlow + " ORDER BY bank_account_cash_flows.created"},
+			{&part.Transactions, QuerySelectTransaction + " ORDER BY transactions.created"},
+			{&part.Transfers, QuerySelectTransfer + " ORDER BY transfers.created"},
+			{&part.Categories, QuerySelectCategory + " ORDER BY categories.created"},
+			{&part.CategoryRules, QuerySelectCategoryRule + " ORDER BY category_rules.created"},
+			{&part.Budgets, QuerySelectBudget + " ORDER BY budgets.created"},
+			{&part.Goals, QuerySelectGoal + " ORDER BY goals.created"},
+			{&part.GoalBankAccounts, QuerySelectGoalBankAccount + " ORDER BY goal_bank_accounts.goal_entity_id, goal_bank_accounts.bank_account_entity_id"},
+			{&part.Vehicles, QuerySelectVehicle + " ORDER BY vehicles.created"},
+			{&part.VehicleValues, QuerySelectVehicleValues + " ORDER BY vehicle_values.created"},
+			{&part.Properties, QuerySelectProperty + " ORDER BY properties.created"},
+			{&part.PropertyValues, QuerySelectPropertyValues + " ORDER BY property_values.created"},
+			{&part.Tags, QuerySelectTag + " ORDER BY tags.created"},
+			{&part.EntityTags, QuerySelectEntityTag + " ORDER BY entity_tags.tag_entity_id, entity_tags.entity_type, entity_tags.subject_entity_id"},
+			{&part.CustomFields, QuerySelectCustomField + " ORDER BY custom_fields.created"},
+			{&part.CustomFieldValues, QuerySelectCustomFieldValue + " ORDER BY custom_field_values.custom_field_entity_id, custom_field_values.subject_entity_id"},
+			{&part.Notes, QuerySelectNote + " ORDER BY notes.created"},
+			{&part.Attachments, QuerySelectAttachment + " ORDER BY attachments.created"},
+		}
+
+		for _, step := range steps {
+			err := tx.Select(step.dest, step.query)
+			if err != nil {
+				logger.ErrNoStack("%v", err)
+				e <- failure.InternalError("export", "Archive", err)
+				return
+			}
+
+			err = write(part)
+			if err != nil {
+				e <- err
+				return
+			}
+
+			// emptying the part in place lets the table just written go, while the steps keep pointing at it
+			part = model.NewArchive()
+		}
+
+		e <- nil
+	})
+}
+
+// IsEmpty checks whether the instance holds no records an archive would restore other than users, not even
+// soft-deleted ones, so that restoring cannot collide with any of them
+func (r *ArchiveMySQLRepo) IsEmpty() (empty bool, err error) {
+	var count int
+	err = r.DB.Get(&count, QueryCountArchiveRecords)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return false, failure.InternalError("check", "Archive", err)
+	}
+
+	return count == 0, nil
+}
+
+// Restore inserts every record of an archive as is, preserving their IDs, and records the restore
+// in the audit trail, all in a single transaction. Categories go in ahead of the Transactions linked to them.
+func (r *ArchiveMySQLRepo) Restore(archive model.Archive, result model.ArchiveRestoreResult, userID uuid.UUID) error {
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		tables := []struct {
+			query   string
+			records []interface{}
+		}{
+			{QueryInsertUser, toArchiveRecords(archive.Users)},
+			{QueryInsertBankAccount, toArchiveRecords(archive.BankAccounts)},
+			{QueryInsertBankAccountBalance, toArchiveRecords(archive.BankAccountBalances)},
+			{QueryInsertBankAccountCashFlow, toArchiveRecords(archive.BankAccountCashFlows)},
+			{QueryInsertCategory, toArchiveRecords(archive.Categories)},
+			{QueryInsertCategoryRule, toArchiveRecords(archive.CategoryRules)},
+			{QueryInsertTransaction, toArchiveRecords(archive.Transactions)},
+			{QueryInsertTransfer, toArchiveRecords(archive.Transfers)},
+			{QueryInsertBudget, toArchiveRecords(archive.Budgets)},
+			{QueryInsertGoal, toArchiveRecords(archive.Goals)},
+			{QueryInsertGoalBankAccount, toArchiveRecords(archive.GoalBankAccounts)},
+			{QueryInsertVehicle, toArchiveRecords(archive.Vehicles)},
+			{QueryInsertVehicleValue, toArchiveRecords(archive.VehicleValues)},
+			{QueryInsertProperty, toArchiveRecords(archive.Properties)},
+			{QueryInsertPropertyValue, toArchiveRecords(archive.PropertyValues)},
+			{QueryInsertTag, toArchiveRecords(archive.Tags)},
+			{QueryInsertEntityTag, toArchiveRecords(archive.EntityTags)},
+			{QueryInsertCustomField, toArchiveRecords(archive.CustomFields)},
+			{QueryInsertCustomFieldValue, toArchiveRecords(archive.CustomFieldValues)},
+			{QueryInsertNote, toArchiveRecords(archive.Notes)},
+			{QueryInsertAttachment, toArchiveRecords(archive.Attachments)},
+		}
+
+		for _, table := range tables {
+			err := r.txInsertArchiveRecords(tx, table.query, table.records)
+			if err != nil {
+				e <- failure.InternalError("restore", "Archive", err)
+				return
+			}
+		}
+
+		err := txCreateAuditLog(
+			tx,
+			model.EntityTypeArchive,
+			result.ID,
+			model.AuditActionRestore,
+			userID,
+			nil,
+			result)
+		if err != nil {
+			e <- failure.InternalError("restore", "Archive", err)
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// txInsertArchiveRecords inserts the records of a single table, preparing its statement only once
+func (r *ArchiveMySQLRepo) txInsertArchiveRecords(tx *sqlx.Tx, query string, records []interface{}) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareNamed(query)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+	defer stmt.Close()
+
+	for _, record := range records {
+		_, err = stmt.Exec(record)
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func toArchiveRecords[T any](entities []T) []interface{} {
+	records := make([]interface{}, 0, len(entities))
+	for _, entity := range entities {
+		records = append(records, entity)
+	}
+	return records
+}
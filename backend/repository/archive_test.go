@@ -0,0 +1,445 @@
+package repository_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	archiveTestUserID, _        = uuid.NewV7()
+	archiveTestBankAccountID, _ = uuid.NewV7()
+	archiveTestBalanceID, _     = uuid.NewV7()
+	archiveTestAttachmentID, _  = uuid.NewV7()
+	archiveTestContent          = []byte("%PDF-1.4\n%statement\n")
+	archiveTestNow              = time.Now()
+)
+
+func getArchiveTestArchive() model.Archive {
+	archive := model.NewArchive()
+	archive.Users = append(archive.Users, model.User{
+		ID:        archiveTestUserID,
+		Username:  "username",
+		Email:     "email@example.com",
+		Password:  "password",
+		Name:      "User",
+		Created:   archiveTestNow,
+		CreatedBy: archiveTestUserID,
+	})
+	archive.BankAccounts = append(archive.BankAccounts, model.BankAccount{
+		ID:          archiveTestBankAccountID,
+		AccountName: "Savings",
+		Status:      model.BankAccountStatusActive,
+		Created:     archiveTestNow,
+		CreatedBy:   archiveTestUserID,
+	})
+	archive.BankAccountBalances = append(archive.BankAccountBalances, model.BankAccountBalance{
+		ID:            archiveTestBalanceID,
+		BankAccountID: archiveTestBankAccountID,
+		Date:          archiveTestNow,
+		Balance:       100,
+		Created:       archiveTestNow,
+		CreatedBy:     archiveTestUserID,
+	})
+	archive.Attachments = append(archive.Attachments, model.Attachment{
+		ID:          archiveTestAttachmentID,
+		EntityType:  model.EntityTypeBankAccountBalance,
+		SubjectID:   archiveTestBalanceID,
+		FileName:    "statement.pdf",
+		ContentType: "application/pdf",
+		Size:        int64(len(archiveTestContent)),
+		Checksum:    model.GetAttachmentChecksum(archiveTestContent),
+		Created:     archiveTestNow,
+		CreatedBy:   archiveTestUserID,
+	})
+	archive.AttachmentContents[archiveTestAttachmentID] = archiveTestContent
+	return archive
+}
+
+func TestArchiveRepository(t *testing.T) {
+
+	t.Run("export", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectQuery(repository.QuerySelectUser + " ORDER BY users.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id", "username"}).AddRow(archiveTestUserID, "username"))
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccount + " ORDER BY bank_accounts.created").
+				WillReturnRows(getSingleEntityIDResult(archiveTestBankAccountID))
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccountBalance + " ORDER BY bank_account_balances.created").
+				WillReturnRows(getSingleEntityIDResult(archiveTestBalanceID))
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccountCashFlow + " ORDER BY bank_account_cash_flows.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectTransaction + " ORDER BY transactions.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectTransfer + " ORDER BY transfers.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectCategory + " ORDER BY categories.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectCategoryRule + " ORDER BY category_rules.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectBudget + " ORDER BY budgets.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectGoal + " ORDER BY goals.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectGoalBankAccount + " ORDER BY goal_bank_accounts.goal_entity_id, goal_bank_accounts.bank_account_entity_id").
+				WillReturnRows(sqlmock.NewRows([]string{"goal_entity_id", "bank_account_entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectVehicle + " ORDER BY vehicles.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectVehicleValues + " ORDER BY vehicle_values.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectProperty + " ORDER BY properties.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectPropertyValues + " ORDER BY property_values.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectTag + " ORDER BY tags.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectEntityTag + " ORDER BY entity_tags.tag_entity_id, entity_tags.entity_type, entity_tags.subject_entity_id").
+				WillReturnRows(sqlmock.NewRows([]string{"tag_entity_id", "entity_type", "subject_entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectCustomField + " ORDER BY custom_fields.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectCustomFieldValue + " ORDER BY custom_field_values.custom_field_entity_id, custom_field_values.subject_entity_id").
+				WillReturnRows(sqlmock.NewRows([]string{"custom_field_entity_id", "subject_entity_id", "value"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectNote + " ORDER BY notes.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectQuery(repository.QuerySelectAttachment + " ORDER BY attachments.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id", "entity_type", "subject_entity_id", "size"}).
+					AddRow(archiveTestAttachmentID, model.EntityTypeBankAccountBalance, archiveTestBalanceID, 21))
+
+			mock.ExpectCommit()
+
+			repo := new(repository.ArchiveMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			parts := []model.Archive{}
+			err := repo.Export(func(part model.Archive) error {
+				parts = append(parts, part)
+				return nil
+			})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, parts, 21)
+			for _, part := range parts {
+				assert.Equal(t, model.ArchiveVersion, part.Version)
+			}
+			assert.Len(t, parts[0].Users, 1)
+			assert.Equal(t, "username", parts[0].Users[0].Username)
+			assert.Len(t, parts[0].BankAccounts, 0)
+			assert.Len(t, parts[1].Users, 0)
+			assert.Len(t, parts[1].BankAccounts, 1)
+			assert.Len(t, parts[2].BankAccountBalances, 1)
+			assert.Len(t, parts[3].BankAccountCashFlows, 0)
+			assert.Len(t, parts[11].Vehicles, 0)
+			assert.NotNil(t, parts[11].Vehicles)
+			assert.Len(t, parts[20].BankAccountBalances, 0)
+			assert.Len(t, parts[20].Attachments, 1)
+			assert.Equal(t, archiveTestAttachmentID, parts[20].Attachments[0].ID)
+			assert.Equal(t, int64(21), parts[20].Attachments[0].Size)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnSelect", func(t *testing.T) {
+			errMsg := "cannot select rows"
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectQuery(repository.QuerySelectUser + " ORDER BY users.created").
+				WillReturnError(errors.New(errMsg))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.ArchiveMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Export(func(part model.Archive) error {
+				t.Fatal("nothing should be written when the select fails")
+				return nil
+			})
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeInternalError, failure.GetCode(err))
+			assert.Contains(t, err.Error(), errMsg)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnWrite", func(t *testing.T) {
+			errMsg := "cannot write table"
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectQuery(repository.QuerySelectUser + " ORDER BY users.created").
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id", "username"}).
+					AddRow(archiveTestUserID, "username"))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.ArchiveMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			written := 0
+			err := repo.Export(func(part model.Archive) error {
+				written++
+				return errors.New(errMsg)
+			})
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, 1, written)
+			assert.Contains(t, err.Error(), errMsg)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("isEmpty", func(t *testing.T) {
+
+		t.Run("countsEveryRestoredTable", func(t *testing.T) {
+			insertQueries := []string{
+				repository.QueryInsertBankAccount,
+				repository.QueryInsertBankAccountBalance,
+				repository.QueryInsertBankAccountCashFlow,
+				repository.QueryInsertTransaction,
+				repository.QueryInsertTransfer,
+				repository.QueryInsertCategory,
+				repository.QueryInsertCategoryRule,
+				repository.QueryInsertBudget,
+				repository.QueryInsertGoal,
+				repository.QueryInsertGoalBankAccount,
+				repository.QueryInsertVehicle,
+				repository.QueryInsertVehicleValue,
+				repository.QueryInsertProperty,
+				repository.QueryInsertPropertyValue,
+				repository.QueryInsertTag,
+				repository.QueryInsertEntityTag,
+				repository.QueryInsertCustomField,
+				repository.QueryInsertCustomFieldValue,
+				repository.QueryInsertNote,
+				repository.QueryInsertAttachment,
+			}
+
+			for _, query := range insertQueries {
+				table := strings.Fields(strings.SplitN(query, "INSERT INTO", 2)[1])[0]
+				assert.Contains(t, repository.QueryCountArchiveRecords, "FROM "+table+")")
+			}
+		})
+
+		t.Run("empty", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QueryCountArchiveRecords).
+				WillReturnRows(getCountResult(0))
+
+			repo := new(repository.ArchiveMySQLRepo)
+			repo.DB = &db
+
+			empty, err := repo.IsEmpty()
+
+			assert.Nil(t, err)
+			assert.True(t, empty)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("notEmpty", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QueryCountArchiveRecords).
+				WillReturnRows(getCountResult(3))
+
+			repo := new(repository.ArchiveMySQLRepo)
+			repo.DB = &db
+
+			empty, err := repo.IsEmpty()
+
+			assert.Nil(t, err)
+			assert.False(t, empty)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("restore", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			archive := getArchiveTestArchive()
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(userStmtInsert).
+				ExpectExec().
+				WithArgs(
+					archiveTestUserID,
+					"username",
+					"email@example.com",
+					"password",
+					"User",
+					archiveTestNow,
+					archiveTestUserID,
+					sqlmock.AnyArg(),
+					sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			mock.
+				ExpectPrepare(bankAccountsStmtInsert).
+				ExpectExec().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			mock.
+				ExpectPrepare(bankAccountBalancesStmtInsert).
+				ExpectExec().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			mock.
+				ExpectPrepare(attachmentsStmtInsert).
+				ExpectExec().
+				WithArgs(
+					archiveTestAttachmentID,
+					model.EntityTypeBankAccountBalance,
+					archiveTestBalanceID,
+					"statement.pdf",
+					"application/pdf",
+					int64(len(archiveTestContent)),
+					model.GetAttachmentChecksum(archiveTestContent),
+					archiveTestNow,
+					archiveTestUserID,
+					nil,
+					nil,
+					nil,
+					nil).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeArchive)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.ArchiveMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Restore(archive, model.NewArchiveRestoreResult(archive, 0), archiveTestUserID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnExecRollsBackEverything", func(t *testing.T) {
+			errMsg := "duplicate entry"
+			archive := getArchiveTestArchive()
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(userStmtInsert).
+				ExpectExec().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			mock.
+				ExpectPrepare(bankAccountsStmtInsert).
+				ExpectExec().
+				WillReturnError(errors.New(errMsg))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.ArchiveMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Restore(archive, model.NewArchiveRestoreResult(archive, 0), archiveTestUserID)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeInternalError, failure.GetCode(err))
+			assert.Contains(t, err.Error(), errMsg)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
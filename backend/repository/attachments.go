@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectAttachment = `
+		SELECT
+			attachments.entity_id,
+			attachments.entity_type,
+			attachments.subject_entity_id,
+			attachments.file_name,
+			attachments.content_type,
+			attachments.size,
+			attachments.checksum,
+			attachments.created,
+			attachments.created_by,
+			attachments.updated,
+			attachments.updated_by,
+			attachments.deleted,
+			attachments.deleted_by
+		FROM
+			attachments `
+
+	QueryInsertAttachment = `
+		INSERT INTO attachments (
+			entity_id,
+			entity_type,
+			subject_entity_id,
+			file_name,
+			content_type,
+			size,
+			checksum,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:entity_type,
+			:subject_entity_id,
+			:file_name,
+			:content_type,
+			:size,
+			:checksum,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	// the content of an Attachment never changes once uploaded, so only its lifecycle is updated
+	QueryUpdateAttachment = `
+		UPDATE attachments
+		SET
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+)
+
+// AttachmentMySQLRepo is the repository for Attachments implemented with MySQL backend
+type AttachmentMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *AttachmentMySQLRepo) Startup() {
+	logger.Trace("Attachment repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *AttachmentMySQLRepo) Shutdown() {
+	logger.Trace("Attachment repository shutting down...")
+}
+
+// ExistsByID checks the existence of an Attachment by its ID
+func (r *AttachmentMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM attachments WHERE attachments.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves Attachments by their IDs
+func (r *AttachmentMySQLRepo) ResolveByIDs(ids []uuid.UUID) (attachments []model.Attachment, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectAttachment+" WHERE attachments.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&attachments, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByEntity resolves the Attachments that have not been deleted and are attached to an entity
+func (r *AttachmentMySQLRepo) ResolveByEntity(entityType model.EntityType, subjectID uuid.UUID) (attachments []model.Attachment, err error) {
+	err = r.DB.Select(
+		&attachments,
+		QuerySelectAttachment+`
+		WHERE
+			attachments.entity_type = ?
+			AND attachments.subject_entity_id = ?
+			AND attachments.deleted IS NULL
+		ORDER BY attachments.created ASC`,
+		entityType,
+		subjectID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByFilter resolves Attachments by a specified filter
+func (r *AttachmentMySQLRepo) ResolveByFilter(filter filter.Filter) (attachments []model.Attachment, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return attachments, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectAttachment+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&attachments, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM attachments "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// Create creates a new Attachment
+func (r *AttachmentMySQLRepo) Create(attachment model.Attachment) error {
+	exists, err := r.ExistsByID(attachment.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Attachment", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreate(tx, attachment); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates an existing Attachment
+func (r *AttachmentMySQLRepo) Update(attachment model.Attachment) error {
+	exists, err := r.ExistsByID(attachment.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "Attachment")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdate(tx, attachment); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+func (r *AttachmentMySQLRepo) txCreate(tx *sqlx.Tx, attachment model.Attachment) error {
+	stmt, err := tx.PrepareNamed(QueryInsertAttachment)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(attachment)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeAttachment,
+		attachment.ID,
+		model.AuditActionCreate,
+		attachment.CreatedBy,
+		nil,
+		attachment.ToOutput())
+}
+
+func (r *AttachmentMySQLRepo) txUpdate(tx *sqlx.Tx, attachment model.Attachment) error {
+	var before model.Attachment
+	err := tx.Get(&before, QuerySelectAttachment+" WHERE attachments.entity_id = ? FOR UPDATE", attachment.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateAttachment)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(attachment)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, attachment.CreatedBy, attachment.UpdatedBy, attachment.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeAttachment,
+		attachment.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		attachment.ToOutput())
+}
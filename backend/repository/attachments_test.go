@@ -0,0 +1,298 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+// attachments
+var (
+	attachmentsStmtInsert = `INSERT INTO attachments
+	( entity_id, entity_type, subject_entity_id, file_name, content_type, size, checksum, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	attachmentsStmtUpdate = `
+	UPDATE attachments
+	SET updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+)
+
+var (
+	attachmentsTestNow              = time.Now()
+	attachmentsTestUserID, _        = uuid.NewV7()
+	attachmentsTestAttachmentID, _  = uuid.NewV7()
+	attachmentsTestBankAccountID, _ = uuid.NewV7()
+
+	attachmentsTestAttachmentModel = model.Attachment{
+		ID:          attachmentsTestAttachmentID,
+		EntityType:  model.EntityTypeBankAccount,
+		SubjectID:   attachmentsTestBankAccountID,
+		FileName:    "statement.pdf",
+		ContentType: "application/pdf",
+		Size:        8,
+		Checksum:    model.GetAttachmentChecksum([]byte("%PDF-1.4")),
+		Created:     attachmentsTestNow,
+		CreatedBy:   attachmentsTestUserID,
+	}
+)
+
+func TestAttachmentsRepository(t *testing.T) {
+
+	t.Run("createAttachment", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM attachments WHERE attachments.entity_id = ?").
+				WithArgs(attachmentsTestAttachmentID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(attachmentsStmtInsert).
+				ExpectExec().
+				WithArgs(
+					attachmentsTestAttachmentModel.ID,
+					attachmentsTestAttachmentModel.EntityType,
+					attachmentsTestAttachmentModel.SubjectID,
+					attachmentsTestAttachmentModel.FileName,
+					attachmentsTestAttachmentModel.ContentType,
+					attachmentsTestAttachmentModel.Size,
+					attachmentsTestAttachmentModel.Checksum,
+					attachmentsTestAttachmentModel.Created,
+					attachmentsTestAttachmentModel.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeAttachment)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.AttachmentMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(attachmentsTestAttachmentModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM attachments WHERE attachments.entity_id = ?").
+				WithArgs(attachmentsTestAttachmentID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.AttachmentMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(attachmentsTestAttachmentModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveAttachmentsByIDs", func(t *testing.T) {
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectAttachment + " WHERE attachments.entity_id IN (?)").
+				WithArgs(attachmentsTestAttachmentID).
+				WillReturnRows(getSingleEntityIDResult(attachmentsTestAttachmentID))
+
+			repo := new(repository.AttachmentMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			attachments, err := repo.ResolveByIDs([]uuid.UUID{attachmentsTestAttachmentID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, attachments, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("noIDs", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.AttachmentMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			attachments, err := repo.ResolveByIDs([]uuid.UUID{})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, attachments, 0)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveAttachmentsByEntity", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectAttachment+`
+				WHERE
+					attachments.entity_type = ?
+					AND attachments.subject_entity_id = ?
+					AND attachments.deleted IS NULL
+				ORDER BY attachments.created ASC`).
+				WithArgs(model.EntityTypeBankAccount, attachmentsTestBankAccountID.String()).
+				WillReturnRows(getSingleEntityIDResult(attachmentsTestAttachmentID))
+
+			repo := new(repository.AttachmentMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			attachments, err := repo.ResolveByEntity(model.EntityTypeBankAccount, attachmentsTestBankAccountID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, attachments, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveAttachmentsByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectAttachment+"WHERE ((attachments.file_name LIKE ?)) AND attachments.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs("%statement%", 10, 0).
+				WillReturnRows(getSingleEntityIDResult(attachmentsTestAttachmentID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM attachments WHERE ((attachments.file_name LIKE ?)) AND attachments.deleted IS NULL").
+				WithArgs("%statement%").
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.AttachmentMySQLRepo)
+			repo.DB = &db
+
+			keyword := "statement"
+			testFilter := model.AttachmentFilterInput{}
+			testFilter.Keyword = &keyword
+
+			repo.Startup()
+			attachments, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, attachments, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateAttachment", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM attachments WHERE attachments.entity_id = ?").
+				WithArgs(attachmentsTestAttachmentID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectAttachment, "attachments")
+
+			mock.
+				ExpectPrepare(attachmentsStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeAttachment)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.AttachmentMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(attachmentsTestAttachmentModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM attachments WHERE attachments.entity_id = ?").
+				WithArgs(attachmentsTestAttachmentID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.AttachmentMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(attachmentsTestAttachmentModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectAuditLog = `
+		SELECT
+			audit_logs.entity_id,
+			audit_logs.entity_type,
+			audit_logs.subject_entity_id,
+			audit_logs.action,
+			audit_logs.actor_entity_id,
+			audit_logs.snapshot_before,
+			audit_logs.snapshot_after,
+			audit_logs.created
+		FROM
+			audit_logs `
+
+	QueryInsertAuditLog = `
+		INSERT INTO audit_logs (
+			entity_id,
+			entity_type,
+			subject_entity_id,
+			action,
+			actor_entity_id,
+			snapshot_before,
+			snapshot_after,
+			created
+		) VALUES (
+			:entity_id,
+			:entity_type,
+			:subject_entity_id,
+			:action,
+			:actor_entity_id,
+			:snapshot_before,
+			:snapshot_after,
+			:created
+		)`
+)
+
+// AuditLogMySQLRepo is the repository for Audit Logs implemented with MySQL backend
+type AuditLogMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *AuditLogMySQLRepo) Startup() {
+	logger.Trace("Audit Log repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *AuditLogMySQLRepo) Shutdown() {
+	logger.Trace("Audit Log repository shutting down...")
+}
+
+// ResolveByFilter resolves Audit Logs by a specified filter, newest first
+func (r *AuditLogMySQLRepo) ResolveByFilter(filter filter.Filter) (auditLogs []model.AuditLog, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return auditLogs, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectAuditLog+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&auditLogs, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM audit_logs "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// txCreateAuditLog records a change to an entity within the transaction that performs the change,
+// so that the change and its Audit Log are either both persisted or both rolled back
+func txCreateAuditLog(tx *sqlx.Tx, entityType model.EntityType, subjectID uuid.UUID, action model.AuditAction, actorID uuid.UUID, before, after interface{}) error {
+	auditLog, err := model.NewAuditLog(entityType, subjectID, action, actorID, before, after)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryInsertAuditLog)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(auditLog)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return nil
+}
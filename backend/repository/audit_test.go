@@ -0,0 +1,138 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	auditLogTestID1, _       = uuid.NewV7()
+	auditLogTestSubjectID, _ = uuid.NewV7()
+)
+
+func TestAuditLogRepository(t *testing.T) {
+
+	t.Run("resolveByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectAuditLog+" WHERE ((audit_logs.entity_type IN (?)) AND (audit_logs.subject_entity_id IN (?))) ORDER BY audit_logs.created DESC, audit_logs.entity_id DESC LIMIT ? OFFSET ?").
+				WithArgs(model.EntityTypeVehicle, auditLogTestSubjectID, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(auditLogTestID1))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM audit_logs WHERE ((audit_logs.entity_type IN (?)) AND (audit_logs.subject_entity_id IN (?)))").
+				WithArgs(model.EntityTypeVehicle, auditLogTestSubjectID).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.AuditLogMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.AuditLogFilterInput{}
+			testFilter.EntityTypes = &[]model.EntityType{model.EntityTypeVehicle}
+			testFilter.SubjectIDs = &[]uuid.UUID{auditLogTestSubjectID}
+
+			repo.Startup()
+			auditLogs, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, auditLogs, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("noClauses", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectAuditLog+" ORDER BY audit_logs.created DESC, audit_logs.entity_id DESC LIMIT ? OFFSET ?").
+				WithArgs(10, 0).
+				WillReturnRows(getSingleEntityIDResult(auditLogTestID1))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM audit_logs").
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.AuditLogMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.AuditLogFilterInput{}
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("errorOnSelect", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectAuditLog+" ORDER BY audit_logs.created DESC, audit_logs.entity_id DESC LIMIT ? OFFSET ?").
+				WithArgs(10, 0).
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.AuditLogMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.AuditLogFilterInput{}
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("errorOnCount", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectAuditLog+" ORDER BY audit_logs.created DESC, audit_logs.entity_id DESC LIMIT ? OFFSET ?").
+				WithArgs(10, 0).
+				WillReturnRows(getSingleEntityIDResult(auditLogTestID1))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM audit_logs").
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.AuditLogMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.AuditLogFilterInput{}
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/kerti/balances/backend/database"
@@ -41,7 +43,8 @@ const (
 			bank_account_balances.updated,
 			bank_account_balances.updated_by,
 			bank_account_balances.deleted,
-			bank_account_balances.deleted_by
+			bank_account_balances.deleted_by,
+			bank_account_balances.deleted_by_cascade
 		FROM
 			bank_account_balances `
 
@@ -89,7 +92,8 @@ const (
 			updated,
 			updated_by,
 			deleted,
-			deleted_by
+			deleted_by,
+			deleted_by_cascade
 		) VALUES (
 			:entity_id,
 			:bank_account_entity_id,
@@ -100,7 +104,8 @@ const (
 			:updated,
 			:updated_by,
 			:deleted,
-			:deleted_by
+			:deleted_by,
+			:deleted_by_cascade
 		)`
 
 	QueryUpdateBankAccount = `
@@ -132,7 +137,8 @@ const (
 			updated = :updated,
 			updated_by = :updated_by,
 			deleted = :deleted,
-			deleted_by = :deleted_by
+			deleted_by = :deleted_by,
+			deleted_by_cascade = :deleted_by_cascade
 		WHERE entity_id = :entity_id`
 )
 
@@ -224,7 +230,7 @@ func (r *BankAccountMySQLRepo) ResolveByFilter(filter filter.Filter) (bankAccoun
 
 	filterArgs := filter.GetArgs(true)
 	query, args, err := r.DB.In(
-		QuerySelectBankAccount+filterQueryString+filter.Pagination.ToQueryString(),
+		QuerySelectBankAccount+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
 		filterArgs...)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -238,8 +244,7 @@ func (r *BankAccountMySQLRepo) ResolveByFilter(filter filter.Filter) (bankAccoun
 
 	var count int
 	filterArgsNoPagination := filter.GetArgs(false)
-	err = r.DB.Get(
-		&count,
+	query, args, err = r.DB.In(
 		"SELECT COUNT(entity_id) FROM bank_accounts "+filterQueryString,
 		filterArgsNoPagination...)
 	if err != nil {
@@ -247,6 +252,12 @@ func (r *BankAccountMySQLRepo) ResolveByFilter(filter filter.Filter) (bankAccoun
 		return
 	}
 
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
 	pageInfo = model.PageInfoOutput{
 		Page:       filter.Pagination.Page,
 		PageSize:   filter.Pagination.PageSize,
@@ -257,6 +268,11 @@ func (r *BankAccountMySQLRepo) ResolveByFilter(filter filter.Filter) (bankAccoun
 	return
 }
 
+// AggregateByFilter groups the Bank Accounts matching a specified filter and aggregates every group
+func (r *BankAccountMySQLRepo) AggregateByFilter(filter filter.Filter) (aggregateRows []model.AggregateRow, err error) {
+	return resolveAggregateByFilter(r.DB, filter)
+}
+
 // ResolveBalancesByFilter resolves Banks Account Balances by a specified filter
 func (r *BankAccountMySQLRepo) ResolveBalancesByFilter(filter filter.Filter) (bankAccountBalances []model.BankAccountBalance, pageInfo model.PageInfoOutput, err error) {
 	filterQueryString, err := filter.ToQueryString()
@@ -266,7 +282,7 @@ func (r *BankAccountMySQLRepo) ResolveBalancesByFilter(filter filter.Filter) (ba
 
 	filterArgs := filter.GetArgs(true)
 	query, args, err := r.DB.In(
-		QuerySelectBankAccountBalance+filterQueryString+filter.Pagination.ToQueryString(),
+		QuerySelectBankAccountBalance+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
 		filterArgs...)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -278,6 +294,15 @@ func (r *BankAccountMySQLRepo) ResolveBalancesByFilter(filter filter.Filter) (ba
 		logger.ErrNoStack("%v", err)
 	}
 
+	if filter.Pagination.IsKeyset() {
+		if err == nil {
+			bankAccountBalances, pageInfo = pageByKeyset(bankAccountBalances, filter.Pagination, func(balance model.BankAccountBalance) (time.Time, uuid.UUID) {
+				return balance.Date, balance.ID
+			})
+		}
+		return
+	}
+
 	var count int
 	filterArgsNoPagination := filter.GetArgs(false)
 	query, args, err = r.DB.In(
@@ -304,13 +329,14 @@ func (r *BankAccountMySQLRepo) ResolveBalancesByFilter(filter filter.Filter) (ba
 	return
 }
 
-// ResolveLastBalancesByBankAccountID resolves last X Bank Account Balances by their Bank Account ID and count param
+// ResolveLastBalancesByBankAccountID resolves last X Bank Account Balances by their Bank Account ID and count param,
+// ordering balances of the same date by when they were recorded
 func (r *BankAccountMySQLRepo) ResolveLastBalancesByBankAccountID(id uuid.UUID, count int) (bankAccountBalances []model.BankAccountBalance, err error) {
 	if count == 0 {
 		return
 	}
 
-	whereClause := " WHERE bank_account_balances.bank_account_entity_id = ? AND bank_account_balances.deleted IS NULL AND bank_account_balances.deleted_by IS NULL ORDER BY bank_account_balances.date DESC LIMIT ?"
+	whereClause := " WHERE bank_account_balances.bank_account_entity_id = ? AND bank_account_balances.deleted IS NULL AND bank_account_balances.deleted_by IS NULL ORDER BY bank_account_balances.date DESC, bank_account_balances.created DESC, bank_account_balances.entity_id DESC LIMIT ?"
 	query, args, err := r.DB.In(
 		QuerySelectBankAccountBalance+whereClause, id, count)
 	if err != nil {
@@ -355,7 +381,8 @@ func (r *BankAccountMySQLRepo) Create(bankAccount model.BankAccount) error {
 	})
 }
 
-// Update updates a bank account
+// Update updates a bank account along with any balances attached to it, such as those
+// cascaded by a delete or a restore
 func (r *BankAccountMySQLRepo) Update(bankAccount model.BankAccount) error {
 	exists, err := r.ExistsByID(bankAccount.ID)
 	if err != nil {
@@ -375,6 +402,13 @@ func (r *BankAccountMySQLRepo) Update(bankAccount model.BankAccount) error {
 			return
 		}
 
+		for _, balance := range bankAccount.Balances {
+			if err := r.txUpdateBankAccountBalance(tx, balance); err != nil {
+				e <- err
+				return
+			}
+		}
+
 		e <- nil
 	})
 }
@@ -410,6 +444,28 @@ func (r *BankAccountMySQLRepo) CreateBalance(bankAccountBalance model.BankAccoun
 	})
 }
 
+// ImportBalances creates imported Bank Account Balances and optionally updates the Bank Account in a single transaction,
+// so that either every Balance is imported or none is
+func (r *BankAccountMySQLRepo) ImportBalances(bankAccountBalances []model.BankAccountBalance, bankAccount *model.BankAccount) error {
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		for _, bankAccountBalance := range bankAccountBalances {
+			if err := r.txCreateBankAccountBalance(tx, bankAccountBalance); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		if bankAccount != nil {
+			if err := r.txUpdateBankAccount(tx, *bankAccount); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		e <- nil
+	})
+}
+
 // UpdateBalance updates an existing Bank Account Balance and optionally updates the Bank Account transactionally
 func (r *BankAccountMySQLRepo) UpdateBalance(bankAccountBalance model.BankAccountBalance, bankAccount *model.BankAccount) error {
 	exists, err := r.ExistsBalanceByID(bankAccountBalance.ID)
@@ -454,7 +510,14 @@ func (r *BankAccountMySQLRepo) txCreateBankAccount(tx *sqlx.Tx, bankAccount mode
 		return err
 	}
 
-	return nil
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeBankAccount,
+		bankAccount.ID,
+		model.AuditActionCreate,
+		bankAccount.CreatedBy,
+		nil,
+		r.getBankAccountSnapshot(bankAccount))
 }
 
 func (r *BankAccountMySQLRepo) txCreateBankAccountBalance(tx *sqlx.Tx, bankAccountBalance model.BankAccountBalance) error {
@@ -470,10 +533,24 @@ func (r *BankAccountMySQLRepo) txCreateBankAccountBalance(tx *sqlx.Tx, bankAccou
 		return err
 	}
 
-	return nil
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeBankAccountBalance,
+		bankAccountBalance.ID,
+		model.AuditActionCreate,
+		bankAccountBalance.CreatedBy,
+		nil,
+		bankAccountBalance.ToOutput())
 }
 
 func (r *BankAccountMySQLRepo) txUpdateBankAccount(tx *sqlx.Tx, bankAccount model.BankAccount) error {
+	var before model.BankAccount
+	err := tx.Get(&before, QuerySelectBankAccount+" WHERE bank_accounts.entity_id = ? FOR UPDATE", bankAccount.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
 	stmt, err := tx.PrepareNamed(QueryUpdateBankAccount)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -486,10 +563,25 @@ func (r *BankAccountMySQLRepo) txUpdateBankAccount(tx *sqlx.Tx, bankAccount mode
 		return err
 	}
 
-	return nil
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, bankAccount.CreatedBy, bankAccount.UpdatedBy, bankAccount.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeBankAccount,
+		bankAccount.ID,
+		action,
+		actorID,
+		r.getBankAccountSnapshot(before),
+		r.getBankAccountSnapshot(bankAccount))
 }
 
 func (r *BankAccountMySQLRepo) txUpdateBankAccountBalance(tx *sqlx.Tx, bankAccountBalance model.BankAccountBalance) error {
+	var before model.BankAccountBalance
+	err := tx.Get(&before, QuerySelectBankAccountBalance+" WHERE bank_account_balances.entity_id = ? FOR UPDATE", bankAccountBalance.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
 	stmt, err := tx.PrepareNamed(QueryUpdateBankAccountBalance)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -502,5 +594,20 @@ func (r *BankAccountMySQLRepo) txUpdateBankAccountBalance(tx *sqlx.Tx, bankAccou
 		return err
 	}
 
-	return nil
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, bankAccountBalance.CreatedBy, bankAccountBalance.UpdatedBy, bankAccountBalance.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeBankAccountBalance,
+		bankAccountBalance.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		bankAccountBalance.ToOutput())
+}
+
+// getBankAccountSnapshot produces the audited representation of a Bank Account, leaving out
+// its Balances as these are audited on their own
+func (r *BankAccountMySQLRepo) getBankAccountSnapshot(bankAccount model.BankAccount) model.BankAccountOutput {
+	bankAccount.Balances = nil
+	return bankAccount.ToOutput()
 }
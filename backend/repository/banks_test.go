@@ -1,6 +1,7 @@
 package repository_test
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/repository"
 	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,12 +37,12 @@ var (
 // bank account balances
 var (
 	bankAccountBalancesStmtInsert = `INSERT INTO bank_account_balances
-	( entity_id, bank_account_entity_id, date, balance, created, created_by, updated, updated_by, deleted, deleted_by )
-	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+	( entity_id, bank_account_entity_id, date, balance, created, created_by, updated, updated_by, deleted, deleted_by, deleted_by_cascade )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
 
 	bankAccountBalancesStmtUpdate = `
 	UPDATE bank_account_balances
-	SET bank_account_entity_id = ?, date = ?, balance = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	SET bank_account_entity_id = ?, date = ?, balance = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?, deleted_by_cascade = ?
 	WHERE entity_id = ?`
 )
 
@@ -119,6 +121,8 @@ func TestBanksRepository(t *testing.T) {
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccount)
+
 			mock.
 				ExpectPrepare(bankAccountBalancesStmtInsert).
 				ExpectExec().
@@ -133,9 +137,12 @@ func TestBanksRepository(t *testing.T) {
 					banksTestBankAccountBalanceModel1.UpdatedBy,
 					banksTestBankAccountBalanceModel1.Deleted,
 					banksTestBankAccountBalanceModel1.DeletedBy,
+					banksTestBankAccountBalanceModel1.DeletedByCascade,
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
 			mock.ExpectCommit()
 
 			repo := new(repository.BankAccountMySQLRepo)
@@ -306,6 +313,8 @@ func TestBanksRepository(t *testing.T) {
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccount)
+
 			mock.
 				ExpectPrepare(bankAccountBalancesStmtInsert).
 				WillReturnError(errors.New(""))
@@ -357,6 +366,8 @@ func TestBanksRepository(t *testing.T) {
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccount)
+
 			mock.
 				ExpectPrepare(bankAccountBalancesStmtInsert).
 				ExpectExec().
@@ -371,6 +382,7 @@ func TestBanksRepository(t *testing.T) {
 					banksTestBankAccountBalanceModel1.UpdatedBy,
 					banksTestBankAccountBalanceModel1.Deleted,
 					banksTestBankAccountBalanceModel1.DeletedBy,
+					banksTestBankAccountBalanceModel1.DeletedByCascade,
 				).
 				WillReturnError(errors.New(""))
 
@@ -418,9 +430,14 @@ func TestBanksRepository(t *testing.T) {
 					nil,
 					nil,
 					nil,
+					false,
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccount, "bank_accounts")
+
 			mock.
 				ExpectPrepare(bankAccountsStmtUpdate).
 				ExpectExec().
@@ -442,6 +459,8 @@ func TestBanksRepository(t *testing.T) {
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccount)
+
 			mock.ExpectCommit()
 
 			repo := new(repository.BankAccountMySQLRepo)
@@ -482,9 +501,12 @@ func TestBanksRepository(t *testing.T) {
 					nil,
 					nil,
 					nil,
+					false,
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
 			mock.ExpectCommit()
 
 			repo := new(repository.BankAccountMySQLRepo)
@@ -600,6 +622,7 @@ func TestBanksRepository(t *testing.T) {
 					nil,
 					nil,
 					nil,
+					false,
 				).
 				WillReturnError(errors.New(""))
 
@@ -643,9 +666,14 @@ func TestBanksRepository(t *testing.T) {
 					nil,
 					nil,
 					nil,
+					false,
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccount, "bank_accounts")
+
 			mock.
 				ExpectPrepare(bankAccountsStmtUpdate).
 				ExpectExec().
@@ -685,6 +713,159 @@ func TestBanksRepository(t *testing.T) {
 
 	})
 
+	t.Run("importBankAccountBalances", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(bankAccountBalancesStmtInsert).
+				ExpectExec().
+				WithArgs(
+					banksTestBankAccountBalanceModel1.ID,
+					banksTestBankAccountBalanceModel1.BankAccountID,
+					banksTestBankAccountBalanceModel1.Date,
+					banksTestBankAccountBalanceModel1.Balance,
+					banksTestBankAccountBalanceModel1.Created,
+					banksTestBankAccountBalanceModel1.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+					false,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
+			mock.
+				ExpectPrepare(bankAccountBalancesStmtInsert).
+				ExpectExec().
+				WithArgs(
+					banksTestBankAccountBalanceModel2.ID,
+					banksTestBankAccountBalanceModel2.BankAccountID,
+					banksTestBankAccountBalanceModel2.Date,
+					banksTestBankAccountBalanceModel2.Balance,
+					banksTestBankAccountBalanceModel2.Created,
+					banksTestBankAccountBalanceModel2.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+					false,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccount, "bank_accounts")
+
+			mock.
+				ExpectPrepare(bankAccountsStmtUpdate).
+				ExpectExec().
+				WithArgs(
+					banksTestBankAccountModel.AccountName,
+					banksTestBankAccountModel.BankName,
+					banksTestBankAccountModel.AccountHolderName,
+					banksTestBankAccountModel.AccountNumber,
+					banksTestBankAccountModel.LastBalance,
+					banksTestBankAccountModel.LastBalanceDate,
+					banksTestBankAccountModel.Status,
+					banksTestBankAccountModel.Created,
+					banksTestBankAccountModel.CreatedBy,
+					banksTestBankAccountModel.Updated,
+					banksTestBankAccountModel.UpdatedBy,
+					banksTestBankAccountModel.Deleted,
+					banksTestBankAccountModel.DeletedBy,
+					banksTestBankAccountModel.ID,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeBankAccount)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.ImportBalances(
+				[]model.BankAccountBalance{banksTestBankAccountBalanceModel1, banksTestBankAccountBalanceModel2},
+				&banksTestBankAccountModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnExecRollsBackEveryBalance", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(bankAccountBalancesStmtInsert).
+				ExpectExec().
+				WithArgs(
+					banksTestBankAccountBalanceModel1.ID,
+					banksTestBankAccountBalanceModel1.BankAccountID,
+					banksTestBankAccountBalanceModel1.Date,
+					banksTestBankAccountBalanceModel1.Balance,
+					banksTestBankAccountBalanceModel1.Created,
+					banksTestBankAccountBalanceModel1.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+					false,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
+			mock.
+				ExpectPrepare(bankAccountBalancesStmtInsert).
+				ExpectExec().
+				WithArgs(
+					banksTestBankAccountBalanceModel2.ID,
+					banksTestBankAccountBalanceModel2.BankAccountID,
+					banksTestBankAccountBalanceModel2.Date,
+					banksTestBankAccountBalanceModel2.Balance,
+					banksTestBankAccountBalanceModel2.Created,
+					banksTestBankAccountBalanceModel2.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+					false,
+				).
+				WillReturnError(errors.New(""))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.ImportBalances(
+				[]model.BankAccountBalance{banksTestBankAccountBalanceModel1, banksTestBankAccountBalanceModel2},
+				&banksTestBankAccountModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
 	t.Run("existsBankAccountByID", func(t *testing.T) {
 
 		t.Run("normal", func(t *testing.T) {
@@ -903,6 +1084,186 @@ func TestBanksRepository(t *testing.T) {
 			assert.Nil(t, errMockExpectationsMet)
 		})
 
+		t.Run("normalWithTags", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			tagClause := "WHERE ((bank_accounts.entity_id IN (" + model.QuerySelectTaggedEntityIDs + "(?, ?)))) AND bank_accounts.deleted IS NULL"
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccount+tagClause+" LIMIT ? OFFSET ?").
+				WithArgs(model.EntityTypeBankAccount, "joint", "business", 10, 0).
+				WillReturnRows(getSingleEntityIDResult(banksTestAccountID1))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM bank_accounts "+tagClause).
+				WithArgs(model.EntityTypeBankAccount, "joint", "business").
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.BankAccountFilterInput{Tags: &[]string{"joint", "business"}}
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("normalWithSort", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccount+"WHERE bank_accounts.deleted IS NULL ORDER BY bank_accounts.bank_name ASC, bank_accounts.last_balance DESC LIMIT ? OFFSET ?").
+				WithArgs(10, 0).
+				WillReturnRows(getSingleEntityIDResult(banksTestAccountID1))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM bank_accounts WHERE bank_accounts.deleted IS NULL").
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.BankAccountFilterInput{}
+			testFilter.Sort = &[]filter.SortInput{
+				{Field: "bankName"},
+				{Field: "lastBalance", Direction: filter.SortDirectionDesc},
+			}
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("normalWithQuery", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccount+"WHERE (((bank_accounts.bank_name IN (?, ?)) OR ((bank_accounts.last_balance BETWEEN ? AND ?) AND (NOT (bank_accounts.updated IS NULL))))) AND bank_accounts.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs("ACME", "Globex", float64(100), float64(200), 10, 0).
+				WillReturnRows(getSingleEntityIDResult(banksTestAccountID1))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM bank_accounts WHERE (((bank_accounts.bank_name IN (?, ?)) OR ((bank_accounts.last_balance BETWEEN ? AND ?) AND (NOT (bank_accounts.updated IS NULL))))) AND bank_accounts.deleted IS NULL").
+				WithArgs("ACME", "Globex", float64(100), float64(200)).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.BankAccountFilterInput{}
+			err := json.Unmarshal([]byte(`{"query": {"or": [
+				{"field": "bankName", "op": "in", "value": ["ACME", "Globex"]},
+				{"and": [
+					{"field": "lastBalance", "op": "between", "value": [100, 200]},
+					{"not": {"field": "updated", "op": "isnull"}}
+				]}
+			]}}`), &testFilter)
+			assert.Nil(t, err)
+
+			repo.Startup()
+			_, _, err = repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("invalidQuery", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			for _, query := range []string{
+				`{"field": "bank_accounts.bank_name", "op": "eq", "value": "ACME"}`,
+				`{"field": "bankName", "op": "regexp", "value": "ACME"}`,
+				`{"field": "bankName", "op": "eq", "value": ["ACME"]}`,
+				`{"field": "bankName", "op": "between", "value": [1]}`,
+				`{"field": "bankName", "op": "in", "value": []}`,
+				`{"field": "bankName", "op": "isnull", "value": "ACME"}`,
+				`{"and": []}`,
+				`{"field": "bankName", "op": "eq", "value": "ACME", "or": [{"field": "status", "op": "eq", "value": "active"}]}`,
+				`{"not": {"not": {"not": {"not": {"not": {"not": {"not": {"not": {"field": "status", "op": "isnull"}}}}}}}}}`,
+			} {
+				testFilter := model.BankAccountFilterInput{}
+				testFilter.Query = &filter.Expression{}
+				assert.Nil(t, json.Unmarshal([]byte(query), testFilter.Query))
+
+				repo.Startup()
+				_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+				repo.Shutdown()
+
+				assert.NotNil(t, err, query)
+				assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err), query)
+			}
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("invalidSortField", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.BankAccountFilterInput{}
+			testFilter.Sort = &[]filter.SortInput{
+				{Field: "bank_name; DROP TABLE bank_accounts"},
+			}
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("invalidSortDirection", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.BankAccountFilterInput{}
+			testFilter.Sort = &[]filter.SortInput{
+				{Field: "bankName", Direction: "sideways"},
+			}
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
 		t.Run("errorOnSelect", func(t *testing.T) {
 			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
 
@@ -966,6 +1327,113 @@ func TestBanksRepository(t *testing.T) {
 
 	})
 
+	t.Run("aggregateBankAccountByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT bank_accounts.bank_name, bank_accounts.status, SUM(bank_accounts.last_balance), COUNT(*) FROM bank_accounts WHERE ((bank_accounts.status = ?)) AND bank_accounts.deleted IS NULL GROUP BY bank_accounts.bank_name, bank_accounts.status ORDER BY bank_accounts.bank_name, bank_accounts.status").
+				WithArgs("active").
+				WillReturnRows(sqlmock.NewRows([]string{"bank_name", "status", "SUM", "COUNT"}).
+					AddRow([]byte("ACME"), []byte("active"), []byte("1500.50"), int64(2)).
+					AddRow([]byte("Globex"), []byte("active"), []byte("200.00"), int64(1)))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			field := "lastBalance"
+			testFilter := model.BankAccountFilterInput{}
+			testFilter.Query = &filter.Expression{}
+			assert.Nil(t, json.Unmarshal([]byte(`{"field": "status", "op": "eq", "value": "active"}`), testFilter.Query))
+			testFilter.Aggregation = &filter.AggregationInput{
+				GroupBy: []string{"bankName", "status"},
+				Aggregates: []filter.AggregateInput{
+					{Function: filter.AggregateFunctionSum, Field: &field},
+					{Function: filter.AggregateFunctionCount},
+				},
+			}
+
+			repo.Startup()
+			res, err := repo.AggregateByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Equal(t, []model.AggregateRow{
+				{
+					Group:  map[string]interface{}{"bankName": "ACME", "status": "active"},
+					Values: map[string]interface{}{"sumLastBalance": 1500.5, "count": int64(2)},
+				},
+				{
+					Group:  map[string]interface{}{"bankName": "Globex", "status": "active"},
+					Values: map[string]interface{}{"sumLastBalance": float64(200), "count": int64(1)},
+				},
+			}, res)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("invalidAggregation", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			field := "accountNumber; DROP TABLE bank_accounts"
+			for _, aggregation := range []filter.AggregationInput{
+				{Aggregates: []filter.AggregateInput{}},
+				{Aggregates: []filter.AggregateInput{{Function: "median", Field: &field}}},
+				{Aggregates: []filter.AggregateInput{{Function: filter.AggregateFunctionSum, Field: &field}}},
+				{Aggregates: []filter.AggregateInput{{Function: filter.AggregateFunctionSum}}},
+				{GroupBy: []string{"bank_name"}, Aggregates: []filter.AggregateInput{{Function: filter.AggregateFunctionCount}}},
+			} {
+				testFilter := model.BankAccountFilterInput{}
+				testFilter.Aggregation = &aggregation
+
+				repo.Startup()
+				_, err := repo.AggregateByFilter(testFilter.ToFilter())
+				repo.Shutdown()
+
+				assert.NotNil(t, err)
+				assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+			}
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("errorOnSelect", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT bank_accounts.status, COUNT(*) FROM bank_accounts WHERE bank_accounts.deleted IS NULL GROUP BY bank_accounts.status ORDER BY bank_accounts.status").
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.BankAccountFilterInput{}
+			testFilter.Aggregation = &filter.AggregationInput{
+				GroupBy:    []string{"status"},
+				Aggregates: []filter.AggregateInput{{Function: filter.AggregateFunctionCount}},
+			}
+
+			repo.Startup()
+			_, err := repo.AggregateByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
 	t.Run("resolveBankAccountBalancesByIDs", func(t *testing.T) {
 
 		t.Run("normalNoID", func(t *testing.T) {
@@ -1082,6 +1550,137 @@ func TestBanksRepository(t *testing.T) {
 			assert.Nil(t, errMockExpectationsMet)
 		})
 
+		t.Run("normalWithSort", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccountBalance+"WHERE ((bank_account_balances.bank_account_entity_id IN (?))) AND bank_account_balances.deleted IS NULL ORDER BY bank_account_balances.date DESC LIMIT ? OFFSET ?").
+				WithArgs(banksTestAccountID1, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(banksTestAccountBalanceID1))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM bank_account_balances WHERE ((bank_account_balances.bank_account_entity_id IN (?))) AND bank_account_balances.deleted IS NULL").
+				WithArgs(banksTestAccountID1).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.BankAccountBalanceFilterInput{}
+			testFilter.BankAccountIDs = &[]uuid.UUID{banksTestAccountID1}
+			testFilter.Sort = &[]filter.SortInput{
+				{Field: "date", Direction: "DESC"},
+			}
+
+			repo.Startup()
+			_, _, err := repo.ResolveBalancesByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("normalCursorFirstPage", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccountBalance+"WHERE ((bank_account_balances.bank_account_entity_id IN (?))) AND bank_account_balances.deleted IS NULL ORDER BY bank_account_balances.date DESC, bank_account_balances.entity_id DESC LIMIT ?").
+				WithArgs(banksTestAccountID1, 2).
+				WillReturnRows(getMultiEntityIDResult([]uuid.UUID{banksTestAccountBalanceID2, banksTestAccountBalanceID1}))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			pageSize := 1
+			paginationMode := filter.PaginationModeCursor
+			testFilter := model.BankAccountBalanceFilterInput{}
+			testFilter.BankAccountIDs = &[]uuid.UUID{banksTestAccountID1}
+			testFilter.PageSize = &pageSize
+			testFilter.PaginationMode = &paginationMode
+
+			repo.Startup()
+			res, pageInfo, err := repo.ResolveBalancesByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, res, 1)
+			assert.Equal(t, banksTestAccountBalanceID2, res[0].ID)
+			assert.Nil(t, pageInfo.PrevCursor)
+			assert.NotNil(t, pageInfo.NextCursor)
+
+			nextCursor, err := filter.DecodeCursor(*pageInfo.NextCursor)
+			assert.Nil(t, err)
+			assert.Equal(t, banksTestAccountBalanceID2, nextCursor.ID)
+			assert.Equal(t, filter.CursorDirectionNext, nextCursor.Direction)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("normalCursorPrevPage", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccountBalance+"WHERE (((bank_account_balances.bank_account_entity_id IN (?)) AND ((bank_account_balances.date > ?) OR ((bank_account_balances.date = ?) AND (bank_account_balances.entity_id > ?))))) AND bank_account_balances.deleted IS NULL ORDER BY bank_account_balances.date ASC, bank_account_balances.entity_id ASC LIMIT ?").
+				WithArgs(banksTestAccountID1, sqlmock.AnyArg(), sqlmock.AnyArg(), banksTestAccountBalanceID2, 3).
+				WillReturnRows(getMultiEntityIDResult([]uuid.UUID{banksTestAccountBalanceID1, banksTestAccountBalanceID2}))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			pageSize := 2
+			cursor := filter.Cursor{
+				Date:      banksTestYesterday,
+				ID:        banksTestAccountBalanceID2,
+				Direction: filter.CursorDirectionPrev,
+			}.Encode()
+			testFilter := model.BankAccountBalanceFilterInput{}
+			testFilter.BankAccountIDs = &[]uuid.UUID{banksTestAccountID1}
+			testFilter.PageSize = &pageSize
+			testFilter.Cursor = &cursor
+
+			repo.Startup()
+			res, pageInfo, err := repo.ResolveBalancesByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, res, 2)
+			assert.Equal(t, banksTestAccountBalanceID2, res[0].ID)
+			assert.Equal(t, banksTestAccountBalanceID1, res[1].ID)
+			assert.Nil(t, pageInfo.PrevCursor)
+			assert.NotNil(t, pageInfo.NextCursor)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("invalidCursor", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			cursor := "not a cursor"
+			testFilter := model.BankAccountBalanceFilterInput{}
+			testFilter.Cursor = &cursor
+
+			repo.Startup()
+			_, _, err := repo.ResolveBalancesByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
 		t.Run("errorOnSelect", func(t *testing.T) {
 			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
 
@@ -1145,7 +1744,7 @@ func TestBanksRepository(t *testing.T) {
 			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
 
 			mock.
-				ExpectQuery(repository.QuerySelectBankAccountBalance+"WHERE bank_account_balances.bank_account_entity_id = ? AND bank_account_balances.deleted IS NULL AND bank_account_balances.deleted_by IS NULL ORDER BY bank_account_balances.date DESC LIMIT ?").
+				ExpectQuery(repository.QuerySelectBankAccountBalance+"WHERE bank_account_balances.bank_account_entity_id = ? AND bank_account_balances.deleted IS NULL AND bank_account_balances.deleted_by IS NULL ORDER BY bank_account_balances.date DESC, bank_account_balances.created DESC, bank_account_balances.entity_id DESC LIMIT ?").
 				WithArgs(banksTestAccountID1, 2).
 				WillReturnRows(getMultiEntityIDResult([]uuid.UUID{banksTestAccountBalanceID2, banksTestAccountBalanceID1}))
 
@@ -1186,7 +1785,7 @@ func TestBanksRepository(t *testing.T) {
 			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
 
 			mock.
-				ExpectQuery(repository.QuerySelectBankAccountBalance+"WHERE bank_account_balances.bank_account_entity_id = ? AND bank_account_balances.deleted IS NULL AND bank_account_balances.deleted_by IS NULL ORDER BY bank_account_balances.date DESC LIMIT ?").
+				ExpectQuery(repository.QuerySelectBankAccountBalance+"WHERE bank_account_balances.bank_account_entity_id = ? AND bank_account_balances.deleted IS NULL AND bank_account_balances.deleted_by IS NULL ORDER BY bank_account_balances.date DESC, bank_account_balances.created DESC, bank_account_balances.entity_id DESC LIMIT ?").
 				WithArgs(banksTestAccountID1, count).
 				WillReturnError(errors.New(""))
 
@@ -1218,6 +1817,8 @@ func TestBanksRepository(t *testing.T) {
 
 			mock.ExpectBegin()
 
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccount, "bank_accounts")
+
 			mock.
 				ExpectPrepare(bankAccountsStmtUpdate).
 				ExpectExec().
@@ -1239,6 +1840,19 @@ func TestBanksRepository(t *testing.T) {
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccount)
+
+			for range banksTestBankAccountModel.Balances {
+				expectSelectForUpdate(mock, repository.QuerySelectBankAccountBalance, "bank_account_balances")
+
+				mock.
+					ExpectPrepare(bankAccountBalancesStmtUpdate).
+					ExpectExec().
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+			}
+
 			mock.ExpectCommit()
 
 			repo := new(repository.BankAccountMySQLRepo)
@@ -1309,6 +1923,8 @@ func TestBanksRepository(t *testing.T) {
 
 			mock.ExpectBegin()
 
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccount, "bank_accounts")
+
 			mock.
 				ExpectPrepare(bankAccountsStmtUpdate).
 				WillReturnError(errors.New(""))
@@ -1339,6 +1955,8 @@ func TestBanksRepository(t *testing.T) {
 
 			mock.ExpectBegin()
 
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccount, "bank_accounts")
+
 			mock.
 				ExpectPrepare(bankAccountsStmtUpdate).
 				ExpectExec().
@@ -1390,18 +2008,26 @@ func TestBanksRepository(t *testing.T) {
 
 			mock.ExpectBegin()
 
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccountBalance, "bank_account_balances")
+
 			mock.
 				ExpectPrepare(bankAccountBalancesStmtUpdate).
 				ExpectExec().
 				WithArgs().
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccount, "bank_accounts")
+
 			mock.
 				ExpectPrepare(bankAccountsStmtUpdate).
 				ExpectExec().
 				WithArgs().
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccount)
+
 			mock.ExpectCommit()
 
 			repo := new(repository.BankAccountMySQLRepo)
@@ -1428,12 +2054,16 @@ func TestBanksRepository(t *testing.T) {
 
 			mock.ExpectBegin()
 
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccountBalance, "bank_account_balances")
+
 			mock.
 				ExpectPrepare(bankAccountBalancesStmtUpdate).
 				ExpectExec().
 				WithArgs().
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
 			mock.ExpectCommit()
 
 			repo := new(repository.BankAccountMySQLRepo)
@@ -1504,6 +2134,8 @@ func TestBanksRepository(t *testing.T) {
 
 			mock.ExpectBegin()
 
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccountBalance, "bank_account_balances")
+
 			mock.
 				ExpectPrepare(bankAccountBalancesStmtUpdate).
 				WillReturnError(errors.New(""))
@@ -1534,6 +2166,8 @@ func TestBanksRepository(t *testing.T) {
 
 			mock.ExpectBegin()
 
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccountBalance, "bank_account_balances")
+
 			mock.
 				ExpectPrepare(bankAccountBalancesStmtUpdate).
 				ExpectExec().
@@ -1566,12 +2200,18 @@ func TestBanksRepository(t *testing.T) {
 
 			mock.ExpectBegin()
 
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccountBalance, "bank_account_balances")
+
 			mock.
 				ExpectPrepare(bankAccountBalancesStmtUpdate).
 				ExpectExec().
 				WithArgs().
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccount, "bank_accounts")
+
 			mock.
 				ExpectPrepare(bankAccountsStmtUpdate).
 				ExpectExec().
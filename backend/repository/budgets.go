@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectBudget = `
+		SELECT
+			budgets.entity_id,
+			budgets.category_entity_id,
+			budgets.month,
+			budgets.amount,
+			budgets.created,
+			budgets.created_by,
+			budgets.updated,
+			budgets.updated_by,
+			budgets.deleted,
+			budgets.deleted_by
+		FROM
+			budgets `
+
+	QueryInsertBudget = `
+		INSERT INTO budgets (
+			entity_id,
+			category_entity_id,
+			month,
+			amount,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:category_entity_id,
+			:month,
+			:amount,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateBudget = `
+		UPDATE budgets
+		SET
+			category_entity_id = :category_entity_id,
+			month = :month,
+			amount = :amount,
+			created = :created,
+			created_by = :created_by,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+)
+
+// BudgetMySQLRepo is the repository for Budgets implemented with MySQL backend
+type BudgetMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *BudgetMySQLRepo) Startup() {
+	logger.Trace("Budget repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *BudgetMySQLRepo) Shutdown() {
+	logger.Trace("Budget repository shutting down...")
+}
+
+// ExistsByID checks the existence of a Budget by its ID
+func (r *BudgetMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM budgets WHERE budgets.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves Budgets by their IDs
+func (r *BudgetMySQLRepo) ResolveByIDs(ids []uuid.UUID) (budgets []model.Budget, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectBudget+" WHERE budgets.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&budgets, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByFilter resolves Budgets by a specified filter
+func (r *BudgetMySQLRepo) ResolveByFilter(filter filter.Filter) (budgets []model.Budget, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return budgets, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectBudget+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&budgets, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM budgets "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// Create creates a new Budget
+func (r *BudgetMySQLRepo) Create(budget model.Budget) error {
+	exists, err := r.ExistsByID(budget.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Budget", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreate(tx, budget); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates an existing Budget
+func (r *BudgetMySQLRepo) Update(budget model.Budget) error {
+	exists, err := r.ExistsByID(budget.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "Budget")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdate(tx, budget); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+func (r *BudgetMySQLRepo) txCreate(tx *sqlx.Tx, budget model.Budget) error {
+	stmt, err := tx.PrepareNamed(QueryInsertBudget)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(budget)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeBudget,
+		budget.ID,
+		model.AuditActionCreate,
+		budget.CreatedBy,
+		nil,
+		budget.ToOutput())
+}
+
+func (r *BudgetMySQLRepo) txUpdate(tx *sqlx.Tx, budget model.Budget) error {
+	var before model.Budget
+	err := tx.Get(&before, QuerySelectBudget+" WHERE budgets.entity_id = ? FOR UPDATE", budget.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateBudget)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(budget)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, budget.CreatedBy, budget.UpdatedBy, budget.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeBudget,
+		budget.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		budget.ToOutput())
+}
@@ -0,0 +1,267 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+// budgets
+var (
+	budgetsStmtInsert = `INSERT INTO budgets
+	( entity_id, category_entity_id, month, amount, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	budgetsStmtUpdate = `
+	UPDATE budgets
+	SET category_entity_id = ?, month = ?, amount = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+)
+
+var (
+	budgetsTestNow           = time.Now()
+	budgetsTestMonth         = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.Local)
+	budgetsTestUserID, _     = uuid.NewV7()
+	budgetsTestCategoryID, _ = uuid.NewV7()
+	budgetsTestBudgetID, _   = uuid.NewV7()
+
+	budgetsTestBudgetModel = model.Budget{
+		ID:         budgetsTestBudgetID,
+		CategoryID: budgetsTestCategoryID,
+		Month:      budgetsTestMonth,
+		Amount:     float64(500),
+		Created:    budgetsTestNow,
+		CreatedBy:  budgetsTestUserID,
+	}
+)
+
+func TestBudgetsRepository(t *testing.T) {
+
+	t.Run("createBudget", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM budgets WHERE budgets.entity_id = ?").
+				WithArgs(budgetsTestBudgetID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(budgetsStmtInsert).
+				ExpectExec().
+				WithArgs(
+					budgetsTestBudgetModel.ID,
+					budgetsTestBudgetModel.CategoryID,
+					budgetsTestBudgetModel.Month,
+					budgetsTestBudgetModel.Amount,
+					budgetsTestBudgetModel.Created,
+					budgetsTestBudgetModel.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeBudget)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.BudgetMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(budgetsTestBudgetModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM budgets WHERE budgets.entity_id = ?").
+				WithArgs(budgetsTestBudgetID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.BudgetMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(budgetsTestBudgetModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveBudgetsByIDs", func(t *testing.T) {
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectBudget + " WHERE budgets.entity_id IN (?)").
+				WithArgs(budgetsTestBudgetID).
+				WillReturnRows(getSingleEntityIDResult(budgetsTestBudgetID))
+
+			repo := new(repository.BudgetMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			budgets, err := repo.ResolveByIDs([]uuid.UUID{budgetsTestBudgetID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, budgets, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveBudgetsByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectBudget+"WHERE (((budgets.category_entity_id IN (?)) AND (budgets.month >= ?))) AND budgets.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs(budgetsTestCategoryID, budgetsTestMonth, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(budgetsTestBudgetID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM budgets WHERE (((budgets.category_entity_id IN (?)) AND (budgets.month >= ?))) AND budgets.deleted IS NULL").
+				WithArgs(budgetsTestCategoryID, budgetsTestMonth).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.BudgetMySQLRepo)
+			repo.DB = &db
+
+			startMonth := "2024-03"
+			testFilter := model.BudgetFilterInput{}
+			testFilter.CategoryIDs = &[]uuid.UUID{budgetsTestCategoryID}
+			testFilter.StartMonth = &startMonth
+
+			repo.Startup()
+			budgets, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, budgets, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("invalidMonth", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.BudgetMySQLRepo)
+			repo.DB = &db
+
+			startMonth := "March 2024"
+			testFilter := model.BudgetFilterInput{}
+			testFilter.StartMonth = &startMonth
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateBudget", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM budgets WHERE budgets.entity_id = ?").
+				WithArgs(budgetsTestBudgetID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectBudget, "budgets")
+
+			mock.
+				ExpectPrepare(budgetsStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeBudget)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.BudgetMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(budgetsTestBudgetModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM budgets WHERE budgets.entity_id = ?").
+				WithArgs(budgetsTestBudgetID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.BudgetMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(budgetsTestBudgetModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectBankAccountCashFlow = `
+		SELECT
+			bank_account_cash_flows.entity_id,
+			bank_account_cash_flows.bank_account_entity_id,
+			bank_account_cash_flows.date,
+			bank_account_cash_flows.type,
+			bank_account_cash_flows.amount,
+			bank_account_cash_flows.note,
+			bank_account_cash_flows.created,
+			bank_account_cash_flows.created_by,
+			bank_account_cash_flows.updated,
+			bank_account_cash_flows.updated_by,
+			bank_account_cash_flows.deleted,
+			bank_account_cash_flows.deleted_by
+		FROM
+			bank_account_cash_flows `
+
+	QueryInsertBankAccountCashFlow = `
+		INSERT INTO bank_account_cash_flows (
+			entity_id,
+			bank_account_entity_id,
+			date,
+			type,
+			amount,
+			note,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:bank_account_entity_id,
+			:date,
+			:type,
+			:amount,
+			:note,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateBankAccountCashFlow = `
+		UPDATE bank_account_cash_flows
+		SET
+			bank_account_entity_id = :bank_account_entity_id,
+			date = :date,
+			type = :type,
+			amount = :amount,
+			note = :note,
+			created = :created,
+			created_by = :created_by,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+)
+
+// ExistsCashFlowByID checks the existence of a Bank Account Cash Flow by its ID
+func (r *BankAccountMySQLRepo) ExistsCashFlowByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM bank_account_cash_flows WHERE bank_account_cash_flows.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveCashFlowsByIDs resolves Bank Account Cash Flows by their IDs
+func (r *BankAccountMySQLRepo) ResolveCashFlowsByIDs(ids []uuid.UUID) (bankAccountCashFlows []model.BankAccountCashFlow, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectBankAccountCashFlow+" WHERE bank_account_cash_flows.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&bankAccountCashFlows, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveCashFlowsByFilter resolves Bank Account Cash Flows by a specified filter
+func (r *BankAccountMySQLRepo) ResolveCashFlowsByFilter(filter filter.Filter) (bankAccountCashFlows []model.BankAccountCashFlow, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return bankAccountCashFlows, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectBankAccountCashFlow+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&bankAccountCashFlows, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	if filter.Pagination.IsKeyset() {
+		if err == nil {
+			bankAccountCashFlows, pageInfo = pageByKeyset(bankAccountCashFlows, filter.Pagination, func(cashFlow model.BankAccountCashFlow) (time.Time, uuid.UUID) {
+				return cashFlow.Date, cashFlow.ID
+			})
+		}
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM bank_account_cash_flows "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// CreateCashFlow creates a new Bank Account Cash Flow
+func (r *BankAccountMySQLRepo) CreateCashFlow(bankAccountCashFlow model.BankAccountCashFlow) error {
+	exists, err := r.ExistsCashFlowByID(bankAccountCashFlow.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Bank Account Cash Flow", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreateBankAccountCashFlow(tx, bankAccountCashFlow); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// UpdateCashFlow updates an existing Bank Account Cash Flow
+func (r *BankAccountMySQLRepo) UpdateCashFlow(bankAccountCashFlow model.BankAccountCashFlow) error {
+	exists, err := r.ExistsCashFlowByID(bankAccountCashFlow.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update cash flow", "Bank Account Cash Flow")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdateBankAccountCashFlow(tx, bankAccountCashFlow); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+func (r *BankAccountMySQLRepo) txCreateBankAccountCashFlow(tx *sqlx.Tx, bankAccountCashFlow model.BankAccountCashFlow) error {
+	stmt, err := tx.PrepareNamed(QueryInsertBankAccountCashFlow)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(bankAccountCashFlow)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeBankAccountCashFlow,
+		bankAccountCashFlow.ID,
+		model.AuditActionCreate,
+		bankAccountCashFlow.CreatedBy,
+		nil,
+		bankAccountCashFlow.ToOutput())
+}
+
+func (r *BankAccountMySQLRepo) txUpdateBankAccountCashFlow(tx *sqlx.Tx, bankAccountCashFlow model.BankAccountCashFlow) error {
+	var before model.BankAccountCashFlow
+	err := tx.Get(&before, QuerySelectBankAccountCashFlow+" WHERE bank_account_cash_flows.entity_id = ? FOR UPDATE", bankAccountCashFlow.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateBankAccountCashFlow)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(bankAccountCashFlow)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, bankAccountCashFlow.CreatedBy, bankAccountCashFlow.UpdatedBy, bankAccountCashFlow.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeBankAccountCashFlow,
+		bankAccountCashFlow.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		bankAccountCashFlow.ToOutput())
+}
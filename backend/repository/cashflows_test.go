@@ -0,0 +1,343 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+// bank account cash flows
+var (
+	bankAccountCashFlowsStmtInsert = `INSERT INTO bank_account_cash_flows
+	( entity_id, bank_account_entity_id, date, type, amount, note, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	bankAccountCashFlowsStmtUpdate = `
+	UPDATE bank_account_cash_flows
+	SET bank_account_entity_id = ?, date = ?, type = ?, amount = ?, note = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+)
+
+var (
+	cashFlowsTestNow           = time.Now()
+	cashFlowsTestUserID, _     = uuid.NewV7()
+	cashFlowsTestAccountID, _  = uuid.NewV7()
+	cashFlowsTestCashFlowID, _ = uuid.NewV7()
+
+	cashFlowsTestCashFlowModel = model.BankAccountCashFlow{
+		ID:            cashFlowsTestCashFlowID,
+		BankAccountID: cashFlowsTestAccountID,
+		Date:          cashFlowsTestNow,
+		Type:          model.BankAccountCashFlowTypeDeposit,
+		Amount:        float64(500000),
+		Note:          "salary",
+		Created:       cashFlowsTestNow,
+		CreatedBy:     cashFlowsTestUserID,
+	}
+)
+
+func TestCashFlowsRepository(t *testing.T) {
+
+	t.Run("createBankAccountCashFlow", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM bank_account_cash_flows WHERE bank_account_cash_flows.entity_id = ?").
+				WithArgs(cashFlowsTestCashFlowID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(bankAccountCashFlowsStmtInsert).
+				ExpectExec().
+				WithArgs(
+					cashFlowsTestCashFlowModel.ID,
+					cashFlowsTestCashFlowModel.BankAccountID,
+					cashFlowsTestCashFlowModel.Date,
+					cashFlowsTestCashFlowModel.Type,
+					cashFlowsTestCashFlowModel.Amount,
+					cashFlowsTestCashFlowModel.Note,
+					cashFlowsTestCashFlowModel.Created,
+					cashFlowsTestCashFlowModel.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeBankAccountCashFlow)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.CreateCashFlow(cashFlowsTestCashFlowModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM bank_account_cash_flows WHERE bank_account_cash_flows.entity_id = ?").
+				WithArgs(cashFlowsTestCashFlowID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.CreateCashFlow(cashFlowsTestCashFlowModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnExec", func(t *testing.T) {
+			errMsg := "cannot insert cash flow"
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM bank_account_cash_flows WHERE bank_account_cash_flows.entity_id = ?").
+				WithArgs(cashFlowsTestCashFlowID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(bankAccountCashFlowsStmtInsert).
+				ExpectExec().
+				WillReturnError(errors.New(errMsg))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.CreateCashFlow(cashFlowsTestCashFlowModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), errMsg)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveBankAccountCashFlowsByIDs", func(t *testing.T) {
+
+		t.Run("normalNoID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			_, err := repo.ResolveCashFlowsByIDs([]uuid.UUID{})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectBankAccountCashFlow + " WHERE bank_account_cash_flows.entity_id IN (?)").
+				WithArgs(cashFlowsTestCashFlowID).
+				WillReturnRows(getSingleEntityIDResult(cashFlowsTestCashFlowID))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			cashFlows, err := repo.ResolveCashFlowsByIDs([]uuid.UUID{cashFlowsTestCashFlowID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, cashFlows, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("errorExecutingSelect", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectBankAccountCashFlow + " WHERE bank_account_cash_flows.entity_id IN (?)").
+				WithArgs(cashFlowsTestCashFlowID).
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			_, err := repo.ResolveCashFlowsByIDs([]uuid.UUID{cashFlowsTestCashFlowID})
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveBankAccountCashFlowsByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccountCashFlow+"WHERE ((bank_account_cash_flows.bank_account_entity_id IN (?))) AND bank_account_cash_flows.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs(cashFlowsTestAccountID, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(cashFlowsTestCashFlowID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM bank_account_cash_flows WHERE ((bank_account_cash_flows.bank_account_entity_id IN (?))) AND bank_account_cash_flows.deleted IS NULL").
+				WithArgs(cashFlowsTestAccountID).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.BankAccountCashFlowFilterInput{}
+			testFilter.BankAccountIDs = &[]uuid.UUID{cashFlowsTestAccountID}
+
+			repo.Startup()
+			cashFlows, pageInfo, err := repo.ResolveCashFlowsByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, cashFlows, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("errorOnSelect", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccountCashFlow+"WHERE ((bank_account_cash_flows.bank_account_entity_id IN (?))) AND bank_account_cash_flows.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs(cashFlowsTestAccountID, 10, 0).
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.BankAccountCashFlowFilterInput{}
+			testFilter.BankAccountIDs = &[]uuid.UUID{cashFlowsTestAccountID}
+
+			repo.Startup()
+			_, _, err := repo.ResolveCashFlowsByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateBankAccountCashFlow", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM bank_account_cash_flows WHERE bank_account_cash_flows.entity_id = ?").
+				WithArgs(cashFlowsTestCashFlowID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccountCashFlow, "bank_account_cash_flows")
+
+			mock.
+				ExpectPrepare(bankAccountCashFlowsStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeBankAccountCashFlow)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.UpdateCashFlow(cashFlowsTestCashFlowModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM bank_account_cash_flows WHERE bank_account_cash_flows.entity_id = ?").
+				WithArgs(cashFlowsTestCashFlowID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.BankAccountMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.UpdateCashFlow(cashFlowsTestCashFlowModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
@@ -0,0 +1,526 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectCategory = `
+		SELECT
+			categories.entity_id,
+			categories.parent_entity_id,
+			categories.name,
+			categories.created,
+			categories.created_by,
+			categories.updated,
+			categories.updated_by,
+			categories.deleted,
+			categories.deleted_by
+		FROM
+			categories `
+
+	QueryInsertCategory = `
+		INSERT INTO categories (
+			entity_id,
+			parent_entity_id,
+			name,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:parent_entity_id,
+			:name,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateCategory = `
+		UPDATE categories
+		SET
+			parent_entity_id = :parent_entity_id,
+			name = :name,
+			created = :created,
+			created_by = :created_by,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+
+	QuerySelectCategoryRule = `
+		SELECT
+			category_rules.entity_id,
+			category_rules.category_entity_id,
+			category_rules.pattern,
+			category_rules.priority,
+			category_rules.created,
+			category_rules.created_by,
+			category_rules.updated,
+			category_rules.updated_by,
+			category_rules.deleted,
+			category_rules.deleted_by
+		FROM
+			category_rules `
+
+	QueryInsertCategoryRule = `
+		INSERT INTO category_rules (
+			entity_id,
+			category_entity_id,
+			pattern,
+			priority,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:category_entity_id,
+			:pattern,
+			:priority,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateCategoryRule = `
+		UPDATE category_rules
+		SET
+			category_entity_id = :category_entity_id,
+			pattern = :pattern,
+			priority = :priority,
+			created = :created,
+			created_by = :created_by,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+)
+
+// CategoryMySQLRepo is the repository for Categories implemented with MySQL backend
+type CategoryMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *CategoryMySQLRepo) Startup() {
+	logger.Trace("Category repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *CategoryMySQLRepo) Shutdown() {
+	logger.Trace("Category repository shutting down...")
+}
+
+// ExistsByID checks the existence of a Category by its ID
+func (r *CategoryMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM categories WHERE categories.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves Categories by their IDs
+func (r *CategoryMySQLRepo) ResolveByIDs(ids []uuid.UUID) (categories []model.Category, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectCategory+" WHERE categories.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&categories, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByFilter resolves Categories by a specified filter
+func (r *CategoryMySQLRepo) ResolveByFilter(filter filter.Filter) (categories []model.Category, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return categories, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectCategory+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&categories, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM categories "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// Create creates a new Category
+func (r *CategoryMySQLRepo) Create(category model.Category) error {
+	exists, err := r.ExistsByID(category.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Category", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreate(tx, category); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates an existing Category, along with the Transactions attached to it when it is renamed,
+// all in a single database transaction
+func (r *CategoryMySQLRepo) Update(category model.Category) error {
+	exists, err := r.ExistsByID(category.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "Category")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	transactionRepo := TransactionMySQLRepo{DB: r.DB}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdate(tx, category); err != nil {
+			e <- err
+			return
+		}
+
+		for _, transaction := range category.Transactions {
+			if err := transactionRepo.txUpdate(tx, transaction); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		e <- nil
+	})
+}
+
+// Categorize files Transactions under the categories given to them, all in a single database transaction
+func (r *CategoryMySQLRepo) Categorize(transactions []model.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	transactionRepo := TransactionMySQLRepo{DB: r.DB}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		for _, transaction := range transactions {
+			if err := transactionRepo.txUpdate(tx, transaction); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		e <- nil
+	})
+}
+
+func (r *CategoryMySQLRepo) txCreate(tx *sqlx.Tx, category model.Category) error {
+	stmt, err := tx.PrepareNamed(QueryInsertCategory)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(category)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeCategory,
+		category.ID,
+		model.AuditActionCreate,
+		category.CreatedBy,
+		nil,
+		category.ToOutput())
+}
+
+func (r *CategoryMySQLRepo) txUpdate(tx *sqlx.Tx, category model.Category) error {
+	var before model.Category
+	err := tx.Get(&before, QuerySelectCategory+" WHERE categories.entity_id = ? FOR UPDATE", category.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateCategory)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(category)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, category.CreatedBy, category.UpdatedBy, category.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeCategory,
+		category.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		category.ToOutput())
+}
+
+// ExistsRuleByID checks the existence of a Category Rule by its ID
+func (r *CategoryMySQLRepo) ExistsRuleByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM category_rules WHERE category_rules.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveRulesByIDs resolves Category Rules by their IDs
+func (r *CategoryMySQLRepo) ResolveRulesByIDs(ids []uuid.UUID) (categoryRules []model.CategoryRule, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectCategoryRule+" WHERE category_rules.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&categoryRules, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveRulesByFilter resolves Category Rules by a specified filter
+func (r *CategoryMySQLRepo) ResolveRulesByFilter(filter filter.Filter) (categoryRules []model.CategoryRule, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return categoryRules, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectCategoryRule+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&categoryRules, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM category_rules "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// CreateRule creates a new Category Rule
+func (r *CategoryMySQLRepo) CreateRule(categoryRule model.CategoryRule) error {
+	exists, err := r.ExistsRuleByID(categoryRule.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Category Rule", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreateCategoryRule(tx, categoryRule); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// UpdateRule updates an existing Category Rule
+func (r *CategoryMySQLRepo) UpdateRule(categoryRule model.CategoryRule) error {
+	exists, err := r.ExistsRuleByID(categoryRule.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update rule", "Category Rule")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdateCategoryRule(tx, categoryRule); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+func (r *CategoryMySQLRepo) txCreateCategoryRule(tx *sqlx.Tx, categoryRule model.CategoryRule) error {
+	stmt, err := tx.PrepareNamed(QueryInsertCategoryRule)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(categoryRule)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeCategoryRule,
+		categoryRule.ID,
+		model.AuditActionCreate,
+		categoryRule.CreatedBy,
+		nil,
+		categoryRule.ToOutput())
+}
+
+func (r *CategoryMySQLRepo) txUpdateCategoryRule(tx *sqlx.Tx, categoryRule model.CategoryRule) error {
+	var before model.CategoryRule
+	err := tx.Get(&before, QuerySelectCategoryRule+" WHERE category_rules.entity_id = ? FOR UPDATE", categoryRule.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateCategoryRule)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(categoryRule)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, categoryRule.CreatedBy, categoryRule.UpdatedBy, categoryRule.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeCategoryRule,
+		categoryRule.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		categoryRule.ToOutput())
+}
@@ -0,0 +1,561 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// categories
+var (
+	categoriesStmtInsert = `INSERT INTO categories
+	( entity_id, parent_entity_id, name, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	categoriesStmtUpdate = `
+	UPDATE categories
+	SET parent_entity_id = ?, name = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+)
+
+// category rules
+var (
+	categoryRulesStmtInsert = `INSERT INTO category_rules
+	( entity_id, category_entity_id, pattern, priority, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	categoryRulesStmtUpdate = `
+	UPDATE category_rules
+	SET category_entity_id = ?, pattern = ?, priority = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+)
+
+var (
+	categoriesTestNow           = time.Now()
+	categoriesTestUserID, _     = uuid.NewV7()
+	categoriesTestParentID, _   = uuid.NewV7()
+	categoriesTestCategoryID, _ = uuid.NewV7()
+	categoriesTestRuleID, _     = uuid.NewV7()
+
+	categoriesTestCategoryModel = model.Category{
+		ID:        categoriesTestCategoryID,
+		ParentID:  nuuid.From(categoriesTestParentID),
+		Name:      "Groceries",
+		Created:   categoriesTestNow,
+		CreatedBy: categoriesTestUserID,
+	}
+
+	categoriesTestRuleModel = model.CategoryRule{
+		ID:         categoriesTestRuleID,
+		CategoryID: categoriesTestCategoryID,
+		Pattern:    "*grocer*",
+		Priority:   1,
+		Created:    categoriesTestNow,
+		CreatedBy:  categoriesTestUserID,
+	}
+)
+
+func TestCategoriesRepository(t *testing.T) {
+
+	t.Run("createCategory", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM categories WHERE categories.entity_id = ?").
+				WithArgs(categoriesTestCategoryID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(categoriesStmtInsert).
+				ExpectExec().
+				WithArgs(
+					categoriesTestCategoryModel.ID,
+					categoriesTestCategoryModel.ParentID,
+					categoriesTestCategoryModel.Name,
+					categoriesTestCategoryModel.Created,
+					categoriesTestCategoryModel.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeCategory)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(categoriesTestCategoryModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM categories WHERE categories.entity_id = ?").
+				WithArgs(categoriesTestCategoryID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(categoriesTestCategoryModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveCategoriesByIDs", func(t *testing.T) {
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectCategory + " WHERE categories.entity_id IN (?)").
+				WithArgs(categoriesTestCategoryID).
+				WillReturnRows(getSingleEntityIDResult(categoriesTestCategoryID))
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			categories, err := repo.ResolveByIDs([]uuid.UUID{categoriesTestCategoryID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, categories, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveCategoriesByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectCategory+"WHERE ((categories.parent_entity_id IN (?))) AND categories.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs(categoriesTestParentID, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(categoriesTestCategoryID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM categories WHERE ((categories.parent_entity_id IN (?))) AND categories.deleted IS NULL").
+				WithArgs(categoriesTestParentID).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.CategoryFilterInput{}
+			testFilter.ParentIDs = &[]uuid.UUID{categoriesTestParentID}
+
+			repo.Startup()
+			categories, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, categories, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateCategory", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM categories WHERE categories.entity_id = ?").
+				WithArgs(categoriesTestCategoryID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectCategory, "categories")
+
+			mock.
+				ExpectPrepare(categoriesStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeCategory)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(categoriesTestCategoryModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("renamedWithTransactions", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			category := categoriesTestCategoryModel
+			category.Transactions = []model.Transaction{transactionsTestTransactionModel}
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM categories WHERE categories.entity_id = ?").
+				WithArgs(categoriesTestCategoryID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectCategory, "categories")
+
+			mock.
+				ExpectPrepare(categoriesStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeCategory)
+
+			expectSelectForUpdate(mock, repository.QuerySelectTransaction, "transactions")
+
+			mock.
+				ExpectPrepare(transactionsStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTransaction)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(category)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnUpdatingTransaction", func(t *testing.T) {
+			errMsg := "cannot update transaction"
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			category := categoriesTestCategoryModel
+			category.Transactions = []model.Transaction{transactionsTestTransactionModel}
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM categories WHERE categories.entity_id = ?").
+				WithArgs(categoriesTestCategoryID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectCategory, "categories")
+
+			mock.
+				ExpectPrepare(categoriesStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeCategory)
+
+			expectSelectForUpdate(mock, repository.QuerySelectTransaction, "transactions")
+
+			mock.
+				ExpectPrepare(transactionsStmtUpdate).
+				ExpectExec().
+				WillReturnError(errors.New(errMsg))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(category)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), errMsg)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM categories WHERE categories.entity_id = ?").
+				WithArgs(categoriesTestCategoryID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(categoriesTestCategoryModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("createCategoryRule", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM category_rules WHERE category_rules.entity_id = ?").
+				WithArgs(categoriesTestRuleID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(categoryRulesStmtInsert).
+				ExpectExec().
+				WithArgs(
+					categoriesTestRuleModel.ID,
+					categoriesTestRuleModel.CategoryID,
+					categoriesTestRuleModel.Pattern,
+					categoriesTestRuleModel.Priority,
+					categoriesTestRuleModel.Created,
+					categoriesTestRuleModel.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeCategoryRule)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.CreateRule(categoriesTestRuleModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveCategoryRulesByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectCategoryRule+"WHERE ((category_rules.category_entity_id IN (?))) AND category_rules.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs(categoriesTestCategoryID, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(categoriesTestRuleID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM category_rules WHERE ((category_rules.category_entity_id IN (?))) AND category_rules.deleted IS NULL").
+				WithArgs(categoriesTestCategoryID).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.CategoryRuleFilterInput{}
+			testFilter.CategoryIDs = &[]uuid.UUID{categoriesTestCategoryID}
+
+			repo.Startup()
+			categoryRules, pageInfo, err := repo.ResolveRulesByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, categoryRules, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateCategoryRule", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM category_rules WHERE category_rules.entity_id = ?").
+				WithArgs(categoriesTestRuleID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectCategoryRule, "category_rules")
+
+			mock.
+				ExpectPrepare(categoryRulesStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeCategoryRule)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.UpdateRule(categoriesTestRuleModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM category_rules WHERE category_rules.entity_id = ?").
+				WithArgs(categoriesTestRuleID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.UpdateRule(categoriesTestRuleModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("categorize", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectTransaction, "transactions")
+
+			mock.
+				ExpectPrepare(transactionsStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTransaction)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Categorize([]model.Transaction{transactionsTestTransactionModel})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("nothingToCategorize", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.CategoryMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Categorize([]model.Transaction{})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
@@ -0,0 +1,345 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectCustomField = `
+		SELECT
+			custom_fields.entity_id,
+			custom_fields.entity_type,
+			custom_fields.name,
+			custom_fields.field_type,
+			custom_fields.created,
+			custom_fields.created_by,
+			custom_fields.updated,
+			custom_fields.updated_by,
+			custom_fields.deleted,
+			custom_fields.deleted_by
+		FROM
+			custom_fields `
+
+	QueryInsertCustomField = `
+		INSERT INTO custom_fields (
+			entity_id,
+			entity_type,
+			name,
+			field_type,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:entity_type,
+			:name,
+			:field_type,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateCustomField = `
+		UPDATE custom_fields
+		SET
+			entity_type = :entity_type,
+			name = :name,
+			field_type = :field_type,
+			created = :created,
+			created_by = :created_by,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+
+	QuerySelectCustomFieldValue = `
+		SELECT
+			custom_field_values.custom_field_entity_id,
+			custom_field_values.subject_entity_id,
+			custom_field_values.value
+		FROM
+			custom_field_values `
+
+	QueryInsertCustomFieldValue = `
+		INSERT INTO custom_field_values (
+			custom_field_entity_id,
+			subject_entity_id,
+			value
+		) VALUES (
+			:custom_field_entity_id,
+			:subject_entity_id,
+			:value
+		)`
+
+	// values for Custom Fields that have been deleted are kept, so that they come back if the Custom Field is restored
+	QueryDeleteCustomFieldValues = `
+		DELETE FROM custom_field_values
+		WHERE
+			custom_field_values.subject_entity_id = ?
+			AND custom_field_values.custom_field_entity_id IN (
+				SELECT custom_fields.entity_id FROM custom_fields WHERE custom_fields.entity_type = ? AND custom_fields.deleted IS NULL
+			)`
+)
+
+// CustomFieldMySQLRepo is the repository for Custom Fields implemented with MySQL backend
+type CustomFieldMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *CustomFieldMySQLRepo) Startup() {
+	logger.Trace("Custom Field repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *CustomFieldMySQLRepo) Shutdown() {
+	logger.Trace("Custom Field repository shutting down...")
+}
+
+// ExistsByID checks the existence of a Custom Field by its ID
+func (r *CustomFieldMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM custom_fields WHERE custom_fields.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves Custom Fields by their IDs
+func (r *CustomFieldMySQLRepo) ResolveByIDs(ids []uuid.UUID) (customFields []model.CustomField, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectCustomField+" WHERE custom_fields.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&customFields, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByFilter resolves Custom Fields by a specified filter
+func (r *CustomFieldMySQLRepo) ResolveByFilter(filter filter.Filter) (customFields []model.CustomField, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return customFields, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectCustomField+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&customFields, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM custom_fields "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// ResolveValuesBySubjectID resolves the values an entity has for Custom Fields that have not been deleted
+func (r *CustomFieldMySQLRepo) ResolveValuesBySubjectID(subjectID uuid.UUID) (values []model.CustomFieldValue, err error) {
+	err = r.DB.Select(
+		&values,
+		QuerySelectCustomFieldValue+`
+		WHERE
+			custom_field_values.subject_entity_id = ?
+			AND custom_field_values.custom_field_entity_id IN (
+				SELECT custom_fields.entity_id FROM custom_fields WHERE custom_fields.deleted IS NULL
+			)`,
+		subjectID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// Create creates a new Custom Field
+func (r *CustomFieldMySQLRepo) Create(customField model.CustomField) error {
+	exists, err := r.ExistsByID(customField.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Custom Field", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreate(tx, customField); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates an existing Custom Field
+func (r *CustomFieldMySQLRepo) Update(customField model.CustomField) error {
+	exists, err := r.ExistsByID(customField.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "Custom Field")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdate(tx, customField); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// SetValues replaces the values an entity has for the Custom Fields of its type
+func (r *CustomFieldMySQLRepo) SetValues(entityType model.EntityType, subjectID uuid.UUID, values []model.CustomFieldValue) error {
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		_, err := tx.Exec(QueryDeleteCustomFieldValues, subjectID.String(), entityType)
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			e <- err
+			return
+		}
+
+		if len(values) == 0 {
+			e <- nil
+			return
+		}
+
+		stmt, err := tx.PrepareNamed(QueryInsertCustomFieldValue)
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			e <- err
+			return
+		}
+
+		for _, value := range values {
+			_, err = stmt.Exec(value)
+			if err != nil {
+				logger.ErrNoStack("%v", err)
+				e <- err
+				return
+			}
+		}
+
+		e <- nil
+	})
+}
+
+func (r *CustomFieldMySQLRepo) txCreate(tx *sqlx.Tx, customField model.CustomField) error {
+	stmt, err := tx.PrepareNamed(QueryInsertCustomField)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(customField)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeCustomField,
+		customField.ID,
+		model.AuditActionCreate,
+		customField.CreatedBy,
+		nil,
+		customField.ToOutput())
+}
+
+func (r *CustomFieldMySQLRepo) txUpdate(tx *sqlx.Tx, customField model.CustomField) error {
+	var before model.CustomField
+	err := tx.Get(&before, QuerySelectCustomField+" WHERE custom_fields.entity_id = ? FOR UPDATE", customField.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateCustomField)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(customField)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, customField.CreatedBy, customField.UpdatedBy, customField.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeCustomField,
+		customField.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		customField.ToOutput())
+}
@@ -0,0 +1,316 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+// custom fields
+var (
+	customFieldsStmtInsert = `INSERT INTO custom_fields
+	( entity_id, entity_type, name, field_type, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	customFieldsStmtUpdate = `
+	UPDATE custom_fields
+	SET entity_type = ?, name = ?, field_type = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+
+	customFieldValuesStmtInsert = `INSERT INTO custom_field_values
+	( custom_field_entity_id, subject_entity_id, value )
+	VALUES ( ?, ?, ? )`
+)
+
+var (
+	customFieldsTestNow          = time.Now()
+	customFieldsTestUserID, _    = uuid.NewV7()
+	customFieldsTestFieldID, _   = uuid.NewV7()
+	customFieldsTestVehicleID, _ = uuid.NewV7()
+	customFieldsTestCustomField  = model.CustomField{
+		ID:         customFieldsTestFieldID,
+		EntityType: model.EntityTypeVehicle,
+		Name:       "Insurance Expiry",
+		Type:       model.CustomFieldTypeDate,
+		Created:    customFieldsTestNow,
+		CreatedBy:  customFieldsTestUserID,
+	}
+)
+
+func TestCustomFieldsRepository(t *testing.T) {
+
+	t.Run("createCustomField", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM custom_fields WHERE custom_fields.entity_id = ?").
+				WithArgs(customFieldsTestFieldID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(customFieldsStmtInsert).
+				ExpectExec().
+				WithArgs(
+					customFieldsTestCustomField.ID,
+					customFieldsTestCustomField.EntityType,
+					customFieldsTestCustomField.Name,
+					customFieldsTestCustomField.Type,
+					customFieldsTestCustomField.Created,
+					customFieldsTestCustomField.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeCustomField)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.CustomFieldMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(customFieldsTestCustomField)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM custom_fields WHERE custom_fields.entity_id = ?").
+				WithArgs(customFieldsTestFieldID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.CustomFieldMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(customFieldsTestCustomField)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveCustomFieldsByIDs", func(t *testing.T) {
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectCustomField + " WHERE custom_fields.entity_id IN (?)").
+				WithArgs(customFieldsTestFieldID).
+				WillReturnRows(getSingleEntityIDResult(customFieldsTestFieldID))
+
+			repo := new(repository.CustomFieldMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			customFields, err := repo.ResolveByIDs([]uuid.UUID{customFieldsTestFieldID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, customFields, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveCustomFieldsByFilter", func(t *testing.T) {
+
+		t.Run("normalWithEntityTypes", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectCustomField+"WHERE ((custom_fields.entity_type IN (?))) AND custom_fields.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs(model.EntityTypeVehicle, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(customFieldsTestFieldID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM custom_fields WHERE ((custom_fields.entity_type IN (?))) AND custom_fields.deleted IS NULL").
+				WithArgs(model.EntityTypeVehicle).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.CustomFieldMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.CustomFieldFilterInput{EntityTypes: &[]model.EntityType{model.EntityTypeVehicle}}
+
+			repo.Startup()
+			customFields, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, customFields, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveCustomFieldValuesBySubjectID", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectCustomFieldValue + `
+				WHERE
+					custom_field_values.subject_entity_id = ?
+					AND custom_field_values.custom_field_entity_id IN (
+						SELECT custom_fields.entity_id FROM custom_fields WHERE custom_fields.deleted IS NULL
+					)`).
+				WithArgs(customFieldsTestVehicleID.String()).
+				WillReturnRows(
+					sqlmock.NewRows([]string{"custom_field_entity_id", "subject_entity_id", "value"}).
+						AddRow(customFieldsTestFieldID, customFieldsTestVehicleID, "2026-12-31"))
+
+			repo := new(repository.CustomFieldMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			values, err := repo.ResolveValuesBySubjectID(customFieldsTestVehicleID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, values, 1)
+			assert.Equal(t, "2026-12-31", values[0].Value)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateCustomField", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM custom_fields WHERE custom_fields.entity_id = ?").
+				WithArgs(customFieldsTestFieldID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectCustomField, "custom_fields")
+
+			mock.
+				ExpectPrepare(customFieldsStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeCustomField)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.CustomFieldMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(customFieldsTestCustomField)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM custom_fields WHERE custom_fields.entity_id = ?").
+				WithArgs(customFieldsTestFieldID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.CustomFieldMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(customFieldsTestCustomField)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("setCustomFieldValues", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectExec(repository.QueryDeleteCustomFieldValues).
+				WithArgs(customFieldsTestVehicleID.String(), model.EntityTypeVehicle).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			mock.
+				ExpectPrepare(customFieldValuesStmtInsert).
+				ExpectExec().
+				WithArgs(customFieldsTestFieldID, customFieldsTestVehicleID, "2026-12-31").
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			mock.ExpectCommit()
+
+			repo := new(repository.CustomFieldMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.SetValues(model.EntityTypeVehicle, customFieldsTestVehicleID, []model.CustomFieldValue{
+				{CustomFieldID: customFieldsTestFieldID, SubjectID: customFieldsTestVehicleID, Value: "2026-12-31"},
+			})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
@@ -0,0 +1,389 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectGoal = `
+		SELECT
+			goals.entity_id,
+			goals.name,
+			goals.target_amount,
+			goals.target_date,
+			goals.created,
+			goals.created_by,
+			goals.updated,
+			goals.updated_by,
+			goals.deleted,
+			goals.deleted_by
+		FROM
+			goals `
+
+	QueryInsertGoal = `
+		INSERT INTO goals (
+			entity_id,
+			name,
+			target_amount,
+			target_date,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:name,
+			:target_amount,
+			:target_date,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateGoal = `
+		UPDATE goals
+		SET
+			name = :name,
+			target_amount = :target_amount,
+			target_date = :target_date,
+			created = :created,
+			created_by = :created_by,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+
+	QuerySelectGoalBankAccount = `
+		SELECT
+			goal_bank_accounts.goal_entity_id,
+			goal_bank_accounts.bank_account_entity_id
+		FROM
+			goal_bank_accounts `
+
+	QueryInsertGoalBankAccount = `
+		INSERT INTO goal_bank_accounts (
+			goal_entity_id,
+			bank_account_entity_id
+		) VALUES (
+			:goal_entity_id,
+			:bank_account_entity_id
+		)`
+
+	QueryDeleteGoalBankAccounts = `
+		DELETE FROM goal_bank_accounts WHERE goal_bank_accounts.goal_entity_id = ?`
+)
+
+// GoalMySQLRepo is the repository for Goals implemented with MySQL backend
+type GoalMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *GoalMySQLRepo) Startup() {
+	logger.Trace("Goal repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *GoalMySQLRepo) Shutdown() {
+	logger.Trace("Goal repository shutting down...")
+}
+
+// ExistsByID checks the existence of a Goal by its ID
+func (r *GoalMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM goals WHERE goals.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves Goals by their IDs, along with the Bank Accounts linked to them
+func (r *GoalMySQLRepo) ResolveByIDs(ids []uuid.UUID) (goals []model.Goal, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectGoal+" WHERE goals.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&goals, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.attachBankAccounts(goals)
+	return
+}
+
+// ResolveByBankAccountID resolves the Goals that have not been deleted and are funded by a Bank Account,
+// along with the Bank Accounts linked to them
+func (r *GoalMySQLRepo) ResolveByBankAccountID(id uuid.UUID) (goals []model.Goal, err error) {
+	err = r.DB.Select(
+		&goals,
+		QuerySelectGoal+`
+		WHERE
+			goals.entity_id IN (
+				SELECT goal_bank_accounts.goal_entity_id FROM goal_bank_accounts WHERE goal_bank_accounts.bank_account_entity_id = ?
+			)
+			AND goals.deleted IS NULL
+		ORDER BY goals.target_date ASC`,
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.attachBankAccounts(goals)
+	return
+}
+
+// ResolveByFilter resolves Goals by a specified filter, along with the Bank Accounts linked to them
+func (r *GoalMySQLRepo) ResolveByFilter(filter filter.Filter) (goals []model.Goal, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return goals, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectGoal+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&goals, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.attachBankAccounts(goals)
+	if err != nil {
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM goals "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// Create creates a new Goal along with its links to the Bank Accounts funding it
+func (r *GoalMySQLRepo) Create(goal model.Goal) error {
+	exists, err := r.ExistsByID(goal.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Goal", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreate(tx, goal); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates an existing Goal, replacing its links to the Bank Accounts funding it
+func (r *GoalMySQLRepo) Update(goal model.Goal) error {
+	exists, err := r.ExistsByID(goal.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "Goal")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdate(tx, goal); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// attachBankAccounts attaches the IDs of the Bank Accounts linked to a set of Goals
+func (r *GoalMySQLRepo) attachBankAccounts(goals []model.Goal) error {
+	if len(goals) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(goals))
+	for _, goal := range goals {
+		ids = append(ids, goal.ID)
+	}
+
+	query, args, err := r.DB.In(QuerySelectGoalBankAccount+" WHERE goal_bank_accounts.goal_entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	var links []model.GoalBankAccount
+	err = r.DB.Select(&links, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	for i := range goals {
+		goals[i].AttachBankAccounts(links, true)
+	}
+
+	return nil
+}
+
+func (r *GoalMySQLRepo) txCreate(tx *sqlx.Tx, goal model.Goal) error {
+	stmt, err := tx.PrepareNamed(QueryInsertGoal)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(goal)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	err = r.txCreateBankAccountLinks(tx, goal)
+	if err != nil {
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeGoal,
+		goal.ID,
+		model.AuditActionCreate,
+		goal.CreatedBy,
+		nil,
+		goal.ToOutput())
+}
+
+func (r *GoalMySQLRepo) txUpdate(tx *sqlx.Tx, goal model.Goal) error {
+	var before model.Goal
+	err := tx.Get(&before, QuerySelectGoal+" WHERE goals.entity_id = ? FOR UPDATE", goal.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	var links []model.GoalBankAccount
+	err = tx.Select(&links, QuerySelectGoalBankAccount+" WHERE goal_bank_accounts.goal_entity_id = ?", goal.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+	before.AttachBankAccounts(links, true)
+
+	stmt, err := tx.PrepareNamed(QueryUpdateGoal)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(goal)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = tx.Exec(QueryDeleteGoalBankAccounts, goal.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	err = r.txCreateBankAccountLinks(tx, goal)
+	if err != nil {
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, goal.CreatedBy, goal.UpdatedBy, goal.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeGoal,
+		goal.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		goal.ToOutput())
+}
+
+func (r *GoalMySQLRepo) txCreateBankAccountLinks(tx *sqlx.Tx, goal model.Goal) error {
+	if len(goal.BankAccountIDs) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareNamed(QueryInsertGoalBankAccount)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	for _, bankAccountID := range goal.BankAccountIDs {
+		_, err = stmt.Exec(model.GoalBankAccount{GoalID: goal.ID, BankAccountID: bankAccountID})
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,349 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+// goals
+var (
+	goalsStmtInsert = `INSERT INTO goals
+	( entity_id, name, target_amount, target_date, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	goalsStmtUpdate = `
+	UPDATE goals
+	SET name = ?, target_amount = ?, target_date = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+
+	goalBankAccountsStmtInsert = `INSERT INTO goal_bank_accounts
+	( goal_entity_id, bank_account_entity_id )
+	VALUES ( ?, ? )`
+)
+
+var (
+	goalsTestNow              = time.Now()
+	goalsTestTargetDate       = time.Date(2026, time.December, 31, 0, 0, 0, 0, time.Local)
+	goalsTestUserID, _        = uuid.NewV7()
+	goalsTestGoalID, _        = uuid.NewV7()
+	goalsTestBankAccountID, _ = uuid.NewV7()
+
+	goalsTestGoalModel = model.Goal{
+		ID:             goalsTestGoalID,
+		Name:           "Emergency Fund",
+		TargetAmount:   float64(10000),
+		TargetDate:     goalsTestTargetDate,
+		Created:        goalsTestNow,
+		CreatedBy:      goalsTestUserID,
+		BankAccountIDs: []uuid.UUID{goalsTestBankAccountID},
+	}
+)
+
+func getGoalBankAccountResult(goalID, bankAccountID uuid.UUID) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"goal_entity_id", "bank_account_entity_id"}).AddRow(goalID, bankAccountID)
+}
+
+func TestGoalsRepository(t *testing.T) {
+
+	t.Run("createGoal", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM goals WHERE goals.entity_id = ?").
+				WithArgs(goalsTestGoalID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(goalsStmtInsert).
+				ExpectExec().
+				WithArgs(
+					goalsTestGoalModel.ID,
+					goalsTestGoalModel.Name,
+					goalsTestGoalModel.TargetAmount,
+					goalsTestGoalModel.TargetDate,
+					goalsTestGoalModel.Created,
+					goalsTestGoalModel.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			mock.
+				ExpectPrepare(goalBankAccountsStmtInsert).
+				ExpectExec().
+				WithArgs(goalsTestGoalID, goalsTestBankAccountID).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeGoal)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.GoalMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(goalsTestGoalModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM goals WHERE goals.entity_id = ?").
+				WithArgs(goalsTestGoalID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.GoalMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(goalsTestGoalModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveGoalsByIDs", func(t *testing.T) {
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectGoal + " WHERE goals.entity_id IN (?)").
+				WithArgs(goalsTestGoalID).
+				WillReturnRows(getSingleEntityIDResult(goalsTestGoalID))
+
+			mock.ExpectQuery(repository.QuerySelectGoalBankAccount + " WHERE goal_bank_accounts.goal_entity_id IN (?)").
+				WithArgs(goalsTestGoalID).
+				WillReturnRows(getGoalBankAccountResult(goalsTestGoalID, goalsTestBankAccountID))
+
+			repo := new(repository.GoalMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			goals, err := repo.ResolveByIDs([]uuid.UUID{goalsTestGoalID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, goals, 1)
+			assert.Equal(t, []uuid.UUID{goalsTestBankAccountID}, goals[0].BankAccountIDs)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveGoalsByBankAccountID", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectGoal + `
+				WHERE
+					goals.entity_id IN (
+						SELECT goal_bank_accounts.goal_entity_id FROM goal_bank_accounts WHERE goal_bank_accounts.bank_account_entity_id = ?
+					)
+					AND goals.deleted IS NULL
+				ORDER BY goals.target_date ASC`).
+				WithArgs(goalsTestBankAccountID.String()).
+				WillReturnRows(getSingleEntityIDResult(goalsTestGoalID))
+
+			mock.ExpectQuery(repository.QuerySelectGoalBankAccount + " WHERE goal_bank_accounts.goal_entity_id IN (?)").
+				WithArgs(goalsTestGoalID).
+				WillReturnRows(getGoalBankAccountResult(goalsTestGoalID, goalsTestBankAccountID))
+
+			repo := new(repository.GoalMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			goals, err := repo.ResolveByBankAccountID(goalsTestBankAccountID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, goals, 1)
+			assert.Equal(t, []uuid.UUID{goalsTestBankAccountID}, goals[0].BankAccountIDs)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("none", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectGoal + `
+				WHERE
+					goals.entity_id IN (
+						SELECT goal_bank_accounts.goal_entity_id FROM goal_bank_accounts WHERE goal_bank_accounts.bank_account_entity_id = ?
+					)
+					AND goals.deleted IS NULL
+				ORDER BY goals.target_date ASC`).
+				WithArgs(goalsTestBankAccountID.String()).
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			repo := new(repository.GoalMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			goals, err := repo.ResolveByBankAccountID(goalsTestBankAccountID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, goals, 0)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveGoalsByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectGoal+"WHERE ((goals.name LIKE ?)) AND goals.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs("%fund%", 10, 0).
+				WillReturnRows(getSingleEntityIDResult(goalsTestGoalID))
+
+			mock.ExpectQuery(repository.QuerySelectGoalBankAccount + " WHERE goal_bank_accounts.goal_entity_id IN (?)").
+				WithArgs(goalsTestGoalID).
+				WillReturnRows(getGoalBankAccountResult(goalsTestGoalID, goalsTestBankAccountID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM goals WHERE ((goals.name LIKE ?)) AND goals.deleted IS NULL").
+				WithArgs("%fund%").
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.GoalMySQLRepo)
+			repo.DB = &db
+
+			keyword := "fund"
+			testFilter := model.GoalFilterInput{}
+			testFilter.Keyword = &keyword
+
+			repo.Startup()
+			goals, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, goals, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateGoal", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM goals WHERE goals.entity_id = ?").
+				WithArgs(goalsTestGoalID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectGoal, "goals")
+
+			mock.
+				ExpectQuery(repository.QuerySelectGoalBankAccount + " WHERE goal_bank_accounts.goal_entity_id = ?").
+				WithArgs(goalsTestGoalID.String()).
+				WillReturnRows(getGoalBankAccountResult(goalsTestGoalID, goalsTestBankAccountID))
+
+			mock.
+				ExpectPrepare(goalsStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			mock.
+				ExpectExec(repository.QueryDeleteGoalBankAccounts).
+				WithArgs(goalsTestGoalID.String()).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			mock.
+				ExpectPrepare(goalBankAccountsStmtInsert).
+				ExpectExec().
+				WithArgs(goalsTestGoalID, goalsTestBankAccountID).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeGoal)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.GoalMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(goalsTestGoalModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM goals WHERE goals.entity_id = ?").
+				WithArgs(goalsTestGoalID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.GoalMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(goalsTestGoalModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
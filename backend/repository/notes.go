@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectNote = `
+		SELECT
+			notes.entity_id,
+			notes.entity_type,
+			notes.subject_entity_id,
+			notes.content,
+			notes.created,
+			notes.created_by,
+			notes.updated,
+			notes.updated_by,
+			notes.deleted,
+			notes.deleted_by
+		FROM
+			notes `
+
+	QueryInsertNote = `
+		INSERT INTO notes (
+			entity_id,
+			entity_type,
+			subject_entity_id,
+			content,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:entity_type,
+			:subject_entity_id,
+			:content,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateNote = `
+		UPDATE notes
+		SET
+			content = :content,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+)
+
+// NoteMySQLRepo is the repository for Notes implemented with MySQL backend
+type NoteMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *NoteMySQLRepo) Startup() {
+	logger.Trace("Note repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *NoteMySQLRepo) Shutdown() {
+	logger.Trace("Note repository shutting down...")
+}
+
+// ExistsByID checks the existence of a Note by its ID
+func (r *NoteMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM notes WHERE notes.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves Notes by their IDs
+func (r *NoteMySQLRepo) ResolveByIDs(ids []uuid.UUID) (notes []model.Note, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectNote+" WHERE notes.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&notes, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByEntity resolves the Notes that have not been deleted and are written on an entity, oldest first
+func (r *NoteMySQLRepo) ResolveByEntity(entityType model.EntityType, subjectID uuid.UUID) (notes []model.Note, err error) {
+	err = r.DB.Select(
+		&notes,
+		QuerySelectNote+`
+		WHERE
+			notes.entity_type = ?
+			AND notes.subject_entity_id = ?
+			AND notes.deleted IS NULL
+		ORDER BY notes.created ASC`,
+		entityType,
+		subjectID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveBySubjectIDs resolves the Notes that have not been deleted and are written on any of a set of entities,
+// oldest first, so that an asset can be fetched along with the Notes on it and on its balances or values
+func (r *NoteMySQLRepo) ResolveBySubjectIDs(subjectIDs []uuid.UUID) (notes []model.Note, err error) {
+	if len(subjectIDs) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(
+		QuerySelectNote+`
+		WHERE
+			notes.subject_entity_id IN (?)
+			AND notes.deleted IS NULL
+		ORDER BY notes.created ASC`,
+		subjectIDs)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&notes, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByFilter resolves Notes by a specified filter
+func (r *NoteMySQLRepo) ResolveByFilter(filter filter.Filter) (notes []model.Note, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return notes, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectNote+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&notes, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM notes "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// Create creates a new Note
+func (r *NoteMySQLRepo) Create(note model.Note) error {
+	exists, err := r.ExistsByID(note.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Note", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreate(tx, note); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates an existing Note
+func (r *NoteMySQLRepo) Update(note model.Note) error {
+	exists, err := r.ExistsByID(note.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "Note")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdate(tx, note); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+func (r *NoteMySQLRepo) txCreate(tx *sqlx.Tx, note model.Note) error {
+	stmt, err := tx.PrepareNamed(QueryInsertNote)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(note)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeNote,
+		note.ID,
+		model.AuditActionCreate,
+		note.CreatedBy,
+		nil,
+		note.ToOutput())
+}
+
+func (r *NoteMySQLRepo) txUpdate(tx *sqlx.Tx, note model.Note) error {
+	var before model.Note
+	err := tx.Get(&before, QuerySelectNote+" WHERE notes.entity_id = ? FOR UPDATE", note.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateNote)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(note)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, note.CreatedBy, note.UpdatedBy, note.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeNote,
+		note.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		note.ToOutput())
+}
@@ -0,0 +1,341 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+// notes
+var (
+	notesStmtInsert = `INSERT INTO notes
+	( entity_id, entity_type, subject_entity_id, content, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	notesStmtUpdate = `
+	UPDATE notes
+	SET content = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+)
+
+var (
+	notesTestNow              = time.Now()
+	notesTestUserID, _        = uuid.NewV7()
+	notesTestNoteID, _        = uuid.NewV7()
+	notesTestBankAccountID, _ = uuid.NewV7()
+	notesTestBalanceID, _     = uuid.NewV7()
+
+	notesTestNoteModel = model.Note{
+		ID:         notesTestNoteID,
+		EntityType: model.EntityTypeBankAccount,
+		SubjectID:  notesTestBankAccountID,
+		Content:    "Rate raised to 4.5% after renewal",
+		Created:    notesTestNow,
+		CreatedBy:  notesTestUserID,
+	}
+)
+
+func TestNotesRepository(t *testing.T) {
+
+	t.Run("createNote", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM notes WHERE notes.entity_id = ?").
+				WithArgs(notesTestNoteID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(notesStmtInsert).
+				ExpectExec().
+				WithArgs(
+					notesTestNoteModel.ID,
+					notesTestNoteModel.EntityType,
+					notesTestNoteModel.SubjectID,
+					notesTestNoteModel.Content,
+					notesTestNoteModel.Created,
+					notesTestNoteModel.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeNote)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(notesTestNoteModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM notes WHERE notes.entity_id = ?").
+				WithArgs(notesTestNoteID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(notesTestNoteModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveNotesByIDs", func(t *testing.T) {
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectNote + " WHERE notes.entity_id IN (?)").
+				WithArgs(notesTestNoteID).
+				WillReturnRows(getSingleEntityIDResult(notesTestNoteID))
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			notes, err := repo.ResolveByIDs([]uuid.UUID{notesTestNoteID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, notes, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("noIDs", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			notes, err := repo.ResolveByIDs([]uuid.UUID{})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, notes, 0)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveNotesByEntity", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectNote+`
+				WHERE
+					notes.entity_type = ?
+					AND notes.subject_entity_id = ?
+					AND notes.deleted IS NULL
+				ORDER BY notes.created ASC`).
+				WithArgs(model.EntityTypeBankAccount, notesTestBankAccountID.String()).
+				WillReturnRows(getSingleEntityIDResult(notesTestNoteID))
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			notes, err := repo.ResolveByEntity(model.EntityTypeBankAccount, notesTestBankAccountID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, notes, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveNotesBySubjectIDs", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectNote+`
+				WHERE
+					notes.subject_entity_id IN (?, ?)
+					AND notes.deleted IS NULL
+				ORDER BY notes.created ASC`).
+				WithArgs(notesTestBankAccountID, notesTestBalanceID).
+				WillReturnRows(getSingleEntityIDResult(notesTestNoteID))
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			notes, err := repo.ResolveBySubjectIDs([]uuid.UUID{notesTestBankAccountID, notesTestBalanceID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, notes, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("noIDs", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			notes, err := repo.ResolveBySubjectIDs([]uuid.UUID{})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, notes, 0)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveNotesByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectNote+"WHERE ((notes.content LIKE ?)) AND notes.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs("%renewal%", 10, 0).
+				WillReturnRows(getSingleEntityIDResult(notesTestNoteID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM notes WHERE ((notes.content LIKE ?)) AND notes.deleted IS NULL").
+				WithArgs("%renewal%").
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			keyword := "renewal"
+			testFilter := model.NoteFilterInput{}
+			testFilter.Keyword = &keyword
+
+			repo.Startup()
+			notes, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, notes, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateNote", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM notes WHERE notes.entity_id = ?").
+				WithArgs(notesTestNoteID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectNote, "notes")
+
+			mock.
+				ExpectPrepare(notesStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeNote)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(notesTestNoteModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM notes WHERE notes.entity_id = ?").
+				WithArgs(notesTestNoteID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.NoteMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(notesTestNoteModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/filter"
+)
+
+// pageByKeyset turns the rows fetched by a keyset-paginated query into a page, newest first,
+// along with the page info holding the cursors to its neighbouring pages
+func pageByKeyset[T any](rows []T, pagination filter.Pagination, position func(T) (time.Time, uuid.UUID)) ([]T, model.PageInfoOutput) {
+	page := pagination.GetKeysetPage(len(rows))
+	rows = rows[:page.Size]
+	if page.Reverse {
+		slices.Reverse(rows)
+	}
+
+	pageInfo := model.PageInfoOutput{
+		PageSize: pagination.PageSize,
+	}
+
+	if page.HasNext {
+		date, id := position(rows[len(rows)-1])
+		nextCursor := filter.Cursor{Date: date, ID: id, Direction: filter.CursorDirectionNext}.Encode()
+		pageInfo.NextCursor = &nextCursor
+	}
+
+	if page.HasPrev {
+		date, id := position(rows[0])
+		prevCursor := filter.Cursor{Date: date, ID: id, Direction: filter.CursorDirectionPrev}.Encode()
+		pageInfo.PrevCursor = &prevCursor
+	}
+
+	return rows, pageInfo
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/repository"
 	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
 	"github.com/kerti/balances/backend/util/nuuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -24,15 +25,15 @@ var (
 	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
 
 	propertyValuesStmtInsert = `INSERT INTO property_values
-	( entity_id, property_entity_id, date, value, created, created_by, updated, updated_by, deleted, deleted_by )
-	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+	( entity_id, property_entity_id, date, value, created, created_by, updated, updated_by, deleted, deleted_by, deleted_by_cascade )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
 
 	propertiesStmtUpdate = `UPDATE properties
 	SET name = ?, address = ?, total_area = ?, building_area = ?, area_unit = ?, type = ?, title_holder = ?, tax_identifier = ?, purchase_date = ?, initial_value = ?, initial_value_date = ?, current_value = ?, current_value_date = ?, annual_appreciation_percent = ?, status = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
 	WHERE entity_id = ?`
 
 	propertyValuesStmtUpdate = `UPDATE property_values
-	SET property_entity_id = ?, date = ?, value = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	SET property_entity_id = ?, date = ?, value = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?, deleted_by_cascade = ?
 	WHERE entity_id = ?`
 )
 
@@ -215,6 +216,7 @@ func (t *propertiesRepositoryTestSuite) getArgsFromPropertyValueModel(propertyVa
 	args = append(args, propertyValue.UpdatedBy)
 	args = append(args, propertyValue.Deleted)
 	args = append(args, propertyValue.DeletedBy)
+	args = append(args, propertyValue.DeletedByCascade)
 
 	if setIdLast {
 		args = append(args, propertyValue.ID)
@@ -239,6 +241,8 @@ func (t *propertiesRepositoryTestSuite) TestCreate_Normal() {
 		WithArgs(t.getArgsFromPropertyModel(testModel, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeProperty)
+
 	for _, valueModel := range testModel.Values {
 		t.sqlmock.
 			ExpectPrepare(propertyValuesStmtInsert).
@@ -246,6 +250,8 @@ func (t *propertiesRepositoryTestSuite) TestCreate_Normal() {
 			WithArgs(t.getArgsFromPropertyValueModel(valueModel, false)...).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
+		expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+
 	}
 
 	t.sqlmock.ExpectCommit()
@@ -365,6 +371,8 @@ func (t *propertiesRepositoryTestSuite) TestCreate_FailOnPreparePropertyValueSta
 		WithArgs(t.getArgsFromPropertyModel(testModel, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeProperty)
+
 	t.sqlmock.
 		ExpectPrepare(propertyValuesStmtInsert).
 		WillReturnError(errors.New(errMsg))
@@ -398,6 +406,8 @@ func (t *propertiesRepositoryTestSuite) TestCreate_FailOnExecPropertyValueStatem
 		WithArgs(t.getArgsFromPropertyModel(testModel, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeProperty)
+
 	t.sqlmock.
 		ExpectPrepare(propertyValuesStmtInsert).
 		ExpectExec().
@@ -436,12 +446,18 @@ func (t *propertiesRepositoryTestSuite) TestCreateValue_Normal() {
 		WithArgs(t.getArgsFromPropertyValueModel(newValue, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
 	t.sqlmock.
 		ExpectPrepare(propertiesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeProperty)
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.CreateValue(newValue, &property)
@@ -465,6 +481,8 @@ func (t *propertiesRepositoryTestSuite) TestCreateValue_NoPropertyUpdate() {
 		WithArgs(t.getArgsFromPropertyValueModel(newValue, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.CreateValue(newValue, nil)
@@ -586,6 +604,10 @@ func (t *propertiesRepositoryTestSuite) TestCreateValue_FailOnPropertyUpdate() {
 		WithArgs(t.getArgsFromPropertyValueModel(newValue, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
 	t.sqlmock.
 		ExpectPrepare(propertiesStmtUpdate).
 		ExpectExec().
@@ -604,6 +626,123 @@ func (t *propertiesRepositoryTestSuite) TestCreateValue_FailOnPropertyUpdate() {
 	assert.Contains(t.T(), err.Error(), errMsg)
 }
 
+func (t *propertiesRepositoryTestSuite) TestImportValues_Normal() {
+	firstValue := t.getNewPropertyValueModel(nuuid.NUUID{Valid: false}, nuuid.From(t.testPropertyID), null.TimeFrom(time.Now().AddDate(0, -2, 0)), nil)
+	secondValue := t.getNewPropertyValueModel(nuuid.NUUID{Valid: false}, nuuid.From(t.testPropertyID), null.TimeFrom(time.Now().AddDate(0, -1, 0)), nil)
+	property := t.getNewPropertyModel(nuuid.From(t.testPropertyID), 0)
+	property.CurrentValue = secondValue.Value
+	property.CurrentValueDate = secondValue.Date
+	property.Updated = null.TimeFrom(time.Now())
+
+	t.sqlmock.ExpectBegin()
+
+	for _, value := range []model.PropertyValue{firstValue, secondValue} {
+		t.sqlmock.
+			ExpectPrepare(propertyValuesStmtInsert).
+			ExpectExec().
+			WithArgs(t.getArgsFromPropertyValueModel(value, false)...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+	}
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
+	t.sqlmock.
+		ExpectPrepare(propertiesStmtUpdate).
+		ExpectExec().
+		WithArgs().
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	expectAuditLog(t.sqlmock, model.EntityTypeProperty)
+
+	t.sqlmock.ExpectCommit()
+
+	err := t.repo.ImportValues([]model.PropertyValue{firstValue, secondValue}, &property)
+
+	assert.NoError(t.T(), err)
+}
+
+func (t *propertiesRepositoryTestSuite) TestImportValues_NoPropertyUpdate() {
+	newValue := t.getNewPropertyValueModel(nuuid.NUUID{Valid: false}, nuuid.From(t.testPropertyID), null.TimeFromPtr(nil), nil)
+
+	t.sqlmock.ExpectBegin()
+
+	t.sqlmock.
+		ExpectPrepare(propertyValuesStmtInsert).
+		ExpectExec().
+		WithArgs(t.getArgsFromPropertyValueModel(newValue, false)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+
+	t.sqlmock.ExpectCommit()
+
+	err := t.repo.ImportValues([]model.PropertyValue{newValue}, nil)
+
+	assert.NoError(t.T(), err)
+}
+
+func (t *propertiesRepositoryTestSuite) TestImportValues_FailOnExec() {
+	errMsg := "failed executing statement to create property value"
+	newValue := t.getNewPropertyValueModel(nuuid.NUUID{Valid: false}, nuuid.From(t.testPropertyID), null.TimeFromPtr(nil), nil)
+
+	t.sqlmock.ExpectBegin()
+
+	t.sqlmock.
+		ExpectPrepare(propertyValuesStmtInsert).
+		ExpectExec().
+		WithArgs(t.getArgsFromPropertyValueModel(newValue, false)...).
+		WillReturnError(errors.New(errMsg))
+
+	t.sqlmock.ExpectRollback()
+
+	err := t.repo.ImportValues([]model.PropertyValue{newValue}, nil)
+
+	assert.Error(t.T(), err)
+	assert.IsType(t.T(), &failure.Failure{}, err)
+	assert.Equal(t.T(), failure.CodeInternalError, err.(*failure.Failure).Code)
+	assert.Equal(t.T(), "Property Value", *err.(*failure.Failure).Entity)
+	assert.Equal(t.T(), "import", *err.(*failure.Failure).Operation)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
+func (t *propertiesRepositoryTestSuite) TestImportValues_FailOnPropertyUpdate() {
+	errMsg := "failed executing statement to update property"
+	newValue := t.getNewPropertyValueModel(nuuid.NUUID{Valid: false}, nuuid.From(t.testPropertyID), null.TimeFromPtr(nil), nil)
+	property := t.getNewPropertyModel(nuuid.From(t.testPropertyID), 0)
+	property.CurrentValue = newValue.Value
+	property.CurrentValueDate = newValue.Date
+	property.Updated = null.TimeFrom(time.Now())
+
+	t.sqlmock.ExpectBegin()
+
+	t.sqlmock.
+		ExpectPrepare(propertyValuesStmtInsert).
+		ExpectExec().
+		WithArgs(t.getArgsFromPropertyValueModel(newValue, false)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
+	t.sqlmock.
+		ExpectPrepare(propertiesStmtUpdate).
+		ExpectExec().
+		WithArgs().
+		WillReturnError(errors.New(errMsg))
+
+	t.sqlmock.ExpectRollback()
+
+	err := t.repo.ImportValues([]model.PropertyValue{newValue}, &property)
+
+	assert.Error(t.T(), err)
+	assert.IsType(t.T(), &failure.Failure{}, err)
+	assert.Equal(t.T(), failure.CodeInternalError, err.(*failure.Failure).Code)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
 func (t *propertiesRepositoryTestSuite) TestExistsByID_Normal() {
 	t.sqlmock.
 		ExpectQuery("SELECT COUNT(entity_id) > 0 FROM properties WHERE properties.entity_id = ?").
@@ -735,6 +874,42 @@ func (t *propertiesRepositoryTestSuite) TestResolveByFilter_Normal() {
 	assert.Equal(t.T(), 10, pageInfo.PageSize)
 }
 
+func (t *propertiesRepositoryTestSuite) TestResolveByFilter_NormalWithSort() {
+	t.sqlmock.
+		ExpectQuery(repository.QuerySelectProperty+"WHERE properties.deleted IS NULL ORDER BY properties.purchase_date DESC, properties.name ASC LIMIT ? OFFSET ?").
+		WithArgs(10, 0).
+		WillReturnRows(getSingleEntityIDResult(t.testPropertyID))
+
+	t.sqlmock.ExpectQuery("SELECT COUNT(entity_id) FROM properties WHERE properties.deleted IS NULL").
+		WillReturnRows(getCountResult(1))
+
+	testFilter := model.PropertyFilterInput{}
+	testFilter.Sort = &[]filter.SortInput{
+		{Field: "purchaseDate", Direction: filter.SortDirectionDesc},
+		{Field: "name", Direction: filter.SortDirectionAsc},
+	}
+
+	res, pageInfo, err := t.repo.ResolveByFilter(testFilter.ToFilter())
+
+	assert.NoError(t.T(), err)
+	assert.Len(t.T(), res, 1)
+	assert.Equal(t.T(), 1, pageInfo.TotalCount)
+}
+
+func (t *propertiesRepositoryTestSuite) TestResolveByFilter_InvalidSort() {
+	testFilter := model.PropertyFilterInput{}
+	testFilter.Sort = &[]filter.SortInput{
+		{Field: "(SELECT 1)"},
+	}
+
+	res, _, err := t.repo.ResolveByFilter(testFilter.ToFilter())
+
+	assert.Error(t.T(), err)
+	assert.IsType(t.T(), &failure.Failure{}, err)
+	assert.Equal(t.T(), failure.CodeBadRequest, err.(*failure.Failure).Code)
+	assert.Len(t.T(), res, 0)
+}
+
 func (t *propertiesRepositoryTestSuite) TestResolveByFilter_ErrorOnSelect() {
 	errMsg := "failed resolving properties by filter"
 	keyword := "example"
@@ -970,12 +1145,55 @@ func (t *propertiesRepositoryTestSuite) TestUpdate_Normal() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
 	t.sqlmock.
 		ExpectPrepare(propertiesStmtUpdate).
 		ExpectExec().
 		WithArgs(t.getArgsFromPropertyModel(testModel, true)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeProperty)
+
+	t.sqlmock.ExpectCommit()
+
+	err := t.repo.Update(testModel)
+
+	assert.NoError(t.T(), err)
+}
+
+func (t *propertiesRepositoryTestSuite) TestUpdate_NormalWithValues() {
+	testModel := t.getNewPropertyModel(nuuid.From(t.testPropertyID), 2)
+
+	t.sqlmock.
+		ExpectQuery("SELECT COUNT(entity_id) > 0 FROM properties WHERE properties.entity_id = ?").
+		WithArgs(t.testPropertyID).
+		WillReturnRows(getExistsResult(true))
+
+	t.sqlmock.ExpectBegin()
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
+	t.sqlmock.
+		ExpectPrepare(propertiesStmtUpdate).
+		ExpectExec().
+		WithArgs(t.getArgsFromPropertyModel(testModel, true)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	expectAuditLog(t.sqlmock, model.EntityTypeProperty)
+
+	for _, valueModel := range testModel.Values {
+		expectSelectForUpdate(t.sqlmock, repository.QuerySelectPropertyValues, "property_values")
+
+		t.sqlmock.
+			ExpectPrepare(propertyValuesStmtUpdate).
+			ExpectExec().
+			WithArgs(t.getArgsFromPropertyValueModel(valueModel, true)...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+	}
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.Update(testModel)
@@ -983,6 +1201,47 @@ func (t *propertiesRepositoryTestSuite) TestUpdate_Normal() {
 	assert.NoError(t.T(), err)
 }
 
+func (t *propertiesRepositoryTestSuite) TestUpdate_FailOnUpdatePropertyValue() {
+	errMsg := "failed executing update statement for property value"
+	testModel := t.getNewPropertyModel(nuuid.From(t.testPropertyID), 2)
+
+	t.sqlmock.
+		ExpectQuery("SELECT COUNT(entity_id) > 0 FROM properties WHERE properties.entity_id = ?").
+		WithArgs(t.testPropertyID).
+		WillReturnRows(getExistsResult(true))
+
+	t.sqlmock.ExpectBegin()
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
+	t.sqlmock.
+		ExpectPrepare(propertiesStmtUpdate).
+		ExpectExec().
+		WithArgs(t.getArgsFromPropertyModel(testModel, true)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	expectAuditLog(t.sqlmock, model.EntityTypeProperty)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectPropertyValues, "property_values")
+
+	t.sqlmock.
+		ExpectPrepare(propertyValuesStmtUpdate).
+		ExpectExec().
+		WithArgs(t.getArgsFromPropertyValueModel(testModel.Values[0], true)...).
+		WillReturnError(errors.New(errMsg))
+
+	t.sqlmock.ExpectRollback()
+
+	err := t.repo.Update(testModel)
+
+	assert.Error(t.T(), err)
+	assert.IsType(t.T(), &failure.Failure{}, err)
+	assert.Equal(t.T(), failure.CodeInternalError, err.(*failure.Failure).Code)
+	assert.Equal(t.T(), "Property", *err.(*failure.Failure).Entity)
+	assert.Equal(t.T(), "update", *err.(*failure.Failure).Operation)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
 func (t *propertiesRepositoryTestSuite) TestUpdate_ErrorOnCheckExistence() {
 	errMsg := "failed checking the existence of property"
 	testModel := t.getNewPropertyModel(nuuid.From(t.testPropertyID), 0)
@@ -1031,6 +1290,8 @@ func (t *propertiesRepositoryTestSuite) TestUpdate_FailOnPrepare() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
 	t.sqlmock.
 		ExpectPrepare(propertiesStmtUpdate).
 		WillReturnError(errors.New(errMsg))
@@ -1058,6 +1319,8 @@ func (t *propertiesRepositoryTestSuite) TestUpdate_FailOnExec() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
 	t.sqlmock.
 		ExpectPrepare(propertiesStmtUpdate).
 		ExpectExec().
@@ -1092,18 +1355,26 @@ func (t *propertiesRepositoryTestSuite) TestUpdateValue_Normal() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectPropertyValues, "property_values")
+
 	t.sqlmock.
 		ExpectPrepare(propertyValuesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
 	t.sqlmock.
 		ExpectPrepare(propertiesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeProperty)
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.UpdateValue(propertyValueModel, &propertyModel)
@@ -1125,12 +1396,16 @@ func (t *propertiesRepositoryTestSuite) TestUpdateValue_Normal_NoAccountUpdate()
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectPropertyValues, "property_values")
+
 	t.sqlmock.
 		ExpectPrepare(propertyValuesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.UpdateValue(propertyValueModel, nil)
@@ -1198,6 +1473,8 @@ func (t *propertiesRepositoryTestSuite) TestUpdateValue_FailOnPrepare() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectPropertyValues, "property_values")
+
 	t.sqlmock.
 		ExpectPrepare(propertyValuesStmtUpdate).
 		WillReturnError(errors.New(errMsg))
@@ -1229,6 +1506,8 @@ func (t *propertiesRepositoryTestSuite) TestUpdateValue_FailOnExec() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectPropertyValues, "property_values")
+
 	t.sqlmock.
 		ExpectPrepare(propertyValuesStmtUpdate).
 		ExpectExec().
@@ -1264,12 +1543,18 @@ func (t *propertiesRepositoryTestSuite) TestUpdateValue_FailOnPropertyUpdate() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectPropertyValues, "property_values")
+
 	t.sqlmock.
 		ExpectPrepare(propertyValuesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypePropertyValue)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectProperty, "properties")
+
 	t.sqlmock.
 		ExpectPrepare(propertiesStmtUpdate).
 		ExpectExec().
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/kerti/balances/backend/database"
@@ -49,7 +51,8 @@ const (
 			property_values.updated,
 			property_values.updated_by,
 			property_values.deleted,
-			property_values.deleted_by
+			property_values.deleted_by,
+			property_values.deleted_by_cascade
 		FROM
 			property_values `
 
@@ -113,7 +116,8 @@ const (
 			updated,
 			updated_by,
 			deleted,
-			deleted_by
+			deleted_by,
+			deleted_by_cascade
 		) VALUES (
 			:entity_id,
 			:property_entity_id,
@@ -124,7 +128,8 @@ const (
 			:updated,
 			:updated_by,
 			:deleted,
-			:deleted_by
+			:deleted_by,
+			:deleted_by_cascade
 		)`
 
 	QueryUpdateProperty = `
@@ -164,7 +169,8 @@ const (
 			updated = :updated,
 			updated_by = :updated_by,
 			deleted = :deleted,
-			deleted_by = :deleted_by
+			deleted_by = :deleted_by,
+			deleted_by_cascade = :deleted_by_cascade
 		WHERE entity_id = :entity_id`
 )
 
@@ -255,6 +261,10 @@ func (r *PropertyMySQLRepo) ResolveValuesByIDs(ids []uuid.UUID) (vehicleValues [
 
 // ResolveByFilter resolves Properties by a specified filter
 func (r *PropertyMySQLRepo) ResolveByFilter(filter filter.Filter) (properties []model.Property, pageInfo model.PageInfoOutput, err error) {
+	if filter.Err != nil {
+		return properties, pageInfo, filter.Err
+	}
+
 	filterQueryString, err := filter.ToQueryString()
 	if err != nil {
 		err = failure.InternalError("resolve by filter", "Property", err)
@@ -263,7 +273,7 @@ func (r *PropertyMySQLRepo) ResolveByFilter(filter filter.Filter) (properties []
 
 	filterArgs := filter.GetArgs(true)
 	query, args, err := r.DB.In(
-		QuerySelectProperty+filterQueryString+filter.Pagination.ToQueryString(),
+		QuerySelectProperty+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
 		filterArgs...)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -280,8 +290,7 @@ func (r *PropertyMySQLRepo) ResolveByFilter(filter filter.Filter) (properties []
 
 	var count int
 	filterArgsNoPagination := filter.GetArgs(false)
-	err = r.DB.Get(
-		&count,
+	query, args, err = r.DB.In(
 		"SELECT COUNT(entity_id) FROM properties "+filterQueryString,
 		filterArgsNoPagination...)
 	if err != nil {
@@ -291,6 +300,14 @@ func (r *PropertyMySQLRepo) ResolveByFilter(filter filter.Filter) (properties []
 		return
 	}
 
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		err = failure.InternalError("resolve by filter", "Property", err)
+		properties = []model.Property{}
+		return
+	}
+
 	pageInfo = model.PageInfoOutput{
 		Page:       filter.Pagination.Page,
 		PageSize:   filter.Pagination.PageSize,
@@ -301,8 +318,26 @@ func (r *PropertyMySQLRepo) ResolveByFilter(filter filter.Filter) (properties []
 	return
 }
 
+// AggregateByFilter groups the Properties matching a specified filter and aggregates every group
+func (r *PropertyMySQLRepo) AggregateByFilter(filter filter.Filter) (aggregateRows []model.AggregateRow, err error) {
+	if filter.Err != nil {
+		return aggregateRows, filter.Err
+	}
+
+	aggregateRows, err = resolveAggregateByFilter(r.DB, filter)
+	if err != nil {
+		err = failure.InternalError("aggregate by filter", "Property", err)
+	}
+
+	return
+}
+
 // ResolveValuesByFilter resolves Property Values by a specified filter
 func (r *PropertyMySQLRepo) ResolveValuesByFilter(filter filter.Filter) (vehicleValues []model.PropertyValue, pageInfo model.PageInfoOutput, err error) {
+	if filter.Err != nil {
+		return vehicleValues, pageInfo, filter.Err
+	}
+
 	filterQueryString, err := filter.ToQueryString()
 	if err != nil {
 		err = failure.InternalError("resolve by filter", "Property Value", err)
@@ -311,7 +346,7 @@ func (r *PropertyMySQLRepo) ResolveValuesByFilter(filter filter.Filter) (vehicle
 
 	filterArgs := filter.GetArgs(true)
 	query, args, err := r.DB.In(
-		QuerySelectPropertyValues+filterQueryString+filter.Pagination.ToQueryString(),
+		QuerySelectPropertyValues+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
 		filterArgs...)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -326,6 +361,13 @@ func (r *PropertyMySQLRepo) ResolveValuesByFilter(filter filter.Filter) (vehicle
 		return
 	}
 
+	if filter.Pagination.IsKeyset() {
+		vehicleValues, pageInfo = pageByKeyset(vehicleValues, filter.Pagination, func(value model.PropertyValue) (time.Time, uuid.UUID) {
+			return value.Date, value.ID
+		})
+		return
+	}
+
 	var count int
 	filterArgsNoPagination := filter.GetArgs(false)
 	query, args, err = r.DB.In(
@@ -413,7 +455,8 @@ func (r *PropertyMySQLRepo) Create(vehicle model.Property) error {
 	})
 }
 
-// Update updates a Property
+// Update updates a Property along with any Property Values attached to it, such as those
+// cascaded by a delete or a restore
 func (r *PropertyMySQLRepo) Update(vehicle model.Property) error {
 	exists, err := r.ExistsByID(vehicle.ID)
 	if err != nil {
@@ -434,6 +477,14 @@ func (r *PropertyMySQLRepo) Update(vehicle model.Property) error {
 			return
 		}
 
+		for _, value := range vehicle.Values {
+			if err := r.txUpdatePropertyValue(tx, value); err != nil {
+				err = failure.InternalError("update", "Property", err)
+				e <- err
+				return
+			}
+		}
+
 		e <- nil
 	})
 }
@@ -471,6 +522,30 @@ func (r *PropertyMySQLRepo) CreateValue(vehicleValue model.PropertyValue, vehicl
 	})
 }
 
+// ImportValues creates imported Property Values and optionally updates the Property in a single transaction,
+// so that either every Value is imported or none is
+func (r *PropertyMySQLRepo) ImportValues(propertyValues []model.PropertyValue, property *model.Property) error {
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		for _, propertyValue := range propertyValues {
+			if err := r.txCreatePropertyValue(tx, propertyValue); err != nil {
+				err = failure.InternalError("import", "Property Value", err)
+				e <- err
+				return
+			}
+		}
+
+		if property != nil {
+			if err := r.txUpdateProperty(tx, *property); err != nil {
+				err = failure.InternalError("import", "Property Value", err)
+				e <- err
+				return
+			}
+		}
+
+		e <- nil
+	})
+}
+
 // UpdateValue updates an existing Property Value and optionally updates the Property transactionally
 func (r *PropertyMySQLRepo) UpdateValue(vehicleValue model.PropertyValue, vehicle *model.Property) error {
 	exists, err := r.ExistsValueByID(vehicleValue.ID)
@@ -517,7 +592,14 @@ func (r *PropertyMySQLRepo) txCreateProperty(tx *sqlx.Tx, vehicle model.Property
 		return err
 	}
 
-	return nil
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeProperty,
+		vehicle.ID,
+		model.AuditActionCreate,
+		vehicle.CreatedBy,
+		nil,
+		r.getPropertySnapshot(vehicle))
 }
 
 func (r *PropertyMySQLRepo) txCreatePropertyValue(tx *sqlx.Tx, vehicleValue model.PropertyValue) error {
@@ -533,10 +615,24 @@ func (r *PropertyMySQLRepo) txCreatePropertyValue(tx *sqlx.Tx, vehicleValue mode
 		return err
 	}
 
-	return nil
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypePropertyValue,
+		vehicleValue.ID,
+		model.AuditActionCreate,
+		vehicleValue.CreatedBy,
+		nil,
+		vehicleValue.ToOutput())
 }
 
 func (r *PropertyMySQLRepo) txUpdateProperty(tx *sqlx.Tx, vehicle model.Property) error {
+	var before model.Property
+	err := tx.Get(&before, QuerySelectProperty+" WHERE properties.entity_id = ? FOR UPDATE", vehicle.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
 	stmt, err := tx.PrepareNamed(QueryUpdateProperty)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -549,10 +645,25 @@ func (r *PropertyMySQLRepo) txUpdateProperty(tx *sqlx.Tx, vehicle model.Property
 		return err
 	}
 
-	return nil
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, vehicle.CreatedBy, vehicle.UpdatedBy, vehicle.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeProperty,
+		vehicle.ID,
+		action,
+		actorID,
+		r.getPropertySnapshot(before),
+		r.getPropertySnapshot(vehicle))
 }
 
 func (r *PropertyMySQLRepo) txUpdatePropertyValue(tx *sqlx.Tx, vehicleValue model.PropertyValue) error {
+	var before model.PropertyValue
+	err := tx.Get(&before, QuerySelectPropertyValues+" WHERE property_values.entity_id = ? FOR UPDATE", vehicleValue.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
 	stmt, err := tx.PrepareNamed(QueryUpdatePropertyValue)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -565,5 +676,20 @@ func (r *PropertyMySQLRepo) txUpdatePropertyValue(tx *sqlx.Tx, vehicleValue mode
 		return err
 	}
 
-	return nil
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, vehicleValue.CreatedBy, vehicleValue.UpdatedBy, vehicleValue.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypePropertyValue,
+		vehicleValue.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		vehicleValue.ToOutput())
+}
+
+// getPropertySnapshot produces the audited representation of a Property, leaving out
+// its Values as these are audited on their own
+func (r *PropertyMySQLRepo) getPropertySnapshot(property model.Property) model.PropertyOutput {
+	property.Values = nil
+	return property.ToOutput()
 }
@@ -0,0 +1,289 @@
+package repository
+
+import (
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// Child rows are purged when they are past the cutoff themselves or when their parent is, so that
+// no row is left referencing a parent that is about to be removed.
+const (
+	// queryPurgedSubjectIDs selects the assets, balances and values about to be purged, which attachments and
+	// notes are written on
+	queryPurgedSubjectIDs = `
+				SELECT bank_accounts.entity_id FROM bank_accounts WHERE bank_accounts.deleted < ?
+				UNION
+				SELECT bank_account_balances.entity_id FROM bank_account_balances
+				WHERE
+					bank_account_balances.deleted < ?
+					OR bank_account_balances.bank_account_entity_id IN (
+						SELECT bank_accounts.entity_id FROM bank_accounts WHERE bank_accounts.deleted < ?
+					)
+				UNION
+				SELECT vehicles.entity_id FROM vehicles WHERE vehicles.deleted < ?
+				UNION
+				SELECT vehicle_values.entity_id FROM vehicle_values
+				WHERE
+					vehicle_values.deleted < ?
+					OR vehicle_values.vehicle_entity_id IN (
+						SELECT vehicles.entity_id FROM vehicles WHERE vehicles.deleted < ?
+					)
+				UNION
+				SELECT properties.entity_id FROM properties WHERE properties.deleted < ?
+				UNION
+				SELECT property_values.entity_id FROM property_values
+				WHERE
+					property_values.deleted < ?
+					OR property_values.property_entity_id IN (
+						SELECT properties.entity_id FROM properties WHERE properties.deleted < ?
+					)`
+
+	// Attachments and Notes are purged first, while the records they are written on are still there to be matched.
+	// The content of Attachments is removed from the blob store once the purge is committed.
+	queryPurgeAttachmentsCondition = `
+			attachments.deleted < ?
+			OR attachments.subject_entity_id IN (` + queryPurgedSubjectIDs + `
+			)`
+
+	QuerySelectPurgedAttachmentIDs = `
+		SELECT attachments.entity_id FROM attachments
+		WHERE` + queryPurgeAttachmentsCondition
+
+	QueryPurgeAttachments = `
+		DELETE FROM attachments
+		WHERE` + queryPurgeAttachmentsCondition
+
+	QueryPurgeNotes = `
+		DELETE FROM notes
+		WHERE
+			notes.deleted < ?
+			OR notes.subject_entity_id IN (` + queryPurgedSubjectIDs + `
+			)`
+
+	QueryPurgeBankAccountBalances = `
+		DELETE FROM bank_account_balances
+		WHERE
+			bank_account_balances.deleted < ?
+			OR bank_account_balances.bank_account_entity_id IN (
+				SELECT bank_accounts.entity_id FROM bank_accounts WHERE bank_accounts.deleted < ?
+			)`
+
+	QueryPurgeBankAccountCashFlows = `
+		DELETE FROM bank_account_cash_flows
+		WHERE
+			bank_account_cash_flows.deleted < ?
+			OR bank_account_cash_flows.bank_account_entity_id IN (
+				SELECT bank_accounts.entity_id FROM bank_accounts WHERE bank_accounts.deleted < ?
+			)`
+
+	QueryPurgeTransactions = `
+		DELETE FROM transactions
+		WHERE
+			transactions.deleted < ?
+			OR transactions.bank_account_entity_id IN (
+				SELECT bank_accounts.entity_id FROM bank_accounts WHERE bank_accounts.deleted < ?
+			)`
+
+	QueryPurgeTransfers = `
+		DELETE FROM transfers
+		WHERE
+			transfers.deleted < ?
+			OR transfers.from_bank_account_entity_id IN (
+				SELECT bank_accounts.entity_id FROM bank_accounts WHERE bank_accounts.deleted < ?
+			)
+			OR transfers.to_bank_account_entity_id IN (
+				SELECT bank_accounts.entity_id FROM bank_accounts WHERE bank_accounts.deleted < ?
+			)`
+
+	QueryPurgeCategoryRules = `
+		DELETE FROM category_rules
+		WHERE
+			category_rules.deleted < ?
+			OR category_rules.category_entity_id IN (
+				SELECT categories.entity_id FROM categories WHERE categories.deleted < ?
+			)`
+
+	QueryPurgeBudgets = `
+		DELETE FROM budgets
+		WHERE
+			budgets.deleted < ?
+			OR budgets.category_entity_id IN (
+				SELECT categories.entity_id FROM categories WHERE categories.deleted < ?
+			)`
+
+	QueryPurgeCategories = `
+		DELETE FROM categories
+		WHERE categories.deleted < ?`
+
+	// links between Goals and Bank Accounts are removed by the database along with either side
+	QueryPurgeGoals = `
+		DELETE FROM goals
+		WHERE goals.deleted < ?`
+
+	// Tags put on Bank Accounts, Vehicles and Properties are removed along with the entity; those left on
+	// entities by a purged Tag are removed by the database
+	QueryPurgeEntityTags = `
+		DELETE FROM entity_tags
+		WHERE
+			entity_tags.subject_entity_id IN (
+				SELECT bank_accounts.entity_id FROM bank_accounts WHERE bank_accounts.deleted < ?
+			)
+			OR entity_tags.subject_entity_id IN (
+				SELECT vehicles.entity_id FROM vehicles WHERE vehicles.deleted < ?
+			)
+			OR entity_tags.subject_entity_id IN (
+				SELECT properties.entity_id FROM properties WHERE properties.deleted < ?
+			)`
+
+	QueryPurgeTags = `
+		DELETE FROM tags
+		WHERE tags.deleted < ?`
+
+	// values of a purged Custom Field are removed by the database
+	QueryPurgeCustomFieldValues = `
+		DELETE FROM custom_field_values
+		WHERE
+			custom_field_values.subject_entity_id IN (
+				SELECT bank_accounts.entity_id FROM bank_accounts WHERE bank_accounts.deleted < ?
+			)
+			OR custom_field_values.subject_entity_id IN (
+				SELECT vehicles.entity_id FROM vehicles WHERE vehicles.deleted < ?
+			)
+			OR custom_field_values.subject_entity_id IN (
+				SELECT properties.entity_id FROM properties WHERE properties.deleted < ?
+			)`
+
+	QueryPurgeCustomFields = `
+		DELETE FROM custom_fields
+		WHERE custom_fields.deleted < ?`
+
+	QueryPurgeBankAccounts = `
+		DELETE FROM bank_accounts
+		WHERE bank_accounts.deleted < ?`
+
+	QueryPurgeVehicleValues = `
+		DELETE FROM vehicle_values
+		WHERE
+			vehicle_values.deleted < ?
+			OR vehicle_values.vehicle_entity_id IN (
+				SELECT vehicles.entity_id FROM vehicles WHERE vehicles.deleted < ?
+			)`
+
+	QueryPurgeVehicles = `
+		DELETE FROM vehicles
+		WHERE vehicles.deleted < ?`
+
+	QueryPurgePropertyValues = `
+		DELETE FROM property_values
+		WHERE
+			property_values.deleted < ?
+			OR property_values.property_entity_id IN (
+				SELECT properties.entity_id FROM properties WHERE properties.deleted < ?
+			)`
+
+	QueryPurgeProperties = `
+		DELETE FROM properties
+		WHERE properties.deleted < ?`
+)
+
+// PurgeMySQLRepo is the repository for Purges implemented with MySQL backend
+type PurgeMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *PurgeMySQLRepo) Startup() {
+	logger.Trace("Purge repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *PurgeMySQLRepo) Shutdown() {
+	logger.Trace("Purge repository shutting down...")
+}
+
+// Purge permanently removes all records soft-deleted before the summary's cutoff and records
+// the summary in the audit trail, all in a single transaction
+func (r *PurgeMySQLRepo) Purge(summary model.PurgeSummary) (model.PurgeSummary, error) {
+	err := r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		subjectArgs := slices.Repeat([]interface{}{summary.Cutoff}, 10)
+		err := tx.Select(&summary.AttachmentIDs, QuerySelectPurgedAttachmentIDs, subjectArgs...)
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			e <- failure.InternalError("purge", "Deleted Records", err)
+			return
+		}
+
+		steps := []struct {
+			query   string
+			args    []interface{}
+			counter *int64
+		}{
+			{QueryPurgeAttachments, subjectArgs, &summary.Attachments},
+			{QueryPurgeNotes, subjectArgs, &summary.Notes},
+			{QueryPurgeBankAccountBalances, []interface{}{summary.Cutoff, summary.Cutoff}, &summary.BankAccountBalances},
+			{QueryPurgeBankAccountCashFlows, []interface{}{summary.Cutoff, summary.Cutoff}, &summary.BankAccountCashFlows},
+			{QueryPurgeTransactions, []interface{}{summary.Cutoff, summary.Cutoff}, &summary.Transactions},
+			{QueryPurgeTransfers, []interface{}{summary.Cutoff, summary.Cutoff, summary.Cutoff}, &summary.Transfers},
+			{QueryPurgeCategoryRules, []interface{}{summary.Cutoff, summary.Cutoff}, &summary.CategoryRules},
+			{QueryPurgeBudgets, []interface{}{summary.Cutoff, summary.Cutoff}, &summary.Budgets},
+			{QueryPurgeCategories, []interface{}{summary.Cutoff}, &summary.Categories},
+			{QueryPurgeGoals, []interface{}{summary.Cutoff}, &summary.Goals},
+			{QueryPurgeEntityTags, []interface{}{summary.Cutoff, summary.Cutoff, summary.Cutoff}, &summary.EntityTags},
+			{QueryPurgeTags, []interface{}{summary.Cutoff}, &summary.Tags},
+			{QueryPurgeCustomFieldValues, []interface{}{summary.Cutoff, summary.Cutoff, summary.Cutoff}, &summary.CustomFieldValues},
+			{QueryPurgeCustomFields, []interface{}{summary.Cutoff}, &summary.CustomFields},
+			{QueryPurgeBankAccounts, []interface{}{summary.Cutoff}, &summary.BankAccounts},
+			{QueryPurgeVehicleValues, []interface{}{summary.Cutoff, summary.Cutoff}, &summary.VehicleValues},
+			{QueryPurgeVehicles, []interface{}{summary.Cutoff}, &summary.Vehicles},
+			{QueryPurgePropertyValues, []interface{}{summary.Cutoff, summary.Cutoff}, &summary.PropertyValues},
+			{QueryPurgeProperties, []interface{}{summary.Cutoff}, &summary.Properties},
+		}
+
+		for _, step := range steps {
+			count, err := r.txPurge(tx, step.query, step.args...)
+			if err != nil {
+				e <- failure.InternalError("purge", "Deleted Records", err)
+				return
+			}
+			*step.counter = count
+		}
+
+		err = txCreateAuditLog(
+			tx,
+			model.EntityTypePurge,
+			summary.ID,
+			model.AuditActionPurge,
+			summary.PurgedBy,
+			nil,
+			summary.ToOutput())
+		if err != nil {
+			e <- failure.InternalError("purge", "Deleted Records", err)
+			return
+		}
+
+		e <- nil
+	})
+
+	return summary, err
+}
+
+func (r *PurgeMySQLRepo) txPurge(tx *sqlx.Tx, query string, args ...interface{}) (int64, error) {
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return 0, err
+	}
+
+	return count, nil
+}
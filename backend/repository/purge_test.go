@@ -0,0 +1,280 @@
+package repository_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	purgeTestUserID, _ = uuid.NewV7()
+	purgeTestCutoff    = time.Now().AddDate(0, 0, -90)
+
+	purgeTestAttachmentID, _ = uuid.NewV7()
+	purgeTestSubjectArgs     = []driver.Value{
+		purgeTestCutoff, purgeTestCutoff, purgeTestCutoff, purgeTestCutoff, purgeTestCutoff,
+		purgeTestCutoff, purgeTestCutoff, purgeTestCutoff, purgeTestCutoff, purgeTestCutoff,
+	}
+)
+
+func TestPurgeRepository(t *testing.T) {
+
+	t.Run("purge", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectQuery(repository.QuerySelectPurgedAttachmentIDs).
+				WithArgs(purgeTestSubjectArgs...).
+				WillReturnRows(getSingleEntityIDResult(purgeTestAttachmentID))
+
+			mock.
+				ExpectExec(repository.QueryPurgeAttachments).
+				WithArgs(purgeTestSubjectArgs...).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			mock.
+				ExpectExec(repository.QueryPurgeNotes).
+				WithArgs(purgeTestSubjectArgs...).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+
+			mock.
+				ExpectExec(repository.QueryPurgeBankAccountBalances).
+				WithArgs(purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 12))
+
+			mock.
+				ExpectExec(repository.QueryPurgeBankAccountCashFlows).
+				WithArgs(purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			mock.
+				ExpectExec(repository.QueryPurgeTransactions).
+				WithArgs(purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 6))
+
+			mock.
+				ExpectExec(repository.QueryPurgeTransfers).
+				WithArgs(purgeTestCutoff, purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 4))
+
+			mock.
+				ExpectExec(repository.QueryPurgeCategoryRules).
+				WithArgs(purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			mock.
+				ExpectExec(repository.QueryPurgeBudgets).
+				WithArgs(purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+
+			mock.
+				ExpectExec(repository.QueryPurgeCategories).
+				WithArgs(purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			mock.
+				ExpectExec(repository.QueryPurgeGoals).
+				WithArgs(purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			mock.
+				ExpectExec(repository.QueryPurgeEntityTags).
+				WithArgs(purgeTestCutoff, purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+
+			mock.
+				ExpectExec(repository.QueryPurgeTags).
+				WithArgs(purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			mock.
+				ExpectExec(repository.QueryPurgeCustomFieldValues).
+				WithArgs(purgeTestCutoff, purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 4))
+
+			mock.
+				ExpectExec(repository.QueryPurgeCustomFields).
+				WithArgs(purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			mock.
+				ExpectExec(repository.QueryPurgeBankAccounts).
+				WithArgs(purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			mock.
+				ExpectExec(repository.QueryPurgeVehicleValues).
+				WithArgs(purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 5))
+
+			mock.
+				ExpectExec(repository.QueryPurgeVehicles).
+				WithArgs(purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			mock.
+				ExpectExec(repository.QueryPurgePropertyValues).
+				WithArgs(purgeTestCutoff, purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 3))
+
+			mock.
+				ExpectExec(repository.QueryPurgeProperties).
+				WithArgs(purgeTestCutoff).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+
+			expectAuditLog(mock, model.EntityTypePurge)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.PurgeMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			summary, err := repo.Purge(model.NewPurgeSummary(purgeTestCutoff, purgeTestUserID))
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Equal(t, int64(12), summary.BankAccountBalances)
+			assert.Equal(t, int64(2), summary.BankAccountCashFlows)
+			assert.Equal(t, int64(6), summary.Transactions)
+			assert.Equal(t, int64(4), summary.Transfers)
+			assert.Equal(t, int64(2), summary.CategoryRules)
+			assert.Equal(t, int64(3), summary.Budgets)
+			assert.Equal(t, int64(1), summary.Categories)
+			assert.Equal(t, int64(2), summary.Goals)
+			assert.Equal(t, int64(3), summary.EntityTags)
+			assert.Equal(t, int64(1), summary.Tags)
+			assert.Equal(t, int64(4), summary.CustomFieldValues)
+			assert.Equal(t, int64(2), summary.CustomFields)
+			assert.Equal(t, int64(1), summary.Attachments)
+			assert.Equal(t, []uuid.UUID{purgeTestAttachmentID}, summary.AttachmentIDs)
+			assert.Equal(t, int64(3), summary.Notes)
+			assert.Equal(t, int64(1), summary.BankAccounts)
+			assert.Equal(t, int64(5), summary.VehicleValues)
+			assert.Equal(t, int64(0), summary.Vehicles)
+			assert.Equal(t, int64(3), summary.PropertyValues)
+			assert.Equal(t, int64(1), summary.Properties)
+			assert.Equal(t, int64(56), summary.Total())
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnPurgingChildren", func(t *testing.T) {
+			errMsg := "cannot delete rows"
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectQuery(repository.QuerySelectPurgedAttachmentIDs).
+				WithArgs(purgeTestSubjectArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			mock.
+				ExpectExec(repository.QueryPurgeAttachments).
+				WithArgs(purgeTestSubjectArgs...).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			mock.
+				ExpectExec(repository.QueryPurgeNotes).
+				WithArgs(purgeTestSubjectArgs...).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+
+			mock.
+				ExpectExec(repository.QueryPurgeBankAccountBalances).
+				WithArgs(purgeTestCutoff, purgeTestCutoff).
+				WillReturnError(errors.New(errMsg))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.PurgeMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			_, err := repo.Purge(model.NewPurgeSummary(purgeTestCutoff, purgeTestUserID))
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.IsType(t, &failure.Failure{}, err)
+			assert.Equal(t, failure.CodeInternalError, err.(*failure.Failure).Code)
+			assert.Contains(t, err.Error(), errMsg)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnRecordingAuditLog", func(t *testing.T) {
+			errMsg := "cannot insert audit log"
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectQuery(repository.QuerySelectPurgedAttachmentIDs).
+				WillReturnRows(sqlmock.NewRows([]string{"entity_id"}))
+
+			for _, query := range []string{
+				repository.QueryPurgeAttachments,
+				repository.QueryPurgeNotes,
+				repository.QueryPurgeBankAccountBalances,
+				repository.QueryPurgeBankAccountCashFlows,
+				repository.QueryPurgeTransactions,
+				repository.QueryPurgeTransfers,
+				repository.QueryPurgeCategoryRules,
+				repository.QueryPurgeBudgets,
+				repository.QueryPurgeCategories,
+				repository.QueryPurgeGoals,
+				repository.QueryPurgeEntityTags,
+				repository.QueryPurgeTags,
+				repository.QueryPurgeCustomFieldValues,
+				repository.QueryPurgeCustomFields,
+				repository.QueryPurgeBankAccounts,
+				repository.QueryPurgeVehicleValues,
+				repository.QueryPurgeVehicles,
+				repository.QueryPurgePropertyValues,
+				repository.QueryPurgeProperties,
+			} {
+				mock.
+					ExpectExec(query).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			}
+
+			mock.
+				ExpectPrepare(auditLogStmtInsert).
+				WillReturnError(errors.New(errMsg))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.PurgeMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			_, err := repo.Purge(model.NewPurgeSummary(purgeTestCutoff, purgeTestUserID))
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), errMsg)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/util/filter"
@@ -15,12 +17,136 @@ type BankAccount interface {
 	ResolveByIDs(ids []uuid.UUID) (bankAccounts []model.BankAccount, err error)
 	ResolveBalancesByIDs(ids []uuid.UUID) (bankAccountBalances []model.BankAccountBalance, err error)
 	ResolveByFilter(filter filter.Filter) (bankAccounts []model.BankAccount, pageInfo model.PageInfoOutput, err error)
+	AggregateByFilter(filter filter.Filter) (aggregateRows []model.AggregateRow, err error)
 	ResolveBalancesByFilter(filter filter.Filter) (bankAccountBalances []model.BankAccountBalance, pageInfo model.PageInfoOutput, err error)
 	ResolveLastBalancesByBankAccountID(id uuid.UUID, count int) (bankAccountBalances []model.BankAccountBalance, err error)
 	Create(bankAccount model.BankAccount) error
 	Update(bankAccount model.BankAccount) error
 	CreateBalance(bankAccountBalance model.BankAccountBalance, bankAccount *model.BankAccount) error
+	ImportBalances(bankAccountBalances []model.BankAccountBalance, bankAccount *model.BankAccount) error
 	UpdateBalance(bankAccountBalance model.BankAccountBalance, bankAccount *model.BankAccount) error
+	ExistsCashFlowByID(id uuid.UUID) (exists bool, err error)
+	ResolveCashFlowsByIDs(ids []uuid.UUID) (bankAccountCashFlows []model.BankAccountCashFlow, err error)
+	ResolveCashFlowsByFilter(filter filter.Filter) (bankAccountCashFlows []model.BankAccountCashFlow, pageInfo model.PageInfoOutput, err error)
+	CreateCashFlow(bankAccountCashFlow model.BankAccountCashFlow) error
+	UpdateCashFlow(bankAccountCashFlow model.BankAccountCashFlow) error
+}
+
+// Transaction is the Transaction repository interface
+type Transaction interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (transactions []model.Transaction, err error)
+	ResolveByFilter(filter filter.Filter) (transactions []model.Transaction, pageInfo model.PageInfoOutput, err error)
+	Create(transaction model.Transaction) error
+	Update(transaction model.Transaction) error
+}
+
+// Transfer is the Transfer repository interface
+type Transfer interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ExistsByTransactionID(transactionID uuid.UUID) (exists bool, err error)
+	ExistsByBalanceID(balanceID uuid.UUID) (exists bool, err error)
+	ExistsByBankAccountID(bankAccountID uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (transfers []model.Transfer, err error)
+	ResolveByFilter(filter filter.Filter) (transfers []model.Transfer, pageInfo model.PageInfoOutput, err error)
+	Create(transfer model.Transfer) error
+	Update(transfer model.Transfer) error
+	Delete(transfer model.Transfer, userID uuid.UUID) (deleted model.Transfer, err error)
+}
+
+// Category is the Category repository interface
+type Category interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (categories []model.Category, err error)
+	ResolveByFilter(filter filter.Filter) (categories []model.Category, pageInfo model.PageInfoOutput, err error)
+	Create(category model.Category) error
+	Update(category model.Category) error
+	ExistsRuleByID(id uuid.UUID) (exists bool, err error)
+	ResolveRulesByIDs(ids []uuid.UUID) (categoryRules []model.CategoryRule, err error)
+	ResolveRulesByFilter(filter filter.Filter) (categoryRules []model.CategoryRule, pageInfo model.PageInfoOutput, err error)
+	CreateRule(categoryRule model.CategoryRule) error
+	UpdateRule(categoryRule model.CategoryRule) error
+	Categorize(transactions []model.Transaction) error
+}
+
+// Budget is the Budget repository interface
+type Budget interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (budgets []model.Budget, err error)
+	ResolveByFilter(filter filter.Filter) (budgets []model.Budget, pageInfo model.PageInfoOutput, err error)
+	Create(budget model.Budget) error
+	Update(budget model.Budget) error
+}
+
+// Goal is the Goal repository interface
+type Goal interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (goals []model.Goal, err error)
+	ResolveByBankAccountID(id uuid.UUID) (goals []model.Goal, err error)
+	ResolveByFilter(filter filter.Filter) (goals []model.Goal, pageInfo model.PageInfoOutput, err error)
+	Create(goal model.Goal) error
+	Update(goal model.Goal) error
+}
+
+// Tag is the Tag repository interface
+type Tag interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (tags []model.Tag, err error)
+	ResolveByEntity(entityType model.EntityType, subjectID uuid.UUID) (tags []model.Tag, err error)
+	ResolveByFilter(filter filter.Filter) (tags []model.Tag, pageInfo model.PageInfoOutput, err error)
+	Create(tag model.Tag) error
+	Update(tag model.Tag) error
+	SetEntityTags(entityType model.EntityType, subjectID uuid.UUID, tagIDs []uuid.UUID) error
+}
+
+// CustomField is the Custom Field repository interface
+type CustomField interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (customFields []model.CustomField, err error)
+	ResolveByFilter(filter filter.Filter) (customFields []model.CustomField, pageInfo model.PageInfoOutput, err error)
+	ResolveValuesBySubjectID(subjectID uuid.UUID) (values []model.CustomFieldValue, err error)
+	Create(customField model.CustomField) error
+	Update(customField model.CustomField) error
+	SetValues(entityType model.EntityType, subjectID uuid.UUID, values []model.CustomFieldValue) error
+}
+
+// Attachment is the Attachment repository interface
+type Attachment interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (attachments []model.Attachment, err error)
+	ResolveByEntity(entityType model.EntityType, subjectID uuid.UUID) (attachments []model.Attachment, err error)
+	ResolveByFilter(filter filter.Filter) (attachments []model.Attachment, pageInfo model.PageInfoOutput, err error)
+	Create(attachment model.Attachment) error
+	Update(attachment model.Attachment) error
+}
+
+// Note is the Note repository interface
+type Note interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (notes []model.Note, err error)
+	ResolveByEntity(entityType model.EntityType, subjectID uuid.UUID) (notes []model.Note, err error)
+	ResolveBySubjectIDs(subjectIDs []uuid.UUID) (notes []model.Note, err error)
+	ResolveByFilter(filter filter.Filter) (notes []model.Note, pageInfo model.PageInfoOutput, err error)
+	Create(note model.Note) error
+	Update(note model.Note) error
 }
 
 // User is the User repository interface
@@ -44,11 +170,13 @@ type Vehicle interface {
 	ResolveByIDs(ids []uuid.UUID) (vehicles []model.Vehicle, err error)
 	ResolveValuesByIDs(ids []uuid.UUID) (vehicleValues []model.VehicleValue, err error)
 	ResolveByFilter(filter filter.Filter) (vehicles []model.Vehicle, pageInfo model.PageInfoOutput, err error)
+	AggregateByFilter(filter filter.Filter) (aggregateRows []model.AggregateRow, err error)
 	ResolveValuesByFilter(filter filter.Filter) (vehicleValues []model.VehicleValue, pageInfo model.PageInfoOutput, err error)
 	ResolveLastValuesByVehicleID(id uuid.UUID, count int) (vehicleValues []model.VehicleValue, err error)
 	Create(vehicle model.Vehicle) error
 	Update(vehicle model.Vehicle) error
 	CreateValue(vehicleValue model.VehicleValue, vehicle *model.Vehicle) error
+	ImportValues(vehicleValues []model.VehicleValue, vehicle *model.Vehicle) error
 	UpdateValue(vehicleValue model.VehicleValue, vehicle *model.Vehicle) error
 }
 
@@ -61,10 +189,55 @@ type Property interface {
 	ResolveByIDs(ids []uuid.UUID) (vehicles []model.Property, err error)
 	ResolveValuesByIDs(ids []uuid.UUID) (vehicleValues []model.PropertyValue, err error)
 	ResolveByFilter(filter filter.Filter) (vehicles []model.Property, pageInfo model.PageInfoOutput, err error)
+	AggregateByFilter(filter filter.Filter) (aggregateRows []model.AggregateRow, err error)
 	ResolveValuesByFilter(filter filter.Filter) (vehicleValues []model.PropertyValue, pageInfo model.PageInfoOutput, err error)
 	ResolveLastValuesByPropertyID(id uuid.UUID, count int) (vehicleValues []model.PropertyValue, err error)
 	Create(vehicle model.Property) error
 	Update(vehicle model.Property) error
 	CreateValue(vehicleValue model.PropertyValue, vehicle *model.Property) error
+	ImportValues(propertyValues []model.PropertyValue, property *model.Property) error
 	UpdateValue(vehicleValue model.PropertyValue, vehicle *model.Property) error
 }
+
+// APIKey is the API Key repository interface
+type APIKey interface {
+	Startup()
+	Shutdown()
+	ExistsByID(id uuid.UUID) (exists bool, err error)
+	ResolveByIDs(ids []uuid.UUID) (apiKeys []model.APIKey, err error)
+	ResolveByPrefix(prefix string) (apiKey model.APIKey, err error)
+	ResolveByFilter(filter filter.Filter) (apiKeys []model.APIKey, pageInfo model.PageInfoOutput, err error)
+	Create(apiKey model.APIKey) error
+	Update(apiKey model.APIKey) error
+	UpdateLastUsed(id uuid.UUID, lastUsed time.Time) error
+}
+
+// AuditLog is the Audit Log repository interface
+type AuditLog interface {
+	Startup()
+	Shutdown()
+	ResolveByFilter(filter filter.Filter) (auditLogs []model.AuditLog, pageInfo model.PageInfoOutput, err error)
+}
+
+// Purge is the Purge repository interface
+type Purge interface {
+	Startup()
+	Shutdown()
+	Purge(summary model.PurgeSummary) (model.PurgeSummary, error)
+}
+
+// Search is the full-text Search repository interface
+type Search interface {
+	Startup()
+	Shutdown()
+	Search(search model.Search) (results []model.SearchResult, err error)
+}
+
+// Archive is the Archive repository interface
+type Archive interface {
+	Startup()
+	Shutdown()
+	Export(write func(part model.Archive) error) error
+	IsEmpty() (empty bool, err error)
+	Restore(archive model.Archive, result model.ArchiveRestoreResult, userID uuid.UUID) error
+}
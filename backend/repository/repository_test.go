@@ -5,6 +5,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
 )
 
 var (
@@ -40,3 +41,31 @@ func getMultiEntityIDResult(ids []uuid.UUID) *sqlmock.Rows {
 	}
 	return result
 }
+
+const auditLogStmtInsert = `INSERT INTO audit_logs
+	( entity_id, entity_type, subject_entity_id, action, actor_entity_id, snapshot_before, snapshot_after, created )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )`
+
+// expectSelectForUpdate expects the locking select that captures the state of an entity before it is updated
+func expectSelectForUpdate(mock sqlmock.Sqlmock, selectQuery, tableName string) {
+	mock.
+		ExpectQuery(selectQuery + " WHERE " + tableName + ".entity_id = ? FOR UPDATE").
+		WillReturnRows(getSingleEntityIDResult(uuid.New()))
+}
+
+// expectAuditLog expects the insertion of an Audit Log for a change to an entity of the given type
+func expectAuditLog(mock sqlmock.Sqlmock, entityType model.EntityType) {
+	mock.
+		ExpectPrepare(auditLogStmtInsert).
+		ExpectExec().
+		WithArgs(
+			sqlmock.AnyArg(),
+			entityType,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
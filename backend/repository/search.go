@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySearchBankAccounts = `
+		SELECT
+			'bankAccount' AS entity_type,
+			bank_accounts.entity_id,
+			bank_accounts.account_name AS name,
+			CONCAT(bank_accounts.bank_name, ' ', bank_accounts.account_number) AS detail,
+			bank_accounts.status,
+			bank_accounts.deleted IS NOT NULL AS is_deleted,
+			MATCH (
+				bank_accounts.account_name,
+				bank_accounts.bank_name,
+				bank_accounts.account_holder_name,
+				bank_accounts.account_number
+			) AGAINST (? IN BOOLEAN MODE) AS relevance
+		FROM
+			bank_accounts
+		WHERE
+			MATCH (
+				bank_accounts.account_name,
+				bank_accounts.bank_name,
+				bank_accounts.account_holder_name,
+				bank_accounts.account_number
+			) AGAINST (? IN BOOLEAN MODE) `
+
+	QuerySearchVehicles = `
+		SELECT
+			'vehicle' AS entity_type,
+			vehicles.entity_id,
+			vehicles.name,
+			CONCAT(vehicles.make, ' ', vehicles.model, ' ', vehicles.license_plate_number) AS detail,
+			vehicles.status,
+			vehicles.deleted IS NOT NULL AS is_deleted,
+			MATCH (
+				vehicles.name,
+				vehicles.make,
+				vehicles.model,
+				vehicles.license_plate_number
+			) AGAINST (? IN BOOLEAN MODE) AS relevance
+		FROM
+			vehicles
+		WHERE
+			MATCH (
+				vehicles.name,
+				vehicles.make,
+				vehicles.model,
+				vehicles.license_plate_number
+			) AGAINST (? IN BOOLEAN MODE) `
+
+	QuerySearchProperties = `
+		SELECT
+			'property' AS entity_type,
+			properties.entity_id,
+			properties.name,
+			properties.address AS detail,
+			properties.status,
+			properties.deleted IS NOT NULL AS is_deleted,
+			MATCH (
+				properties.name,
+				properties.address,
+				properties.tax_identifier
+			) AGAINST (? IN BOOLEAN MODE) AS relevance
+		FROM
+			properties
+		WHERE
+			MATCH (
+				properties.name,
+				properties.address,
+				properties.tax_identifier
+			) AGAINST (? IN BOOLEAN MODE) `
+
+	QuerySearchOrder = ` ORDER BY relevance DESC, name ASC LIMIT ?`
+)
+
+// searchQueries are the queries matching each type of entity covered by a search, along with the table they search
+var searchQueries = map[model.EntityType]struct {
+	query     string
+	tableName string
+}{
+	model.EntityTypeBankAccount: {QuerySearchBankAccounts, "bank_accounts"},
+	model.EntityTypeVehicle:     {QuerySearchVehicles, "vehicles"},
+	model.EntityTypeProperty:    {QuerySearchProperties, "properties"},
+}
+
+// SearchMySQLRepo is the repository for full-text searches across assets implemented with MySQL backend
+type SearchMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *SearchMySQLRepo) Startup() {
+	logger.Trace("Search repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *SearchMySQLRepo) Shutdown() {
+	logger.Trace("Search repository shutting down...")
+}
+
+// Search runs a full-text search across every type of asset it covers in a single query,
+// returning the matches of all types ranked together by relevance
+func (r *SearchMySQLRepo) Search(search model.Search) (results []model.SearchResult, err error) {
+	results = make([]model.SearchResult, 0)
+
+	query, args := getSearchQuery(search)
+	err = r.DB.Select(&results, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// getSearchQuery unites the queries of every type of entity a search covers, ranking and limiting
+// their combined results
+func getSearchQuery(search model.Search) (string, []interface{}) {
+	queries := make([]string, 0, len(search.EntityTypes))
+	args := make([]interface{}, 0, len(search.EntityTypes)*2+1)
+
+	for _, entityType := range model.SearchEntityTypes {
+		if !search.Includes(entityType) {
+			continue
+		}
+
+		searchQuery := searchQueries[entityType]
+		query := searchQuery.query
+		if !search.IncludeDeleted {
+			query += "AND " + searchQuery.tableName + ".deleted IS NULL "
+		}
+
+		queries = append(queries, "("+query+")")
+		args = append(args, search.Terms, search.Terms)
+	}
+
+	args = append(args, search.Limit)
+
+	return strings.Join(queries, " UNION ALL ") + QuerySearchOrder, args
+}
@@ -0,0 +1,116 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+var searchTestColumns = []string{"entity_type", "entity_id", "name", "detail", "status", "is_deleted", "relevance"}
+
+func TestSearchRepository(t *testing.T) {
+
+	t.Run("search", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+			bankAccountID, _ := uuid.NewV7()
+			propertyID, _ := uuid.NewV7()
+
+			mock.
+				ExpectQuery("("+repository.QuerySearchBankAccounts+"AND bank_accounts.deleted IS NULL ) UNION ALL ("+
+					repository.QuerySearchVehicles+"AND vehicles.deleted IS NULL ) UNION ALL ("+
+					repository.QuerySearchProperties+"AND properties.deleted IS NULL )"+
+					repository.QuerySearchOrder).
+				WithArgs("+acme*", "+acme*", "+acme*", "+acme*", "+acme*", "+acme*", 20).
+				WillReturnRows(sqlmock.NewRows(searchTestColumns).
+					AddRow("bankAccount", bankAccountID, "ACME Savings", "ACME 1234", "active", 0, 2.5).
+					AddRow("property", propertyID, "Warehouse", "1 ACME Road", "in_use", 0, 1.25))
+
+			repo := new(repository.SearchMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			results, err := repo.Search(model.Search{
+				Terms:       "+acme*",
+				EntityTypes: model.SearchEntityTypes,
+				Limit:       20,
+			})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, results, 2)
+			assert.Equal(t, model.EntityTypeBankAccount, results[0].EntityType)
+			assert.Equal(t, bankAccountID, results[0].ID)
+			assert.Equal(t, 2.5, results[0].Relevance)
+			assert.Equal(t, model.EntityTypeProperty, results[1].EntityType)
+			assert.False(t, results[1].Deleted)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("normalSingleTypeIncludeDeleted", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+			vehicleID, _ := uuid.NewV7()
+
+			mock.
+				ExpectQuery("("+repository.QuerySearchVehicles+")"+repository.QuerySearchOrder).
+				WithArgs("+red* +truck*", "+red* +truck*", 5).
+				WillReturnRows(sqlmock.NewRows(searchTestColumns).
+					AddRow("vehicle", vehicleID, "Red Truck", "Ford F150 B1234", "sold", 1, 3.0))
+
+			repo := new(repository.SearchMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			results, err := repo.Search(model.Search{
+				Terms:          "+red* +truck*",
+				EntityTypes:    []model.EntityType{model.EntityTypeVehicle},
+				IncludeDeleted: true,
+				Limit:          5,
+			})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, results, 1)
+			assert.True(t, results[0].Deleted)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("errorOnSelect", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("("+repository.QuerySearchProperties+"AND properties.deleted IS NULL )"+repository.QuerySearchOrder).
+				WithArgs("+acme*", "+acme*", 20).
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.SearchMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			results, err := repo.Search(model.Search{
+				Terms:       "+acme*",
+				EntityTypes: []model.EntityType{model.EntityTypeProperty},
+				Limit:       20,
+			})
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Empty(t, results)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
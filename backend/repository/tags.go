@@ -0,0 +1,338 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectTag = `
+		SELECT
+			tags.entity_id,
+			tags.name,
+			tags.created,
+			tags.created_by,
+			tags.updated,
+			tags.updated_by,
+			tags.deleted,
+			tags.deleted_by
+		FROM
+			tags `
+
+	QueryInsertTag = `
+		INSERT INTO tags (
+			entity_id,
+			name,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:name,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateTag = `
+		UPDATE tags
+		SET
+			name = :name,
+			created = :created,
+			created_by = :created_by,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+
+	QuerySelectEntityTag = `
+		SELECT
+			entity_tags.tag_entity_id,
+			entity_tags.entity_type,
+			entity_tags.subject_entity_id
+		FROM
+			entity_tags `
+
+	QueryInsertEntityTag = `
+		INSERT INTO entity_tags (
+			tag_entity_id,
+			entity_type,
+			subject_entity_id
+		) VALUES (
+			:tag_entity_id,
+			:entity_type,
+			:subject_entity_id
+		)`
+
+	// Tags that have been deleted are left on the entity, so that they come back if the Tag is restored
+	QueryDeleteEntityTags = `
+		DELETE FROM entity_tags
+		WHERE
+			entity_tags.entity_type = ?
+			AND entity_tags.subject_entity_id = ?
+			AND entity_tags.tag_entity_id IN (SELECT tags.entity_id FROM tags WHERE tags.deleted IS NULL)`
+)
+
+// TagMySQLRepo is the repository for Tags implemented with MySQL backend
+type TagMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *TagMySQLRepo) Startup() {
+	logger.Trace("Tag repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *TagMySQLRepo) Shutdown() {
+	logger.Trace("Tag repository shutting down...")
+}
+
+// ExistsByID checks the existence of a Tag by its ID
+func (r *TagMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM tags WHERE tags.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves Tags by their IDs
+func (r *TagMySQLRepo) ResolveByIDs(ids []uuid.UUID) (tags []model.Tag, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectTag+" WHERE tags.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&tags, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByEntity resolves the Tags that have not been deleted and are put on an entity
+func (r *TagMySQLRepo) ResolveByEntity(entityType model.EntityType, subjectID uuid.UUID) (tags []model.Tag, err error) {
+	err = r.DB.Select(
+		&tags,
+		QuerySelectTag+`
+		WHERE
+			tags.entity_id IN (
+				SELECT entity_tags.tag_entity_id FROM entity_tags WHERE entity_tags.entity_type = ? AND entity_tags.subject_entity_id = ?
+			)
+			AND tags.deleted IS NULL
+		ORDER BY tags.name ASC`,
+		entityType,
+		subjectID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByFilter resolves Tags by a specified filter
+func (r *TagMySQLRepo) ResolveByFilter(filter filter.Filter) (tags []model.Tag, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return tags, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectTag+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&tags, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM tags "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// Create creates a new Tag
+func (r *TagMySQLRepo) Create(tag model.Tag) error {
+	exists, err := r.ExistsByID(tag.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Tag", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreate(tx, tag); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates an existing Tag
+func (r *TagMySQLRepo) Update(tag model.Tag) error {
+	exists, err := r.ExistsByID(tag.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "Tag")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdate(tx, tag); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// SetEntityTags replaces the Tags put on an entity
+func (r *TagMySQLRepo) SetEntityTags(entityType model.EntityType, subjectID uuid.UUID, tagIDs []uuid.UUID) error {
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		_, err := tx.Exec(QueryDeleteEntityTags, entityType, subjectID.String())
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			e <- err
+			return
+		}
+
+		if len(tagIDs) == 0 {
+			e <- nil
+			return
+		}
+
+		stmt, err := tx.PrepareNamed(QueryInsertEntityTag)
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			e <- err
+			return
+		}
+
+		for _, tagID := range tagIDs {
+			_, err = stmt.Exec(model.EntityTag{TagID: tagID, EntityType: entityType, SubjectID: subjectID})
+			if err != nil {
+				logger.ErrNoStack("%v", err)
+				e <- err
+				return
+			}
+		}
+
+		e <- nil
+	})
+}
+
+func (r *TagMySQLRepo) txCreate(tx *sqlx.Tx, tag model.Tag) error {
+	stmt, err := tx.PrepareNamed(QueryInsertTag)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(tag)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeTag,
+		tag.ID,
+		model.AuditActionCreate,
+		tag.CreatedBy,
+		nil,
+		tag.ToOutput())
+}
+
+func (r *TagMySQLRepo) txUpdate(tx *sqlx.Tx, tag model.Tag) error {
+	var before model.Tag
+	err := tx.Get(&before, QuerySelectTag+" WHERE tags.entity_id = ? FOR UPDATE", tag.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateTag)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(tag)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, tag.CreatedBy, tag.UpdatedBy, tag.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeTag,
+		tag.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		tag.ToOutput())
+}
@@ -0,0 +1,355 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+// tags
+var (
+	tagsStmtInsert = `INSERT INTO tags
+	( entity_id, name, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	tagsStmtUpdate = `
+	UPDATE tags
+	SET name = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+
+	entityTagsStmtInsert = `INSERT INTO entity_tags
+	( tag_entity_id, entity_type, subject_entity_id )
+	VALUES ( ?, ?, ? )`
+)
+
+var (
+	tagsTestNow              = time.Now()
+	tagsTestUserID, _        = uuid.NewV7()
+	tagsTestTagID, _         = uuid.NewV7()
+	tagsTestBankAccountID, _ = uuid.NewV7()
+
+	tagsTestTagModel = model.Tag{
+		ID:        tagsTestTagID,
+		Name:      "Emergency Fund",
+		Created:   tagsTestNow,
+		CreatedBy: tagsTestUserID,
+	}
+)
+
+func TestTagsRepository(t *testing.T) {
+
+	t.Run("createTag", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM tags WHERE tags.entity_id = ?").
+				WithArgs(tagsTestTagID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(tagsStmtInsert).
+				ExpectExec().
+				WithArgs(
+					tagsTestTagModel.ID,
+					tagsTestTagModel.Name,
+					tagsTestTagModel.Created,
+					tagsTestTagModel.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTag)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(tagsTestTagModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM tags WHERE tags.entity_id = ?").
+				WithArgs(tagsTestTagID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(tagsTestTagModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveTagsByIDs", func(t *testing.T) {
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectTag + " WHERE tags.entity_id IN (?)").
+				WithArgs(tagsTestTagID).
+				WillReturnRows(getSingleEntityIDResult(tagsTestTagID))
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			tags, err := repo.ResolveByIDs([]uuid.UUID{tagsTestTagID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, tags, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("noIDs", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			tags, err := repo.ResolveByIDs([]uuid.UUID{})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, tags, 0)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveTagsByEntity", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectTag+`
+				WHERE
+					tags.entity_id IN (
+						SELECT entity_tags.tag_entity_id FROM entity_tags WHERE entity_tags.entity_type = ? AND entity_tags.subject_entity_id = ?
+					)
+					AND tags.deleted IS NULL
+				ORDER BY tags.name ASC`).
+				WithArgs(model.EntityTypeBankAccount, tagsTestBankAccountID.String()).
+				WillReturnRows(getSingleEntityIDResult(tagsTestTagID))
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			tags, err := repo.ResolveByEntity(model.EntityTypeBankAccount, tagsTestBankAccountID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, tags, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveTagsByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectTag+"WHERE ((tags.name LIKE ?)) AND tags.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs("%fund%", 10, 0).
+				WillReturnRows(getSingleEntityIDResult(tagsTestTagID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM tags WHERE ((tags.name LIKE ?)) AND tags.deleted IS NULL").
+				WithArgs("%fund%").
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			keyword := "fund"
+			testFilter := model.TagFilterInput{}
+			testFilter.Keyword = &keyword
+
+			repo.Startup()
+			tags, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, tags, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateTag", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM tags WHERE tags.entity_id = ?").
+				WithArgs(tagsTestTagID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectTag, "tags")
+
+			mock.
+				ExpectPrepare(tagsStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTag)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(tagsTestTagModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM tags WHERE tags.entity_id = ?").
+				WithArgs(tagsTestTagID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(tagsTestTagModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("setEntityTags", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectExec(repository.QueryDeleteEntityTags).
+				WithArgs(model.EntityTypeBankAccount, tagsTestBankAccountID.String()).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			mock.
+				ExpectPrepare(entityTagsStmtInsert).
+				ExpectExec().
+				WithArgs(tagsTestTagID, model.EntityTypeBankAccount, tagsTestBankAccountID).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			mock.ExpectCommit()
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.SetEntityTags(model.EntityTypeBankAccount, tagsTestBankAccountID, []uuid.UUID{tagsTestTagID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("clear", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectExec(repository.QueryDeleteEntityTags).
+				WithArgs(model.EntityTypeBankAccount, tagsTestBankAccountID.String()).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+
+			mock.ExpectCommit()
+
+			repo := new(repository.TagMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.SetEntityTags(model.EntityTypeBankAccount, tagsTestBankAccountID, []uuid.UUID{})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
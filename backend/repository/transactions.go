@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectTransaction = `
+		SELECT
+			transactions.entity_id,
+			transactions.bank_account_entity_id,
+			transactions.date,
+			transactions.direction,
+			transactions.amount,
+			transactions.payee,
+			transactions.memo,
+			transactions.category,
+			transactions.category_entity_id,
+			transactions.created,
+			transactions.created_by,
+			transactions.updated,
+			transactions.updated_by,
+			transactions.deleted,
+			transactions.deleted_by
+		FROM
+			transactions `
+
+	QueryInsertTransaction = `
+		INSERT INTO transactions (
+			entity_id,
+			bank_account_entity_id,
+			date,
+			direction,
+			amount,
+			payee,
+			memo,
+			category,
+			category_entity_id,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:bank_account_entity_id,
+			:date,
+			:direction,
+			:amount,
+			:payee,
+			:memo,
+			:category,
+			:category_entity_id,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateTransaction = `
+		UPDATE transactions
+		SET
+			bank_account_entity_id = :bank_account_entity_id,
+			date = :date,
+			direction = :direction,
+			amount = :amount,
+			payee = :payee,
+			memo = :memo,
+			category = :category,
+			category_entity_id = :category_entity_id,
+			created = :created,
+			created_by = :created_by,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+)
+
+// TransactionMySQLRepo is the repository for Transactions implemented with MySQL backend
+type TransactionMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *TransactionMySQLRepo) Startup() {
+	logger.Trace("Transaction repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *TransactionMySQLRepo) Shutdown() {
+	logger.Trace("Transaction repository shutting down...")
+}
+
+// ExistsByID checks the existence of a Transaction by its ID
+func (r *TransactionMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM transactions WHERE transactions.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves Transactions by their IDs
+func (r *TransactionMySQLRepo) ResolveByIDs(ids []uuid.UUID) (transactions []model.Transaction, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectTransaction+" WHERE transactions.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&transactions, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByFilter resolves Transactions by a specified filter
+func (r *TransactionMySQLRepo) ResolveByFilter(filter filter.Filter) (transactions []model.Transaction, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return transactions, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectTransaction+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&transactions, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	if filter.Pagination.IsKeyset() {
+		if err == nil {
+			transactions, pageInfo = pageByKeyset(transactions, filter.Pagination, func(transaction model.Transaction) (time.Time, uuid.UUID) {
+				return transaction.Date, transaction.ID
+			})
+		}
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM transactions "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// Create creates a new Transaction
+func (r *TransactionMySQLRepo) Create(transaction model.Transaction) error {
+	exists, err := r.ExistsByID(transaction.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Transaction", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreate(tx, transaction); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates an existing Transaction
+func (r *TransactionMySQLRepo) Update(transaction model.Transaction) error {
+	exists, err := r.ExistsByID(transaction.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "Transaction")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdate(tx, transaction); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+func (r *TransactionMySQLRepo) txCreate(tx *sqlx.Tx, transaction model.Transaction) error {
+	stmt, err := tx.PrepareNamed(QueryInsertTransaction)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(transaction)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeTransaction,
+		transaction.ID,
+		model.AuditActionCreate,
+		transaction.CreatedBy,
+		nil,
+		transaction.ToOutput())
+}
+
+func (r *TransactionMySQLRepo) txUpdate(tx *sqlx.Tx, transaction model.Transaction) error {
+	var before model.Transaction
+	err := tx.Get(&before, QuerySelectTransaction+" WHERE transactions.entity_id = ? FOR UPDATE", transaction.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateTransaction)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(transaction)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, transaction.CreatedBy, transaction.UpdatedBy, transaction.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeTransaction,
+		transaction.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		transaction.ToOutput())
+}
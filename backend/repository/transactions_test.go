@@ -0,0 +1,348 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+// transactions
+var (
+	transactionsStmtInsert = `INSERT INTO transactions
+	( entity_id, bank_account_entity_id, date, direction, amount, payee, memo, category, category_entity_id, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	transactionsStmtUpdate = `
+	UPDATE transactions
+	SET bank_account_entity_id = ?, date = ?, direction = ?, amount = ?, payee = ?, memo = ?, category = ?, category_entity_id = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+)
+
+var (
+	transactionsTestNow              = time.Now()
+	transactionsTestUserID, _        = uuid.NewV7()
+	transactionsTestAccountID, _     = uuid.NewV7()
+	transactionsTestTransactionID, _ = uuid.NewV7()
+
+	transactionsTestTransactionModel = model.Transaction{
+		ID:            transactionsTestTransactionID,
+		BankAccountID: transactionsTestAccountID,
+		Date:          transactionsTestNow,
+		Direction:     model.TransactionDirectionDebit,
+		Amount:        float64(125000),
+		Payee:         "Grocery Store",
+		Memo:          "weekly groceries",
+		Category:      "groceries",
+		Created:       transactionsTestNow,
+		CreatedBy:     transactionsTestUserID,
+	}
+)
+
+func TestTransactionsRepository(t *testing.T) {
+
+	t.Run("createTransaction", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transactions WHERE transactions.entity_id = ?").
+				WithArgs(transactionsTestTransactionID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(transactionsStmtInsert).
+				ExpectExec().
+				WithArgs(
+					transactionsTestTransactionModel.ID,
+					transactionsTestTransactionModel.BankAccountID,
+					transactionsTestTransactionModel.Date,
+					transactionsTestTransactionModel.Direction,
+					transactionsTestTransactionModel.Amount,
+					transactionsTestTransactionModel.Payee,
+					transactionsTestTransactionModel.Memo,
+					transactionsTestTransactionModel.Category,
+					transactionsTestTransactionModel.CategoryID,
+					transactionsTestTransactionModel.Created,
+					transactionsTestTransactionModel.CreatedBy,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTransaction)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(transactionsTestTransactionModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transactions WHERE transactions.entity_id = ?").
+				WithArgs(transactionsTestTransactionID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(transactionsTestTransactionModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnExec", func(t *testing.T) {
+			errMsg := "cannot insert transaction"
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transactions WHERE transactions.entity_id = ?").
+				WithArgs(transactionsTestTransactionID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(transactionsStmtInsert).
+				ExpectExec().
+				WillReturnError(errors.New(errMsg))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(transactionsTestTransactionModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), errMsg)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveTransactionsByIDs", func(t *testing.T) {
+
+		t.Run("normalNoID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			_, err := repo.ResolveByIDs([]uuid.UUID{})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectTransaction + " WHERE transactions.entity_id IN (?)").
+				WithArgs(transactionsTestTransactionID).
+				WillReturnRows(getSingleEntityIDResult(transactionsTestTransactionID))
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			transactions, err := repo.ResolveByIDs([]uuid.UUID{transactionsTestTransactionID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, transactions, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("errorExecutingSelect", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectTransaction + " WHERE transactions.entity_id IN (?)").
+				WithArgs(transactionsTestTransactionID).
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			_, err := repo.ResolveByIDs([]uuid.UUID{transactionsTestTransactionID})
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveTransactionsByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectTransaction+"WHERE ((transactions.bank_account_entity_id IN (?))) AND transactions.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs(transactionsTestAccountID, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(transactionsTestTransactionID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM transactions WHERE ((transactions.bank_account_entity_id IN (?))) AND transactions.deleted IS NULL").
+				WithArgs(transactionsTestAccountID).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.TransactionFilterInput{}
+			testFilter.BankAccountIDs = &[]uuid.UUID{transactionsTestAccountID}
+
+			repo.Startup()
+			transactions, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, transactions, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("errorOnSelect", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectTransaction+"WHERE ((transactions.bank_account_entity_id IN (?))) AND transactions.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs(transactionsTestAccountID, 10, 0).
+				WillReturnError(errors.New(""))
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.TransactionFilterInput{}
+			testFilter.BankAccountIDs = &[]uuid.UUID{transactionsTestAccountID}
+
+			repo.Startup()
+			_, _, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateTransaction", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transactions WHERE transactions.entity_id = ?").
+				WithArgs(transactionsTestTransactionID).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectTransaction, "transactions")
+
+			mock.
+				ExpectPrepare(transactionsStmtUpdate).
+				ExpectExec().
+				WithArgs().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTransaction)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(transactionsTestTransactionModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transactions WHERE transactions.entity_id = ?").
+				WithArgs(transactionsTestTransactionID).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.TransactionMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(transactionsTestTransactionModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
@@ -0,0 +1,558 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/kerti/balances/backend/database"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+const (
+	QuerySelectTransfer = `
+		SELECT
+			transfers.entity_id,
+			transfers.from_bank_account_entity_id,
+			transfers.to_bank_account_entity_id,
+			transfers.date,
+			transfers.amount,
+			transfers.note,
+			transfers.from_balance_entity_id,
+			transfers.to_balance_entity_id,
+			transfers.from_transaction_entity_id,
+			transfers.to_transaction_entity_id,
+			transfers.created,
+			transfers.created_by,
+			transfers.updated,
+			transfers.updated_by,
+			transfers.deleted,
+			transfers.deleted_by
+		FROM
+			transfers `
+
+	QueryInsertTransfer = `
+		INSERT INTO transfers (
+			entity_id,
+			from_bank_account_entity_id,
+			to_bank_account_entity_id,
+			date,
+			amount,
+			note,
+			from_balance_entity_id,
+			to_balance_entity_id,
+			from_transaction_entity_id,
+			to_transaction_entity_id,
+			created,
+			created_by,
+			updated,
+			updated_by,
+			deleted,
+			deleted_by
+		) VALUES (
+			:entity_id,
+			:from_bank_account_entity_id,
+			:to_bank_account_entity_id,
+			:date,
+			:amount,
+			:note,
+			:from_balance_entity_id,
+			:to_balance_entity_id,
+			:from_transaction_entity_id,
+			:to_transaction_entity_id,
+			:created,
+			:created_by,
+			:updated,
+			:updated_by,
+			:deleted,
+			:deleted_by
+		)`
+
+	QueryUpdateTransfer = `
+		UPDATE transfers
+		SET
+			from_bank_account_entity_id = :from_bank_account_entity_id,
+			to_bank_account_entity_id = :to_bank_account_entity_id,
+			date = :date,
+			amount = :amount,
+			note = :note,
+			from_balance_entity_id = :from_balance_entity_id,
+			to_balance_entity_id = :to_balance_entity_id,
+			from_transaction_entity_id = :from_transaction_entity_id,
+			to_transaction_entity_id = :to_transaction_entity_id,
+			created = :created,
+			created_by = :created_by,
+			updated = :updated,
+			updated_by = :updated_by,
+			deleted = :deleted,
+			deleted_by = :deleted_by
+		WHERE entity_id = :entity_id`
+)
+
+// TransferMySQLRepo is the repository for Transfers implemented with MySQL backend
+type TransferMySQLRepo struct {
+	DB *database.MySQL `inject:"mysql"`
+}
+
+// Startup perform startup functions
+func (r *TransferMySQLRepo) Startup() {
+	logger.Trace("Transfer repository starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (r *TransferMySQLRepo) Shutdown() {
+	logger.Trace("Transfer repository shutting down...")
+}
+
+// ExistsByID checks the existence of a Transfer by its ID
+func (r *TransferMySQLRepo) ExistsByID(id uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		"SELECT COUNT(entity_id) > 0 FROM transfers WHERE transfers.entity_id = ?",
+		id.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ExistsByTransactionID checks whether a Transaction was recorded by a Transfer that has not been deleted
+func (r *TransferMySQLRepo) ExistsByTransactionID(transactionID uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		`SELECT COUNT(entity_id) > 0 FROM transfers
+		WHERE (transfers.from_transaction_entity_id = ? OR transfers.to_transaction_entity_id = ?)
+		AND transfers.deleted IS NULL`,
+		transactionID.String(),
+		transactionID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ExistsByBalanceID checks whether a Bank Account Balance was recorded by a Transfer that has not been deleted
+func (r *TransferMySQLRepo) ExistsByBalanceID(balanceID uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		`SELECT COUNT(entity_id) > 0 FROM transfers
+		WHERE (transfers.from_balance_entity_id = ? OR transfers.to_balance_entity_id = ?)
+		AND transfers.deleted IS NULL`,
+		balanceID.String(),
+		balanceID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ExistsByBankAccountID checks whether a Bank Account is either side of a Transfer that has not been deleted
+func (r *TransferMySQLRepo) ExistsByBankAccountID(bankAccountID uuid.UUID) (exists bool, err error) {
+	err = r.DB.Get(
+		&exists,
+		`SELECT COUNT(entity_id) > 0 FROM transfers
+		WHERE (transfers.from_bank_account_entity_id = ? OR transfers.to_bank_account_entity_id = ?)
+		AND transfers.deleted IS NULL`,
+		bankAccountID.String(),
+		bankAccountID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+	return
+}
+
+// ResolveByIDs resolves Transfers by their IDs
+func (r *TransferMySQLRepo) ResolveByIDs(ids []uuid.UUID) (transfers []model.Transfer, err error) {
+	if len(ids) == 0 {
+		return
+	}
+
+	query, args, err := r.DB.In(QuerySelectTransfer+" WHERE transfers.entity_id IN (?)", ids)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&transfers, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return
+}
+
+// ResolveByFilter resolves Transfers by a specified filter
+func (r *TransferMySQLRepo) ResolveByFilter(filter filter.Filter) (transfers []model.Transfer, pageInfo model.PageInfoOutput, err error) {
+	filterQueryString, err := filter.ToQueryString()
+	if err != nil {
+		return transfers, pageInfo, err
+	}
+
+	filterArgs := filter.GetArgs(true)
+	query, args, err := r.DB.In(
+		QuerySelectTransfer+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
+		filterArgs...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Select(&transfers, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	if filter.Pagination.IsKeyset() {
+		if err == nil {
+			transfers, pageInfo = pageByKeyset(transfers, filter.Pagination, func(transfer model.Transfer) (time.Time, uuid.UUID) {
+				return transfer.Date, transfer.ID
+			})
+		}
+		return
+	}
+
+	var count int
+	filterArgsNoPagination := filter.GetArgs(false)
+	query, args, err = r.DB.In(
+		"SELECT COUNT(entity_id) FROM transfers "+filterQueryString,
+		filterArgsNoPagination...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	pageInfo = model.PageInfoOutput{
+		Page:       filter.Pagination.Page,
+		PageSize:   filter.Pagination.PageSize,
+		TotalCount: count,
+		PageCount:  filter.Pagination.GetPageCount(count),
+	}
+
+	return
+}
+
+// Create creates a new Transfer together with the balances, Bank Account updates and Transactions attached to it,
+// all in a single database transaction so that both sides are either written or not at all
+func (r *TransferMySQLRepo) Create(transfer model.Transfer) error {
+	exists, err := r.ExistsByID(transfer.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if exists {
+		err = failure.OperationNotPermitted("create", "Transfer", "already exists")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	bankAccountRepo := BankAccountMySQLRepo{DB: r.DB}
+	transactionRepo := TransactionMySQLRepo{DB: r.DB}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreate(tx, transfer); err != nil {
+			e <- err
+			return
+		}
+
+		for _, balance := range transfer.Balances {
+			if err := bankAccountRepo.txCreateBankAccountBalance(tx, balance); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		for _, bankAccount := range transfer.BankAccounts {
+			if err := bankAccountRepo.txUpdateBankAccount(tx, bankAccount); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		for _, transaction := range transfer.Transactions {
+			if err := transactionRepo.txCreate(tx, transaction); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates an existing Transfer together with the balances, Bank Accounts and Transactions attached to it,
+// all in a single database transaction
+func (r *TransferMySQLRepo) Update(transfer model.Transfer) error {
+	exists, err := r.ExistsByID(transfer.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("update", "Transfer")
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	bankAccountRepo := BankAccountMySQLRepo{DB: r.DB}
+	transactionRepo := TransactionMySQLRepo{DB: r.DB}
+
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdate(tx, transfer); err != nil {
+			e <- err
+			return
+		}
+
+		for _, balance := range transfer.Balances {
+			if err := bankAccountRepo.txUpdateBankAccountBalance(tx, balance); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		for _, bankAccount := range transfer.BankAccounts {
+			if err := bankAccountRepo.txUpdateBankAccount(tx, bankAccount); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		for _, transaction := range transfer.Transactions {
+			if err := transactionRepo.txUpdate(tx, transaction); err != nil {
+				e <- err
+				return
+			}
+		}
+
+		e <- nil
+	})
+}
+
+// Delete deletes a Transfer along with the balances and Transactions it recorded on both sides, returning
+// each Bank Account to its latest remaining balance. The balances are read and written in a single database
+// transaction, so that no balance can be recorded in between.
+func (r *TransferMySQLRepo) Delete(transfer model.Transfer, userID uuid.UUID) (deleted model.Transfer, err error) {
+	exists, err := r.ExistsByID(transfer.ID)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	if !exists {
+		err = failure.EntityNotFound("delete", "Transfer")
+		logger.ErrNoStack("%v", err)
+		return
+	}
+
+	err = r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		result, err := r.txDelete(tx, transfer, userID)
+		if err != nil {
+			e <- err
+			return
+		}
+
+		deleted = result
+		e <- nil
+	})
+
+	return
+}
+
+func (r *TransferMySQLRepo) txCreate(tx *sqlx.Tx, transfer model.Transfer) error {
+	stmt, err := tx.PrepareNamed(QueryInsertTransfer)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(transfer)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeTransfer,
+		transfer.ID,
+		model.AuditActionCreate,
+		transfer.CreatedBy,
+		nil,
+		r.getTransferSnapshot(transfer))
+}
+
+func (r *TransferMySQLRepo) txUpdate(tx *sqlx.Tx, transfer model.Transfer) error {
+	var before model.Transfer
+	err := tx.Get(&before, QuerySelectTransfer+" WHERE transfers.entity_id = ? FOR UPDATE", transfer.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	stmt, err := tx.PrepareNamed(QueryUpdateTransfer)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	_, err = stmt.Exec(transfer)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, transfer.CreatedBy, transfer.UpdatedBy, transfer.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeTransfer,
+		transfer.ID,
+		action,
+		actorID,
+		r.getTransferSnapshot(before),
+		r.getTransferSnapshot(transfer))
+}
+
+func (r *TransferMySQLRepo) txDelete(tx *sqlx.Tx, transfer model.Transfer, userID uuid.UUID) (model.Transfer, error) {
+	bankAccountRepo := BankAccountMySQLRepo{DB: r.DB}
+	transactionRepo := TransactionMySQLRepo{DB: r.DB}
+
+	sides := []struct {
+		bankAccountID uuid.UUID
+		balanceID     uuid.UUID
+	}{
+		{transfer.FromBankAccountID, transfer.FromBalanceID},
+		{transfer.ToBankAccountID, transfer.ToBalanceID},
+	}
+
+	var stored model.Transfer
+	err := tx.Get(&stored, QuerySelectTransfer+" WHERE transfers.entity_id = ? FOR UPDATE", transfer.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return transfer, err
+	}
+
+	if stored.Deleted.Valid {
+		return transfer, failure.OperationNotPermitted("delete", "Transfer", "already deleted")
+	}
+
+	transfer.BankAccounts = nil
+	transfer.Balances = nil
+	transfer.Transactions = nil
+
+	for _, side := range sides {
+		var bankAccount model.BankAccount
+		err = tx.Get(&bankAccount, QuerySelectBankAccount+" WHERE bank_accounts.entity_id = ? FOR UPDATE", side.bankAccountID.String())
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			return transfer, err
+		}
+
+		var balance model.BankAccountBalance
+		err = tx.Get(&balance, QuerySelectBankAccountBalance+" WHERE bank_account_balances.entity_id = ? FOR UPDATE", side.balanceID.String())
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			return transfer, err
+		}
+
+		var lastBalances []model.BankAccountBalance
+		err = tx.Select(
+			&lastBalances,
+			QuerySelectBankAccountBalance+` WHERE bank_account_balances.bank_account_entity_id = ? AND bank_account_balances.deleted IS NULL
+			ORDER BY bank_account_balances.date DESC, bank_account_balances.created DESC, bank_account_balances.entity_id DESC LIMIT 2 FOR UPDATE`,
+			bankAccount.ID.String())
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			return transfer, err
+		}
+
+		if len(lastBalances) == 0 || lastBalances[0].ID != balance.ID {
+			return transfer, failure.OperationNotPermitted("delete", "Transfer", "a newer balance has been recorded on "+bankAccount.AccountName)
+		}
+
+		if len(lastBalances) < 2 {
+			return transfer, failure.OperationNotPermitted("delete", "Transfer", "the transfer recorded the only balance of "+bankAccount.AccountName)
+		}
+
+		err = balance.Delete(userID)
+		if err != nil {
+			return transfer, err
+		}
+
+		err = bankAccount.SetNewBalance(model.BankAccountBalanceInput{
+			ID:            lastBalances[1].ID,
+			BankAccountID: lastBalances[1].BankAccountID,
+			Balance:       lastBalances[1].Balance,
+			Date:          cachetime.CacheTime(lastBalances[1].Date),
+		}, userID)
+		if err != nil {
+			return transfer, err
+		}
+
+		transfer.BankAccounts = append(transfer.BankAccounts, bankAccount)
+		transfer.Balances = append(transfer.Balances, balance)
+	}
+
+	for _, transactionID := range []uuid.UUID{transfer.FromTransactionID, transfer.ToTransactionID} {
+		var transaction model.Transaction
+		err = tx.Get(&transaction, QuerySelectTransaction+" WHERE transactions.entity_id = ? FOR UPDATE", transactionID.String())
+		if err != nil {
+			logger.ErrNoStack("%v", err)
+			return transfer, err
+		}
+
+		if transaction.Deleted.Valid {
+			continue
+		}
+
+		err = transaction.Delete(userID)
+		if err != nil {
+			return transfer, err
+		}
+
+		transfer.Transactions = append(transfer.Transactions, transaction)
+	}
+
+	err = r.txUpdate(tx, transfer)
+	if err != nil {
+		return transfer, err
+	}
+
+	for _, balance := range transfer.Balances {
+		if err := bankAccountRepo.txUpdateBankAccountBalance(tx, balance); err != nil {
+			return transfer, err
+		}
+	}
+
+	for _, bankAccount := range transfer.BankAccounts {
+		if err := bankAccountRepo.txUpdateBankAccount(tx, bankAccount); err != nil {
+			return transfer, err
+		}
+	}
+
+	for _, transaction := range transfer.Transactions {
+		if err := transactionRepo.txUpdate(tx, transaction); err != nil {
+			return transfer, err
+		}
+	}
+
+	return transfer, nil
+}
+
+// getTransferSnapshot produces the audited representation of a Transfer, leaving out
+// its balances and Transactions as these are audited on their own
+func (r *TransferMySQLRepo) getTransferSnapshot(transfer model.Transfer) model.TransferOutput {
+	transfer.Balances = nil
+	transfer.Transactions = nil
+	return transfer.ToOutput()
+}
@@ -0,0 +1,588 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+)
+
+// transfers
+var (
+	transfersStmtInsert = `INSERT INTO transfers
+	( entity_id, from_bank_account_entity_id, to_bank_account_entity_id, date, amount, note, from_balance_entity_id, to_balance_entity_id, from_transaction_entity_id, to_transaction_entity_id, created, created_by, updated, updated_by, deleted, deleted_by )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+
+	transfersStmtUpdate = `
+	UPDATE transfers
+	SET from_bank_account_entity_id = ?, to_bank_account_entity_id = ?, date = ?, amount = ?, note = ?, from_balance_entity_id = ?, to_balance_entity_id = ?, from_transaction_entity_id = ?, to_transaction_entity_id = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	WHERE entity_id = ?`
+)
+
+var (
+	transfersTestNow              = time.Now()
+	transfersTestUserID, _        = uuid.NewV7()
+	transfersTestFromAccountID, _ = uuid.NewV7()
+	transfersTestToAccountID, _   = uuid.NewV7()
+
+	transfersTestTransferModel = model.NewTransferFromInput(
+		model.TransferInput{
+			FromBankAccountID: transfersTestFromAccountID,
+			ToBankAccountID:   transfersTestToAccountID,
+			Date:              cachetime.CacheTime(transfersTestNow),
+			Amount:            float64(50000),
+			Note:              "monthly savings",
+		},
+		model.BankAccount{ID: transfersTestFromAccountID, AccountName: "Checking", LastBalance: float64(200000)},
+		model.BankAccount{ID: transfersTestToAccountID, AccountName: "Savings", LastBalance: float64(100000)},
+		transfersTestUserID)
+)
+
+// expectTransferEntries expects the balances, Bank Account updates and Transactions written along with a Transfer
+func expectTransferEntries(mock sqlmock.Sqlmock, balanceStmt string, withSelectForUpdate bool, transactionStmt string) {
+	for i := 0; i < 2; i++ {
+		if withSelectForUpdate {
+			expectSelectForUpdate(mock, repository.QuerySelectBankAccountBalance, "bank_account_balances")
+		}
+		mock.
+			ExpectPrepare(balanceStmt).
+			ExpectExec().
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+	}
+
+	for i := 0; i < 2; i++ {
+		expectSelectForUpdate(mock, repository.QuerySelectBankAccount, "bank_accounts")
+		mock.
+			ExpectPrepare(bankAccountsStmtUpdate).
+			ExpectExec().
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectAuditLog(mock, model.EntityTypeBankAccount)
+	}
+
+	for i := 0; i < 2; i++ {
+		if withSelectForUpdate {
+			expectSelectForUpdate(mock, repository.QuerySelectTransaction, "transactions")
+		}
+		mock.
+			ExpectPrepare(transactionStmt).
+			ExpectExec().
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectAuditLog(mock, model.EntityTypeTransaction)
+	}
+}
+
+func TestTransfersRepository(t *testing.T) {
+
+	t.Run("createTransfer", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transfers WHERE transfers.entity_id = ?").
+				WithArgs(transfersTestTransferModel.ID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(transfersStmtInsert).
+				ExpectExec().
+				WithArgs(
+					transfersTestTransferModel.ID,
+					transfersTestFromAccountID,
+					transfersTestToAccountID,
+					transfersTestTransferModel.Date,
+					transfersTestTransferModel.Amount,
+					transfersTestTransferModel.Note,
+					transfersTestTransferModel.FromBalanceID,
+					transfersTestTransferModel.ToBalanceID,
+					transfersTestTransferModel.FromTransactionID,
+					transfersTestTransferModel.ToTransactionID,
+					transfersTestTransferModel.Created,
+					transfersTestUserID,
+					nil,
+					nil,
+					nil,
+					nil,
+				).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTransfer)
+
+			expectTransferEntries(mock, bankAccountBalancesStmtInsert, false, transactionsStmtInsert)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(transfersTestTransferModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("alreadyExists", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transfers WHERE transfers.entity_id = ?").
+				WithArgs(transfersTestTransferModel.ID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(transfersTestTransferModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("failOnSecondSide", func(t *testing.T) {
+			errMsg := "cannot insert balance"
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transfers WHERE transfers.entity_id = ?").
+				WithArgs(transfersTestTransferModel.ID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			mock.ExpectBegin()
+
+			mock.
+				ExpectPrepare(transfersStmtInsert).
+				ExpectExec().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTransfer)
+
+			mock.
+				ExpectPrepare(bankAccountBalancesStmtInsert).
+				ExpectExec().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeBankAccountBalance)
+
+			mock.
+				ExpectPrepare(bankAccountBalancesStmtInsert).
+				ExpectExec().
+				WillReturnError(errors.New(errMsg))
+
+			mock.ExpectRollback()
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Create(transfersTestTransferModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), errMsg)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("existsTransferByTransactionID", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			transactionID := transfersTestTransferModel.FromTransactionID
+
+			mock.
+				ExpectQuery(`SELECT COUNT(entity_id) > 0 FROM transfers
+		WHERE (transfers.from_transaction_entity_id = ? OR transfers.to_transaction_entity_id = ?)
+		AND transfers.deleted IS NULL`).
+				WithArgs(transactionID.String(), transactionID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			exists, err := repo.ExistsByTransactionID(transactionID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.True(t, exists)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("existsTransferByBalanceID", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			balanceID := transfersTestTransferModel.ToBalanceID
+
+			mock.
+				ExpectQuery(`SELECT COUNT(entity_id) > 0 FROM transfers
+		WHERE (transfers.from_balance_entity_id = ? OR transfers.to_balance_entity_id = ?)
+		AND transfers.deleted IS NULL`).
+				WithArgs(balanceID.String(), balanceID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			exists, err := repo.ExistsByBalanceID(balanceID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.True(t, exists)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("existsTransferByBankAccountID", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(`SELECT COUNT(entity_id) > 0 FROM transfers
+		WHERE (transfers.from_bank_account_entity_id = ? OR transfers.to_bank_account_entity_id = ?)
+		AND transfers.deleted IS NULL`).
+				WithArgs(transfersTestFromAccountID.String(), transfersTestFromAccountID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			exists, err := repo.ExistsByBankAccountID(transfersTestFromAccountID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.True(t, exists)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveTransfersByIDs", func(t *testing.T) {
+
+		t.Run("normalNoID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			_, err := repo.ResolveByIDs([]uuid.UUID{})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("normalSingleID", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.ExpectQuery(repository.QuerySelectTransfer + " WHERE transfers.entity_id IN (?)").
+				WithArgs(transfersTestTransferModel.ID).
+				WillReturnRows(getSingleEntityIDResult(transfersTestTransferModel.ID))
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			transfers, err := repo.ResolveByIDs([]uuid.UUID{transfersTestTransferModel.ID})
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, transfers, 1)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("resolveTransfersByFilter", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery(repository.QuerySelectTransfer+"WHERE (((transfers.from_bank_account_entity_id IN (?)) OR (transfers.to_bank_account_entity_id IN (?)))) AND transfers.deleted IS NULL LIMIT ? OFFSET ?").
+				WithArgs(transfersTestFromAccountID, transfersTestFromAccountID, 10, 0).
+				WillReturnRows(getSingleEntityIDResult(transfersTestTransferModel.ID))
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) FROM transfers WHERE (((transfers.from_bank_account_entity_id IN (?)) OR (transfers.to_bank_account_entity_id IN (?)))) AND transfers.deleted IS NULL").
+				WithArgs(transfersTestFromAccountID, transfersTestFromAccountID).
+				WillReturnRows(getCountResult(1))
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			testFilter := model.TransferFilterInput{}
+			testFilter.BankAccountIDs = &[]uuid.UUID{transfersTestFromAccountID}
+
+			repo.Startup()
+			transfers, pageInfo, err := repo.ResolveByFilter(testFilter.ToFilter())
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, transfers, 1)
+			assert.Equal(t, 1, pageInfo.TotalCount)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("deleteTransfer", func(t *testing.T) {
+
+		previousBalanceID, _ := uuid.NewV7()
+
+		expectLastBalances := func(mock sqlmock.Sqlmock, bankAccountID, balanceID uuid.UUID, lastBalanceIDs []uuid.UUID) {
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccount + " WHERE bank_accounts.entity_id = ? FOR UPDATE").
+				WithArgs(bankAccountID.String()).
+				WillReturnRows(getSingleEntityIDResult(bankAccountID))
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccountBalance + " WHERE bank_account_balances.entity_id = ? FOR UPDATE").
+				WithArgs(balanceID.String()).
+				WillReturnRows(getSingleEntityIDResult(balanceID))
+			mock.
+				ExpectQuery(repository.QuerySelectBankAccountBalance + " WHERE bank_account_balances.bank_account_entity_id = ? AND bank_account_balances.deleted IS NULL ORDER BY bank_account_balances.date DESC, bank_account_balances.created DESC, bank_account_balances.entity_id DESC LIMIT 2 FOR UPDATE").
+				WithArgs(bankAccountID.String()).
+				WillReturnRows(getMultiEntityIDResult(lastBalanceIDs))
+		}
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transfers WHERE transfers.entity_id = ?").
+				WithArgs(transfersTestTransferModel.ID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectTransfer, "transfers")
+
+			expectLastBalances(mock, transfersTestFromAccountID, transfersTestTransferModel.FromBalanceID,
+				[]uuid.UUID{transfersTestTransferModel.FromBalanceID, previousBalanceID})
+			expectLastBalances(mock, transfersTestToAccountID, transfersTestTransferModel.ToBalanceID,
+				[]uuid.UUID{transfersTestTransferModel.ToBalanceID, previousBalanceID})
+
+			for _, transactionID := range []uuid.UUID{transfersTestTransferModel.FromTransactionID, transfersTestTransferModel.ToTransactionID} {
+				mock.
+					ExpectQuery(repository.QuerySelectTransaction + " WHERE transactions.entity_id = ? FOR UPDATE").
+					WithArgs(transactionID.String()).
+					WillReturnRows(getSingleEntityIDResult(transactionID))
+			}
+
+			expectSelectForUpdate(mock, repository.QuerySelectTransfer, "transfers")
+
+			mock.
+				ExpectPrepare(transfersStmtUpdate).
+				ExpectExec().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTransfer)
+
+			expectTransferEntries(mock, bankAccountBalancesStmtUpdate, true, transactionsStmtUpdate)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			transfer := transfersTestTransferModel
+			_ = transfer.Delete(transfersTestUserID)
+
+			repo.Startup()
+			deleted, err := repo.Delete(transfer, transfersTestUserID)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+			assert.Len(t, deleted.Balances, 2)
+			for _, balance := range deleted.Balances {
+				assert.True(t, balance.Deleted.Valid)
+			}
+			assert.Len(t, deleted.Transactions, 2)
+			for _, transaction := range deleted.Transactions {
+				assert.True(t, transaction.Deleted.Valid)
+			}
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("newerBalance", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			newerBalanceID, _ := uuid.NewV7()
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transfers WHERE transfers.entity_id = ?").
+				WithArgs(transfersTestTransferModel.ID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectTransfer, "transfers")
+
+			expectLastBalances(mock, transfersTestFromAccountID, transfersTestTransferModel.FromBalanceID,
+				[]uuid.UUID{newerBalanceID, transfersTestTransferModel.FromBalanceID})
+
+			mock.ExpectRollback()
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			transfer := transfersTestTransferModel
+			_ = transfer.Delete(transfersTestUserID)
+
+			repo.Startup()
+			_, err := repo.Delete(transfer, transfersTestUserID)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeOperationNotPermitted, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transfers WHERE transfers.entity_id = ?").
+				WithArgs(transfersTestTransferModel.ID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			_, err := repo.Delete(transfersTestTransferModel, transfersTestUserID)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+	t.Run("updateTransfer", func(t *testing.T) {
+
+		t.Run("normal", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transfers WHERE transfers.entity_id = ?").
+				WithArgs(transfersTestTransferModel.ID.String()).
+				WillReturnRows(getExistsResult(true))
+
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectTransfer, "transfers")
+
+			mock.
+				ExpectPrepare(transfersStmtUpdate).
+				ExpectExec().
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			expectAuditLog(mock, model.EntityTypeTransfer)
+
+			expectTransferEntries(mock, bankAccountBalancesStmtUpdate, true, transactionsStmtUpdate)
+
+			mock.ExpectCommit()
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(transfersTestTransferModel)
+			repo.Shutdown()
+
+			assert.Nil(t, err)
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+		t.Run("doesNotExist", func(t *testing.T) {
+			db, mock := getMockedDriver(sqlmock.QueryMatcherEqual)
+
+			mock.
+				ExpectQuery("SELECT COUNT(entity_id) > 0 FROM transfers WHERE transfers.entity_id = ?").
+				WithArgs(transfersTestTransferModel.ID.String()).
+				WillReturnRows(getExistsResult(false))
+
+			repo := new(repository.TransferMySQLRepo)
+			repo.DB = &db
+
+			repo.Startup()
+			err := repo.Update(transfersTestTransferModel)
+			repo.Shutdown()
+
+			assert.NotNil(t, err)
+			assert.Equal(t, failure.CodeEntityNotFound, failure.GetCode(err))
+
+			errMockExpectationsMet := mock.ExpectationsWereMet()
+
+			assert.Nil(t, errMockExpectationsMet)
+		})
+
+	})
+
+}
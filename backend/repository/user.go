@@ -2,11 +2,13 @@ package repository
 
 import (
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/kerti/balances/backend/database"
 	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/util/failure"
 	"github.com/kerti/balances/backend/util/filter"
 	"github.com/kerti/balances/backend/util/logger"
+	"github.com/kerti/balances/backend/util/nuuid"
 )
 
 const (
@@ -133,7 +135,7 @@ func (r *UserMySQLRepo) ResolveByFilter(filter filter.Filter) (users []model.Use
 
 	filterArgs := filter.GetArgs(true)
 	query, args, err := r.DB.In(
-		QuerySelectUser+filterQueryString+filter.Pagination.ToQueryString(),
+		QuerySelectUser+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
 		filterArgs...)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -150,8 +152,7 @@ func (r *UserMySQLRepo) ResolveByFilter(filter filter.Filter) (users []model.Use
 
 	var count int
 	filterArgsNoPagination := filter.GetArgs(false)
-	err = r.DB.Get(
-		&count,
+	query, args, err = r.DB.In(
 		"SELECT COUNT(entity_id) FROM users "+filterQueryString,
 		filterArgsNoPagination...)
 	if err != nil {
@@ -160,6 +161,13 @@ func (r *UserMySQLRepo) ResolveByFilter(filter filter.Filter) (users []model.Use
 		return
 	}
 
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		err = failure.InternalError("get by filter", "user", err)
+		return
+	}
+
 	pageInfo = model.PageInfoOutput{
 		Page:       filter.Pagination.Page,
 		PageSize:   filter.Pagination.PageSize,
@@ -184,36 +192,72 @@ func (r *UserMySQLRepo) Create(user model.User) error {
 		return err
 	}
 
-	stmt, err := r.DB.Prepare(QueryInsertUser)
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txCreateUser(tx, user); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
+}
+
+// Update updates a User
+func (r *UserMySQLRepo) Update(user model.User) error {
+	exists, err := r.ExistsByID(user.ID)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
 		return err
 	}
 
-	_, err = stmt.Exec(user)
-	if err != nil {
+	if !exists {
+		err = failure.EntityNotFound("update", "User")
 		logger.ErrNoStack("%v", err)
 		return err
 	}
 
-	return nil
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		if err := r.txUpdateUser(tx, user); err != nil {
+			e <- err
+			return
+		}
+
+		e <- nil
+	})
 }
 
-// Update updates a User
-func (r *UserMySQLRepo) Update(user model.User) error {
-	exists, err := r.ExistsByID(user.ID)
+func (r *UserMySQLRepo) txCreateUser(tx *sqlx.Tx, user model.User) error {
+	stmt, err := tx.PrepareNamed(QueryInsertUser)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
 		return err
 	}
 
-	if !exists {
-		err = failure.EntityNotFound("update", "User")
+	_, err = stmt.Exec(user)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeUser,
+		user.ID,
+		model.AuditActionCreate,
+		user.CreatedBy,
+		nil,
+		user.ToOutput())
+}
+
+func (r *UserMySQLRepo) txUpdateUser(tx *sqlx.Tx, user model.User) error {
+	var before model.User
+	err := tx.Get(&before, QuerySelectUser+" WHERE users.entity_id = ? FOR UPDATE", user.ID.String())
+	if err != nil {
 		logger.ErrNoStack("%v", err)
 		return err
 	}
 
-	stmt, err := r.DB.Prepare(QueryUpdateUser)
+	stmt, err := tx.PrepareNamed(QueryUpdateUser)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
 		return err
@@ -225,5 +269,14 @@ func (r *UserMySQLRepo) Update(user model.User) error {
 		return err
 	}
 
-	return nil
+	// users are never deleted, and their snapshots never contain the password hash
+	action, actorID := model.GetAuditAction(false, user.CreatedBy, user.UpdatedBy, nuuid.NUUID{})
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeUser,
+		user.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		user.ToOutput())
 }
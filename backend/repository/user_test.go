@@ -52,6 +52,8 @@ func TestUserRepository(t *testing.T) {
 				WithArgs(userTestID1.String()).
 				WillReturnRows(getExistsResult(false))
 
+			mock.ExpectBegin()
+
 			mock.
 				ExpectPrepare(userStmtInsert).
 				ExpectExec().
@@ -67,6 +69,10 @@ func TestUserRepository(t *testing.T) {
 					nil).
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeUser)
+
+			mock.ExpectCommit()
+
 			repo := new(repository.UserMySQLRepo)
 			repo.DB = &db
 
@@ -135,10 +141,14 @@ func TestUserRepository(t *testing.T) {
 				WithArgs(userTestID1.String()).
 				WillReturnRows(getExistsResult(false))
 
+			mock.ExpectBegin()
+
 			mock.
 				ExpectPrepare(userStmtInsert).
 				WillReturnError(errors.New(""))
 
+			mock.ExpectRollback()
+
 			repo := new(repository.UserMySQLRepo)
 			repo.DB = &db
 
@@ -161,6 +171,8 @@ func TestUserRepository(t *testing.T) {
 				WithArgs(userTestID1.String()).
 				WillReturnRows(getExistsResult(false))
 
+			mock.ExpectBegin()
+
 			mock.
 				ExpectPrepare(userStmtInsert).
 				ExpectExec().
@@ -176,6 +188,8 @@ func TestUserRepository(t *testing.T) {
 					nil).
 				WillReturnError(errors.New(""))
 
+			mock.ExpectRollback()
+
 			repo := new(repository.UserMySQLRepo)
 			repo.DB = &db
 
@@ -423,12 +437,20 @@ func TestUserRepository(t *testing.T) {
 				WithArgs(userTestID1.String()).
 				WillReturnRows(getExistsResult(true))
 
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectUser, "users")
+
 			mock.
 				ExpectPrepare(userStmtUpdate).
 				ExpectExec().
 				WithArgs().
 				WillReturnResult(sqlmock.NewResult(1, 1))
 
+			expectAuditLog(mock, model.EntityTypeUser)
+
+			mock.ExpectCommit()
+
 			repo := new(repository.UserMySQLRepo)
 			repo.DB = &db
 
@@ -497,10 +519,16 @@ func TestUserRepository(t *testing.T) {
 				WithArgs(userTestID1.String()).
 				WillReturnRows(getExistsResult(true))
 
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectUser, "users")
+
 			mock.
 				ExpectPrepare(userStmtUpdate).
 				WillReturnError(errors.New(""))
 
+			mock.ExpectRollback()
+
 			repo := new(repository.UserMySQLRepo)
 			repo.DB = &db
 
@@ -523,12 +551,18 @@ func TestUserRepository(t *testing.T) {
 				WithArgs(userTestID1.String()).
 				WillReturnRows(getExistsResult(true))
 
+			mock.ExpectBegin()
+
+			expectSelectForUpdate(mock, repository.QuerySelectUser, "users")
+
 			mock.
 				ExpectPrepare(userStmtUpdate).
 				ExpectExec().
 				WithArgs().
 				WillReturnError(errors.New(""))
 
+			mock.ExpectRollback()
+
 			repo := new(repository.UserMySQLRepo)
 			repo.DB = &db
 
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 	"github.com/kerti/balances/backend/database"
@@ -48,7 +50,8 @@ const (
 			vehicle_values.updated,
 			vehicle_values.updated_by,
 			vehicle_values.deleted,
-			vehicle_values.deleted_by
+			vehicle_values.deleted_by,
+			vehicle_values.deleted_by_cascade
 		FROM
 			vehicle_values `
 
@@ -110,7 +113,8 @@ const (
 			updated,
 			updated_by,
 			deleted,
-			deleted_by
+			deleted_by,
+			deleted_by_cascade
 		) VALUES (
 			:entity_id,
 			:vehicle_entity_id,
@@ -121,7 +125,8 @@ const (
 			:updated,
 			:updated_by,
 			:deleted,
-			:deleted_by
+			:deleted_by,
+			:deleted_by_cascade
 		)`
 
 	QueryUpdateVehicle = `
@@ -160,7 +165,8 @@ const (
 			updated = :updated,
 			updated_by = :updated_by,
 			deleted = :deleted,
-			deleted_by = :deleted_by
+			deleted_by = :deleted_by,
+			deleted_by_cascade = :deleted_by_cascade
 		WHERE entity_id = :entity_id`
 )
 
@@ -251,6 +257,10 @@ func (r *VehicleMySQLRepo) ResolveValuesByIDs(ids []uuid.UUID) (vehicleValues []
 
 // ResolveByFilter resolves Vehicles by a specified filter
 func (r *VehicleMySQLRepo) ResolveByFilter(filter filter.Filter) (vehicles []model.Vehicle, pageInfo model.PageInfoOutput, err error) {
+	if filter.Err != nil {
+		return vehicles, pageInfo, filter.Err
+	}
+
 	filterQueryString, err := filter.ToQueryString()
 	if err != nil {
 		err = failure.InternalError("resolve by filter", "Vehicle", err)
@@ -259,7 +269,7 @@ func (r *VehicleMySQLRepo) ResolveByFilter(filter filter.Filter) (vehicles []mod
 
 	filterArgs := filter.GetArgs(true)
 	query, args, err := r.DB.In(
-		QuerySelectVehicle+filterQueryString+filter.Pagination.ToQueryString(),
+		QuerySelectVehicle+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
 		filterArgs...)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -276,8 +286,7 @@ func (r *VehicleMySQLRepo) ResolveByFilter(filter filter.Filter) (vehicles []mod
 
 	var count int
 	filterArgsNoPagination := filter.GetArgs(false)
-	err = r.DB.Get(
-		&count,
+	query, args, err = r.DB.In(
 		"SELECT COUNT(entity_id) FROM vehicles "+filterQueryString,
 		filterArgsNoPagination...)
 	if err != nil {
@@ -287,6 +296,14 @@ func (r *VehicleMySQLRepo) ResolveByFilter(filter filter.Filter) (vehicles []mod
 		return
 	}
 
+	err = r.DB.Get(&count, query, args...)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		err = failure.InternalError("resolve by filter", "Vehicle", err)
+		vehicles = []model.Vehicle{}
+		return
+	}
+
 	pageInfo = model.PageInfoOutput{
 		Page:       filter.Pagination.Page,
 		PageSize:   filter.Pagination.PageSize,
@@ -297,8 +314,26 @@ func (r *VehicleMySQLRepo) ResolveByFilter(filter filter.Filter) (vehicles []mod
 	return
 }
 
+// AggregateByFilter groups the Vehicles matching a specified filter and aggregates every group
+func (r *VehicleMySQLRepo) AggregateByFilter(filter filter.Filter) (aggregateRows []model.AggregateRow, err error) {
+	if filter.Err != nil {
+		return aggregateRows, filter.Err
+	}
+
+	aggregateRows, err = resolveAggregateByFilter(r.DB, filter)
+	if err != nil {
+		err = failure.InternalError("aggregate by filter", "Vehicle", err)
+	}
+
+	return
+}
+
 // ResolveValuesByFilter resolves Vehicle Values by a specified filter
 func (r *VehicleMySQLRepo) ResolveValuesByFilter(filter filter.Filter) (vehicleValues []model.VehicleValue, pageInfo model.PageInfoOutput, err error) {
+	if filter.Err != nil {
+		return vehicleValues, pageInfo, filter.Err
+	}
+
 	filterQueryString, err := filter.ToQueryString()
 	if err != nil {
 		err = failure.InternalError("resolve by filter", "Vehicle Value", err)
@@ -307,7 +342,7 @@ func (r *VehicleMySQLRepo) ResolveValuesByFilter(filter filter.Filter) (vehicleV
 
 	filterArgs := filter.GetArgs(true)
 	query, args, err := r.DB.In(
-		QuerySelectVehicleValues+filterQueryString+filter.Pagination.ToQueryString(),
+		QuerySelectVehicleValues+filterQueryString+filter.ToOrderString()+filter.Pagination.ToQueryString(),
 		filterArgs...)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -322,6 +357,13 @@ func (r *VehicleMySQLRepo) ResolveValuesByFilter(filter filter.Filter) (vehicleV
 		return
 	}
 
+	if filter.Pagination.IsKeyset() {
+		vehicleValues, pageInfo = pageByKeyset(vehicleValues, filter.Pagination, func(value model.VehicleValue) (time.Time, uuid.UUID) {
+			return value.Date, value.ID
+		})
+		return
+	}
+
 	var count int
 	filterArgsNoPagination := filter.GetArgs(false)
 	query, args, err = r.DB.In(
@@ -409,7 +451,8 @@ func (r *VehicleMySQLRepo) Create(vehicle model.Vehicle) error {
 	})
 }
 
-// Update updates a Vehicle
+// Update updates a Vehicle along with any Vehicle Values attached to it, such as those
+// cascaded by a delete or a restore
 func (r *VehicleMySQLRepo) Update(vehicle model.Vehicle) error {
 	exists, err := r.ExistsByID(vehicle.ID)
 	if err != nil {
@@ -430,6 +473,14 @@ func (r *VehicleMySQLRepo) Update(vehicle model.Vehicle) error {
 			return
 		}
 
+		for _, value := range vehicle.Values {
+			if err := r.txUpdateVehicleValue(tx, value); err != nil {
+				err = failure.InternalError("update", "Vehicle", err)
+				e <- err
+				return
+			}
+		}
+
 		e <- nil
 	})
 }
@@ -467,6 +518,30 @@ func (r *VehicleMySQLRepo) CreateValue(vehicleValue model.VehicleValue, vehicle
 	})
 }
 
+// ImportValues creates imported Vehicle Values and optionally updates the Vehicle in a single transaction,
+// so that either every Value is imported or none is
+func (r *VehicleMySQLRepo) ImportValues(vehicleValues []model.VehicleValue, vehicle *model.Vehicle) error {
+	return r.DB.WithTransaction(r.DB, func(tx *sqlx.Tx, e chan error) {
+		for _, vehicleValue := range vehicleValues {
+			if err := r.txCreateVehicleValue(tx, vehicleValue); err != nil {
+				err = failure.InternalError("import", "Vehicle Value", err)
+				e <- err
+				return
+			}
+		}
+
+		if vehicle != nil {
+			if err := r.txUpdateVehicle(tx, *vehicle); err != nil {
+				err = failure.InternalError("import", "Vehicle Value", err)
+				e <- err
+				return
+			}
+		}
+
+		e <- nil
+	})
+}
+
 // UpdateValue updates an existing Vehicle Value and optionally updates the Vehicle transactionally
 func (r *VehicleMySQLRepo) UpdateValue(vehicleValue model.VehicleValue, vehicle *model.Vehicle) error {
 	exists, err := r.ExistsValueByID(vehicleValue.ID)
@@ -513,7 +588,14 @@ func (r *VehicleMySQLRepo) txCreateVehicle(tx *sqlx.Tx, vehicle model.Vehicle) e
 		return err
 	}
 
-	return nil
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeVehicle,
+		vehicle.ID,
+		model.AuditActionCreate,
+		vehicle.CreatedBy,
+		nil,
+		r.getVehicleSnapshot(vehicle))
 }
 
 func (r *VehicleMySQLRepo) txCreateVehicleValue(tx *sqlx.Tx, vehicleValue model.VehicleValue) error {
@@ -529,10 +611,24 @@ func (r *VehicleMySQLRepo) txCreateVehicleValue(tx *sqlx.Tx, vehicleValue model.
 		return err
 	}
 
-	return nil
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeVehicleValue,
+		vehicleValue.ID,
+		model.AuditActionCreate,
+		vehicleValue.CreatedBy,
+		nil,
+		vehicleValue.ToOutput())
 }
 
 func (r *VehicleMySQLRepo) txUpdateVehicle(tx *sqlx.Tx, vehicle model.Vehicle) error {
+	var before model.Vehicle
+	err := tx.Get(&before, QuerySelectVehicle+" WHERE vehicles.entity_id = ? FOR UPDATE", vehicle.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
 	stmt, err := tx.PrepareNamed(QueryUpdateVehicle)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -545,10 +641,25 @@ func (r *VehicleMySQLRepo) txUpdateVehicle(tx *sqlx.Tx, vehicle model.Vehicle) e
 		return err
 	}
 
-	return nil
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, vehicle.CreatedBy, vehicle.UpdatedBy, vehicle.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeVehicle,
+		vehicle.ID,
+		action,
+		actorID,
+		r.getVehicleSnapshot(before),
+		r.getVehicleSnapshot(vehicle))
 }
 
 func (r *VehicleMySQLRepo) txUpdateVehicleValue(tx *sqlx.Tx, vehicleValue model.VehicleValue) error {
+	var before model.VehicleValue
+	err := tx.Get(&before, QuerySelectVehicleValues+" WHERE vehicle_values.entity_id = ? FOR UPDATE", vehicleValue.ID.String())
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
 	stmt, err := tx.PrepareNamed(QueryUpdateVehicleValue)
 	if err != nil {
 		logger.ErrNoStack("%v", err)
@@ -561,5 +672,20 @@ func (r *VehicleMySQLRepo) txUpdateVehicleValue(tx *sqlx.Tx, vehicleValue model.
 		return err
 	}
 
-	return nil
+	action, actorID := model.GetAuditAction(before.Deleted.Valid, vehicleValue.CreatedBy, vehicleValue.UpdatedBy, vehicleValue.DeletedBy)
+	return txCreateAuditLog(
+		tx,
+		model.EntityTypeVehicleValue,
+		vehicleValue.ID,
+		action,
+		actorID,
+		before.ToOutput(),
+		vehicleValue.ToOutput())
+}
+
+// getVehicleSnapshot produces the audited representation of a Vehicle, leaving out
+// its Values as these are audited on their own
+func (r *VehicleMySQLRepo) getVehicleSnapshot(vehicle model.Vehicle) model.VehicleOutput {
+	vehicle.Values = nil
+	return vehicle.ToOutput()
 }
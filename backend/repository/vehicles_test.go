@@ -13,6 +13,7 @@ import (
 	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/repository"
 	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
 	"github.com/kerti/balances/backend/util/nuuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -24,15 +25,15 @@ var (
 	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
 
 	vehicleValuesStmtInsert = `INSERT INTO vehicle_values
-	( entity_id, vehicle_entity_id, date, value, created, created_by, updated, updated_by, deleted, deleted_by )
-	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
+	( entity_id, vehicle_entity_id, date, value, created, created_by, updated, updated_by, deleted, deleted_by, deleted_by_cascade )
+	VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )`
 
 	vehiclesStmtUpdate = `UPDATE vehicles
 	SET name = ?, make = ?, model = ?, year = ?, type = ?, title_holder = ?, license_plate_number = ?, purchase_date = ?, initial_value = ?, initial_value_date = ?, current_value = ?, current_value_date = ?, annual_depreciation_percent = ?, status = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
 	WHERE entity_id = ?`
 
 	vehicleValuesStmtUpdate = `UPDATE vehicle_values
-	SET vehicle_entity_id = ?, date = ?, value = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?
+	SET vehicle_entity_id = ?, date = ?, value = ?, created = ?, created_by = ?, updated = ?, updated_by = ?, deleted = ?, deleted_by = ?, deleted_by_cascade = ?
 	WHERE entity_id = ?`
 )
 
@@ -213,6 +214,7 @@ func (t *vehiclesRepositoryTestSuite) getArgsFromVehicleValueModel(vehicleValue
 	args = append(args, vehicleValue.UpdatedBy)
 	args = append(args, vehicleValue.Deleted)
 	args = append(args, vehicleValue.DeletedBy)
+	args = append(args, vehicleValue.DeletedByCascade)
 
 	if setIdLast {
 		args = append(args, vehicleValue.ID)
@@ -237,6 +239,8 @@ func (t *vehiclesRepositoryTestSuite) TestCreate_Normal() {
 		WithArgs(t.getArgsFromVehicleModel(testModel, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicle)
+
 	for _, valueModel := range testModel.Values {
 		t.sqlmock.
 			ExpectPrepare(vehicleValuesStmtInsert).
@@ -244,6 +248,8 @@ func (t *vehiclesRepositoryTestSuite) TestCreate_Normal() {
 			WithArgs(t.getArgsFromVehicleValueModel(valueModel, false)...).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
+		expectAuditLog(t.sqlmock, model.EntityTypeVehicleValue)
+
 	}
 
 	t.sqlmock.ExpectCommit()
@@ -363,6 +369,8 @@ func (t *vehiclesRepositoryTestSuite) TestCreate_FailOnPrepareVehicleValueStatem
 		WithArgs(t.getArgsFromVehicleModel(testModel, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicle)
+
 	t.sqlmock.
 		ExpectPrepare(vehicleValuesStmtInsert).
 		WillReturnError(errors.New(errMsg))
@@ -396,6 +404,8 @@ func (t *vehiclesRepositoryTestSuite) TestCreate_FailOnExecVehicleValueStatement
 		WithArgs(t.getArgsFromVehicleModel(testModel, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicle)
+
 	t.sqlmock.
 		ExpectPrepare(vehicleValuesStmtInsert).
 		ExpectExec().
@@ -434,12 +444,18 @@ func (t *vehiclesRepositoryTestSuite) TestCreateValue_Normal() {
 		WithArgs(t.getArgsFromVehicleValueModel(newValue, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicleValue)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
 	t.sqlmock.
 		ExpectPrepare(vehiclesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicle)
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.CreateValue(newValue, &vehicle)
@@ -463,6 +479,8 @@ func (t *vehiclesRepositoryTestSuite) TestCreateValue_NoVehicleUpdate() {
 		WithArgs(t.getArgsFromVehicleValueModel(newValue, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicleValue)
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.CreateValue(newValue, nil)
@@ -584,6 +602,10 @@ func (t *vehiclesRepositoryTestSuite) TestCreateValue_FailOnVehicleUpdate() {
 		WithArgs(t.getArgsFromVehicleValueModel(newValue, false)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicleValue)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
 	t.sqlmock.
 		ExpectPrepare(vehiclesStmtUpdate).
 		ExpectExec().
@@ -602,6 +624,67 @@ func (t *vehiclesRepositoryTestSuite) TestCreateValue_FailOnVehicleUpdate() {
 	assert.Contains(t.T(), err.Error(), errMsg)
 }
 
+func (t *vehiclesRepositoryTestSuite) TestImportValues_Normal() {
+	firstValue := t.getNewVehicleValueModel(nuuid.NUUID{Valid: false}, nuuid.From(t.testVehicleID), null.TimeFrom(time.Now().AddDate(0, -2, 0)), nil)
+	secondValue := t.getNewVehicleValueModel(nuuid.NUUID{Valid: false}, nuuid.From(t.testVehicleID), null.TimeFrom(time.Now().AddDate(0, -1, 0)), nil)
+	vehicle := t.getNewVehicleModel(nuuid.From(t.testVehicleID), 0)
+	vehicle.CurrentValue = secondValue.Value
+	vehicle.CurrentValueDate = secondValue.Date
+	vehicle.Updated = null.TimeFrom(time.Now())
+
+	t.sqlmock.ExpectBegin()
+
+	for _, value := range []model.VehicleValue{firstValue, secondValue} {
+		t.sqlmock.
+			ExpectPrepare(vehicleValuesStmtInsert).
+			ExpectExec().
+			WithArgs(t.getArgsFromVehicleValueModel(value, false)...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		expectAuditLog(t.sqlmock, model.EntityTypeVehicleValue)
+	}
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
+	t.sqlmock.
+		ExpectPrepare(vehiclesStmtUpdate).
+		ExpectExec().
+		WithArgs().
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicle)
+
+	t.sqlmock.ExpectCommit()
+
+	err := t.repo.ImportValues([]model.VehicleValue{firstValue, secondValue}, &vehicle)
+
+	assert.NoError(t.T(), err)
+}
+
+func (t *vehiclesRepositoryTestSuite) TestImportValues_FailOnExec() {
+	errMsg := "failed executing statement to create vehicle value"
+	newValue := t.getNewVehicleValueModel(nuuid.NUUID{Valid: false}, nuuid.From(t.testVehicleID), null.TimeFromPtr(nil), nil)
+
+	t.sqlmock.ExpectBegin()
+
+	t.sqlmock.
+		ExpectPrepare(vehicleValuesStmtInsert).
+		ExpectExec().
+		WithArgs(t.getArgsFromVehicleValueModel(newValue, false)...).
+		WillReturnError(errors.New(errMsg))
+
+	t.sqlmock.ExpectRollback()
+
+	err := t.repo.ImportValues([]model.VehicleValue{newValue}, nil)
+
+	assert.Error(t.T(), err)
+	assert.IsType(t.T(), &failure.Failure{}, err)
+	assert.Equal(t.T(), failure.CodeInternalError, err.(*failure.Failure).Code)
+	assert.Equal(t.T(), "Vehicle Value", *err.(*failure.Failure).Entity)
+	assert.Equal(t.T(), "import", *err.(*failure.Failure).Operation)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
 func (t *vehiclesRepositoryTestSuite) TestExistsByID_Normal() {
 	t.sqlmock.
 		ExpectQuery("SELECT COUNT(entity_id) > 0 FROM vehicles WHERE vehicles.entity_id = ?").
@@ -733,6 +816,42 @@ func (t *vehiclesRepositoryTestSuite) TestResolveByFilter_Normal() {
 	assert.Equal(t.T(), 10, pageInfo.PageSize)
 }
 
+func (t *vehiclesRepositoryTestSuite) TestResolveByFilter_NormalWithSort() {
+	t.sqlmock.
+		ExpectQuery(repository.QuerySelectVehicle+"WHERE vehicles.deleted IS NULL ORDER BY vehicles.make DESC, vehicles.name ASC LIMIT ? OFFSET ?").
+		WithArgs(10, 0).
+		WillReturnRows(getSingleEntityIDResult(t.testVehicleID))
+
+	t.sqlmock.ExpectQuery("SELECT COUNT(entity_id) FROM vehicles WHERE vehicles.deleted IS NULL").
+		WillReturnRows(getCountResult(1))
+
+	testFilter := model.VehicleFilterInput{}
+	testFilter.Sort = &[]filter.SortInput{
+		{Field: "make", Direction: filter.SortDirectionDesc},
+		{Field: "name", Direction: filter.SortDirectionAsc},
+	}
+
+	res, pageInfo, err := t.repo.ResolveByFilter(testFilter.ToFilter())
+
+	assert.NoError(t.T(), err)
+	assert.Len(t.T(), res, 1)
+	assert.Equal(t.T(), 1, pageInfo.TotalCount)
+}
+
+func (t *vehiclesRepositoryTestSuite) TestResolveByFilter_InvalidSort() {
+	testFilter := model.VehicleFilterInput{}
+	testFilter.Sort = &[]filter.SortInput{
+		{Field: "(SELECT 1)"},
+	}
+
+	res, _, err := t.repo.ResolveByFilter(testFilter.ToFilter())
+
+	assert.Error(t.T(), err)
+	assert.IsType(t.T(), &failure.Failure{}, err)
+	assert.Equal(t.T(), failure.CodeBadRequest, err.(*failure.Failure).Code)
+	assert.Len(t.T(), res, 0)
+}
+
 func (t *vehiclesRepositoryTestSuite) TestResolveByFilter_ErrorOnSelect() {
 	errMsg := "failed resolving vehicles by filter"
 	keyword := "example"
@@ -865,6 +984,48 @@ func (t *vehiclesRepositoryTestSuite) TestResolveValuesByFilter_Normal() {
 	assert.Equal(t.T(), 10, pageInfo.PageSize)
 }
 
+func (t *vehiclesRepositoryTestSuite) TestResolveValuesByFilter_NormalCursorNextPage() {
+	previousID, _ := uuid.NewV7()
+	olderID, _ := uuid.NewV7()
+	cursor := filter.Cursor{
+		Date:      time.Now(),
+		ID:        previousID,
+		Direction: filter.CursorDirectionNext,
+	}.Encode()
+
+	t.sqlmock.
+		ExpectQuery(repository.QuerySelectVehicleValues+"WHERE (((vehicle_values.date < ?) OR ((vehicle_values.date = ?) AND (vehicle_values.entity_id < ?)))) AND vehicle_values.deleted IS NULL ORDER BY vehicle_values.date DESC, vehicle_values.entity_id DESC LIMIT ?").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), previousID, 11).
+		WillReturnRows(getSingleEntityIDResult(olderID))
+
+	testFilter := model.VehicleValueFilterInput{}
+	testFilter.Cursor = &cursor
+
+	res, pageInfo, err := t.repo.ResolveValuesByFilter(testFilter.ToFilter())
+
+	assert.NoError(t.T(), err)
+	assert.Len(t.T(), res, 1)
+	assert.Equal(t.T(), olderID, res[0].ID)
+	assert.Equal(t.T(), 10, pageInfo.PageSize)
+	assert.Nil(t.T(), pageInfo.NextCursor)
+	assert.NotNil(t.T(), pageInfo.PrevCursor)
+}
+
+func (t *vehiclesRepositoryTestSuite) TestResolveValuesByFilter_CursorWithSort() {
+	paginationMode := filter.PaginationModeCursor
+	testFilter := model.VehicleValueFilterInput{}
+	testFilter.PaginationMode = &paginationMode
+	testFilter.Sort = &[]filter.SortInput{
+		{Field: "value"},
+	}
+
+	res, _, err := t.repo.ResolveValuesByFilter(testFilter.ToFilter())
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Len(t.T(), res, 0)
+}
+
 func (t *vehiclesRepositoryTestSuite) TestResolveValuesByFilter_ErrorOnSelect() {
 	errMsg := "failed resolving vehicle values by filter"
 
@@ -968,12 +1129,55 @@ func (t *vehiclesRepositoryTestSuite) TestUpdate_Normal() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
+	t.sqlmock.
+		ExpectPrepare(vehiclesStmtUpdate).
+		ExpectExec().
+		WithArgs(t.getArgsFromVehicleModel(testModel, true)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicle)
+
+	t.sqlmock.ExpectCommit()
+
+	err := t.repo.Update(testModel)
+
+	assert.NoError(t.T(), err)
+}
+
+func (t *vehiclesRepositoryTestSuite) TestUpdate_NormalWithValues() {
+	testModel := t.getNewVehicleModel(nuuid.From(t.testVehicleID), 2)
+
+	t.sqlmock.
+		ExpectQuery("SELECT COUNT(entity_id) > 0 FROM vehicles WHERE vehicles.entity_id = ?").
+		WithArgs(t.testVehicleID).
+		WillReturnRows(getExistsResult(true))
+
+	t.sqlmock.ExpectBegin()
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
 	t.sqlmock.
 		ExpectPrepare(vehiclesStmtUpdate).
 		ExpectExec().
 		WithArgs(t.getArgsFromVehicleModel(testModel, true)...).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicle)
+
+	for _, valueModel := range testModel.Values {
+		expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicleValues, "vehicle_values")
+
+		t.sqlmock.
+			ExpectPrepare(vehicleValuesStmtUpdate).
+			ExpectExec().
+			WithArgs(t.getArgsFromVehicleValueModel(valueModel, true)...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		expectAuditLog(t.sqlmock, model.EntityTypeVehicleValue)
+	}
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.Update(testModel)
@@ -981,6 +1185,47 @@ func (t *vehiclesRepositoryTestSuite) TestUpdate_Normal() {
 	assert.NoError(t.T(), err)
 }
 
+func (t *vehiclesRepositoryTestSuite) TestUpdate_FailOnUpdateVehicleValue() {
+	errMsg := "failed executing update statement for vehicle value"
+	testModel := t.getNewVehicleModel(nuuid.From(t.testVehicleID), 2)
+
+	t.sqlmock.
+		ExpectQuery("SELECT COUNT(entity_id) > 0 FROM vehicles WHERE vehicles.entity_id = ?").
+		WithArgs(t.testVehicleID).
+		WillReturnRows(getExistsResult(true))
+
+	t.sqlmock.ExpectBegin()
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
+	t.sqlmock.
+		ExpectPrepare(vehiclesStmtUpdate).
+		ExpectExec().
+		WithArgs(t.getArgsFromVehicleModel(testModel, true)...).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicle)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicleValues, "vehicle_values")
+
+	t.sqlmock.
+		ExpectPrepare(vehicleValuesStmtUpdate).
+		ExpectExec().
+		WithArgs(t.getArgsFromVehicleValueModel(testModel.Values[0], true)...).
+		WillReturnError(errors.New(errMsg))
+
+	t.sqlmock.ExpectRollback()
+
+	err := t.repo.Update(testModel)
+
+	assert.Error(t.T(), err)
+	assert.IsType(t.T(), &failure.Failure{}, err)
+	assert.Equal(t.T(), failure.CodeInternalError, err.(*failure.Failure).Code)
+	assert.Equal(t.T(), "Vehicle", *err.(*failure.Failure).Entity)
+	assert.Equal(t.T(), "update", *err.(*failure.Failure).Operation)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
 func (t *vehiclesRepositoryTestSuite) TestUpdate_ErrorOnCheckExistence() {
 	errMsg := "failed checking the existence of vehicle"
 	testModel := t.getNewVehicleModel(nuuid.From(t.testVehicleID), 0)
@@ -1029,6 +1274,8 @@ func (t *vehiclesRepositoryTestSuite) TestUpdate_FailOnPrepare() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
 	t.sqlmock.
 		ExpectPrepare(vehiclesStmtUpdate).
 		WillReturnError(errors.New(errMsg))
@@ -1056,6 +1303,8 @@ func (t *vehiclesRepositoryTestSuite) TestUpdate_FailOnExec() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
 	t.sqlmock.
 		ExpectPrepare(vehiclesStmtUpdate).
 		ExpectExec().
@@ -1090,18 +1339,26 @@ func (t *vehiclesRepositoryTestSuite) TestUpdateValue_Normal() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicleValues, "vehicle_values")
+
 	t.sqlmock.
 		ExpectPrepare(vehicleValuesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicleValue)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
 	t.sqlmock.
 		ExpectPrepare(vehiclesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicle)
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.UpdateValue(vehicleValueModel, &vehicleModel)
@@ -1123,12 +1380,16 @@ func (t *vehiclesRepositoryTestSuite) TestUpdateValue_Normal_NoAccountUpdate() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicleValues, "vehicle_values")
+
 	t.sqlmock.
 		ExpectPrepare(vehicleValuesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicleValue)
+
 	t.sqlmock.ExpectCommit()
 
 	err := t.repo.UpdateValue(vehicleValueModel, nil)
@@ -1196,6 +1457,8 @@ func (t *vehiclesRepositoryTestSuite) TestUpdateValue_FailOnPrepare() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicleValues, "vehicle_values")
+
 	t.sqlmock.
 		ExpectPrepare(vehicleValuesStmtUpdate).
 		WillReturnError(errors.New(errMsg))
@@ -1227,6 +1490,8 @@ func (t *vehiclesRepositoryTestSuite) TestUpdateValue_FailOnExec() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicleValues, "vehicle_values")
+
 	t.sqlmock.
 		ExpectPrepare(vehicleValuesStmtUpdate).
 		ExpectExec().
@@ -1262,12 +1527,18 @@ func (t *vehiclesRepositoryTestSuite) TestUpdateValue_FailOnVehicleUpdate() {
 
 	t.sqlmock.ExpectBegin()
 
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicleValues, "vehicle_values")
+
 	t.sqlmock.
 		ExpectPrepare(vehicleValuesStmtUpdate).
 		ExpectExec().
 		WithArgs().
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectAuditLog(t.sqlmock, model.EntityTypeVehicleValue)
+
+	expectSelectForUpdate(t.sqlmock, repository.QuerySelectVehicle, "vehicles")
+
 	t.sqlmock.
 		ExpectPrepare(vehiclesStmtUpdate).
 		ExpectExec().
@@ -24,6 +24,31 @@ func (s *Server) InitRoutes() {
 	// Authentication/Authorization
 	s.router.HandleFunc("/auth/login", s.AuthHandler.HandleAuthLogin).Methods("POST")
 	s.router.HandleFunc("/auth/token", s.AuthHandler.HandleGetToken).Methods("GET")
+	s.router.HandleFunc("/.well-known/jwks.json", s.AuthHandler.HandleGetJWKS).Methods("GET")
+
+	// API Keys
+	s.router.HandleFunc("/apiKeys", s.APIKeyHandler.HandleCreateAPIKey).Methods("POST")
+	s.router.HandleFunc("/apiKeys/search", s.APIKeyHandler.HandleGetAPIKeyByFilter).Methods("POST")
+	s.router.HandleFunc("/apiKeys/{id}", s.APIKeyHandler.HandleRevokeAPIKey).Methods("DELETE")
+
+	// Administration
+	s.router.HandleFunc("/admin/purge", s.PurgeHandler.HandlePurge).Methods("POST")
+
+	// Archives
+	s.router.HandleFunc("/export", s.ArchiveHandler.HandleExport).Methods("GET")
+	s.router.HandleFunc("/import/archive", s.ArchiveHandler.HandleRestore).Methods("POST")
+
+	// Audit Logs
+	s.router.HandleFunc("/audit/search", s.AuditLogHandler.HandleGetAuditLogByFilter).Methods("POST")
+
+	// Reports
+	s.router.HandleFunc("/reports/changes", s.ReportHandler.HandleGetAssetChange).Methods("GET")
+	s.router.HandleFunc("/reports/networth.pdf", s.ReportHandler.HandleGetNetWorthPDF).Methods("GET")
+	s.router.HandleFunc("/reports/networth.xlsx", s.ReportHandler.HandleGetNetWorthXLSX).Methods("GET")
+	s.router.HandleFunc("/reports/budgets", s.BudgetHandler.HandleGetBudgetReport).Methods("GET")
+
+	// Search
+	s.router.HandleFunc("/search", s.SearchHandler.HandleSearch).Methods("POST")
 
 	// Users
 	s.router.HandleFunc("/users/{id}", s.UserHandler.HandleGetUserByID).Methods("GET")
@@ -37,11 +62,98 @@ func (s *Server) InitRoutes() {
 	s.router.HandleFunc("/bankAccounts/search", s.BankAccountHandler.HandleGetBankAccountByFilter).Methods("POST")
 	s.router.HandleFunc("/bankAccounts/{id}", s.BankAccountHandler.HandleUpdateBankAccount).Methods("PATCH")
 	s.router.HandleFunc("/bankAccounts/{id}", s.BankAccountHandler.HandleDeleteBankAccount).Methods("DELETE")
+	s.router.HandleFunc("/bankAccounts/{id}/restore", s.BankAccountHandler.HandleRestoreBankAccount).Methods("POST")
 	s.router.HandleFunc("/bankAccounts/balances", s.BankAccountHandler.HandleCreateBankAccountBalance).Methods("POST")
+	s.router.HandleFunc("/bankAccounts/balances/import", s.BankAccountHandler.HandleImportBankAccountBalances).Methods("POST")
+	s.router.HandleFunc("/bankAccounts/balances/statements", s.BankAccountHandler.HandleImportBankStatement).Methods("POST")
 	s.router.HandleFunc("/bankAccounts/balances/{id}", s.BankAccountHandler.HandleGetBankAccountBalanceByID).Methods("GET")
 	s.router.HandleFunc("/bankAccounts/balances/search", s.BankAccountHandler.HandleGetBankAccountBalanceByFilter).Methods("POST")
 	s.router.HandleFunc("/bankAccounts/balances/{id}", s.BankAccountHandler.HandleUpdateBankAccountBalance).Methods("PATCH")
 	s.router.HandleFunc("/bankAccounts/balances/{id}", s.BankAccountHandler.HandleDeleteBankAccountBalance).Methods("DELETE")
+	s.router.HandleFunc("/bankAccounts/cashFlows", s.BankAccountHandler.HandleCreateBankAccountCashFlow).Methods("POST")
+	s.router.HandleFunc("/bankAccounts/cashFlows/{id}", s.BankAccountHandler.HandleGetBankAccountCashFlowByID).Methods("GET")
+	s.router.HandleFunc("/bankAccounts/cashFlows/search", s.BankAccountHandler.HandleGetBankAccountCashFlowByFilter).Methods("POST")
+	s.router.HandleFunc("/bankAccounts/cashFlows/{id}", s.BankAccountHandler.HandleUpdateBankAccountCashFlow).Methods("PATCH")
+	s.router.HandleFunc("/bankAccounts/cashFlows/{id}", s.BankAccountHandler.HandleDeleteBankAccountCashFlow).Methods("DELETE")
+	s.router.HandleFunc("/bankAccounts/{id}/returns", s.BankAccountHandler.HandleGetBankAccountReturns).Methods("GET")
+	s.router.HandleFunc("/bankAccounts/{id}/reconciliation", s.TransactionHandler.HandleGetBankAccountReconciliation).Methods("GET")
+	s.router.HandleFunc("/bankAccounts/{id}/goals", s.GoalHandler.HandleGetBankAccountGoals).Methods("GET")
+
+	// Transactions
+	s.router.HandleFunc("/transactions", s.TransactionHandler.HandleCreateTransaction).Methods("POST")
+	s.router.HandleFunc("/transactions/{id}", s.TransactionHandler.HandleGetTransactionByID).Methods("GET")
+	s.router.HandleFunc("/transactions/search", s.TransactionHandler.HandleGetTransactionByFilter).Methods("POST")
+	s.router.HandleFunc("/transactions/{id}", s.TransactionHandler.HandleUpdateTransaction).Methods("PATCH")
+	s.router.HandleFunc("/transactions/{id}", s.TransactionHandler.HandleDeleteTransaction).Methods("DELETE")
+
+	// Transfers
+	s.router.HandleFunc("/transfers", s.TransferHandler.HandleCreateTransfer).Methods("POST")
+	s.router.HandleFunc("/transfers/{id}", s.TransferHandler.HandleGetTransferByID).Methods("GET")
+	s.router.HandleFunc("/transfers/search", s.TransferHandler.HandleGetTransferByFilter).Methods("POST")
+	s.router.HandleFunc("/transfers/{id}", s.TransferHandler.HandleDeleteTransfer).Methods("DELETE")
+
+	// Categories
+	s.router.HandleFunc("/categories", s.CategoryHandler.HandleCreateCategory).Methods("POST")
+	s.router.HandleFunc("/categories", s.CategoryHandler.HandleGetCategoryTree).Methods("GET")
+	s.router.HandleFunc("/categories/{id}", s.CategoryHandler.HandleGetCategoryByID).Methods("GET")
+	s.router.HandleFunc("/categories/search", s.CategoryHandler.HandleGetCategoryByFilter).Methods("POST")
+	s.router.HandleFunc("/categories/{id}", s.CategoryHandler.HandleUpdateCategory).Methods("PATCH")
+	s.router.HandleFunc("/categories/{id}", s.CategoryHandler.HandleDeleteCategory).Methods("DELETE")
+	s.router.HandleFunc("/categories/rules", s.CategoryHandler.HandleCreateCategoryRule).Methods("POST")
+	s.router.HandleFunc("/categories/rules/apply", s.CategoryHandler.HandleApplyCategoryRules).Methods("POST")
+	s.router.HandleFunc("/categories/rules/{id}", s.CategoryHandler.HandleGetCategoryRuleByID).Methods("GET")
+	s.router.HandleFunc("/categories/rules/search", s.CategoryHandler.HandleGetCategoryRuleByFilter).Methods("POST")
+	s.router.HandleFunc("/categories/rules/{id}", s.CategoryHandler.HandleUpdateCategoryRule).Methods("PATCH")
+	s.router.HandleFunc("/categories/rules/{id}", s.CategoryHandler.HandleDeleteCategoryRule).Methods("DELETE")
+
+	// Budgets
+	s.router.HandleFunc("/budgets", s.BudgetHandler.HandleCreateBudget).Methods("POST")
+	s.router.HandleFunc("/budgets/{id}", s.BudgetHandler.HandleGetBudgetByID).Methods("GET")
+	s.router.HandleFunc("/budgets/search", s.BudgetHandler.HandleGetBudgetByFilter).Methods("POST")
+	s.router.HandleFunc("/budgets/{id}", s.BudgetHandler.HandleUpdateBudget).Methods("PATCH")
+	s.router.HandleFunc("/budgets/{id}", s.BudgetHandler.HandleDeleteBudget).Methods("DELETE")
+
+	// Goals
+	s.router.HandleFunc("/goals", s.GoalHandler.HandleCreateGoal).Methods("POST")
+	s.router.HandleFunc("/goals/{id}", s.GoalHandler.HandleGetGoalByID).Methods("GET")
+	s.router.HandleFunc("/goals/search", s.GoalHandler.HandleGetGoalByFilter).Methods("POST")
+	s.router.HandleFunc("/goals/{id}", s.GoalHandler.HandleUpdateGoal).Methods("PATCH")
+	s.router.HandleFunc("/goals/{id}", s.GoalHandler.HandleDeleteGoal).Methods("DELETE")
+	s.router.HandleFunc("/goals/{id}/progress", s.GoalHandler.HandleGetGoalProgress).Methods("GET")
+
+	// Tags
+	s.router.HandleFunc("/tags", s.TagHandler.HandleCreateTag).Methods("POST")
+	s.router.HandleFunc("/tags/{id}", s.TagHandler.HandleGetTagByID).Methods("GET")
+	s.router.HandleFunc("/tags/search", s.TagHandler.HandleGetTagByFilter).Methods("POST")
+	s.router.HandleFunc("/tags/{id}", s.TagHandler.HandleUpdateTag).Methods("PATCH")
+	s.router.HandleFunc("/tags/{id}", s.TagHandler.HandleDeleteTag).Methods("DELETE")
+	s.router.HandleFunc("/tags/entities/{entityType}/{id}", s.TagHandler.HandleGetEntityTags).Methods("GET")
+	s.router.HandleFunc("/tags/entities/{entityType}/{id}", s.TagHandler.HandleSetEntityTags).Methods("PATCH")
+
+	// Custom Fields
+	s.router.HandleFunc("/customFields", s.CustomFieldHandler.HandleCreateCustomField).Methods("POST")
+	s.router.HandleFunc("/customFields/{id}", s.CustomFieldHandler.HandleGetCustomFieldByID).Methods("GET")
+	s.router.HandleFunc("/customFields/search", s.CustomFieldHandler.HandleGetCustomFieldByFilter).Methods("POST")
+	s.router.HandleFunc("/customFields/{id}", s.CustomFieldHandler.HandleUpdateCustomField).Methods("PATCH")
+	s.router.HandleFunc("/customFields/{id}", s.CustomFieldHandler.HandleDeleteCustomField).Methods("DELETE")
+	s.router.HandleFunc("/customFields/values/{entityType}/{id}", s.CustomFieldHandler.HandleGetCustomFieldValues).Methods("GET")
+	s.router.HandleFunc("/customFields/values/{entityType}/{id}", s.CustomFieldHandler.HandleSetCustomFieldValues).Methods("PATCH")
+
+	// Attachments
+	s.router.HandleFunc("/attachments/{id}", s.AttachmentHandler.HandleGetAttachmentByID).Methods("GET")
+	s.router.HandleFunc("/attachments/search", s.AttachmentHandler.HandleGetAttachmentByFilter).Methods("POST")
+	s.router.HandleFunc("/attachments/{id}/download", s.AttachmentHandler.HandleDownloadAttachment).Methods("GET")
+	s.router.HandleFunc("/attachments/{id}", s.AttachmentHandler.HandleDeleteAttachment).Methods("DELETE")
+	s.router.HandleFunc("/attachments/entities/{entityType}/{id}", s.AttachmentHandler.HandleGetEntityAttachments).Methods("GET")
+	s.router.HandleFunc("/attachments/entities/{entityType}/{id}", s.AttachmentHandler.HandleCreateAttachment).Methods("POST")
+
+	// Notes
+	s.router.HandleFunc("/notes", s.NoteHandler.HandleCreateNote).Methods("POST")
+	s.router.HandleFunc("/notes/{id}", s.NoteHandler.HandleGetNoteByID).Methods("GET")
+	s.router.HandleFunc("/notes/search", s.NoteHandler.HandleGetNoteByFilter).Methods("POST")
+	s.router.HandleFunc("/notes/{id}", s.NoteHandler.HandleUpdateNote).Methods("PATCH")
+	s.router.HandleFunc("/notes/{id}", s.NoteHandler.HandleDeleteNote).Methods("DELETE")
+	s.router.HandleFunc("/notes/entities/{entityType}/{id}", s.NoteHandler.HandleGetEntityNotes).Methods("GET")
 
 	// Vehicles
 	s.router.HandleFunc("/vehicles", s.VehicleHandler.HandleCreateVehicle).Methods("POST")
@@ -49,7 +161,9 @@ func (s *Server) InitRoutes() {
 	s.router.HandleFunc("/vehicles/search", s.VehicleHandler.HandleGetVehicleByFilter).Methods("POST")
 	s.router.HandleFunc("/vehicles/{id}", s.VehicleHandler.HandleUpdateVehicle).Methods("PATCH")
 	s.router.HandleFunc("/vehicles/{id}", s.VehicleHandler.HandleDeleteVehicle).Methods("DELETE")
+	s.router.HandleFunc("/vehicles/{id}/restore", s.VehicleHandler.HandleRestoreVehicle).Methods("POST")
 	s.router.HandleFunc("/vehicles/values", s.VehicleHandler.HandleCreateVehicleValue).Methods("POST")
+	s.router.HandleFunc("/vehicles/values/import", s.VehicleHandler.HandleImportVehicleValues).Methods("POST")
 	s.router.HandleFunc("/vehicles/values/{id}", s.VehicleHandler.HandleGetVehicleValueByID).Methods("GET")
 	s.router.HandleFunc("/vehicles/values/search", s.VehicleHandler.HandleGetVehicleValueByFilter).Methods("POST")
 	s.router.HandleFunc("/vehicles/values/{id}", s.VehicleHandler.HandleUpdateVehicleValue).Methods("PATCH")
@@ -61,7 +175,9 @@ func (s *Server) InitRoutes() {
 	s.router.HandleFunc("/properties/search", s.PropertyHandler.HandleGetPropertyByFilter).Methods("POST")
 	s.router.HandleFunc("/properties/{id}", s.PropertyHandler.HandleUpdateProperty).Methods("PATCH")
 	s.router.HandleFunc("/properties/{id}", s.PropertyHandler.HandleDeleteProperty).Methods("DELETE")
+	s.router.HandleFunc("/properties/{id}/restore", s.PropertyHandler.HandleRestoreProperty).Methods("POST")
 	s.router.HandleFunc("/properties/values", s.PropertyHandler.HandleCreatePropertyValue).Methods("POST")
+	s.router.HandleFunc("/properties/values/import", s.PropertyHandler.HandleImportPropertyValues).Methods("POST")
 	s.router.HandleFunc("/properties/values/{id}", s.PropertyHandler.HandleGetPropertyValueByID).Methods("GET")
 	s.router.HandleFunc("/properties/values/search", s.PropertyHandler.HandleGetPropertyValueByFilter).Methods("POST")
 	s.router.HandleFunc("/properties/values/{id}", s.PropertyHandler.HandleUpdatePropertyValue).Methods("PATCH")
@@ -11,21 +11,43 @@ import (
 	"github.com/kerti/balances/backend/config"
 	"github.com/kerti/balances/backend/handler"
 	"github.com/kerti/balances/backend/handler/response"
+	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/service"
 	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/kerti/balances/backend/util/failure"
 	"github.com/kerti/balances/backend/util/logger"
 )
 
+const (
+	// apiKeyHeader is the request header carrying an API Key
+	apiKeyHeader = "X-API-Key"
+)
+
 // Server is the server instance
 type Server struct {
 	config             *config.Config
+	APIKeyHandler      handler.APIKey      `inject:"apiKeyHandler"`
+	ArchiveHandler     handler.Archive     `inject:"archiveHandler"`
+	AttachmentHandler  handler.Attachment  `inject:"attachmentHandler"`
+	AuditLogHandler    handler.AuditLog    `inject:"auditLogHandler"`
 	AuthHandler        handler.Auth        `inject:"authHandler"`
 	AuthService        service.Auth        `inject:"authService"`
 	BankAccountHandler handler.BankAccount `inject:"bankAccountHandler"`
+	BudgetHandler      handler.Budget      `inject:"budgetHandler"`
+	CategoryHandler    handler.Category    `inject:"categoryHandler"`
+	CustomFieldHandler handler.CustomField `inject:"customFieldHandler"`
+	GoalHandler        handler.Goal        `inject:"goalHandler"`
 	HealthHandler      handler.Health      `inject:"healthHandler"`
+	NoteHandler        handler.Note        `inject:"noteHandler"`
 	UserHandler        handler.User        `inject:"userHandler"`
 	VehicleHandler     handler.Vehicle     `inject:"vehicleHandler"`
 	PropertyHandler    handler.Property    `inject:"propertyHandler"`
+	PurgeHandler       handler.Purge       `inject:"purgeHandler"`
+	ReportHandler      handler.Report      `inject:"reportHandler"`
+	SearchHandler      handler.Search      `inject:"searchHandler"`
+	TagHandler         handler.Tag         `inject:"tagHandler"`
+	TransactionHandler handler.Transaction `inject:"transactionHandler"`
+	TransferHandler    handler.Transfer    `inject:"transferHandler"`
 	router             *mux.Router
 }
 
@@ -84,12 +106,31 @@ func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 func (s *Server) jwtMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-		// no JWT checks for preflights, health checks and logins
-		if r.Method == http.MethodOptions || r.RequestURI == "/health" || r.RequestURI == "/auth/login" {
+		// no JWT checks for preflights, health checks, logins and public keys
+		if r.Method == http.MethodOptions || r.RequestURI == "/health" || r.RequestURI == "/auth/login" || r.RequestURI == "/.well-known/jwks.json" {
 			next.ServeHTTP(w, r)
 			return
 		}
 
+		if apiKey := r.Header.Get(apiKeyHeader); len(apiKey) > 0 {
+			if isAPIKeyForbiddenPath(r.URL.Path) {
+				logger.Trace(fmt.Sprintf("API key used on forbidden path %s", r.URL.Path))
+				response.RespondWithError(w, failure.Forbidden("authorize", "API Key", "API keys cannot obtain tokens, manage API keys or export and restore archives"))
+				return
+			}
+
+			userID, err := s.AuthService.AuthorizeAPIKey(apiKey, s.getRequiredAPIKeyScope(r))
+			if err != nil {
+				logger.Trace(fmt.Sprintf("API key authorization failed: %v", err.Error()))
+				response.RespondWithError(w, err)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ctxprops.PropUserID, userID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
 		token := ""
 		tokenCookie, _ := r.Cookie(s.config.JWT.TokenCookie)
 		if tokenCookie != nil {
@@ -113,6 +154,32 @@ func (s *Server) jwtMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// isAPIKeyForbiddenPath determines whether a request path is out of reach of API keys of any scope, since API
+// keys must never be able to mint tokens, manage other API keys or move the whole database in or out
+func isAPIKeyForbiddenPath(path string) bool {
+	return strings.HasPrefix(path, "/auth/") ||
+		strings.HasPrefix(path, "/apiKeys") ||
+		path == "/export" ||
+		path == "/import/archive"
+}
+
+// getRequiredAPIKeyScope determines the API Key scope required to perform a request
+func (s *Server) getRequiredAPIKeyScope(r *http.Request) model.APIKeyScope {
+	path := r.URL.Path
+
+	if r.Method == http.MethodGet || strings.HasSuffix(path, "/search") {
+		return model.APIKeyScopeReadOnly
+	}
+
+	for _, prefix := range []string{"/bankAccounts/balances", "/bankAccounts/cashFlows", "/vehicles/values", "/properties/values", "/transactions", "/transfers"} {
+		if strings.HasPrefix(path, prefix) {
+			return model.APIKeyScopeBalancesWrite
+		}
+	}
+
+	return model.APIKeyScopeFullAccess
+}
+
 func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		headers := r.Header
@@ -128,7 +195,7 @@ func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 		}
 
 		w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, POST, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Authorization,Content-Type")
+		w.Header().Set("Access-Control-Allow-Headers", "Authorization,Content-Type,"+apiKeyHeader)
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 		w.Header().Set("Vary", "Origin")
 		next.ServeHTTP(w, r)
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	mock_service "github.com/kerti/balances/backend/mock/service"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/ctxprops"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTMiddleware(t *testing.T) {
+
+	t.Run("apiKeyForbiddenPaths", func(t *testing.T) {
+		for _, target := range []struct {
+			method string
+			path   string
+		}{
+			{http.MethodGet, "/auth/token"},
+			{http.MethodPost, "/apiKeys"},
+			{http.MethodGet, "/apiKeys"},
+			{http.MethodDelete, "/apiKeys/0190c1f0-0000-7000-8000-000000000000"},
+			{http.MethodGet, "/export"},
+			{http.MethodPost, "/import/archive"},
+		} {
+			t.Run(target.method+" "+target.path, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				// a full access key would pass authorization, so it must never be asked for
+				mockAuth := mock_service.NewMockAuth(ctrl)
+				s := &Server{AuthService: mockAuth}
+
+				nextCalled := false
+				next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					nextCalled = true
+				})
+
+				req := httptest.NewRequest(target.method, target.path, nil)
+				req.Header.Set(apiKeyHeader, "bal_fullaccesskey")
+				rr := httptest.NewRecorder()
+
+				s.jwtMiddleware(next).ServeHTTP(rr, req)
+
+				assert.Equal(t, http.StatusForbidden, rr.Result().StatusCode)
+				assert.False(t, nextCalled)
+			})
+		}
+	})
+
+	t.Run("apiKeyAllowedPath", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userID, _ := uuid.NewV7()
+		mockAuth := mock_service.NewMockAuth(ctrl)
+		mockAuth.EXPECT().AuthorizeAPIKey("bal_readonlykey", model.APIKeyScopeReadOnly).Return(&userID, nil)
+		s := &Server{AuthService: mockAuth}
+
+		var contextUserID *uuid.UUID
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contextUserID, _ = r.Context().Value(ctxprops.PropUserID).(*uuid.UUID)
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/bankAccounts", nil)
+		req.Header.Set(apiKeyHeader, "bal_readonlykey")
+		rr := httptest.NewRecorder()
+
+		s.jwtMiddleware(next).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+		assert.Equal(t, &userID, contextUserID)
+	})
+
+	t.Run("apiKeyStatuses", func(t *testing.T) {
+		userID, _ := uuid.NewV7()
+
+		for _, test := range []struct {
+			name   string
+			method string
+			path   string
+			revoke bool
+			status int
+		}{
+			{"scopePermitted", http.MethodGet, "/bankAccounts", false, http.StatusOK},
+			{"scopeMissing", http.MethodPost, "/bankAccounts/balances", false, http.StatusForbidden},
+			{"revoked", http.MethodGet, "/bankAccounts", true, http.StatusUnauthorized},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				apiKey, plaintext, err := model.NewAPIKeyFromInput(model.APIKeyInput{
+					Name:   "Spreadsheet Sync",
+					Scopes: []model.APIKeyScope{model.APIKeyScopeReadOnly},
+				}, userID)
+				assert.NoError(t, err)
+				if test.revoke {
+					apiKey.Revoke(userID)
+				}
+
+				// the real auth service decides the failure, so that its status is the one a client receives
+				mockRepo := mock_repository.NewMockAPIKey(ctrl)
+				mockRepo.EXPECT().ResolveByPrefix(apiKey.Prefix).Return(apiKey, nil)
+				mockRepo.EXPECT().UpdateLastUsed(apiKey.ID, gomock.Any()).Return(nil).AnyTimes()
+				s := &Server{AuthService: &service.AuthImpl{APIKeyRepository: mockRepo}}
+
+				next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				})
+
+				req := httptest.NewRequest(test.method, test.path, nil)
+				req.Header.Set(apiKeyHeader, plaintext)
+				rr := httptest.NewRecorder()
+
+				s.jwtMiddleware(next).ServeHTTP(rr, req)
+
+				assert.Equal(t, test.status, rr.Result().StatusCode)
+			})
+		}
+	})
+
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// APIKeyImpl is the service provider implementation
+type APIKeyImpl struct {
+	Repository repository.APIKey `inject:"apiKeyRepository"`
+}
+
+// Startup performs startup functions
+func (s *APIKeyImpl) Startup() {
+	logger.Trace("API Key Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *APIKeyImpl) Shutdown() {
+	logger.Trace("API Key Service shutting down...")
+}
+
+// Create creates a new API Key for the User. The returned plaintext key is not stored anywhere.
+func (s *APIKeyImpl) Create(input model.APIKeyInput, userID uuid.UUID) (*model.APIKey, *string, error) {
+	apiKey, plaintext, err := model.NewAPIKeyFromInput(input, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = s.Repository.Create(apiKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &apiKey, &plaintext, nil
+}
+
+// GetByFilter fetches a set of API Keys belonging to the User by its filter
+func (s *APIKeyImpl) GetByFilter(input model.APIKeyFilterInput, userID uuid.UUID) ([]model.APIKey, model.PageInfoOutput, error) {
+	input.UserIDs = &[]uuid.UUID{userID}
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// Revoke revokes an existing API Key belonging to the User
+func (s *APIKeyImpl) Revoke(id uuid.UUID, userID uuid.UUID) (*model.APIKey, error) {
+	apiKeys, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	// keys belonging to other users are reported as missing to avoid leaking their existence
+	if len(apiKeys) != 1 || apiKeys[0].UserID != userID {
+		return nil, failure.EntityNotFound("revoke", "API Key")
+	}
+
+	apiKey := apiKeys[0]
+
+	err = apiKey.Revoke(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &apiKey, nil
+}
@@ -0,0 +1,302 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type apiKeysServiceTestSuite struct {
+	suite.Suite
+	ctrl        *gomock.Controller
+	svc         service.APIKey
+	authSvc     service.Auth
+	mockRepo    *mock_repository.MockAPIKey
+	testUserID  uuid.UUID
+	testOtherID uuid.UUID
+}
+
+func TestAPIKeysService(t *testing.T) {
+	suite.Run(t, new(apiKeysServiceTestSuite))
+}
+
+func (t *apiKeysServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockAPIKey(t.ctrl)
+	t.svc = &service.APIKeyImpl{
+		Repository: t.mockRepo,
+	}
+	t.authSvc = &service.AuthImpl{
+		APIKeyRepository: t.mockRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testOtherID, _ = uuid.NewV7()
+	t.svc.Startup()
+	t.authSvc.Startup()
+}
+
+func (t *apiKeysServiceTestSuite) TearDownTest() {
+	t.authSvc.Shutdown()
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *apiKeysServiceTestSuite) getNewAPIKeyInput(scopes ...model.APIKeyScope) model.APIKeyInput {
+	return model.APIKeyInput{
+		Name:    "Spreadsheet Sync",
+		Scopes:  scopes,
+		Expires: cachetime.NCacheTime(null.TimeFrom(time.Now().AddDate(0, 1, 0))),
+	}
+}
+
+func (t *apiKeysServiceTestSuite) getNewAPIKey(scopes ...model.APIKeyScope) (model.APIKey, string) {
+	apiKey, plaintext, err := model.NewAPIKeyFromInput(t.getNewAPIKeyInput(scopes...), t.testUserID)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	return apiKey, plaintext
+}
+
+func (t *apiKeysServiceTestSuite) TestCreate_Normal() {
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	res, plaintext, err := t.svc.Create(t.getNewAPIKeyInput(model.APIKeyScopeBalancesWrite), t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.NotNil(t.T(), res)
+	assert.NotNil(t.T(), plaintext)
+	assert.Equal(t.T(), t.testUserID, res.UserID)
+	assert.Equal(t.T(), model.APIKeyScopes{model.APIKeyScopeBalancesWrite}, res.Scopes)
+	assert.True(t.T(), res.CompareKey(*plaintext))
+	assert.NotContains(t.T(), res.KeyHash, *plaintext)
+}
+
+func (t *apiKeysServiceTestSuite) TestCreate_DefaultScope() {
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	res, _, err := t.svc.Create(t.getNewAPIKeyInput(), t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), model.APIKeyScopes{model.APIKeyScopeReadOnly}, res.Scopes)
+}
+
+func (t *apiKeysServiceTestSuite) TestCreate_InvalidScope() {
+	res, plaintext, err := t.svc.Create(t.getNewAPIKeyInput("everything"), t.testUserID)
+
+	assert.Nil(t.T(), res)
+	assert.Nil(t.T(), plaintext)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "invalid API key scope")
+}
+
+func (t *apiKeysServiceTestSuite) TestCreate_ExpiredInPast() {
+	input := t.getNewAPIKeyInput()
+	input.Expires = cachetime.NCacheTime(null.TimeFrom(time.Now().AddDate(0, 0, -1)))
+
+	res, _, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), res)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "future")
+}
+
+func (t *apiKeysServiceTestSuite) TestCreate_RepoFailToCreate() {
+	errMsg := "failed to create API key"
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(errors.New(errMsg))
+
+	res, plaintext, err := t.svc.Create(t.getNewAPIKeyInput(), t.testUserID)
+
+	assert.Nil(t.T(), res)
+	assert.Nil(t.T(), plaintext)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
+func (t *apiKeysServiceTestSuite) TestGetByFilter_ForcesUser() {
+	input := model.APIKeyFilterInput{}
+	forced := input
+	forced.UserIDs = &[]uuid.UUID{t.testUserID}
+
+	t.mockRepo.EXPECT().ResolveByFilter(forced.ToFilter()).
+		Return([]model.APIKey{}, getDefaultPageInfo(), nil)
+
+	_, _, err := t.svc.GetByFilter(input, t.testUserID)
+
+	assert.NoError(t.T(), err)
+}
+
+func (t *apiKeysServiceTestSuite) TestRevoke_Normal() {
+	apiKey, _ := t.getNewAPIKey()
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{apiKey.ID}).
+		Return([]model.APIKey{apiKey}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	res, err := t.svc.Revoke(apiKey.ID, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), res.Revoked.Valid)
+	assert.Equal(t.T(), t.testUserID, res.RevokedBy.UUID)
+}
+
+func (t *apiKeysServiceTestSuite) TestRevoke_NotFound() {
+	apiKey, _ := t.getNewAPIKey()
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{apiKey.ID}).
+		Return([]model.APIKey{}, nil)
+
+	res, err := t.svc.Revoke(apiKey.ID, t.testUserID)
+
+	assert.Nil(t.T(), res)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "EntityNotFound")
+}
+
+func (t *apiKeysServiceTestSuite) TestRevoke_BelongsToOtherUser() {
+	apiKey, _ := t.getNewAPIKey()
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{apiKey.ID}).
+		Return([]model.APIKey{apiKey}, nil)
+
+	res, err := t.svc.Revoke(apiKey.ID, t.testOtherID)
+
+	assert.Nil(t.T(), res)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "EntityNotFound")
+}
+
+func (t *apiKeysServiceTestSuite) TestRevoke_AlreadyRevoked() {
+	apiKey, _ := t.getNewAPIKey()
+	apiKey.Revoke(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{apiKey.ID}).
+		Return([]model.APIKey{apiKey}, nil)
+
+	res, err := t.svc.Revoke(apiKey.ID, t.testUserID)
+
+	assert.Nil(t.T(), res)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "already revoked")
+}
+
+func (t *apiKeysServiceTestSuite) TestRevoke_RepoErrorUpdating() {
+	errMsg := "failed to update"
+	apiKey, _ := t.getNewAPIKey()
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{apiKey.ID}).
+		Return([]model.APIKey{apiKey}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(errors.New(errMsg))
+
+	res, err := t.svc.Revoke(apiKey.ID, t.testUserID)
+
+	assert.Nil(t.T(), res)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
+func (t *apiKeysServiceTestSuite) TestAuthorizeAPIKey_Normal() {
+	apiKey, plaintext := t.getNewAPIKey(model.APIKeyScopeBalancesWrite)
+
+	t.mockRepo.EXPECT().ResolveByPrefix(apiKey.Prefix).Return(apiKey, nil)
+	t.mockRepo.EXPECT().UpdateLastUsed(apiKey.ID, gomock.Any()).Return(nil)
+
+	userID, err := t.authSvc.AuthorizeAPIKey(plaintext, model.APIKeyScopeReadOnly)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), t.testUserID, *userID)
+}
+
+func (t *apiKeysServiceTestSuite) TestAuthorizeAPIKey_FailedRecordingUsage() {
+	apiKey, plaintext := t.getNewAPIKey()
+
+	t.mockRepo.EXPECT().ResolveByPrefix(apiKey.Prefix).Return(apiKey, nil)
+	t.mockRepo.EXPECT().UpdateLastUsed(apiKey.ID, gomock.Any()).Return(errors.New("failed"))
+
+	userID, err := t.authSvc.AuthorizeAPIKey(plaintext, model.APIKeyScopeReadOnly)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), t.testUserID, *userID)
+}
+
+func (t *apiKeysServiceTestSuite) TestAuthorizeAPIKey_Malformed() {
+	userID, err := t.authSvc.AuthorizeAPIKey("not-an-api-key", model.APIKeyScopeReadOnly)
+
+	assert.Nil(t.T(), userID)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "malformed")
+}
+
+func (t *apiKeysServiceTestSuite) TestAuthorizeAPIKey_NotFound() {
+	apiKey, plaintext := t.getNewAPIKey()
+
+	t.mockRepo.EXPECT().ResolveByPrefix(apiKey.Prefix).Return(model.APIKey{}, errors.New("no rows"))
+
+	userID, err := t.authSvc.AuthorizeAPIKey(plaintext, model.APIKeyScopeReadOnly)
+
+	assert.Nil(t.T(), userID)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "invalid API key")
+}
+
+func (t *apiKeysServiceTestSuite) TestAuthorizeAPIKey_SecretMismatch() {
+	apiKey, plaintext := t.getNewAPIKey()
+
+	t.mockRepo.EXPECT().ResolveByPrefix(apiKey.Prefix).Return(apiKey, nil)
+
+	userID, err := t.authSvc.AuthorizeAPIKey(plaintext+"x", model.APIKeyScopeReadOnly)
+
+	assert.Nil(t.T(), userID)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "invalid API key")
+}
+
+func (t *apiKeysServiceTestSuite) TestAuthorizeAPIKey_Revoked() {
+	apiKey, plaintext := t.getNewAPIKey()
+	apiKey.Revoke(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByPrefix(apiKey.Prefix).Return(apiKey, nil)
+
+	userID, err := t.authSvc.AuthorizeAPIKey(plaintext, model.APIKeyScopeReadOnly)
+
+	assert.Nil(t.T(), userID)
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeUnauthorized, failure.GetCode(err))
+	assert.Contains(t.T(), err.Error(), "revoked or expired")
+}
+
+func (t *apiKeysServiceTestSuite) TestAuthorizeAPIKey_Expired() {
+	apiKey, plaintext := t.getNewAPIKey()
+	apiKey.Expires = null.TimeFrom(time.Now().Add(-time.Minute))
+
+	t.mockRepo.EXPECT().ResolveByPrefix(apiKey.Prefix).Return(apiKey, nil)
+
+	userID, err := t.authSvc.AuthorizeAPIKey(plaintext, model.APIKeyScopeReadOnly)
+
+	assert.Nil(t.T(), userID)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "revoked or expired")
+}
+
+func (t *apiKeysServiceTestSuite) TestAuthorizeAPIKey_InsufficientScope() {
+	apiKey, plaintext := t.getNewAPIKey(model.APIKeyScopeReadOnly)
+
+	t.mockRepo.EXPECT().ResolveByPrefix(apiKey.Prefix).Return(apiKey, nil)
+
+	userID, err := t.authSvc.AuthorizeAPIKey(plaintext, model.APIKeyScopeBalancesWrite)
+
+	assert.Nil(t.T(), userID)
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeForbidden, failure.GetCode(err))
+	assert.Contains(t.T(), err.Error(), string(model.APIKeyScopeBalancesWrite))
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/storage"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// ArchiveImpl is the service provider implementation
+type ArchiveImpl struct {
+	Repository   repository.Archive `inject:"archiveRepository"`
+	BlobStore    storage.BlobStore  `inject:"blobStore"`
+	AdminUserIDs []uuid.UUID
+}
+
+// Startup performs startup functions
+func (s *ArchiveImpl) Startup() {
+	logger.Trace("Archive Service starting up...")
+	if s.AdminUserIDs == nil {
+		s.AdminUserIDs = getAdminUserIDs()
+	}
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *ArchiveImpl) Shutdown() {
+	logger.Trace("Archive Service shutting down...")
+}
+
+// Export writes every record of the instance to an archive in a format, one table at a time followed by the
+// content of one Attachment at a time, so that the instance is never held in memory as a whole. As it writes
+// while reading, a failure part way through leaves an incomplete archive behind, which cannot be restored. Only
+// admins may export archives, as they hold the records of every user.
+func (s *ArchiveImpl) Export(w io.Writer, format model.ArchiveFormat, userID uuid.UUID) error {
+	if !slices.Contains(s.AdminUserIDs, userID) {
+		return failure.Forbidden("export", "Archive", "admin only")
+	}
+
+	writer, err := model.NewArchiveWriter(w, format, time.Now())
+	if err != nil {
+		return failure.InternalError("export", "Archive", err)
+	}
+
+	attachments := make([]model.Attachment, 0)
+	err = s.Repository.Export(func(part model.Archive) error {
+		attachments = append(attachments, part.Attachments...)
+		return writer.WriteTable(part)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		err = s.writeAttachmentContent(writer, attachment)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return failure.InternalError("export", "Archive", err)
+	}
+
+	return nil
+}
+
+// writeAttachmentContent copies the content of an Attachment from the blob store into an archive
+func (s *ArchiveImpl) writeAttachmentContent(writer *model.ArchiveWriter, attachment model.Attachment) error {
+	blob, err := s.BlobStore.Get(attachment.ID.String())
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return failure.InternalError("export", "Archive", fmt.Errorf("content of attachment %s is missing", attachment.ID))
+	}
+
+	if err != nil {
+		return failure.InternalError("export", "Archive", err)
+	}
+	defer blob.Close()
+
+	err = writer.WriteAttachmentContent(attachment.ID, blob)
+	if err != nil {
+		return failure.InternalError("export", "Archive", err)
+	}
+
+	return nil
+}
+
+// Restore restores an archive into an instance that holds no records other than users yet, preserving the
+// IDs of its records. Users that already exist are kept as they are, while the others are restored with a
+// random password as archives never hold passwords. Only admins may restore archives.
+func (s *ArchiveImpl) Restore(data []byte, userID uuid.UUID) (*model.ArchiveRestoreResult, error) {
+	if !slices.Contains(s.AdminUserIDs, userID) {
+		return nil, failure.Forbidden("restore", "Archive", "admin only")
+	}
+
+	output, err := model.ReadArchive(data)
+	if err != nil {
+		return nil, err
+	}
+
+	archive := output.ToArchive()
+	err = archive.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	empty, err := s.Repository.IsEmpty()
+	if err != nil {
+		return nil, err
+	}
+
+	if !empty {
+		return nil, failure.OperationNotPermitted("restore", "Archive", "the instance already holds records")
+	}
+
+	// an instance without assets is cheap to export, and its users are the ones the archive may clash with
+	currentUsers := make([]model.User, 0)
+	err = s.Repository.Export(func(part model.Archive) error {
+		currentUsers = append(currentUsers, part.Users...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]model.User, 0, len(archive.Users))
+	for _, user := range archive.Users {
+		if userExists(currentUsers, user) {
+			continue
+		}
+		users = append(users, model.NewUserFromArchive(user))
+	}
+
+	skippedUsers := len(archive.Users) - len(users)
+	archive.Users = users
+
+	// the content of attachments goes first, so that no attachment is ever restored without it
+	for _, attachment := range archive.Attachments {
+		err = s.BlobStore.Put(attachment.ID.String(), bytes.NewReader(archive.AttachmentContents[attachment.ID]))
+		if err != nil {
+			s.deleteAttachmentContents(archive.Attachments)
+			return nil, failure.InternalError("restore", "Archive", err)
+		}
+	}
+
+	result := model.NewArchiveRestoreResult(archive, skippedUsers)
+	err = s.Repository.Restore(archive, result, userID)
+	if err != nil {
+		s.deleteAttachmentContents(archive.Attachments)
+		return nil, err
+	}
+
+	logger.Info("Restored archive version %d exported at %v", archive.Version, archive.Exported)
+
+	return &result, nil
+}
+
+// deleteAttachmentContents removes the content of a set of Attachments from the blob store after a failed
+// restore, which is best effort as the instance was empty beforehand
+func (s *ArchiveImpl) deleteAttachmentContents(attachments []model.Attachment) {
+	for _, attachment := range attachments {
+		if err := s.BlobStore.Delete(attachment.ID.String()); err != nil {
+			logger.ErrNoStack("Failed removing content of attachment %s after a failed restore: %v", attachment.ID, err)
+		}
+	}
+}
+
+// userExists checks whether a User shares its ID, username or email with any of a set of Users
+func userExists(users []model.User, user model.User) bool {
+	return slices.ContainsFunc(users, func(existing model.User) bool {
+		return existing.ID == user.ID ||
+			strings.EqualFold(existing.Username, user.Username) ||
+			strings.EqualFold(existing.Email, user.Email)
+	})
+}
@@ -0,0 +1,483 @@
+package service_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/storage"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type archiveServiceTestSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	svc              service.Archive
+	mockRepo         *mock_repository.MockArchive
+	blobStore        *storage.LocalBlobStore
+	testAdminID      uuid.UUID
+	testUserID       uuid.UUID
+	testArchive      model.Archive
+	testVehicleID    uuid.UUID
+	testCategoryID   uuid.UUID
+	testAttachmentID uuid.UUID
+}
+
+const (
+	archiveServiceTestContent = "%PDF-1.4\n%valuation report\n"
+	archiveServiceTestTables  = 21
+)
+
+func TestArchiveService(t *testing.T) {
+	suite.Run(t, new(archiveServiceTestSuite))
+}
+
+func (t *archiveServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockArchive(t.ctrl)
+	t.testAdminID, _ = uuid.NewV7()
+	t.testUserID, _ = uuid.NewV7()
+	t.testVehicleID, _ = uuid.NewV7()
+	t.testCategoryID, _ = uuid.NewV7()
+	t.testAttachmentID, _ = uuid.NewV7()
+	t.blobStore = &storage.LocalBlobStore{Root: t.T().TempDir()}
+	t.blobStore.Startup()
+	t.svc = &service.ArchiveImpl{
+		Repository:   t.mockRepo,
+		BlobStore:    t.blobStore,
+		AdminUserIDs: []uuid.UUID{t.testAdminID},
+	}
+	t.testArchive = t.getTestArchive()
+	t.svc.Startup()
+}
+
+func (t *archiveServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *archiveServiceTestSuite) getTestArchive() model.Archive {
+	created := time.Date(2024, 1, 31, 10, 30, 15, 123000000, time.UTC)
+	deleted := time.Date(2024, 2, 29, 8, 0, 0, 0, time.UTC)
+	valueID, _ := uuid.NewV7()
+
+	archive := model.NewArchive()
+	archive.Users = append(archive.Users, model.User{
+		ID:        t.testUserID,
+		Username:  "jdoe",
+		Email:     "jdoe@example.com",
+		Password:  "never exported",
+		Name:      "John \"JD\" Doe, Jr.",
+		Created:   created,
+		CreatedBy: t.testAdminID,
+	})
+	archive.Vehicles = append(archive.Vehicles, model.Vehicle{
+		ID:                        t.testVehicleID,
+		Name:                      "Daily Driver",
+		Make:                      "Toyota",
+		Model:                     "Corolla",
+		Year:                      2019,
+		Type:                      model.VehicleTypeCar,
+		PurchaseDate:              created,
+		InitialValue:              20000,
+		InitialValueDate:          created,
+		CurrentValue:              15000.5,
+		CurrentValueDate:          created,
+		AnnualDepreciationPercent: 12.5,
+		Status:                    model.VehicleStatusInUse,
+		Created:                   created,
+		CreatedBy:                 t.testUserID,
+		Deleted:                   null.TimeFrom(deleted),
+		DeletedBy:                 nuuid.From(t.testUserID),
+	})
+	archive.VehicleValues = append(archive.VehicleValues, model.VehicleValue{
+		ID:        valueID,
+		VehicleID: t.testVehicleID,
+		Date:      created,
+		Value:     15000.5,
+		Created:   created,
+		CreatedBy: t.testUserID,
+	})
+	archive.Attachments = append(archive.Attachments, model.Attachment{
+		ID:          t.testAttachmentID,
+		EntityType:  model.EntityTypeVehicleValue,
+		SubjectID:   valueID,
+		FileName:    "valuation, final.pdf",
+		ContentType: "application/pdf",
+		Size:        int64(len(archiveServiceTestContent)),
+		Checksum:    model.GetAttachmentChecksum([]byte(archiveServiceTestContent)),
+		Created:     created,
+		CreatedBy:   t.testUserID,
+	})
+	archive.AttachmentContents[t.testAttachmentID] = []byte(archiveServiceTestContent)
+
+	return archive
+}
+
+func (t *archiveServiceTestSuite) getStoredContent(id uuid.UUID) (string, error) {
+	blob, err := t.blobStore.Get(id.String())
+	if err != nil {
+		return "", err
+	}
+	defer blob.Close()
+
+	content, err := io.ReadAll(blob)
+	return string(content), err
+}
+
+func (t *archiveServiceTestSuite) getArchiveData(format model.ArchiveFormat) []byte {
+	var buffer bytes.Buffer
+	output := t.testArchive.ToOutput()
+	err := output.Write(&buffer, format)
+	assert.NoError(t.T(), err)
+	return buffer.Bytes()
+}
+
+// expectExport has the repository hand an archive over one table at a time, as it does when exporting
+func (t *archiveServiceTestSuite) expectExport(archive model.Archive) {
+	t.mockRepo.EXPECT().Export(gomock.Any()).
+		DoAndReturn(func(write func(part model.Archive) error) error {
+			parts := make([]model.Archive, archiveServiceTestTables)
+			for i := range parts {
+				parts[i] = model.NewArchive()
+			}
+			parts[0].Users = archive.Users
+			parts[11].Vehicles = archive.Vehicles
+			parts[12].VehicleValues = archive.VehicleValues
+			parts[20].Attachments = archive.Attachments
+
+			for _, part := range parts {
+				err := write(part)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+func (t *archiveServiceTestSuite) testExportRoundTrip(format model.ArchiveFormat) {
+	err := t.blobStore.Put(t.testAttachmentID.String(), bytes.NewBufferString(archiveServiceTestContent))
+	assert.NoError(t.T(), err)
+
+	t.expectExport(t.getTestArchive())
+
+	var buffer bytes.Buffer
+	err = t.svc.Export(&buffer, format, t.testAdminID)
+	assert.NoError(t.T(), err)
+
+	output, err := model.ReadArchive(buffer.Bytes())
+	assert.NoError(t.T(), err)
+
+	res := output.ToArchive()
+	assert.Equal(t.T(), model.ArchiveVersion, res.Version)
+	assert.Len(t.T(), res.Users, 1)
+	assert.Equal(t.T(), t.testArchive.Users[0].Name, res.Users[0].Name)
+	assert.Len(t.T(), res.Vehicles, 1)
+	assert.Equal(t.T(), t.testVehicleID, res.Vehicles[0].ID)
+	assert.Len(t.T(), res.VehicleValues, 1)
+	assert.Len(t.T(), res.BankAccounts, 0)
+	assert.Len(t.T(), res.Attachments, 1)
+	assert.Equal(t.T(), archiveServiceTestContent, string(res.AttachmentContents[t.testAttachmentID]))
+}
+
+func (t *archiveServiceTestSuite) TestExport_JSON() {
+	t.testExportRoundTrip(model.ArchiveFormatJSON)
+}
+
+func (t *archiveServiceTestSuite) TestExport_ZIP() {
+	t.testExportRoundTrip(model.ArchiveFormatZIP)
+}
+
+func (t *archiveServiceTestSuite) TestExport_NotAdmin() {
+	var buffer bytes.Buffer
+	err := t.svc.Export(&buffer, model.ArchiveFormatJSON, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeForbidden, failure.GetCode(err))
+	assert.Equal(t.T(), 0, buffer.Len())
+}
+
+func (t *archiveServiceTestSuite) TestExport_AttachmentContentMissing() {
+	t.expectExport(t.getTestArchive())
+
+	var buffer bytes.Buffer
+	err := t.svc.Export(&buffer, model.ArchiveFormatZIP, t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeInternalError, failure.GetCode(err))
+	assert.Contains(t.T(), err.Error(), t.testAttachmentID.String())
+}
+
+func (t *archiveServiceTestSuite) TestExport_RepoFailed() {
+	errMsg := "failed exporting"
+	t.mockRepo.EXPECT().Export(gomock.Any()).Return(errors.New(errMsg))
+
+	var buffer bytes.Buffer
+	err := t.svc.Export(&buffer, model.ArchiveFormatJSON, t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
+func (t *archiveServiceTestSuite) testRestoreRoundTrip(format model.ArchiveFormat) {
+	t.mockRepo.EXPECT().IsEmpty().Return(true, nil)
+	t.mockRepo.EXPECT().Export(gomock.Any()).Return(nil)
+	t.mockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), t.testAdminID).
+		DoAndReturn(func(archive model.Archive, result model.ArchiveRestoreResult, userID uuid.UUID) error {
+			assert.Len(t.T(), archive.Users, 1)
+			assert.Equal(t.T(), t.testArchive.Users[0].Name, archive.Users[0].Name)
+			assert.True(t.T(), t.testArchive.Users[0].Created.Equal(archive.Users[0].Created))
+			assert.NotEqual(t.T(), t.testArchive.Users[0].Password, archive.Users[0].Password)
+			assert.False(t.T(), archive.Users[0].ComparePassword(""))
+
+			vehicle := archive.Vehicles[0]
+			expected := t.testArchive.Vehicles[0]
+			assert.Equal(t.T(), expected.ID, vehicle.ID)
+			assert.Equal(t.T(), expected.Year, vehicle.Year)
+			assert.Equal(t.T(), expected.Type, vehicle.Type)
+			assert.Equal(t.T(), expected.CurrentValue, vehicle.CurrentValue)
+			assert.True(t.T(), expected.Deleted.Time.Equal(vehicle.Deleted.Time))
+			assert.Equal(t.T(), expected.DeletedBy, vehicle.DeletedBy)
+			assert.False(t.T(), vehicle.Updated.Valid)
+			assert.False(t.T(), vehicle.UpdatedBy.Valid)
+			assert.Equal(t.T(), t.testVehicleID, archive.VehicleValues[0].VehicleID)
+
+			attachment := archive.Attachments[0]
+			expectedAttachment := t.testArchive.Attachments[0]
+			assert.Equal(t.T(), expectedAttachment.FileName, attachment.FileName)
+			assert.Equal(t.T(), expectedAttachment.Size, attachment.Size)
+			assert.Equal(t.T(), expectedAttachment.Checksum, attachment.Checksum)
+
+			// the content is stored before the records are written
+			content, err := t.getStoredContent(t.testAttachmentID)
+			assert.NoError(t.T(), err)
+			assert.Equal(t.T(), archiveServiceTestContent, content)
+
+			assert.Equal(t.T(), 1, result.Users)
+			assert.Equal(t.T(), 1, result.Vehicles)
+			assert.Equal(t.T(), 1, result.VehicleValues)
+			assert.Equal(t.T(), 1, result.Attachments)
+			return nil
+		})
+
+	res, err := t.svc.Restore(t.getArchiveData(format), t.testAdminID)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), model.ArchiveVersion, res.Version)
+	assert.Equal(t.T(), 0, res.SkippedUsers)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_JSON() {
+	t.testRestoreRoundTrip(model.ArchiveFormatJSON)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_ZIP() {
+	t.testRestoreRoundTrip(model.ArchiveFormatZIP)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_SkipsExistingUsers() {
+	existing := model.NewArchive()
+	existingUserID, _ := uuid.NewV7()
+	existing.Users = append(existing.Users, model.User{ID: existingUserID, Username: "JDOE", Email: "admin@example.com"})
+
+	t.mockRepo.EXPECT().IsEmpty().Return(true, nil)
+	t.expectExport(existing)
+	t.mockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), t.testAdminID).
+		DoAndReturn(func(archive model.Archive, result model.ArchiveRestoreResult, userID uuid.UUID) error {
+			assert.Len(t.T(), archive.Users, 0)
+			assert.Len(t.T(), archive.Vehicles, 1)
+			return nil
+		})
+
+	res, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatJSON), t.testAdminID)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), 0, res.Users)
+	assert.Equal(t.T(), 1, res.SkippedUsers)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_MarksCascadedDeletesOfEarlierVersions() {
+	t.testArchive.Version = 9
+	t.testArchive.VehicleValues[0].Deleted = t.testArchive.Vehicles[0].Deleted
+	t.testArchive.VehicleValues[0].DeletedBy = t.testArchive.Vehicles[0].DeletedBy
+
+	t.mockRepo.EXPECT().IsEmpty().Return(true, nil)
+	t.mockRepo.EXPECT().Export(gomock.Any()).Return(nil)
+	t.mockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), t.testAdminID).
+		DoAndReturn(func(archive model.Archive, result model.ArchiveRestoreResult, userID uuid.UUID) error {
+			// the deletion time and user are all an archive written before the marker has to go by
+			assert.True(t.T(), archive.VehicleValues[0].DeletedByCascade)
+			return nil
+		})
+
+	_, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatZIP), t.testAdminID)
+
+	assert.NoError(t.T(), err)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_KeepsCascadedDeletes() {
+	t.testArchive.VehicleValues[0].Deleted = null.TimeFrom(t.testArchive.Vehicles[0].Deleted.Time.Add(time.Minute))
+	t.testArchive.VehicleValues[0].DeletedBy = t.testArchive.Vehicles[0].DeletedBy
+	t.testArchive.VehicleValues[0].DeletedByCascade = true
+
+	t.mockRepo.EXPECT().IsEmpty().Return(true, nil)
+	t.mockRepo.EXPECT().Export(gomock.Any()).Return(nil)
+	t.mockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), t.testAdminID).
+		DoAndReturn(func(archive model.Archive, result model.ArchiveRestoreResult, userID uuid.UUID) error {
+			assert.True(t.T(), archive.VehicleValues[0].DeletedByCascade)
+			return nil
+		})
+
+	_, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatZIP), t.testAdminID)
+
+	assert.NoError(t.T(), err)
+}
+
+// addCategorizedTransaction adds a Transaction filed under a Category by its name, along with its Bank Account
+func (t *archiveServiceTestSuite) addCategorizedTransaction(categoryID nuuid.NUUID) {
+	bankAccountID, _ := uuid.NewV7()
+	transactionID, _ := uuid.NewV7()
+	created := time.Date(2024, 1, 31, 10, 30, 15, 0, time.UTC)
+
+	t.testArchive.BankAccounts = append(t.testArchive.BankAccounts, model.BankAccount{
+		ID:          bankAccountID,
+		AccountName: "Checking",
+		Status:      model.BankAccountStatusActive,
+		Created:     created,
+		CreatedBy:   t.testUserID,
+	})
+	t.testArchive.Categories = append(t.testArchive.Categories, model.Category{
+		ID:        t.testCategoryID,
+		Name:      "Groceries",
+		Created:   created,
+		CreatedBy: t.testUserID,
+	})
+	t.testArchive.Transactions = append(t.testArchive.Transactions, model.Transaction{
+		ID:            transactionID,
+		BankAccountID: bankAccountID,
+		Date:          created,
+		Direction:     model.TransactionDirectionDebit,
+		Amount:        42,
+		Category:      "groceries",
+		CategoryID:    categoryID,
+		Created:       created,
+		CreatedBy:     t.testUserID,
+	})
+}
+
+func (t *archiveServiceTestSuite) TestRestore_LinksCategoriesOfEarlierVersions() {
+	t.testArchive.Version = 10
+	t.addCategorizedTransaction(nuuid.NUUID{})
+
+	t.mockRepo.EXPECT().IsEmpty().Return(true, nil)
+	t.mockRepo.EXPECT().Export(gomock.Any()).Return(nil)
+	t.mockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), t.testAdminID).
+		DoAndReturn(func(archive model.Archive, result model.ArchiveRestoreResult, userID uuid.UUID) error {
+			// the category name is all an archive written before the link has to go by
+			assert.Equal(t.T(), nuuid.From(t.testCategoryID), archive.Transactions[0].CategoryID)
+			return nil
+		})
+
+	_, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatZIP), t.testAdminID)
+
+	assert.NoError(t.T(), err)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_MissingCategory() {
+	missingID, _ := uuid.NewV7()
+	t.addCategorizedTransaction(nuuid.From(missingID))
+
+	res, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatJSON), t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Contains(t.T(), err.Error(), "missing Category")
+	assert.Nil(t.T(), res)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_NotAdmin() {
+	res, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatJSON), t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeForbidden, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_InstanceNotEmpty() {
+	t.mockRepo.EXPECT().IsEmpty().Return(false, nil)
+
+	res, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatJSON), t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_MissingParent() {
+	t.testArchive.Vehicles = []model.Vehicle{}
+
+	res, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatZIP), t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Contains(t.T(), err.Error(), "missing Vehicle")
+	assert.Nil(t.T(), res)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_AttachmentContentMismatch() {
+	t.testArchive.AttachmentContents[t.testAttachmentID] = []byte("tampered")
+
+	res, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatZIP), t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Contains(t.T(), err.Error(), "checksum")
+	assert.Nil(t.T(), res)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_UnsupportedVersion() {
+	t.testArchive.Version = model.ArchiveVersion + 1
+
+	res, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatJSON), t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_MalformedArchive() {
+	res, err := t.svc.Restore([]byte("PK\x03\x04not really a zip"), t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *archiveServiceTestSuite) TestRestore_RepoFailedRestoring() {
+	errMsg := "failed restoring"
+	t.mockRepo.EXPECT().IsEmpty().Return(true, nil)
+	t.mockRepo.EXPECT().Export(gomock.Any()).Return(nil)
+	t.mockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), t.testAdminID).Return(errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.getArchiveData(model.ArchiveFormatJSON), t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+
+	// the content stored ahead of the records is removed again
+	_, err = t.getStoredContent(t.testAttachmentID)
+	assert.Equal(t.T(), storage.ErrBlobNotFound, err)
+}
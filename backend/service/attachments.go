@@ -0,0 +1,179 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/config"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/storage"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// AttachmentImpl is the service provider implementation
+type AttachmentImpl struct {
+	Repository            repository.Attachment  `inject:"attachmentRepository"`
+	BankAccountRepository repository.BankAccount `inject:"bankAccountRepository"`
+	VehicleRepository     repository.Vehicle     `inject:"vehicleRepository"`
+	PropertyRepository    repository.Property    `inject:"propertyRepository"`
+	BlobStore             storage.BlobStore      `inject:"blobStore"`
+	MaxSize               int64
+	AllowedTypes          []string
+}
+
+// Startup performs startup functions
+func (s *AttachmentImpl) Startup() {
+	logger.Trace("Attachment Service starting up...")
+	if s.MaxSize == 0 {
+		config := config.Get()
+		s.MaxSize = config.Attachment.MaxSize
+		s.AllowedTypes = config.Attachment.AllowedTypes
+	}
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *AttachmentImpl) Shutdown() {
+	logger.Trace("Attachment Service shutting down...")
+}
+
+// Create attaches a new file to an entity. Its content is stored before the Attachment is recorded,
+// and removed again if recording it fails.
+func (s *AttachmentImpl) Create(input model.AttachmentInput, userID uuid.UUID) (*model.Attachment, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkAttachableEntity("create", input.EntityType, input.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := input.ReadContent(s.MaxSize, s.AllowedTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := model.NewAttachmentFromInput(input, content, userID)
+	err = s.BlobStore.Put(attachment.ID.String(), bytes.NewReader(content.Data))
+	if err != nil {
+		return nil, failure.InternalError("create", "Attachment", err)
+	}
+
+	err = s.Repository.Create(attachment)
+	if err != nil {
+		if errBlob := s.BlobStore.Delete(attachment.ID.String()); errBlob != nil {
+			logger.ErrNoStack("Failed removing content of attachment %s: %v", attachment.ID, errBlob)
+		}
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// GetByID fetches an Attachment by its ID
+func (s *AttachmentImpl) GetByID(id uuid.UUID) (*model.Attachment, error) {
+	attachments, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachments) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Attachment")
+	}
+
+	return &attachments[0], nil
+}
+
+// GetByFilter fetches a set of Attachments by its filter
+func (s *AttachmentImpl) GetByFilter(input model.AttachmentFilterInput) ([]model.Attachment, model.PageInfoOutput, error) {
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// GetByEntity fetches the Attachments of an entity
+func (s *AttachmentImpl) GetByEntity(entityType model.EntityType, subjectID uuid.UUID) ([]model.Attachment, error) {
+	err := s.checkAttachableEntity("get attachments", entityType, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Repository.ResolveByEntity(entityType, subjectID)
+}
+
+// Download fetches the content of an Attachment, making sure that it still matches its checksum
+func (s *AttachmentImpl) Download(id uuid.UUID) (*model.AttachmentDownload, error) {
+	attachments, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachments) != 1 {
+		return nil, failure.EntityNotFound("download", "Attachment")
+	}
+
+	attachment := attachments[0]
+	if attachment.Deleted.Valid {
+		return nil, failure.OperationNotPermitted("download", "Attachment", "already deleted")
+	}
+
+	blob, err := s.BlobStore.Get(attachment.ID.String())
+	if errors.Is(err, storage.ErrBlobNotFound) {
+		return nil, failure.InternalError("download", "Attachment", errors.New("content of attachment is missing"))
+	}
+
+	if err != nil {
+		return nil, failure.InternalError("download", "Attachment", err)
+	}
+	defer blob.Close()
+
+	data, err := io.ReadAll(blob)
+	if err != nil {
+		return nil, failure.InternalError("download", "Attachment", err)
+	}
+
+	err = attachment.VerifyContent(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AttachmentDownload{Attachment: attachment, Data: data}, nil
+}
+
+// Delete deletes an existing Attachment, which only its uploader may do
+func (s *AttachmentImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Attachment, error) {
+	attachments, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachments) != 1 {
+		return nil, failure.EntityNotFound("delete", "Attachment")
+	}
+
+	attachment := attachments[0]
+
+	if attachment.CreatedBy != userID {
+		return nil, failure.Forbidden("delete", "Attachment", "uploader only")
+	}
+
+	err = attachment.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// checkAttachableEntity makes sure that files can be attached to an entity, and that it exists
+func (s *AttachmentImpl) checkAttachableEntity(operation string, entityType model.EntityType, subjectID uuid.UUID) error {
+	return checkEntity(operation, entityType, subjectID, model.CheckAttachableEntityType, s.BankAccountRepository, s.VehicleRepository, s.PropertyRepository)
+}
@@ -0,0 +1,261 @@
+package service_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/storage"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const attachmentsTestContent = "%PDF-1.4\n%test statement\n"
+
+type attachmentsServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.Attachment
+	mockRepo            *mock_repository.MockAttachment
+	mockBankAccountRepo *mock_repository.MockBankAccount
+	mockVehicleRepo     *mock_repository.MockVehicle
+	mockPropertyRepo    *mock_repository.MockProperty
+	blobStore           *storage.LocalBlobStore
+	testUserID          uuid.UUID
+	testAttachmentID    uuid.UUID
+	testBankAccountID   uuid.UUID
+}
+
+func TestAttachmentsService(t *testing.T) {
+	suite.Run(t, new(attachmentsServiceTestSuite))
+}
+
+func (t *attachmentsServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockAttachment(t.ctrl)
+	t.mockBankAccountRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.mockVehicleRepo = mock_repository.NewMockVehicle(t.ctrl)
+	t.mockPropertyRepo = mock_repository.NewMockProperty(t.ctrl)
+	t.blobStore = &storage.LocalBlobStore{Root: t.T().TempDir()}
+	t.blobStore.Startup()
+	t.svc = &service.AttachmentImpl{
+		Repository:            t.mockRepo,
+		BankAccountRepository: t.mockBankAccountRepo,
+		VehicleRepository:     t.mockVehicleRepo,
+		PropertyRepository:    t.mockPropertyRepo,
+		BlobStore:             t.blobStore,
+		MaxSize:               1024,
+		AllowedTypes:          []string{"application/pdf", "image/png"},
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testAttachmentID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.svc.Startup()
+}
+
+func (t *attachmentsServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *attachmentsServiceTestSuite) getInput(content string) model.AttachmentInput {
+	return model.AttachmentInput{
+		EntityType: model.EntityTypeBankAccount,
+		SubjectID:  t.testBankAccountID,
+		FileName:   "statement.pdf",
+		Content:    strings.NewReader(content),
+	}
+}
+
+func (t *attachmentsServiceTestSuite) getAttachment() model.Attachment {
+	return model.Attachment{
+		ID:          t.testAttachmentID,
+		EntityType:  model.EntityTypeBankAccount,
+		SubjectID:   t.testBankAccountID,
+		FileName:    "statement.pdf",
+		ContentType: "application/pdf",
+		Size:        int64(len(attachmentsTestContent)),
+		Checksum:    model.GetAttachmentChecksum([]byte(attachmentsTestContent)),
+		Created:     time.Now(),
+		CreatedBy:   t.testUserID,
+	}
+}
+
+func (t *attachmentsServiceTestSuite) putContent(content string) {
+	err := t.blobStore.Put(t.testAttachmentID.String(), strings.NewReader(content))
+	assert.Nil(t.T(), err)
+}
+
+func (t *attachmentsServiceTestSuite) TestCreate_Normal() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	attachment, err := t.svc.Create(t.getInput(attachmentsTestContent), t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), attachment)
+	assert.Equal(t.T(), "application/pdf", attachment.ContentType)
+	assert.Equal(t.T(), int64(len(attachmentsTestContent)), attachment.Size)
+	assert.Equal(t.T(), model.GetAttachmentChecksum([]byte(attachmentsTestContent)), attachment.Checksum)
+
+	blob, err := t.blobStore.Get(attachment.ID.String())
+	assert.Nil(t.T(), err)
+	defer blob.Close()
+
+	data, err := io.ReadAll(blob)
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), attachmentsTestContent, string(data))
+}
+
+func (t *attachmentsServiceTestSuite) TestCreate_EntityNotFound() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(false, nil)
+
+	attachment, err := t.svc.Create(t.getInput(attachmentsTestContent), t.testUserID)
+
+	assert.Nil(t.T(), attachment)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *attachmentsServiceTestSuite) TestCreate_EntityTypeNotAttachable() {
+	input := t.getInput(attachmentsTestContent)
+	input.EntityType = model.EntityTypeUser
+
+	attachment, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), attachment)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *attachmentsServiceTestSuite) TestCreate_TooLarge() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil)
+
+	attachment, err := t.svc.Create(t.getInput(attachmentsTestContent+strings.Repeat("x", 1024)), t.testUserID)
+
+	assert.Nil(t.T(), attachment)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *attachmentsServiceTestSuite) TestCreate_TypeNotAllowed() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil)
+
+	attachment, err := t.svc.Create(t.getInput("just some plain text"), t.testUserID)
+
+	assert.Nil(t.T(), attachment)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *attachmentsServiceTestSuite) TestCreate_RemovesContentOnFailure() {
+	var created model.Attachment
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).
+		DoAndReturn(func(attachment model.Attachment) error {
+			created = attachment
+			return errors.New("cannot insert")
+		})
+
+	attachment, err := t.svc.Create(t.getInput(attachmentsTestContent), t.testUserID)
+
+	assert.Nil(t.T(), attachment)
+	assert.NotNil(t.T(), err)
+
+	_, err = t.blobStore.Get(created.ID.String())
+	assert.Equal(t.T(), storage.ErrBlobNotFound, err)
+}
+
+func (t *attachmentsServiceTestSuite) TestGetByEntity_Normal() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByEntity(model.EntityTypeBankAccount, t.testBankAccountID).Return([]model.Attachment{t.getAttachment()}, nil)
+
+	attachments, err := t.svc.GetByEntity(model.EntityTypeBankAccount, t.testBankAccountID)
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), attachments, 1)
+}
+
+func (t *attachmentsServiceTestSuite) TestDownload_Normal() {
+	t.putContent(attachmentsTestContent)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testAttachmentID}).Return([]model.Attachment{t.getAttachment()}, nil)
+
+	download, err := t.svc.Download(t.testAttachmentID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), download)
+	assert.Equal(t.T(), attachmentsTestContent, string(download.Data))
+}
+
+func (t *attachmentsServiceTestSuite) TestDownload_ContentChanged() {
+	t.putContent(strings.Replace(attachmentsTestContent, "test", "fake", 1))
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testAttachmentID}).Return([]model.Attachment{t.getAttachment()}, nil)
+
+	download, err := t.svc.Download(t.testAttachmentID)
+
+	assert.Nil(t.T(), download)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeInternalError, failure.GetCode(err))
+}
+
+func (t *attachmentsServiceTestSuite) TestDownload_ContentMissing() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testAttachmentID}).Return([]model.Attachment{t.getAttachment()}, nil)
+
+	download, err := t.svc.Download(t.testAttachmentID)
+
+	assert.Nil(t.T(), download)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeInternalError, failure.GetCode(err))
+}
+
+func (t *attachmentsServiceTestSuite) TestDownload_Deleted() {
+	attachment := t.getAttachment()
+	_ = attachment.Delete(t.testUserID)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testAttachmentID}).Return([]model.Attachment{attachment}, nil)
+
+	download, err := t.svc.Download(t.testAttachmentID)
+
+	assert.Nil(t.T(), download)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *attachmentsServiceTestSuite) TestDelete_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testAttachmentID}).Return([]model.Attachment{t.getAttachment()}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	attachment, err := t.svc.Delete(t.testAttachmentID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), attachment)
+	assert.True(t.T(), attachment.Deleted.Valid)
+}
+
+func (t *attachmentsServiceTestSuite) TestDelete_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testAttachmentID}).Return([]model.Attachment{}, nil)
+
+	attachment, err := t.svc.Delete(t.testAttachmentID, t.testUserID)
+
+	assert.Nil(t.T(), attachment)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *attachmentsServiceTestSuite) TestDelete_NotUploader() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testAttachmentID}).Return([]model.Attachment{t.getAttachment()}, nil)
+
+	otherUserID, _ := uuid.NewV7()
+	attachment, err := t.svc.Delete(t.testAttachmentID, otherUserID)
+
+	assert.Nil(t.T(), attachment)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeForbidden, failure.GetCode(err))
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// AuditLogImpl is the service provider implementation
+type AuditLogImpl struct {
+	Repository   repository.AuditLog `inject:"auditLogRepository"`
+	AdminUserIDs []uuid.UUID
+}
+
+// Startup performs startup functions
+func (s *AuditLogImpl) Startup() {
+	logger.Trace("Audit Log Service starting up...")
+	if s.AdminUserIDs == nil {
+		s.AdminUserIDs = getAdminUserIDs()
+	}
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *AuditLogImpl) Shutdown() {
+	logger.Trace("Audit Log Service shutting down...")
+}
+
+// GetByFilter fetches a set of Audit Logs by its filter. Only admins may search Audit Logs, as they hold the
+// changes of every user.
+func (s *AuditLogImpl) GetByFilter(input model.AuditLogFilterInput, userID uuid.UUID) ([]model.AuditLog, model.PageInfoOutput, error) {
+	if !slices.Contains(s.AdminUserIDs, userID) {
+		return []model.AuditLog{}, model.PageInfoOutput{}, failure.Forbidden("search", "Audit Log", "admin only")
+	}
+
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
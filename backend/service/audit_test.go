@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type auditLogServiceTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	svc           service.AuditLog
+	mockRepo      *mock_repository.MockAuditLog
+	testAdminID   uuid.UUID
+	testSubjectID uuid.UUID
+}
+
+func TestAuditLogService(t *testing.T) {
+	suite.Run(t, new(auditLogServiceTestSuite))
+}
+
+func (t *auditLogServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockAuditLog(t.ctrl)
+	t.testAdminID, _ = uuid.NewV7()
+	t.svc = &service.AuditLogImpl{
+		Repository:   t.mockRepo,
+		AdminUserIDs: []uuid.UUID{t.testAdminID},
+	}
+	t.testSubjectID, _ = uuid.NewV7()
+	t.svc.Startup()
+}
+
+func (t *auditLogServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *auditLogServiceTestSuite) TestGetByFilter_Normal() {
+	input := model.AuditLogFilterInput{
+		SubjectIDs: &[]uuid.UUID{t.testSubjectID},
+	}
+	auditLog, err := model.NewAuditLog(model.EntityTypeVehicle, t.testSubjectID, model.AuditActionCreate, t.testSubjectID, nil, map[string]string{"name": "Car"})
+	assert.NoError(t.T(), err)
+
+	t.mockRepo.EXPECT().ResolveByFilter(input.ToFilter()).
+		Return([]model.AuditLog{auditLog}, model.PageInfoOutput{TotalCount: 1}, nil)
+
+	res, pageInfo, err := t.svc.GetByFilter(input, t.testAdminID)
+
+	assert.NoError(t.T(), err)
+	assert.Len(t.T(), res, 1)
+	assert.Equal(t.T(), 1, pageInfo.TotalCount)
+}
+
+func (t *auditLogServiceTestSuite) TestGetByFilter_RepoError() {
+	errMsg := "failed resolving audit logs by filter"
+	input := model.AuditLogFilterInput{}
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.AuditLog{}, model.PageInfoOutput{}, errors.New(errMsg))
+
+	res, _, err := t.svc.GetByFilter(input, t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Empty(t.T(), res)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
+func (t *auditLogServiceTestSuite) TestGetByFilter_NotAdmin() {
+	res, _, err := t.svc.GetByFilter(model.AuditLogFilterInput{}, t.testSubjectID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeForbidden, failure.GetCode(err))
+	assert.Empty(t.T(), res)
+}
@@ -13,17 +13,33 @@ import (
 	"github.com/kerti/balances/backend/repository"
 	"github.com/kerti/balances/backend/util/cachetime"
 	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/jwtkeys"
 	"github.com/kerti/balances/backend/util/logger"
 )
 
 // AuthImpl is the service provider implementation
 type AuthImpl struct {
-	UserRepository repository.User `inject:"userRepository"`
+	UserRepository   repository.User   `inject:"userRepository"`
+	APIKeyRepository repository.APIKey `inject:"apiKeyRepository"`
+	KeyRing          *jwtkeys.KeyRing
 }
 
 // Startup performs startup functions
 func (s *AuthImpl) Startup() {
 	logger.Trace("Auth service starting up...")
+	if s.KeyRing == nil {
+		config := config.Get()
+		keyRing, err := jwtkeys.Load(
+			config.JWT.SigningMethod,
+			config.JWT.Secret,
+			config.JWT.PrivateKeyFile,
+			config.JWT.KeyID,
+			config.JWT.PublicKeyFiles)
+		if err != nil {
+			logger.Fatal("Failed to load JWT keys: %v", err)
+		}
+		s.KeyRing = keyRing
+	}
 }
 
 // Shutdown cleans up everything and shuts down
@@ -60,19 +76,12 @@ func (s *AuthImpl) Authenticate(basic string) (authInfo *model.AuthenticationInf
 
 // Authorize authorizes a request based on its Bearer token
 func (s *AuthImpl) Authorize(bearer string) (userID *uuid.UUID, err error) {
-	config := config.Get()
 	jwtToken, err := s.validateBearerAuthHeader(bearer)
 	if err != nil {
 		return nil, err
 	}
 
-	token, err := jwt.Parse(jwtToken, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(config.JWT.Secret), nil
-	})
-
+	token, err := jwt.Parse(jwtToken, s.KeyRing.Keyfunc)
 	if err != nil {
 		return nil, err
 	}
@@ -91,6 +100,45 @@ func (s *AuthImpl) Authorize(bearer string) (userID *uuid.UUID, err error) {
 	return nil, failure.Unauthorized("invalid JWT token")
 }
 
+// AuthorizeAPIKey authorizes a request based on its API Key and the scope it requires. A key that is valid but
+// lacks the scope is forbidden rather than unauthorized, as presenting it again will never help.
+func (s *AuthImpl) AuthorizeAPIKey(key string, scope model.APIKeyScope) (userID *uuid.UUID, err error) {
+	prefix, err := model.GetAPIKeyPrefix(key)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := s.APIKeyRepository.ResolveByPrefix(prefix)
+	if err != nil {
+		return nil, failure.Unauthorized("invalid API key")
+	}
+
+	if !apiKey.CompareKey(key) {
+		return nil, failure.Unauthorized("invalid API key")
+	}
+
+	now := time.Now()
+	if !apiKey.IsActive(now) {
+		return nil, failure.Unauthorized("API key is revoked or expired")
+	}
+
+	if !apiKey.Scopes.Permits(scope) {
+		return nil, failure.Forbidden("authorize", "API Key", fmt.Sprintf("API key does not have the required scope: %s", scope))
+	}
+
+	// failing to record usage should not fail an otherwise valid request
+	if err := s.APIKeyRepository.UpdateLastUsed(apiKey.ID, now); err != nil {
+		logger.Warn("[authService] failed recording API key usage: %v", err)
+	}
+
+	return &apiKey.UserID, nil
+}
+
+// GetJWKS returns the public keys that can be used to verify tokens issued by this service
+func (s *AuthImpl) GetJWKS() jwtkeys.JSONWebKeySet {
+	return s.KeyRing.JWKS()
+}
+
 // GetToken signs a new token for a specified user
 func (s *AuthImpl) GetToken(user model.User) (token *string, expiration *time.Time, err error) {
 	return s.signJWT(&user)
@@ -127,13 +175,12 @@ func (s *AuthImpl) signJWT(user *model.User) (*string, *time.Time, error) {
 	now := time.Now()
 	expTime := now.Add(config.JWT.Expiration)
 	expiration := cachetime.CacheTime(expTime)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	tokenString, err := s.KeyRing.Sign(jwt.MapClaims{
 		"id":         base64.StdEncoding.EncodeToString([]byte(user.ID.String())),
 		"created":    user.ToOutput().Created,
 		"expiration": expiration,
 		"iss":        "balances",
 	})
-	tokenString, err := token.SignedString([]byte(config.JWT.Secret))
 	return &tokenString, &expTime, err
 }
 
@@ -0,0 +1,217 @@
+package service_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/jwtkeys"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type authServiceTestSuite struct {
+	suite.Suite
+	dir      string
+	testUser model.User
+}
+
+func TestAuthService(t *testing.T) {
+	suite.Run(t, new(authServiceTestSuite))
+}
+
+func (t *authServiceTestSuite) SetupTest() {
+	t.dir = t.T().TempDir()
+	userID, _ := uuid.NewV7()
+	t.testUser = model.User{
+		ID:       userID,
+		Username: "johndoe",
+		Created:  time.Now(),
+	}
+}
+
+func (t *authServiceTestSuite) writePEM(name, blockType string, der []byte) string {
+	path := filepath.Join(t.dir, name)
+	err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	return path
+}
+
+func (t *authServiceTestSuite) writeRSAKey(name string) (privatePath, publicPath string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	privateDER, _ := x509.MarshalPKCS8PrivateKey(key)
+	publicDER, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	return t.writePEM(name+".key", "PRIVATE KEY", privateDER), t.writePEM(name+".pub", "PUBLIC KEY", publicDER)
+}
+
+func (t *authServiceTestSuite) writeEd25519Key(name string) (privatePath, publicPath string) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	privateDER, _ := x509.MarshalPKCS8PrivateKey(privateKey)
+	publicDER, _ := x509.MarshalPKIXPublicKey(publicKey)
+	return t.writePEM(name+".key", "PRIVATE KEY", privateDER), t.writePEM(name+".pub", "PUBLIC KEY", publicDER)
+}
+
+func (t *authServiceTestSuite) getService(method, secret, privateKeyFile, keyID string, publicKeyFiles map[string]string) service.Auth {
+	keyRing, err := jwtkeys.Load(method, secret, privateKeyFile, keyID, publicKeyFiles)
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	svc := &service.AuthImpl{KeyRing: keyRing}
+	svc.Startup()
+	return svc
+}
+
+func (t *authServiceTestSuite) getTokenHeader(token string) map[string]interface{} {
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		t.T().Fatal(err)
+	}
+	return parsed.Header
+}
+
+func (t *authServiceTestSuite) TestGetToken_HS256() {
+	svc := t.getService(jwtkeys.MethodHS256, "secret", "", "", nil)
+
+	token, _, err := svc.GetToken(t.testUser)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "HS256", t.getTokenHeader(*token)["alg"])
+
+	userID, err := svc.Authorize("Bearer " + *token)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), t.testUser.ID, *userID)
+
+	assert.Empty(t.T(), svc.GetJWKS().Keys)
+}
+
+func (t *authServiceTestSuite) TestGetToken_RS256() {
+	privatePath, _ := t.writeRSAKey("rsa")
+	svc := t.getService(jwtkeys.MethodRS256, "", privatePath, "rsa-1", nil)
+
+	token, _, err := svc.GetToken(t.testUser)
+	assert.NoError(t.T(), err)
+	header := t.getTokenHeader(*token)
+	assert.Equal(t.T(), "RS256", header["alg"])
+	assert.Equal(t.T(), "rsa-1", header["kid"])
+
+	userID, err := svc.Authorize("Bearer " + *token)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), t.testUser.ID, *userID)
+
+	jwks := svc.GetJWKS()
+	assert.Len(t.T(), jwks.Keys, 1)
+	assert.Equal(t.T(), "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t.T(), "rsa-1", jwks.Keys[0].KeyID)
+	assert.Equal(t.T(), "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t.T(), "AQAB", jwks.Keys[0].E)
+	assert.NotEmpty(t.T(), jwks.Keys[0].N)
+}
+
+func (t *authServiceTestSuite) TestGetToken_EdDSA() {
+	privatePath, _ := t.writeEd25519Key("ed")
+	svc := t.getService(jwtkeys.MethodEdDSA, "", privatePath, "ed-1", nil)
+
+	token, _, err := svc.GetToken(t.testUser)
+	assert.NoError(t.T(), err)
+	header := t.getTokenHeader(*token)
+	assert.Equal(t.T(), "EdDSA", header["alg"])
+	assert.Equal(t.T(), "ed-1", header["kid"])
+
+	userID, err := svc.Authorize("Bearer " + *token)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), t.testUser.ID, *userID)
+
+	jwks := svc.GetJWKS()
+	assert.Len(t.T(), jwks.Keys, 1)
+	assert.Equal(t.T(), "OKP", jwks.Keys[0].KeyType)
+	assert.Equal(t.T(), "Ed25519", jwks.Keys[0].Curve)
+	x, err := base64.RawURLEncoding.DecodeString(jwks.Keys[0].X)
+	assert.NoError(t.T(), err)
+	assert.Len(t.T(), x, ed25519.PublicKeySize)
+}
+
+func (t *authServiceTestSuite) TestAuthorize_RotatedKeyStillValid() {
+	oldPrivatePath, oldPublicPath := t.writeRSAKey("old")
+	newPrivatePath, _ := t.writeEd25519Key("new")
+
+	oldSvc := t.getService(jwtkeys.MethodRS256, "", oldPrivatePath, "old", nil)
+	oldToken, _, err := oldSvc.GetToken(t.testUser)
+	assert.NoError(t.T(), err)
+
+	newSvc := t.getService(jwtkeys.MethodEdDSA, "", newPrivatePath, "new", map[string]string{"old": oldPublicPath})
+
+	userID, err := newSvc.Authorize("Bearer " + *oldToken)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), t.testUser.ID, *userID)
+
+	jwks := newSvc.GetJWKS()
+	assert.Len(t.T(), jwks.Keys, 2)
+	assert.Equal(t.T(), "new", jwks.Keys[0].KeyID)
+	assert.Equal(t.T(), "old", jwks.Keys[1].KeyID)
+}
+
+func (t *authServiceTestSuite) TestAuthorize_RetiredKeyRejected() {
+	oldPrivatePath, _ := t.writeRSAKey("old")
+	newPrivatePath, _ := t.writeRSAKey("new")
+
+	oldSvc := t.getService(jwtkeys.MethodRS256, "", oldPrivatePath, "old", nil)
+	oldToken, _, err := oldSvc.GetToken(t.testUser)
+	assert.NoError(t.T(), err)
+
+	newSvc := t.getService(jwtkeys.MethodRS256, "", newPrivatePath, "new", nil)
+
+	userID, err := newSvc.Authorize("Bearer " + *oldToken)
+	assert.Nil(t.T(), userID)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "unknown key ID")
+}
+
+func (t *authServiceTestSuite) TestAuthorize_AlgorithmMismatchRejected() {
+	privatePath, publicPath := t.writeRSAKey("rsa")
+	svc := t.getService(jwtkeys.MethodRS256, "", privatePath, "rsa-1", nil)
+
+	// a token signed with HMAC using the public key as the secret must not verify
+	publicPEM, _ := os.ReadFile(publicPath)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": "x"})
+	forged.Header["kid"] = "rsa-1"
+	forgedString, err := forged.SignedString(publicPEM)
+	assert.NoError(t.T(), err)
+
+	userID, err := svc.Authorize("Bearer " + forgedString)
+	assert.Nil(t.T(), userID)
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "unexpected signing method")
+}
+
+func (t *authServiceTestSuite) TestLoad_Errors() {
+	_, err := jwtkeys.Load("PS512", "", "", "", nil)
+	assert.Error(t.T(), err)
+
+	_, err = jwtkeys.Load(jwtkeys.MethodRS256, "", filepath.Join(t.dir, "missing.key"), "kid", nil)
+	assert.Error(t.T(), err)
+
+	privatePath, _ := t.writeRSAKey("rsa")
+	_, err = jwtkeys.Load(jwtkeys.MethodRS256, "", privatePath, "", nil)
+	assert.Error(t.T(), err)
+
+	_, err = jwtkeys.Load(jwtkeys.MethodRS256, "", privatePath, "kid", map[string]string{"old": privatePath + ".missing"})
+	assert.Error(t.T(), err)
+}
@@ -2,8 +2,10 @@ package service
 
 import (
 	"math"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/guregu/null"
 	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/repository"
 	"github.com/kerti/balances/backend/util/cachetime"
@@ -13,7 +15,9 @@ import (
 
 // BankAccountImpl is the service provider implementation
 type BankAccountImpl struct {
-	Repository repository.BankAccount `inject:"bankAccountRepository"`
+	Repository         repository.BankAccount `inject:"bankAccountRepository"`
+	TransferRepository repository.Transfer    `inject:"transferRepository"`
+	NoteRepository     repository.Note        `inject:"noteRepository"`
 }
 
 // Startup performs startup functions
@@ -73,6 +77,13 @@ func (s *BankAccountImpl) GetByID(id uuid.UUID, withBalances bool, balanceStartD
 		bankAccount.AttachBalances(balances, true)
 	}
 
+	notes, err := s.NoteRepository.ResolveBySubjectIDs(bankAccount.GetNoteSubjectIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	bankAccount.AttachNotes(notes)
+
 	return &bankAccount, nil
 }
 
@@ -81,6 +92,11 @@ func (s *BankAccountImpl) GetByFilter(input model.BankAccountFilterInput) ([]mod
 	return s.Repository.ResolveByFilter(input.ToFilter())
 }
 
+// AggregateByFilter groups a set of Bank Accounts by its filter and aggregates every group
+func (s *BankAccountImpl) AggregateByFilter(input model.BankAccountFilterInput) ([]model.AggregateRow, error) {
+	return s.Repository.AggregateByFilter(input.ToFilter())
+}
+
 // Update updates an existing Bank Account
 func (s *BankAccountImpl) Update(input model.BankAccountInput, userID uuid.UUID) (*model.BankAccount, error) {
 	bankAccounts, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
@@ -108,7 +124,7 @@ func (s *BankAccountImpl) Update(input model.BankAccountInput, userID uuid.UUID)
 }
 
 // Delete deletes an existing Bank Account. The method will find all the account's balances
-// and delete all of them also.
+// and delete all of them also. A Bank Account that is still either side of a Transfer cannot be deleted.
 func (s *BankAccountImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.BankAccount, error) {
 	bankAccounts, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
 	if err != nil {
@@ -123,6 +139,16 @@ func (s *BankAccountImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.BankAcc
 
 	// pre-validate to save one database call
 	if !bankAccount.Deleted.Valid && !bankAccount.DeletedBy.Valid {
+		// deleting the account would leave its Transfers, and their balances on the other account, behind
+		transferred, err := s.TransferRepository.ExistsByBankAccountID(bankAccount.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if transferred {
+			return nil, failure.OperationNotPermitted("delete", "Bank Account", "the Bank Account has Transfers that have not been deleted")
+		}
+
 		filter := model.BankAccountBalanceFilterInput{}
 		filter.BankAccountIDs = &[]uuid.UUID{bankAccount.ID}
 
@@ -152,6 +178,54 @@ func (s *BankAccountImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.BankAcc
 	return &bankAccount, err
 }
 
+// Restore restores a deleted Bank Account. The method will find all the account's balances, deleted or
+// not, and restore those that were deleted together with the Bank Account.
+func (s *BankAccountImpl) Restore(id uuid.UUID, userID uuid.UUID) (*model.BankAccount, error) {
+	bankAccounts, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccounts) != 1 {
+		return nil, failure.EntityNotFound("restore", "Bank Account")
+	}
+
+	bankAccount := bankAccounts[0]
+
+	// pre-validate to save one database call
+	if bankAccount.Deleted.Valid || bankAccount.DeletedBy.Valid {
+		filter := model.BankAccountBalanceFilterInput{}
+		filter.BankAccountIDs = &[]uuid.UUID{bankAccount.ID}
+
+		page := 1
+		pageSize := math.MaxInt
+		includeDeleted := true
+
+		filter.Page = &page
+		filter.PageSize = &pageSize
+		filter.IncludeDeleted = &includeDeleted
+
+		balances, _, err := s.Repository.ResolveBalancesByFilter(filter.ToFilter())
+		if err != nil {
+			return nil, err
+		}
+
+		bankAccount.AttachBalances(balances, true)
+	}
+
+	err = bankAccount.Restore(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(bankAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bankAccount, err
+}
+
 // CreateBalance creates a new Bank Account Balance
 func (s *BankAccountImpl) CreateBalance(input model.BankAccountBalanceInput, userID uuid.UUID) (*model.BankAccountBalance, error) {
 	bankAccounts, err := s.Repository.ResolveByIDs([]uuid.UUID{input.BankAccountID})
@@ -200,6 +274,218 @@ func (s *BankAccountImpl) CreateBalance(input model.BankAccountBalanceInput, use
 	return &bankAccountBalance, nil
 }
 
+// ImportBalances validates a history of Bank Account Balances from CSV and, unless it is a dry run,
+// imports every Balance at once, updating the Bank Account's last balance only once
+func (s *BankAccountImpl) ImportBalances(input model.BankAccountBalanceImportInput, userID uuid.UUID) (*model.ImportResult, error) {
+	bankAccounts, err := s.Repository.ResolveByIDs([]uuid.UUID{input.BankAccountID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccounts) != 1 {
+		return nil, failure.EntityNotFound("import balances", "Bank Account")
+	}
+
+	bankAccount := bankAccounts[0]
+
+	if bankAccount.Deleted.Valid {
+		return nil, failure.OperationNotPermitted("import balances", "Bank Account", "the Bank Account is already deleted")
+	}
+
+	if bankAccount.Status == model.BankAccountStatusInactive {
+		return nil, failure.OperationNotPermitted("import balances", "Bank Account", "the Bank Account is inactive")
+	}
+
+	rows, rowErrors, err := input.ParseRows()
+	if err != nil {
+		return nil, err
+	}
+
+	result := model.NewImportResult(input.DryRun, rows, rowErrors)
+	if !result.IsValid() || result.DryRun {
+		return &result, nil
+	}
+
+	bankAccountBalances := make([]model.BankAccountBalance, 0, len(rows))
+	for _, row := range rows {
+		bankAccountBalances = append(bankAccountBalances, model.NewBankAccountBalanceFromInput(input.ToBalanceInput(row), bankAccount.ID, userID))
+	}
+
+	lastBalances, err := s.Repository.ResolveLastBalancesByBankAccountID(bankAccount.ID, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	latestInput := input.ToBalanceInput(model.GetLatestImportRow(rows))
+	var bankAccountToUpdate *model.BankAccount
+
+	if len(lastBalances) == 0 || lastBalances[0].Date.Before(latestInput.Date.Time()) {
+		bankAccount.SetNewBalance(latestInput, userID)
+		bankAccountToUpdate = &bankAccount
+	}
+
+	err = s.Repository.ImportBalances(bankAccountBalances, bankAccountToUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(bankAccountBalances)
+	return &result, nil
+}
+
+// ImportStatement imports the closing balances of a bank statement file as Bank Account Balances, matching each
+// account in the file to a Bank Account by its account number. Accounts that cannot be matched are reported
+// rather than failing the whole import, and balances already recorded on the same date are skipped so that
+// a statement may safely be imported twice.
+func (s *BankAccountImpl) ImportStatement(input model.StatementImportInput, userID uuid.UUID) (*model.StatementImportResult, error) {
+	statements, err := input.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	accountNumbers := model.GetStatementAccountNumbers(statements)
+	bankAccountFilter := model.BankAccountFilterInput{AccountNumbers: &accountNumbers}
+
+	page := 1
+	pageSize := math.MaxInt
+
+	bankAccountFilter.Page = &page
+	bankAccountFilter.PageSize = &pageSize
+
+	bankAccounts, _, err := s.Repository.ResolveByFilter(bankAccountFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	result := model.StatementImportResult{
+		DryRun:   input.DryRun,
+		Accounts: make([]model.StatementAccountResult, len(statements)),
+	}
+	matches := make(map[int]model.BankAccount)
+	matchedIDs := make([]uuid.UUID, 0, len(statements))
+	var earliest, latest time.Time
+
+	for idx, statement := range statements {
+		result.Accounts[idx] = model.StatementAccountResult{
+			AccountNumber: statement.AccountNumber,
+			Balances:      len(statement.Balances),
+		}
+
+		candidates := make([]model.BankAccount, 0, 1)
+		for _, bankAccount := range bankAccounts {
+			if model.NormalizeAccountNumber(bankAccount.AccountNumber) == model.NormalizeAccountNumber(statement.AccountNumber) {
+				candidates = append(candidates, bankAccount)
+			}
+		}
+
+		switch {
+		case len(candidates) == 0:
+			result.Accounts[idx].SetError("no Bank Account has this account number")
+			continue
+		case len(candidates) > 1:
+			result.Accounts[idx].SetError("several Bank Accounts share this account number")
+			continue
+		}
+
+		bankAccount := candidates[0]
+		result.Accounts[idx].BankAccountID = &bankAccount.ID
+
+		if bankAccount.Status == model.BankAccountStatusInactive {
+			result.Accounts[idx].SetError("the Bank Account is inactive")
+			continue
+		}
+
+		if len(statement.Balances) == 0 {
+			result.Accounts[idx].SetError("the statement holds no closing balance")
+			continue
+		}
+
+		matches[idx] = bankAccount
+		matchedIDs = append(matchedIDs, bankAccount.ID)
+		for _, balance := range statement.Balances {
+			if earliest.IsZero() || balance.Date.Before(earliest) {
+				earliest = balance.Date
+			}
+			if latest.IsZero() || balance.Date.After(latest) {
+				latest = balance.Date
+			}
+		}
+	}
+
+	if len(matches) == 0 {
+		return &result, nil
+	}
+
+	balanceFilter := model.BankAccountBalanceFilterInput{
+		BankAccountIDs: &matchedIDs,
+		StartDate:      cachetime.NCacheTime(null.TimeFrom(earliest)),
+		EndDate:        cachetime.NCacheTime(null.TimeFrom(latest)),
+	}
+	balanceFilter.Page = &page
+	balanceFilter.PageSize = &pageSize
+
+	existingBalances, _, err := s.Repository.ResolveBalancesByFilter(balanceFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, statement := range statements {
+		bankAccount, ok := matches[idx]
+		if !ok {
+			continue
+		}
+
+		bankAccountBalances := make([]model.BankAccountBalance, 0, len(statement.Balances))
+		var latestInput *model.BankAccountBalanceInput
+		for _, balance := range statement.Balances {
+			if isBalanceRecorded(existingBalances, bankAccount.ID, balance.Date) {
+				result.Accounts[idx].Skipped++
+				continue
+			}
+
+			balanceInput := model.NewBankAccountBalanceInputFromStatement(bankAccount.ID, balance)
+			bankAccountBalances = append(bankAccountBalances, model.NewBankAccountBalanceFromInput(balanceInput, bankAccount.ID, userID))
+			if latestInput == nil || balance.Date.After(latestInput.Date.Time()) {
+				latestInput = &balanceInput
+			}
+		}
+
+		if result.DryRun || len(bankAccountBalances) == 0 {
+			continue
+		}
+
+		lastBalances, err := s.Repository.ResolveLastBalancesByBankAccountID(bankAccount.ID, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		var bankAccountToUpdate *model.BankAccount
+		if len(lastBalances) == 0 || lastBalances[0].Date.Before(latestInput.Date.Time()) {
+			bankAccount.SetNewBalance(*latestInput, userID)
+			bankAccountToUpdate = &bankAccount
+		}
+
+		err = s.Repository.ImportBalances(bankAccountBalances, bankAccountToUpdate)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Accounts[idx].Imported = len(bankAccountBalances)
+	}
+
+	return &result, nil
+}
+
+// isBalanceRecorded checks whether a Bank Account already has a balance recorded on a date
+func isBalanceRecorded(bankAccountBalances []model.BankAccountBalance, bankAccountID uuid.UUID, date time.Time) bool {
+	for _, bankAccountBalance := range bankAccountBalances {
+		if bankAccountBalance.BankAccountID == bankAccountID && bankAccountBalance.Date.Equal(date) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetBalanceByID fetches a Bank Account Balance by its ID
 func (s *BankAccountImpl) GetBalanceByID(id uuid.UUID) (*model.BankAccountBalance, error) {
 	bankAccountBalances, err := s.Repository.ResolveBalancesByIDs([]uuid.UUID{id})
@@ -211,7 +497,16 @@ func (s *BankAccountImpl) GetBalanceByID(id uuid.UUID) (*model.BankAccountBalanc
 		return nil, failure.EntityNotFound("get by ID", "Bank Account Balance")
 	}
 
-	return &bankAccountBalances[0], nil
+	bankAccountBalance := bankAccountBalances[0]
+
+	notes, err := s.NoteRepository.ResolveBySubjectIDs([]uuid.UUID{bankAccountBalance.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	bankAccountBalance.AttachNotes(notes)
+
+	return &bankAccountBalance, nil
 }
 
 // GetBalancesByFilter fetches a set of Bank Account Balances by its filter
@@ -255,6 +550,11 @@ func (s *BankAccountImpl) UpdateBalance(input model.BankAccountBalanceInput, use
 		return nil, failure.OperationNotPermitted("update", "Bank Account Balance", "the Bank Account Balance is already deleted")
 	}
 
+	err = s.checkNotTransferred("update", bankAccountBalance.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	err = bankAccountBalance.Update(input, userID)
 	if err != nil {
 		return nil, err
@@ -303,6 +603,11 @@ func (s *BankAccountImpl) DeleteBalance(id uuid.UUID, userID uuid.UUID) (*model.
 		return nil, failure.OperationNotPermitted("delete", "Bank Account Balance", "the Bank Account Balance is already deleted")
 	}
 
+	err = s.checkNotTransferred("delete", bankAccountBalance.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	bankAccounts, err := s.Repository.ResolveByIDs([]uuid.UUID{bankAccountBalance.BankAccountID})
 	if err != nil {
 		return nil, err
@@ -358,3 +663,18 @@ func (s *BankAccountImpl) DeleteBalance(id uuid.UUID, userID uuid.UUID) (*model.
 
 	return &bankAccountBalance, nil
 }
+
+// checkNotTransferred makes sure that a Bank Account Balance was not recorded by a Transfer, whose both sides
+// may only be changed together through the Transfer itself
+func (s *BankAccountImpl) checkNotTransferred(operation string, id uuid.UUID) error {
+	transferred, err := s.TransferRepository.ExistsByBalanceID(id)
+	if err != nil {
+		return err
+	}
+
+	if transferred {
+		return failure.OperationNotPermitted(operation, "Bank Account Balance", "the Bank Account Balance belongs to a Transfer")
+	}
+
+	return nil
+}
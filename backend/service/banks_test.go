@@ -13,7 +13,10 @@ import (
 	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/service"
 	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
 	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/kerti/balances/backend/util/statement"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -23,6 +26,8 @@ type bankAccountsServiceTestSuite struct {
 	ctrl                     *gomock.Controller
 	svc                      service.BankAccount
 	mockRepo                 *mock_repository.MockBankAccount
+	mockTransferRepo         *mock_repository.MockTransfer
+	mockNoteRepo             *mock_repository.MockNote
 	testUserID               uuid.UUID
 	testBankAccountID        uuid.UUID
 	testBankAccountBalanceID uuid.UUID
@@ -35,8 +40,12 @@ func TestBankAccountsService(t *testing.T) {
 func (t *bankAccountsServiceTestSuite) SetupTest() {
 	t.ctrl = gomock.NewController(t.T())
 	t.mockRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.mockTransferRepo = mock_repository.NewMockTransfer(t.ctrl)
+	t.mockNoteRepo = mock_repository.NewMockNote(t.ctrl)
 	t.svc = &service.BankAccountImpl{
-		Repository: t.mockRepo,
+		Repository:         t.mockRepo,
+		TransferRepository: t.mockTransferRepo,
+		NoteRepository:     t.mockNoteRepo,
 	}
 	t.testUserID, _ = uuid.NewV7()
 	t.testBankAccountID, _ = uuid.NewV7()
@@ -192,11 +201,50 @@ func (t *bankAccountsServiceTestSuite) TestGetByID_Exists_NoBalance() {
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return(resolvedBankAccountSlice, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testBankAccountID, false, cachetime.NCacheTime{}, cachetime.NCacheTime{}, nil)
 
 	assert.NoError(t.T(), err)
 }
 
+func (t *bankAccountsServiceTestSuite) TestGetByID_Exists_WithNotes() {
+	balanceFilterInput := model.BankAccountBalanceFilterInput{
+		BankAccountIDs: &[]uuid.UUID{t.testBankAccountID},
+	}
+	pageInfo := getDefaultPageInfo()
+
+	bankAccount := t.getNewBankAccount(nuuid.NUUID{}, nil)
+	balance := t.getNewBankAccountBalance(nuuid.NUUID{}, nuuid.From(bankAccount.ID), float64(1000), time.Now())
+	balanceSlice := []model.BankAccountBalance{balance}
+
+	accountNote := model.NewNoteFromInput(model.NoteInput{
+		EntityType: model.EntityTypeBankAccount,
+		SubjectID:  bankAccount.ID,
+		Content:    "Rate raised to 4.5% after renewal",
+	}, t.testUserID)
+	balanceNote := model.NewNoteFromInput(model.NoteInput{
+		EntityType: model.EntityTypeBankAccountBalance,
+		SubjectID:  balance.ID,
+		Content:    "Includes the yearly bonus",
+	}, t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{bankAccount}, nil)
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(balanceFilterInput.ToFilter()).
+		Return(balanceSlice, pageInfo, nil)
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs([]uuid.UUID{bankAccount.ID, balance.ID}).
+		Return([]model.Note{accountNote, balanceNote}, nil)
+
+	res, err := t.svc.GetByID(t.testBankAccountID, true, cachetime.NCacheTime{}, cachetime.NCacheTime{}, nil)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), []model.Note{accountNote}, res.Notes)
+	assert.Len(t.T(), res.Balances, 1)
+	assert.Equal(t.T(), []model.Note{balanceNote}, res.Balances[0].Notes)
+}
+
 func (t *bankAccountsServiceTestSuite) TestGetByID_Exists_WithBalance_NoFilter() {
 	balanceFilterInput := model.BankAccountBalanceFilterInput{
 		BankAccountIDs: &[]uuid.UUID{t.testBankAccountID},
@@ -216,6 +264,9 @@ func (t *bankAccountsServiceTestSuite) TestGetByID_Exists_WithBalance_NoFilter()
 	t.mockRepo.EXPECT().ResolveBalancesByFilter(balanceFilterInput.ToFilter()).
 		Return(balanceSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testBankAccountID, true, cachetime.NCacheTime{}, cachetime.NCacheTime{}, nil)
 
 	assert.NoError(t.T(), err)
@@ -242,6 +293,9 @@ func (t *bankAccountsServiceTestSuite) TestGetByID_Exists_WithBalance_WithStartD
 	t.mockRepo.EXPECT().ResolveBalancesByFilter(balanceFilterInput.ToFilter()).
 		Return(balanceSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testBankAccountID, true, yesterday, cachetime.NCacheTime{}, nil)
 
 	assert.NoError(t.T(), err)
@@ -268,6 +322,9 @@ func (t *bankAccountsServiceTestSuite) TestGetByID_Exists_WithBalance_WithEndDat
 	t.mockRepo.EXPECT().ResolveBalancesByFilter(balanceFilterInput.ToFilter()).
 		Return(balanceSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testBankAccountID, true, cachetime.NCacheTime{}, today, nil)
 
 	assert.NoError(t.T(), err)
@@ -296,6 +353,9 @@ func (t *bankAccountsServiceTestSuite) TestGetByID_Exists_WithBalance_WithBothDa
 	t.mockRepo.EXPECT().ResolveBalancesByFilter(balanceFilterInput.ToFilter()).
 		Return(balanceSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testBankAccountID, true, yesterday, today, nil)
 
 	assert.NoError(t.T(), err)
@@ -324,6 +384,9 @@ func (t *bankAccountsServiceTestSuite) TestGetByID_Exists_WithBalance_WithPageSi
 	t.mockRepo.EXPECT().ResolveBalancesByFilter(balanceFilterInput.ToFilter()).
 		Return(balanceSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testBankAccountID, true, cachetime.NCacheTime{}, cachetime.NCacheTime{}, &pageSize)
 
 	assert.NoError(t.T(), err)
@@ -402,6 +465,26 @@ func (t *bankAccountsServiceTestSuite) TestGetByFilter_WithKeyword() {
 	assert.NoError(t.T(), err)
 }
 
+func (t *bankAccountsServiceTestSuite) TestAggregateByFilter_Normal() {
+	filterInput := model.BankAccountFilterInput{}
+	filterInput.Aggregation = &filter.AggregationInput{
+		GroupBy:    []string{"status"},
+		Aggregates: []filter.AggregateInput{{Function: filter.AggregateFunctionCount}},
+	}
+	bankAccountFilter := filterInput.ToFilter()
+
+	t.mockRepo.EXPECT().AggregateByFilter(bankAccountFilter).
+		Return([]model.AggregateRow{{
+			Group:  map[string]interface{}{"status": "active"},
+			Values: map[string]interface{}{"count": int64(2)},
+		}}, nil)
+
+	res, err := t.svc.AggregateByFilter(filterInput)
+
+	assert.NoError(t.T(), err)
+	assert.Len(t.T(), res, 1)
+}
+
 func (t *bankAccountsServiceTestSuite) TestUpdate_Normal() {
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
@@ -496,6 +579,9 @@ func (t *bankAccountsServiceTestSuite) TestDelete_Normal() {
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return([]model.BankAccount{testBankAccount}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBankAccountID(t.testBankAccountID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
 		Return(balanceSlice, getDefaultPageInfo(), nil)
 
@@ -513,6 +599,8 @@ func (t *bankAccountsServiceTestSuite) TestDelete_Normal() {
 	for _, resBalance := range res.Balances {
 		assert.True(t.T(), resBalance.Deleted.Valid)
 		assert.True(t.T(), resBalance.DeletedBy.Valid)
+		assert.True(t.T(), resBalance.DeletedByCascade)
+		assert.Equal(t.T(), res.Deleted, resBalance.Deleted)
 	}
 }
 
@@ -537,6 +625,9 @@ func (t *bankAccountsServiceTestSuite) TestDelete_RepoErrorResolvingBalancesByFi
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return([]model.BankAccount{testBankAccount}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBankAccountID(t.testBankAccountID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
 		Return([]model.BankAccountBalance{}, model.PageInfoOutput{}, errors.New(errMsg))
 
@@ -580,6 +671,9 @@ func (t *bankAccountsServiceTestSuite) TestDelete_AccountBalanceAlreadyDeleted()
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBankAccountID(t.testBankAccountID).
+		Return(false, nil)
+
 	testDeletedNonLastBankAccountBalance := t.getNewBankAccountBalance(
 		nuuid.NUUID{},
 		nuuid.From(t.testBankAccountID),
@@ -613,6 +707,37 @@ func (t *bankAccountsServiceTestSuite) TestDelete_AccountBalanceAlreadyDeleted()
 	assert.Nil(t.T(), res)
 }
 
+func (t *bankAccountsServiceTestSuite) TestDelete_AccountTransferred() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
+
+	t.mockTransferRepo.EXPECT().ExistsByBankAccountID(t.testBankAccountID).
+		Return(true, nil)
+
+	res, err := t.svc.Delete(t.testBankAccountID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+	assert.Contains(t.T(), err.Error(), "Transfers")
+	assert.Nil(t.T(), res)
+}
+
+func (t *bankAccountsServiceTestSuite) TestDelete_RepoErrorCheckingTransfers() {
+	errMsg := "failed checking transfers"
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
+
+	t.mockTransferRepo.EXPECT().ExistsByBankAccountID(t.testBankAccountID).
+		Return(false, errors.New(errMsg))
+
+	res, err := t.svc.Delete(t.testBankAccountID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
 func (t *bankAccountsServiceTestSuite) TestDelete_RepoErrorUpdating() {
 	errMsg := "failed updating bank account"
 	balanceSlice := []model.BankAccountBalance{}
@@ -638,6 +763,9 @@ func (t *bankAccountsServiceTestSuite) TestDelete_RepoErrorUpdating() {
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return([]model.BankAccount{testBankAccount}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBankAccountID(t.testBankAccountID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
 		Return(balanceSlice, getDefaultPageInfo(), nil)
 
@@ -651,6 +779,135 @@ func (t *bankAccountsServiceTestSuite) TestDelete_RepoErrorUpdating() {
 	assert.Nil(t.T(), res)
 }
 
+func (t *bankAccountsServiceTestSuite) TestRestore_Normal() {
+	deletedAt := time.Now().AddDate(0, 0, -1)
+
+	// deleted together with the Bank Account
+	cascadedBalance := t.getNewBankAccountBalance(
+		nuuid.NUUID{},
+		nuuid.From(t.testBankAccountID),
+		float64(10000),
+		time.Now().AddDate(0, 0, -2))
+	cascadedBalance.Deleted = null.TimeFrom(deletedAt)
+	cascadedBalance.DeletedBy = nuuid.From(t.testUserID)
+	cascadedBalance.DeletedByCascade = true
+
+	// deleted on its own in the same second and by the same user as the Bank Account
+	independentBalance := t.getNewBankAccountBalance(
+		nuuid.NUUID{},
+		nuuid.From(t.testBankAccountID),
+		float64(12000),
+		time.Now().AddDate(0, 0, -3))
+	independentBalance.Deleted = null.TimeFrom(deletedAt)
+	independentBalance.DeletedBy = nuuid.From(t.testUserID)
+
+	testBankAccount := t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)
+	testBankAccount.Deleted = null.TimeFrom(deletedAt)
+	testBankAccount.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{testBankAccount}, nil)
+
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
+		Return([]model.BankAccountBalance{cascadedBalance, independentBalance}, getDefaultPageInfo(), nil)
+
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	res, err := t.svc.Restore(t.testBankAccountID, t.testUserID)
+
+	assert.NoError(t.T(), err)
+
+	assert.NotNil(t.T(), res)
+	assert.False(t.T(), res.Deleted.Valid)
+	assert.False(t.T(), res.DeletedBy.Valid)
+	assert.True(t.T(), res.UpdatedBy.Valid)
+
+	assert.Len(t.T(), res.Balances, 1)
+	assert.Equal(t.T(), cascadedBalance.ID, res.Balances[0].ID)
+	assert.False(t.T(), res.Balances[0].Deleted.Valid)
+	assert.False(t.T(), res.Balances[0].DeletedBy.Valid)
+	assert.False(t.T(), res.Balances[0].DeletedByCascade)
+}
+
+func (t *bankAccountsServiceTestSuite) TestRestore_RepoErrorResolvingByIDs() {
+	errMsg := "failed resolving by IDs"
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{}, errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.testBankAccountID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
+func (t *bankAccountsServiceTestSuite) TestRestore_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{}, nil)
+
+	res, err := t.svc.Restore(t.testBankAccountID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "EntityNotFound")
+	assert.Nil(t.T(), res)
+}
+
+func (t *bankAccountsServiceTestSuite) TestRestore_NotDeleted() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
+
+	res, err := t.svc.Restore(t.testBankAccountID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "restore")
+	assert.Contains(t.T(), err.Error(), "Bank Account")
+	assert.Contains(t.T(), err.Error(), "not deleted")
+	assert.Nil(t.T(), res)
+}
+
+func (t *bankAccountsServiceTestSuite) TestRestore_RepoErrorResolvingBalancesByFilter() {
+	errMsg := "failed resolving balances"
+
+	testBankAccount := t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)
+	testBankAccount.Deleted = null.TimeFrom(time.Now())
+	testBankAccount.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{testBankAccount}, nil)
+
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
+		Return([]model.BankAccountBalance{}, model.PageInfoOutput{}, errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.testBankAccountID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
+func (t *bankAccountsServiceTestSuite) TestRestore_RepoErrorUpdating() {
+	errMsg := "failed updating"
+
+	testBankAccount := t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)
+	testBankAccount.Deleted = null.TimeFrom(time.Now())
+	testBankAccount.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{testBankAccount}, nil)
+
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
+		Return([]model.BankAccountBalance{}, getDefaultPageInfo(), nil)
+
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.testBankAccountID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
 func (t *bankAccountsServiceTestSuite) TestCreateBalance_Normal_LastBalance() {
 	testBalanceDate := time.Now()
 	testInput := t.getNewBankAccountBalanceInput(
@@ -909,6 +1166,356 @@ func (t *bankAccountsServiceTestSuite) TestCreateBalance_RepoFailedCreatingBankA
 	assert.Nil(t.T(), res)
 }
 
+func (t *bankAccountsServiceTestSuite) getNewBankAccountBalanceImportInput(csv string, dryRun bool) model.BankAccountBalanceImportInput {
+	return model.BankAccountBalanceImportInput{
+		ImportInput: model.ImportInput{
+			CSV:     csv,
+			Mapping: model.ImportColumnMapping{Date: "Month End", Amount: "Balance"},
+			DryRun:  dryRun,
+		},
+		BankAccountID: t.testBankAccountID,
+	}
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportBalances_Normal_UpdatesLastBalanceOnce() {
+	testInput := t.getNewBankAccountBalanceImportInput("Month End,Balance\n2024-01-31,100.5\n2024-03-31,300\n2024-02-29,200\n", false)
+	testAccount := t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)
+
+	testAccountAfterUpdate := t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)
+	testAccountAfterUpdate.LastBalance = float64(300)
+	testAccountAfterUpdate.LastBalanceDate = time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{testAccount}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
+		Return(
+			[]model.BankAccountBalance{
+				t.getNewBankAccountBalance(
+					nuuid.NUUID{},
+					nuuid.From(t.testBankAccountID),
+					float64(50),
+					time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))},
+			nil)
+
+	t.mockRepo.EXPECT().ImportBalances(gomock.Len(3), accountPointerMatcher{testAccountAfterUpdate}).
+		DoAndReturn(func(bankAccountBalances []model.BankAccountBalance, bankAccount *model.BankAccount) error {
+			assert.Equal(t.T(), float64(100.5), bankAccountBalances[0].Balance)
+			assert.Equal(t.T(), t.testBankAccountID, bankAccountBalances[0].BankAccountID)
+			assert.Equal(t.T(), t.testUserID, bankAccountBalances[0].CreatedBy)
+			return nil
+		})
+
+	res, err := t.svc.ImportBalances(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), res.IsValid())
+	assert.Equal(t.T(), 3, res.Rows)
+	assert.Equal(t.T(), 3, res.Imported)
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportBalances_Normal_OlderThanLastBalance() {
+	testInput := t.getNewBankAccountBalanceImportInput("Month End,Balance\n2024-01-31,100\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
+		Return(
+			[]model.BankAccountBalance{
+				t.getNewBankAccountBalance(
+					nuuid.NUUID{},
+					nuuid.From(t.testBankAccountID),
+					float64(900),
+					time.Now())},
+			nil)
+
+	t.mockRepo.EXPECT().ImportBalances(gomock.Len(1), nil).Return(nil)
+
+	res, err := t.svc.ImportBalances(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), 1, res.Imported)
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportBalances_DryRun() {
+	testInput := t.getNewBankAccountBalanceImportInput("Month End,Balance\n2024-01-31,100\n2024-02-29,200\n", true)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
+
+	res, err := t.svc.ImportBalances(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), res.IsValid())
+	assert.True(t.T(), res.DryRun)
+	assert.Equal(t.T(), 2, res.Rows)
+	assert.Equal(t.T(), 0, res.Imported)
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportBalances_RowErrors() {
+	testInput := t.getNewBankAccountBalanceImportInput(
+		"Month End,Balance\n2024-01-31,100\n31/02/2024,abc\n2024-01-31,150\n2024-03-31\n",
+		false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
+
+	res, err := t.svc.ImportBalances(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), res.IsValid())
+	assert.Equal(t.T(), 4, res.Rows)
+	assert.Equal(t.T(), 0, res.Imported)
+	assert.Len(t.T(), res.Errors, 4)
+	assert.Equal(t.T(), 3, res.Errors[0].Line)
+	assert.Equal(t.T(), "Month End", *res.Errors[0].Column)
+	assert.Equal(t.T(), "Balance", *res.Errors[1].Column)
+	assert.Contains(t.T(), res.Errors[2].Message, "line 2")
+	assert.Equal(t.T(), 5, res.Errors[3].Line)
+	assert.Nil(t.T(), res.Errors[3].Column)
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportBalances_InvalidMapping() {
+	testInput := t.getNewBankAccountBalanceImportInput("Date,Balance\n2024-01-31,100\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
+
+	res, err := t.svc.ImportBalances(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportBalances_BankAccountInactive() {
+	testInput := t.getNewBankAccountBalanceImportInput("Month End,Balance\n2024-01-31,100\n", false)
+	testAccount := t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)
+	testAccount.Status = model.BankAccountStatusInactive
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{testAccount}, nil)
+
+	res, err := t.svc.ImportBalances(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportBalances_RepoFailedImporting() {
+	errMsg := "failed to import bank account balances"
+	testInput := t.getNewBankAccountBalanceImportInput("Month End,Balance\n2024-01-31,100\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
+		Return([]model.BankAccountBalance{}, nil)
+
+	t.mockRepo.EXPECT().ImportBalances(gomock.Len(1), gomock.Not(gomock.Nil())).
+		Return(errors.New(errMsg))
+
+	res, err := t.svc.ImportBalances(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
+const testOFXStatement = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20240301120000</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS><TRNUID>1<STMTRS>
+<CURDEF>USD
+<BANKACCTFROM><BANKID>021000021<ACCTID>1234567890<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST><STMTTRN><TRNTYPE>XFER<DTPOSTED>20240215<TRNAMT>-50.00<FITID>1
+<BANKACCTTO><BANKID>021000021<ACCTID>5555555555<ACCTTYPE>SAVINGS</BANKACCTTO></STMTTRN></BANKTRANLIST>
+<LEDGERBAL><BALAMT>1500.25<DTASOF>20240229120000.000[-5:EST]</LEDGERBAL>
+<AVAILBAL><BALAMT>1400.00<DTASOF>20240229</AVAILBAL>
+</STMTRS></STMTTRNRS>
+<STMTTRNRS><TRNUID>2<STMTRS>
+<CURDEF>USD
+<BANKACCTFROM><BANKID>021000021<ACCTID>9999999999<ACCTTYPE>SAVINGS</BANKACCTFROM>
+<LEDGERBAL><BALAMT>10.00<DTASOF>20240229</LEDGERBAL>
+</STMTRS></STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+const testCAMT053Statement = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <GrpHdr><MsgId>1</MsgId><CreDtTm>2024-03-01T08:00:00</CreDtTm></GrpHdr>
+    <Stmt>
+      <Id>1</Id>
+      <Acct><Id><Othr><Id>123 456 7890</Id></Othr></Id></Acct>
+      <Bal><Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp><Amt Ccy="EUR">900.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2024-01-31</Dt></Dt></Bal>
+      <Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="EUR">1000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Dt><Dt>2024-01-31</Dt></Dt></Bal>
+    </Stmt>
+    <Stmt>
+      <Id>2</Id>
+      <Acct><Id><Othr><Id>123 456 7890</Id></Othr></Id></Acct>
+      <Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="EUR">75.50</Amt><CdtDbtInd>DBIT</CdtDbtInd><Dt><DtTm>2024-02-29T23:59:59+01:00</DtTm></Dt></Bal>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+
+func (t *bankAccountsServiceTestSuite) TestImportStatement_OFX_SkipsRecordedBalancesAndUnmatchedAccounts() {
+	testInput := model.StatementImportInput{Content: testOFXStatement}
+	testAccount := t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		DoAndReturn(func(f filter.Filter) ([]model.BankAccount, model.PageInfoOutput, error) {
+			query, err := f.ToQueryString()
+			assert.NoError(t.T(), err)
+			assert.Contains(t.T(), query, "bank_accounts.account_number  IN  (?, ?)")
+			assert.Contains(t.T(), f.GetArgs(true), "1234567890")
+			return []model.BankAccount{testAccount}, model.PageInfoOutput{}, nil
+		})
+
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
+		Return([]model.BankAccountBalance{}, model.PageInfoOutput{}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
+		Return([]model.BankAccountBalance{}, nil)
+
+	t.mockRepo.EXPECT().ImportBalances(gomock.Len(1), gomock.Not(gomock.Nil())).
+		DoAndReturn(func(bankAccountBalances []model.BankAccountBalance, bankAccount *model.BankAccount) error {
+			assert.Equal(t.T(), float64(1500.25), bankAccountBalances[0].Balance)
+			assert.Equal(t.T(), time.Date(2024, 2, 29, 17, 0, 0, 0, time.UTC), bankAccountBalances[0].Date.UTC())
+			assert.Equal(t.T(), float64(1500.25), bankAccount.LastBalance)
+			return nil
+		})
+
+	res, err := t.svc.ImportStatement(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.Len(t.T(), res.Accounts, 2)
+	assert.Equal(t.T(), t.testBankAccountID, *res.Accounts[0].BankAccountID)
+	assert.Equal(t.T(), 1, res.Accounts[0].Imported)
+	assert.Nil(t.T(), res.Accounts[0].Error)
+	assert.Equal(t.T(), "9999999999", res.Accounts[1].AccountNumber)
+	assert.NotNil(t.T(), res.Accounts[1].Error)
+	assert.Equal(t.T(), 1, res.Imported())
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportStatement_CAMT053_MergesStatements() {
+	testInput := model.StatementImportInput{Content: testCAMT053Statement}
+	testAccount := t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.BankAccount{testAccount}, model.PageInfoOutput{}, nil)
+
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
+		Return(
+			[]model.BankAccountBalance{
+				t.getNewBankAccountBalance(
+					nuuid.NUUID{},
+					nuuid.From(t.testBankAccountID),
+					float64(1000),
+					time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))},
+			model.PageInfoOutput{},
+			nil)
+
+	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
+		Return(
+			[]model.BankAccountBalance{
+				t.getNewBankAccountBalance(
+					nuuid.NUUID{},
+					nuuid.From(t.testBankAccountID),
+					float64(1000),
+					time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC))},
+			nil)
+
+	t.mockRepo.EXPECT().ImportBalances(gomock.Len(1), gomock.Not(gomock.Nil())).
+		DoAndReturn(func(bankAccountBalances []model.BankAccountBalance, bankAccount *model.BankAccount) error {
+			assert.Equal(t.T(), float64(-75.5), bankAccountBalances[0].Balance)
+			assert.Equal(t.T(), time.Date(2024, 2, 29, 22, 59, 59, 0, time.UTC), bankAccountBalances[0].Date.UTC())
+			return nil
+		})
+
+	res, err := t.svc.ImportStatement(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.Len(t.T(), res.Accounts, 1)
+	assert.Equal(t.T(), 2, res.Accounts[0].Balances)
+	assert.Equal(t.T(), 1, res.Accounts[0].Skipped)
+	assert.Equal(t.T(), 1, res.Accounts[0].Imported)
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportStatement_DryRun() {
+	testFormat := statement.FormatOFX
+	testInput := model.StatementImportInput{Content: testOFXStatement, Format: &testFormat, DryRun: true}
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, model.PageInfoOutput{}, nil)
+
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
+		Return([]model.BankAccountBalance{}, model.PageInfoOutput{}, nil)
+
+	res, err := t.svc.ImportStatement(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), res.DryRun)
+	assert.Equal(t.T(), 1, res.Accounts[0].Balances)
+	assert.Equal(t.T(), 0, res.Imported())
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportStatement_AmbiguousAccountNumber() {
+	testInput := model.StatementImportInput{Content: testCAMT053Statement}
+	testAccounts := t.getBankAccountSlice(2)
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return(testAccounts, model.PageInfoOutput{}, nil)
+
+	res, err := t.svc.ImportStatement(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.Nil(t.T(), res.Accounts[0].BankAccountID)
+	assert.Contains(t.T(), *res.Accounts[0].Error, "several Bank Accounts")
+	assert.Equal(t.T(), 0, res.Imported())
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportStatement_UnrecognizedFormat() {
+	testInput := model.StatementImportInput{Content: "Date,Balance\n2024-01-31,100\n"}
+
+	res, err := t.svc.ImportStatement(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *bankAccountsServiceTestSuite) TestImportStatement_RepoFailedImporting() {
+	errMsg := "failed to import bank account balances"
+	testInput := model.StatementImportInput{Content: testOFXStatement}
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)}, model.PageInfoOutput{}, nil)
+
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
+		Return([]model.BankAccountBalance{}, model.PageInfoOutput{}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
+		Return([]model.BankAccountBalance{}, nil)
+
+	t.mockRepo.EXPECT().ImportBalances(gomock.Len(1), gomock.Not(gomock.Nil())).
+		Return(errors.New(errMsg))
+
+	res, err := t.svc.ImportStatement(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
 func (t *bankAccountsServiceTestSuite) TestGetBalanceByID_Normal() {
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return(
@@ -919,6 +1526,9 @@ func (t *bankAccountsServiceTestSuite) TestGetBalanceByID_Normal() {
 				time.Now())},
 			nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	res, err := t.svc.GetBalanceByID(t.testBankAccountBalanceID)
 
 	assert.NoError(t.T(), err)
@@ -1005,6 +1615,9 @@ func (t *bankAccountsServiceTestSuite) TestUpdateBalance_Normal_LastBalance() {
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{balanceToUpdate}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
 		Return([]model.BankAccountBalance{balanceToUpdate}, nil)
 
@@ -1050,6 +1663,9 @@ func (t *bankAccountsServiceTestSuite) TestUpdateBalance_Normal_NonLastBalance()
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{newBalanceID}).
 		Return([]model.BankAccountBalance{balanceToUpdate}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
 		Return([]model.BankAccountBalance{balanceToUpdate}, nil)
 
@@ -1235,6 +1851,39 @@ func (t *bankAccountsServiceTestSuite) TestUpdateBalance_BalanceDeleted() {
 	assert.Nil(t.T(), res)
 }
 
+func (t *bankAccountsServiceTestSuite) TestUpdateBalance_BalanceTransferred() {
+	testInput := t.getNewBankAccountBalanceInput(
+		nuuid.From(t.testBankAccountBalanceID),
+		nuuid.From(t.testBankAccountID),
+		float64(1000),
+		time.Now(),
+	)
+
+	resolvedBankAccount := t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)
+
+	resolvedBalance := t.getNewBankAccountBalance(
+		nuuid.From(t.testBankAccountBalanceID),
+		nuuid.From(t.testBankAccountID),
+		float64(900),
+		time.Now().AddDate(0, 0, -1))
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{resolvedBankAccount}, nil)
+
+	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
+		Return([]model.BankAccountBalance{resolvedBalance}, nil)
+
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(true, nil)
+
+	res, err := t.svc.UpdateBalance(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+	assert.Contains(t.T(), err.Error(), "Transfer")
+	assert.Nil(t.T(), res)
+}
+
 func (t *bankAccountsServiceTestSuite) TestUpdateBalance_RepoFailedResolvingLastBalances() {
 	errMsg := "failed resolving bank account last balance"
 	testInput := t.getNewBankAccountBalanceInput(
@@ -1260,6 +1909,9 @@ func (t *bankAccountsServiceTestSuite) TestUpdateBalance_RepoFailedResolvingLast
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{resolvedBankAccountBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
 		Return([]model.BankAccountBalance{}, errors.New(errMsg))
 
@@ -1294,6 +1946,9 @@ func (t *bankAccountsServiceTestSuite) TestUpdateBalance_LastBalanceNotFound() {
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{resolvedBankAccountBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
 		Return([]model.BankAccountBalance{}, nil)
 
@@ -1340,6 +1995,9 @@ func (t *bankAccountsServiceTestSuite) TestUpdateBalance_RepoFailedUpdatingBalan
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{balanceToUpdate}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveLastBalancesByBankAccountID(t.testBankAccountID, 1).
 		Return([]model.BankAccountBalance{balanceToUpdate}, nil)
 
@@ -1384,6 +2042,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_Normal_LastBalance() {
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{lastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return(
 			[]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)},
@@ -1429,6 +2090,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_Normal_NonLastBalance()
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{secondToLastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return(
 			[]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)},
@@ -1494,6 +2158,27 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_BalanceAlreadyDeleted()
 	assert.Nil(t.T(), res)
 }
 
+func (t *bankAccountsServiceTestSuite) TestDeleteBalance_BalanceTransferred() {
+	balance := t.getNewBankAccountBalance(
+		nuuid.From(t.testBankAccountBalanceID),
+		nuuid.From(t.testBankAccountID),
+		float64(123),
+		time.Now())
+
+	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
+		Return([]model.BankAccountBalance{balance}, nil)
+
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(true, nil)
+
+	res, err := t.svc.DeleteBalance(t.testBankAccountBalanceID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+	assert.Contains(t.T(), err.Error(), "Transfer")
+	assert.Nil(t.T(), res)
+}
+
 func (t *bankAccountsServiceTestSuite) TestDeleteBalance_RepoFailedResolvingByIDs() {
 	errMsg := "failed resolving bank accounts by IDs"
 	lastBalance := t.getNewBankAccountBalance(
@@ -1505,6 +2190,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_RepoFailedResolvingByID
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{lastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return([]model.BankAccount{}, errors.New(errMsg))
 
@@ -1525,6 +2213,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_AccountNotFound() {
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{lastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return([]model.BankAccount{}, nil)
 
@@ -1550,6 +2241,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_AccountDeleted() {
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{lastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return(
 			[]model.BankAccount{resolvedBankAccount},
@@ -1578,6 +2272,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_AccountInactive() {
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{lastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return(
 			[]model.BankAccount{resolvedBankAccount},
@@ -1603,6 +2300,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_RepoFailedResolvingLast
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{lastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return(
 			[]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)},
@@ -1630,6 +2330,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_LastBalanceNotFound() {
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{lastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return(
 			[]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)},
@@ -1658,6 +2361,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_CannotDeleteTheOnlyBala
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{lastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return(
 			[]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)},
@@ -1706,6 +2412,9 @@ func (t *bankAccountsServiceTestSuite) TestDeleteBalance_RepoFailedUpdatingBalan
 	t.mockRepo.EXPECT().ResolveBalancesByIDs([]uuid.UUID{t.testBankAccountBalanceID}).
 		Return([]model.BankAccountBalance{lastBalance}, nil)
 
+	t.mockTransferRepo.EXPECT().ExistsByBalanceID(t.testBankAccountBalanceID).
+		Return(false, nil)
+
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
 		Return(
 			[]model.BankAccount{t.getNewBankAccount(nuuid.From(t.testBankAccountID), nil)},
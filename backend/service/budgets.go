@@ -0,0 +1,233 @@
+package service
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// BudgetImpl is the service provider implementation
+type BudgetImpl struct {
+	Repository            repository.Budget      `inject:"budgetRepository"`
+	CategoryRepository    repository.Category    `inject:"categoryRepository"`
+	TransactionRepository repository.Transaction `inject:"transactionRepository"`
+}
+
+// Startup performs startup functions
+func (s *BudgetImpl) Startup() {
+	logger.Trace("Budget Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *BudgetImpl) Shutdown() {
+	logger.Trace("Budget Service shutting down...")
+}
+
+// Create creates a new Budget
+func (s *BudgetImpl) Create(input model.BudgetInput, userID uuid.UUID) (*model.Budget, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkCategory("create", input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkMonthAvailable("create", input)
+	if err != nil {
+		return nil, err
+	}
+
+	budget := model.NewBudgetFromInput(input, userID)
+	err = s.Repository.Create(budget)
+	if err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
+
+// GetByID fetches a Budget by its ID
+func (s *BudgetImpl) GetByID(id uuid.UUID) (*model.Budget, error) {
+	budgets, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(budgets) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Budget")
+	}
+
+	return &budgets[0], nil
+}
+
+// GetByFilter fetches a set of Budgets by its filter
+func (s *BudgetImpl) GetByFilter(input model.BudgetFilterInput) ([]model.Budget, model.PageInfoOutput, error) {
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// Update updates an existing Budget
+func (s *BudgetImpl) Update(input model.BudgetInput, userID uuid.UUID) (*model.Budget, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(budgets) != 1 {
+		return nil, failure.EntityNotFound("update", "Budget")
+	}
+
+	budget := budgets[0]
+
+	err = s.checkCategory("update", input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkMonthAvailable("update", input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = budget.Update(input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(budget)
+	if err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
+
+// Delete deletes an existing Budget
+func (s *BudgetImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Budget, error) {
+	budgets, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(budgets) != 1 {
+		return nil, failure.EntityNotFound("delete", "Budget")
+	}
+
+	budget := budgets[0]
+
+	err = budget.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(budget)
+	if err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
+
+// GetReport compares the spending of a month, given as YYYY-MM, against its Budgets
+func (s *BudgetImpl) GetReport(month string) (*model.BudgetReport, error) {
+	start, err := model.ParseBudgetMonth(month)
+	if err != nil {
+		return nil, err
+	}
+
+	end := start.AddDate(0, 1, 0).Add(-1)
+	page := 1
+	pageSize := math.MaxInt
+
+	budgetFilter := model.BudgetFilterInput{StartMonth: &month, EndMonth: &month}
+	budgetFilter.Page = &page
+	budgetFilter.PageSize = &pageSize
+
+	budgets, _, err := s.Repository.ResolveByFilter(budgetFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	categoryFilter := model.CategoryFilterInput{}
+	categoryFilter.Page = &page
+	categoryFilter.PageSize = &pageSize
+
+	categories, _, err := s.CategoryRepository.ResolveByFilter(categoryFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	transactionFilter := model.TransactionFilterInput{
+		StartDate: cachetime.NCacheTime(null.TimeFrom(start)),
+		EndDate:   cachetime.NCacheTime(null.TimeFrom(end)),
+	}
+	transactionFilter.Page = &page
+	transactionFilter.PageSize = &pageSize
+
+	transactions, _, err := s.TransactionRepository.ResolveByFilter(transactionFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	report := model.NewBudgetReport(start, budgets, categories, transactions)
+	return &report, nil
+}
+
+// checkCategory makes sure that the Category of a Budget exists and has not been deleted
+func (s *BudgetImpl) checkCategory(operation string, id uuid.UUID) error {
+	categories, err := s.CategoryRepository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return err
+	}
+
+	if len(categories) != 1 {
+		return failure.EntityNotFound(operation, "Category")
+	}
+
+	if categories[0].Deleted.Valid {
+		return failure.OperationNotPermitted(operation, "Category", "the Category is already deleted")
+	}
+
+	return nil
+}
+
+// checkMonthAvailable makes sure that a Category has no other Budget for the same month
+func (s *BudgetImpl) checkMonthAvailable(operation string, input model.BudgetInput) error {
+	page := 1
+	pageSize := math.MaxInt
+	categoryIDs := []uuid.UUID{input.CategoryID}
+
+	budgetFilter := model.BudgetFilterInput{
+		CategoryIDs: &categoryIDs,
+		StartMonth:  &input.Month,
+		EndMonth:    &input.Month,
+	}
+	budgetFilter.Page = &page
+	budgetFilter.PageSize = &pageSize
+
+	budgets, _, err := s.Repository.ResolveByFilter(budgetFilter.ToFilter())
+	if err != nil {
+		return err
+	}
+
+	for _, budget := range budgets {
+		if budget.ID != input.ID {
+			return failure.OperationNotPermitted(operation, "Budget", "the Category already has a budget for the month")
+		}
+	}
+
+	return nil
+}
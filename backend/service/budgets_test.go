@@ -0,0 +1,269 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type budgetsServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.Budget
+	mockRepo            *mock_repository.MockBudget
+	mockCategoryRepo    *mock_repository.MockCategory
+	mockTransactionRepo *mock_repository.MockTransaction
+	testUserID          uuid.UUID
+	testParentID        uuid.UUID
+	testCategoryID      uuid.UUID
+	testBudgetID        uuid.UUID
+	testMonth           time.Time
+}
+
+func TestBudgetsService(t *testing.T) {
+	suite.Run(t, new(budgetsServiceTestSuite))
+}
+
+func (t *budgetsServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockBudget(t.ctrl)
+	t.mockCategoryRepo = mock_repository.NewMockCategory(t.ctrl)
+	t.mockTransactionRepo = mock_repository.NewMockTransaction(t.ctrl)
+	t.svc = &service.BudgetImpl{
+		Repository:            t.mockRepo,
+		CategoryRepository:    t.mockCategoryRepo,
+		TransactionRepository: t.mockTransactionRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testParentID, _ = uuid.NewV7()
+	t.testCategoryID, _ = uuid.NewV7()
+	t.testBudgetID, _ = uuid.NewV7()
+	t.testMonth = time.Date(2024, time.March, 1, 0, 0, 0, 0, time.Local)
+	t.svc.Startup()
+}
+
+func (t *budgetsServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *budgetsServiceTestSuite) getCategories() []model.Category {
+	return []model.Category{
+		{ID: t.testParentID, Name: "Household", Created: time.Now(), CreatedBy: t.testUserID},
+		{ID: t.testCategoryID, ParentID: nuuid.From(t.testParentID), Name: "Groceries", Created: time.Now(), CreatedBy: t.testUserID},
+	}
+}
+
+func (t *budgetsServiceTestSuite) getBudget() model.Budget {
+	return model.Budget{
+		ID:         t.testBudgetID,
+		CategoryID: t.testCategoryID,
+		Month:      t.testMonth,
+		Amount:     float64(400),
+		Created:    time.Now(),
+		CreatedBy:  t.testUserID,
+	}
+}
+
+func (t *budgetsServiceTestSuite) getBudgetInput() model.BudgetInput {
+	return model.BudgetInput{
+		ID:         t.testBudgetID,
+		CategoryID: t.testCategoryID,
+		Month:      "2024-03",
+		Amount:     float64(400),
+	}
+}
+
+func (t *budgetsServiceTestSuite) getTransaction(direction model.TransactionDirection, amount float64, categoryID nuuid.NUUID, category string) model.Transaction {
+	id, _ := uuid.NewV7()
+	return model.Transaction{
+		ID:         id,
+		Date:       t.testMonth.AddDate(0, 0, 10),
+		Direction:  direction,
+		Amount:     amount,
+		Category:   category,
+		CategoryID: categoryID,
+		Created:    time.Now(),
+		CreatedBy:  t.testUserID,
+	}
+}
+
+func (t *budgetsServiceTestSuite) TestCreate_Normal() {
+	input := t.getBudgetInput()
+	input.ID = uuid.Nil
+
+	t.mockCategoryRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return(t.getCategories()[1:], nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Budget{}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	budget, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), budget)
+	assert.Equal(t.T(), t.testMonth, budget.Month)
+	assert.Equal(t.T(), "2024-03", budget.ToOutput().Month)
+}
+
+func (t *budgetsServiceTestSuite) TestCreate_InvalidMonth() {
+	input := t.getBudgetInput()
+	input.Month = "2024-13"
+
+	budget, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), budget)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *budgetsServiceTestSuite) TestCreate_InvalidAmount() {
+	input := t.getBudgetInput()
+	input.Amount = 0
+
+	budget, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), budget)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *budgetsServiceTestSuite) TestCreate_CategoryNotFound() {
+	t.mockCategoryRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{}, nil)
+
+	budget, err := t.svc.Create(t.getBudgetInput(), t.testUserID)
+
+	assert.Nil(t.T(), budget)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *budgetsServiceTestSuite) TestCreate_AlreadyBudgeted() {
+	input := t.getBudgetInput()
+	input.ID = uuid.Nil
+
+	t.mockCategoryRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return(t.getCategories()[1:], nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Budget{t.getBudget()}, model.PageInfoOutput{}, nil)
+
+	budget, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), budget)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *budgetsServiceTestSuite) TestUpdate_Normal() {
+	input := t.getBudgetInput()
+	input.Amount = float64(450)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBudgetID}).Return([]model.Budget{t.getBudget()}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return(t.getCategories()[1:], nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Budget{t.getBudget()}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	budget, err := t.svc.Update(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), budget)
+	assert.Equal(t.T(), float64(450), budget.Amount)
+}
+
+func (t *budgetsServiceTestSuite) TestUpdate_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBudgetID}).Return([]model.Budget{}, nil)
+
+	budget, err := t.svc.Update(t.getBudgetInput(), t.testUserID)
+
+	assert.Nil(t.T(), budget)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *budgetsServiceTestSuite) TestDelete_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBudgetID}).Return([]model.Budget{t.getBudget()}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	budget, err := t.svc.Delete(t.testBudgetID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), budget)
+	assert.True(t.T(), budget.Deleted.Valid)
+}
+
+func (t *budgetsServiceTestSuite) TestGetReport_Normal() {
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Budget{t.getBudget()}, model.PageInfoOutput{}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveByFilter(gomock.Any()).Return(t.getCategories(), model.PageInfoOutput{}, nil)
+	t.mockTransactionRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Transaction{
+		t.getTransaction(model.TransactionDirectionDebit, 120, nuuid.From(t.testCategoryID), "Groceries"),
+		t.getTransaction(model.TransactionDirectionDebit, 80.5, nuuid.From(t.testCategoryID), "Groceries"),
+		t.getTransaction(model.TransactionDirectionCredit, 20, nuuid.From(t.testCategoryID), "Groceries"),
+		t.getTransaction(model.TransactionDirectionDebit, 60, nuuid.From(t.testParentID), "Household"),
+		t.getTransaction(model.TransactionDirectionDebit, 15, nuuid.NUUID{}, ""),
+		t.getTransaction(model.TransactionDirectionCredit, 3000, nuuid.NUUID{}, ""),
+		t.getTransaction(model.TransactionDirectionDebit, 500, nuuid.NUUID{}, model.TransferCategory),
+	}, model.PageInfoOutput{}, nil)
+
+	report, err := t.svc.GetReport("2024-03")
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), report)
+
+	output := report.ToOutput()
+	assert.Equal(t.T(), "2024-03", output.Month)
+	assert.Len(t.T(), output.Items, 1)
+	assert.Equal(t.T(), "Groceries", output.Items[0].CategoryName)
+	assert.Equal(t.T(), float64(400), output.Items[0].Planned)
+	assert.Equal(t.T(), float64(180.5), output.Items[0].Actual)
+	assert.Equal(t.T(), float64(219.5), output.Items[0].Remaining)
+	assert.Equal(t.T(), 3, output.Items[0].Transactions)
+	assert.Equal(t.T(), float64(75), output.Unbudgeted)
+}
+
+func (t *budgetsServiceTestSuite) TestGetReport_ChildCountsTowardsParent() {
+	childID, _ := uuid.NewV7()
+	categories := append(t.getCategories(), model.Category{
+		ID:        childID,
+		ParentID:  nuuid.From(t.testCategoryID),
+		Name:      "Snacks",
+		Created:   time.Now(),
+		CreatedBy: t.testUserID,
+	})
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Budget{t.getBudget()}, model.PageInfoOutput{}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveByFilter(gomock.Any()).Return(categories, model.PageInfoOutput{}, nil)
+	t.mockTransactionRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Transaction{
+		t.getTransaction(model.TransactionDirectionDebit, 25, nuuid.From(childID), "Snacks"),
+	}, model.PageInfoOutput{}, nil)
+
+	report, err := t.svc.GetReport("2024-03")
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), float64(25), report.Items[0].Actual)
+	assert.Equal(t.T(), float64(0), report.Unbudgeted)
+}
+
+func (t *budgetsServiceTestSuite) TestGetReport_MatchedByCategoryID() {
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Budget{t.getBudget()}, model.PageInfoOutput{}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveByFilter(gomock.Any()).Return(t.getCategories(), model.PageInfoOutput{}, nil)
+	t.mockTransactionRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Transaction{
+		// filed before the Category was renamed from Food, but still linked to it
+		t.getTransaction(model.TransactionDirectionDebit, 30, nuuid.From(t.testCategoryID), "Food"),
+		// going by the name of the Category without being linked to it
+		t.getTransaction(model.TransactionDirectionDebit, 45, nuuid.NUUID{}, "Groceries"),
+	}, model.PageInfoOutput{}, nil)
+
+	report, err := t.svc.GetReport("2024-03")
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), float64(30), report.Items[0].Actual)
+	assert.Equal(t.T(), 1, report.Items[0].Transactions)
+	assert.Equal(t.T(), float64(45), report.Unbudgeted)
+}
+
+func (t *budgetsServiceTestSuite) TestGetReport_InvalidMonth() {
+	report, err := t.svc.GetReport("March")
+
+	assert.Nil(t.T(), report)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
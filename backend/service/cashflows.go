@@ -0,0 +1,192 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+)
+
+// CreateCashFlow records a deposit into or a withdrawal from a Bank Account
+func (s *BankAccountImpl) CreateCashFlow(input model.BankAccountCashFlowInput, userID uuid.UUID) (*model.BankAccountCashFlow, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	bankAccount, err := s.resolveCashFlowBankAccount("create cash flow", input.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	bankAccountCashFlow := model.NewBankAccountCashFlowFromInput(input, bankAccount.ID, userID)
+	err = s.Repository.CreateCashFlow(bankAccountCashFlow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bankAccountCashFlow, nil
+}
+
+// GetCashFlowByID fetches a Bank Account Cash Flow by its ID
+func (s *BankAccountImpl) GetCashFlowByID(id uuid.UUID) (*model.BankAccountCashFlow, error) {
+	bankAccountCashFlows, err := s.Repository.ResolveCashFlowsByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccountCashFlows) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Bank Account Cash Flow")
+	}
+
+	return &bankAccountCashFlows[0], nil
+}
+
+// GetCashFlowsByFilter fetches a set of Bank Account Cash Flows by its filter
+func (s *BankAccountImpl) GetCashFlowsByFilter(input model.BankAccountCashFlowFilterInput) ([]model.BankAccountCashFlow, model.PageInfoOutput, error) {
+	return s.Repository.ResolveCashFlowsByFilter(input.ToFilter())
+}
+
+// UpdateCashFlow updates an existing Bank Account Cash Flow
+func (s *BankAccountImpl) UpdateCashFlow(input model.BankAccountCashFlowInput, userID uuid.UUID) (*model.BankAccountCashFlow, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	bankAccountCashFlows, err := s.Repository.ResolveCashFlowsByIDs([]uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccountCashFlows) != 1 {
+		return nil, failure.EntityNotFound("update", "Bank Account Cash Flow")
+	}
+
+	bankAccountCashFlow := bankAccountCashFlows[0]
+
+	_, err = s.resolveCashFlowBankAccount("update cash flow", bankAccountCashFlow.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bankAccountCashFlow.Update(input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.UpdateCashFlow(bankAccountCashFlow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bankAccountCashFlow, nil
+}
+
+// DeleteCashFlow deletes an existing Bank Account Cash Flow
+func (s *BankAccountImpl) DeleteCashFlow(id uuid.UUID, userID uuid.UUID) (*model.BankAccountCashFlow, error) {
+	bankAccountCashFlows, err := s.Repository.ResolveCashFlowsByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccountCashFlows) != 1 {
+		return nil, failure.EntityNotFound("delete", "Bank Account Cash Flow")
+	}
+
+	bankAccountCashFlow := bankAccountCashFlows[0]
+
+	_, err = s.resolveCashFlowBankAccount("delete cash flow", bankAccountCashFlow.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bankAccountCashFlow.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.UpdateCashFlow(bankAccountCashFlow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bankAccountCashFlow, nil
+}
+
+// GetReturns calculates the time-weighted and money-weighted returns of a Bank Account over a period from
+// its balances, treating its recorded cash flows as money put in or taken out rather than growth
+func (s *BankAccountImpl) GetReturns(id uuid.UUID, start, end time.Time) (*model.BankAccountReturns, error) {
+	if !start.Before(end) {
+		return nil, failure.BadRequestFromString("the start date must be before the end date")
+	}
+
+	bankAccounts, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccounts) != 1 {
+		return nil, failure.EntityNotFound("get returns", "Bank Account")
+	}
+
+	page := 1
+	pageSize := math.MaxInt
+	ids := []uuid.UUID{id}
+
+	balanceFilter := model.BankAccountBalanceFilterInput{
+		BankAccountIDs: &ids,
+		EndDate:        cachetime.NCacheTime(null.TimeFrom(end)),
+	}
+	balanceFilter.Page = &page
+	balanceFilter.PageSize = &pageSize
+
+	balances, _, err := s.Repository.ResolveBalancesByFilter(balanceFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	cashFlowFilter := model.BankAccountCashFlowFilterInput{
+		BankAccountIDs: &ids,
+		EndDate:        cachetime.NCacheTime(null.TimeFrom(end)),
+	}
+	cashFlowFilter.Page = &page
+	cashFlowFilter.PageSize = &pageSize
+
+	cashFlows, _, err := s.Repository.ResolveCashFlowsByFilter(cashFlowFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	returns := model.NewBankAccountReturns(id, start, end, balances, cashFlows)
+	return &returns, nil
+}
+
+// resolveCashFlowBankAccount resolves the Bank Account a Cash Flow belongs to, which must be neither deleted nor inactive
+// for its cash flows to be changed
+func (s *BankAccountImpl) resolveCashFlowBankAccount(operation string, id uuid.UUID) (*model.BankAccount, error) {
+	bankAccounts, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccounts) != 1 {
+		return nil, failure.EntityNotFound(operation, "Bank Account")
+	}
+
+	bankAccount := bankAccounts[0]
+
+	if bankAccount.Deleted.Valid {
+		return nil, failure.OperationNotPermitted(operation, "Bank Account", "the Bank Account is already deleted")
+	}
+
+	if bankAccount.Status == model.BankAccountStatusInactive {
+		return nil, failure.OperationNotPermitted(operation, "Bank Account", "the Bank Account is inactive")
+	}
+
+	return &bankAccount, nil
+}
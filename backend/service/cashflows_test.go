@@ -0,0 +1,297 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type cashFlowsServiceTestSuite struct {
+	suite.Suite
+	ctrl                      *gomock.Controller
+	svc                       service.BankAccount
+	mockRepo                  *mock_repository.MockBankAccount
+	testUserID                uuid.UUID
+	testBankAccountID         uuid.UUID
+	testBankAccountCashFlowID uuid.UUID
+}
+
+func TestCashFlowsService(t *testing.T) {
+	suite.Run(t, new(cashFlowsServiceTestSuite))
+}
+
+func (t *cashFlowsServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.svc = &service.BankAccountImpl{
+		Repository: t.mockRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.testBankAccountCashFlowID, _ = uuid.NewV7()
+	t.svc.Startup()
+}
+
+func (t *cashFlowsServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *cashFlowsServiceTestSuite) getBankAccount(status model.BankAccountStatus) model.BankAccount {
+	return model.BankAccount{
+		ID:          t.testBankAccountID,
+		AccountName: "Brokerage Account",
+		LastBalance: float64(2310),
+		Status:      status,
+		Created:     time.Now(),
+		CreatedBy:   t.testUserID,
+	}
+}
+
+func (t *cashFlowsServiceTestSuite) getCashFlowInput() model.BankAccountCashFlowInput {
+	return model.BankAccountCashFlowInput{
+		ID:            t.testBankAccountCashFlowID,
+		BankAccountID: t.testBankAccountID,
+		Date:          cachetime.CacheTime(time.Now()),
+		Type:          model.BankAccountCashFlowTypeDeposit,
+		Amount:        float64(1000),
+		Note:          " monthly contribution ",
+	}
+}
+
+func (t *cashFlowsServiceTestSuite) getCashFlow() model.BankAccountCashFlow {
+	return model.BankAccountCashFlow{
+		ID:            t.testBankAccountCashFlowID,
+		BankAccountID: t.testBankAccountID,
+		Date:          time.Now(),
+		Type:          model.BankAccountCashFlowTypeDeposit,
+		Amount:        float64(1000),
+		Created:       time.Now(),
+		CreatedBy:     t.testUserID,
+	}
+}
+
+func (t *cashFlowsServiceTestSuite) TestCreateCashFlow_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockRepo.EXPECT().CreateCashFlow(gomock.Any()).Return(nil)
+
+	cashFlow, err := t.svc.CreateCashFlow(t.getCashFlowInput(), t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), cashFlow)
+	assert.Equal(t.T(), t.testBankAccountID, cashFlow.BankAccountID)
+	assert.Equal(t.T(), "monthly contribution", cashFlow.Note)
+	assert.Equal(t.T(), float64(1000), cashFlow.SignedAmount())
+}
+
+func (t *cashFlowsServiceTestSuite) TestCreateCashFlow_InvalidAmount() {
+	input := t.getCashFlowInput()
+	input.Amount = -1000
+
+	cashFlow, err := t.svc.CreateCashFlow(input, t.testUserID)
+
+	assert.Nil(t.T(), cashFlow)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *cashFlowsServiceTestSuite) TestCreateCashFlow_InvalidType() {
+	input := t.getCashFlowInput()
+	input.Type = "dividend"
+
+	cashFlow, err := t.svc.CreateCashFlow(input, t.testUserID)
+
+	assert.Nil(t.T(), cashFlow)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *cashFlowsServiceTestSuite) TestCreateCashFlow_BankAccountNotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{}, nil)
+
+	cashFlow, err := t.svc.CreateCashFlow(t.getCashFlowInput(), t.testUserID)
+
+	assert.Nil(t.T(), cashFlow)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *cashFlowsServiceTestSuite) TestCreateCashFlow_BankAccountInactive() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusInactive)}, nil)
+
+	cashFlow, err := t.svc.CreateCashFlow(t.getCashFlowInput(), t.testUserID)
+
+	assert.Nil(t.T(), cashFlow)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *cashFlowsServiceTestSuite) TestCreateCashFlow_ErrorCreating() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockRepo.EXPECT().CreateCashFlow(gomock.Any()).Return(errors.New("failed creating cash flow"))
+
+	cashFlow, err := t.svc.CreateCashFlow(t.getCashFlowInput(), t.testUserID)
+
+	assert.Nil(t.T(), cashFlow)
+	assert.NotNil(t.T(), err)
+}
+
+func (t *cashFlowsServiceTestSuite) TestGetCashFlowByID_NotFound() {
+	t.mockRepo.EXPECT().ResolveCashFlowsByIDs([]uuid.UUID{t.testBankAccountCashFlowID}).Return([]model.BankAccountCashFlow{}, nil)
+
+	cashFlow, err := t.svc.GetCashFlowByID(t.testBankAccountCashFlowID)
+
+	assert.Nil(t.T(), cashFlow)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *cashFlowsServiceTestSuite) TestUpdateCashFlow_Normal() {
+	input := t.getCashFlowInput()
+	input.Type = model.BankAccountCashFlowTypeWithdrawal
+
+	t.mockRepo.EXPECT().ResolveCashFlowsByIDs([]uuid.UUID{t.testBankAccountCashFlowID}).
+		Return([]model.BankAccountCashFlow{t.getCashFlow()}, nil)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockRepo.EXPECT().UpdateCashFlow(gomock.Any()).Return(nil)
+
+	cashFlow, err := t.svc.UpdateCashFlow(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), model.BankAccountCashFlowTypeWithdrawal, cashFlow.Type)
+	assert.Equal(t.T(), float64(-1000), cashFlow.SignedAmount())
+	assert.True(t.T(), cashFlow.Updated.Valid)
+}
+
+func (t *cashFlowsServiceTestSuite) TestUpdateCashFlow_AlreadyDeleted() {
+	deleted := t.getCashFlow()
+	deleted.Deleted = null.TimeFrom(time.Now())
+	deleted.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveCashFlowsByIDs([]uuid.UUID{t.testBankAccountCashFlowID}).
+		Return([]model.BankAccountCashFlow{deleted}, nil)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+
+	cashFlow, err := t.svc.UpdateCashFlow(t.getCashFlowInput(), t.testUserID)
+
+	assert.Nil(t.T(), cashFlow)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *cashFlowsServiceTestSuite) TestDeleteCashFlow_Normal() {
+	t.mockRepo.EXPECT().ResolveCashFlowsByIDs([]uuid.UUID{t.testBankAccountCashFlowID}).
+		Return([]model.BankAccountCashFlow{t.getCashFlow()}, nil)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockRepo.EXPECT().UpdateCashFlow(gomock.Any()).Return(nil)
+
+	cashFlow, err := t.svc.DeleteCashFlow(t.testBankAccountCashFlowID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.True(t.T(), cashFlow.Deleted.Valid)
+	assert.Equal(t.T(), nuuid.From(t.testUserID), cashFlow.DeletedBy)
+}
+
+func (t *cashFlowsServiceTestSuite) TestDeleteCashFlow_BankAccountDeleted() {
+	bankAccount := t.getBankAccount(model.BankAccountStatusActive)
+	bankAccount.Deleted = null.TimeFrom(time.Now())
+
+	t.mockRepo.EXPECT().ResolveCashFlowsByIDs([]uuid.UUID{t.testBankAccountCashFlowID}).
+		Return([]model.BankAccountCashFlow{t.getCashFlow()}, nil)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{bankAccount}, nil)
+
+	cashFlow, err := t.svc.DeleteCashFlow(t.testBankAccountCashFlowID, t.testUserID)
+
+	assert.Nil(t.T(), cashFlow)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *cashFlowsServiceTestSuite) TestGetReturns_Normal() {
+	jan1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	jul2 := time.Date(2023, 7, 2, 0, 0, 0, 0, time.UTC)
+	dec31 := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	// the account grows by 10% in each half of the year, with a deposit in between
+	balances := []model.BankAccountBalance{
+		{BankAccountID: t.testBankAccountID, Date: jan1.AddDate(0, -1, 0), Balance: 900},
+		{BankAccountID: t.testBankAccountID, Date: jan1, Balance: 1000},
+		{BankAccountID: t.testBankAccountID, Date: jul2, Balance: 2100},
+		{BankAccountID: t.testBankAccountID, Date: dec31, Balance: 2310},
+	}
+	cashFlows := []model.BankAccountCashFlow{
+		{BankAccountID: t.testBankAccountID, Date: jul2, Type: model.BankAccountCashFlowTypeDeposit, Amount: 1000},
+		{BankAccountID: t.testBankAccountID, Date: jul2, Type: model.BankAccountCashFlowTypeWithdrawal, Amount: 500, Deleted: null.TimeFrom(jul2)},
+	}
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return(balances, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveCashFlowsByFilter(gomock.Any()).Return(cashFlows, model.PageInfoOutput{}, nil)
+
+	returns, err := t.svc.GetReturns(t.testBankAccountID, jan1.Add(time.Hour), dec31.Add(time.Hour))
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), jan1.Add(time.Hour), returns.StartDate)
+	assert.Equal(t.T(), dec31, returns.EndDate)
+	assert.Equal(t.T(), null.FloatFrom(1000), returns.StartBalance)
+	assert.Equal(t.T(), null.FloatFrom(2310), returns.EndBalance)
+	assert.Equal(t.T(), float64(1000), returns.Deposits)
+	assert.Equal(t.T(), float64(0), returns.Withdrawals)
+	assert.InDelta(t.T(), 310, returns.Growth.Float64, 1e-9)
+	assert.InDelta(t.T(), 21, returns.TimeWeightedReturn.Float64, 1e-6)
+	assert.InDelta(t.T(), 21.0634, returns.MoneyWeightedReturn.Float64, 1e-3)
+	assert.Len(t.T(), returns.CashFlows, 1)
+}
+
+func (t *cashFlowsServiceTestSuite) TestGetReturns_StartsAtFirstBalance() {
+	jan1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	balances := []model.BankAccountBalance{
+		{BankAccountID: t.testBankAccountID, Date: jan1.AddDate(0, 6, 0), Balance: 1000},
+	}
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return(balances, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveCashFlowsByFilter(gomock.Any()).Return([]model.BankAccountCashFlow{}, model.PageInfoOutput{}, nil)
+
+	returns, err := t.svc.GetReturns(t.testBankAccountID, jan1, jan1.AddDate(1, 0, 0))
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), jan1.AddDate(0, 6, 0), returns.StartDate)
+	assert.Equal(t.T(), null.FloatFrom(1000), returns.StartBalance)
+	assert.False(t.T(), returns.TimeWeightedReturn.Valid)
+	assert.False(t.T(), returns.MoneyWeightedReturn.Valid)
+}
+
+func (t *cashFlowsServiceTestSuite) TestGetReturns_InvalidPeriod() {
+	now := time.Now()
+
+	returns, err := t.svc.GetReturns(t.testBankAccountID, now, now.AddDate(0, 0, -1))
+
+	assert.Nil(t.T(), returns)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *cashFlowsServiceTestSuite) TestGetReturns_BankAccountNotFound() {
+	now := time.Now()
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{}, nil)
+
+	returns, err := t.svc.GetReturns(t.testBankAccountID, now.AddDate(-1, 0, 0), now)
+
+	assert.Nil(t.T(), returns)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
@@ -0,0 +1,453 @@
+package service
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// CategoryImpl is the service provider implementation
+type CategoryImpl struct {
+	Repository            repository.Category    `inject:"categoryRepository"`
+	BudgetRepository      repository.Budget      `inject:"budgetRepository"`
+	TransactionRepository repository.Transaction `inject:"transactionRepository"`
+}
+
+// Startup performs startup functions
+func (s *CategoryImpl) Startup() {
+	logger.Trace("Category Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *CategoryImpl) Shutdown() {
+	logger.Trace("Category Service shutting down...")
+}
+
+// Create creates a new Category
+func (s *CategoryImpl) Create(input model.CategoryInput, userID uuid.UUID) (*model.Category, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.resolveCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkParent("create", categories, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkNameAvailable("create", categories, input)
+	if err != nil {
+		return nil, err
+	}
+
+	category := model.NewCategoryFromInput(input, userID)
+	err = s.Repository.Create(category)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// GetByID fetches a Category by its ID
+func (s *CategoryImpl) GetByID(id uuid.UUID) (*model.Category, error) {
+	categories, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categories) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Category")
+	}
+
+	return &categories[0], nil
+}
+
+// GetByFilter fetches a set of Categories by its filter
+func (s *CategoryImpl) GetByFilter(input model.CategoryFilterInput) ([]model.Category, model.PageInfoOutput, error) {
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// GetTree fetches all Categories that have not been deleted, arranged into a tree
+func (s *CategoryImpl) GetTree() ([]model.CategoryTreeOutput, error) {
+	categories, err := s.resolveCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	return model.NewCategoryTree(categories), nil
+}
+
+// Update updates an existing Category. Renaming a Category renames it on the Transactions linked to it as well,
+// which stay linked to it by its ID.
+func (s *CategoryImpl) Update(input model.CategoryInput, userID uuid.UUID) (*model.Category, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categories) != 1 {
+		return nil, failure.EntityNotFound("update", "Category")
+	}
+
+	category := categories[0]
+
+	all, err := s.resolveCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkParent("update", all, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ParentID.Valid && model.IsCategoryAncestor(all, category.ID, input.ParentID.UUID) {
+		return nil, failure.BadRequestFromString("a category cannot be moved below one of its own children")
+	}
+
+	err = s.checkNameAvailable("update", all, input)
+	if err != nil {
+		return nil, err
+	}
+
+	oldName := category.Name
+	err = category.Update(input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if category.Name != oldName {
+		ids := []uuid.UUID{category.ID}
+		transactions, err := s.resolveTransactions(model.TransactionFilterInput{CategoryIDs: &ids})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range transactions {
+			err = transactions[i].Categorize(category, userID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		category.Transactions = transactions
+	}
+
+	err = s.Repository.Update(category)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// Delete deletes an existing Category, which must no longer have any Categories, Category Rules or Budgets below it
+func (s *CategoryImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Category, error) {
+	categories, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categories) != 1 {
+		return nil, failure.EntityNotFound("delete", "Category")
+	}
+
+	category := categories[0]
+	page := 1
+	pageSize := math.MaxInt
+	ids := []uuid.UUID{id}
+
+	childFilter := model.CategoryFilterInput{ParentIDs: &ids}
+	childFilter.Page = &page
+	childFilter.PageSize = &pageSize
+
+	children, _, err := s.Repository.ResolveByFilter(childFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(children) > 0 {
+		return nil, failure.OperationNotPermitted("delete", "Category", "the Category still has categories below it")
+	}
+
+	ruleFilter := model.CategoryRuleFilterInput{CategoryIDs: &ids}
+	ruleFilter.Page = &page
+	ruleFilter.PageSize = &pageSize
+
+	rules, _, err := s.Repository.ResolveRulesByFilter(ruleFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) > 0 {
+		return nil, failure.OperationNotPermitted("delete", "Category", "the Category still has rules")
+	}
+
+	budgetFilter := model.BudgetFilterInput{CategoryIDs: &ids}
+	budgetFilter.Page = &page
+	budgetFilter.PageSize = &pageSize
+
+	budgets, _, err := s.BudgetRepository.ResolveByFilter(budgetFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(budgets) > 0 {
+		return nil, failure.OperationNotPermitted("delete", "Category", "the Category still has budgets")
+	}
+
+	err = category.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(category)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// CreateRule creates a new Category Rule
+func (s *CategoryImpl) CreateRule(input model.CategoryRuleInput, userID uuid.UUID) (*model.CategoryRule, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.resolveCategory("create rule", input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryRule := model.NewCategoryRuleFromInput(input, userID)
+	err = s.Repository.CreateRule(categoryRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &categoryRule, nil
+}
+
+// GetRuleByID fetches a Category Rule by its ID
+func (s *CategoryImpl) GetRuleByID(id uuid.UUID) (*model.CategoryRule, error) {
+	categoryRules, err := s.Repository.ResolveRulesByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categoryRules) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Category Rule")
+	}
+
+	return &categoryRules[0], nil
+}
+
+// GetRulesByFilter fetches a set of Category Rules by its filter
+func (s *CategoryImpl) GetRulesByFilter(input model.CategoryRuleFilterInput) ([]model.CategoryRule, model.PageInfoOutput, error) {
+	return s.Repository.ResolveRulesByFilter(input.ToFilter())
+}
+
+// UpdateRule updates an existing Category Rule
+func (s *CategoryImpl) UpdateRule(input model.CategoryRuleInput, userID uuid.UUID) (*model.CategoryRule, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	categoryRules, err := s.Repository.ResolveRulesByIDs([]uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categoryRules) != 1 {
+		return nil, failure.EntityNotFound("update rule", "Category Rule")
+	}
+
+	categoryRule := categoryRules[0]
+
+	_, err = s.resolveCategory("update rule", input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = categoryRule.Update(input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.UpdateRule(categoryRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &categoryRule, nil
+}
+
+// DeleteRule deletes an existing Category Rule
+func (s *CategoryImpl) DeleteRule(id uuid.UUID, userID uuid.UUID) (*model.CategoryRule, error) {
+	categoryRules, err := s.Repository.ResolveRulesByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categoryRules) != 1 {
+		return nil, failure.EntityNotFound("delete rule", "Category Rule")
+	}
+
+	categoryRule := categoryRules[0]
+
+	err = categoryRule.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.UpdateRule(categoryRule)
+	if err != nil {
+		return nil, err
+	}
+
+	return &categoryRule, nil
+}
+
+// ApplyRules files the Transactions that have not been categorized yet under the Category of the first
+// Category Rule that matches their payee, returning the Transactions that were categorized
+func (s *CategoryImpl) ApplyRules(userID uuid.UUID) ([]model.Transaction, error) {
+	categories, err := s.resolveCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.resolveRules()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{""}
+	transactions, err := s.resolveTransactions(model.TransactionFilterInput{Categories: &names})
+	if err != nil {
+		return nil, err
+	}
+
+	categorized := make([]model.Transaction, 0)
+	for _, transaction := range transactions {
+		category := model.MatchCategory(rules, categories, transaction.Payee)
+		if category == nil {
+			continue
+		}
+
+		err = transaction.Categorize(*category, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		categorized = append(categorized, transaction)
+	}
+
+	err = s.Repository.Categorize(categorized)
+	if err != nil {
+		return nil, err
+	}
+
+	return categorized, nil
+}
+
+// checkParent makes sure that the parent of a Category, if it has one, exists and has not been deleted
+func (s *CategoryImpl) checkParent(operation string, categories []model.Category, input model.CategoryInput) error {
+	if !input.ParentID.Valid {
+		return nil
+	}
+
+	for _, category := range categories {
+		if category.ID == input.ParentID.UUID {
+			return nil
+		}
+	}
+
+	return failure.EntityNotFound(operation, "Parent Category")
+}
+
+// checkNameAvailable makes sure that no other Category that has not been deleted goes by the same name,
+// as Transactions can be filed under a Category by its name
+func (s *CategoryImpl) checkNameAvailable(operation string, categories []model.Category, input model.CategoryInput) error {
+	existing := model.FindCategoryByName(categories, input.Name)
+	if existing != nil && existing.ID != input.ID {
+		return failure.OperationNotPermitted(operation, "Category", "a category with the same name already exists")
+	}
+
+	return nil
+}
+
+// resolveCategory resolves a Category that Category Rules are being attached to, which must not be deleted
+func (s *CategoryImpl) resolveCategory(operation string, id uuid.UUID) (*model.Category, error) {
+	categories, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categories) != 1 {
+		return nil, failure.EntityNotFound(operation, "Category")
+	}
+
+	category := categories[0]
+
+	if category.Deleted.Valid {
+		return nil, failure.OperationNotPermitted(operation, "Category", "the Category is already deleted")
+	}
+
+	return &category, nil
+}
+
+// resolveCategories resolves all Categories that have not been deleted
+func (s *CategoryImpl) resolveCategories() ([]model.Category, error) {
+	page := 1
+	pageSize := math.MaxInt
+
+	categoryFilter := model.CategoryFilterInput{}
+	categoryFilter.Page = &page
+	categoryFilter.PageSize = &pageSize
+
+	categories, _, err := s.Repository.ResolveByFilter(categoryFilter.ToFilter())
+	return categories, err
+}
+
+// resolveRules resolves all Category Rules that have not been deleted
+func (s *CategoryImpl) resolveRules() ([]model.CategoryRule, error) {
+	page := 1
+	pageSize := math.MaxInt
+
+	ruleFilter := model.CategoryRuleFilterInput{}
+	ruleFilter.Page = &page
+	ruleFilter.PageSize = &pageSize
+
+	rules, _, err := s.Repository.ResolveRulesByFilter(ruleFilter.ToFilter())
+	return rules, err
+}
+
+// resolveTransactions resolves all Transactions matching a filter that have not been deleted
+func (s *CategoryImpl) resolveTransactions(transactionFilter model.TransactionFilterInput) ([]model.Transaction, error) {
+	page := 1
+	pageSize := math.MaxInt
+
+	transactionFilter.Page = &page
+	transactionFilter.PageSize = &pageSize
+
+	transactions, _, err := s.TransactionRepository.ResolveByFilter(transactionFilter.ToFilter())
+	return transactions, err
+}
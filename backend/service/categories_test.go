@@ -0,0 +1,409 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type categoriesServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.Category
+	mockRepo            *mock_repository.MockCategory
+	mockBudgetRepo      *mock_repository.MockBudget
+	mockTransactionRepo *mock_repository.MockTransaction
+	testUserID          uuid.UUID
+	testParentID        uuid.UUID
+	testCategoryID      uuid.UUID
+	testRuleID          uuid.UUID
+}
+
+func TestCategoriesService(t *testing.T) {
+	suite.Run(t, new(categoriesServiceTestSuite))
+}
+
+func (t *categoriesServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockCategory(t.ctrl)
+	t.mockBudgetRepo = mock_repository.NewMockBudget(t.ctrl)
+	t.mockTransactionRepo = mock_repository.NewMockTransaction(t.ctrl)
+	t.svc = &service.CategoryImpl{
+		Repository:            t.mockRepo,
+		BudgetRepository:      t.mockBudgetRepo,
+		TransactionRepository: t.mockTransactionRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testParentID, _ = uuid.NewV7()
+	t.testCategoryID, _ = uuid.NewV7()
+	t.testRuleID, _ = uuid.NewV7()
+	t.svc.Startup()
+}
+
+func (t *categoriesServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *categoriesServiceTestSuite) getParent() model.Category {
+	return model.Category{
+		ID:        t.testParentID,
+		Name:      "Household",
+		Created:   time.Now(),
+		CreatedBy: t.testUserID,
+	}
+}
+
+func (t *categoriesServiceTestSuite) getCategory() model.Category {
+	return model.Category{
+		ID:        t.testCategoryID,
+		ParentID:  nuuid.From(t.testParentID),
+		Name:      "Groceries",
+		Created:   time.Now(),
+		CreatedBy: t.testUserID,
+	}
+}
+
+func (t *categoriesServiceTestSuite) getCategoryInput() model.CategoryInput {
+	return model.CategoryInput{
+		ID:       t.testCategoryID,
+		ParentID: nuuid.From(t.testParentID),
+		Name:     " Groceries ",
+	}
+}
+
+func (t *categoriesServiceTestSuite) getRule() model.CategoryRule {
+	return model.CategoryRule{
+		ID:         t.testRuleID,
+		CategoryID: t.testCategoryID,
+		Pattern:    "*grocer*",
+		Created:    time.Now(),
+		CreatedBy:  t.testUserID,
+	}
+}
+
+func (t *categoriesServiceTestSuite) getRuleInput() model.CategoryRuleInput {
+	return model.CategoryRuleInput{
+		ID:         t.testRuleID,
+		CategoryID: t.testCategoryID,
+		Pattern:    "*grocer*",
+		Priority:   1,
+	}
+}
+
+func (t *categoriesServiceTestSuite) getTransaction(payee string, category string) model.Transaction {
+	id, _ := uuid.NewV7()
+	return model.Transaction{
+		ID:        id,
+		Date:      time.Now(),
+		Direction: model.TransactionDirectionDebit,
+		Amount:    float64(50),
+		Payee:     payee,
+		Category:  category,
+		Created:   time.Now(),
+		CreatedBy: t.testUserID,
+	}
+}
+
+func (t *categoriesServiceTestSuite) TestCreate_Normal() {
+	input := t.getCategoryInput()
+	input.ID = uuid.Nil
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{t.getParent()}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	category, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), category)
+	assert.Equal(t.T(), "Groceries", category.Name)
+	assert.Equal(t.T(), t.testParentID, category.ParentID.UUID)
+}
+
+func (t *categoriesServiceTestSuite) TestCreate_MissingName() {
+	input := t.getCategoryInput()
+	input.Name = " "
+
+	category, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), category)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestCreate_ReservedName() {
+	input := t.getCategoryInput()
+	input.Name = "Transfer"
+
+	category, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), category)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestCreate_ParentNotFound() {
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{}, model.PageInfoOutput{}, nil)
+
+	category, err := t.svc.Create(t.getCategoryInput(), t.testUserID)
+
+	assert.Nil(t.T(), category)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestCreate_DuplicateName() {
+	existing := t.getCategory()
+	existing.ID, _ = uuid.NewV7()
+	existing.Name = "groceries"
+	input := t.getCategoryInput()
+	input.ID = uuid.Nil
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{t.getParent(), existing}, model.PageInfoOutput{}, nil)
+
+	category, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), category)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestGetByID_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{}, nil)
+
+	category, err := t.svc.GetByID(t.testCategoryID)
+
+	assert.Nil(t.T(), category)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestGetTree_Normal() {
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{t.getCategory(), t.getParent()}, model.PageInfoOutput{}, nil)
+
+	tree, err := t.svc.GetTree()
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), tree, 1)
+	assert.Equal(t.T(), "Household", tree[0].Name)
+	assert.Len(t.T(), tree[0].Children, 1)
+	assert.Equal(t.T(), "Groceries", tree[0].Children[0].Name)
+}
+
+func (t *categoriesServiceTestSuite) TestUpdate_Renamed() {
+	input := t.getCategoryInput()
+	input.Name = "Food"
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{t.getCategory()}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{t.getParent(), t.getCategory()}, model.PageInfoOutput{}, nil)
+	t.mockTransactionRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Transaction{t.getTransaction("Corner Grocery", "Groceries")}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	category, err := t.svc.Update(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), category)
+	assert.Equal(t.T(), "Food", category.Name)
+	assert.Len(t.T(), category.Transactions, 1)
+	assert.Equal(t.T(), "Food", category.Transactions[0].Category)
+	assert.Equal(t.T(), nuuid.From(t.testCategoryID), category.Transactions[0].CategoryID)
+	assert.True(t.T(), category.Transactions[0].UpdatedBy.Valid)
+}
+
+func (t *categoriesServiceTestSuite) TestUpdate_NotRenamed() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{t.getCategory()}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{t.getParent(), t.getCategory()}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	category, err := t.svc.Update(t.getCategoryInput(), t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), category)
+	assert.Len(t.T(), category.Transactions, 0)
+}
+
+func (t *categoriesServiceTestSuite) TestUpdate_MovedBelowOwnChild() {
+	input := model.CategoryInput{
+		ID:       t.testParentID,
+		ParentID: nuuid.From(t.testCategoryID),
+		Name:     "Household",
+	}
+	current := t.getParent()
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testParentID}).Return([]model.Category{current}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{current, t.getCategory()}, model.PageInfoOutput{}, nil)
+
+	updated, err := t.svc.Update(input, t.testUserID)
+
+	assert.Nil(t.T(), updated)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestUpdate_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{}, nil)
+
+	category, err := t.svc.Update(t.getCategoryInput(), t.testUserID)
+
+	assert.Nil(t.T(), category)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestDelete_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{t.getCategory()}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Category{}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveRulesByFilter(gomock.Any()).Return([]model.CategoryRule{}, model.PageInfoOutput{}, nil)
+	t.mockBudgetRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Budget{}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	category, err := t.svc.Delete(t.testCategoryID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), category)
+	assert.True(t.T(), category.Deleted.Valid)
+}
+
+func (t *categoriesServiceTestSuite) TestDelete_HasChildren() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testParentID}).Return([]model.Category{t.getParent()}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Category{t.getCategory()}, model.PageInfoOutput{}, nil)
+
+	category, err := t.svc.Delete(t.testParentID, t.testUserID)
+
+	assert.Nil(t.T(), category)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestDelete_HasBudgets() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{t.getCategory()}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Category{}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveRulesByFilter(gomock.Any()).Return([]model.CategoryRule{}, model.PageInfoOutput{}, nil)
+	t.mockBudgetRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Budget{{CategoryID: t.testCategoryID}}, model.PageInfoOutput{}, nil)
+
+	category, err := t.svc.Delete(t.testCategoryID, t.testUserID)
+
+	assert.Nil(t.T(), category)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestCreateRule_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{t.getCategory()}, nil)
+	t.mockRepo.EXPECT().CreateRule(gomock.Any()).Return(nil)
+
+	categoryRule, err := t.svc.CreateRule(t.getRuleInput(), t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), categoryRule)
+	assert.Equal(t.T(), "*grocer*", categoryRule.Pattern)
+}
+
+func (t *categoriesServiceTestSuite) TestCreateRule_CategoryDeleted() {
+	category := t.getCategory()
+	category.Deleted = null.TimeFrom(time.Now())
+	category.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{category}, nil)
+
+	categoryRule, err := t.svc.CreateRule(t.getRuleInput(), t.testUserID)
+
+	assert.Nil(t.T(), categoryRule)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestCreateRule_MissingPattern() {
+	input := t.getRuleInput()
+	input.Pattern = ""
+
+	categoryRule, err := t.svc.CreateRule(input, t.testUserID)
+
+	assert.Nil(t.T(), categoryRule)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestUpdateRule_Normal() {
+	input := t.getRuleInput()
+	input.Pattern = "*market*"
+
+	t.mockRepo.EXPECT().ResolveRulesByIDs([]uuid.UUID{t.testRuleID}).Return([]model.CategoryRule{t.getRule()}, nil)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{t.getCategory()}, nil)
+	t.mockRepo.EXPECT().UpdateRule(gomock.Any()).Return(nil)
+
+	categoryRule, err := t.svc.UpdateRule(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), categoryRule)
+	assert.Equal(t.T(), "*market*", categoryRule.Pattern)
+}
+
+func (t *categoriesServiceTestSuite) TestDeleteRule_Normal() {
+	t.mockRepo.EXPECT().ResolveRulesByIDs([]uuid.UUID{t.testRuleID}).Return([]model.CategoryRule{t.getRule()}, nil)
+	t.mockRepo.EXPECT().UpdateRule(gomock.Any()).Return(nil)
+
+	categoryRule, err := t.svc.DeleteRule(t.testRuleID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), categoryRule)
+	assert.True(t.T(), categoryRule.Deleted.Valid)
+}
+
+func (t *categoriesServiceTestSuite) TestDeleteRule_NotFound() {
+	t.mockRepo.EXPECT().ResolveRulesByIDs([]uuid.UUID{t.testRuleID}).Return([]model.CategoryRule{}, nil)
+
+	categoryRule, err := t.svc.DeleteRule(t.testRuleID, t.testUserID)
+
+	assert.Nil(t.T(), categoryRule)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *categoriesServiceTestSuite) TestApplyRules_Normal() {
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{t.getParent(), t.getCategory()}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveRulesByFilter(gomock.Any()).
+		Return([]model.CategoryRule{t.getRule()}, model.PageInfoOutput{}, nil)
+	t.mockTransactionRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Transaction{
+			t.getTransaction("Corner Grocery Store", ""),
+			t.getTransaction("Gas Station", ""),
+		}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Categorize(gomock.Any()).
+		DoAndReturn(func(transactions []model.Transaction) error {
+			assert.Len(t.T(), transactions, 1)
+			return nil
+		})
+
+	transactions, err := t.svc.ApplyRules(t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), transactions, 1)
+	assert.Equal(t.T(), "Groceries", transactions[0].Category)
+	assert.Equal(t.T(), nuuid.From(t.testCategoryID), transactions[0].CategoryID)
+	assert.Equal(t.T(), "Corner Grocery Store", transactions[0].Payee)
+}
+
+func (t *categoriesServiceTestSuite) TestApplyRules_ErrorCategorizing() {
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{t.getCategory()}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveRulesByFilter(gomock.Any()).
+		Return([]model.CategoryRule{t.getRule()}, model.PageInfoOutput{}, nil)
+	t.mockTransactionRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Transaction{t.getTransaction("Corner Grocery Store", "")}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Categorize(gomock.Any()).Return(errors.New("failed"))
+
+	transactions, err := t.svc.ApplyRules(t.testUserID)
+
+	assert.Nil(t.T(), transactions)
+	assert.NotNil(t.T(), err)
+}
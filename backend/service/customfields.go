@@ -0,0 +1,226 @@
+package service
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// CustomFieldImpl is the service provider implementation
+type CustomFieldImpl struct {
+	Repository            repository.CustomField `inject:"customFieldRepository"`
+	BankAccountRepository repository.BankAccount `inject:"bankAccountRepository"`
+	VehicleRepository     repository.Vehicle     `inject:"vehicleRepository"`
+	PropertyRepository    repository.Property    `inject:"propertyRepository"`
+}
+
+// Startup performs startup functions
+func (s *CustomFieldImpl) Startup() {
+	logger.Trace("Custom Field Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *CustomFieldImpl) Shutdown() {
+	logger.Trace("Custom Field Service shutting down...")
+}
+
+// Create creates a new Custom Field
+func (s *CustomFieldImpl) Create(input model.CustomFieldInput, userID uuid.UUID) (*model.CustomField, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	customFields, err := s.resolveCustomFields(input.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkNameAvailable("create", customFields, input)
+	if err != nil {
+		return nil, err
+	}
+
+	customField := model.NewCustomFieldFromInput(input, userID)
+	err = s.Repository.Create(customField)
+	if err != nil {
+		return nil, err
+	}
+
+	return &customField, nil
+}
+
+// GetByID fetches a Custom Field by its ID
+func (s *CustomFieldImpl) GetByID(id uuid.UUID) (*model.CustomField, error) {
+	customFields, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(customFields) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Custom Field")
+	}
+
+	return &customFields[0], nil
+}
+
+// GetByFilter fetches a set of Custom Fields by its filter
+func (s *CustomFieldImpl) GetByFilter(input model.CustomFieldFilterInput) ([]model.CustomField, model.PageInfoOutput, error) {
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// Update updates an existing Custom Field
+func (s *CustomFieldImpl) Update(input model.CustomFieldInput, userID uuid.UUID) (*model.CustomField, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	customFields, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(customFields) != 1 {
+		return nil, failure.EntityNotFound("update", "Custom Field")
+	}
+
+	customField := customFields[0]
+
+	all, err := s.resolveCustomFields(input.EntityType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkNameAvailable("update", all, input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = customField.Update(input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(customField)
+	if err != nil {
+		return nil, err
+	}
+
+	return &customField, nil
+}
+
+// Delete deletes an existing Custom Field
+func (s *CustomFieldImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.CustomField, error) {
+	customFields, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(customFields) != 1 {
+		return nil, failure.EntityNotFound("delete", "Custom Field")
+	}
+
+	customField := customFields[0]
+
+	err = customField.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(customField)
+	if err != nil {
+		return nil, err
+	}
+
+	return &customField, nil
+}
+
+// GetValues fetches the values an entity has for the Custom Fields of its type
+func (s *CustomFieldImpl) GetValues(entityType model.EntityType, subjectID uuid.UUID) (*model.CustomFieldValues, error) {
+	err := checkEntity("get custom field values", entityType, subjectID, model.CheckTaggableEntityType, s.BankAccountRepository, s.VehicleRepository, s.PropertyRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	customFields, err := s.resolveCustomFields(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := s.Repository.ResolveValuesBySubjectID(subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CustomFieldValues{
+		EntityType:   entityType,
+		SubjectID:    subjectID,
+		CustomFields: customFields,
+		Values:       values,
+	}, nil
+}
+
+// SetValues replaces the values an entity has for the Custom Fields of its type
+func (s *CustomFieldImpl) SetValues(entityType model.EntityType, subjectID uuid.UUID, input model.CustomFieldValuesInput) (*model.CustomFieldValues, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkEntity("set custom field values", entityType, subjectID, model.CheckTaggableEntityType, s.BankAccountRepository, s.VehicleRepository, s.PropertyRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	customFields, err := s.resolveCustomFields(entityType)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := model.NewCustomFieldValuesFromInput(input, subjectID, customFields)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.SetValues(entityType, subjectID, values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CustomFieldValues{
+		EntityType:   entityType,
+		SubjectID:    subjectID,
+		CustomFields: customFields,
+		Values:       values,
+	}, nil
+}
+
+// checkNameAvailable makes sure that no other Custom Field for the same type of entity that has not been deleted
+// goes by the same name
+func (s *CustomFieldImpl) checkNameAvailable(operation string, customFields []model.CustomField, input model.CustomFieldInput) error {
+	existing := model.FindCustomFieldByName(customFields, input.EntityType, input.Name)
+	if existing != nil && existing.ID != input.ID {
+		return failure.OperationNotPermitted(operation, "Custom Field", "a custom field with the same name already exists")
+	}
+
+	return nil
+}
+
+// resolveCustomFields resolves all Custom Fields for a type of entity that have not been deleted
+func (s *CustomFieldImpl) resolveCustomFields(entityType model.EntityType) ([]model.CustomField, error) {
+	page := 1
+	pageSize := math.MaxInt
+	entityTypes := []model.EntityType{entityType}
+
+	customFieldFilter := model.CustomFieldFilterInput{EntityTypes: &entityTypes}
+	customFieldFilter.Page = &page
+	customFieldFilter.PageSize = &pageSize
+
+	customFields, _, err := s.Repository.ResolveByFilter(customFieldFilter.ToFilter())
+	return customFields, err
+}
@@ -0,0 +1,244 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type customFieldsServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.CustomField
+	mockRepo            *mock_repository.MockCustomField
+	mockBankAccountRepo *mock_repository.MockBankAccount
+	mockVehicleRepo     *mock_repository.MockVehicle
+	mockPropertyRepo    *mock_repository.MockProperty
+	testUserID          uuid.UUID
+	testCustomFieldID   uuid.UUID
+	testVehicleID       uuid.UUID
+}
+
+func TestCustomFieldsService(t *testing.T) {
+	suite.Run(t, new(customFieldsServiceTestSuite))
+}
+
+func (t *customFieldsServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockCustomField(t.ctrl)
+	t.mockBankAccountRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.mockVehicleRepo = mock_repository.NewMockVehicle(t.ctrl)
+	t.mockPropertyRepo = mock_repository.NewMockProperty(t.ctrl)
+	t.svc = &service.CustomFieldImpl{
+		Repository:            t.mockRepo,
+		BankAccountRepository: t.mockBankAccountRepo,
+		VehicleRepository:     t.mockVehicleRepo,
+		PropertyRepository:    t.mockPropertyRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testCustomFieldID, _ = uuid.NewV7()
+	t.testVehicleID, _ = uuid.NewV7()
+	t.svc.Startup()
+}
+
+func (t *customFieldsServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *customFieldsServiceTestSuite) getCustomField() model.CustomField {
+	return model.CustomField{
+		ID:         t.testCustomFieldID,
+		EntityType: model.EntityTypeVehicle,
+		Name:       "Insurance Expiry",
+		Type:       model.CustomFieldTypeDate,
+		Created:    time.Now(),
+		CreatedBy:  t.testUserID,
+	}
+}
+
+func (t *customFieldsServiceTestSuite) getCustomFieldInput() model.CustomFieldInput {
+	return model.CustomFieldInput{
+		ID:         t.testCustomFieldID,
+		EntityType: model.EntityTypeVehicle,
+		Name:       "Insurance Expiry",
+		Type:       model.CustomFieldTypeDate,
+	}
+}
+
+func (t *customFieldsServiceTestSuite) TestCreate_Normal() {
+	input := t.getCustomFieldInput()
+	input.ID = uuid.Nil
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.CustomField{}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	customField, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), customField)
+	assert.Equal(t.T(), model.CustomFieldTypeDate, customField.Type)
+}
+
+func (t *customFieldsServiceTestSuite) TestCreate_InvalidType() {
+	input := t.getCustomFieldInput()
+	input.Type = model.CustomFieldType("color")
+
+	customField, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), customField)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *customFieldsServiceTestSuite) TestCreate_NotTaggable() {
+	input := t.getCustomFieldInput()
+	input.EntityType = model.EntityTypeGoal
+
+	customField, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), customField)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *customFieldsServiceTestSuite) TestCreate_NameTaken() {
+	input := t.getCustomFieldInput()
+	input.ID = uuid.Nil
+
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.CustomField{t.getCustomField()}, model.PageInfoOutput{}, nil)
+
+	customField, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), customField)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *customFieldsServiceTestSuite) TestUpdate_Normal() {
+	input := t.getCustomFieldInput()
+	input.Name = "Insurance Renewal"
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCustomFieldID}).Return([]model.CustomField{t.getCustomField()}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.CustomField{t.getCustomField()}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	customField, err := t.svc.Update(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), customField)
+	assert.Equal(t.T(), "Insurance Renewal", customField.Name)
+	assert.True(t.T(), customField.UpdatedBy.Valid)
+}
+
+func (t *customFieldsServiceTestSuite) TestUpdate_TypeChanged() {
+	input := t.getCustomFieldInput()
+	input.Type = model.CustomFieldTypeText
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCustomFieldID}).Return([]model.CustomField{t.getCustomField()}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.CustomField{t.getCustomField()}, model.PageInfoOutput{}, nil)
+
+	customField, err := t.svc.Update(input, t.testUserID)
+
+	assert.Nil(t.T(), customField)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *customFieldsServiceTestSuite) TestDelete_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCustomFieldID}).Return([]model.CustomField{t.getCustomField()}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	customField, err := t.svc.Delete(t.testCustomFieldID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), customField)
+	assert.True(t.T(), customField.Deleted.Valid)
+}
+
+func (t *customFieldsServiceTestSuite) TestGetValues_Normal() {
+	values := []model.CustomFieldValue{
+		{CustomFieldID: t.testCustomFieldID, SubjectID: t.testVehicleID, Value: "2026-12-31"},
+	}
+
+	t.mockVehicleRepo.EXPECT().ExistsByID(t.testVehicleID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.CustomField{t.getCustomField()}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveValuesBySubjectID(t.testVehicleID).Return(values, nil)
+
+	customFieldValues, err := t.svc.GetValues(model.EntityTypeVehicle, t.testVehicleID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), customFieldValues)
+
+	output := customFieldValues.ToOutput()
+	assert.Len(t.T(), output.Values, 1)
+	assert.Equal(t.T(), "Insurance Expiry", output.Values[0].Name)
+	assert.Equal(t.T(), "2026-12-31", output.Values[0].Value)
+}
+
+func (t *customFieldsServiceTestSuite) TestGetValues_EntityNotFound() {
+	t.mockVehicleRepo.EXPECT().ExistsByID(t.testVehicleID).Return(false, nil)
+
+	customFieldValues, err := t.svc.GetValues(model.EntityTypeVehicle, t.testVehicleID)
+
+	assert.Nil(t.T(), customFieldValues)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *customFieldsServiceTestSuite) TestSetValues_Normal() {
+	expected := []model.CustomFieldValue{
+		{CustomFieldID: t.testCustomFieldID, SubjectID: t.testVehicleID, Value: "2026-12-31"},
+	}
+
+	t.mockVehicleRepo.EXPECT().ExistsByID(t.testVehicleID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.CustomField{t.getCustomField()}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().SetValues(model.EntityTypeVehicle, t.testVehicleID, expected).Return(nil)
+
+	input := model.CustomFieldValuesInput{
+		Values: []model.CustomFieldValueInput{{CustomFieldID: t.testCustomFieldID, Value: " 2026-12-31 "}},
+	}
+	customFieldValues, err := t.svc.SetValues(model.EntityTypeVehicle, t.testVehicleID, input)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), customFieldValues)
+	assert.Equal(t.T(), expected, customFieldValues.Values)
+}
+
+func (t *customFieldsServiceTestSuite) TestSetValues_InvalidValue() {
+	t.mockVehicleRepo.EXPECT().ExistsByID(t.testVehicleID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.CustomField{t.getCustomField()}, model.PageInfoOutput{}, nil)
+
+	input := model.CustomFieldValuesInput{
+		Values: []model.CustomFieldValueInput{{CustomFieldID: t.testCustomFieldID, Value: "next year"}},
+	}
+	customFieldValues, err := t.svc.SetValues(model.EntityTypeVehicle, t.testVehicleID, input)
+
+	assert.Nil(t.T(), customFieldValues)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *customFieldsServiceTestSuite) TestSetValues_UnknownCustomField() {
+	otherID, _ := uuid.NewV7()
+
+	t.mockVehicleRepo.EXPECT().ExistsByID(t.testVehicleID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.CustomField{t.getCustomField()}, model.PageInfoOutput{}, nil)
+
+	input := model.CustomFieldValuesInput{
+		Values: []model.CustomFieldValueInput{{CustomFieldID: otherID, Value: "true"}},
+	}
+	customFieldValues, err := t.svc.SetValues(model.EntityTypeVehicle, t.testVehicleID, input)
+
+	assert.Nil(t.T(), customFieldValues)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// GoalImpl is the service provider implementation
+type GoalImpl struct {
+	Repository            repository.Goal        `inject:"goalRepository"`
+	BankAccountRepository repository.BankAccount `inject:"bankAccountRepository"`
+}
+
+// Startup performs startup functions
+func (s *GoalImpl) Startup() {
+	logger.Trace("Goal Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *GoalImpl) Shutdown() {
+	logger.Trace("Goal Service shutting down...")
+}
+
+// Create creates a new Goal
+func (s *GoalImpl) Create(input model.GoalInput, userID uuid.UUID) (*model.Goal, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkBankAccounts("create", input.BankAccountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	goal := model.NewGoalFromInput(input, userID)
+	err = s.Repository.Create(goal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &goal, nil
+}
+
+// GetByID fetches a Goal by its ID
+func (s *GoalImpl) GetByID(id uuid.UUID) (*model.Goal, error) {
+	goals, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(goals) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Goal")
+	}
+
+	return &goals[0], nil
+}
+
+// GetByFilter fetches a set of Goals by its filter
+func (s *GoalImpl) GetByFilter(input model.GoalFilterInput) ([]model.Goal, model.PageInfoOutput, error) {
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// Update updates an existing Goal
+func (s *GoalImpl) Update(input model.GoalInput, userID uuid.UUID) (*model.Goal, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	goals, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(goals) != 1 {
+		return nil, failure.EntityNotFound("update", "Goal")
+	}
+
+	goal := goals[0]
+
+	err = s.checkBankAccounts("update", input.BankAccountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = goal.Update(input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(goal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &goal, nil
+}
+
+// Delete deletes an existing Goal
+func (s *GoalImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Goal, error) {
+	goals, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(goals) != 1 {
+		return nil, failure.EntityNotFound("delete", "Goal")
+	}
+
+	goal := goals[0]
+
+	err = goal.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(goal)
+	if err != nil {
+		return nil, err
+	}
+
+	return &goal, nil
+}
+
+// GetProgress works out how far a Goal has come from the balances of the Bank Accounts funding it
+func (s *GoalImpl) GetProgress(id uuid.UUID) (*model.GoalProgress, error) {
+	goal, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bankAccounts, err := s.resolveBankAccounts(goal.BankAccountIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := model.NewGoalProgress(*goal, bankAccounts, now)
+	return &progress, nil
+}
+
+// GetProgressByBankAccountID works out the progress of every Goal a Bank Account funds
+func (s *GoalImpl) GetProgressByBankAccountID(id uuid.UUID) ([]model.GoalProgress, error) {
+	exists, err := s.BankAccountRepository.ExistsByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, failure.EntityNotFound("get goals", "Bank Account")
+	}
+
+	goals, err := s.Repository.ResolveByBankAccountID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	bankAccountIDs := make([]uuid.UUID, 0)
+	for _, goal := range goals {
+		for _, bankAccountID := range goal.BankAccountIDs {
+			if !seen[bankAccountID] {
+				seen[bankAccountID] = true
+				bankAccountIDs = append(bankAccountIDs, bankAccountID)
+			}
+		}
+	}
+
+	now := time.Now()
+	bankAccounts, err := s.resolveBankAccounts(bankAccountIDs, now)
+	if err != nil {
+		return nil, err
+	}
+
+	progresses := make([]model.GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		progresses = append(progresses, model.NewGoalProgress(goal, bankAccounts, now))
+	}
+
+	return progresses, nil
+}
+
+// checkBankAccounts makes sure that the Bank Accounts funding a Goal exist and have not been deleted
+func (s *GoalImpl) checkBankAccounts(operation string, ids []uuid.UUID) error {
+	bankAccounts, err := s.BankAccountRepository.ResolveByIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	if len(bankAccounts) != len(ids) {
+		return failure.EntityNotFound(operation, "Bank Account")
+	}
+
+	for _, bankAccount := range bankAccounts {
+		if bankAccount.Deleted.Valid {
+			return failure.OperationNotPermitted(operation, "Bank Account", "the Bank Account is already deleted")
+		}
+	}
+
+	return nil
+}
+
+// resolveBankAccounts resolves Bank Accounts along with their balances up to a moment
+func (s *GoalImpl) resolveBankAccounts(ids []uuid.UUID, asOf time.Time) ([]model.BankAccount, error) {
+	bankAccounts, err := s.BankAccountRepository.ResolveByIDs(ids)
+	if err != nil || len(bankAccounts) == 0 {
+		return bankAccounts, err
+	}
+
+	page := 1
+	pageSize := math.MaxInt
+
+	balanceFilter := model.BankAccountBalanceFilterInput{
+		BankAccountIDs: &ids,
+		EndDate:        cachetime.NCacheTime(null.TimeFrom(asOf)),
+	}
+	balanceFilter.Page = &page
+	balanceFilter.PageSize = &pageSize
+
+	balances, _, err := s.BankAccountRepository.ResolveBalancesByFilter(balanceFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range bankAccounts {
+		bankAccounts[idx].AttachBalances(balances, true)
+	}
+
+	return bankAccounts, nil
+}
@@ -0,0 +1,318 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type goalsServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.Goal
+	mockRepo            *mock_repository.MockGoal
+	mockBankAccountRepo *mock_repository.MockBankAccount
+	testUserID          uuid.UUID
+	testGoalID          uuid.UUID
+	testBankAccountID   uuid.UUID
+	testTargetDate      time.Time
+}
+
+func TestGoalsService(t *testing.T) {
+	suite.Run(t, new(goalsServiceTestSuite))
+}
+
+func (t *goalsServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockGoal(t.ctrl)
+	t.mockBankAccountRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.svc = &service.GoalImpl{
+		Repository:            t.mockRepo,
+		BankAccountRepository: t.mockBankAccountRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testGoalID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.testTargetDate = time.Now().AddDate(1, 0, 0)
+	t.svc.Startup()
+}
+
+func (t *goalsServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *goalsServiceTestSuite) getGoal() model.Goal {
+	return model.Goal{
+		ID:             t.testGoalID,
+		Name:           "Emergency Fund",
+		TargetAmount:   float64(10000),
+		TargetDate:     t.testTargetDate,
+		Created:        time.Now(),
+		CreatedBy:      t.testUserID,
+		BankAccountIDs: []uuid.UUID{t.testBankAccountID},
+	}
+}
+
+func (t *goalsServiceTestSuite) getGoalInput() model.GoalInput {
+	return model.GoalInput{
+		ID:             t.testGoalID,
+		Name:           "Emergency Fund",
+		TargetAmount:   float64(10000),
+		TargetDate:     cachetime.CacheTime(t.testTargetDate),
+		BankAccountIDs: []uuid.UUID{t.testBankAccountID, t.testBankAccountID},
+	}
+}
+
+func (t *goalsServiceTestSuite) getBankAccount() model.BankAccount {
+	return model.BankAccount{
+		ID:              t.testBankAccountID,
+		AccountName:     "Savings",
+		LastBalance:     float64(7000),
+		LastBalanceDate: time.Now().AddDate(0, 0, -1),
+		Status:          model.BankAccountStatusActive,
+		Created:         time.Now().AddDate(0, -6, 0),
+		CreatedBy:       t.testUserID,
+	}
+}
+
+func (t *goalsServiceTestSuite) getBalance(date time.Time, balance float64) model.BankAccountBalance {
+	id, _ := uuid.NewV7()
+	return model.BankAccountBalance{
+		ID:            id,
+		BankAccountID: t.testBankAccountID,
+		Date:          date,
+		Balance:       balance,
+		Created:       date,
+		CreatedBy:     t.testUserID,
+	}
+}
+
+func (t *goalsServiceTestSuite) TestCreate_Normal() {
+	input := t.getGoalInput()
+	input.ID = uuid.Nil
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{t.getBankAccount()}, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	goal, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), goal)
+	assert.Equal(t.T(), []uuid.UUID{t.testBankAccountID}, goal.BankAccountIDs)
+}
+
+func (t *goalsServiceTestSuite) TestCreate_NoBankAccounts() {
+	input := t.getGoalInput()
+	input.BankAccountIDs = []uuid.UUID{}
+
+	goal, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), goal)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *goalsServiceTestSuite) TestCreate_NoTargetDate() {
+	input := t.getGoalInput()
+	input.TargetDate = cachetime.CacheTime{}
+
+	goal, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), goal)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *goalsServiceTestSuite) TestCreate_BankAccountNotFound() {
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{}, nil)
+
+	goal, err := t.svc.Create(t.getGoalInput(), t.testUserID)
+
+	assert.Nil(t.T(), goal)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *goalsServiceTestSuite) TestCreate_BankAccountDeleted() {
+	bankAccount := t.getBankAccount()
+	bankAccount.Deleted = null.TimeFrom(time.Now())
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{bankAccount}, nil)
+
+	goal, err := t.svc.Create(t.getGoalInput(), t.testUserID)
+
+	assert.Nil(t.T(), goal)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *goalsServiceTestSuite) TestUpdate_Normal() {
+	input := t.getGoalInput()
+	input.TargetAmount = float64(12000)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testGoalID}).Return([]model.Goal{t.getGoal()}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{t.getBankAccount()}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	goal, err := t.svc.Update(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), goal)
+	assert.Equal(t.T(), float64(12000), goal.TargetAmount)
+	assert.True(t.T(), goal.UpdatedBy.Valid)
+}
+
+func (t *goalsServiceTestSuite) TestUpdate_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testGoalID}).Return([]model.Goal{}, nil)
+
+	goal, err := t.svc.Update(t.getGoalInput(), t.testUserID)
+
+	assert.Nil(t.T(), goal)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *goalsServiceTestSuite) TestDelete_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testGoalID}).Return([]model.Goal{t.getGoal()}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	goal, err := t.svc.Delete(t.testGoalID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), goal)
+	assert.True(t.T(), goal.Deleted.Valid)
+}
+
+func (t *goalsServiceTestSuite) TestDelete_AlreadyDeleted() {
+	goal := t.getGoal()
+	goal.Deleted = null.TimeFrom(time.Now())
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testGoalID}).Return([]model.Goal{goal}, nil)
+
+	deleted, err := t.svc.Delete(t.testGoalID, t.testUserID)
+
+	assert.Nil(t.T(), deleted)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *goalsServiceTestSuite) TestGetProgress_Normal() {
+	now := time.Now()
+	balances := []model.BankAccountBalance{
+		t.getBalance(now.AddDate(0, -4, 0), float64(4000)),
+		t.getBalance(now.AddDate(0, 0, -1), float64(7000)),
+	}
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testGoalID}).Return([]model.Goal{t.getGoal()}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{t.getBankAccount()}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return(balances, model.PageInfoOutput{}, nil)
+
+	progress, err := t.svc.GetProgress(t.testGoalID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), progress)
+
+	output := progress.ToOutput()
+	assert.Equal(t.T(), float64(7000), output.Current)
+	assert.Equal(t.T(), float64(3000), output.Remaining)
+	assert.Equal(t.T(), float64(70), output.Percent)
+	assert.Equal(t.T(), float64(1000), output.SavingsRate)
+	assert.False(t.T(), output.Achieved)
+	assert.True(t.T(), output.OnTrack)
+	assert.True(t.T(), progress.ProjectedDate.Valid)
+	assert.WithinDuration(t.T(), now.AddDate(0, 3, 0), progress.ProjectedDate.Time, 24*time.Hour)
+	assert.True(t.T(), progress.RequiredMonthlySavings.Valid)
+	assert.InDelta(t.T(), float64(250), progress.RequiredMonthlySavings.Float64, 5)
+	assert.NotEmpty(t.T(), output.History)
+	assert.Equal(t.T(), float64(7000), output.History[len(output.History)-1].Balance)
+}
+
+func (t *goalsServiceTestSuite) TestGetProgress_NotSaving() {
+	now := time.Now()
+	balances := []model.BankAccountBalance{
+		t.getBalance(now.AddDate(0, -4, 0), float64(7000)),
+		t.getBalance(now.AddDate(0, 0, -1), float64(6500)),
+	}
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testGoalID}).Return([]model.Goal{t.getGoal()}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{t.getBankAccount()}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return(balances, model.PageInfoOutput{}, nil)
+
+	progress, err := t.svc.GetProgress(t.testGoalID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), progress)
+	assert.False(t.T(), progress.ProjectedDate.Valid)
+	assert.False(t.T(), progress.IsOnTrack())
+	assert.Less(t.T(), progress.SavingsRate, float64(0))
+}
+
+func (t *goalsServiceTestSuite) TestGetProgress_Achieved() {
+	now := time.Now()
+	balances := []model.BankAccountBalance{
+		t.getBalance(now.AddDate(0, 0, -1), float64(12000)),
+	}
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testGoalID}).Return([]model.Goal{t.getGoal()}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{t.getBankAccount()}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return(balances, model.PageInfoOutput{}, nil)
+
+	progress, err := t.svc.GetProgress(t.testGoalID)
+
+	assert.Nil(t.T(), err)
+
+	output := progress.ToOutput()
+	assert.True(t.T(), output.Achieved)
+	assert.True(t.T(), output.OnTrack)
+	assert.Equal(t.T(), float64(0), output.Remaining)
+	assert.Equal(t.T(), float64(100), output.Percent)
+	assert.False(t.T(), output.RequiredMonthlySavings.Valid)
+}
+
+func (t *goalsServiceTestSuite) TestGetProgress_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testGoalID}).Return([]model.Goal{}, nil)
+
+	progress, err := t.svc.GetProgress(t.testGoalID)
+
+	assert.Nil(t.T(), progress)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *goalsServiceTestSuite) TestGetProgressByBankAccountID_Normal() {
+	balances := []model.BankAccountBalance{
+		t.getBalance(time.Now().AddDate(0, 0, -1), float64(7000)),
+	}
+
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByBankAccountID(t.testBankAccountID).Return([]model.Goal{t.getGoal()}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{t.getBankAccount()}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return(balances, model.PageInfoOutput{}, nil)
+
+	progresses, err := t.svc.GetProgressByBankAccountID(t.testBankAccountID)
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), progresses, 1)
+	assert.Equal(t.T(), float64(7000), progresses[0].Current)
+}
+
+func (t *goalsServiceTestSuite) TestGetProgressByBankAccountID_BankAccountNotFound() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(false, nil)
+
+	progresses, err := t.svc.GetProgressByBankAccountID(t.testBankAccountID)
+
+	assert.Nil(t.T(), progresses)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
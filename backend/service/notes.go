@@ -0,0 +1,147 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// NoteImpl is the service provider implementation
+type NoteImpl struct {
+	Repository            repository.Note        `inject:"noteRepository"`
+	BankAccountRepository repository.BankAccount `inject:"bankAccountRepository"`
+	VehicleRepository     repository.Vehicle     `inject:"vehicleRepository"`
+	PropertyRepository    repository.Property    `inject:"propertyRepository"`
+}
+
+// Startup performs startup functions
+func (s *NoteImpl) Startup() {
+	logger.Trace("Note Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *NoteImpl) Shutdown() {
+	logger.Trace("Note Service shutting down...")
+}
+
+// Create writes a new Note on an entity
+func (s *NoteImpl) Create(input model.NoteInput, userID uuid.UUID) (*model.Note, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkNotableEntity("create", input.EntityType, input.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := model.NewNoteFromInput(input, userID)
+	err = s.Repository.Create(note)
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// GetByID fetches a Note by its ID
+func (s *NoteImpl) GetByID(id uuid.UUID) (*model.Note, error) {
+	notes, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notes) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Note")
+	}
+
+	return &notes[0], nil
+}
+
+// GetByFilter fetches a set of Notes by its filter
+func (s *NoteImpl) GetByFilter(input model.NoteFilterInput) ([]model.Note, model.PageInfoOutput, error) {
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// GetByEntity fetches the Notes written on an entity
+func (s *NoteImpl) GetByEntity(entityType model.EntityType, subjectID uuid.UUID) ([]model.Note, error) {
+	err := s.checkNotableEntity("get notes", entityType, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Repository.ResolveByEntity(entityType, subjectID)
+}
+
+// Update updates the content of an existing Note, which only its author may do
+func (s *NoteImpl) Update(input model.NoteInput, userID uuid.UUID) (*model.Note, error) {
+	notes, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notes) != 1 {
+		return nil, failure.EntityNotFound("update", "Note")
+	}
+
+	err = input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	note := notes[0]
+
+	if note.CreatedBy != userID {
+		return nil, failure.Forbidden("update", "Note", "author only")
+	}
+
+	err = note.Update(input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(note)
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// Delete deletes an existing Note, which only its author may do
+func (s *NoteImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Note, error) {
+	notes, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(notes) != 1 {
+		return nil, failure.EntityNotFound("delete", "Note")
+	}
+
+	note := notes[0]
+
+	if note.CreatedBy != userID {
+		return nil, failure.Forbidden("delete", "Note", "author only")
+	}
+
+	err = note.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(note)
+	if err != nil {
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+// checkNotableEntity makes sure that notes can be written on an entity, and that it exists
+func (s *NoteImpl) checkNotableEntity(operation string, entityType model.EntityType, subjectID uuid.UUID) error {
+	return checkEntity(operation, entityType, subjectID, model.CheckNotableEntityType, s.BankAccountRepository, s.VehicleRepository, s.PropertyRepository)
+}
@@ -0,0 +1,257 @@
+package service_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type notesServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.Note
+	mockRepo            *mock_repository.MockNote
+	mockBankAccountRepo *mock_repository.MockBankAccount
+	mockVehicleRepo     *mock_repository.MockVehicle
+	mockPropertyRepo    *mock_repository.MockProperty
+	testUserID          uuid.UUID
+	testNoteID          uuid.UUID
+	testVehicleValueID  uuid.UUID
+}
+
+func TestNotesService(t *testing.T) {
+	suite.Run(t, new(notesServiceTestSuite))
+}
+
+func (t *notesServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockNote(t.ctrl)
+	t.mockBankAccountRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.mockVehicleRepo = mock_repository.NewMockVehicle(t.ctrl)
+	t.mockPropertyRepo = mock_repository.NewMockProperty(t.ctrl)
+	t.svc = &service.NoteImpl{
+		Repository:            t.mockRepo,
+		BankAccountRepository: t.mockBankAccountRepo,
+		VehicleRepository:     t.mockVehicleRepo,
+		PropertyRepository:    t.mockPropertyRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testNoteID, _ = uuid.NewV7()
+	t.testVehicleValueID, _ = uuid.NewV7()
+	t.svc.Startup()
+}
+
+func (t *notesServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *notesServiceTestSuite) getNote() model.Note {
+	return model.Note{
+		ID:         t.testNoteID,
+		EntityType: model.EntityTypeVehicleValue,
+		SubjectID:  t.testVehicleValueID,
+		Content:    "Dealer trade-in quote",
+		Created:    time.Now(),
+		CreatedBy:  t.testUserID,
+	}
+}
+
+func (t *notesServiceTestSuite) TestCreate_Normal() {
+	t.mockVehicleRepo.EXPECT().ExistsValueByID(t.testVehicleValueID).Return(true, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	input := model.NoteInput{
+		EntityType: model.EntityTypeVehicleValue,
+		SubjectID:  t.testVehicleValueID,
+		Content:    " Dealer trade-in quote ",
+	}
+	note, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), note)
+	assert.Equal(t.T(), "Dealer trade-in quote", note.Content)
+	assert.Equal(t.T(), t.testUserID, note.CreatedBy)
+}
+
+func (t *notesServiceTestSuite) TestCreate_NoContent() {
+	input := model.NoteInput{
+		EntityType: model.EntityTypeVehicleValue,
+		SubjectID:  t.testVehicleValueID,
+		Content:    " ",
+	}
+	note, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestCreate_ContentTooLong() {
+	input := model.NoteInput{
+		EntityType: model.EntityTypeVehicleValue,
+		SubjectID:  t.testVehicleValueID,
+		Content:    strings.Repeat("a", model.NoteMaxLength+1),
+	}
+	note, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestCreate_NotNotable() {
+	input := model.NoteInput{
+		EntityType: model.EntityTypeGoal,
+		SubjectID:  t.testVehicleValueID,
+		Content:    "Dealer trade-in quote",
+	}
+	note, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestCreate_EntityNotFound() {
+	t.mockVehicleRepo.EXPECT().ExistsValueByID(t.testVehicleValueID).Return(false, nil)
+
+	input := model.NoteInput{
+		EntityType: model.EntityTypeVehicleValue,
+		SubjectID:  t.testVehicleValueID,
+		Content:    "Dealer trade-in quote",
+	}
+	note, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestGetByID_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testNoteID}).Return([]model.Note{}, nil)
+
+	note, err := t.svc.GetByID(t.testNoteID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestGetByEntity_Normal() {
+	t.mockVehicleRepo.EXPECT().ExistsValueByID(t.testVehicleValueID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByEntity(model.EntityTypeVehicleValue, t.testVehicleValueID).Return([]model.Note{t.getNote()}, nil)
+
+	notes, err := t.svc.GetByEntity(model.EntityTypeVehicleValue, t.testVehicleValueID)
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), notes, 1)
+}
+
+func (t *notesServiceTestSuite) TestUpdate_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testNoteID}).Return([]model.Note{t.getNote()}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	input := model.NoteInput{
+		ID:         t.testNoteID,
+		EntityType: model.EntityTypeBankAccount,
+		Content:    "Dealer trade-in quote, confirmed by phone",
+	}
+	note, err := t.svc.Update(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), note)
+	assert.Equal(t.T(), "Dealer trade-in quote, confirmed by phone", note.Content)
+	assert.Equal(t.T(), model.EntityTypeVehicleValue, note.EntityType)
+	assert.Equal(t.T(), t.testVehicleValueID, note.SubjectID)
+	assert.True(t.T(), note.UpdatedBy.Valid)
+}
+
+func (t *notesServiceTestSuite) TestUpdate_NotAuthor() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testNoteID}).Return([]model.Note{t.getNote()}, nil)
+
+	otherUserID, _ := uuid.NewV7()
+	note, err := t.svc.Update(model.NoteInput{ID: t.testNoteID, Content: "Quote"}, otherUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeForbidden, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestUpdate_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testNoteID}).Return([]model.Note{}, nil)
+
+	note, err := t.svc.Update(model.NoteInput{ID: t.testNoteID, Content: "Quote"}, t.testUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestUpdate_AlreadyDeleted() {
+	deleted := t.getNote()
+	deleted.Deleted = null.TimeFrom(time.Now())
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testNoteID}).Return([]model.Note{deleted}, nil)
+
+	note, err := t.svc.Update(model.NoteInput{ID: t.testNoteID, Content: "Quote"}, t.testUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestDelete_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testNoteID}).Return([]model.Note{t.getNote()}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	note, err := t.svc.Delete(t.testNoteID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), note)
+	assert.True(t.T(), note.Deleted.Valid)
+}
+
+func (t *notesServiceTestSuite) TestDelete_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testNoteID}).Return([]model.Note{}, nil)
+
+	note, err := t.svc.Delete(t.testNoteID, t.testUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestDelete_NotAuthor() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testNoteID}).Return([]model.Note{t.getNote()}, nil)
+
+	otherUserID, _ := uuid.NewV7()
+	note, err := t.svc.Delete(t.testNoteID, otherUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeForbidden, failure.GetCode(err))
+}
+
+func (t *notesServiceTestSuite) TestDelete_AlreadyDeleted() {
+	deleted := t.getNote()
+	deleted.Deleted = null.TimeFrom(time.Now())
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testNoteID}).Return([]model.Note{deleted}, nil)
+
+	note, err := t.svc.Delete(t.testNoteID, t.testUserID)
+
+	assert.Nil(t.T(), note)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
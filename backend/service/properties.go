@@ -13,7 +13,8 @@ import (
 
 // PropertyImpl is the service provider implementation
 type PropertyImpl struct {
-	Repository repository.Property `inject:"propertyRepository"`
+	Repository     repository.Property `inject:"propertyRepository"`
+	NoteRepository repository.Note     `inject:"noteRepository"`
 }
 
 // Startup performs startup functions
@@ -74,6 +75,13 @@ func (s *PropertyImpl) GetByID(id uuid.UUID, withValues bool, valueStartDate, va
 		property.AttachValues(values, true)
 	}
 
+	notes, err := s.NoteRepository.ResolveBySubjectIDs(property.GetNoteSubjectIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	property.AttachNotes(notes)
+
 	return &property, nil
 }
 
@@ -82,6 +90,11 @@ func (s *PropertyImpl) GetByFilter(input model.PropertyFilterInput) ([]model.Pro
 	return s.Repository.ResolveByFilter(input.ToFilter())
 }
 
+// AggregateByFilter groups a set of Properties by its filter and aggregates every group
+func (s *PropertyImpl) AggregateByFilter(input model.PropertyFilterInput) ([]model.AggregateRow, error) {
+	return s.Repository.AggregateByFilter(input.ToFilter())
+}
+
 // Update updates an existing Property
 func (s *PropertyImpl) Update(input model.PropertyInput, userID uuid.UUID) (*model.Property, error) {
 	properties, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
@@ -154,6 +167,54 @@ func (s *PropertyImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Property,
 	return &property, err
 }
 
+// Restore restores a deleted Property. The method will find all the property's values, deleted or
+// not, and restore those that were deleted together with the Property.
+func (s *PropertyImpl) Restore(id uuid.UUID, userID uuid.UUID) (*model.Property, error) {
+	properties, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(properties) != 1 {
+		return nil, failure.EntityNotFound("restore", "Property")
+	}
+
+	property := properties[0]
+
+	// pre-validate to save one database call
+	if property.Deleted.Valid || property.DeletedBy.Valid {
+		filter := model.PropertyValueFilterInput{}
+		filter.PropertyIDs = &[]uuid.UUID{property.ID}
+
+		page := 1
+		pageSize := math.MaxInt
+		includeDeleted := true
+
+		filter.Page = &page
+		filter.PageSize = &pageSize
+		filter.IncludeDeleted = &includeDeleted
+
+		values, _, err := s.Repository.ResolveValuesByFilter(filter.ToFilter())
+		if err != nil {
+			return nil, err
+		}
+
+		property.AttachValues(values, true)
+	}
+
+	err = property.Restore(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(property)
+	if err != nil {
+		return nil, err
+	}
+
+	return &property, err
+}
+
 // CreateValue creates a new Property Value
 func (s *PropertyImpl) CreateValue(input model.PropertyValueInput, userID uuid.UUID) (*model.PropertyValue, error) {
 	properties, err := s.Repository.ResolveByIDs([]uuid.UUID{input.PropertyID})
@@ -202,6 +263,65 @@ func (s *PropertyImpl) CreateValue(input model.PropertyValueInput, userID uuid.U
 	return &propertyValue, nil
 }
 
+// ImportValues validates a history of Property Values from CSV and, unless it is a dry run,
+// imports every Value at once, updating the Property's current value only once
+func (s *PropertyImpl) ImportValues(input model.PropertyValueImportInput, userID uuid.UUID) (*model.ImportResult, error) {
+	properties, err := s.Repository.ResolveByIDs([]uuid.UUID{input.PropertyID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(properties) != 1 {
+		return nil, failure.EntityNotFound("import values", "Property")
+	}
+
+	property := properties[0]
+
+	if property.Deleted.Valid || property.DeletedBy.Valid {
+		return nil, failure.OperationNotPermitted("import values", "Property", "the Property is already deleted")
+	}
+
+	if property.Status == model.PropertyStatusSold {
+		return nil, failure.OperationNotPermitted("import values", "Property", "the Property has been sold")
+	}
+
+	rows, rowErrors, err := input.ParseRows()
+	if err != nil {
+		return nil, err
+	}
+
+	result := model.NewImportResult(input.DryRun, rows, rowErrors)
+	if !result.IsValid() || result.DryRun {
+		return &result, nil
+	}
+
+	propertyValues := make([]model.PropertyValue, 0, len(rows))
+	for _, row := range rows {
+		propertyValues = append(propertyValues, model.NewPropertyValueFromInput(input.ToValueInput(row), property.ID, userID))
+	}
+
+	lastValues, err := s.Repository.ResolveLastValuesByPropertyID(property.ID, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	latestInput := input.ToValueInput(model.GetLatestImportRow(rows))
+	var propertyToUpdate *model.Property
+
+	if len(lastValues) == 0 || lastValues[0].Date.Before(latestInput.Date.Time()) {
+		property.SetCurrentValue(latestInput, userID)
+		propertyToUpdate = &property
+	}
+
+	err = s.Repository.ImportValues(propertyValues, propertyToUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(propertyValues)
+	return &result, nil
+}
+
 // GetValueByID fetches a Property Value by its ID
 func (s *PropertyImpl) GetValueByID(id uuid.UUID) (*model.PropertyValue, error) {
 	values, err := s.Repository.ResolveValuesByIDs([]uuid.UUID{id})
@@ -213,7 +333,16 @@ func (s *PropertyImpl) GetValueByID(id uuid.UUID) (*model.PropertyValue, error)
 		return nil, failure.EntityNotFound("get by ID", "Property Value")
 	}
 
-	return &values[0], nil
+	value := values[0]
+
+	notes, err := s.NoteRepository.ResolveBySubjectIDs([]uuid.UUID{value.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	value.AttachNotes(notes)
+
+	return &value, nil
 }
 
 // GetValuesByFilter fetches a set of Property Values by its filter
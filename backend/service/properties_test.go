@@ -24,6 +24,7 @@ type propertiesServiceTestSuite struct {
 	ctrl                *gomock.Controller
 	svc                 service.Property
 	mockRepo            *mock_repository.MockProperty
+	mockNoteRepo        *mock_repository.MockNote
 	testUserID          uuid.UUID
 	testPropertyID      uuid.UUID
 	testPropertyValueID uuid.UUID
@@ -36,8 +37,10 @@ func TestPropertiesService(t *testing.T) {
 func (t *propertiesServiceTestSuite) SetupTest() {
 	t.ctrl = gomock.NewController(t.T())
 	t.mockRepo = mock_repository.NewMockProperty(t.ctrl)
+	t.mockNoteRepo = mock_repository.NewMockNote(t.ctrl)
 	t.svc = &service.PropertyImpl{
-		Repository: t.mockRepo,
+		Repository:     t.mockRepo,
+		NoteRepository: t.mockNoteRepo,
 	}
 	t.testUserID, _ = uuid.NewV7()
 	t.testPropertyID, _ = uuid.NewV7()
@@ -221,6 +224,9 @@ func (t *propertiesServiceTestSuite) TestGetByID_Exists_NoValue() {
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
 		Return(resolvedPropertySlice, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testPropertyID, false, cachetime.NCacheTime{}, cachetime.NCacheTime{}, nil)
 
 	assert.NoError(t.T(), err)
@@ -245,6 +251,9 @@ func (t *propertiesServiceTestSuite) TestGetByID_Exists_WithValue_NoFilter() {
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testPropertyID, true, cachetime.NCacheTime{}, cachetime.NCacheTime{}, nil)
 
 	assert.NoError(t.T(), err)
@@ -271,6 +280,9 @@ func (t *propertiesServiceTestSuite) TestGetByID_Exists_WithValue_WithStartDate(
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testPropertyID, true, yesterday, cachetime.NCacheTime{}, nil)
 
 	assert.NoError(t.T(), err)
@@ -297,6 +309,9 @@ func (t *propertiesServiceTestSuite) TestGetByID_Exists_WithValue_WithEndDate()
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testPropertyID, true, cachetime.NCacheTime{}, today, nil)
 
 	assert.NoError(t.T(), err)
@@ -325,6 +340,9 @@ func (t *propertiesServiceTestSuite) TestGetByID_Exists_WithValue_WithBothDates(
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testPropertyID, true, yesterday, today, nil)
 
 	assert.NoError(t.T(), err)
@@ -353,6 +371,9 @@ func (t *propertiesServiceTestSuite) TestGetByID_Exists_WithValue_WithPageSize()
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testPropertyID, true, cachetime.NCacheTime{}, cachetime.NCacheTime{}, &pageSize)
 
 	assert.NoError(t.T(), err)
@@ -575,6 +596,8 @@ func (t *propertiesServiceTestSuite) TestDelete_Normal() {
 	for _, resValue := range res.Values {
 		assert.True(t.T(), resValue.Deleted.Valid)
 		assert.True(t.T(), resValue.DeletedBy.Valid)
+		assert.True(t.T(), resValue.DeletedByCascade)
+		assert.Equal(t.T(), res.Deleted, resValue.Deleted)
 	}
 }
 
@@ -713,6 +736,135 @@ func (t *propertiesServiceTestSuite) TestDelete_RepoErrorUpdating() {
 	assert.Nil(t.T(), res)
 }
 
+func (t *propertiesServiceTestSuite) TestRestore_Normal() {
+	deletedAt := time.Now().AddDate(0, 0, -1)
+
+	// deleted together with the Property
+	cascadedValue := t.getNewPropertyValue(
+		nuuid.NUUID{},
+		nuuid.From(t.testPropertyID),
+		float64(10000),
+		time.Now().AddDate(0, 0, -2))
+	cascadedValue.Deleted = null.TimeFrom(deletedAt)
+	cascadedValue.DeletedBy = nuuid.From(t.testUserID)
+	cascadedValue.DeletedByCascade = true
+
+	// deleted on its own in the same second and by the same user as the Property
+	independentValue := t.getNewPropertyValue(
+		nuuid.NUUID{},
+		nuuid.From(t.testPropertyID),
+		float64(12000),
+		time.Now().AddDate(0, 0, -3))
+	independentValue.Deleted = null.TimeFrom(deletedAt)
+	independentValue.DeletedBy = nuuid.From(t.testUserID)
+
+	testProperty := t.getNewProperty(nuuid.From(t.testPropertyID), nil)
+	testProperty.Deleted = null.TimeFrom(deletedAt)
+	testProperty.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{testProperty}, nil)
+
+	t.mockRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).
+		Return([]model.PropertyValue{cascadedValue, independentValue}, getDefaultPageInfo(), nil)
+
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	res, err := t.svc.Restore(t.testPropertyID, t.testUserID)
+
+	assert.NoError(t.T(), err)
+
+	assert.NotNil(t.T(), res)
+	assert.False(t.T(), res.Deleted.Valid)
+	assert.False(t.T(), res.DeletedBy.Valid)
+	assert.True(t.T(), res.UpdatedBy.Valid)
+
+	assert.Len(t.T(), res.Values, 1)
+	assert.Equal(t.T(), cascadedValue.ID, res.Values[0].ID)
+	assert.False(t.T(), res.Values[0].Deleted.Valid)
+	assert.False(t.T(), res.Values[0].DeletedBy.Valid)
+	assert.False(t.T(), res.Values[0].DeletedByCascade)
+}
+
+func (t *propertiesServiceTestSuite) TestRestore_RepoErrorResolvingByIDs() {
+	errMsg := "failed resolving by IDs"
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{}, errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.testPropertyID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
+func (t *propertiesServiceTestSuite) TestRestore_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{}, nil)
+
+	res, err := t.svc.Restore(t.testPropertyID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "EntityNotFound")
+	assert.Nil(t.T(), res)
+}
+
+func (t *propertiesServiceTestSuite) TestRestore_NotDeleted() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{t.getNewProperty(nuuid.From(t.testPropertyID), nil)}, nil)
+
+	res, err := t.svc.Restore(t.testPropertyID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "restore")
+	assert.Contains(t.T(), err.Error(), "Property")
+	assert.Contains(t.T(), err.Error(), "not deleted")
+	assert.Nil(t.T(), res)
+}
+
+func (t *propertiesServiceTestSuite) TestRestore_RepoErrorResolvingValuesByFilter() {
+	errMsg := "failed resolving values"
+
+	testProperty := t.getNewProperty(nuuid.From(t.testPropertyID), nil)
+	testProperty.Deleted = null.TimeFrom(time.Now())
+	testProperty.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{testProperty}, nil)
+
+	t.mockRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).
+		Return([]model.PropertyValue{}, model.PageInfoOutput{}, errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.testPropertyID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
+func (t *propertiesServiceTestSuite) TestRestore_RepoErrorUpdating() {
+	errMsg := "failed updating"
+
+	testProperty := t.getNewProperty(nuuid.From(t.testPropertyID), nil)
+	testProperty.Deleted = null.TimeFrom(time.Now())
+	testProperty.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{testProperty}, nil)
+
+	t.mockRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).
+		Return([]model.PropertyValue{}, getDefaultPageInfo(), nil)
+
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.testPropertyID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
 func (t *propertiesServiceTestSuite) TestCreateValue_Normal_CurrentValue() {
 	testValueDate := time.Now()
 	testInput := t.getNewPropertyValueInput(
@@ -975,6 +1127,189 @@ func (t *propertiesServiceTestSuite) TestCreateValue_RepoFailedCreatingPropertyV
 	assert.Nil(t.T(), res)
 }
 
+func (t *propertiesServiceTestSuite) getNewPropertyValueImportInput(csv string, dryRun bool) model.PropertyValueImportInput {
+	return model.PropertyValueImportInput{
+		ImportInput: model.ImportInput{
+			CSV:     csv,
+			Mapping: model.ImportColumnMapping{Date: "Appraised", Amount: "Value"},
+			DryRun:  dryRun,
+		},
+		PropertyID: t.testPropertyID,
+	}
+}
+
+func (t *propertiesServiceTestSuite) TestImportValues_Normal_UpdatesCurrentValueOnce() {
+	testInput := t.getNewPropertyValueImportInput("Appraised,Value\n2024-01-31,1000.5\n2024-03-31,3000\n2024-02-29,2000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{t.getNewProperty(nuuid.From(t.testPropertyID), nil)}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastValuesByPropertyID(t.testPropertyID, 1).
+		Return(
+			[]model.PropertyValue{
+				t.getNewPropertyValue(
+					nuuid.NUUID{},
+					nuuid.From(t.testPropertyID),
+					float64(500),
+					time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))},
+			nil)
+
+	t.mockRepo.EXPECT().ImportValues(gomock.Len(3), gomock.Not(gomock.Nil())).
+		DoAndReturn(func(propertyValues []model.PropertyValue, property *model.Property) error {
+			assert.Equal(t.T(), float64(1000.5), propertyValues[0].Value)
+			assert.Equal(t.T(), t.testPropertyID, propertyValues[0].PropertyID)
+			assert.Equal(t.T(), t.testUserID, propertyValues[0].CreatedBy)
+			assert.Equal(t.T(), float64(3000), property.CurrentValue)
+			assert.Equal(t.T(), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), property.CurrentValueDate)
+			return nil
+		})
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), res.IsValid())
+	assert.Equal(t.T(), 3, res.Rows)
+	assert.Equal(t.T(), 3, res.Imported)
+}
+
+func (t *propertiesServiceTestSuite) TestImportValues_Normal_OlderThanCurrentValue() {
+	testInput := t.getNewPropertyValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{t.getNewProperty(nuuid.From(t.testPropertyID), nil)}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastValuesByPropertyID(t.testPropertyID, 1).
+		Return(
+			[]model.PropertyValue{
+				t.getNewPropertyValue(
+					nuuid.NUUID{},
+					nuuid.From(t.testPropertyID),
+					float64(9000),
+					time.Now())},
+			nil)
+
+	t.mockRepo.EXPECT().ImportValues(gomock.Len(1), nil).Return(nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), 1, res.Imported)
+}
+
+func (t *propertiesServiceTestSuite) TestImportValues_DryRun() {
+	testInput := t.getNewPropertyValueImportInput("Appraised,Value\n2024-01-31,1000\n2024-02-29,2000\n", true)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{t.getNewProperty(nuuid.From(t.testPropertyID), nil)}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), res.IsValid())
+	assert.True(t.T(), res.DryRun)
+	assert.Equal(t.T(), 2, res.Rows)
+	assert.Equal(t.T(), 0, res.Imported)
+}
+
+func (t *propertiesServiceTestSuite) TestImportValues_RowErrors() {
+	testInput := t.getNewPropertyValueImportInput(
+		"Appraised,Value\n2024-01-31,1000\n31/02/2024,abc\n2024-01-31,1500\n2024-03-31\n",
+		false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{t.getNewProperty(nuuid.From(t.testPropertyID), nil)}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), res.IsValid())
+	assert.Equal(t.T(), 4, res.Rows)
+	assert.Equal(t.T(), 0, res.Imported)
+	assert.Len(t.T(), res.Errors, 4)
+	assert.Equal(t.T(), 3, res.Errors[0].Line)
+	assert.Equal(t.T(), "Appraised", *res.Errors[0].Column)
+	assert.Equal(t.T(), "Value", *res.Errors[1].Column)
+	assert.Contains(t.T(), res.Errors[2].Message, "line 2")
+	assert.Equal(t.T(), 5, res.Errors[3].Line)
+	assert.Nil(t.T(), res.Errors[3].Column)
+}
+
+func (t *propertiesServiceTestSuite) TestImportValues_InvalidMapping() {
+	testInput := t.getNewPropertyValueImportInput("Date,Value\n2024-01-31,1000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{t.getNewProperty(nuuid.From(t.testPropertyID), nil)}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *propertiesServiceTestSuite) TestImportValues_PropertyNotFound() {
+	testInput := t.getNewPropertyValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *propertiesServiceTestSuite) TestImportValues_PropertyDeleted() {
+	testInput := t.getNewPropertyValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+	testProperty := t.getNewProperty(nuuid.From(t.testPropertyID), nil)
+	testProperty.Deleted = null.TimeFrom(time.Now())
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{testProperty}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *propertiesServiceTestSuite) TestImportValues_PropertySold() {
+	testInput := t.getNewPropertyValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+	testProperty := t.getNewProperty(nuuid.From(t.testPropertyID), nil)
+	testProperty.Status = model.PropertyStatusSold
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{testProperty}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *propertiesServiceTestSuite) TestImportValues_RepoFailedImporting() {
+	errMsg := "failed to import property values"
+	testInput := t.getNewPropertyValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testPropertyID}).
+		Return([]model.Property{t.getNewProperty(nuuid.From(t.testPropertyID), nil)}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastValuesByPropertyID(t.testPropertyID, 1).
+		Return([]model.PropertyValue{}, nil)
+
+	t.mockRepo.EXPECT().ImportValues(gomock.Len(1), gomock.Not(gomock.Nil())).
+		Return(errors.New(errMsg))
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
 func (t *propertiesServiceTestSuite) TestGetValueByID_Normal() {
 	t.mockRepo.EXPECT().ResolveValuesByIDs([]uuid.UUID{t.testPropertyValueID}).
 		Return(
@@ -987,6 +1322,9 @@ func (t *propertiesServiceTestSuite) TestGetValueByID_Normal() {
 			},
 			nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	res, err := t.svc.GetValueByID(t.testPropertyValueID)
 
 	assert.NoError(t.T(), err)
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/config"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/storage"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// PurgeImpl is the service provider implementation
+type PurgeImpl struct {
+	Repository   repository.Purge  `inject:"purgeRepository"`
+	BlobStore    storage.BlobStore `inject:"blobStore"`
+	AdminUserIDs []uuid.UUID
+	Retention    time.Duration
+	Interval     time.Duration
+	stop         chan struct{}
+	running      sync.WaitGroup
+}
+
+// Startup performs startup functions
+func (s *PurgeImpl) Startup() {
+	logger.Trace("Purge Service starting up...")
+	if s.Retention == 0 {
+		config := config.Get()
+		s.AdminUserIDs = getAdminUserIDs()
+		s.Retention = config.Purge.Retention
+		s.Interval = config.Purge.Interval
+	}
+
+	if s.Interval > 0 {
+		s.stop = make(chan struct{})
+		s.running.Add(1)
+		go s.runSchedule(s.stop)
+	}
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *PurgeImpl) Shutdown() {
+	logger.Trace("Purge Service shutting down...")
+	if s.stop != nil {
+		close(s.stop)
+		s.running.Wait()
+		s.stop = nil
+	}
+}
+
+// Purge permanently removes the records soft-deleted longer ago than the retention period.
+// Only admins may purge on demand.
+func (s *PurgeImpl) Purge(userID uuid.UUID) (*model.PurgeSummary, error) {
+	if !s.isAdmin(userID) {
+		return nil, failure.Forbidden("purge", "Deleted Records", "admin only")
+	}
+
+	return s.purge(userID)
+}
+
+func (s *PurgeImpl) purge(userID uuid.UUID) (*model.PurgeSummary, error) {
+	summary, err := s.Repository.Purge(model.NewPurgeSummary(time.Now().Add(-s.Retention), userID))
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Purged %d soft-deleted records deleted before %v", summary.Total(), summary.Cutoff)
+
+	// the records are gone for good at this point, so content that cannot be removed is only logged
+	for _, attachmentID := range summary.AttachmentIDs {
+		if err := s.BlobStore.Delete(attachmentID.String()); err != nil {
+			logger.ErrNoStack("Failed removing content of purged attachment %s: %v", attachmentID, err)
+		}
+	}
+
+	return &summary, nil
+}
+
+func (s *PurgeImpl) isAdmin(userID uuid.UUID) bool {
+	return slices.Contains(s.AdminUserIDs, userID)
+}
+
+// getAdminUserIDs reads the IDs of the admin users from the configuration
+func getAdminUserIDs() (adminUserIDs []uuid.UUID) {
+	for _, id := range config.Get().Admin.UserIDs {
+		adminUserID, err := uuid.Parse(id)
+		if err != nil {
+			logger.Fatal("Invalid admin user ID %s: %v", id, err)
+		}
+		adminUserIDs = append(adminUserIDs, adminUserID)
+	}
+	return
+}
+
+// runSchedule purges on every interval until stopped, on behalf of the system
+func (s *PurgeImpl) runSchedule(stop chan struct{}) {
+	defer s.running.Done()
+
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.purge(uuid.Nil); err != nil {
+				logger.ErrNoStack("Scheduled purge failed: %v", err)
+			}
+		case <-stop:
+			return
+		}
+	}
+}
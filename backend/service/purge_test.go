@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/storage"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type purgeServiceTestSuite struct {
+	suite.Suite
+	ctrl        *gomock.Controller
+	svc         service.Purge
+	mockRepo    *mock_repository.MockPurge
+	blobStore   *storage.LocalBlobStore
+	testAdminID uuid.UUID
+	testUserID  uuid.UUID
+	retention   time.Duration
+}
+
+func TestPurgeService(t *testing.T) {
+	suite.Run(t, new(purgeServiceTestSuite))
+}
+
+func (t *purgeServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockPurge(t.ctrl)
+	t.testAdminID, _ = uuid.NewV7()
+	t.testUserID, _ = uuid.NewV7()
+	t.retention = 90 * 24 * time.Hour
+	t.blobStore = &storage.LocalBlobStore{Root: t.T().TempDir()}
+	t.blobStore.Startup()
+	t.svc = &service.PurgeImpl{
+		Repository:   t.mockRepo,
+		BlobStore:    t.blobStore,
+		AdminUserIDs: []uuid.UUID{t.testAdminID},
+		Retention:    t.retention,
+	}
+	t.svc.Startup()
+}
+
+func (t *purgeServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *purgeServiceTestSuite) TestPurge_Normal() {
+	t.mockRepo.EXPECT().Purge(gomock.Any()).
+		DoAndReturn(func(summary model.PurgeSummary) (model.PurgeSummary, error) {
+			summary.BankAccounts = 1
+			summary.BankAccountBalances = 4
+			return summary, nil
+		})
+
+	res, err := t.svc.Purge(t.testAdminID)
+
+	assert.NoError(t.T(), err)
+	assert.NotNil(t.T(), res)
+	assert.Equal(t.T(), t.testAdminID, res.PurgedBy)
+	assert.Equal(t.T(), int64(5), res.Total())
+	assert.WithinDuration(t.T(), time.Now().Add(-t.retention), res.Cutoff, time.Minute)
+}
+
+func (t *purgeServiceTestSuite) TestPurge_RemovesAttachmentContent() {
+	attachmentID, _ := uuid.NewV7()
+	err := t.blobStore.Put(attachmentID.String(), strings.NewReader("%PDF-1.4"))
+	assert.NoError(t.T(), err)
+
+	t.mockRepo.EXPECT().Purge(gomock.Any()).
+		DoAndReturn(func(summary model.PurgeSummary) (model.PurgeSummary, error) {
+			summary.Attachments = 1
+			summary.AttachmentIDs = []uuid.UUID{attachmentID}
+			return summary, nil
+		})
+
+	res, err := t.svc.Purge(t.testAdminID)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), int64(1), res.Total())
+
+	_, err = t.blobStore.Get(attachmentID.String())
+	assert.Equal(t.T(), storage.ErrBlobNotFound, err)
+}
+
+func (t *purgeServiceTestSuite) TestPurge_NotAdmin() {
+	res, err := t.svc.Purge(t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Nil(t.T(), res)
+	assert.Equal(t.T(), failure.CodeForbidden, err.(*failure.Failure).Code)
+}
+
+func (t *purgeServiceTestSuite) TestPurge_RepoError() {
+	errMsg := "failed purging"
+
+	t.mockRepo.EXPECT().Purge(gomock.Any()).
+		Return(model.PurgeSummary{}, errors.New(errMsg))
+
+	res, err := t.svc.Purge(t.testAdminID)
+
+	assert.Error(t.T(), err)
+	assert.Nil(t.T(), res)
+	assert.Contains(t.T(), err.Error(), errMsg)
+}
+
+func (t *purgeServiceTestSuite) TestSchedule_PurgesAsSystem() {
+	purged := make(chan model.PurgeSummary, 1)
+	t.mockRepo.EXPECT().Purge(gomock.Any()).
+		DoAndReturn(func(summary model.PurgeSummary) (model.PurgeSummary, error) {
+			select {
+			case purged <- summary:
+			default:
+			}
+			return summary, nil
+		}).
+		MinTimes(1)
+
+	svc := &service.PurgeImpl{
+		Repository:   t.mockRepo,
+		AdminUserIDs: []uuid.UUID{t.testAdminID},
+		Retention:    t.retention,
+		Interval:     10 * time.Millisecond,
+	}
+	svc.Startup()
+
+	select {
+	case summary := <-purged:
+		assert.Equal(t.T(), uuid.Nil, summary.PurgedBy)
+	case <-time.After(time.Second):
+		t.T().Fatal("scheduled purge did not run")
+	}
+
+	svc.Shutdown()
+}
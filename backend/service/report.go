@@ -0,0 +1,237 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// ReportImpl is the service provider implementation
+type ReportImpl struct {
+	AuditLogRepository    repository.AuditLog    `inject:"auditLogRepository"`
+	BankAccountRepository repository.BankAccount `inject:"bankAccountRepository"`
+	VehicleRepository     repository.Vehicle     `inject:"vehicleRepository"`
+	PropertyRepository    repository.Property    `inject:"propertyRepository"`
+}
+
+// Startup performs startup functions
+func (s *ReportImpl) Startup() {
+	logger.Trace("Report Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *ReportImpl) Shutdown() {
+	logger.Trace("Report Service shutting down...")
+}
+
+// GetNetWorth resolves every asset that is not deleted, along with its balance or value history
+func (s *ReportImpl) GetNetWorth() (*model.NetWorthReport, error) {
+	return s.resolveAssets(false, cachetime.NCacheTime{})
+}
+
+// GetNetWorthStatement values every asset held as of a date from its balance or value history. Assets
+// deleted since are still resolved, as they were held at the time, and whether they were sold by then is
+// taken from the audit trail.
+func (s *ReportImpl) GetNetWorthStatement(asOf time.Time) (*model.NetWorthStatement, error) {
+	report, err := s.resolveAssets(true, cachetime.NCacheTime(null.TimeFrom(asOf)))
+	if err != nil {
+		return nil, err
+	}
+
+	page := 1
+	pageSize := math.MaxInt
+
+	auditLogFilter := model.AuditLogFilterInput{
+		EntityTypes: &[]model.EntityType{model.EntityTypeVehicle, model.EntityTypeProperty},
+		Actions:     &[]model.AuditAction{model.AuditActionCreate, model.AuditActionUpdate, model.AuditActionRestore},
+		EndDate:     cachetime.NCacheTime(null.TimeFrom(asOf)),
+	}
+	auditLogFilter.Page = &page
+	auditLogFilter.PageSize = &pageSize
+
+	auditLogs, _, err := s.AuditLogRepository.ResolveByFilter(auditLogFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	statement := model.NewNetWorthStatement(*report, asOf, auditLogs)
+	return &statement, nil
+}
+
+// GetAssetChange compares the value of every asset between two dates, flagging the assets created, sold
+// or deleted in between. Sales are taken from the audit trail, as assets only record their current status.
+func (s *ReportImpl) GetAssetChange(from, to time.Time) (*model.AssetChangeReport, error) {
+	if !from.Before(to) {
+		return nil, failure.BadRequestFromString("the start date must be before the end date")
+	}
+
+	report, err := s.resolveAssets(true, cachetime.NCacheTime(null.TimeFrom(to)))
+	if err != nil {
+		return nil, err
+	}
+
+	page := 1
+	pageSize := math.MaxInt
+
+	auditLogFilter := model.AuditLogFilterInput{
+		EntityTypes: &[]model.EntityType{model.EntityTypeVehicle, model.EntityTypeProperty},
+		Actions:     &[]model.AuditAction{model.AuditActionUpdate},
+		StartDate:   cachetime.NCacheTime(null.TimeFrom(from)),
+		EndDate:     cachetime.NCacheTime(null.TimeFrom(to)),
+	}
+	auditLogFilter.Page = &page
+	auditLogFilter.PageSize = &pageSize
+
+	auditLogs, _, err := s.AuditLogRepository.ResolveByFilter(auditLogFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	soldAssetIDs := make([]uuid.UUID, 0)
+	for _, auditLog := range auditLogs {
+		if model.IsSaleAuditLog(auditLog) {
+			soldAssetIDs = append(soldAssetIDs, auditLog.SubjectID)
+		}
+	}
+
+	changeReport := model.NewAssetChangeReport(*report, from, to, soldAssetIDs)
+	return &changeReport, nil
+}
+
+// resolveAssets resolves the assets of every class, along with their balances or values up to an optional end date.
+// When deleted assets are included, so are deleted balances and values, as those deleted along with an asset
+// still make up its history.
+func (s *ReportImpl) resolveAssets(includeDeleted bool, endDate cachetime.NCacheTime) (*model.NetWorthReport, error) {
+	bankAccounts, err := s.resolveBankAccounts(includeDeleted, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles, err := s.resolveVehicles(includeDeleted, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := s.resolveProperties(includeDeleted, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report := model.NewNetWorthReport(bankAccounts, vehicles, properties)
+	return &report, nil
+}
+
+func (s *ReportImpl) resolveBankAccounts(includeDeleted bool, endDate cachetime.NCacheTime) ([]model.BankAccount, error) {
+	page := 1
+	pageSize := math.MaxInt
+
+	bankAccountFilter := model.BankAccountFilterInput{}
+	bankAccountFilter.Page = &page
+	bankAccountFilter.PageSize = &pageSize
+	bankAccountFilter.IncludeDeleted = &includeDeleted
+
+	bankAccounts, _, err := s.BankAccountRepository.ResolveByFilter(bankAccountFilter.ToFilter())
+	if err != nil || len(bankAccounts) == 0 {
+		return bankAccounts, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(bankAccounts))
+	for _, bankAccount := range bankAccounts {
+		ids = append(ids, bankAccount.ID)
+	}
+
+	balanceFilter := model.BankAccountBalanceFilterInput{BankAccountIDs: &ids, EndDate: endDate}
+	balanceFilter.Page = &page
+	balanceFilter.PageSize = &pageSize
+	balanceFilter.IncludeDeleted = &includeDeleted
+
+	balances, _, err := s.BankAccountRepository.ResolveBalancesByFilter(balanceFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range bankAccounts {
+		bankAccounts[idx].AttachBalances(balances, true)
+	}
+
+	return bankAccounts, nil
+}
+
+func (s *ReportImpl) resolveVehicles(includeDeleted bool, endDate cachetime.NCacheTime) ([]model.Vehicle, error) {
+	page := 1
+	pageSize := math.MaxInt
+
+	vehicleFilter := model.VehicleFilterInput{}
+	vehicleFilter.Page = &page
+	vehicleFilter.PageSize = &pageSize
+	vehicleFilter.IncludeDeleted = &includeDeleted
+
+	vehicles, _, err := s.VehicleRepository.ResolveByFilter(vehicleFilter.ToFilter())
+	if err != nil || len(vehicles) == 0 {
+		return vehicles, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		ids = append(ids, vehicle.ID)
+	}
+
+	valueFilter := model.VehicleValueFilterInput{VehicleIDs: &ids, EndDate: endDate}
+	valueFilter.Page = &page
+	valueFilter.PageSize = &pageSize
+	valueFilter.IncludeDeleted = &includeDeleted
+
+	values, _, err := s.VehicleRepository.ResolveValuesByFilter(valueFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range vehicles {
+		vehicles[idx].AttachValues(values, true)
+	}
+
+	return vehicles, nil
+}
+
+func (s *ReportImpl) resolveProperties(includeDeleted bool, endDate cachetime.NCacheTime) ([]model.Property, error) {
+	page := 1
+	pageSize := math.MaxInt
+
+	propertyFilter := model.PropertyFilterInput{}
+	propertyFilter.Page = &page
+	propertyFilter.PageSize = &pageSize
+	propertyFilter.IncludeDeleted = &includeDeleted
+
+	properties, _, err := s.PropertyRepository.ResolveByFilter(propertyFilter.ToFilter())
+	if err != nil || len(properties) == 0 {
+		return properties, err
+	}
+
+	ids := make([]uuid.UUID, 0, len(properties))
+	for _, property := range properties {
+		ids = append(ids, property.ID)
+	}
+
+	valueFilter := model.PropertyValueFilterInput{PropertyIDs: &ids, EndDate: endDate}
+	valueFilter.Page = &page
+	valueFilter.PageSize = &pageSize
+	valueFilter.IncludeDeleted = &includeDeleted
+
+	values, _, err := s.PropertyRepository.ResolveValuesByFilter(valueFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range properties {
+		properties[idx].AttachValues(values, true)
+	}
+
+	return properties, nil
+}
@@ -0,0 +1,448 @@
+package service_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type reportServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.Report
+	mockAuditLogRepo    *mock_repository.MockAuditLog
+	mockBankAccountRepo *mock_repository.MockBankAccount
+	mockVehicleRepo     *mock_repository.MockVehicle
+	mockPropertyRepo    *mock_repository.MockProperty
+	testBankAccountID   uuid.UUID
+	testVehicleID       uuid.UUID
+	testPropertyID      uuid.UUID
+}
+
+func TestReportService(t *testing.T) {
+	suite.Run(t, new(reportServiceTestSuite))
+}
+
+func (t *reportServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockAuditLogRepo = mock_repository.NewMockAuditLog(t.ctrl)
+	t.mockBankAccountRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.mockVehicleRepo = mock_repository.NewMockVehicle(t.ctrl)
+	t.mockPropertyRepo = mock_repository.NewMockProperty(t.ctrl)
+	t.svc = &service.ReportImpl{
+		AuditLogRepository:    t.mockAuditLogRepo,
+		BankAccountRepository: t.mockBankAccountRepo,
+		VehicleRepository:     t.mockVehicleRepo,
+		PropertyRepository:    t.mockPropertyRepo,
+	}
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.testVehicleID, _ = uuid.NewV7()
+	t.testPropertyID, _ = uuid.NewV7()
+	t.svc.Startup()
+}
+
+func (t *reportServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *reportServiceTestSuite) expectAssets(vehicleStatus model.VehicleStatus) {
+	jan := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.BankAccount{
+		{ID: t.testBankAccountID, AccountName: "Savings", LastBalance: 1500, LastBalanceDate: feb},
+	}, model.PageInfoOutput{}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return([]model.BankAccountBalance{
+		{BankAccountID: t.testBankAccountID, Date: jan, Balance: 1000},
+		{BankAccountID: t.testBankAccountID, Date: feb, Balance: 1500},
+	}, model.PageInfoOutput{}, nil)
+
+	t.mockVehicleRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Vehicle{
+		{ID: t.testVehicleID, Name: "Car", CurrentValue: 8000, CurrentValueDate: jan, Status: vehicleStatus},
+	}, model.PageInfoOutput{}, nil)
+	t.mockVehicleRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).Return([]model.VehicleValue{
+		{VehicleID: t.testVehicleID, Date: jan, Value: 8000},
+	}, model.PageInfoOutput{}, nil)
+
+	t.mockPropertyRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Property{
+		{ID: t.testPropertyID, Name: "House", CurrentValue: 250000, CurrentValueDate: jan, Status: model.PropertyStatusInUse},
+	}, model.PageInfoOutput{}, nil)
+	t.mockPropertyRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).Return([]model.PropertyValue{
+		{PropertyID: t.testPropertyID, Date: jan, Value: 250000},
+	}, model.PageInfoOutput{}, nil)
+}
+
+func (t *reportServiceTestSuite) TestGetNetWorth_Normal() {
+	t.expectAssets(model.VehicleStatusInUse)
+
+	report, err := t.svc.GetNetWorth()
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), report)
+	assert.Len(t.T(), report.BankAccounts[0].Balances, 2)
+	assert.Len(t.T(), report.Vehicles[0].Values, 1)
+	assert.Len(t.T(), report.Properties[0].Values, 1)
+	assert.Equal(t.T(), float64(259500), report.NetWorth())
+
+	history := report.History()
+	assert.Len(t.T(), history, 4)
+	assert.Equal(t.T(), "Car", history[0].AssetName)
+	assert.Equal(t.T(), "House", history[1].AssetName)
+	assert.Equal(t.T(), "Savings", history[2].AssetName)
+	assert.Equal(t.T(), float64(1500), history[3].Value)
+
+	buf := new(bytes.Buffer)
+	err = report.ToWorkbook().Write(buf)
+	assert.Nil(t.T(), err)
+	assert.True(t.T(), bytes.HasPrefix(buf.Bytes(), []byte("PK\x03\x04")))
+}
+
+func (t *reportServiceTestSuite) TestGetNetWorth_ExcludesSoldAssetsFromTotals() {
+	t.expectAssets(model.VehicleStatusSold)
+
+	report, err := t.svc.GetNetWorth()
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), report.Vehicles, 1)
+	assert.Equal(t.T(), float64(251500), report.NetWorth())
+
+	totals := report.Totals()
+	assert.Equal(t.T(), model.EntityTypeVehicle, totals[1].AssetClass)
+	assert.Equal(t.T(), 0, totals[1].Count)
+	assert.Equal(t.T(), float64(0), totals[1].Total)
+}
+
+func (t *reportServiceTestSuite) TestGetNetWorth_Empty() {
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.BankAccount{}, model.PageInfoOutput{}, nil)
+	t.mockVehicleRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Vehicle{}, model.PageInfoOutput{}, nil)
+	t.mockPropertyRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Property{}, model.PageInfoOutput{}, nil)
+
+	report, err := t.svc.GetNetWorth()
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), float64(0), report.NetWorth())
+	assert.Empty(t.T(), report.History())
+}
+
+func (t *reportServiceTestSuite) TestGetNetWorth_ErrorResolvingValues() {
+	errMsg := "failed resolving values"
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.BankAccount{}, model.PageInfoOutput{}, nil)
+	t.mockVehicleRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Vehicle{{ID: t.testVehicleID}}, model.PageInfoOutput{}, nil)
+	t.mockVehicleRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).Return(nil, model.PageInfoOutput{}, errors.New(errMsg))
+
+	report, err := t.svc.GetNetWorth()
+
+	assert.Nil(t.T(), report)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), errMsg, err.Error())
+}
+
+func (t *reportServiceTestSuite) TestGetNetWorthStatement_Normal() {
+	jan := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2024, 2, 15, 23, 59, 59, 0, time.UTC)
+	deletedValueID, _ := uuid.NewV7()
+	deletedVehicleID, _ := uuid.NewV7()
+
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.BankAccount{
+		{ID: t.testBankAccountID, AccountName: "Savings", LastBalance: 1500, Status: model.BankAccountStatusActive},
+	}, model.PageInfoOutput{}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return([]model.BankAccountBalance{
+		{BankAccountID: t.testBankAccountID, Date: jan, Balance: 1000},
+		{BankAccountID: t.testBankAccountID, Date: feb, Balance: 1500},
+	}, model.PageInfoOutput{}, nil)
+
+	// the first vehicle was deleted after the statement date and still counts, the second one before it
+	t.mockVehicleRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Vehicle{
+		{ID: t.testVehicleID, Name: "Car", Status: model.VehicleStatusInUse, Deleted: null.TimeFrom(feb)},
+		{ID: deletedVehicleID, Name: "Old Car", Status: model.VehicleStatusInUse, Deleted: null.TimeFrom(jan)},
+	}, model.PageInfoOutput{}, nil)
+	t.mockVehicleRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).Return([]model.VehicleValue{
+		{VehicleID: t.testVehicleID, Date: jan, Value: 8000},
+		{ID: deletedValueID, VehicleID: t.testVehicleID, Date: jan.AddDate(0, 0, 1), Value: 1, Deleted: null.TimeFrom(jan)},
+		{VehicleID: deletedVehicleID, Date: jan, Value: 3000},
+	}, model.PageInfoOutput{}, nil)
+
+	t.mockPropertyRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Property{
+		{ID: t.testPropertyID, Name: "House", Status: model.PropertyStatusSold},
+	}, model.PageInfoOutput{}, nil)
+	t.mockPropertyRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).Return([]model.PropertyValue{
+		{PropertyID: t.testPropertyID, Date: jan, Value: 250000},
+	}, model.PageInfoOutput{}, nil)
+
+	// without any audit log, the house keeps its current sold status
+	t.mockAuditLogRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.AuditLog{}, model.PageInfoOutput{}, nil)
+
+	statement, err := t.svc.GetNetWorthStatement(asOf)
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), statement.Assets, 3)
+	assert.Equal(t.T(), float64(1000), statement.Assets[0].Value)
+	assert.Equal(t.T(), jan, statement.Assets[0].ValueDate)
+	assert.Equal(t.T(), float64(8000), statement.Assets[1].Value)
+	assert.False(t.T(), statement.Assets[2].Counted)
+	assert.Equal(t.T(), float64(9000), statement.NetWorth())
+
+	buf := new(bytes.Buffer)
+	err = statement.ToPDF().Write(buf)
+	assert.Nil(t.T(), err)
+	assert.True(t.T(), bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
+
+func (t *reportServiceTestSuite) TestGetNetWorthStatement_SoldAfterAsOf() {
+	jan := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2024, 2, 15, 23, 59, 59, 0, time.UTC)
+
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.BankAccount{}, model.PageInfoOutput{}, nil)
+
+	// both assets are sold today, but the car was only sold after the statement date
+	t.mockVehicleRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Vehicle{
+		{ID: t.testVehicleID, Name: "Car", Status: model.VehicleStatusSold},
+	}, model.PageInfoOutput{}, nil)
+	t.mockVehicleRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).Return([]model.VehicleValue{
+		{VehicleID: t.testVehicleID, Date: jan, Value: 8000},
+	}, model.PageInfoOutput{}, nil)
+
+	t.mockPropertyRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Property{
+		{ID: t.testPropertyID, Name: "House", Status: model.PropertyStatusSold},
+	}, model.PageInfoOutput{}, nil)
+	t.mockPropertyRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).Return([]model.PropertyValue{
+		{PropertyID: t.testPropertyID, Date: jan, Value: 250000},
+	}, model.PageInfoOutput{}, nil)
+
+	t.mockAuditLogRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.AuditLog{
+		{
+			EntityType:    model.EntityTypeVehicle,
+			SubjectID:     t.testVehicleID,
+			Action:        model.AuditActionCreate,
+			SnapshotAfter: null.StringFrom(`{"status":"in_use"}`),
+			Created:       jan,
+		},
+		{
+			EntityType:     model.EntityTypeVehicle,
+			SubjectID:      t.testVehicleID,
+			Action:         model.AuditActionUpdate,
+			SnapshotBefore: null.StringFrom(`{"status":"in_use"}`),
+			SnapshotAfter:  null.StringFrom(`{"status":"sold"}`),
+			Created:        feb,
+		},
+		{
+			EntityType:    model.EntityTypeProperty,
+			SubjectID:     t.testPropertyID,
+			Action:        model.AuditActionCreate,
+			SnapshotAfter: null.StringFrom(`{"status":"in_use"}`),
+			Created:       jan,
+		},
+		{
+			EntityType:     model.EntityTypeProperty,
+			SubjectID:      t.testPropertyID,
+			Action:         model.AuditActionUpdate,
+			SnapshotBefore: null.StringFrom(`{"status":"in_use"}`),
+			SnapshotAfter:  null.StringFrom(`{"status":"sold"}`),
+			Created:        jan.AddDate(0, 0, 1),
+		},
+	}, model.PageInfoOutput{}, nil)
+
+	statement, err := t.svc.GetNetWorthStatement(asOf)
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), statement.Assets, 2)
+	assert.Equal(t.T(), t.testVehicleID, statement.Assets[0].AssetID)
+	assert.True(t.T(), statement.Assets[0].Counted)
+	assert.Equal(t.T(), t.testPropertyID, statement.Assets[1].AssetID)
+	assert.False(t.T(), statement.Assets[1].Counted)
+	assert.Equal(t.T(), float64(8000), statement.NetWorth())
+}
+
+func (t *reportServiceTestSuite) TestGetNetWorthStatement_DeletedAfterAsOf() {
+	jan := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2024, 2, 15, 23, 59, 59, 0, time.UTC)
+
+	// the account was deleted after the statement date, taking its balances with it
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.BankAccount{
+		{ID: t.testBankAccountID, AccountName: "Savings", Status: model.BankAccountStatusActive, Deleted: null.TimeFrom(feb)},
+	}, model.PageInfoOutput{}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
+		DoAndReturn(func(f filter.Filter) ([]model.BankAccountBalance, model.PageInfoOutput, error) {
+			assert.True(t.T(), f.IncludeDeleted)
+			return []model.BankAccountBalance{
+				{BankAccountID: t.testBankAccountID, Date: jan, Balance: 1000, Deleted: null.TimeFrom(feb)},
+			}, model.PageInfoOutput{}, nil
+		})
+
+	t.mockVehicleRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Vehicle{}, model.PageInfoOutput{}, nil)
+	t.mockPropertyRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Property{}, model.PageInfoOutput{}, nil)
+	t.mockAuditLogRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.AuditLog{}, model.PageInfoOutput{}, nil)
+
+	statement, err := t.svc.GetNetWorthStatement(asOf)
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), statement.Assets, 1)
+	assert.Equal(t.T(), float64(1000), statement.Assets[0].Value)
+	assert.Equal(t.T(), jan, statement.Assets[0].ValueDate)
+	assert.Equal(t.T(), float64(1000), statement.NetWorth())
+}
+
+func (t *reportServiceTestSuite) TestGetNetWorthStatement_Error() {
+	errMsg := "failed resolving bank accounts"
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return(nil, model.PageInfoOutput{}, errors.New(errMsg))
+
+	statement, err := t.svc.GetNetWorthStatement(time.Now())
+
+	assert.Nil(t.T(), statement)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), errMsg, err.Error())
+}
+
+func (t *reportServiceTestSuite) TestGetAssetChange_Normal() {
+	jan := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	from := jan.Add(24*time.Hour - time.Nanosecond)
+	to := feb.Add(24*time.Hour - time.Nanosecond)
+	newVehicleID, _ := uuid.NewV7()
+
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.BankAccount{
+		{ID: t.testBankAccountID, AccountName: "Savings", Created: jan.AddDate(-1, 0, 0)},
+	}, model.PageInfoOutput{}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return([]model.BankAccountBalance{
+		{BankAccountID: t.testBankAccountID, Date: jan.AddDate(0, 0, -10), Balance: 1000},
+		{BankAccountID: t.testBankAccountID, Date: feb.AddDate(0, 0, -1), Balance: 1500},
+	}, model.PageInfoOutput{}, nil)
+
+	t.mockVehicleRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Vehicle{
+		{ID: t.testVehicleID, Name: "Car", Status: model.VehicleStatusSold, Created: jan.AddDate(-1, 0, 0)},
+		{ID: newVehicleID, Name: "New Car", Status: model.VehicleStatusInUse, Created: feb},
+	}, model.PageInfoOutput{}, nil)
+	t.mockVehicleRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).Return([]model.VehicleValue{
+		{VehicleID: t.testVehicleID, Date: jan, Value: 8000},
+		{VehicleID: t.testVehicleID, Date: feb, Value: 7000},
+		{VehicleID: newVehicleID, Date: feb, Value: 20000},
+	}, model.PageInfoOutput{}, nil)
+
+	t.mockPropertyRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Property{
+		{ID: t.testPropertyID, Name: "House", Status: model.PropertyStatusInUse, Created: jan.AddDate(-1, 0, 0), Deleted: null.TimeFrom(feb)},
+	}, model.PageInfoOutput{}, nil)
+	t.mockPropertyRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).Return([]model.PropertyValue{
+		{PropertyID: t.testPropertyID, Date: jan, Value: 250000},
+	}, model.PageInfoOutput{}, nil)
+
+	t.mockAuditLogRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.AuditLog{
+		{
+			EntityType:     model.EntityTypeVehicle,
+			SubjectID:      t.testVehicleID,
+			Action:         model.AuditActionUpdate,
+			SnapshotBefore: null.StringFrom(`{"status":"in_use"}`),
+			SnapshotAfter:  null.StringFrom(`{"status":"sold"}`),
+		},
+		{
+			EntityType:     model.EntityTypeProperty,
+			SubjectID:      t.testPropertyID,
+			Action:         model.AuditActionUpdate,
+			SnapshotBefore: null.StringFrom(`{"status":"in_use"}`),
+			SnapshotAfter:  null.StringFrom(`{"status":"rented"}`),
+		},
+	}, model.PageInfoOutput{}, nil)
+
+	report, err := t.svc.GetAssetChange(from, to)
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), report.Assets, 4)
+
+	savings := report.Assets[0]
+	assert.Equal(t.T(), null.FloatFrom(1000), savings.FromValue)
+	assert.Equal(t.T(), null.FloatFrom(1500), savings.ToValue)
+	assert.Equal(t.T(), float64(500), savings.Change)
+	assert.Equal(t.T(), null.FloatFrom(50), savings.ChangePercent)
+	assert.False(t.T(), savings.Created || savings.Sold || savings.Deleted)
+
+	car := report.Assets[1]
+	assert.Equal(t.T(), float64(-1000), car.Change)
+	assert.True(t.T(), car.Sold)
+
+	newCar := report.Assets[2]
+	assert.False(t.T(), newCar.FromValue.Valid)
+	assert.Equal(t.T(), float64(20000), newCar.Change)
+	assert.False(t.T(), newCar.ChangePercent.Valid)
+	assert.True(t.T(), newCar.Created)
+
+	house := report.Assets[3]
+	assert.Equal(t.T(), null.FloatFrom(250000), house.FromValue)
+	assert.False(t.T(), house.ToValue.Valid)
+	assert.Equal(t.T(), float64(-250000), house.Change)
+	assert.Equal(t.T(), null.FloatFrom(-100), house.ChangePercent)
+	assert.True(t.T(), house.Deleted)
+	assert.False(t.T(), house.Sold)
+}
+
+func (t *reportServiceTestSuite) TestGetAssetChange_DeletedInPeriod() {
+	jan := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	from := jan.Add(24*time.Hour - time.Nanosecond)
+	to := feb.Add(24*time.Hour - time.Nanosecond)
+
+	// the account was deleted within the period, taking its balances with it
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.BankAccount{
+		{ID: t.testBankAccountID, AccountName: "Savings", Created: jan.AddDate(-1, 0, 0), Deleted: null.TimeFrom(feb)},
+	}, model.PageInfoOutput{}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).
+		DoAndReturn(func(f filter.Filter) ([]model.BankAccountBalance, model.PageInfoOutput, error) {
+			assert.True(t.T(), f.IncludeDeleted)
+			return []model.BankAccountBalance{
+				{BankAccountID: t.testBankAccountID, Date: jan.AddDate(0, 0, -10), Balance: 1000, Deleted: null.TimeFrom(feb)},
+			}, model.PageInfoOutput{}, nil
+		})
+
+	t.mockVehicleRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Vehicle{}, model.PageInfoOutput{}, nil)
+	t.mockPropertyRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Property{}, model.PageInfoOutput{}, nil)
+	t.mockAuditLogRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.AuditLog{}, model.PageInfoOutput{}, nil)
+
+	report, err := t.svc.GetAssetChange(from, to)
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), report.Assets, 1)
+
+	savings := report.Assets[0]
+	assert.Equal(t.T(), null.FloatFrom(1000), savings.FromValue)
+	assert.False(t.T(), savings.ToValue.Valid)
+	assert.Equal(t.T(), float64(-1000), savings.Change)
+	assert.True(t.T(), savings.Deleted)
+}
+
+func (t *reportServiceTestSuite) TestGetAssetChange_InvalidPeriod() {
+	now := time.Now()
+
+	report, err := t.svc.GetAssetChange(now, now.AddDate(0, -1, 0))
+
+	assert.Nil(t.T(), report)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *reportServiceTestSuite) TestGetAssetChange_ErrorResolvingAuditLogs() {
+	errMsg := "failed resolving audit logs"
+	t.mockBankAccountRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.BankAccount{}, model.PageInfoOutput{}, nil)
+	t.mockVehicleRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Vehicle{}, model.PageInfoOutput{}, nil)
+	t.mockPropertyRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Property{}, model.PageInfoOutput{}, nil)
+	t.mockAuditLogRepo.EXPECT().ResolveByFilter(gomock.Any()).Return(nil, model.PageInfoOutput{}, errors.New(errMsg))
+
+	report, err := t.svc.GetAssetChange(time.Now().AddDate(0, -1, 0), time.Now())
+
+	assert.Nil(t.T(), report)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), errMsg, err.Error())
+}
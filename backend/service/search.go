@@ -0,0 +1,32 @@
+package service
+
+import (
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// SearchImpl is the service provider implementation
+type SearchImpl struct {
+	Repository repository.Search `inject:"searchRepository"`
+}
+
+// Startup performs startup functions
+func (s *SearchImpl) Startup() {
+	logger.Trace("Search Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *SearchImpl) Shutdown() {
+	logger.Trace("Search Service shutting down...")
+}
+
+// Search runs a full-text search across assets, returning the matches ranked by relevance
+func (s *SearchImpl) Search(input model.SearchInput) ([]model.SearchResult, error) {
+	search, err := input.ToSearch()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Repository.Search(search)
+}
@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type searchServiceTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	svc      service.Search
+	mockRepo *mock_repository.MockSearch
+}
+
+func TestSearchService(t *testing.T) {
+	suite.Run(t, new(searchServiceTestSuite))
+}
+
+func (t *searchServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockSearch(t.ctrl)
+	t.svc = &service.SearchImpl{
+		Repository: t.mockRepo,
+	}
+	t.svc.Startup()
+}
+
+func (t *searchServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *searchServiceTestSuite) TestSearch_Normal() {
+	id, _ := uuid.NewV7()
+	limit := 10
+	input := model.SearchInput{
+		Keyword:     "  acme+ (bank)* ",
+		EntityTypes: &[]model.EntityType{model.EntityTypeProperty, model.EntityTypeBankAccount, model.EntityTypeProperty},
+		Limit:       &limit,
+	}
+
+	t.mockRepo.EXPECT().Search(model.Search{
+		Terms:       "+acme* +bank*",
+		EntityTypes: []model.EntityType{model.EntityTypeProperty, model.EntityTypeBankAccount},
+		Limit:       10,
+	}).Return([]model.SearchResult{{EntityType: model.EntityTypeBankAccount, ID: id, Relevance: 1}}, nil)
+
+	res, err := t.svc.Search(input)
+
+	assert.NoError(t.T(), err)
+	assert.Len(t.T(), res, 1)
+	assert.Equal(t.T(), id, res[0].ID)
+}
+
+func (t *searchServiceTestSuite) TestSearch_Defaults() {
+	t.mockRepo.EXPECT().Search(model.Search{
+		Terms:       "+acme*",
+		EntityTypes: model.SearchEntityTypes,
+		Limit:       model.SearchDefaultLimit,
+	}).Return([]model.SearchResult{}, nil)
+
+	res, err := t.svc.Search(model.SearchInput{Keyword: "acme"})
+
+	assert.NoError(t.T(), err)
+	assert.Empty(t.T(), res)
+}
+
+func (t *searchServiceTestSuite) TestSearch_InvalidInput() {
+	tooMany := model.SearchMaxLimit + 1
+	inputs := []model.SearchInput{
+		{Keyword: " +-*() "},
+		{Keyword: "acme", EntityTypes: &[]model.EntityType{model.EntityTypeUser}},
+		{Keyword: "acme", Limit: &tooMany},
+	}
+
+	for _, input := range inputs {
+		res, err := t.svc.Search(input)
+
+		assert.Nil(t.T(), res)
+		assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	}
+}
+
+func (t *searchServiceTestSuite) TestSearch_RepoError() {
+	t.mockRepo.EXPECT().Search(gomock.Any()).Return(nil, errors.New(""))
+
+	res, err := t.svc.Search(model.SearchInput{Keyword: "acme"})
+
+	assert.Error(t.T(), err)
+	assert.Nil(t.T(), res)
+}
@@ -1,11 +1,13 @@
 package service
 
 import (
+	"io"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/kerti/balances/backend/model"
 	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/jwtkeys"
 )
 
 // Auth is the service provider interface
@@ -14,6 +16,8 @@ type Auth interface {
 	Shutdown()
 	Authenticate(basic string) (authInfo *model.AuthenticationInfo, err error)
 	Authorize(bearer string) (userID *uuid.UUID, err error)
+	AuthorizeAPIKey(key string, scope model.APIKeyScope) (userID *uuid.UUID, err error)
+	GetJWKS() jwtkeys.JSONWebKeySet
 	GetToken(user model.User) (token *string, expiration *time.Time, err error)
 }
 
@@ -24,13 +28,138 @@ type BankAccount interface {
 	Create(input model.BankAccountInput, userID uuid.UUID) (*model.BankAccount, error)
 	GetByID(id uuid.UUID, withBalances bool, balanceStartDate, balanceEndDate cachetime.NCacheTime, pageSize *int) (*model.BankAccount, error)
 	GetByFilter(input model.BankAccountFilterInput) ([]model.BankAccount, model.PageInfoOutput, error)
+	AggregateByFilter(input model.BankAccountFilterInput) ([]model.AggregateRow, error)
 	Update(input model.BankAccountInput, userID uuid.UUID) (*model.BankAccount, error)
 	Delete(id uuid.UUID, userID uuid.UUID) (*model.BankAccount, error)
+	Restore(id uuid.UUID, userID uuid.UUID) (*model.BankAccount, error)
 	CreateBalance(input model.BankAccountBalanceInput, userID uuid.UUID) (*model.BankAccountBalance, error)
+	ImportBalances(input model.BankAccountBalanceImportInput, userID uuid.UUID) (*model.ImportResult, error)
+	ImportStatement(input model.StatementImportInput, userID uuid.UUID) (*model.StatementImportResult, error)
 	GetBalanceByID(id uuid.UUID) (*model.BankAccountBalance, error)
 	GetBalancesByFilter(input model.BankAccountBalanceFilterInput) ([]model.BankAccountBalance, model.PageInfoOutput, error)
 	UpdateBalance(input model.BankAccountBalanceInput, userID uuid.UUID) (*model.BankAccountBalance, error)
 	DeleteBalance(id uuid.UUID, userID uuid.UUID) (*model.BankAccountBalance, error)
+	CreateCashFlow(input model.BankAccountCashFlowInput, userID uuid.UUID) (*model.BankAccountCashFlow, error)
+	GetCashFlowByID(id uuid.UUID) (*model.BankAccountCashFlow, error)
+	GetCashFlowsByFilter(input model.BankAccountCashFlowFilterInput) ([]model.BankAccountCashFlow, model.PageInfoOutput, error)
+	UpdateCashFlow(input model.BankAccountCashFlowInput, userID uuid.UUID) (*model.BankAccountCashFlow, error)
+	DeleteCashFlow(id uuid.UUID, userID uuid.UUID) (*model.BankAccountCashFlow, error)
+	GetReturns(id uuid.UUID, start, end time.Time) (*model.BankAccountReturns, error)
+}
+
+// Transaction is the service provider interface
+type Transaction interface {
+	Startup()
+	Shutdown()
+	Create(input model.TransactionInput, userID uuid.UUID) (*model.Transaction, error)
+	GetByID(id uuid.UUID) (*model.Transaction, error)
+	GetByFilter(input model.TransactionFilterInput) ([]model.Transaction, model.PageInfoOutput, error)
+	Update(input model.TransactionInput, userID uuid.UUID) (*model.Transaction, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (*model.Transaction, error)
+	Reconcile(bankAccountID uuid.UUID, start, end time.Time) (*model.Reconciliation, error)
+}
+
+// Transfer is the service provider interface
+type Transfer interface {
+	Startup()
+	Shutdown()
+	Create(input model.TransferInput, userID uuid.UUID) (*model.Transfer, error)
+	GetByID(id uuid.UUID) (*model.Transfer, error)
+	GetByFilter(input model.TransferFilterInput) ([]model.Transfer, model.PageInfoOutput, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (*model.Transfer, error)
+}
+
+// Category is the service provider interface
+type Category interface {
+	Startup()
+	Shutdown()
+	Create(input model.CategoryInput, userID uuid.UUID) (*model.Category, error)
+	GetByID(id uuid.UUID) (*model.Category, error)
+	GetByFilter(input model.CategoryFilterInput) ([]model.Category, model.PageInfoOutput, error)
+	GetTree() ([]model.CategoryTreeOutput, error)
+	Update(input model.CategoryInput, userID uuid.UUID) (*model.Category, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (*model.Category, error)
+	CreateRule(input model.CategoryRuleInput, userID uuid.UUID) (*model.CategoryRule, error)
+	GetRuleByID(id uuid.UUID) (*model.CategoryRule, error)
+	GetRulesByFilter(input model.CategoryRuleFilterInput) ([]model.CategoryRule, model.PageInfoOutput, error)
+	UpdateRule(input model.CategoryRuleInput, userID uuid.UUID) (*model.CategoryRule, error)
+	DeleteRule(id uuid.UUID, userID uuid.UUID) (*model.CategoryRule, error)
+	ApplyRules(userID uuid.UUID) ([]model.Transaction, error)
+}
+
+// Budget is the service provider interface
+type Budget interface {
+	Startup()
+	Shutdown()
+	Create(input model.BudgetInput, userID uuid.UUID) (*model.Budget, error)
+	GetByID(id uuid.UUID) (*model.Budget, error)
+	GetByFilter(input model.BudgetFilterInput) ([]model.Budget, model.PageInfoOutput, error)
+	Update(input model.BudgetInput, userID uuid.UUID) (*model.Budget, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (*model.Budget, error)
+	GetReport(month string) (*model.BudgetReport, error)
+}
+
+// Goal is the service provider interface
+type Goal interface {
+	Startup()
+	Shutdown()
+	Create(input model.GoalInput, userID uuid.UUID) (*model.Goal, error)
+	GetByID(id uuid.UUID) (*model.Goal, error)
+	GetByFilter(input model.GoalFilterInput) ([]model.Goal, model.PageInfoOutput, error)
+	Update(input model.GoalInput, userID uuid.UUID) (*model.Goal, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (*model.Goal, error)
+	GetProgress(id uuid.UUID) (*model.GoalProgress, error)
+	GetProgressByBankAccountID(id uuid.UUID) ([]model.GoalProgress, error)
+}
+
+// Tag is the service provider interface
+type Tag interface {
+	Startup()
+	Shutdown()
+	Create(input model.TagInput, userID uuid.UUID) (*model.Tag, error)
+	GetByID(id uuid.UUID) (*model.Tag, error)
+	GetByFilter(input model.TagFilterInput) ([]model.Tag, model.PageInfoOutput, error)
+	Update(input model.TagInput, userID uuid.UUID) (*model.Tag, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (*model.Tag, error)
+	GetEntityTags(entityType model.EntityType, subjectID uuid.UUID) (*model.EntityTags, error)
+	SetEntityTags(entityType model.EntityType, subjectID uuid.UUID, input model.EntityTagsInput) (*model.EntityTags, error)
+}
+
+// CustomField is the service provider interface
+type CustomField interface {
+	Startup()
+	Shutdown()
+	Create(input model.CustomFieldInput, userID uuid.UUID) (*model.CustomField, error)
+	GetByID(id uuid.UUID) (*model.CustomField, error)
+	GetByFilter(input model.CustomFieldFilterInput) ([]model.CustomField, model.PageInfoOutput, error)
+	Update(input model.CustomFieldInput, userID uuid.UUID) (*model.CustomField, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (*model.CustomField, error)
+	GetValues(entityType model.EntityType, subjectID uuid.UUID) (*model.CustomFieldValues, error)
+	SetValues(entityType model.EntityType, subjectID uuid.UUID, input model.CustomFieldValuesInput) (*model.CustomFieldValues, error)
+}
+
+// Attachment is the service provider interface
+type Attachment interface {
+	Startup()
+	Shutdown()
+	Create(input model.AttachmentInput, userID uuid.UUID) (*model.Attachment, error)
+	GetByID(id uuid.UUID) (*model.Attachment, error)
+	GetByFilter(input model.AttachmentFilterInput) ([]model.Attachment, model.PageInfoOutput, error)
+	GetByEntity(entityType model.EntityType, subjectID uuid.UUID) ([]model.Attachment, error)
+	Download(id uuid.UUID) (*model.AttachmentDownload, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (*model.Attachment, error)
+}
+
+// Note is the service provider interface
+type Note interface {
+	Startup()
+	Shutdown()
+	Create(input model.NoteInput, userID uuid.UUID) (*model.Note, error)
+	GetByID(id uuid.UUID) (*model.Note, error)
+	GetByFilter(input model.NoteFilterInput) ([]model.Note, model.PageInfoOutput, error)
+	GetByEntity(entityType model.EntityType, subjectID uuid.UUID) ([]model.Note, error)
+	Update(input model.NoteInput, userID uuid.UUID) (*model.Note, error)
+	Delete(id uuid.UUID, userID uuid.UUID) (*model.Note, error)
 }
 
 // User is the service provider interface
@@ -50,9 +179,12 @@ type Vehicle interface {
 	Create(input model.VehicleInput, userID uuid.UUID) (*model.Vehicle, error)
 	GetByID(id uuid.UUID, withValues bool, valueStartDate, valueEndDate cachetime.NCacheTime, pageSize *int) (*model.Vehicle, error)
 	GetByFilter(input model.VehicleFilterInput) ([]model.Vehicle, model.PageInfoOutput, error)
+	AggregateByFilter(input model.VehicleFilterInput) ([]model.AggregateRow, error)
 	Update(input model.VehicleInput, userID uuid.UUID) (*model.Vehicle, error)
 	Delete(id uuid.UUID, userID uuid.UUID) (*model.Vehicle, error)
+	Restore(id uuid.UUID, userID uuid.UUID) (*model.Vehicle, error)
 	CreateValue(input model.VehicleValueInput, userID uuid.UUID) (*model.VehicleValue, error)
+	ImportValues(input model.VehicleValueImportInput, userID uuid.UUID) (*model.ImportResult, error)
 	GetValueByID(id uuid.UUID) (*model.VehicleValue, error)
 	GetValuesByFilter(input model.VehicleValueFilterInput) ([]model.VehicleValue, model.PageInfoOutput, error)
 	UpdateValue(input model.VehicleValueInput, userID uuid.UUID) (*model.VehicleValue, error)
@@ -66,11 +198,61 @@ type Property interface {
 	Create(input model.PropertyInput, userID uuid.UUID) (*model.Property, error)
 	GetByID(id uuid.UUID, withValues bool, valueStartDate, valueEndDate cachetime.NCacheTime, pageSize *int) (*model.Property, error)
 	GetByFilter(input model.PropertyFilterInput) ([]model.Property, model.PageInfoOutput, error)
+	AggregateByFilter(input model.PropertyFilterInput) ([]model.AggregateRow, error)
 	Update(input model.PropertyInput, userID uuid.UUID) (*model.Property, error)
 	Delete(id uuid.UUID, userID uuid.UUID) (*model.Property, error)
+	Restore(id uuid.UUID, userID uuid.UUID) (*model.Property, error)
 	CreateValue(input model.PropertyValueInput, userID uuid.UUID) (*model.PropertyValue, error)
+	ImportValues(input model.PropertyValueImportInput, userID uuid.UUID) (*model.ImportResult, error)
 	GetValueByID(id uuid.UUID) (*model.PropertyValue, error)
 	GetValuesByFilter(input model.PropertyValueFilterInput) ([]model.PropertyValue, model.PageInfoOutput, error)
 	UpdateValue(input model.PropertyValueInput, userID uuid.UUID) (*model.PropertyValue, error)
 	DeleteValue(id uuid.UUID, userID uuid.UUID) (*model.PropertyValue, error)
 }
+
+// APIKey is the service provider interface
+type APIKey interface {
+	Startup()
+	Shutdown()
+	Create(input model.APIKeyInput, userID uuid.UUID) (*model.APIKey, *string, error)
+	GetByFilter(input model.APIKeyFilterInput, userID uuid.UUID) ([]model.APIKey, model.PageInfoOutput, error)
+	Revoke(id uuid.UUID, userID uuid.UUID) (*model.APIKey, error)
+}
+
+// AuditLog is the service provider interface
+type AuditLog interface {
+	Startup()
+	Shutdown()
+	GetByFilter(input model.AuditLogFilterInput, userID uuid.UUID) ([]model.AuditLog, model.PageInfoOutput, error)
+}
+
+// Purge is the service provider interface
+type Purge interface {
+	Startup()
+	Shutdown()
+	Purge(userID uuid.UUID) (*model.PurgeSummary, error)
+}
+
+// Search is the service provider interface
+type Search interface {
+	Startup()
+	Shutdown()
+	Search(input model.SearchInput) ([]model.SearchResult, error)
+}
+
+// Archive is the service provider interface
+type Archive interface {
+	Startup()
+	Shutdown()
+	Export(w io.Writer, format model.ArchiveFormat, userID uuid.UUID) error
+	Restore(data []byte, userID uuid.UUID) (*model.ArchiveRestoreResult, error)
+}
+
+// Report is the service provider interface
+type Report interface {
+	Startup()
+	Shutdown()
+	GetNetWorth() (*model.NetWorthReport, error)
+	GetNetWorthStatement(asOf time.Time) (*model.NetWorthStatement, error)
+	GetAssetChange(from, to time.Time) (*model.AssetChangeReport, error)
+}
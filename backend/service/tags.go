@@ -0,0 +1,257 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// TagImpl is the service provider implementation
+type TagImpl struct {
+	Repository            repository.Tag         `inject:"tagRepository"`
+	BankAccountRepository repository.BankAccount `inject:"bankAccountRepository"`
+	VehicleRepository     repository.Vehicle     `inject:"vehicleRepository"`
+	PropertyRepository    repository.Property    `inject:"propertyRepository"`
+}
+
+// Startup performs startup functions
+func (s *TagImpl) Startup() {
+	logger.Trace("Tag Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *TagImpl) Shutdown() {
+	logger.Trace("Tag Service shutting down...")
+}
+
+// Create creates a new Tag
+func (s *TagImpl) Create(input model.TagInput, userID uuid.UUID) (*model.Tag, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.checkNameAvailable("create", input)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := model.NewTagFromInput(input, userID)
+	err = s.Repository.Create(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// GetByID fetches a Tag by its ID
+func (s *TagImpl) GetByID(id uuid.UUID) (*model.Tag, error) {
+	tags, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Tag")
+	}
+
+	return &tags[0], nil
+}
+
+// GetByFilter fetches a set of Tags by its filter
+func (s *TagImpl) GetByFilter(input model.TagFilterInput) ([]model.Tag, model.PageInfoOutput, error) {
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// Update updates an existing Tag
+func (s *TagImpl) Update(input model.TagInput, userID uuid.UUID) (*model.Tag, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) != 1 {
+		return nil, failure.EntityNotFound("update", "Tag")
+	}
+
+	tag := tags[0]
+
+	err = s.checkNameAvailable("update", input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tag.Update(input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// Delete deletes an existing Tag
+func (s *TagImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Tag, error) {
+	tags, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) != 1 {
+		return nil, failure.EntityNotFound("delete", "Tag")
+	}
+
+	tag := tags[0]
+
+	err = tag.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// GetEntityTags fetches the Tags put on an entity
+func (s *TagImpl) GetEntityTags(entityType model.EntityType, subjectID uuid.UUID) (*model.EntityTags, error) {
+	err := checkEntity("get tags", entityType, subjectID, model.CheckTaggableEntityType, s.BankAccountRepository, s.VehicleRepository, s.PropertyRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.Repository.ResolveByEntity(entityType, subjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.EntityTags{EntityType: entityType, SubjectID: subjectID, Tags: tags}, nil
+}
+
+// SetEntityTags replaces the Tags put on an entity
+func (s *TagImpl) SetEntityTags(entityType model.EntityType, subjectID uuid.UUID, input model.EntityTagsInput) (*model.EntityTags, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkEntity("set tags", entityType, subjectID, model.CheckTaggableEntityType, s.BankAccountRepository, s.VehicleRepository, s.PropertyRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.Repository.ResolveByIDs(input.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tags) != len(input.TagIDs) {
+		return nil, failure.EntityNotFound("set tags", "Tag")
+	}
+
+	for _, tag := range tags {
+		if tag.Deleted.Valid {
+			return nil, failure.OperationNotPermitted("set tags", "Tag", "the Tag is already deleted")
+		}
+	}
+
+	err = s.Repository.SetEntityTags(entityType, subjectID, input.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetEntityTags(entityType, subjectID)
+}
+
+// checkNameAvailable makes sure that no other Tag that has not been deleted goes by the same name,
+// as entities are filtered by their Tags' names
+func (s *TagImpl) checkNameAvailable(operation string, input model.TagInput) error {
+	page := 1
+	pageSize := math.MaxInt
+
+	tagFilter := model.TagFilterInput{}
+	tagFilter.Page = &page
+	tagFilter.PageSize = &pageSize
+
+	tags, _, err := s.Repository.ResolveByFilter(tagFilter.ToFilter())
+	if err != nil {
+		return err
+	}
+
+	existing := model.FindTagByName(tags, input.Name)
+	if existing != nil && existing.ID != input.ID {
+		return failure.OperationNotPermitted(operation, "Tag", "a tag with the same name already exists")
+	}
+
+	return nil
+}
+
+// checkEntity makes sure that an entity is of a type the operation allows, as told by checkEntityType, and that
+// it exists
+func checkEntity(
+	operation string,
+	entityType model.EntityType,
+	subjectID uuid.UUID,
+	checkEntityType func(model.EntityType) error,
+	bankAccountRepository repository.BankAccount,
+	vehicleRepository repository.Vehicle,
+	propertyRepository repository.Property,
+) error {
+	err := checkEntityType(entityType)
+	if err != nil {
+		return err
+	}
+
+	var exists bool
+	var entityName string
+	switch entityType {
+	case model.EntityTypeBankAccount:
+		exists, err = bankAccountRepository.ExistsByID(subjectID)
+		entityName = "Bank Account"
+	case model.EntityTypeBankAccountBalance:
+		exists, err = bankAccountRepository.ExistsBalanceByID(subjectID)
+		entityName = "Bank Account Balance"
+	case model.EntityTypeVehicle:
+		exists, err = vehicleRepository.ExistsByID(subjectID)
+		entityName = "Vehicle"
+	case model.EntityTypeVehicleValue:
+		exists, err = vehicleRepository.ExistsValueByID(subjectID)
+		entityName = "Vehicle Value"
+	case model.EntityTypeProperty:
+		exists, err = propertyRepository.ExistsByID(subjectID)
+		entityName = "Property"
+	case model.EntityTypePropertyValue:
+		exists, err = propertyRepository.ExistsValueByID(subjectID)
+		entityName = "Property Value"
+	default:
+		return failure.BadRequestFromString(fmt.Sprintf("unsupported entity type: %s", entityType))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return failure.EntityNotFound(operation, entityName)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type tagsServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.Tag
+	mockRepo            *mock_repository.MockTag
+	mockBankAccountRepo *mock_repository.MockBankAccount
+	mockVehicleRepo     *mock_repository.MockVehicle
+	mockPropertyRepo    *mock_repository.MockProperty
+	testUserID          uuid.UUID
+	testTagID           uuid.UUID
+	testBankAccountID   uuid.UUID
+}
+
+func TestTagsService(t *testing.T) {
+	suite.Run(t, new(tagsServiceTestSuite))
+}
+
+func (t *tagsServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockTag(t.ctrl)
+	t.mockBankAccountRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.mockVehicleRepo = mock_repository.NewMockVehicle(t.ctrl)
+	t.mockPropertyRepo = mock_repository.NewMockProperty(t.ctrl)
+	t.svc = &service.TagImpl{
+		Repository:            t.mockRepo,
+		BankAccountRepository: t.mockBankAccountRepo,
+		VehicleRepository:     t.mockVehicleRepo,
+		PropertyRepository:    t.mockPropertyRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testTagID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.svc.Startup()
+}
+
+func (t *tagsServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *tagsServiceTestSuite) getTag() model.Tag {
+	return model.Tag{
+		ID:        t.testTagID,
+		Name:      "Joint",
+		Created:   time.Now(),
+		CreatedBy: t.testUserID,
+	}
+}
+
+func (t *tagsServiceTestSuite) TestCreate_Normal() {
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Tag{}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	tag, err := t.svc.Create(model.TagInput{Name: " Joint "}, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), tag)
+	assert.Equal(t.T(), "Joint", tag.Name)
+}
+
+func (t *tagsServiceTestSuite) TestCreate_NoName() {
+	tag, err := t.svc.Create(model.TagInput{Name: " "}, t.testUserID)
+
+	assert.Nil(t.T(), tag)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *tagsServiceTestSuite) TestCreate_NameTaken() {
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Tag{t.getTag()}, model.PageInfoOutput{}, nil)
+
+	tag, err := t.svc.Create(model.TagInput{Name: "joint"}, t.testUserID)
+
+	assert.Nil(t.T(), tag)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *tagsServiceTestSuite) TestUpdate_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTagID}).Return([]model.Tag{t.getTag()}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Tag{t.getTag()}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	tag, err := t.svc.Update(model.TagInput{ID: t.testTagID, Name: "JOINT"}, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), tag)
+	assert.Equal(t.T(), "JOINT", tag.Name)
+	assert.True(t.T(), tag.UpdatedBy.Valid)
+}
+
+func (t *tagsServiceTestSuite) TestUpdate_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTagID}).Return([]model.Tag{}, nil)
+
+	tag, err := t.svc.Update(model.TagInput{ID: t.testTagID, Name: "Joint"}, t.testUserID)
+
+	assert.Nil(t.T(), tag)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *tagsServiceTestSuite) TestDelete_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTagID}).Return([]model.Tag{t.getTag()}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	tag, err := t.svc.Delete(t.testTagID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), tag)
+	assert.True(t.T(), tag.Deleted.Valid)
+}
+
+func (t *tagsServiceTestSuite) TestGetEntityTags_Normal() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByEntity(model.EntityTypeBankAccount, t.testBankAccountID).Return([]model.Tag{t.getTag()}, nil)
+
+	entityTags, err := t.svc.GetEntityTags(model.EntityTypeBankAccount, t.testBankAccountID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), entityTags)
+	assert.Len(t.T(), entityTags.Tags, 1)
+}
+
+func (t *tagsServiceTestSuite) TestGetEntityTags_NotTaggable() {
+	entityTags, err := t.svc.GetEntityTags(model.EntityTypeGoal, t.testBankAccountID)
+
+	assert.Nil(t.T(), entityTags)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *tagsServiceTestSuite) TestGetEntityTags_EntityNotFound() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(false, nil)
+
+	entityTags, err := t.svc.GetEntityTags(model.EntityTypeBankAccount, t.testBankAccountID)
+
+	assert.Nil(t.T(), entityTags)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *tagsServiceTestSuite) TestSetEntityTags_Normal() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil).Times(2)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTagID}).Return([]model.Tag{t.getTag()}, nil)
+	t.mockRepo.EXPECT().SetEntityTags(model.EntityTypeBankAccount, t.testBankAccountID, []uuid.UUID{t.testTagID}).Return(nil)
+	t.mockRepo.EXPECT().ResolveByEntity(model.EntityTypeBankAccount, t.testBankAccountID).Return([]model.Tag{t.getTag()}, nil)
+
+	input := model.EntityTagsInput{TagIDs: []uuid.UUID{t.testTagID, t.testTagID}}
+	entityTags, err := t.svc.SetEntityTags(model.EntityTypeBankAccount, t.testBankAccountID, input)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), entityTags)
+	assert.Len(t.T(), entityTags.Tags, 1)
+}
+
+func (t *tagsServiceTestSuite) TestSetEntityTags_TagNotFound() {
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTagID}).Return([]model.Tag{}, nil)
+
+	input := model.EntityTagsInput{TagIDs: []uuid.UUID{t.testTagID}}
+	entityTags, err := t.svc.SetEntityTags(model.EntityTypeBankAccount, t.testBankAccountID, input)
+
+	assert.Nil(t.T(), entityTags)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *tagsServiceTestSuite) TestSetEntityTags_TagDeleted() {
+	tag := t.getTag()
+	tag.Deleted = null.TimeFrom(time.Now())
+
+	t.mockBankAccountRepo.EXPECT().ExistsByID(t.testBankAccountID).Return(true, nil)
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTagID}).Return([]model.Tag{tag}, nil)
+
+	input := model.EntityTagsInput{TagIDs: []uuid.UUID{t.testTagID}}
+	entityTags, err := t.svc.SetEntityTags(model.EntityTypeBankAccount, t.testBankAccountID, input)
+
+	assert.Nil(t.T(), entityTags)
+	assert.NotNil(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+	"github.com/kerti/balances/backend/util/nuuid"
+)
+
+// TransactionImpl is the service provider implementation
+type TransactionImpl struct {
+	Repository            repository.Transaction `inject:"transactionRepository"`
+	BankAccountRepository repository.BankAccount `inject:"bankAccountRepository"`
+	TransferRepository    repository.Transfer    `inject:"transferRepository"`
+	CategoryRepository    repository.Category    `inject:"categoryRepository"`
+}
+
+// Startup performs startup functions
+func (s *TransactionImpl) Startup() {
+	logger.Trace("Transaction Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *TransactionImpl) Shutdown() {
+	logger.Trace("Transaction Service shutting down...")
+}
+
+// Create records a new Transaction in the ledger of a Bank Account, linked to the Category given by its ID or
+// name. A Transaction without a category is filed under the Category of the first Category Rule that matches
+// its payee.
+func (s *TransactionImpl) Create(input model.TransactionInput, userID uuid.UUID) (*model.Transaction, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	bankAccount, err := s.resolveBankAccount("create transaction", input.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.linkCategory("create", &input)
+	if err != nil {
+		return nil, err
+	}
+
+	if !input.CategoryID.Valid && len(strings.TrimSpace(input.Category)) == 0 {
+		category, err := s.matchCategory(input.Payee)
+		if err != nil {
+			return nil, err
+		}
+		if category != nil {
+			input.CategoryID = nuuid.From(category.ID)
+			input.Category = category.Name
+		}
+	}
+
+	transaction := model.NewTransactionFromInput(input, bankAccount.ID, userID)
+	err = s.Repository.Create(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+// GetByID fetches a Transaction by its ID
+func (s *TransactionImpl) GetByID(id uuid.UUID) (*model.Transaction, error) {
+	transactions, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transactions) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Transaction")
+	}
+
+	return &transactions[0], nil
+}
+
+// GetByFilter fetches a set of Transactions by its filter
+func (s *TransactionImpl) GetByFilter(input model.TransactionFilterInput) ([]model.Transaction, model.PageInfoOutput, error) {
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// Update updates an existing Transaction
+func (s *TransactionImpl) Update(input model.TransactionInput, userID uuid.UUID) (*model.Transaction, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transactions) != 1 {
+		return nil, failure.EntityNotFound("update", "Transaction")
+	}
+
+	transaction := transactions[0]
+
+	err = s.checkNotTransferred("update", transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.resolveBankAccount("update transaction", transaction.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.linkCategory("update", &input)
+	if err != nil {
+		return nil, err
+	}
+
+	err = transaction.Update(input, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+// Delete deletes an existing Transaction
+func (s *TransactionImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Transaction, error) {
+	transactions, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transactions) != 1 {
+		return nil, failure.EntityNotFound("delete", "Transaction")
+	}
+
+	transaction := transactions[0]
+
+	err = s.checkNotTransferred("delete", transaction.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.resolveBankAccount("delete transaction", transaction.BankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = transaction.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+// Reconcile checks that the transactions of a Bank Account add up to the change between each pair of its
+// consecutive balances over a period, reporting the gaps where they do not
+func (s *TransactionImpl) Reconcile(bankAccountID uuid.UUID, start, end time.Time) (*model.Reconciliation, error) {
+	if !start.Before(end) {
+		return nil, failure.BadRequestFromString("the start date must be before the end date")
+	}
+
+	bankAccounts, err := s.BankAccountRepository.ResolveByIDs([]uuid.UUID{bankAccountID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccounts) != 1 {
+		return nil, failure.EntityNotFound("reconcile", "Bank Account")
+	}
+
+	page := 1
+	pageSize := math.MaxInt
+	ids := []uuid.UUID{bankAccountID}
+
+	balanceFilter := model.BankAccountBalanceFilterInput{
+		BankAccountIDs: &ids,
+		EndDate:        cachetime.NCacheTime(null.TimeFrom(end)),
+	}
+	balanceFilter.Page = &page
+	balanceFilter.PageSize = &pageSize
+
+	balances, _, err := s.BankAccountRepository.ResolveBalancesByFilter(balanceFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	// only the transactions after the first balance of the narrowed period can count towards it
+	transactionStart := start
+	if narrowed := model.NarrowBalancesToPeriod(bankAccountID, start, end, balances); len(narrowed) > 0 {
+		transactionStart = narrowed[0].Date
+	}
+
+	transactionFilter := model.TransactionFilterInput{
+		BankAccountIDs: &ids,
+		StartDate:      cachetime.NCacheTime(null.TimeFrom(transactionStart)),
+		EndDate:        cachetime.NCacheTime(null.TimeFrom(end)),
+	}
+	transactionFilter.Page = &page
+	transactionFilter.PageSize = &pageSize
+
+	transactions, _, err := s.Repository.ResolveByFilter(transactionFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation := model.NewReconciliation(bankAccountID, start, end, balances, transactions)
+	return &reconciliation, nil
+}
+
+// linkCategory links the input of a Transaction to the Category it is filed under, taking the name of the
+// Category given by its ID or else the ID of the Category going by its name. A name that no Category goes by
+// is kept as it is, unlinked.
+func (s *TransactionImpl) linkCategory(operation string, input *model.TransactionInput) error {
+	if input.CategoryID.Valid {
+		categories, err := s.CategoryRepository.ResolveByIDs([]uuid.UUID{input.CategoryID.UUID})
+		if err != nil {
+			return err
+		}
+
+		if len(categories) != 1 {
+			return failure.EntityNotFound(operation, "Category")
+		}
+
+		if categories[0].Deleted.Valid {
+			return failure.OperationNotPermitted(operation, "Category", "the Category is already deleted")
+		}
+
+		input.Category = categories[0].Name
+		return nil
+	}
+
+	name := strings.TrimSpace(input.Category)
+	if len(name) == 0 {
+		return nil
+	}
+
+	page := 1
+	pageSize := math.MaxInt
+	names := []string{name}
+
+	categoryFilter := model.CategoryFilterInput{Names: &names}
+	categoryFilter.Page = &page
+	categoryFilter.PageSize = &pageSize
+
+	categories, _, err := s.CategoryRepository.ResolveByFilter(categoryFilter.ToFilter())
+	if err != nil {
+		return err
+	}
+
+	category := model.FindCategoryByName(categories, name)
+	if category != nil {
+		input.CategoryID = nuuid.From(category.ID)
+	}
+
+	return nil
+}
+
+// matchCategory finds the Category a payee is filed under by the Category Rules, which is nil when no rule
+// matches it
+func (s *TransactionImpl) matchCategory(payee string) (*model.Category, error) {
+	page := 1
+	pageSize := math.MaxInt
+
+	ruleFilter := model.CategoryRuleFilterInput{}
+	ruleFilter.Page = &page
+	ruleFilter.PageSize = &pageSize
+
+	rules, _, err := s.CategoryRepository.ResolveRulesByFilter(ruleFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	categoryFilter := model.CategoryFilterInput{}
+	categoryFilter.Page = &page
+	categoryFilter.PageSize = &pageSize
+
+	categories, _, err := s.CategoryRepository.ResolveByFilter(categoryFilter.ToFilter())
+	if err != nil {
+		return nil, err
+	}
+
+	return model.MatchCategory(rules, categories, payee), nil
+}
+
+// checkNotTransferred refuses changes to a Transaction recorded by a Transfer, as it can only be
+// changed together with the other side of that Transfer
+func (s *TransactionImpl) checkNotTransferred(operation string, id uuid.UUID) error {
+	transferred, err := s.TransferRepository.ExistsByTransactionID(id)
+	if err != nil {
+		return err
+	}
+
+	if transferred {
+		return failure.OperationNotPermitted(operation, "Transaction", "the Transaction belongs to a Transfer")
+	}
+
+	return nil
+}
+
+// resolveBankAccount resolves the Bank Account a Transaction belongs to, which must be neither deleted nor
+// inactive for its transactions to be changed
+func (s *TransactionImpl) resolveBankAccount(operation string, id uuid.UUID) (*model.BankAccount, error) {
+	bankAccounts, err := s.BankAccountRepository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccounts) != 1 {
+		return nil, failure.EntityNotFound(operation, "Bank Account")
+	}
+
+	bankAccount := bankAccounts[0]
+
+	if bankAccount.Deleted.Valid {
+		return nil, failure.OperationNotPermitted(operation, "Bank Account", "the Bank Account is already deleted")
+	}
+
+	if bankAccount.Status == model.BankAccountStatusInactive {
+		return nil, failure.OperationNotPermitted(operation, "Bank Account", "the Bank Account is inactive")
+	}
+
+	return &bankAccount, nil
+}
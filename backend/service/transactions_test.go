@@ -0,0 +1,534 @@
+package service_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type transactionsServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.Transaction
+	mockRepo            *mock_repository.MockTransaction
+	mockBankAccountRepo *mock_repository.MockBankAccount
+	mockTransferRepo    *mock_repository.MockTransfer
+	mockCategoryRepo    *mock_repository.MockCategory
+	testUserID          uuid.UUID
+	testBankAccountID   uuid.UUID
+	testTransactionID   uuid.UUID
+	testCategoryID      uuid.UUID
+}
+
+func TestTransactionsService(t *testing.T) {
+	suite.Run(t, new(transactionsServiceTestSuite))
+}
+
+func (t *transactionsServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockTransaction(t.ctrl)
+	t.mockBankAccountRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.mockTransferRepo = mock_repository.NewMockTransfer(t.ctrl)
+	t.mockCategoryRepo = mock_repository.NewMockCategory(t.ctrl)
+	t.svc = &service.TransactionImpl{
+		Repository:            t.mockRepo,
+		BankAccountRepository: t.mockBankAccountRepo,
+		TransferRepository:    t.mockTransferRepo,
+		CategoryRepository:    t.mockCategoryRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testBankAccountID, _ = uuid.NewV7()
+	t.testTransactionID, _ = uuid.NewV7()
+	t.testCategoryID, _ = uuid.NewV7()
+	t.svc.Startup()
+}
+
+func (t *transactionsServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *transactionsServiceTestSuite) getBankAccount(status model.BankAccountStatus) model.BankAccount {
+	return model.BankAccount{
+		ID:          t.testBankAccountID,
+		AccountName: "Checking Account",
+		LastBalance: float64(1000),
+		Status:      status,
+		Created:     time.Now(),
+		CreatedBy:   t.testUserID,
+	}
+}
+
+func (t *transactionsServiceTestSuite) getCategory() model.Category {
+	return model.Category{
+		ID:        t.testCategoryID,
+		Name:      "Groceries",
+		Created:   time.Now(),
+		CreatedBy: t.testUserID,
+	}
+}
+
+func (t *transactionsServiceTestSuite) expectCategoryByName() {
+	t.mockCategoryRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Category{t.getCategory()}, model.PageInfoOutput{}, nil)
+}
+
+func (t *transactionsServiceTestSuite) getTransactionInput() model.TransactionInput {
+	return model.TransactionInput{
+		ID:            t.testTransactionID,
+		BankAccountID: t.testBankAccountID,
+		Date:          cachetime.CacheTime(time.Now()),
+		Direction:     model.TransactionDirectionDebit,
+		Amount:        float64(250),
+		Payee:         " Grocery Store ",
+		Memo:          "weekly groceries",
+		Category:      "groceries",
+	}
+}
+
+func (t *transactionsServiceTestSuite) getTransaction() model.Transaction {
+	return model.Transaction{
+		ID:            t.testTransactionID,
+		BankAccountID: t.testBankAccountID,
+		Date:          time.Now(),
+		Direction:     model.TransactionDirectionDebit,
+		Amount:        float64(250),
+		Payee:         "Grocery Store",
+		Category:      "groceries",
+		Created:       time.Now(),
+		CreatedBy:     t.testUserID,
+	}
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_Normal() {
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.expectCategoryByName()
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	transaction, err := t.svc.Create(t.getTransactionInput(), t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), transaction)
+	assert.Equal(t.T(), t.testBankAccountID, transaction.BankAccountID)
+	assert.Equal(t.T(), "Grocery Store", transaction.Payee)
+	assert.Equal(t.T(), float64(-250), transaction.SignedAmount())
+	assert.Equal(t.T(), nuuid.From(t.testCategoryID), transaction.CategoryID)
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_UnknownCategoryName() {
+	input := t.getTransactionInput()
+	input.Category = "Hobbies"
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Category{}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	transaction, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), "Hobbies", transaction.Category)
+	assert.False(t.T(), transaction.CategoryID.Valid)
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_CategoryByID() {
+	input := t.getTransactionInput()
+	input.Category = ""
+	input.CategoryID = nuuid.From(t.testCategoryID)
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{t.getCategory()}, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	transaction, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), "Groceries", transaction.Category)
+	assert.Equal(t.T(), nuuid.From(t.testCategoryID), transaction.CategoryID)
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_CategoryNotFound() {
+	input := t.getTransactionInput()
+	input.CategoryID = nuuid.From(t.testCategoryID)
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{}, nil)
+
+	transaction, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_CategoryDeleted() {
+	input := t.getTransactionInput()
+	input.CategoryID = nuuid.From(t.testCategoryID)
+	category := t.getCategory()
+	category.Deleted = null.TimeFrom(time.Now())
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testCategoryID}).Return([]model.Category{category}, nil)
+
+	transaction, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_CategorizedByRule() {
+	categoryID, _ := uuid.NewV7()
+	input := t.getTransactionInput()
+	input.Category = ""
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveRulesByFilter(gomock.Any()).Return([]model.CategoryRule{
+		{CategoryID: categoryID, Pattern: "*grocer*", Created: time.Now(), CreatedBy: t.testUserID},
+	}, model.PageInfoOutput{}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveByFilter(gomock.Any()).Return([]model.Category{
+		{ID: categoryID, Name: "Groceries", Created: time.Now(), CreatedBy: t.testUserID},
+	}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	transaction, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), transaction)
+	assert.Equal(t.T(), "Groceries", transaction.Category)
+	assert.Equal(t.T(), nuuid.From(categoryID), transaction.CategoryID)
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_NoMatchingRule() {
+	input := t.getTransactionInput()
+	input.Category = ""
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockCategoryRepo.EXPECT().ResolveRulesByFilter(gomock.Any()).Return([]model.CategoryRule{}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	transaction, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.NotNil(t.T(), transaction)
+	assert.Equal(t.T(), "", transaction.Category)
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_InvalidAmount() {
+	input := t.getTransactionInput()
+	input.Amount = 0
+
+	transaction, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_InvalidDirection() {
+	input := t.getTransactionInput()
+	input.Direction = "sideways"
+
+	transaction, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_BankAccountNotFound() {
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{}, nil)
+
+	transaction, err := t.svc.Create(t.getTransactionInput(), t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_BankAccountInactive() {
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusInactive)}, nil)
+
+	transaction, err := t.svc.Create(t.getTransactionInput(), t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestCreate_ErrorCreating() {
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.expectCategoryByName()
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(errors.New("failed creating transaction"))
+
+	transaction, err := t.svc.Create(t.getTransactionInput(), t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.NotNil(t.T(), err)
+}
+
+func (t *transactionsServiceTestSuite) TestGetByID_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTransactionID}).Return([]model.Transaction{t.getTransaction()}, nil)
+
+	transaction, err := t.svc.GetByID(t.testTransactionID)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), t.testTransactionID, transaction.ID)
+}
+
+func (t *transactionsServiceTestSuite) TestGetByID_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTransactionID}).Return([]model.Transaction{}, nil)
+
+	transaction, err := t.svc.GetByID(t.testTransactionID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestGetByFilter_Normal() {
+	t.mockRepo.EXPECT().ResolveByFilter(gomock.Any()).
+		Return([]model.Transaction{t.getTransaction()}, model.PageInfoOutput{TotalCount: 1}, nil)
+
+	transactions, pageInfo, err := t.svc.GetByFilter(model.TransactionFilterInput{})
+
+	assert.Nil(t.T(), err)
+	assert.Len(t.T(), transactions, 1)
+	assert.Equal(t.T(), 1, pageInfo.TotalCount)
+}
+
+func (t *transactionsServiceTestSuite) TestUpdate_Normal() {
+	input := t.getTransactionInput()
+	input.Direction = model.TransactionDirectionCredit
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTransactionID}).
+		Return([]model.Transaction{t.getTransaction()}, nil)
+	t.mockTransferRepo.EXPECT().ExistsByTransactionID(t.testTransactionID).Return(false, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.expectCategoryByName()
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	transaction, err := t.svc.Update(input, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), model.TransactionDirectionCredit, transaction.Direction)
+	assert.Equal(t.T(), float64(250), transaction.SignedAmount())
+	assert.True(t.T(), transaction.Updated.Valid)
+}
+
+func (t *transactionsServiceTestSuite) TestUpdate_AlreadyDeleted() {
+	deleted := t.getTransaction()
+	deleted.Deleted = null.TimeFrom(time.Now())
+	deleted.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTransactionID}).
+		Return([]model.Transaction{deleted}, nil)
+	t.mockTransferRepo.EXPECT().ExistsByTransactionID(t.testTransactionID).Return(false, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.expectCategoryByName()
+
+	transaction, err := t.svc.Update(t.getTransactionInput(), t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestDelete_Normal() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTransactionID}).
+		Return([]model.Transaction{t.getTransaction()}, nil)
+	t.mockTransferRepo.EXPECT().ExistsByTransactionID(t.testTransactionID).Return(false, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	transaction, err := t.svc.Delete(t.testTransactionID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.True(t.T(), transaction.Deleted.Valid)
+	assert.Equal(t.T(), nuuid.From(t.testUserID), transaction.DeletedBy)
+}
+
+func (t *transactionsServiceTestSuite) TestDelete_BankAccountDeleted() {
+	bankAccount := t.getBankAccount(model.BankAccountStatusActive)
+	bankAccount.Deleted = null.TimeFrom(time.Now())
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTransactionID}).
+		Return([]model.Transaction{t.getTransaction()}, nil)
+	t.mockTransferRepo.EXPECT().ExistsByTransactionID(t.testTransactionID).Return(false, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{bankAccount}, nil)
+
+	transaction, err := t.svc.Delete(t.testTransactionID, t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestUpdate_BelongsToTransfer() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTransactionID}).
+		Return([]model.Transaction{t.getTransaction()}, nil)
+	t.mockTransferRepo.EXPECT().ExistsByTransactionID(t.testTransactionID).Return(true, nil)
+
+	transaction, err := t.svc.Update(t.getTransactionInput(), t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestDelete_BelongsToTransfer() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testTransactionID}).
+		Return([]model.Transaction{t.getTransaction()}, nil)
+	t.mockTransferRepo.EXPECT().ExistsByTransactionID(t.testTransactionID).Return(true, nil)
+
+	transaction, err := t.svc.Delete(t.testTransactionID, t.testUserID)
+
+	assert.Nil(t.T(), transaction)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) getReconcileTransactionFilter(start, end time.Time) filter.Filter {
+	page := 1
+	pageSize := math.MaxInt
+	ids := []uuid.UUID{t.testBankAccountID}
+
+	transactionFilter := model.TransactionFilterInput{
+		BankAccountIDs: &ids,
+		StartDate:      cachetime.NCacheTime(null.TimeFrom(start)),
+		EndDate:        cachetime.NCacheTime(null.TimeFrom(end)),
+	}
+	transactionFilter.Page = &page
+	transactionFilter.PageSize = &pageSize
+
+	return transactionFilter.ToFilter()
+}
+
+func (t *transactionsServiceTestSuite) TestReconcile_Normal() {
+	jan1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb1 := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+	mar1 := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	balances := []model.BankAccountBalance{
+		{BankAccountID: t.testBankAccountID, Date: mar1, Balance: 1350},
+		{BankAccountID: t.testBankAccountID, Date: jan1, Balance: 1000},
+		{BankAccountID: t.testBankAccountID, Date: feb1, Balance: 1200.10},
+	}
+	// January is fully accounted for, while 100 of the change in February was never recorded
+	transactions := []model.Transaction{
+		{BankAccountID: t.testBankAccountID, Date: jan1, Direction: model.TransactionDirectionCredit, Amount: 999},
+		{BankAccountID: t.testBankAccountID, Date: jan1.AddDate(0, 0, 14), Direction: model.TransactionDirectionCredit, Amount: 300},
+		{BankAccountID: t.testBankAccountID, Date: feb1, Direction: model.TransactionDirectionDebit, Amount: 99.90},
+		{BankAccountID: t.testBankAccountID, Date: feb1.AddDate(0, 0, 3), Direction: model.TransactionDirectionCredit, Amount: 50},
+		{BankAccountID: t.testBankAccountID, Date: feb1.AddDate(0, 0, 5), Direction: model.TransactionDirectionDebit, Amount: 500, Deleted: null.TimeFrom(mar1)},
+	}
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return(balances, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(t.getReconcileTransactionFilter(jan1, mar1.AddDate(0, 0, 10))).Return(transactions, model.PageInfoOutput{}, nil)
+
+	reconciliation, err := t.svc.Reconcile(t.testBankAccountID, jan1, mar1.AddDate(0, 0, 10))
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), jan1, reconciliation.StartDate)
+	assert.Equal(t.T(), mar1, reconciliation.EndDate)
+	assert.Len(t.T(), reconciliation.Periods, 2)
+
+	assert.Equal(t.T(), 200.10, reconciliation.Periods[0].BalanceChange)
+	assert.Equal(t.T(), 200.10, reconciliation.Periods[0].TransactionTotal)
+	assert.Equal(t.T(), 2, reconciliation.Periods[0].TransactionCount)
+	assert.True(t.T(), reconciliation.Periods[0].Reconciled)
+
+	assert.Equal(t.T(), 149.90, reconciliation.Periods[1].BalanceChange)
+	assert.Equal(t.T(), float64(50), reconciliation.Periods[1].TransactionTotal)
+	assert.Equal(t.T(), 1, reconciliation.Periods[1].TransactionCount)
+	assert.Equal(t.T(), 99.90, reconciliation.Periods[1].Difference)
+	assert.False(t.T(), reconciliation.Periods[1].Reconciled)
+
+	assert.False(t.T(), reconciliation.Reconciled)
+	assert.Equal(t.T(), 1, reconciliation.UnreconciledCount)
+	assert.Equal(t.T(), 99.90, reconciliation.UnreconciledTotal)
+}
+
+func (t *transactionsServiceTestSuite) TestReconcile_StartsAtPrecedingBalance() {
+	jan1 := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan15 := time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb1 := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	balances := []model.BankAccountBalance{
+		{BankAccountID: t.testBankAccountID, Date: jan1, Balance: 1000},
+		{BankAccountID: t.testBankAccountID, Date: feb1, Balance: 1300},
+	}
+	// a transaction before the requested start still counts towards the period starting at the balance before it
+	transactions := []model.Transaction{
+		{BankAccountID: t.testBankAccountID, Date: jan1.AddDate(0, 0, 5), Direction: model.TransactionDirectionCredit, Amount: 300},
+	}
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return(balances, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(t.getReconcileTransactionFilter(jan1, feb1)).Return(transactions, model.PageInfoOutput{}, nil)
+
+	reconciliation, err := t.svc.Reconcile(t.testBankAccountID, jan15, feb1)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), jan15, reconciliation.RequestedStartDate)
+	assert.Equal(t.T(), jan1, reconciliation.StartDate)
+	assert.Equal(t.T(), feb1, reconciliation.EndDate)
+	assert.Len(t.T(), reconciliation.Periods, 1)
+	assert.Equal(t.T(), 1, reconciliation.Periods[0].TransactionCount)
+	assert.True(t.T(), reconciliation.Reconciled)
+}
+
+func (t *transactionsServiceTestSuite) TestReconcile_NoBalances() {
+	now := time.Now()
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).
+		Return([]model.BankAccount{t.getBankAccount(model.BankAccountStatusActive)}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveBalancesByFilter(gomock.Any()).Return([]model.BankAccountBalance{}, model.PageInfoOutput{}, nil)
+	t.mockRepo.EXPECT().ResolveByFilter(t.getReconcileTransactionFilter(now.AddDate(-1, 0, 0), now)).Return([]model.Transaction{}, model.PageInfoOutput{}, nil)
+
+	reconciliation, err := t.svc.Reconcile(t.testBankAccountID, now.AddDate(-1, 0, 0), now)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), reconciliation.RequestedStartDate, reconciliation.StartDate)
+	assert.Equal(t.T(), reconciliation.RequestedEndDate, reconciliation.EndDate)
+	assert.Len(t.T(), reconciliation.Periods, 0)
+	assert.True(t.T(), reconciliation.Reconciled)
+}
+
+func (t *transactionsServiceTestSuite) TestReconcile_InvalidPeriod() {
+	now := time.Now()
+
+	reconciliation, err := t.svc.Reconcile(t.testBankAccountID, now, now.AddDate(0, 0, -1))
+
+	assert.Nil(t.T(), reconciliation)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *transactionsServiceTestSuite) TestReconcile_BankAccountNotFound() {
+	now := time.Now()
+
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testBankAccountID}).Return([]model.BankAccount{}, nil)
+
+	reconciliation, err := t.svc.Reconcile(t.testBankAccountID, now.AddDate(-1, 0, 0), now)
+
+	assert.Nil(t.T(), reconciliation)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
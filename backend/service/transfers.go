@@ -0,0 +1,139 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/repository"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// TransferImpl is the service provider implementation
+type TransferImpl struct {
+	Repository            repository.Transfer    `inject:"transferRepository"`
+	BankAccountRepository repository.BankAccount `inject:"bankAccountRepository"`
+	TransactionRepository repository.Transaction `inject:"transactionRepository"`
+}
+
+// Startup performs startup functions
+func (s *TransferImpl) Startup() {
+	logger.Trace("Transfer Service starting up...")
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *TransferImpl) Shutdown() {
+	logger.Trace("Transfer Service shutting down...")
+}
+
+// Create moves an amount from one Bank Account to another, recording a new balance and a Transaction on both sides
+func (s *TransferImpl) Create(input model.TransferInput, userID uuid.UUID) (*model.Transfer, error) {
+	err := input.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := s.resolveBankAccount("create transfer", input.FromBankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := s.resolveBankAccount("create transfer", input.ToBankAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	// transfers only ever append to the balance history of both accounts, as a transfer dated before
+	// a later balance would leave that balance out of step with it
+	for _, bankAccount := range []*model.BankAccount{from, to} {
+		if input.Date.Time().Before(bankAccount.LastBalanceDate) {
+			return nil, failure.BadRequestFromString("the transfer date must not be before the last balance of " + bankAccount.AccountName)
+		}
+	}
+
+	transfer := model.NewTransferFromInput(input, *from, *to, userID)
+	err = s.Repository.Create(transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+// GetByID fetches a Transfer by its ID
+func (s *TransferImpl) GetByID(id uuid.UUID) (*model.Transfer, error) {
+	transfers, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transfers) != 1 {
+		return nil, failure.EntityNotFound("get by ID", "Transfer")
+	}
+
+	return &transfers[0], nil
+}
+
+// GetByFilter fetches a set of Transfers by its filter
+func (s *TransferImpl) GetByFilter(input model.TransferFilterInput) ([]model.Transfer, model.PageInfoOutput, error) {
+	return s.Repository.ResolveByFilter(input.ToFilter())
+}
+
+// Delete deletes an existing Transfer along with the balances and Transactions it recorded on both sides,
+// returning each Bank Account to the balance it had before. Only a Transfer whose balances are still the
+// latest on both Bank Accounts can be deleted.
+func (s *TransferImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Transfer, error) {
+	transfers, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transfers) != 1 {
+		return nil, failure.EntityNotFound("delete", "Transfer")
+	}
+
+	transfer := transfers[0]
+
+	err = transfer.Delete(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bankAccountID := range []uuid.UUID{transfer.FromBankAccountID, transfer.ToBankAccountID} {
+		_, err = s.resolveBankAccount("delete transfer", bankAccountID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the balances are checked and rolled back by the repository, which reads and writes them together
+	transfer, err = s.Repository.Delete(transfer, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
+
+// resolveBankAccount resolves one side of a Transfer, which must be neither deleted nor inactive
+func (s *TransferImpl) resolveBankAccount(operation string, id uuid.UUID) (*model.BankAccount, error) {
+	bankAccounts, err := s.BankAccountRepository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bankAccounts) != 1 {
+		return nil, failure.EntityNotFound(operation, "Bank Account")
+	}
+
+	bankAccount := bankAccounts[0]
+
+	if bankAccount.Deleted.Valid {
+		return nil, failure.OperationNotPermitted(operation, "Bank Account", "the Bank Account is already deleted")
+	}
+
+	if bankAccount.Status == model.BankAccountStatusInactive {
+		return nil, failure.OperationNotPermitted(operation, "Bank Account", "the Bank Account is inactive")
+	}
+
+	return &bankAccount, nil
+}
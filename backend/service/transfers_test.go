@@ -0,0 +1,250 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/guregu/null"
+	mock_repository "github.com/kerti/balances/backend/mock/repository"
+	"github.com/kerti/balances/backend/model"
+	"github.com/kerti/balances/backend/service"
+	"github.com/kerti/balances/backend/util/cachetime"
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/nuuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type transfersServiceTestSuite struct {
+	suite.Suite
+	ctrl                *gomock.Controller
+	svc                 service.Transfer
+	mockRepo            *mock_repository.MockTransfer
+	mockBankAccountRepo *mock_repository.MockBankAccount
+	mockTransactionRepo *mock_repository.MockTransaction
+	testUserID          uuid.UUID
+	testFromAccountID   uuid.UUID
+	testToAccountID     uuid.UUID
+	testLastBalanceDate time.Time
+}
+
+func TestTransfersService(t *testing.T) {
+	suite.Run(t, new(transfersServiceTestSuite))
+}
+
+func (t *transfersServiceTestSuite) SetupTest() {
+	t.ctrl = gomock.NewController(t.T())
+	t.mockRepo = mock_repository.NewMockTransfer(t.ctrl)
+	t.mockBankAccountRepo = mock_repository.NewMockBankAccount(t.ctrl)
+	t.mockTransactionRepo = mock_repository.NewMockTransaction(t.ctrl)
+	t.svc = &service.TransferImpl{
+		Repository:            t.mockRepo,
+		BankAccountRepository: t.mockBankAccountRepo,
+		TransactionRepository: t.mockTransactionRepo,
+	}
+	t.testUserID, _ = uuid.NewV7()
+	t.testFromAccountID, _ = uuid.NewV7()
+	t.testToAccountID, _ = uuid.NewV7()
+	t.testLastBalanceDate = time.Now().AddDate(0, 0, -7)
+	t.svc.Startup()
+}
+
+func (t *transfersServiceTestSuite) TearDownTest() {
+	t.svc.Shutdown()
+	t.ctrl.Finish()
+}
+
+func (t *transfersServiceTestSuite) getBankAccount(id uuid.UUID, name string, lastBalance float64) model.BankAccount {
+	return model.BankAccount{
+		ID:              id,
+		AccountName:     name,
+		LastBalance:     lastBalance,
+		LastBalanceDate: t.testLastBalanceDate,
+		Status:          model.BankAccountStatusActive,
+		Created:         time.Now(),
+		CreatedBy:       t.testUserID,
+	}
+}
+
+func (t *transfersServiceTestSuite) getTransferInput() model.TransferInput {
+	return model.TransferInput{
+		FromBankAccountID: t.testFromAccountID,
+		ToBankAccountID:   t.testToAccountID,
+		Date:              cachetime.CacheTime(time.Now()),
+		Amount:            float64(250),
+		Note:              " monthly savings ",
+	}
+}
+
+func (t *transfersServiceTestSuite) expectBankAccounts(from, to model.BankAccount) {
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testFromAccountID}).Return([]model.BankAccount{from}, nil)
+	t.mockBankAccountRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testToAccountID}).Return([]model.BankAccount{to}, nil)
+}
+
+func (t *transfersServiceTestSuite) getTransfer() model.Transfer {
+	transfer := model.NewTransferFromInput(
+		t.getTransferInput(),
+		t.getBankAccount(t.testFromAccountID, "Checking", float64(1000)),
+		t.getBankAccount(t.testToAccountID, "Savings", float64(500)),
+		t.testUserID)
+	return transfer
+}
+
+func (t *transfersServiceTestSuite) TestCreate_Normal() {
+	t.expectBankAccounts(
+		t.getBankAccount(t.testFromAccountID, "Checking", float64(1000)),
+		t.getBankAccount(t.testToAccountID, "Savings", float64(500)))
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	transfer, err := t.svc.Create(t.getTransferInput(), t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.Equal(t.T(), "monthly savings", transfer.Note)
+	assert.Len(t.T(), transfer.Balances, 2)
+	assert.Equal(t.T(), float64(750), transfer.Balances[0].Balance)
+	assert.Equal(t.T(), float64(750), transfer.Balances[1].Balance)
+	assert.Equal(t.T(), transfer.FromBalanceID, transfer.Balances[0].ID)
+	assert.Equal(t.T(), float64(750), transfer.BankAccounts[0].LastBalance)
+	assert.Equal(t.T(), float64(750), transfer.BankAccounts[1].LastBalance)
+	assert.Len(t.T(), transfer.Transactions, 2)
+	assert.Equal(t.T(), float64(-250), transfer.Transactions[0].SignedAmount())
+	assert.Equal(t.T(), "Savings", transfer.Transactions[0].Payee)
+	assert.Equal(t.T(), float64(250), transfer.Transactions[1].SignedAmount())
+	assert.Equal(t.T(), "Checking", transfer.Transactions[1].Payee)
+	assert.Equal(t.T(), transfer.ToTransactionID, transfer.Transactions[1].ID)
+}
+
+func (t *transfersServiceTestSuite) TestCreate_SameBankAccount() {
+	input := t.getTransferInput()
+	input.ToBankAccountID = input.FromBankAccountID
+
+	transfer, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), transfer)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *transfersServiceTestSuite) TestCreate_InvalidAmount() {
+	input := t.getTransferInput()
+	input.Amount = 0
+
+	transfer, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), transfer)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *transfersServiceTestSuite) TestCreate_BankAccountInactive() {
+	to := t.getBankAccount(t.testToAccountID, "Savings", float64(500))
+	to.Status = model.BankAccountStatusInactive
+
+	t.expectBankAccounts(t.getBankAccount(t.testFromAccountID, "Checking", float64(1000)), to)
+
+	transfer, err := t.svc.Create(t.getTransferInput(), t.testUserID)
+
+	assert.Nil(t.T(), transfer)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *transfersServiceTestSuite) TestCreate_BeforeLastBalance() {
+	t.expectBankAccounts(
+		t.getBankAccount(t.testFromAccountID, "Checking", float64(1000)),
+		t.getBankAccount(t.testToAccountID, "Savings", float64(500)))
+
+	input := t.getTransferInput()
+	input.Date = cachetime.CacheTime(t.testLastBalanceDate.AddDate(0, 0, -1))
+
+	transfer, err := t.svc.Create(input, t.testUserID)
+
+	assert.Nil(t.T(), transfer)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+}
+
+func (t *transfersServiceTestSuite) TestCreate_ErrorCreating() {
+	t.expectBankAccounts(
+		t.getBankAccount(t.testFromAccountID, "Checking", float64(1000)),
+		t.getBankAccount(t.testToAccountID, "Savings", float64(500)))
+	t.mockRepo.EXPECT().Create(gomock.Any()).Return(errors.New(""))
+
+	transfer, err := t.svc.Create(t.getTransferInput(), t.testUserID)
+
+	assert.Nil(t.T(), transfer)
+	assert.NotNil(t.T(), err)
+}
+
+func (t *transfersServiceTestSuite) TestGetByID_NotFound() {
+	id, _ := uuid.NewV7()
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{id}).Return([]model.Transfer{}, nil)
+
+	transfer, err := t.svc.GetByID(id)
+
+	assert.Nil(t.T(), transfer)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+}
+
+func (t *transfersServiceTestSuite) TestDelete_Normal() {
+	created := t.getTransfer()
+	stored := created
+	stored.BankAccounts, stored.Balances, stored.Transactions = nil, nil, nil
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{created.ID}).Return([]model.Transfer{stored}, nil)
+	t.expectBankAccounts(created.BankAccounts[0], created.BankAccounts[1])
+	t.mockRepo.EXPECT().Delete(gomock.Any(), t.testUserID).
+		DoAndReturn(func(transfer model.Transfer, userID uuid.UUID) (model.Transfer, error) {
+			assert.True(t.T(), transfer.Deleted.Valid)
+			assert.Equal(t.T(), nuuid.From(t.testUserID), transfer.DeletedBy)
+			transfer.BankAccounts = created.BankAccounts
+			return transfer, nil
+		})
+
+	transfer, err := t.svc.Delete(created.ID, t.testUserID)
+
+	assert.Nil(t.T(), err)
+	assert.True(t.T(), transfer.Deleted.Valid)
+	assert.Equal(t.T(), nuuid.From(t.testUserID), transfer.DeletedBy)
+	assert.Len(t.T(), transfer.BankAccounts, 2)
+}
+
+func (t *transfersServiceTestSuite) TestDelete_BankAccountInactive() {
+	created := t.getTransfer()
+	to := created.BankAccounts[1]
+	to.Status = model.BankAccountStatusInactive
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{created.ID}).Return([]model.Transfer{created}, nil)
+	t.expectBankAccounts(created.BankAccounts[0], to)
+
+	transfer, err := t.svc.Delete(created.ID, t.testUserID)
+
+	assert.Nil(t.T(), transfer)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *transfersServiceTestSuite) TestDelete_NewerBalance() {
+	created := t.getTransfer()
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{created.ID}).Return([]model.Transfer{created}, nil)
+	t.expectBankAccounts(created.BankAccounts[0], created.BankAccounts[1])
+	t.mockRepo.EXPECT().Delete(gomock.Any(), t.testUserID).
+		Return(model.Transfer{}, failure.OperationNotPermitted("delete", "Transfer", "a newer balance has been recorded on Checking"))
+
+	transfer, err := t.svc.Delete(created.ID, t.testUserID)
+
+	assert.Nil(t.T(), transfer)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
+
+func (t *transfersServiceTestSuite) TestDelete_AlreadyDeleted() {
+	created := t.getTransfer()
+	created.Deleted = null.TimeFrom(time.Now())
+	created.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{created.ID}).Return([]model.Transfer{created}, nil)
+
+	transfer, err := t.svc.Delete(created.ID, t.testUserID)
+
+	assert.Nil(t.T(), transfer)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+}
@@ -13,7 +13,8 @@ import (
 
 // VehicleImpl is the service provider implementation
 type VehicleImpl struct {
-	Repository repository.Vehicle `inject:"vehicleRepository"`
+	Repository     repository.Vehicle `inject:"vehicleRepository"`
+	NoteRepository repository.Note    `inject:"noteRepository"`
 }
 
 // Startup performs startup functions
@@ -74,6 +75,13 @@ func (s *VehicleImpl) GetByID(id uuid.UUID, withValues bool, valueStartDate, val
 		vehicle.AttachValues(values, true)
 	}
 
+	notes, err := s.NoteRepository.ResolveBySubjectIDs(vehicle.GetNoteSubjectIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle.AttachNotes(notes)
+
 	return &vehicle, nil
 }
 
@@ -82,6 +90,11 @@ func (s *VehicleImpl) GetByFilter(input model.VehicleFilterInput) ([]model.Vehic
 	return s.Repository.ResolveByFilter(input.ToFilter())
 }
 
+// AggregateByFilter groups a set of Vehicles by its filter and aggregates every group
+func (s *VehicleImpl) AggregateByFilter(input model.VehicleFilterInput) ([]model.AggregateRow, error) {
+	return s.Repository.AggregateByFilter(input.ToFilter())
+}
+
 // Update updates an existing Vehicle
 func (s *VehicleImpl) Update(input model.VehicleInput, userID uuid.UUID) (*model.Vehicle, error) {
 	vehicles, err := s.Repository.ResolveByIDs([]uuid.UUID{input.ID})
@@ -154,6 +167,54 @@ func (s *VehicleImpl) Delete(id uuid.UUID, userID uuid.UUID) (*model.Vehicle, er
 	return &vehicle, err
 }
 
+// Restore restores a deleted Vehicle. The method will find all the vehicle's values, deleted or
+// not, and restore those that were deleted together with the Vehicle.
+func (s *VehicleImpl) Restore(id uuid.UUID, userID uuid.UUID) (*model.Vehicle, error) {
+	vehicles, err := s.Repository.ResolveByIDs([]uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vehicles) != 1 {
+		return nil, failure.EntityNotFound("restore", "Vehicle")
+	}
+
+	vehicle := vehicles[0]
+
+	// pre-validate to save one database call
+	if vehicle.Deleted.Valid || vehicle.DeletedBy.Valid {
+		filter := model.VehicleValueFilterInput{}
+		filter.VehicleIDs = &[]uuid.UUID{vehicle.ID}
+
+		page := 1
+		pageSize := math.MaxInt
+		includeDeleted := true
+
+		filter.Page = &page
+		filter.PageSize = &pageSize
+		filter.IncludeDeleted = &includeDeleted
+
+		values, _, err := s.Repository.ResolveValuesByFilter(filter.ToFilter())
+		if err != nil {
+			return nil, err
+		}
+
+		vehicle.AttachValues(values, true)
+	}
+
+	err = vehicle.Restore(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.Update(vehicle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vehicle, err
+}
+
 // CreateValue creates a new Vehicle Value
 func (s *VehicleImpl) CreateValue(input model.VehicleValueInput, userID uuid.UUID) (*model.VehicleValue, error) {
 	vehicles, err := s.Repository.ResolveByIDs([]uuid.UUID{input.VehicleID})
@@ -202,6 +263,65 @@ func (s *VehicleImpl) CreateValue(input model.VehicleValueInput, userID uuid.UUI
 	return &vehicleValue, nil
 }
 
+// ImportValues validates a history of Vehicle Values from CSV and, unless it is a dry run,
+// imports every Value at once, updating the Vehicle's current value only once
+func (s *VehicleImpl) ImportValues(input model.VehicleValueImportInput, userID uuid.UUID) (*model.ImportResult, error) {
+	vehicles, err := s.Repository.ResolveByIDs([]uuid.UUID{input.VehicleID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(vehicles) != 1 {
+		return nil, failure.EntityNotFound("import values", "Vehicle")
+	}
+
+	vehicle := vehicles[0]
+
+	if vehicle.Deleted.Valid || vehicle.DeletedBy.Valid {
+		return nil, failure.OperationNotPermitted("import values", "Vehicle", "the Vehicle is already deleted")
+	}
+
+	if vehicle.Status == model.VehicleStatusSold {
+		return nil, failure.OperationNotPermitted("import values", "Vehicle", "the Vehicle has been sold")
+	}
+
+	rows, rowErrors, err := input.ParseRows()
+	if err != nil {
+		return nil, err
+	}
+
+	result := model.NewImportResult(input.DryRun, rows, rowErrors)
+	if !result.IsValid() || result.DryRun {
+		return &result, nil
+	}
+
+	vehicleValues := make([]model.VehicleValue, 0, len(rows))
+	for _, row := range rows {
+		vehicleValues = append(vehicleValues, model.NewVehicleValueFromInput(input.ToValueInput(row), vehicle.ID, userID))
+	}
+
+	lastValues, err := s.Repository.ResolveLastValuesByVehicleID(vehicle.ID, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	latestInput := input.ToValueInput(model.GetLatestImportRow(rows))
+	var vehicleToUpdate *model.Vehicle
+
+	if len(lastValues) == 0 || lastValues[0].Date.Before(latestInput.Date.Time()) {
+		vehicle.SetCurrentValue(latestInput, userID)
+		vehicleToUpdate = &vehicle
+	}
+
+	err = s.Repository.ImportValues(vehicleValues, vehicleToUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = len(vehicleValues)
+	return &result, nil
+}
+
 // GetValueByID fetches a Vehicle Value by its ID
 func (s *VehicleImpl) GetValueByID(id uuid.UUID) (*model.VehicleValue, error) {
 	values, err := s.Repository.ResolveValuesByIDs([]uuid.UUID{id})
@@ -213,7 +333,16 @@ func (s *VehicleImpl) GetValueByID(id uuid.UUID) (*model.VehicleValue, error) {
 		return nil, failure.EntityNotFound("get by ID", "Vehicle Value")
 	}
 
-	return &values[0], nil
+	value := values[0]
+
+	notes, err := s.NoteRepository.ResolveBySubjectIDs([]uuid.UUID{value.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	value.AttachNotes(notes)
+
+	return &value, nil
 }
 
 // GetValuesByFilter fetches a set of Vehicle Values by its filter
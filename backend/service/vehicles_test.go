@@ -24,6 +24,7 @@ type vehiclesServiceTestSuite struct {
 	ctrl               *gomock.Controller
 	svc                service.Vehicle
 	mockRepo           *mock_repository.MockVehicle
+	mockNoteRepo       *mock_repository.MockNote
 	testUserID         uuid.UUID
 	testVehicleID      uuid.UUID
 	testVehicleValueID uuid.UUID
@@ -36,8 +37,10 @@ func TestVehiclesService(t *testing.T) {
 func (t *vehiclesServiceTestSuite) SetupTest() {
 	t.ctrl = gomock.NewController(t.T())
 	t.mockRepo = mock_repository.NewMockVehicle(t.ctrl)
+	t.mockNoteRepo = mock_repository.NewMockNote(t.ctrl)
 	t.svc = &service.VehicleImpl{
-		Repository: t.mockRepo,
+		Repository:     t.mockRepo,
+		NoteRepository: t.mockNoteRepo,
 	}
 	t.testUserID, _ = uuid.NewV7()
 	t.testVehicleID, _ = uuid.NewV7()
@@ -218,6 +221,9 @@ func (t *vehiclesServiceTestSuite) TestGetByID_Exists_NoValue() {
 	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
 		Return(resolvedVehicleSlice, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testVehicleID, false, cachetime.NCacheTime{}, cachetime.NCacheTime{}, nil)
 
 	assert.NoError(t.T(), err)
@@ -242,6 +248,9 @@ func (t *vehiclesServiceTestSuite) TestGetByID_Exists_WithValue_NoFilter() {
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testVehicleID, true, cachetime.NCacheTime{}, cachetime.NCacheTime{}, nil)
 
 	assert.NoError(t.T(), err)
@@ -268,6 +277,9 @@ func (t *vehiclesServiceTestSuite) TestGetByID_Exists_WithValue_WithStartDate()
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testVehicleID, true, yesterday, cachetime.NCacheTime{}, nil)
 
 	assert.NoError(t.T(), err)
@@ -294,6 +306,9 @@ func (t *vehiclesServiceTestSuite) TestGetByID_Exists_WithValue_WithEndDate() {
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testVehicleID, true, cachetime.NCacheTime{}, today, nil)
 
 	assert.NoError(t.T(), err)
@@ -322,6 +337,9 @@ func (t *vehiclesServiceTestSuite) TestGetByID_Exists_WithValue_WithBothDates()
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testVehicleID, true, yesterday, today, nil)
 
 	assert.NoError(t.T(), err)
@@ -350,6 +368,9 @@ func (t *vehiclesServiceTestSuite) TestGetByID_Exists_WithValue_WithPageSize() {
 	t.mockRepo.EXPECT().ResolveValuesByFilter(valueFilterInput.ToFilter()).
 		Return(valueSlice, pageInfo, nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	_, err := t.svc.GetByID(t.testVehicleID, true, cachetime.NCacheTime{}, cachetime.NCacheTime{}, &pageSize)
 
 	assert.NoError(t.T(), err)
@@ -572,6 +593,8 @@ func (t *vehiclesServiceTestSuite) TestDelete_Normal() {
 	for _, resValue := range res.Values {
 		assert.True(t.T(), resValue.Deleted.Valid)
 		assert.True(t.T(), resValue.DeletedBy.Valid)
+		assert.True(t.T(), resValue.DeletedByCascade)
+		assert.Equal(t.T(), res.Deleted, resValue.Deleted)
 	}
 }
 
@@ -710,6 +733,135 @@ func (t *vehiclesServiceTestSuite) TestDelete_RepoErrorUpdating() {
 	assert.Nil(t.T(), res)
 }
 
+func (t *vehiclesServiceTestSuite) TestRestore_Normal() {
+	deletedAt := time.Now().AddDate(0, 0, -1)
+
+	// deleted together with the Vehicle
+	cascadedValue := t.getNewVehicleValue(
+		nuuid.NUUID{},
+		nuuid.From(t.testVehicleID),
+		float64(10000),
+		time.Now().AddDate(0, 0, -2))
+	cascadedValue.Deleted = null.TimeFrom(deletedAt)
+	cascadedValue.DeletedBy = nuuid.From(t.testUserID)
+	cascadedValue.DeletedByCascade = true
+
+	// deleted on its own in the same second and by the same user as the Vehicle
+	independentValue := t.getNewVehicleValue(
+		nuuid.NUUID{},
+		nuuid.From(t.testVehicleID),
+		float64(12000),
+		time.Now().AddDate(0, 0, -3))
+	independentValue.Deleted = null.TimeFrom(deletedAt)
+	independentValue.DeletedBy = nuuid.From(t.testUserID)
+
+	testVehicle := t.getNewVehicle(nuuid.From(t.testVehicleID), nil)
+	testVehicle.Deleted = null.TimeFrom(deletedAt)
+	testVehicle.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{testVehicle}, nil)
+
+	t.mockRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).
+		Return([]model.VehicleValue{cascadedValue, independentValue}, getDefaultPageInfo(), nil)
+
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	res, err := t.svc.Restore(t.testVehicleID, t.testUserID)
+
+	assert.NoError(t.T(), err)
+
+	assert.NotNil(t.T(), res)
+	assert.False(t.T(), res.Deleted.Valid)
+	assert.False(t.T(), res.DeletedBy.Valid)
+	assert.True(t.T(), res.UpdatedBy.Valid)
+
+	assert.Len(t.T(), res.Values, 1)
+	assert.Equal(t.T(), cascadedValue.ID, res.Values[0].ID)
+	assert.False(t.T(), res.Values[0].Deleted.Valid)
+	assert.False(t.T(), res.Values[0].DeletedBy.Valid)
+	assert.False(t.T(), res.Values[0].DeletedByCascade)
+}
+
+func (t *vehiclesServiceTestSuite) TestRestore_RepoErrorResolvingByIDs() {
+	errMsg := "failed resolving by IDs"
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{}, errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.testVehicleID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
+func (t *vehiclesServiceTestSuite) TestRestore_NotFound() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{}, nil)
+
+	res, err := t.svc.Restore(t.testVehicleID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "EntityNotFound")
+	assert.Nil(t.T(), res)
+}
+
+func (t *vehiclesServiceTestSuite) TestRestore_NotDeleted() {
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{t.getNewVehicle(nuuid.From(t.testVehicleID), nil)}, nil)
+
+	res, err := t.svc.Restore(t.testVehicleID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), "restore")
+	assert.Contains(t.T(), err.Error(), "Vehicle")
+	assert.Contains(t.T(), err.Error(), "not deleted")
+	assert.Nil(t.T(), res)
+}
+
+func (t *vehiclesServiceTestSuite) TestRestore_RepoErrorResolvingValuesByFilter() {
+	errMsg := "failed resolving values"
+
+	testVehicle := t.getNewVehicle(nuuid.From(t.testVehicleID), nil)
+	testVehicle.Deleted = null.TimeFrom(time.Now())
+	testVehicle.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{testVehicle}, nil)
+
+	t.mockRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).
+		Return([]model.VehicleValue{}, model.PageInfoOutput{}, errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.testVehicleID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
+func (t *vehiclesServiceTestSuite) TestRestore_RepoErrorUpdating() {
+	errMsg := "failed updating"
+
+	testVehicle := t.getNewVehicle(nuuid.From(t.testVehicleID), nil)
+	testVehicle.Deleted = null.TimeFrom(time.Now())
+	testVehicle.DeletedBy = nuuid.From(t.testUserID)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{testVehicle}, nil)
+
+	t.mockRepo.EXPECT().ResolveValuesByFilter(gomock.Any()).
+		Return([]model.VehicleValue{}, getDefaultPageInfo(), nil)
+
+	t.mockRepo.EXPECT().Update(gomock.Any()).Return(errors.New(errMsg))
+
+	res, err := t.svc.Restore(t.testVehicleID, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
 func (t *vehiclesServiceTestSuite) TestCreateValue_Normal_CurrentValue() {
 	testValueDate := time.Now()
 	testInput := t.getNewVehicleValueInput(
@@ -972,6 +1124,189 @@ func (t *vehiclesServiceTestSuite) TestCreateValue_RepoFailedCreatingVehicleValu
 	assert.Nil(t.T(), res)
 }
 
+func (t *vehiclesServiceTestSuite) getNewVehicleValueImportInput(csv string, dryRun bool) model.VehicleValueImportInput {
+	return model.VehicleValueImportInput{
+		ImportInput: model.ImportInput{
+			CSV:     csv,
+			Mapping: model.ImportColumnMapping{Date: "Appraised", Amount: "Value"},
+			DryRun:  dryRun,
+		},
+		VehicleID: t.testVehicleID,
+	}
+}
+
+func (t *vehiclesServiceTestSuite) TestImportValues_Normal_UpdatesCurrentValueOnce() {
+	testInput := t.getNewVehicleValueImportInput("Appraised,Value\n2024-01-31,1000.5\n2024-03-31,3000\n2024-02-29,2000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{t.getNewVehicle(nuuid.From(t.testVehicleID), nil)}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastValuesByVehicleID(t.testVehicleID, 1).
+		Return(
+			[]model.VehicleValue{
+				t.getNewVehicleValue(
+					nuuid.NUUID{},
+					nuuid.From(t.testVehicleID),
+					float64(500),
+					time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC))},
+			nil)
+
+	t.mockRepo.EXPECT().ImportValues(gomock.Len(3), gomock.Not(gomock.Nil())).
+		DoAndReturn(func(vehicleValues []model.VehicleValue, vehicle *model.Vehicle) error {
+			assert.Equal(t.T(), float64(1000.5), vehicleValues[0].Value)
+			assert.Equal(t.T(), t.testVehicleID, vehicleValues[0].VehicleID)
+			assert.Equal(t.T(), t.testUserID, vehicleValues[0].CreatedBy)
+			assert.Equal(t.T(), float64(3000), vehicle.CurrentValue)
+			assert.Equal(t.T(), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), vehicle.CurrentValueDate)
+			return nil
+		})
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), res.IsValid())
+	assert.Equal(t.T(), 3, res.Rows)
+	assert.Equal(t.T(), 3, res.Imported)
+}
+
+func (t *vehiclesServiceTestSuite) TestImportValues_Normal_OlderThanCurrentValue() {
+	testInput := t.getNewVehicleValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{t.getNewVehicle(nuuid.From(t.testVehicleID), nil)}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastValuesByVehicleID(t.testVehicleID, 1).
+		Return(
+			[]model.VehicleValue{
+				t.getNewVehicleValue(
+					nuuid.NUUID{},
+					nuuid.From(t.testVehicleID),
+					float64(9000),
+					time.Now())},
+			nil)
+
+	t.mockRepo.EXPECT().ImportValues(gomock.Len(1), nil).Return(nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), 1, res.Imported)
+}
+
+func (t *vehiclesServiceTestSuite) TestImportValues_DryRun() {
+	testInput := t.getNewVehicleValueImportInput("Appraised,Value\n2024-01-31,1000\n2024-02-29,2000\n", true)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{t.getNewVehicle(nuuid.From(t.testVehicleID), nil)}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.True(t.T(), res.IsValid())
+	assert.True(t.T(), res.DryRun)
+	assert.Equal(t.T(), 2, res.Rows)
+	assert.Equal(t.T(), 0, res.Imported)
+}
+
+func (t *vehiclesServiceTestSuite) TestImportValues_RowErrors() {
+	testInput := t.getNewVehicleValueImportInput(
+		"Appraised,Value\n2024-01-31,1000\n31/02/2024,abc\n2024-01-31,1500\n2024-03-31\n",
+		false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{t.getNewVehicle(nuuid.From(t.testVehicleID), nil)}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.NoError(t.T(), err)
+	assert.False(t.T(), res.IsValid())
+	assert.Equal(t.T(), 4, res.Rows)
+	assert.Equal(t.T(), 0, res.Imported)
+	assert.Len(t.T(), res.Errors, 4)
+	assert.Equal(t.T(), 3, res.Errors[0].Line)
+	assert.Equal(t.T(), "Appraised", *res.Errors[0].Column)
+	assert.Equal(t.T(), "Value", *res.Errors[1].Column)
+	assert.Contains(t.T(), res.Errors[2].Message, "line 2")
+	assert.Equal(t.T(), 5, res.Errors[3].Line)
+	assert.Nil(t.T(), res.Errors[3].Column)
+}
+
+func (t *vehiclesServiceTestSuite) TestImportValues_InvalidMapping() {
+	testInput := t.getNewVehicleValueImportInput("Date,Value\n2024-01-31,1000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{t.getNewVehicle(nuuid.From(t.testVehicleID), nil)}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeBadRequest, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *vehiclesServiceTestSuite) TestImportValues_VehicleNotFound() {
+	testInput := t.getNewVehicleValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeEntityNotFound, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *vehiclesServiceTestSuite) TestImportValues_VehicleDeleted() {
+	testInput := t.getNewVehicleValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+	testVehicle := t.getNewVehicle(nuuid.From(t.testVehicleID), nil)
+	testVehicle.Deleted = null.TimeFrom(time.Now())
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{testVehicle}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *vehiclesServiceTestSuite) TestImportValues_VehicleSold() {
+	testInput := t.getNewVehicleValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+	testVehicle := t.getNewVehicle(nuuid.From(t.testVehicleID), nil)
+	testVehicle.Status = model.VehicleStatusSold
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{testVehicle}, nil)
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Equal(t.T(), failure.CodeOperationNotPermitted, failure.GetCode(err))
+	assert.Nil(t.T(), res)
+}
+
+func (t *vehiclesServiceTestSuite) TestImportValues_RepoFailedImporting() {
+	errMsg := "failed to import vehicle values"
+	testInput := t.getNewVehicleValueImportInput("Appraised,Value\n2024-01-31,1000\n", false)
+
+	t.mockRepo.EXPECT().ResolveByIDs([]uuid.UUID{t.testVehicleID}).
+		Return([]model.Vehicle{t.getNewVehicle(nuuid.From(t.testVehicleID), nil)}, nil)
+
+	t.mockRepo.EXPECT().ResolveLastValuesByVehicleID(t.testVehicleID, 1).
+		Return([]model.VehicleValue{}, nil)
+
+	t.mockRepo.EXPECT().ImportValues(gomock.Len(1), gomock.Not(gomock.Nil())).
+		Return(errors.New(errMsg))
+
+	res, err := t.svc.ImportValues(testInput, t.testUserID)
+
+	assert.Error(t.T(), err)
+	assert.Contains(t.T(), err.Error(), errMsg)
+	assert.Nil(t.T(), res)
+}
+
 func (t *vehiclesServiceTestSuite) TestGetValueByID_Normal() {
 	t.mockRepo.EXPECT().ResolveValuesByIDs([]uuid.UUID{t.testVehicleValueID}).
 		Return(
@@ -984,6 +1319,9 @@ func (t *vehiclesServiceTestSuite) TestGetValueByID_Normal() {
 			},
 			nil)
 
+	t.mockNoteRepo.EXPECT().ResolveBySubjectIDs(gomock.Any()).
+		Return([]model.Note{}, nil)
+
 	res, err := t.svc.GetValueByID(t.testVehicleValueID)
 
 	assert.NoError(t.T(), err)
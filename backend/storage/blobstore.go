@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound is returned when there is no blob stored under a key
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps the contents of files under keys chosen by the caller
+type BlobStore interface {
+	Startup()
+	Shutdown()
+	Put(key string, content io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/kerti/balances/backend/config"
+	"github.com/kerti/balances/backend/util/logger"
+)
+
+// LocalBlobStore is the blob store implemented with a directory on the local filesystem
+type LocalBlobStore struct {
+	Root string
+}
+
+// Startup perform startup functions
+func (s *LocalBlobStore) Startup() {
+	logger.Trace("Local blob store starting up...")
+	if s.Root == "" {
+		s.Root = config.Get().Attachment.StorePath
+	}
+
+	err := os.MkdirAll(s.Root, 0o750)
+	if err != nil {
+		logger.Err("Failed to prepare blob store directory [%s]: %v", s.Root, err)
+	}
+}
+
+// Shutdown cleans up everything and shuts down
+func (s *LocalBlobStore) Shutdown() {
+	logger.Trace("Local blob store shutting down...")
+}
+
+// Put stores the content under a key, replacing whatever was stored under it. The content is written
+// to a temporary file first, so that a failed write never leaves a partial blob behind.
+func (s *LocalBlobStore) Put(key string, content io.Reader) error {
+	path, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(s.Root, ".upload-*")
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = io.Copy(file, content)
+	if err != nil {
+		file.Close()
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+	}
+
+	return err
+}
+
+// Get opens the content stored under a key, which the caller must close
+func (s *LocalBlobStore) Get(key string) (io.ReadCloser, error) {
+	path, err := s.getPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+
+	if err != nil {
+		logger.ErrNoStack("%v", err)
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Delete removes the content stored under a key, if there is any
+func (s *LocalBlobStore) Delete(key string) error {
+	path, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.ErrNoStack("%v", err)
+		return err
+	}
+
+	return nil
+}
+
+// getPath resolves the file a key is stored in, making sure that the key cannot point outside the root directory
+func (s *LocalBlobStore) getPath(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || filepath.Base(key) != key {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+
+	return filepath.Join(s.Root, key), nil
+}
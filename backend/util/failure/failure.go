@@ -54,6 +54,16 @@ func Unauthorized(msg string) error {
 	}
 }
 
+// Forbidden returns a new Failure with code for operations the requesting user is not allowed to perform
+func Forbidden(operationName, entityName string, message string) error {
+	return &Failure{
+		Code:      CodeForbidden,
+		Operation: &operationName,
+		Entity:    &entityName,
+		Message:   message,
+	}
+}
+
 // InternalError returns a new Failure with code for internal error and message derived from an error interface
 func InternalError(operationName, entityName string, err error) error {
 	if err != nil {
@@ -111,6 +121,8 @@ var (
 	CodeBadRequest Code = "BadRequest"
 	// CodeUnauthorized us the string code for unauthorized requests
 	CodeUnauthorized Code = "Unauthorized"
+	// CodeForbidden is the string code for operations the requesting user is not allowed to perform
+	CodeForbidden Code = "Forbidden"
 	// CodeInternalError is the string code for internal errors
 	CodeInternalError Code = "InternalError"
 	// CodeUnimplemented is the string code for errors caused by unimplemented methods
@@ -0,0 +1,171 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kerti/balances/backend/util/failure"
+)
+
+// MaxAggregationGroups is the most fields an aggregation may group by
+const MaxAggregationGroups = 4
+
+// MaxAggregationAggregates is the most aggregates an aggregation may compute per group
+const MaxAggregationAggregates = 8
+
+// AggregateFunction represents an SQL aggregate function
+type AggregateFunction string
+
+const (
+	// AggregateFunctionCount counts the rows in a group, or the non-null values of a field
+	AggregateFunctionCount AggregateFunction = "count"
+	// AggregateFunctionSum sums the values of a field
+	AggregateFunctionSum AggregateFunction = "sum"
+	// AggregateFunctionAvg averages the values of a field
+	AggregateFunctionAvg AggregateFunction = "avg"
+	// AggregateFunctionMin finds the lowest value of a field
+	AggregateFunctionMin AggregateFunction = "min"
+	// AggregateFunctionMax finds the highest value of a field
+	AggregateFunctionMax AggregateFunction = "max"
+)
+
+// AggregateFunctionMap is the map of aggregate functions to its query string equivalent
+var AggregateFunctionMap = map[AggregateFunction]string{
+	AggregateFunctionCount: "COUNT",
+	AggregateFunctionSum:   "SUM",
+	AggregateFunctionAvg:   "AVG",
+	AggregateFunctionMin:   "MIN",
+	AggregateFunctionMax:   "MAX",
+}
+
+// Group represents a field an aggregation groups by, along with its name in the API
+type Group struct {
+	Name  string
+	Field Field
+}
+
+// Aggregate represents a single aggregate computed for every group, along with its name in the API
+type Aggregate struct {
+	Name     string
+	Function AggregateFunction
+	// Field is nil when counting rows
+	Field *Field
+}
+
+// ToQueryString returns the string representation of the aggregate
+func (a *Aggregate) ToQueryString() string {
+	if a.Field == nil {
+		return fmt.Sprintf("%s(*)", AggregateFunctionMap[a.Function])
+	}
+	return fmt.Sprintf("%s(%s)", AggregateFunctionMap[a.Function], *a.Field)
+}
+
+// Aggregation represents the grouping part of an SQL query, which turns a filtered result set
+// into one row per group holding the group's fields followed by its aggregates
+type Aggregation struct {
+	Groups     []Group
+	Aggregates []Aggregate
+}
+
+// ToSelectString returns the SELECT part of the aggregation query, up to and including FROM
+func (a *Aggregation) ToSelectString() string {
+	columns := make([]string, 0, len(a.Groups)+len(a.Aggregates))
+	for _, group := range a.Groups {
+		columns = append(columns, string(group.Field))
+	}
+	for _, aggregate := range a.Aggregates {
+		columns = append(columns, aggregate.ToQueryString())
+	}
+	return "SELECT " + strings.Join(columns, ", ") + " FROM "
+}
+
+// ToGroupString returns the GROUP BY part of the aggregation query, ordering the groups by their fields
+func (a *Aggregation) ToGroupString() string {
+	if len(a.Groups) == 0 {
+		return " "
+	}
+
+	fields := make([]string, 0, len(a.Groups))
+	for _, group := range a.Groups {
+		fields = append(fields, string(group.Field))
+	}
+	groupStr := strings.Join(fields, ", ")
+
+	return " GROUP BY " + groupStr + " ORDER BY " + groupStr + " "
+}
+
+// AggregateInput is the input object for a single aggregate
+type AggregateInput struct {
+	Function AggregateFunction `json:"function"`
+	Field    *string           `json:"field,omitempty"`
+}
+
+// AggregationInput is the input object for an aggregation
+type AggregationInput struct {
+	GroupBy    []string         `json:"groupBy"`
+	Aggregates []AggregateInput `json:"aggregates"`
+}
+
+// ToAggregation converts the input to an aggregation, validating every field against a whitelist
+// of fields keyed by their names in the API
+func (a *AggregationInput) ToAggregation(fields map[string]Field) (*Aggregation, error) {
+	if a == nil {
+		return nil, nil
+	}
+
+	if len(a.GroupBy) > MaxAggregationGroups {
+		return nil, failure.BadRequestFromString(fmt.Sprintf("aggregation groups by more than %d fields", MaxAggregationGroups))
+	}
+	if len(a.Aggregates) == 0 || len(a.Aggregates) > MaxAggregationAggregates {
+		return nil, failure.BadRequestFromString(fmt.Sprintf("aggregation requires between 1 and %d aggregates", MaxAggregationAggregates))
+	}
+
+	aggregation := Aggregation{
+		Groups:     make([]Group, 0, len(a.GroupBy)),
+		Aggregates: make([]Aggregate, 0, len(a.Aggregates)),
+	}
+	names := make(map[string]bool)
+
+	for _, name := range a.GroupBy {
+		field, ok := fields[name]
+		if !ok {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("unsupported aggregation group field: %s", name))
+		}
+		if names[name] {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("duplicate aggregation group field: %s", name))
+		}
+		names[name] = true
+		aggregation.Groups = append(aggregation.Groups, Group{Name: name, Field: field})
+	}
+
+	names = make(map[string]bool)
+	for _, aggregateInput := range a.Aggregates {
+		if _, ok := AggregateFunctionMap[aggregateInput.Function]; !ok {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("unsupported aggregate function: %s", aggregateInput.Function))
+		}
+
+		aggregate := Aggregate{
+			Name:     string(aggregateInput.Function),
+			Function: aggregateInput.Function,
+		}
+
+		if aggregateInput.Field != nil {
+			field, ok := fields[*aggregateInput.Field]
+			if !ok {
+				return nil, failure.BadRequestFromString(fmt.Sprintf("unsupported aggregate field: %s", *aggregateInput.Field))
+			}
+			aggregate.Name = string(aggregateInput.Function) + strings.ToUpper((*aggregateInput.Field)[:1]) + (*aggregateInput.Field)[1:]
+			aggregate.Field = &field
+		} else if aggregateInput.Function != AggregateFunctionCount {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("aggregate function %s requires a field", aggregateInput.Function))
+		}
+
+		if names[aggregate.Name] {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("duplicate aggregate: %s", aggregate.Name))
+		}
+		names[aggregate.Name] = true
+		aggregation.Aggregates = append(aggregation.Aggregates, aggregate)
+	}
+
+	return &aggregation, nil
+}
@@ -0,0 +1,207 @@
+package filter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kerti/balances/backend/util/failure"
+)
+
+// PaginationMode indicates how a result set is paged through
+type PaginationMode string
+
+const (
+	// PaginationModeOffset pages through a result set using LIMIT and OFFSET
+	PaginationModeOffset PaginationMode = "offset"
+	// PaginationModeCursor pages through a result set using opaque cursors
+	PaginationModeCursor PaginationMode = "cursor"
+)
+
+// CursorDirection indicates which neighbouring page a cursor points to
+type CursorDirection string
+
+const (
+	// CursorDirectionNext points to the page with older rows
+	CursorDirectionNext CursorDirection = "next"
+	// CursorDirectionPrev points to the page with newer rows
+	CursorDirectionPrev CursorDirection = "prev"
+)
+
+// Cursor represents a position in a keyset-paginated result set
+type Cursor struct {
+	Date      time.Time       `json:"d"`
+	ID        uuid.UUID       `json:"i"`
+	Direction CursorDirection `json:"r"`
+}
+
+// Encode returns the opaque string representation of the cursor
+func (c Cursor) Encode() string {
+	cursorJSON, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(cursorJSON)
+}
+
+// DecodeCursor parses a cursor from its opaque string representation
+func DecodeCursor(encoded string) (*Cursor, error) {
+	cursorJSON, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, failure.BadRequestFromString("malformed cursor")
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(cursorJSON, &cursor)
+	if err != nil {
+		return nil, failure.BadRequestFromString("malformed cursor")
+	}
+
+	if cursor.Direction != CursorDirectionNext && cursor.Direction != CursorDirectionPrev {
+		return nil, failure.BadRequestFromString(fmt.Sprintf("unsupported cursor direction: %s", cursor.Direction))
+	}
+
+	return &cursor, nil
+}
+
+// Keyset represents the columns a keyset-paginated result set is ordered by, newest first,
+// along with the position to continue from
+type Keyset struct {
+	DateField Field
+	IDField   Field
+	Cursor    *Cursor
+}
+
+func (k *Keyset) isBackward() bool {
+	return k.Cursor != nil && k.Cursor.Direction == CursorDirectionPrev
+}
+
+// getClause returns the clause selecting the rows past the cursor, or nil when starting from the first page
+func (k *Keyset) getClause() *Clause {
+	if k.Cursor == nil {
+		return nil
+	}
+
+	operator := OperatorLessThan
+	if k.isBackward() {
+		operator = OperatorGreaterThan
+	}
+
+	return &Clause{
+		Operand1: Clause{
+			Operand1: k.DateField,
+			Operand2: k.Cursor.Date,
+			Operator: operator,
+		},
+		Operand2: Clause{
+			Operand1: Clause{
+				Operand1: k.DateField,
+				Operand2: k.Cursor.Date,
+				Operator: OperatorEqual,
+			},
+			Operand2: Clause{
+				Operand1: k.IDField,
+				Operand2: k.Cursor.ID,
+				Operator: operator,
+			},
+			Operator: OperatorAnd,
+		},
+		Operator: OperatorOr,
+	}
+}
+
+// ToOrderString returns the ORDER BY string required to walk the keyset in the cursor's direction
+func (k *Keyset) ToOrderString() string {
+	direction := SortDirectionMap[SortDirectionDesc]
+	if k.isBackward() {
+		direction = SortDirectionMap[SortDirectionAsc]
+	}
+	return fmt.Sprintf(" ORDER BY %s %s, %s %s ", k.DateField, direction, k.IDField, direction)
+}
+
+// KeysetPage describes how the rows fetched by a keyset-paginated query make up a page
+type KeysetPage struct {
+	// Size is the number of fetched rows that belong to the page
+	Size int
+	// Reverse indicates the rows were fetched oldest first and must be reversed
+	Reverse bool
+	// HasNext indicates there are older rows past the page
+	HasNext bool
+	// HasPrev indicates there are newer rows before the page
+	HasPrev bool
+}
+
+// IsKeyset indicates whether this pagination uses cursors instead of offsets
+func (p *Pagination) IsKeyset() bool {
+	return p.Keyset != nil
+}
+
+// GetKeysetPage inspects the number of rows fetched by a keyset-paginated query, which asks for one row
+// more than the page size to find out whether there are more rows in the direction of the cursor
+func (p *Pagination) GetKeysetPage(fetched int) KeysetPage {
+	page := KeysetPage{
+		Size:    fetched,
+		Reverse: p.Keyset.isBackward(),
+	}
+
+	more := fetched > p.PageSize
+	if more {
+		page.Size = p.PageSize
+	}
+
+	if page.Size == 0 {
+		return page
+	}
+
+	if page.Reverse {
+		page.HasNext = true
+		page.HasPrev = more
+	} else {
+		page.HasNext = more
+		page.HasPrev = p.Keyset.Cursor != nil
+	}
+
+	return page
+}
+
+// GetKeysetPagination returns the pagination object from a filter input, switching to cursor-based
+// pagination over the specified keyset columns when requested
+func (f *BaseFilterInput) GetKeysetPagination(dateField, idField Field) (Pagination, error) {
+	pagination := f.GetPagination()
+
+	mode := PaginationModeOffset
+	if f.Cursor != nil {
+		mode = PaginationModeCursor
+	}
+	if f.PaginationMode != nil {
+		if *f.PaginationMode != PaginationModeOffset && *f.PaginationMode != PaginationModeCursor {
+			return pagination, failure.BadRequestFromString(fmt.Sprintf("unsupported pagination mode: %s", *f.PaginationMode))
+		}
+		if *f.PaginationMode == PaginationModeOffset && f.Cursor != nil {
+			return pagination, failure.BadRequestFromString("cursor is not supported with offset pagination")
+		}
+		mode = *f.PaginationMode
+	}
+
+	if mode == PaginationModeOffset {
+		return pagination, nil
+	}
+
+	if f.Sort != nil && len(*f.Sort) > 0 {
+		return pagination, failure.BadRequestFromString("sort is not supported with cursor pagination")
+	}
+
+	pagination.Keyset = &Keyset{
+		DateField: dateField,
+		IDField:   idField,
+	}
+
+	if f.Cursor != nil && len(*f.Cursor) > 0 {
+		cursor, err := DecodeCursor(*f.Cursor)
+		if err != nil {
+			return pagination, err
+		}
+		pagination.Keyset.Cursor = cursor
+	}
+
+	return pagination, nil
+}
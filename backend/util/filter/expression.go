@@ -0,0 +1,221 @@
+package filter
+
+import (
+	"fmt"
+
+	"github.com/kerti/balances/backend/util/failure"
+)
+
+// MaxExpressionDepth is the deepest an Expression may nest its and/or/not groups
+const MaxExpressionDepth = 8
+
+// MaxExpressionConditions is the most field conditions a single Expression may hold
+const MaxExpressionConditions = 64
+
+// ExpressionOperator represents a comparison an API client can apply to a field
+type ExpressionOperator string
+
+const (
+	// ExpressionOperatorEqual matches fields equal to the value
+	ExpressionOperatorEqual ExpressionOperator = "eq"
+	// ExpressionOperatorNotEqual matches fields not equal to the value
+	ExpressionOperatorNotEqual ExpressionOperator = "neq"
+	// ExpressionOperatorLessThan matches fields less than the value
+	ExpressionOperatorLessThan ExpressionOperator = "lt"
+	// ExpressionOperatorLessThanEqual matches fields less than or equal to the value
+	ExpressionOperatorLessThanEqual ExpressionOperator = "lte"
+	// ExpressionOperatorGreaterThan matches fields greater than the value
+	ExpressionOperatorGreaterThan ExpressionOperator = "gt"
+	// ExpressionOperatorGreaterThanEqual matches fields greater than or equal to the value
+	ExpressionOperatorGreaterThanEqual ExpressionOperator = "gte"
+	// ExpressionOperatorIn matches fields equal to any of the values in a list
+	ExpressionOperatorIn ExpressionOperator = "in"
+	// ExpressionOperatorLike matches fields against an SQL LIKE pattern
+	ExpressionOperatorLike ExpressionOperator = "like"
+	// ExpressionOperatorBetween matches fields within an inclusive [from, to] pair of values
+	ExpressionOperatorBetween ExpressionOperator = "between"
+	// ExpressionOperatorIsNull matches fields without a value
+	ExpressionOperatorIsNull ExpressionOperator = "isnull"
+)
+
+// expressionOperatorMap is the map of expression operators that compare a field with a single value
+// to their clause operator equivalent
+var expressionOperatorMap = map[ExpressionOperator]Operator{
+	ExpressionOperatorEqual:            OperatorEqual,
+	ExpressionOperatorNotEqual:         OperatorNotEqual,
+	ExpressionOperatorLessThan:         OperatorLessThan,
+	ExpressionOperatorLessThanEqual:    OperatorLessThanEqual,
+	ExpressionOperatorGreaterThan:      OperatorGreaterThan,
+	ExpressionOperatorGreaterThanEqual: OperatorGreaterThanEqual,
+	ExpressionOperatorLike:             OperatorLike,
+}
+
+// Expression is a client-supplied filter condition, either a group combining nested expressions
+// with and/or/not, or a single comparison of a field against a value, e.g.
+//
+//	{"and": [{"field": "bankName", "op": "eq", "value": "ACME"}, {"not": {"field": "deleted", "op": "isnull"}}]}
+type Expression struct {
+	And   *[]Expression      `json:"and,omitempty"`
+	Or    *[]Expression      `json:"or,omitempty"`
+	Not   *Expression        `json:"not,omitempty"`
+	Field *string            `json:"field,omitempty"`
+	Op    ExpressionOperator `json:"op,omitempty"`
+	Value interface{}        `json:"value,omitempty"`
+}
+
+// ToClause converts the expression to a clause, validating every field against a whitelist of
+// queryable fields keyed by their names in the API
+func (e *Expression) ToClause(fields map[string]Field) (*Clause, error) {
+	conditions := 0
+	return e.toClause(fields, 1, &conditions)
+}
+
+func (e *Expression) toClause(fields map[string]Field, depth int, conditions *int) (*Clause, error) {
+	if depth > MaxExpressionDepth {
+		return nil, failure.BadRequestFromString(fmt.Sprintf("query is nested deeper than %d levels", MaxExpressionDepth))
+	}
+
+	kinds := 0
+	for _, isSet := range []bool{e.And != nil, e.Or != nil, e.Not != nil, e.Field != nil} {
+		if isSet {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return nil, failure.BadRequestFromString("query expression must have exactly one of and, or, not or field")
+	}
+
+	switch {
+	case e.And != nil:
+		return e.groupToClause(*e.And, OperatorAnd, fields, depth, conditions)
+	case e.Or != nil:
+		return e.groupToClause(*e.Or, OperatorOr, fields, depth, conditions)
+	case e.Not != nil:
+		clause, err := e.Not.toClause(fields, depth+1, conditions)
+		if err != nil {
+			return nil, err
+		}
+		return &Clause{
+			Operand1: *clause,
+			Operator: OperatorNot,
+		}, nil
+	default:
+		*conditions++
+		if *conditions > MaxExpressionConditions {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("query has more than %d conditions", MaxExpressionConditions))
+		}
+		return e.conditionToClause(fields)
+	}
+}
+
+func (e *Expression) groupToClause(expressions []Expression, operator Operator, fields map[string]Field, depth int, conditions *int) (*Clause, error) {
+	if len(expressions) == 0 {
+		return nil, failure.BadRequestFromString(fmt.Sprintf("query %s group must not be empty", operator))
+	}
+
+	var clause *Clause
+	for _, expression := range expressions {
+		newClause, err := expression.toClause(fields, depth+1, conditions)
+		if err != nil {
+			return nil, err
+		}
+		if clause == nil {
+			clause = newClause
+		} else {
+			clause = &Clause{
+				Operand1: *clause,
+				Operand2: *newClause,
+				Operator: operator,
+			}
+		}
+	}
+
+	return clause, nil
+}
+
+func (e *Expression) conditionToClause(fields map[string]Field) (*Clause, error) {
+	field, ok := fields[*e.Field]
+	if !ok {
+		return nil, failure.BadRequestFromString(fmt.Sprintf("unsupported query field: %s", *e.Field))
+	}
+
+	switch e.Op {
+	case ExpressionOperatorIsNull:
+		if e.Value != nil {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("query operator %s on %s takes no value", e.Op, *e.Field))
+		}
+		return &Clause{
+			Operand1: field,
+			Operator: OperatorIsNull,
+		}, nil
+	case ExpressionOperatorIn:
+		values, ok := e.Value.([]interface{})
+		if !ok || len(values) == 0 || !isScalarList(values) {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("query operator %s on %s requires a non-empty list of values", e.Op, *e.Field))
+		}
+		return &Clause{
+			Operand1: field,
+			Operand2: values,
+			Operator: OperatorIn,
+		}, nil
+	case ExpressionOperatorBetween:
+		values, ok := e.Value.([]interface{})
+		if !ok || len(values) != 2 || !isScalarList(values) {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("query operator %s on %s requires a pair of values", e.Op, *e.Field))
+		}
+		return &Clause{
+			Operand1: field,
+			Operand2: Range{From: values[0], To: values[1]},
+			Operator: OperatorBetween,
+		}, nil
+	}
+
+	operator, ok := expressionOperatorMap[e.Op]
+	if !ok {
+		return nil, failure.BadRequestFromString(fmt.Sprintf("unsupported query operator: %s", e.Op))
+	}
+	if !isScalar(e.Value) {
+		return nil, failure.BadRequestFromString(fmt.Sprintf("query operator %s on %s requires a single value", e.Op, *e.Field))
+	}
+
+	return &Clause{
+		Operand1: field,
+		Operand2: e.Value,
+		Operator: operator,
+	}, nil
+}
+
+// isScalar checks whether a decoded JSON value can be bound to a single placeholder
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, float64, bool:
+		return true
+	default:
+		return false
+	}
+}
+
+func isScalarList(values []interface{}) bool {
+	for _, value := range values {
+		if !isScalar(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// AddExpression adds a client-supplied query expression to this Filter, validated against a whitelist
+// of queryable fields keyed by their names in the API
+func (f *Filter) AddExpression(expression *Expression, fields map[string]Field) error {
+	if expression == nil {
+		return nil
+	}
+
+	clause, err := expression.ToClause(fields)
+	if err != nil {
+		return err
+	}
+
+	f.AddClause(*clause, OperatorAnd)
+	return nil
+}
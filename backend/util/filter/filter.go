@@ -1,7 +1,12 @@
 package filter
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kerti/balances/backend/util/failure"
 )
 
 // Field represents an SQL field
@@ -13,7 +18,7 @@ type Operator string
 const (
 	// OperatorEqual represents an SQL operator of the same name
 	OperatorEqual Operator = "eq"
-	// OperatorNot represents an SQL operator of the same name
+	// OperatorNot represents an SQL operator of the same name, negating the clause in its first operand
 	OperatorNot Operator = "not"
 	// OperatorNotEqual represents an SQL operator of the same name
 	OperatorNotEqual Operator = "noteq"
@@ -31,14 +36,20 @@ const (
 	OperatorOr Operator = "or"
 	// OperatorLike represents an SQL operator of the same name
 	OperatorLike Operator = "like"
-	// OperatorIn represents an SQL operator of the same name
+	// OperatorIn represents an SQL operator of the same name, matching a field against a slice of values
 	OperatorIn Operator = "in"
+	// OperatorIsNull represents the SQL IS NULL predicate, which takes no value
+	OperatorIsNull Operator = "isnull"
+	// OperatorIsNotNull represents the SQL IS NOT NULL predicate, which takes no value
+	OperatorIsNotNull Operator = "isnotnull"
+	// OperatorBetween represents an SQL operator of the same name, matching a field against a Range
+	OperatorBetween Operator = "between"
 )
 
 // OperandMap is the map of operands to its query string equivalent
 var OperandMap = map[Operator]string{
 	OperatorEqual:            " = ",
-	OperatorNot:              " ! ",
+	OperatorNot:              "NOT ",
 	OperatorNotEqual:         " != ",
 	OperatorLessThan:         " < ",
 	OperatorLessThanEqual:    " <= ",
@@ -48,6 +59,48 @@ var OperandMap = map[Operator]string{
 	OperatorOr:               " OR ",
 	OperatorLike:             " LIKE ",
 	OperatorIn:               " IN ",
+	OperatorIsNull:           " IS NULL",
+	OperatorIsNotNull:        " IS NOT NULL",
+	OperatorBetween:          " BETWEEN ",
+}
+
+var (
+	// ErrNilOperand is returned when a clause has a nil *Field or *Clause operand
+	ErrNilOperand = errors.New("nil filter operand")
+	// ErrUnsupportedOperator is returned when a clause has an operator missing from OperandMap
+	ErrUnsupportedOperator = errors.New("unsupported filter operator")
+	// ErrUnsupportedCombination is returned when a clause's operator cannot be applied to its operands
+	ErrUnsupportedCombination = errors.New("unsupported filter param combination")
+	// ErrEmptyList is returned when an IN clause has no values to match against
+	ErrEmptyList = errors.New("empty filter list")
+)
+
+// isComparison checks whether the operator compares a field with another operand
+func (o Operator) isComparison() bool {
+	switch o {
+	case OperatorEqual, OperatorNotEqual, OperatorLessThan, OperatorLessThanEqual,
+		OperatorGreaterThan, OperatorGreaterThanEqual, OperatorLike:
+		return true
+	default:
+		return false
+	}
+}
+
+// isLogical checks whether the operator combines two clauses
+func (o Operator) isLogical() bool {
+	return o == OperatorAnd || o == OperatorOr
+}
+
+// Range represents the inclusive bounds of a BETWEEN clause
+type Range struct {
+	From interface{}
+	To   interface{}
+}
+
+// Subquery represents a query an IN clause matches a field against, along with the arguments of its placeholders
+type Subquery struct {
+	Query string
+	Args  []interface{}
 }
 
 // QueryPart represents part of a query
@@ -62,66 +115,89 @@ type Clause struct {
 	Operator Operator
 }
 
-// GetArgs gets the arguments required for this clause
-func (c *Clause) GetArgs(args []interface{}) []interface{} {
-	switch c.Operand1.(type) {
-	case Field, *Field:
-	case Clause:
-		clause1 := c.Operand1.(Clause)
-		args = clause1.GetArgs(args)
-	case *Clause:
-		clause1 := c.Operand1.(*Clause)
-		args = clause1.GetArgs(args)
+// isUnary checks whether this clause only has its first operand, i.e. a negated clause or a null check
+func (c *Clause) isUnary() bool {
+	switch c.Operator {
+	case OperatorIsNull, OperatorIsNotNull:
+		return true
+	case OperatorNot:
+		return c.Operand2 == nil
 	default:
-		args = append(args, c.Operand1)
+		return false
+	}
+}
+
+// GetArgs gets the arguments required for this clause, in the order of their placeholders
+func (c *Clause) GetArgs(args []interface{}) []interface{} {
+	args = appendOperandArgs(args, c.Operand1)
+	if c.isUnary() {
+		return args
 	}
+	return appendOperandArgs(args, c.Operand2)
+}
 
-	switch c.Operand2.(type) {
+func appendOperandArgs(args []interface{}, operand interface{}) []interface{} {
+	switch operand := operand.(type) {
 	case Field, *Field:
+		return args
 	case Clause:
-		clause2 := c.Operand2.(Clause)
-		args = clause2.GetArgs(args)
+		return operand.GetArgs(args)
 	case *Clause:
-		clause1 := c.Operand2.(*Clause)
-		args = clause1.GetArgs(args)
-	default:
-		args = append(args, c.Operand2)
+		if operand == nil {
+			return args
+		}
+		return operand.GetArgs(args)
+	case Range:
+		return append(args, operand.From, operand.To)
+	case Subquery:
+		return append(args, operand.Args...)
 	}
 
-	return args
+	if values, ok := getListValues(operand); ok {
+		return append(args, values...)
+	}
+	return append(args, operand)
+}
+
+// getListValues returns the values of a slice operand one by one, so an IN list gets a placeholder per value
+func getListValues(operand interface{}) ([]interface{}, bool) {
+	value := reflect.ValueOf(operand)
+	if value.Kind() != reflect.Slice || value.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	values := make([]interface{}, 0, value.Len())
+	for idx := 0; idx < value.Len(); idx++ {
+		values = append(values, value.Index(idx).Interface())
+	}
+	return values, true
 }
 
 func (c *Clause) handlePointers() error {
-	switch c.Operand1.(type) {
+	switch operand1 := c.Operand1.(type) {
 	case *Field:
-		if c.Operand1 == nil {
-			return fmt.Errorf("operand1 is nil, expected non-nil *Field")
+		if operand1 == nil {
+			return fmt.Errorf("%w: operand1 is nil, expected non-nil *Field", ErrNilOperand)
 		}
-		operand1 := c.Operand1.(*Field)
 		c.Operand1 = *operand1
 	case *Clause:
-		if c.Operand1 == nil {
-			return fmt.Errorf("operand1 is nil, expected non-nil *Clause")
+		if operand1 == nil {
+			return fmt.Errorf("%w: operand1 is nil, expected non-nil *Clause", ErrNilOperand)
 		}
-		operand1 := c.Operand1.(*Clause)
 		c.Operand1 = *operand1
-	default:
 	}
 
-	switch c.Operand2.(type) {
+	switch operand2 := c.Operand2.(type) {
 	case *Field:
-		if c.Operand2 == nil {
-			return fmt.Errorf("operand2 is nil, expected non-nil *Field")
+		if operand2 == nil {
+			return fmt.Errorf("%w: operand2 is nil, expected non-nil *Field", ErrNilOperand)
 		}
-		operand2 := c.Operand2.(*Field)
 		c.Operand2 = *operand2
 	case *Clause:
-		if c.Operand2 == nil {
-			return fmt.Errorf("operand2 is nil, expected non-nil *Clause")
+		if operand2 == nil {
+			return fmt.Errorf("%w: operand2 is nil, expected non-nil *Clause", ErrNilOperand)
 		}
-		operand2 := c.Operand2.(*Clause)
 		c.Operand2 = *operand2
-	default:
 	}
 
 	return nil
@@ -134,6 +210,14 @@ func (c *Clause) ToQueryString() (string, error) {
 		return "", err
 	}
 
+	if _, ok := OperandMap[c.Operator]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedOperator, c.Operator)
+	}
+
+	if c.isUnary() {
+		return c.toStringUnary()
+	}
+
 	switch c.Operand1.(type) {
 	case Field:
 		switch c.Operand2.(type) {
@@ -151,88 +235,120 @@ func (c *Clause) ToQueryString() (string, error) {
 		case Clause:
 			return c.toStringClauseVsClause()
 		default:
-			return "", fmt.Errorf("unsupported filter param combination: clause vs value")
+			return "", fmt.Errorf("%w: clause vs value", ErrUnsupportedCombination)
 		}
 	default:
 		switch c.Operand2.(type) {
 		case Field:
-			return "", fmt.Errorf("unsupported filter param combination: value vs field")
+			return "", fmt.Errorf("%w: value vs field", ErrUnsupportedCombination)
 		case Clause:
-			return "", fmt.Errorf("unsupported filter param combination: value vs clause")
+			return "", fmt.Errorf("%w: value vs clause", ErrUnsupportedCombination)
 		default:
-			return "", fmt.Errorf("unsupported filter param combination: value vs value")
+			return "", fmt.Errorf("%w: value vs value", ErrUnsupportedCombination)
 		}
 	}
 }
 
-func (c *Clause) toStringFieldVsField() (string, error) {
-	field1, ok := c.Operand1.(Field)
-	if !ok {
-		return "", fmt.Errorf("failed processing field [%#v]", field1)
+func (c *Clause) toStringUnary() (string, error) {
+	if c.Operand2 != nil {
+		return "", fmt.Errorf("%w: %s takes a single operand", ErrUnsupportedCombination, c.Operator)
 	}
-	field2, ok := c.Operand2.(Field)
-	if !ok {
-		return "", fmt.Errorf("failed processing field [%#v]", field2)
+
+	switch operand := c.Operand1.(type) {
+	case Field:
+		if c.Operator != OperatorNot {
+			return fmt.Sprintf("(%s%s)", operand, OperandMap[c.Operator]), nil
+		}
+	case Clause:
+		if c.Operator == OperatorNot {
+			clauseStr, err := operand.ToQueryString()
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("(%s%s)", OperandMap[c.Operator], clauseStr), nil
+		}
 	}
-	return fmt.Sprintf("(%s %s %s)", field1, OperandMap[c.Operator], field2), nil
+
+	return "", fmt.Errorf("%w: %s on %T", ErrUnsupportedCombination, c.Operator, c.Operand1)
 }
 
-func (c *Clause) toStringFieldVsClause() (string, error) {
-	field, ok := c.Operand1.(Field)
-	if !ok {
-		return "", fmt.Errorf("failed processing field [%#v]", field)
+func (c *Clause) toStringFieldVsField() (string, error) {
+	if !c.Operator.isComparison() {
+		return "", fmt.Errorf("%w: %s on field vs field", ErrUnsupportedCombination, c.Operator)
 	}
-	clause, ok := c.Operand2.(Clause)
-	if !ok {
-		return "", fmt.Errorf("failed processing clause [%#v]", clause)
+	return fmt.Sprintf("(%s %s %s)", c.Operand1, OperandMap[c.Operator], c.Operand2), nil
+}
+
+func (c *Clause) toStringFieldVsClause() (string, error) {
+	if !c.Operator.isComparison() {
+		return "", fmt.Errorf("%w: %s on field vs clause", ErrUnsupportedCombination, c.Operator)
 	}
+	clause := c.Operand2.(Clause)
 	clauseStr, err := clause.ToQueryString()
 	if err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("(%s %s %s)", field, OperandMap[c.Operator], clauseStr), nil
+	return fmt.Sprintf("(%s %s %s)", c.Operand1, OperandMap[c.Operator], clauseStr), nil
 }
 
 func (c *Clause) toStringFieldVsValue() (string, error) {
-	field, ok := c.Operand1.(Field)
-	if !ok {
-		return "", fmt.Errorf("failed processing field [%#v]", field)
-	}
-	if c.Operator == OperatorIn {
-		return fmt.Sprintf("(%s %s (?))", field, OperandMap[c.Operator]), nil
+	field := c.Operand1.(Field)
+	values, isList := getListValues(c.Operand2)
+	_, isRange := c.Operand2.(Range)
+	subquery, isSubquery := c.Operand2.(Subquery)
+
+	switch {
+	case c.Operator == OperatorIn && isSubquery:
+		if strings.TrimSpace(subquery.Query) == "" {
+			return "", fmt.Errorf("%w: %s on %s", ErrEmptyList, c.Operator, field)
+		}
+		return fmt.Sprintf("(%s %s (%s))", field, OperandMap[c.Operator], subquery.Query), nil
+	case c.Operator == OperatorIn:
+		if !isList {
+			return "", fmt.Errorf("%w: %s on %s expects a slice, got %T", ErrUnsupportedCombination, c.Operator, field, c.Operand2)
+		}
+		if len(values) == 0 {
+			return "", fmt.Errorf("%w: %s on %s", ErrEmptyList, c.Operator, field)
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+		return fmt.Sprintf("(%s %s (%s))", field, OperandMap[c.Operator], placeholders), nil
+	case c.Operator == OperatorBetween:
+		if !isRange {
+			return "", fmt.Errorf("%w: %s on %s expects a Range, got %T", ErrUnsupportedCombination, c.Operator, field, c.Operand2)
+		}
+		return fmt.Sprintf("(%s %s ? AND ?)", field, OperandMap[c.Operator]), nil
+	case c.Operator.isComparison():
+		if isList || isRange || isSubquery || c.Operand2 == nil {
+			return "", fmt.Errorf("%w: %s on %s expects a single value, got %T", ErrUnsupportedCombination, c.Operator, field, c.Operand2)
+		}
+		return fmt.Sprintf("(%s %s ?)", field, OperandMap[c.Operator]), nil
+	default:
+		return "", fmt.Errorf("%w: %s on field vs value", ErrUnsupportedCombination, c.Operator)
 	}
-	return fmt.Sprintf("(%s %s ?)", field, OperandMap[c.Operator]), nil
 }
 
 func (c *Clause) toStringClauseVsField() (string, error) {
-	clause, ok := c.Operand1.(Clause)
-	if !ok {
-		return "", fmt.Errorf("failed processing clause [%#v]", clause)
+	if !c.Operator.isComparison() {
+		return "", fmt.Errorf("%w: %s on clause vs field", ErrUnsupportedCombination, c.Operator)
 	}
+	clause := c.Operand1.(Clause)
 	clauseStr, err := clause.ToQueryString()
 	if err != nil {
 		return "", err
 	}
-	field, ok := c.Operand2.(Field)
-	if !ok {
-		return "", fmt.Errorf("failed processing field [%#v]", field)
-	}
-	return fmt.Sprintf("(%s %s %s)", clauseStr, OperandMap[c.Operator], field), nil
+	return fmt.Sprintf("(%s %s %s)", clauseStr, OperandMap[c.Operator], c.Operand2), nil
 }
 
 func (c *Clause) toStringClauseVsClause() (string, error) {
-	clause1, ok := c.Operand1.(Clause)
-	if !ok {
-		return "", fmt.Errorf("failed processing clause [%#v]", clause1)
+	if !c.Operator.isLogical() {
+		return "", fmt.Errorf("%w: %s on clause vs clause", ErrUnsupportedCombination, c.Operator)
 	}
+	clause1 := c.Operand1.(Clause)
 	clause1Str, err := clause1.ToQueryString()
 	if err != nil {
 		return "", err
 	}
-	clause2, ok := c.Operand2.(Clause)
-	if !ok {
-		return "", fmt.Errorf("failed processing clause [%#v]", clause2)
-	}
+	clause2 := c.Operand2.(Clause)
 	clause2Str, err := clause2.ToQueryString()
 	if err != nil {
 		return "", err
@@ -244,6 +360,8 @@ func (c *Clause) toStringClauseVsClause() (string, error) {
 type Pagination struct {
 	Page     int
 	PageSize int
+	// Keyset is set when paging through the result set using cursors instead of offsets
+	Keyset *Keyset
 }
 
 // GetOffset returns the offset required for the current page
@@ -253,6 +371,10 @@ func (p *Pagination) GetOffset() int {
 
 // GetArgs gets the arguments required for this pagination
 func (p *Pagination) GetArgs(args []interface{}) []interface{} {
+	if p.IsKeyset() {
+		// one extra row tells whether there are more rows past the page
+		return append(args, p.PageSize+1)
+	}
 	args = append(args, p.PageSize, p.GetOffset())
 	return args
 }
@@ -269,16 +391,61 @@ func (p *Pagination) GetPageCount(itemCount int) (pageCount int) {
 
 // ToQueryString returns the string representation of the pagination
 func (p *Pagination) ToQueryString() string {
+	if p.IsKeyset() {
+		return "LIMIT ?"
+	}
 	return fmt.Sprintf("LIMIT ? OFFSET ?")
 }
 
+// SortDirection represents an SQL sort direction
+type SortDirection string
+
+const (
+	// SortDirectionAsc sorts in ascending order
+	SortDirectionAsc SortDirection = "asc"
+	// SortDirectionDesc sorts in descending order
+	SortDirectionDesc SortDirection = "desc"
+)
+
+// SortDirectionMap is the map of sort directions to its query string equivalent
+var SortDirectionMap = map[SortDirection]string{
+	SortDirectionAsc:  "ASC",
+	SortDirectionDesc: "DESC",
+}
+
+// Sort represents a single column of the ordering part of an SQL query
+type Sort struct {
+	Field     Field
+	Direction SortDirection
+}
+
+// ToQueryString returns the string representation of the sort
+func (s *Sort) ToQueryString() string {
+	direction, ok := SortDirectionMap[s.Direction]
+	if !ok {
+		direction = SortDirectionMap[SortDirectionAsc]
+	}
+	return fmt.Sprintf("%s %s", s.Field, direction)
+}
+
+// SortInput is the input object for a single sort column
+type SortInput struct {
+	Field     string        `json:"field"`
+	Direction SortDirection `json:"direction,omitempty"`
+}
+
 // Filter represents a generic SQL filter
 type Filter struct {
 	TableName      string
 	DeletedColumn  string
 	Clause         *Clause
 	IncludeDeleted bool
+	Sorts          []Sort
 	Pagination     Pagination
+	// Aggregation is set when the filtered rows are to be grouped and aggregated instead of listed
+	Aggregation *Aggregation
+	// Err holds any error encountered while building the filter from its input
+	Err error
 }
 
 // GetArgs gets the arguments required for this filter
@@ -289,6 +456,12 @@ func (f *Filter) GetArgs(withPagination bool) []interface{} {
 		args = f.Clause.GetArgs(args)
 	}
 
+	if f.Pagination.IsKeyset() {
+		if keysetClause := f.Pagination.Keyset.getClause(); keysetClause != nil {
+			args = keysetClause.GetArgs(args)
+		}
+	}
+
 	if withPagination {
 		args = f.Pagination.GetArgs(args)
 	}
@@ -312,11 +485,30 @@ func (f *Filter) AddClause(clause Clause, operator Operator) {
 
 // ToQueryString converts the Filter to a string query
 func (f *Filter) ToQueryString() (string, error) {
+	if f.Err != nil {
+		return "", f.Err
+	}
+
 	var err error
 	clauseStr := ""
 
-	if f.Clause != nil {
-		clauseStr, err = f.Clause.ToQueryString()
+	clause := f.Clause
+	if f.Pagination.IsKeyset() {
+		if keysetClause := f.Pagination.Keyset.getClause(); keysetClause != nil {
+			if clause == nil {
+				clause = keysetClause
+			} else {
+				clause = &Clause{
+					Operand1: *clause,
+					Operand2: *keysetClause,
+					Operator: OperatorAnd,
+				}
+			}
+		}
+	}
+
+	if clause != nil {
+		clauseStr, err = clause.ToQueryString()
 		if err != nil {
 			return "", err
 		}
@@ -332,15 +524,43 @@ func (f *Filter) ToQueryString() (string, error) {
 			clauseStr = fmt.Sprintf(" %s.%s IS NULL ", f.TableName, f.DeletedColumn)
 		}
 	}
+
+	// a filter without any condition must not produce a dangling WHERE
+	if len(clauseStr) == 0 {
+		return " ", nil
+	}
+
 	return " WHERE " + clauseStr + " ", nil
 }
 
+// ToOrderString converts the sorts of the Filter to an ORDER BY string
+func (f *Filter) ToOrderString() string {
+	if f.Pagination.IsKeyset() {
+		return f.Pagination.Keyset.ToOrderString()
+	}
+
+	if len(f.Sorts) == 0 {
+		return " "
+	}
+
+	sortStrs := make([]string, 0, len(f.Sorts))
+	for _, sort := range f.Sorts {
+		sortStrs = append(sortStrs, sort.ToQueryString())
+	}
+
+	return " ORDER BY " + strings.Join(sortStrs, ", ") + " "
+}
+
 // BaseFilterInput is the base type for all filter inputs
 type BaseFilterInput struct {
-	Keyword        *string `json:"keyword,omitempty"`
-	IncludeDeleted *bool   `json:"includeDeleted,omitempty"`
-	Page           *int    `json:"page,omitempty"`
-	PageSize       *int    `json:"pageSize,omitempty"`
+	Keyword        *string         `json:"keyword,omitempty"`
+	IncludeDeleted *bool           `json:"includeDeleted,omitempty"`
+	Sort           *[]SortInput    `json:"sort,omitempty"`
+	Page           *int            `json:"page,omitempty"`
+	PageSize       *int            `json:"pageSize,omitempty"`
+	PaginationMode *PaginationMode `json:"paginationMode,omitempty"`
+	Cursor         *string         `json:"cursor,omitempty"`
+	Query          *Expression     `json:"query,omitempty"`
 }
 
 // GetKeywordFilter produces the filter object from a list of searchable fields
@@ -386,6 +606,37 @@ func (f *BaseFilterInput) GetIncludeDeleted() bool {
 	return *f.IncludeDeleted
 }
 
+// GetSorts returns the sorts from a filter input, validated against a whitelist of sortable fields
+// keyed by their names in the API
+func (f *BaseFilterInput) GetSorts(fields map[string]Field) ([]Sort, error) {
+	sorts := make([]Sort, 0)
+	if f.Sort == nil {
+		return sorts, nil
+	}
+
+	for _, sortInput := range *f.Sort {
+		field, ok := fields[sortInput.Field]
+		if !ok {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("unsupported sort field: %s", sortInput.Field))
+		}
+
+		direction := SortDirection(strings.ToLower(string(sortInput.Direction)))
+		if len(direction) == 0 {
+			direction = SortDirectionAsc
+		}
+		if _, ok := SortDirectionMap[direction]; !ok {
+			return nil, failure.BadRequestFromString(fmt.Sprintf("unsupported sort direction: %s", sortInput.Direction))
+		}
+
+		sorts = append(sorts, Sort{
+			Field:     field,
+			Direction: direction,
+		})
+	}
+
+	return sorts, nil
+}
+
 // GetPagination returns the pagination object from a filter input
 func (f *BaseFilterInput) GetPagination() Pagination {
 	page := 1
@@ -0,0 +1,453 @@
+package filter_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/kerti/balances/backend/util/filter"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testFieldA filter.Field = "things.a"
+	testFieldB filter.Field = "things.b"
+)
+
+// normalize collapses whitespace, as the builder pads its operators generously
+func normalize(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+func TestClauseToQueryString(t *testing.T) {
+	fieldA := testFieldA
+	eqClause := filter.Clause{Operand1: testFieldA, Operand2: 1, Operator: filter.OperatorEqual}
+
+	t.Run("normal", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			clause filter.Clause
+			query  string
+			args   []interface{}
+		}{
+			{
+				name:   "fieldVsValue",
+				clause: filter.Clause{Operand1: testFieldA, Operand2: "x", Operator: filter.OperatorLike},
+				query:  "(things.a LIKE ?)",
+				args:   []interface{}{"x"},
+			},
+			{
+				name:   "fieldVsField",
+				clause: filter.Clause{Operand1: testFieldA, Operand2: testFieldB, Operator: filter.OperatorLessThan},
+				query:  "(things.a < things.b)",
+				args:   []interface{}{},
+			},
+			{
+				name:   "fieldPointers",
+				clause: filter.Clause{Operand1: &fieldA, Operand2: 2, Operator: filter.OperatorGreaterThanEqual},
+				query:  "(things.a >= ?)",
+				args:   []interface{}{2},
+			},
+			{
+				name:   "inExpandsSlice",
+				clause: filter.Clause{Operand1: testFieldA, Operand2: []string{"x", "y", "z"}, Operator: filter.OperatorIn},
+				query:  "(things.a IN (?, ?, ?))",
+				args:   []interface{}{"x", "y", "z"},
+			},
+			{
+				name: "inSubquery",
+				clause: filter.Clause{
+					Operand1: testFieldA,
+					Operand2: filter.Subquery{Query: "SELECT others.a FROM others WHERE others.b = ?", Args: []interface{}{"y"}},
+					Operator: filter.OperatorIn,
+				},
+				query: "(things.a IN (SELECT others.a FROM others WHERE others.b = ?))",
+				args:  []interface{}{"y"},
+			},
+			{
+				name:   "between",
+				clause: filter.Clause{Operand1: testFieldA, Operand2: filter.Range{From: 1, To: 5}, Operator: filter.OperatorBetween},
+				query:  "(things.a BETWEEN ? AND ?)",
+				args:   []interface{}{1, 5},
+			},
+			{
+				name:   "isNull",
+				clause: filter.Clause{Operand1: testFieldA, Operator: filter.OperatorIsNull},
+				query:  "(things.a IS NULL)",
+				args:   []interface{}{},
+			},
+			{
+				name:   "isNotNull",
+				clause: filter.Clause{Operand1: testFieldA, Operator: filter.OperatorIsNotNull},
+				query:  "(things.a IS NOT NULL)",
+				args:   []interface{}{},
+			},
+			{
+				name:   "not",
+				clause: filter.Clause{Operand1: &eqClause, Operator: filter.OperatorNot},
+				query:  "(NOT (things.a = ?))",
+				args:   []interface{}{1},
+			},
+			{
+				name: "nested",
+				clause: filter.Clause{
+					Operand1: eqClause,
+					Operand2: filter.Clause{
+						Operand1: filter.Clause{Operand1: testFieldB, Operand2: []int{7, 8}, Operator: filter.OperatorIn},
+						Operand2: filter.Clause{Operand1: testFieldB, Operator: filter.OperatorIsNull},
+						Operator: filter.OperatorOr,
+					},
+					Operator: filter.OperatorAnd,
+				},
+				query: "((things.a = ?) AND ((things.b IN (?, ?)) OR (things.b IS NULL)))",
+				args:  []interface{}{1, 7, 8},
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				query, err := testCase.clause.ToQueryString()
+
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.query, normalize(query))
+				assert.Equal(t, testCase.args, testCase.clause.GetArgs(make([]interface{}, 0)))
+			})
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		var nilField *filter.Field
+		var nilClause *filter.Clause
+
+		testCases := []struct {
+			name   string
+			clause filter.Clause
+			err    error
+		}{
+			{"nilFieldPointer", filter.Clause{Operand1: nilField, Operand2: 1, Operator: filter.OperatorEqual}, filter.ErrNilOperand},
+			{"nilClausePointer", filter.Clause{Operand1: eqClause, Operand2: nilClause, Operator: filter.OperatorAnd}, filter.ErrNilOperand},
+			{"nilClauseNegated", filter.Clause{Operand1: nilClause, Operator: filter.OperatorNot}, filter.ErrNilOperand},
+			{"unknownOperator", filter.Clause{Operand1: testFieldA, Operand2: 1, Operator: "xor"}, filter.ErrUnsupportedOperator},
+			{"emptyIn", filter.Clause{Operand1: testFieldA, Operand2: []int{}, Operator: filter.OperatorIn}, filter.ErrEmptyList},
+			{"inWithoutSlice", filter.Clause{Operand1: testFieldA, Operand2: 1, Operator: filter.OperatorIn}, filter.ErrUnsupportedCombination},
+			{"equalWithSlice", filter.Clause{Operand1: testFieldA, Operand2: []int{1}, Operator: filter.OperatorEqual}, filter.ErrUnsupportedCombination},
+			{"emptySubquery", filter.Clause{Operand1: testFieldA, Operand2: filter.Subquery{}, Operator: filter.OperatorIn}, filter.ErrEmptyList},
+			{"equalWithSubquery", filter.Clause{Operand1: testFieldA, Operand2: filter.Subquery{Query: "SELECT 1"}, Operator: filter.OperatorEqual}, filter.ErrUnsupportedCombination},
+			{"equalWithNil", filter.Clause{Operand1: testFieldA, Operator: filter.OperatorEqual}, filter.ErrUnsupportedCombination},
+			{"betweenWithoutRange", filter.Clause{Operand1: testFieldA, Operand2: []int{1, 2}, Operator: filter.OperatorBetween}, filter.ErrUnsupportedCombination},
+			{"andOnFieldVsValue", filter.Clause{Operand1: testFieldA, Operand2: 1, Operator: filter.OperatorAnd}, filter.ErrUnsupportedCombination},
+			{"equalOnClauses", filter.Clause{Operand1: eqClause, Operand2: eqClause, Operator: filter.OperatorEqual}, filter.ErrUnsupportedCombination},
+			{"notWithSecondOperand", filter.Clause{Operand1: eqClause, Operand2: eqClause, Operator: filter.OperatorNot}, filter.ErrUnsupportedCombination},
+			{"notOnField", filter.Clause{Operand1: testFieldA, Operator: filter.OperatorNot}, filter.ErrUnsupportedCombination},
+			{"isNullOnClause", filter.Clause{Operand1: eqClause, Operator: filter.OperatorIsNull}, filter.ErrUnsupportedCombination},
+			{"isNullWithValue", filter.Clause{Operand1: testFieldA, Operand2: 1, Operator: filter.OperatorIsNull}, filter.ErrUnsupportedCombination},
+			{"clauseVsValue", filter.Clause{Operand1: eqClause, Operand2: 1, Operator: filter.OperatorAnd}, filter.ErrUnsupportedCombination},
+			{"valueVsValue", filter.Clause{Operand1: 1, Operand2: 1, Operator: filter.OperatorEqual}, filter.ErrUnsupportedCombination},
+			{"nestedMalformed", filter.Clause{Operand1: eqClause, Operand2: filter.Clause{Operand1: testFieldA, Operand2: []int{}, Operator: filter.OperatorIn}, Operator: filter.OperatorOr}, filter.ErrEmptyList},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				assert.NotPanics(t, func() { testCase.clause.GetArgs(nil) })
+
+				_, err := testCase.clause.ToQueryString()
+
+				assert.Error(t, err)
+				assert.True(t, errors.Is(err, testCase.err), "expected %v, got %v", testCase.err, err)
+			})
+		}
+	})
+}
+
+func TestFilterToQueryString(t *testing.T) {
+	t.Run("noClause", func(t *testing.T) {
+		theFilter := filter.Filter{TableName: "things"}
+
+		query, err := theFilter.ToQueryString()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "WHERE things.deleted IS NULL", normalize(query))
+	})
+
+	t.Run("noClauseIncludeDeleted", func(t *testing.T) {
+		theFilter := filter.Filter{TableName: "things", IncludeDeleted: true}
+
+		query, err := theFilter.ToQueryString()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", normalize(query))
+	})
+
+	t.Run("clausesAndPagination", func(t *testing.T) {
+		theFilter := filter.Filter{
+			TableName:     "things",
+			DeletedColumn: "revoked",
+			Pagination:    filter.Pagination{Page: 3, PageSize: 20},
+		}
+		theFilter.AddClause(filter.Clause{Operand1: testFieldA, Operand2: []int{1, 2}, Operator: filter.OperatorIn}, filter.OperatorAnd)
+		theFilter.AddClause(filter.Clause{Operand1: testFieldB, Operator: filter.OperatorIsNotNull}, filter.OperatorAnd)
+
+		query, err := theFilter.ToQueryString()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "WHERE (((things.a IN (?, ?)) AND (things.b IS NOT NULL))) AND things.revoked IS NULL", normalize(query))
+		assert.Equal(t, []interface{}{1, 2}, theFilter.GetArgs(false))
+		assert.Equal(t, []interface{}{1, 2, 20, 40}, theFilter.GetArgs(true))
+	})
+
+	t.Run("malformedClause", func(t *testing.T) {
+		theFilter := filter.Filter{TableName: "things"}
+		theFilter.AddClause(filter.Clause{Operand1: testFieldA, Operand2: []int{}, Operator: filter.OperatorIn}, filter.OperatorAnd)
+
+		_, err := theFilter.ToQueryString()
+
+		assert.True(t, errors.Is(err, filter.ErrEmptyList))
+	})
+
+	t.Run("buildError", func(t *testing.T) {
+		buildErr := errors.New("bad input")
+		theFilter := filter.Filter{TableName: "things", Err: buildErr}
+
+		_, err := theFilter.ToQueryString()
+
+		assert.Equal(t, buildErr, err)
+	})
+}
+
+// generatedClause is a random clause along with the SQL and args it is expected to produce,
+// worked out independently of the builder
+type generatedClause struct {
+	clause filter.Clause
+	query  string
+	args   []interface{}
+}
+
+var generatedFields = []filter.Field{"t.a", "t.b", "t.c"}
+
+var generatedComparisons = map[filter.Operator]string{
+	filter.OperatorEqual:            "=",
+	filter.OperatorNotEqual:         "!=",
+	filter.OperatorLessThan:         "<",
+	filter.OperatorLessThanEqual:    "<=",
+	filter.OperatorGreaterThan:      ">",
+	filter.OperatorGreaterThanEqual: ">=",
+	filter.OperatorLike:             "LIKE",
+}
+
+func generateField(r *rand.Rand) filter.Field {
+	return generatedFields[r.Intn(len(generatedFields))]
+}
+
+func generateComparison(r *rand.Rand) (filter.Operator, string) {
+	operators := []filter.Operator{
+		filter.OperatorEqual, filter.OperatorNotEqual, filter.OperatorLessThan, filter.OperatorLessThanEqual,
+		filter.OperatorGreaterThan, filter.OperatorGreaterThanEqual, filter.OperatorLike,
+	}
+	operator := operators[r.Intn(len(operators))]
+	return operator, generatedComparisons[operator]
+}
+
+// generateOperand randomly wraps a clause in a pointer, which the builder must treat the same
+func generateOperand(r *rand.Rand, clause filter.Clause) interface{} {
+	if r.Intn(2) == 0 {
+		return &clause
+	}
+	return clause
+}
+
+func generateClause(r *rand.Rand, depth int) generatedClause {
+	kind := r.Intn(9)
+	if depth <= 0 {
+		kind = r.Intn(5)
+	}
+
+	field := generateField(r)
+
+	switch kind {
+	case 0:
+		operator, sql := generateComparison(r)
+		value := r.Int()
+		return generatedClause{
+			clause: filter.Clause{Operand1: field, Operand2: value, Operator: operator},
+			query:  fmt.Sprintf("(%s %s ?)", field, sql),
+			args:   []interface{}{value},
+		}
+	case 1:
+		operator, sql := generateComparison(r)
+		other := generateField(r)
+		return generatedClause{
+			clause: filter.Clause{Operand1: field, Operand2: &other, Operator: operator},
+			query:  fmt.Sprintf("(%s %s %s)", field, sql, other),
+			args:   []interface{}{},
+		}
+	case 2:
+		values := make([]string, r.Intn(5)+1)
+		args := make([]interface{}, 0, len(values))
+		placeholders := make([]string, 0, len(values))
+		for idx := range values {
+			values[idx] = fmt.Sprintf("v%d", r.Int())
+			args = append(args, values[idx])
+			placeholders = append(placeholders, "?")
+		}
+		return generatedClause{
+			clause: filter.Clause{Operand1: field, Operand2: values, Operator: filter.OperatorIn},
+			query:  fmt.Sprintf("(%s IN (%s))", field, strings.Join(placeholders, ", ")),
+			args:   args,
+		}
+	case 3:
+		from, to := r.Float64(), r.Float64()
+		return generatedClause{
+			clause: filter.Clause{Operand1: field, Operand2: filter.Range{From: from, To: to}, Operator: filter.OperatorBetween},
+			query:  fmt.Sprintf("(%s BETWEEN ? AND ?)", field),
+			args:   []interface{}{from, to},
+		}
+	case 4:
+		if r.Intn(2) == 0 {
+			return generatedClause{
+				clause: filter.Clause{Operand1: field, Operator: filter.OperatorIsNull},
+				query:  fmt.Sprintf("(%s IS NULL)", field),
+				args:   []interface{}{},
+			}
+		}
+		return generatedClause{
+			clause: filter.Clause{Operand1: field, Operator: filter.OperatorIsNotNull},
+			query:  fmt.Sprintf("(%s IS NOT NULL)", field),
+			args:   []interface{}{},
+		}
+	case 5, 6:
+		operator, sql := filter.OperatorAnd, "AND"
+		if kind == 6 {
+			operator, sql = filter.OperatorOr, "OR"
+		}
+		left := generateClause(r, depth-1)
+		right := generateClause(r, depth-1)
+		return generatedClause{
+			clause: filter.Clause{Operand1: generateOperand(r, left.clause), Operand2: generateOperand(r, right.clause), Operator: operator},
+			query:  fmt.Sprintf("(%s %s %s)", left.query, sql, right.query),
+			args:   append(append([]interface{}{}, left.args...), right.args...),
+		}
+	default:
+		negated := generateClause(r, depth-1)
+		return generatedClause{
+			clause: filter.Clause{Operand1: generateOperand(r, negated.clause), Operator: filter.OperatorNot},
+			query:  fmt.Sprintf("(NOT %s)", negated.query),
+			args:   negated.args,
+		}
+	}
+}
+
+func TestClauseProperties(t *testing.T) {
+	r := rand.New(rand.NewSource(20240601))
+
+	for iteration := 0; iteration < 2000; iteration++ {
+		generated := generateClause(r, 5)
+
+		query, err := generated.clause.ToQueryString()
+		if !assert.NoError(t, err, "iteration %d", iteration) {
+			return
+		}
+
+		args := generated.clause.GetArgs(make([]interface{}, 0))
+		if !assert.Equal(t, generated.query, normalize(query), "iteration %d", iteration) ||
+			!assert.Equal(t, generated.args, args, "iteration %d", iteration) ||
+			!assert.Equal(t, strings.Count(query, "?"), len(args), "iteration %d", iteration) {
+			return
+		}
+	}
+}
+
+// fuzzDecoder builds arbitrary, possibly malformed clause trees out of fuzzer input
+type fuzzDecoder struct {
+	data []byte
+}
+
+func (d *fuzzDecoder) next() int {
+	if len(d.data) == 0 {
+		return 0
+	}
+	b := d.data[0]
+	d.data = d.data[1:]
+	return int(b)
+}
+
+func (d *fuzzDecoder) operator() filter.Operator {
+	operators := []filter.Operator{
+		filter.OperatorEqual, filter.OperatorNot, filter.OperatorNotEqual, filter.OperatorLessThan,
+		filter.OperatorLessThanEqual, filter.OperatorGreaterThan, filter.OperatorGreaterThanEqual,
+		filter.OperatorAnd, filter.OperatorOr, filter.OperatorLike, filter.OperatorIn,
+		filter.OperatorIsNull, filter.OperatorIsNotNull, filter.OperatorBetween, "bogus",
+	}
+	return operators[d.next()%len(operators)]
+}
+
+func (d *fuzzDecoder) operand(depth int) interface{} {
+	switch d.next() % 11 {
+	case 0:
+		return nil
+	case 1:
+		return generatedFields[d.next()%len(generatedFields)]
+	case 2:
+		field := generatedFields[d.next()%len(generatedFields)]
+		return &field
+	case 3:
+		var field *filter.Field
+		return field
+	case 4:
+		var clause *filter.Clause
+		return clause
+	case 5:
+		values := make([]int, d.next()%4)
+		return values
+	case 6:
+		return filter.Range{From: d.next(), To: d.next()}
+	case 7, 8:
+		if depth > 0 {
+			return d.clause(depth - 1)
+		}
+		return d.next()
+	case 9:
+		if depth > 0 {
+			clause := d.clause(depth - 1)
+			return &clause
+		}
+		return d.next()
+	default:
+		return d.next()
+	}
+}
+
+func (d *fuzzDecoder) clause(depth int) filter.Clause {
+	return filter.Clause{
+		Operator: d.operator(),
+		Operand1: d.operand(depth),
+		Operand2: d.operand(depth),
+	}
+}
+
+func FuzzClause(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 1, 0, 10, 7})
+	f.Add([]byte{7, 7, 10, 1, 1, 0, 8, 0, 1, 2, 10, 3})
+	f.Add([]byte{1, 8, 12, 1, 0, 0, 0})
+	f.Add([]byte{10, 1, 0, 5, 3, 13, 1, 1, 6, 4, 9})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoder := &fuzzDecoder{data: data}
+		clause := decoder.clause(4)
+
+		args := clause.GetArgs(make([]interface{}, 0))
+		query, err := clause.ToQueryString()
+		if err != nil {
+			return
+		}
+
+		if strings.Count(query, "?") != len(args) {
+			t.Fatalf("query %q has %d placeholders but %d args", query, strings.Count(query, "?"), len(args))
+		}
+		if strings.Count(query, "(") != strings.Count(query, ")") {
+			t.Fatalf("query %q has unbalanced parentheses", query)
+		}
+	})
+}
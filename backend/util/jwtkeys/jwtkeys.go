@@ -0,0 +1,206 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// MethodHS256 signs tokens with a shared HMAC secret
+	MethodHS256 = "HS256"
+	// MethodRS256 signs tokens with an RSA private key
+	MethodRS256 = "RS256"
+	// MethodEdDSA signs tokens with an Ed25519 private key
+	MethodEdDSA = "EdDSA"
+)
+
+// verificationKey is a key that can verify tokens signed with a specific method
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    interface{}
+}
+
+// KeyRing holds the key used for signing new tokens and all keys accepted for verification
+type KeyRing struct {
+	method       jwt.SigningMethod
+	signingKeyID string
+	signingKey   interface{}
+	keys         map[string]verificationKey
+}
+
+// JSONWebKey is the public representation of a verification key as described in RFC 7517
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+// JSONWebKeySet is a set of JSON Web Keys as served on the JWKS endpoint
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// Load builds a key ring. For HS256 the secret is used for both signing and verification.
+// For RS256 and EdDSA the private key is read from a PEM file and its public key is published
+// under keyID, while publicKeyFiles maps the key IDs of older keys to their public PEM files so
+// tokens signed before a rotation remain valid until they expire.
+func Load(method, secret, privateKeyFile, keyID string, publicKeyFiles map[string]string) (*KeyRing, error) {
+	ring := &KeyRing{
+		signingKeyID: keyID,
+		keys:         make(map[string]verificationKey),
+	}
+
+	switch method {
+	case "", MethodHS256:
+		ring.method = jwt.SigningMethodHS256
+		ring.signingKey = []byte(secret)
+		ring.keys[keyID] = verificationKey{method: ring.method, key: []byte(secret)}
+		return ring, nil
+	case MethodRS256:
+		ring.method = jwt.SigningMethodRS256
+	case MethodEdDSA:
+		ring.method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported JWT signing method: %s", method)
+	}
+
+	if len(keyID) == 0 {
+		return nil, fmt.Errorf("a key ID is required for JWT signing method %s", method)
+	}
+
+	privatePEM, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading JWT private key: %w", err)
+	}
+
+	var publicKey crypto.PublicKey
+	switch ring.method {
+	case jwt.SigningMethodRS256:
+		privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing JWT private key: %w", err)
+		}
+		ring.signingKey = privateKey
+		publicKey = &privateKey.PublicKey
+	case jwt.SigningMethodEdDSA:
+		privateKey, err := jwt.ParseEdPrivateKeyFromPEM(privatePEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing JWT private key: %w", err)
+		}
+		ring.signingKey = privateKey
+		publicKey = privateKey.(crypto.Signer).Public()
+	}
+	ring.keys[keyID] = verificationKey{method: ring.method, key: publicKey}
+
+	for kid, path := range publicKeyFiles {
+		if kid == keyID {
+			continue
+		}
+
+		publicPEM, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading JWT public key %s: %w", kid, err)
+		}
+
+		key, err := parsePublicKey(publicPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing JWT public key %s: %w", kid, err)
+		}
+		ring.keys[kid] = key
+	}
+
+	return ring, nil
+}
+
+// parsePublicKey parses either an RSA or an Ed25519 public key from PEM
+func parsePublicKey(publicPEM []byte) (verificationKey, error) {
+	if rsaKey, err := jwt.ParseRSAPublicKeyFromPEM(publicPEM); err == nil {
+		return verificationKey{method: jwt.SigningMethodRS256, key: rsaKey}, nil
+	}
+
+	edKey, err := jwt.ParseEdPublicKeyFromPEM(publicPEM)
+	if err != nil {
+		return verificationKey{}, fmt.Errorf("key is neither an RSA nor an Ed25519 public key")
+	}
+
+	return verificationKey{method: jwt.SigningMethodEdDSA, key: edKey}, nil
+}
+
+// Sign signs the claims with the current signing key and sets the kid header
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.method, claims)
+	if len(r.signingKeyID) > 0 {
+		token.Header["kid"] = r.signingKeyID
+	}
+	return token.SignedString(r.signingKey)
+}
+
+// Keyfunc resolves the verification key of a token by its kid header, making sure the token
+// is signed with the method that key belongs to
+func (r *KeyRing) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid := r.signingKeyID
+	if headerKid, ok := token.Header["kid"]; ok {
+		kid, ok = headerKid.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid key ID in token header")
+		}
+	}
+
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID: %s", kid)
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.key, nil
+}
+
+// JWKS returns the public verification keys as a JSON Web Key Set. Shared HMAC secrets are
+// never published.
+func (r *KeyRing) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: make([]JSONWebKey, 0)}
+	for kid, key := range r.keys {
+		switch publicKey := key.key.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				KeyType:   "RSA",
+				KeyID:     kid,
+				Use:       "sig",
+				Algorithm: key.method.Alg(),
+				N:         base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				KeyType:   "OKP",
+				KeyID:     kid,
+				Use:       "sig",
+				Algorithm: key.method.Alg(),
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(publicKey),
+			})
+		}
+	}
+
+	sort.Slice(set.Keys, func(i, j int) bool {
+		return set.Keys[i].KeyID < set.Keys[j].KeyID
+	})
+
+	return set
+}
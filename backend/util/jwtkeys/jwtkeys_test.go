@@ -0,0 +1,339 @@
+package jwtkeys_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/kerti/balances/backend/util/jwtkeys"
+	"github.com/stretchr/testify/assert"
+)
+
+func failOnError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func assertErrorContains(t *testing.T, err error, expected string) {
+	t.Helper()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), expected)
+	}
+}
+
+// testKey is a generated key pair written to PEM files the way a deployment would provide them
+type testKey struct {
+	privateFile string
+	publicFile  string
+	private     interface{}
+	public      interface{}
+}
+
+func writeTestKey(t *testing.T, method string) testKey {
+	t.Helper()
+
+	var private, public interface{}
+	switch method {
+	case jwtkeys.MethodRS256:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		failOnError(t, err)
+		private, public = key, &key.PublicKey
+	case jwtkeys.MethodEdDSA:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		failOnError(t, err)
+		private, public = privateKey, publicKey
+	default:
+		t.Fatalf("no key pair for method %s", method)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	failOnError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(public)
+	failOnError(t, err)
+
+	dir := t.TempDir()
+	key := testKey{
+		privateFile: filepath.Join(dir, "private.pem"),
+		publicFile:  filepath.Join(dir, "public.pem"),
+		private:     private,
+		public:      public,
+	}
+	failOnError(t, os.WriteFile(key.privateFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}), 0600))
+	failOnError(t, os.WriteFile(key.publicFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0600))
+
+	return key
+}
+
+func loadTestRing(t *testing.T, method, keyID string, key testKey, retired map[string]string) *jwtkeys.KeyRing {
+	t.Helper()
+
+	ring, err := jwtkeys.Load(method, "", key.privateFile, keyID, retired)
+	failOnError(t, err)
+	return ring
+}
+
+func TestKeyRing(t *testing.T) {
+
+	t.Run("signAndVerify", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			method string
+			keyID  string
+			alg    string
+		}{
+			{name: "hs256", method: jwtkeys.MethodHS256, alg: "HS256"},
+			{name: "defaultsToHS256", method: "", alg: "HS256"},
+			{name: "rs256", method: jwtkeys.MethodRS256, keyID: "rsa-1", alg: "RS256"},
+			{name: "edDSA", method: jwtkeys.MethodEdDSA, keyID: "ed-1", alg: "EdDSA"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var ring *jwtkeys.KeyRing
+				if tc.keyID == "" {
+					var err error
+					ring, err = jwtkeys.Load(tc.method, "secret", "", "", nil)
+					failOnError(t, err)
+				} else {
+					ring = loadTestRing(t, tc.method, tc.keyID, writeTestKey(t, tc.method), nil)
+				}
+
+				signed, err := ring.Sign(jwt.MapClaims{"id": "jdoe"})
+				failOnError(t, err)
+
+				token, err := jwt.Parse(signed, ring.Keyfunc)
+				failOnError(t, err)
+				assert.True(t, token.Valid)
+				assert.Equal(t, tc.alg, token.Method.Alg())
+				assert.Equal(t, "jdoe", token.Claims.(jwt.MapClaims)["id"])
+
+				kid, hasKid := token.Header["kid"]
+				assert.Equal(t, tc.keyID != "", hasKid)
+				if hasKid {
+					assert.Equal(t, tc.keyID, kid)
+				}
+			})
+		}
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		testCases := []struct {
+			name          string
+			retiredMethod string
+			currentMethod string
+		}{
+			{name: "rs256ToRS256", retiredMethod: jwtkeys.MethodRS256, currentMethod: jwtkeys.MethodRS256},
+			{name: "edDSAToEdDSA", retiredMethod: jwtkeys.MethodEdDSA, currentMethod: jwtkeys.MethodEdDSA},
+			{name: "rs256ToEdDSA", retiredMethod: jwtkeys.MethodRS256, currentMethod: jwtkeys.MethodEdDSA},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				retiredKey := writeTestKey(t, tc.retiredMethod)
+				currentKey := writeTestKey(t, tc.currentMethod)
+
+				retiredRing := loadTestRing(t, tc.retiredMethod, "old", retiredKey, nil)
+				currentRing := loadTestRing(t, tc.currentMethod, "new", currentKey, map[string]string{"old": retiredKey.publicFile})
+
+				issuedBefore, err := retiredRing.Sign(jwt.MapClaims{"id": "jdoe"})
+				failOnError(t, err)
+				issuedAfter, err := currentRing.Sign(jwt.MapClaims{"id": "jdoe"})
+				failOnError(t, err)
+
+				// tokens signed before the rotation remain valid until they expire
+				token, err := jwt.Parse(issuedBefore, currentRing.Keyfunc)
+				failOnError(t, err)
+				assert.True(t, token.Valid)
+				assert.Equal(t, "old", token.Header["kid"])
+
+				// while new tokens are signed with the current key only
+				token, err = jwt.Parse(issuedAfter, currentRing.Keyfunc)
+				failOnError(t, err)
+				assert.Equal(t, "new", token.Header["kid"])
+				assert.Equal(t, tc.currentMethod, token.Method.Alg())
+
+				_, err = jwt.Parse(issuedAfter, retiredRing.Keyfunc)
+				assertErrorContains(t, err, "unknown key ID: new")
+			})
+		}
+	})
+
+	t.Run("rejects", func(t *testing.T) {
+		rsaKey := writeTestKey(t, jwtkeys.MethodRS256)
+		ring := loadTestRing(t, jwtkeys.MethodRS256, "rsa-1", rsaKey, nil)
+
+		testCases := []struct {
+			name     string
+			token    func() string
+			expected string
+		}{
+			{
+				name: "unknownKeyID",
+				token: func() string {
+					token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": "jdoe"})
+					token.Header["kid"] = "missing"
+					signed, _ := token.SignedString([]byte("secret"))
+					return signed
+				},
+				expected: "unknown key ID: missing",
+			},
+			{
+				name: "methodOfAnotherKey",
+				token: func() string {
+					// an HMAC token keyed with the published RSA key must not pass as an RSA token
+					publicDER, _ := x509.MarshalPKIXPublicKey(rsaKey.public)
+					token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": "jdoe"})
+					token.Header["kid"] = "rsa-1"
+					signed, _ := token.SignedString(publicDER)
+					return signed
+				},
+				expected: "unexpected signing method",
+			},
+			{
+				name: "invalidKeyID",
+				token: func() string {
+					token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{"id": "jdoe"})
+					token.Header["kid"] = 1
+					signed, _ := token.SignedString(rsaKey.private)
+					return signed
+				},
+				expected: "invalid key ID",
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := jwt.Parse(tc.token(), ring.Keyfunc)
+				assertErrorContains(t, err, tc.expected)
+			})
+		}
+	})
+
+	t.Run("jwks", func(t *testing.T) {
+		rsaKey := writeTestKey(t, jwtkeys.MethodRS256)
+		edKey := writeTestKey(t, jwtkeys.MethodEdDSA)
+		rsaPublic := rsaKey.public.(*rsa.PublicKey)
+		edPublic := edKey.public.(ed25519.PublicKey)
+
+		testCases := []struct {
+			name     string
+			ring     func() *jwtkeys.KeyRing
+			expected []jwtkeys.JSONWebKey
+		}{
+			{
+				name: "hs256PublishesNothing",
+				ring: func() *jwtkeys.KeyRing {
+					ring, err := jwtkeys.Load(jwtkeys.MethodHS256, "secret", "", "hmac-1", nil)
+					failOnError(t, err)
+					return ring
+				},
+				expected: []jwtkeys.JSONWebKey{},
+			},
+			{
+				name: "currentAndRetiredKeys",
+				ring: func() *jwtkeys.KeyRing {
+					return loadTestRing(t, jwtkeys.MethodEdDSA, "b-ed", edKey, map[string]string{"a-rsa": rsaKey.publicFile})
+				},
+				expected: []jwtkeys.JSONWebKey{
+					{
+						KeyType:   "RSA",
+						KeyID:     "a-rsa",
+						Use:       "sig",
+						Algorithm: "RS256",
+						N:         base64.RawURLEncoding.EncodeToString(rsaPublic.N.Bytes()),
+						E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaPublic.E)).Bytes()),
+					},
+					{
+						KeyType:   "OKP",
+						KeyID:     "b-ed",
+						Use:       "sig",
+						Algorithm: "EdDSA",
+						Curve:     "Ed25519",
+						X:         base64.RawURLEncoding.EncodeToString(edPublic),
+					},
+				},
+			},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				set := tc.ring().JWKS()
+				assert.Equal(t, tc.expected, set.Keys)
+			})
+		}
+
+		t.Run("encoding", func(t *testing.T) {
+			ring := loadTestRing(t, jwtkeys.MethodRS256, "rsa-1", rsaKey, map[string]string{"ed-1": edKey.publicFile})
+
+			encoded, err := json.Marshal(ring.JWKS())
+			failOnError(t, err)
+
+			var decoded struct {
+				Keys []map[string]string `json:"keys"`
+			}
+			failOnError(t, json.Unmarshal(encoded, &decoded))
+			if !assert.Len(t, decoded.Keys, 2) {
+				return
+			}
+
+			// members that do not apply to a key type are left out
+			assert.Equal(t, map[string]string{
+				"kty": "OKP",
+				"kid": "ed-1",
+				"use": "sig",
+				"alg": "EdDSA",
+				"crv": "Ed25519",
+				"x":   base64.RawURLEncoding.EncodeToString(edPublic),
+			}, decoded.Keys[0])
+			assert.Equal(t, "RSA", decoded.Keys[1]["kty"])
+			assert.NotContains(t, decoded.Keys[1], "crv")
+			assert.NotContains(t, decoded.Keys[1], "x")
+
+			// the modulus and exponent decode back to the key they were published for
+			n, err := base64.RawURLEncoding.DecodeString(decoded.Keys[1]["n"])
+			failOnError(t, err)
+			e, err := base64.RawURLEncoding.DecodeString(decoded.Keys[1]["e"])
+			failOnError(t, err)
+			assert.Equal(t, 0, new(big.Int).SetBytes(n).Cmp(rsaPublic.N))
+			assert.Equal(t, int64(rsaPublic.E), new(big.Int).SetBytes(e).Int64())
+		})
+	})
+
+	t.Run("load", func(t *testing.T) {
+		rsaKey := writeTestKey(t, jwtkeys.MethodRS256)
+
+		testCases := []struct {
+			name           string
+			method         string
+			privateKeyFile string
+			keyID          string
+			retired        map[string]string
+			expected       string
+		}{
+			{name: "unsupportedMethod", method: "ES256", keyID: "k", expected: "unsupported JWT signing method"},
+			{name: "missingKeyID", method: jwtkeys.MethodRS256, privateKeyFile: rsaKey.privateFile, expected: "a key ID is required"},
+			{name: "missingPrivateKey", method: jwtkeys.MethodRS256, privateKeyFile: filepath.Join(t.TempDir(), "missing.pem"), keyID: "k", expected: "failed reading JWT private key"},
+			{name: "privateKeyOfAnotherMethod", method: jwtkeys.MethodEdDSA, privateKeyFile: rsaKey.privateFile, keyID: "k", expected: "failed parsing JWT private key"},
+			{name: "notAPublicKey", method: jwtkeys.MethodRS256, privateKeyFile: rsaKey.privateFile, keyID: "k", retired: map[string]string{"old": rsaKey.privateFile}, expected: "failed parsing JWT public key old"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				ring, err := jwtkeys.Load(tc.method, "", tc.privateKeyFile, tc.keyID, tc.retired)
+				assert.Nil(t, ring)
+				assertErrorContains(t, err, tc.expected)
+			})
+		}
+	})
+}
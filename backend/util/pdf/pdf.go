@@ -0,0 +1,224 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the content type of a PDF document
+const ContentType = "application/pdf"
+
+const (
+	// PageWidth is the width of an A4 page in points
+	PageWidth = 595.28
+	// PageHeight is the height of an A4 page in points
+	PageHeight = 841.89
+)
+
+// Font is one of the standard fonts every PDF reader provides, so that none have to be embedded
+type Font int
+
+const (
+	// FontRegular is Helvetica
+	FontRegular Font = iota
+	// FontBold is Helvetica Bold
+	FontBold
+)
+
+// fontNames are the resource names and base fonts of the fonts, in order
+var fontNames = []struct {
+	resource string
+	base     string
+}{
+	{"F1", "Helvetica"},
+	{"F2", "Helvetica-Bold"},
+}
+
+// Document is a PDF document, built in memory and written out in one go
+type Document struct {
+	title string
+	pages []*Page
+}
+
+// Page is a single page of a document. Coordinates are in points, from the bottom left corner of the page.
+type Page struct {
+	content bytes.Buffer
+}
+
+// NewDocument creates a new, empty Document
+func NewDocument(title string) *Document {
+	return &Document{title: title, pages: make([]*Page, 0)}
+}
+
+// AddPage adds an A4 page to the document
+func (d *Document) AddPage() *Page {
+	page := new(Page)
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// Pages returns the pages of the document, in order
+func (d *Document) Pages() []*Page {
+	return d.pages
+}
+
+// Text writes a line of text starting at the given position
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		fontNames[font].resource, formatNumber(size), formatNumber(x), formatNumber(y), escape(text))
+}
+
+// TextRight writes a line of text ending at the given position
+func (p *Page) TextRight(x, y float64, font Font, size float64, text string) {
+	p.Text(x-TextWidth(font, size, text), y, font, size, text)
+}
+
+// Line draws a straight line between two points
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		formatNumber(width), formatNumber(x1), formatNumber(y1), formatNumber(x2), formatNumber(y2))
+}
+
+// TextWidth measures the width of a line of text
+func TextWidth(font Font, size float64, text string) float64 {
+	widths := helveticaWidths
+	if font == FontBold {
+		widths = helveticaBoldWidths
+	}
+
+	total := 0
+	for _, b := range encode(text) {
+		if b >= 32 && int(b)-32 < len(widths) {
+			total += widths[b-32]
+		} else {
+			total += defaultWidth
+		}
+	}
+
+	return float64(total) * size / 1000
+}
+
+// Truncate shortens a line of text to fit within the given width, marking it with an ellipsis when shortened
+func Truncate(font Font, size float64, text string, width float64) string {
+	if TextWidth(font, size, text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		shortened := strings.TrimSpace(string(runes)) + "..."
+		if TextWidth(font, size, shortened) <= width {
+			return shortened
+		}
+	}
+
+	return ""
+}
+
+// Write writes the document as a PDF file
+func (d *Document) Write(out io.Writer) error {
+	var b bytes.Buffer
+	offsets := make([]int, 0)
+
+	// objects are numbered from one: the catalog, the page tree, the info dictionary, the fonts,
+	// then the page and content stream of each page
+	object := func(content string) {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", len(offsets), content)
+	}
+	firstFont := 4
+	firstPage := firstFont + len(fontNames)
+
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, 0, len(d.pages))
+	for idx := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", firstPage+idx*2))
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+
+	object(fmt.Sprintf("<< /Title (%s) /Producer (Balances) >>", escape(d.title)))
+
+	fonts := make([]string, 0, len(fontNames))
+	for idx, font := range fontNames {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font.base))
+		fonts = append(fonts, fmt.Sprintf("/%s %d 0 R", font.resource, firstFont+idx))
+	}
+
+	for idx, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R >>",
+			formatNumber(PageWidth), formatNumber(PageHeight), strings.Join(fonts, " "), firstPage+idx*2+1))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()))
+	}
+
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := out.Write(b.Bytes())
+	return err
+}
+
+// encode converts text to WinAnsiEncoding, which matches Latin-1 for the characters it shares with it,
+// replacing the characters it cannot represent
+func encode(text string) []byte {
+	encoded := make([]byte, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r < 32:
+			encoded = append(encoded, ' ')
+		case r < 127, r >= 0xA0 && r <= 0xFF:
+			encoded = append(encoded, byte(r))
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+func escape(text string) string {
+	var b strings.Builder
+	for _, c := range encode(text) {
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// defaultWidth is the width of the characters the width tables do not cover, in thousandths of the font size
+const defaultWidth = 556
+
+// helveticaWidths are the widths of the printable ASCII characters in Helvetica, from the space onwards
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// helveticaBoldWidths are the widths of the printable ASCII characters in Helvetica Bold, from the space onwards
+var helveticaBoldWidths = []int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
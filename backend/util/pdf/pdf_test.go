@@ -0,0 +1,140 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected []byte
+	}{
+		{name: "ascii", text: "Net Worth 2024", expected: []byte("Net Worth 2024")},
+		{name: "latin1", text: "Café ©", expected: []byte{'C', 'a', 'f', 0xE9, ' ', 0xA9}},
+		{name: "controlCharacters", text: "a\tb\nc", expected: []byte("a b c")},
+		{name: "delete", text: "a\x7fb", expected: []byte("a?b")},
+		{name: "c1Controls", text: "a\u0085b", expected: []byte("a?b")},
+		{name: "beyondLatin1", text: "€ 100 – Ωμέγα", expected: []byte("? 100 ? ?????")},
+		{name: "cjkAndEmoji", text: "日本💰", expected: []byte("???")},
+		{name: "empty", text: "", expected: []byte{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, encode(testCase.text))
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "plain", text: "Savings", expected: "Savings"},
+		{name: "parentheses", text: "Savings (joint)", expected: `Savings \(joint\)`},
+		{name: "backslash", text: `C:\Temp`, expected: `C:\\Temp`},
+		{name: "unbalanced", text: `)(\`, expected: `\)\(\\`},
+		{name: "encodedFirst", text: "(日本)", expected: `\(??\)`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, escape(testCase.text))
+		})
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	// H, e, l, l and o are 722, 556, 222, 222 and 556 thousandths wide in Helvetica
+	assert.InDelta(t, 22.78, TextWidth(FontRegular, 10, "Hello"), 1e-9)
+	// and 722, 556, 278, 278 and 611 in Helvetica Bold
+	assert.InDelta(t, 24.45, TextWidth(FontBold, 10, "Hello"), 1e-9)
+	// characters outside the width tables take the default width
+	assert.InDelta(t, float64(defaultWidth*2)*12/1000, TextWidth(FontRegular, 12, "é©"), 1e-9)
+}
+
+func TestTruncate(t *testing.T) {
+	// "Hello World" is 51.67 points wide at 10 points, "Hello W..." 43.34 and "Hello..." 31.12
+	testCases := []struct {
+		name     string
+		text     string
+		width    float64
+		expected string
+	}{
+		{name: "fits", text: "Hello World", width: 60, expected: "Hello World"},
+		{name: "fitsExactly", text: "Hello World", width: 51.67, expected: "Hello World"},
+		{name: "shortened", text: "Hello World", width: 45, expected: "Hello W..."},
+		{name: "trailingSpaceTrimmed", text: "Hello World", width: 40, expected: "Hello..."},
+		{name: "onlyEllipsisFits", text: "Hello World", width: 9, expected: "..."},
+		{name: "nothingFits", text: "Hello World", width: 5, expected: ""},
+		{name: "multibyteRunes", text: "Ωμέγα Savings", width: 20, expected: "Ωμ..."},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			truncated := Truncate(FontRegular, 10, testCase.text, testCase.width)
+
+			assert.Equal(t, testCase.expected, truncated)
+			assert.LessOrEqual(t, TextWidth(FontRegular, 10, truncated), testCase.width)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	for _, pageCount := range []int{0, 1, 3} {
+		t.Run(fmt.Sprintf("pages%d", pageCount), func(t *testing.T) {
+			document := NewDocument("Net Worth (2024) – Café")
+			for idx := 0; idx < pageCount; idx++ {
+				page := document.AddPage()
+				page.Text(40, PageHeight-40, FontBold, 14, fmt.Sprintf("Page %d (of %d) \\ 日本", idx+1, pageCount))
+				page.Line(40, 40, PageWidth-40, 40, 0.5)
+			}
+
+			var buf bytes.Buffer
+			err := document.Write(&buf)
+			assert.Nil(t, err)
+
+			output := buf.Bytes()
+			assert.True(t, bytes.HasPrefix(output, []byte("%PDF-1.4\n")))
+			assert.True(t, bytes.HasSuffix(output, []byte("%%EOF\n")))
+
+			// the startxref offset points at the cross-reference table
+			startxref := bytes.LastIndex(output, []byte("startxref\n"))
+			assert.True(t, startxref > 0)
+			xref, err := strconv.Atoi(strings.Fields(string(output[startxref+len("startxref\n"):]))[0])
+			assert.Nil(t, err)
+			assert.True(t, bytes.HasPrefix(output[xref:], []byte("xref\n")))
+
+			// the catalog, page tree, info dictionary and fonts, then a page and content stream per page
+			objectCount := 3 + len(fontNames) + pageCount*2
+			lines := strings.Split(string(output[xref:]), "\n")
+			assert.Equal(t, fmt.Sprintf("0 %d", objectCount+1), lines[1])
+			assert.Equal(t, "0000000000 65535 f ", lines[2])
+
+			// every entry points at the start of its own object
+			for idx := 1; idx <= objectCount; idx++ {
+				entry := lines[2+idx]
+				assert.Len(t, entry, 19)
+				assert.True(t, strings.HasSuffix(entry, " 00000 n "), entry)
+
+				offset, err := strconv.Atoi(entry[:10])
+				assert.Nil(t, err)
+				assert.True(t, bytes.HasPrefix(output[offset:], []byte(fmt.Sprintf("%d 0 obj\n", idx))), "object %d", idx)
+			}
+			assert.Equal(t, "trailer", lines[3+objectCount])
+
+			assert.Contains(t, string(output), fmt.Sprintf("/Size %d /Root 1 0 R /Info 3 0 R", objectCount+1))
+			assert.Contains(t, string(output), fmt.Sprintf("/Count %d", pageCount))
+			assert.Contains(t, string(output), "/Title (Net Worth \\(2024\\) ? Caf\xe9)")
+		})
+	}
+}
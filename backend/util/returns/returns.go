@@ -0,0 +1,169 @@
+package returns
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+// ErrUndefined is returned when a return cannot be calculated from the valuations and cash flows given,
+// such as when nothing was held over a period or the cash flows never change sign
+var ErrUndefined = errors.New("return is undefined")
+
+const (
+	daysPerYear   = 365.0
+	maxIterations = 100
+	tolerance     = 1e-9
+)
+
+// Valuation is the value of an account on a specific date
+type Valuation struct {
+	Date  time.Time
+	Value float64
+}
+
+// CashFlow is money moved into the account, or out of it when negative, on a specific date
+type CashFlow struct {
+	Date   time.Time
+	Amount float64
+}
+
+// TimeWeighted calculates the time-weighted return over the period between the first and last valuations,
+// as a fraction. The period is split at every valuation and the return of each sub-period is calculated
+// with the Modified Dietz method, weighting the cash flows within it by how long they were held, so that
+// deposits and withdrawals do not count as growth. Cash flows on the date of a valuation are taken to be
+// included in it.
+func TimeWeighted(valuations []Valuation, cashFlows []CashFlow) (float64, error) {
+	if len(valuations) < 2 {
+		return 0, ErrUndefined
+	}
+
+	valuations = sortValuations(valuations)
+	cashFlows = sortCashFlows(cashFlows)
+
+	growth := 1.0
+	for idx := 1; idx < len(valuations); idx++ {
+		start, end := valuations[idx-1], valuations[idx]
+		period := end.Date.Sub(start.Date).Hours()
+		if period <= 0 {
+			continue
+		}
+
+		netFlow, weightedFlow := 0.0, 0.0
+		for _, cashFlow := range cashFlows {
+			if !cashFlow.Date.After(start.Date) || cashFlow.Date.After(end.Date) {
+				continue
+			}
+			netFlow += cashFlow.Amount
+			weightedFlow += cashFlow.Amount * end.Date.Sub(cashFlow.Date).Hours() / period
+		}
+
+		gain := end.Value - start.Value - netFlow
+		invested := start.Value + weightedFlow
+		if math.Abs(invested) < tolerance {
+			// nothing was held over the sub-period, which leaves it out unless something was still gained
+			if math.Abs(gain) < tolerance {
+				continue
+			}
+			return 0, ErrUndefined
+		}
+
+		growth *= 1 + gain/invested
+	}
+
+	return growth - 1, nil
+}
+
+// XIRR calculates the money-weighted return of a series of cash flows as an annual rate, as a fraction.
+// Cash flows are seen from the investor's side: money put in is negative and money taken out, including
+// the value held at the end, is positive.
+func XIRR(cashFlows []CashFlow) (float64, error) {
+	cashFlows = sortCashFlows(cashFlows)
+
+	hasPositive, hasNegative := false, false
+	for _, cashFlow := range cashFlows {
+		hasPositive = hasPositive || cashFlow.Amount > 0
+		hasNegative = hasNegative || cashFlow.Amount < 0
+	}
+	if !hasPositive || !hasNegative {
+		return 0, ErrUndefined
+	}
+
+	years := make([]float64, len(cashFlows))
+	for idx, cashFlow := range cashFlows {
+		years[idx] = cashFlow.Date.Sub(cashFlows[0].Date).Hours() / 24 / daysPerYear
+	}
+
+	npv := func(rate float64) (value, derivative float64) {
+		for idx, cashFlow := range cashFlows {
+			discount := math.Pow(1+rate, years[idx])
+			value += cashFlow.Amount / discount
+			derivative -= years[idx] * cashFlow.Amount / (discount * (1 + rate))
+		}
+		return
+	}
+
+	// Newton's method converges quickly from a sensible guess
+	rate := 0.1
+	for i := 0; i < maxIterations; i++ {
+		value, derivative := npv(rate)
+		if math.Abs(value) < tolerance {
+			return rate, nil
+		}
+		if derivative == 0 {
+			break
+		}
+		next := rate - value/derivative
+		if next <= -1 || math.IsNaN(next) || math.IsInf(next, 0) {
+			break
+		}
+		if math.Abs(next-rate) < tolerance {
+			return next, nil
+		}
+		rate = next
+	}
+
+	// otherwise fall back to bisection, which is slower but cannot diverge
+	low, high := -0.999999, 1.0
+	lowValue, _ := npv(low)
+	highValue, _ := npv(high)
+	for lowValue*highValue > 0 {
+		if high > 1e6 {
+			return 0, ErrUndefined
+		}
+		high *= 2
+		highValue, _ = npv(high)
+	}
+
+	for i := 0; i < maxIterations*10; i++ {
+		mid := (low + high) / 2
+		midValue, _ := npv(mid)
+		if math.Abs(midValue) < tolerance || (high-low)/2 < tolerance {
+			return mid, nil
+		}
+		if lowValue*midValue < 0 {
+			high = mid
+		} else {
+			low, lowValue = mid, midValue
+		}
+	}
+
+	return (low + high) / 2, nil
+}
+
+func sortValuations(valuations []Valuation) []Valuation {
+	sorted := append([]Valuation(nil), valuations...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+	return sorted
+}
+
+func sortCashFlows(cashFlows []CashFlow) []CashFlow {
+	sorted := append([]CashFlow(nil), cashFlows...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Date.Before(sorted[j].Date)
+	})
+	return sorted
+}
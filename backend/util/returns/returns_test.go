@@ -0,0 +1,310 @@
+package returns_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/kerti/balances/backend/util/returns"
+	"github.com/stretchr/testify/assert"
+)
+
+// returnDelta is how far a calculated return may stray from the expected one
+const returnDelta = 1e-6
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTimeWeighted(t *testing.T) {
+
+	t.Run("normal", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			valuations []returns.Valuation
+			cashFlows  []returns.CashFlow
+			expected   float64
+		}{
+			{
+				name: "noCashFlows",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 100},
+					{Date: date(2024, time.December, 31), Value: 110},
+				},
+				expected: 0.1,
+			},
+			{
+				name: "chainsSubPeriods",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 100},
+					{Date: date(2024, time.July, 1), Value: 110},
+					{Date: date(2024, time.December, 31), Value: 121},
+				},
+				expected: 0.21,
+			},
+			{
+				name: "unsortedValuations",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.December, 31), Value: 121},
+					{Date: date(2024, time.January, 1), Value: 100},
+					{Date: date(2024, time.July, 1), Value: 110},
+				},
+				expected: 0.21,
+			},
+			{
+				name: "depositWeightedByTimeHeld",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 100},
+					{Date: date(2024, time.January, 31), Value: 210},
+				},
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.January, 16), Amount: 100},
+				},
+				expected: 10.0 / 150.0,
+			},
+			{
+				name: "cashFlowOnValuationDateBelongsToEndingSubPeriod",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 100},
+					{Date: date(2024, time.February, 1), Value: 200},
+					{Date: date(2024, time.March, 1), Value: 220},
+				},
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.February, 1), Amount: 100},
+				},
+				expected: 0.1,
+			},
+			{
+				name: "cashFlowsOutsidePeriodIgnored",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 100},
+					{Date: date(2024, time.December, 31), Value: 110},
+				},
+				cashFlows: []returns.CashFlow{
+					{Date: date(2023, time.June, 1), Amount: 500},
+					{Date: date(2024, time.January, 1), Amount: 100},
+					{Date: date(2025, time.January, 1), Amount: -50},
+				},
+				expected: 0.1,
+			},
+			{
+				name: "zeroInvestedSubPeriodSkipped",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 100},
+					{Date: date(2024, time.February, 1), Value: 0},
+					{Date: date(2024, time.March, 1), Value: 0},
+				},
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.February, 1), Amount: -110},
+				},
+				expected: 0.1,
+			},
+			{
+				name: "sameDayValuationsSkipped",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 100},
+					{Date: date(2024, time.January, 1), Value: 105},
+					{Date: date(2024, time.December, 31), Value: 110},
+				},
+				expected: 110.0/105.0 - 1,
+			},
+			{
+				name: "allValuationsSameDay",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 100},
+					{Date: date(2024, time.January, 1), Value: 105},
+				},
+				expected: 0,
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				result, err := returns.TimeWeighted(testCase.valuations, testCase.cashFlows)
+
+				assert.Nil(t, err)
+				assert.InDelta(t, testCase.expected, result, returnDelta)
+			})
+		}
+	})
+
+	t.Run("undefined", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			valuations []returns.Valuation
+			cashFlows  []returns.CashFlow
+		}{
+			{
+				name: "noValuations",
+			},
+			{
+				name: "singleValuation",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 100},
+				},
+			},
+			{
+				name: "gainWithNothingInvested",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 0},
+					{Date: date(2024, time.December, 31), Value: 10},
+				},
+			},
+			{
+				name: "gainOnDepositMadeAtEnd",
+				valuations: []returns.Valuation{
+					{Date: date(2024, time.January, 1), Value: 0},
+					{Date: date(2024, time.December, 31), Value: 110},
+				},
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.December, 31), Amount: 100},
+				},
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				result, err := returns.TimeWeighted(testCase.valuations, testCase.cashFlows)
+
+				assert.Equal(t, returns.ErrUndefined, err)
+				assert.Equal(t, 0.0, result)
+			})
+		}
+	})
+
+}
+
+func TestXIRR(t *testing.T) {
+
+	t.Run("normal", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			cashFlows []returns.CashFlow
+			expected  float64
+		}{
+			{
+				name: "singleYear",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2021, time.January, 1), Amount: -1000},
+					{Date: date(2022, time.January, 1), Amount: 1100},
+				},
+				expected: 0.1,
+			},
+			{
+				name: "twoYearsCompounded",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2021, time.January, 1), Amount: -1000},
+					{Date: date(2023, time.January, 1), Amount: 1210},
+				},
+				expected: 0.1,
+			},
+			{
+				name: "leapYearCountsActualDays",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.January, 1), Amount: -1000},
+					{Date: date(2025, time.January, 1), Amount: 1100},
+				},
+				expected: math.Pow(1.1, 365.0/366.0) - 1,
+			},
+			{
+				// the reference example for the spreadsheet XIRR function
+				name: "spreadsheetReference",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2008, time.January, 1), Amount: -10000},
+					{Date: date(2008, time.March, 1), Amount: 2750},
+					{Date: date(2008, time.October, 30), Amount: 4250},
+					{Date: date(2009, time.February, 15), Amount: 3250},
+					{Date: date(2009, time.April, 1), Amount: 2750},
+				},
+				expected: 0.373362535,
+			},
+			{
+				name: "unsortedCashFlows",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2009, time.April, 1), Amount: 2750},
+					{Date: date(2008, time.October, 30), Amount: 4250},
+					{Date: date(2008, time.January, 1), Amount: -10000},
+					{Date: date(2009, time.February, 15), Amount: 3250},
+					{Date: date(2008, time.March, 1), Amount: 2750},
+				},
+				expected: 0.373362535,
+			},
+			{
+				name: "loss",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2021, time.January, 1), Amount: -1000},
+					{Date: date(2022, time.January, 1), Amount: 800},
+				},
+				expected: -0.2,
+			},
+			{
+				// Newton's method overshoots past -100% from its initial guess on a steep loss over a single
+				// day, leaving the rate to bisection
+				name: "bisectionFallback",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.January, 1), Amount: -100},
+					{Date: date(2024, time.January, 2), Amount: 99.5},
+				},
+				expected: math.Pow(0.995, 365) - 1,
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				result, err := returns.XIRR(testCase.cashFlows)
+
+				assert.Nil(t, err)
+				assert.InDelta(t, testCase.expected, result, returnDelta)
+			})
+		}
+	})
+
+	t.Run("undefined", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			cashFlows []returns.CashFlow
+		}{
+			{
+				name: "noCashFlows",
+			},
+			{
+				name: "onlyNegative",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.January, 1), Amount: -100},
+					{Date: date(2024, time.July, 1), Amount: -50},
+				},
+			},
+			{
+				name: "onlyPositive",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.January, 1), Amount: 100},
+					{Date: date(2024, time.July, 1), Amount: 50},
+				},
+			},
+			{
+				name: "onlyZero",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.January, 1), Amount: 0},
+					{Date: date(2024, time.July, 1), Amount: 0},
+				},
+			},
+			{
+				name: "noRateAboveTotalLoss",
+				cashFlows: []returns.CashFlow{
+					{Date: date(2024, time.January, 1), Amount: -100},
+					{Date: date(2024, time.January, 2), Amount: 1},
+				},
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				result, err := returns.XIRR(testCase.cashFlows)
+
+				assert.Equal(t, returns.ErrUndefined, err)
+				assert.Equal(t, 0.0, result)
+			})
+		}
+	})
+
+}
@@ -0,0 +1,105 @@
+package statement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kerti/balances/backend/util/failure"
+)
+
+const (
+	// camtClosingBooked is the code of the closing balance of a camt.053 statement
+	camtClosingBooked = "CLBD"
+	// camtDebit is the indicator of a balance in the customer's debit
+	camtDebit = "DBIT"
+)
+
+// camtDocument is the part of a camt.053 document holding balances, matched regardless of its schema version
+type camtDocument struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	IBAN     string        `xml:"Acct>Id>IBAN"`
+	Other    string        `xml:"Acct>Id>Othr>Id"`
+	Balances []camtBalance `xml:"Bal"`
+}
+
+type camtBalance struct {
+	Code      string `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    string `xml:"Amt"`
+	Indicator string `xml:"CdtDbtInd"`
+	Date      string `xml:"Dt>Dt"`
+	DateTime  string `xml:"Dt>DtTm"`
+}
+
+// parseCAMT053 reads the closing booked balances of every statement in a camt.053 document
+func parseCAMT053(data []byte) ([]Statement, error) {
+	var document camtDocument
+	err := xml.Unmarshal(data, &document)
+	if err != nil {
+		return nil, failure.BadRequest(err)
+	}
+
+	statements := make([]Statement, 0, len(document.Statements))
+	for _, camtStatement := range document.Statements {
+		statement := Statement{
+			AccountNumber: strings.TrimSpace(camtStatement.IBAN),
+			Balances:      make([]Balance, 0),
+		}
+		if statement.AccountNumber == "" {
+			statement.AccountNumber = strings.TrimSpace(camtStatement.Other)
+		}
+		if statement.AccountNumber == "" {
+			return nil, failure.BadRequestFromString("camt.053 statement has no account number")
+		}
+
+		for _, camtBalance := range camtStatement.Balances {
+			if strings.TrimSpace(camtBalance.Code) != camtClosingBooked {
+				continue
+			}
+
+			balance, err := getCAMTBalance(camtBalance)
+			if err != nil {
+				return nil, err
+			}
+			statement.Balances = append(statement.Balances, balance)
+		}
+
+		statements = append(statements, statement)
+	}
+
+	return statements, nil
+}
+
+func getCAMTBalance(camtBalance camtBalance) (balance Balance, err error) {
+	balance.Amount, err = parseAmount(camtBalance.Amount)
+	if err != nil {
+		return balance, failure.BadRequestFromString(fmt.Sprintf("invalid camt.053 closing balance amount: %q", camtBalance.Amount))
+	}
+	if strings.TrimSpace(camtBalance.Indicator) == camtDebit {
+		balance.Amount = -balance.Amount
+	}
+
+	if date := strings.TrimSpace(camtBalance.Date); date != "" {
+		balance.Date, err = time.Parse("2006-01-02", date)
+	} else {
+		balance.Date, err = parseCAMTDateTime(strings.TrimSpace(camtBalance.DateTime))
+	}
+	if err != nil {
+		return balance, failure.BadRequestFromString("invalid camt.053 closing balance date")
+	}
+
+	return
+}
+
+// parseCAMTDateTime parses an ISO date and time, which may or may not carry an offset from UTC
+func parseCAMTDateTime(value string) (time.Time, error) {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02T15:04:05", value)
+}
@@ -0,0 +1,171 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kerti/balances/backend/util/failure"
+)
+
+// ofxToken is a single tag of an OFX document along with the text following it
+type ofxToken struct {
+	name    string
+	closing bool
+	text    string
+}
+
+// tokenizeOFX splits an OFX document into tags. It reads both the SGML flavour, which leaves the
+// elements holding values unclosed, and the XML flavour, skipping headers, declarations and comments.
+func tokenizeOFX(data []byte) []ofxToken {
+	// SGML headers are plain "KEY:VALUE" lines preceding the document itself
+	if start := bytes.Index(bytes.ToUpper(data), []byte("<OFX>")); start > 0 {
+		data = data[start:]
+	}
+
+	tokens := make([]ofxToken, 0)
+	for {
+		open := bytes.IndexByte(data, '<')
+		if open < 0 {
+			break
+		}
+		end := bytes.IndexByte(data[open:], '>')
+		if end < 0 {
+			break
+		}
+
+		tag := strings.TrimSpace(string(data[open+1 : open+end]))
+		data = data[open+end+1:]
+
+		text := data
+		if next := bytes.IndexByte(data, '<'); next >= 0 {
+			text = data[:next]
+		}
+
+		if tag == "" || tag[0] == '?' || tag[0] == '!' {
+			continue
+		}
+
+		token := ofxToken{text: html.UnescapeString(strings.TrimSpace(string(text)))}
+		if tag[0] == '/' {
+			token.closing = true
+			tag = tag[1:]
+		}
+		token.name = strings.ToUpper(strings.TrimSuffix(strings.TrimSpace(tag), "/"))
+		tokens = append(tokens, token)
+	}
+
+	return tokens
+}
+
+// parseOFX reads the ledger balances of every bank and credit card statement in an OFX document
+func parseOFX(data []byte) ([]Statement, error) {
+	statements := make([]Statement, 0)
+
+	var current *Statement
+	var inAccount, inLedger bool
+	var amount, date string
+
+	for _, token := range tokenizeOFX(data) {
+		switch token.name {
+		case "STMTRS", "CCSTMTRS":
+			if !token.closing {
+				current = &Statement{Balances: make([]Balance, 0)}
+				continue
+			}
+			if current != nil {
+				if current.AccountNumber == "" {
+					return nil, failure.BadRequestFromString("OFX statement has no account number")
+				}
+				statements = append(statements, *current)
+			}
+			current = nil
+		case "BANKACCTFROM", "CCACCTFROM":
+			inAccount = !token.closing
+		case "ACCTID":
+			if current != nil && inAccount && !token.closing {
+				current.AccountNumber = token.text
+			}
+		case "LEDGERBAL":
+			if !token.closing {
+				inLedger = true
+				amount, date = "", ""
+				continue
+			}
+			inLedger = false
+			if current == nil {
+				continue
+			}
+
+			balance, err := getOFXBalance(amount, date)
+			if err != nil {
+				return nil, err
+			}
+			current.Balances = append(current.Balances, balance)
+		case "BALAMT":
+			if inLedger && !token.closing {
+				amount = token.text
+			}
+		case "DTASOF":
+			if inLedger && !token.closing {
+				date = token.text
+			}
+		}
+	}
+
+	return statements, nil
+}
+
+func getOFXBalance(amount, date string) (balance Balance, err error) {
+	balance.Amount, err = parseAmount(amount)
+	if err != nil {
+		return balance, failure.BadRequestFromString(fmt.Sprintf("invalid OFX ledger balance amount: %q", amount))
+	}
+
+	balance.Date, err = parseOFXDate(date)
+	if err != nil {
+		return balance, failure.BadRequestFromString(fmt.Sprintf("invalid OFX ledger balance date: %q", date))
+	}
+
+	return
+}
+
+// parseOFXDate parses an OFX date, written as YYYYMMDD[HHMMSS[.XXX]][offset[:TZ]] where the bracketed
+// offset is in hours from UTC. Dates without an offset are taken to be in UTC.
+func parseOFXDate(value string) (time.Time, error) {
+	location := time.UTC
+	if open := strings.IndexByte(value, '['); open >= 0 {
+		zone := strings.TrimSuffix(value[open+1:], "]")
+		value = value[:open]
+
+		offset := zone
+		if colon := strings.IndexByte(zone, ':'); colon >= 0 {
+			offset = zone[:colon]
+		}
+		hours, err := strconv.ParseFloat(offset, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		location = time.FixedZone(zone, int(math.Round(hours*3600)))
+	}
+
+	// fractional seconds are of no use for balances
+	if dot := strings.IndexByte(value, '.'); dot >= 0 {
+		value = value[:dot]
+	}
+
+	switch len(value) {
+	case 8:
+		return time.ParseInLocation("20060102", value, location)
+	case 12:
+		return time.ParseInLocation("200601021504", value, location)
+	case 14:
+		return time.ParseInLocation("20060102150405", value, location)
+	default:
+		return time.Time{}, fmt.Errorf("unexpected OFX date length: %d", len(value))
+	}
+}
@@ -0,0 +1,115 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kerti/balances/backend/util/failure"
+)
+
+// Format represents a bank statement file format
+type Format string
+
+const (
+	// FormatOFX represents Open Financial Exchange statements, both the SGML (1.x) and XML (2.x) flavours,
+	// which also covers Quicken's QFX
+	FormatOFX Format = "ofx"
+	// FormatCAMT053 represents ISO 20022 Bank to Customer Statements (camt.053)
+	FormatCAMT053 Format = "camt053"
+)
+
+// Balance represents a closing balance of an account on a specific date
+type Balance struct {
+	Date   time.Time
+	Amount float64
+}
+
+// Statement represents the balances of a single account reported by a bank statement
+type Statement struct {
+	AccountNumber string
+	Balances      []Balance
+}
+
+// DetectFormat guesses the format of a bank statement from its content
+func DetectFormat(data []byte) (Format, error) {
+	switch {
+	case bytes.Contains(data, []byte("BkToCstmrStmt")):
+		return FormatCAMT053, nil
+	case bytes.Contains(data, []byte("OFXHEADER")), bytes.Contains(bytes.ToUpper(data), []byte("<OFX>")):
+		return FormatOFX, nil
+	default:
+		return "", failure.BadRequestFromString("unrecognized statement format")
+	}
+}
+
+// Parse reads the statements held in a bank statement file. The format is detected from the content when empty.
+func Parse(format Format, data []byte) (statements []Statement, err error) {
+	if format == "" {
+		format, err = DetectFormat(data)
+		if err != nil {
+			return
+		}
+	}
+
+	switch format {
+	case FormatOFX:
+		statements, err = parseOFX(data)
+	case FormatCAMT053:
+		statements, err = parseCAMT053(data)
+	default:
+		return nil, failure.BadRequestFromString(fmt.Sprintf("unsupported statement format: %s", format))
+	}
+	if err != nil {
+		return
+	}
+
+	if len(statements) == 0 {
+		return nil, failure.BadRequestFromString("statement holds no accounts")
+	}
+
+	return mergeStatements(statements), nil
+}
+
+// mergeStatements combines the statements of the same account, as files often hold one statement
+// per day, keeping the last balance reported for any given date
+func mergeStatements(statements []Statement) []Statement {
+	merged := make([]Statement, 0, len(statements))
+	indexes := make(map[string]int)
+
+	for _, statement := range statements {
+		idx, ok := indexes[statement.AccountNumber]
+		if !ok {
+			idx = len(merged)
+			indexes[statement.AccountNumber] = idx
+			merged = append(merged, Statement{AccountNumber: statement.AccountNumber, Balances: make([]Balance, 0)})
+		}
+
+		for _, balance := range statement.Balances {
+			replaced := false
+			for balanceIdx, existing := range merged[idx].Balances {
+				if existing.Date.Equal(balance.Date) {
+					merged[idx].Balances[balanceIdx] = balance
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				merged[idx].Balances = append(merged[idx].Balances, balance)
+			}
+		}
+	}
+
+	return merged
+}
+
+// parseAmount parses a statement amount, accepting a comma as the decimal separator as some banks write them
+func parseAmount(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	if !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	return strconv.ParseFloat(value, 64)
+}
@@ -0,0 +1,390 @@
+package statement_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kerti/balances/backend/util/failure"
+	"github.com/kerti/balances/backend/util/statement"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testOFXSGML = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+ENCODING:USASCII
+CHARSET:1252
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0<SEVERITY>INFO</STATUS><DTSERVER>20240131120000</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1>
+<STMTTRNRS>
+<STMTRS>
+<CURDEF>USD
+<BANKACCTFROM>
+<BANKID>121000248
+<ACCTID>123456789
+<ACCTTYPE>CHECKING
+</BANKACCTFROM>
+<LEDGERBAL>
+<BALAMT>1523.45
+<DTASOF>20240131
+</LEDGERBAL>
+<AVAILBAL>
+<BALAMT>999.99
+<DTASOF>20240130
+</AVAILBAL>
+</STMTRS>
+</STMTTRNRS>
+</BANKMSGSRSV1>
+</OFX>
+`
+
+	testOFXXML = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<!-- exported by the bank -->
+<OFX>
+  <CREDITCARDMSGSRSV1>
+    <CCSTMTTRNRS>
+      <CCSTMTRS>
+        <CURDEF>USD</CURDEF>
+        <CCACCTFROM>
+          <ACCTID>4111 &amp; 1111</ACCTID>
+        </CCACCTFROM>
+        <LEDGERBAL>
+          <BALAMT>-250.10</BALAMT>
+          <DTASOF>20240131120000[-5:EST]</DTASOF>
+        </LEDGERBAL>
+      </CCSTMTRS>
+    </CCSTMTTRNRS>
+  </CREDITCARDMSGSRSV1>
+</OFX>
+`
+
+	testCAMT053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Acct><Id><IBAN>DE89370400440532013000</IBAN></Id></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">100.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><Dt>2024-01-01</Dt></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">1234,56</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Dt><Dt>2024-01-31</Dt></Dt>
+      </Bal>
+    </Stmt>
+    <Stmt>
+      <Acct><Id><Othr><Id>0532013000</Id></Othr></Id></Acct>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">50.25</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><DtTm>2024-01-31T18:00:00+01:00</DtTm></Dt>
+      </Bal>
+      <Bal>
+        <Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp>
+        <Amt Ccy="EUR">60.75</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Dt><DtTm>2024-02-29T18:00:00</DtTm></Dt>
+      </Bal>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>
+`
+)
+
+func getOFXWithLedgerBalance(amount, date string) []byte {
+	return []byte(`<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKACCTFROM><ACCTID>123456789</BANKACCTFROM>
+<LEDGERBAL><BALAMT>` + amount + `<DTASOF>` + date + `</LEDGERBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`)
+}
+
+func TestDetectFormat(t *testing.T) {
+
+	t.Run("normal", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			data     string
+			expected statement.Format
+		}{
+			{name: "ofxSGML", data: testOFXSGML, expected: statement.FormatOFX},
+			{name: "ofxXML", data: testOFXXML, expected: statement.FormatOFX},
+			{name: "ofxLowercaseWithoutHeader", data: "<ofx><stmtrs></stmtrs></ofx>", expected: statement.FormatOFX},
+			{name: "camt053", data: testCAMT053, expected: statement.FormatCAMT053},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				format, err := statement.DetectFormat([]byte(testCase.data))
+
+				assert.Nil(t, err)
+				assert.Equal(t, testCase.expected, format)
+			})
+		}
+	})
+
+	t.Run("unrecognized", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			data string
+		}{
+			{name: "empty", data: ""},
+			{name: "csv", data: "date,amount\n2024-01-31,100.00\n"},
+			{name: "otherXML", data: `<?xml version="1.0"?><Document><Other/></Document>`},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				format, err := statement.DetectFormat([]byte(testCase.data))
+
+				assert.Equal(t, statement.Format(""), format)
+				assert.NotNil(t, err)
+				assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+			})
+		}
+	})
+
+}
+
+func TestParseOFX(t *testing.T) {
+
+	t.Run("sgml", func(t *testing.T) {
+		statements, err := statement.Parse("", []byte(testOFXSGML))
+
+		assert.Nil(t, err)
+		assert.Len(t, statements, 1)
+		assert.Equal(t, "123456789", statements[0].AccountNumber)
+		assert.Len(t, statements[0].Balances, 1)
+		assert.Equal(t, 1523.45, statements[0].Balances[0].Amount)
+		assert.True(t, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC).Equal(statements[0].Balances[0].Date))
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		statements, err := statement.Parse(statement.FormatOFX, []byte(testOFXXML))
+
+		assert.Nil(t, err)
+		assert.Len(t, statements, 1)
+		assert.Equal(t, "4111 & 1111", statements[0].AccountNumber)
+		assert.Len(t, statements[0].Balances, 1)
+		assert.Equal(t, -250.10, statements[0].Balances[0].Amount)
+		assert.True(t, time.Date(2024, time.January, 31, 17, 0, 0, 0, time.UTC).Equal(statements[0].Balances[0].Date))
+	})
+
+	t.Run("dates", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			date     string
+			expected time.Time
+		}{
+			{
+				name:     "dateOnly",
+				date:     "20240131",
+				expected: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				name:     "withoutSeconds",
+				date:     "202401311230",
+				expected: time.Date(2024, time.January, 31, 12, 30, 0, 0, time.UTC),
+			},
+			{
+				name:     "withSeconds",
+				date:     "20240131123045",
+				expected: time.Date(2024, time.January, 31, 12, 30, 45, 0, time.UTC),
+			},
+			{
+				name:     "fractionalSeconds",
+				date:     "20240131123045.678",
+				expected: time.Date(2024, time.January, 31, 12, 30, 45, 0, time.UTC),
+			},
+			{
+				name:     "offsetWithZoneName",
+				date:     "20240131120000[-5:EST]",
+				expected: time.Date(2024, time.January, 31, 17, 0, 0, 0, time.UTC),
+			},
+			{
+				name:     "offsetWithoutZoneName",
+				date:     "20240131120000[+7]",
+				expected: time.Date(2024, time.January, 31, 5, 0, 0, 0, time.UTC),
+			},
+			{
+				name:     "fractionalOffset",
+				date:     "20240131120000[5.5:IST]",
+				expected: time.Date(2024, time.January, 31, 6, 30, 0, 0, time.UTC),
+			},
+			{
+				name:     "fractionalSecondsWithOffset",
+				date:     "20240131120000.000[-5:EST]",
+				expected: time.Date(2024, time.January, 31, 17, 0, 0, 0, time.UTC),
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				statements, err := statement.Parse(statement.FormatOFX, getOFXWithLedgerBalance("100.00", testCase.date))
+
+				assert.Nil(t, err)
+				assert.Len(t, statements, 1)
+				assert.Len(t, statements[0].Balances, 1)
+				assert.True(t, testCase.expected.Equal(statements[0].Balances[0].Date),
+					"expected %s, got %s", testCase.expected, statements[0].Balances[0].Date)
+			})
+		}
+	})
+
+	t.Run("commaDecimalAmount", func(t *testing.T) {
+		statements, err := statement.Parse(statement.FormatOFX, getOFXWithLedgerBalance("-1523,45", "20240131"))
+
+		assert.Nil(t, err)
+		assert.Len(t, statements, 1)
+		assert.Equal(t, -1523.45, statements[0].Balances[0].Amount)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			data []byte
+		}{
+			{name: "amount", data: getOFXWithLedgerBalance("1.523,45", "20240131")},
+			{name: "dateLength", data: getOFXWithLedgerBalance("100.00", "202401")},
+			{name: "dateOffset", data: getOFXWithLedgerBalance("100.00", "20240131[EST]")},
+			{name: "noAccountNumber", data: []byte("<OFX><STMTRS><LEDGERBAL><BALAMT>1<DTASOF>20240131</LEDGERBAL></STMTRS></OFX>")},
+			{name: "noStatements", data: []byte("<OFX><SIGNONMSGSRSV1></SIGNONMSGSRSV1></OFX>")},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				statements, err := statement.Parse(statement.FormatOFX, testCase.data)
+
+				assert.Nil(t, statements)
+				assert.NotNil(t, err)
+				assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+			})
+		}
+	})
+
+}
+
+func TestParseCAMT053(t *testing.T) {
+
+	t.Run("normal", func(t *testing.T) {
+		statements, err := statement.Parse("", []byte(testCAMT053))
+
+		assert.Nil(t, err)
+		assert.Len(t, statements, 2)
+
+		// only closing booked balances are read, and debit balances are negative
+		assert.Equal(t, "DE89370400440532013000", statements[0].AccountNumber)
+		assert.Len(t, statements[0].Balances, 1)
+		assert.Equal(t, -1234.56, statements[0].Balances[0].Amount)
+		assert.True(t, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC).Equal(statements[0].Balances[0].Date))
+
+		// the date and time is used when the balance has no date, with or without an offset
+		assert.Equal(t, "0532013000", statements[1].AccountNumber)
+		assert.Len(t, statements[1].Balances, 2)
+		assert.Equal(t, 50.25, statements[1].Balances[0].Amount)
+		assert.True(t, time.Date(2024, time.January, 31, 17, 0, 0, 0, time.UTC).Equal(statements[1].Balances[0].Date))
+		assert.Equal(t, 60.75, statements[1].Balances[1].Amount)
+		assert.True(t, time.Date(2024, time.February, 29, 18, 0, 0, 0, time.UTC).Equal(statements[1].Balances[1].Date))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			data string
+		}{
+			{
+				name: "malformed",
+				data: `<Document><BkToCstmrStmt><Stmt>`,
+			},
+			{
+				name: "noAccountNumber",
+				data: `<Document><BkToCstmrStmt><Stmt><Acct><Id></Id></Acct></Stmt></BkToCstmrStmt></Document>`,
+			},
+			{
+				name: "amount",
+				data: `<Document><BkToCstmrStmt><Stmt><Acct><Id><IBAN>DE89</IBAN></Id></Acct>
+					<Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt>abc</Amt><Dt><Dt>2024-01-31</Dt></Dt></Bal>
+					</Stmt></BkToCstmrStmt></Document>`,
+			},
+			{
+				name: "date",
+				data: `<Document><BkToCstmrStmt><Stmt><Acct><Id><IBAN>DE89</IBAN></Id></Acct>
+					<Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt>1.00</Amt><Dt><DtTm>31.01.2024</DtTm></Dt></Bal>
+					</Stmt></BkToCstmrStmt></Document>`,
+			},
+			{
+				name: "noStatements",
+				data: `<Document><BkToCstmrStmt></BkToCstmrStmt></Document>`,
+			},
+		}
+
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				statements, err := statement.Parse(statement.FormatCAMT053, []byte(testCase.data))
+
+				assert.Nil(t, statements)
+				assert.NotNil(t, err)
+				assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+			})
+		}
+	})
+
+}
+
+func TestParse(t *testing.T) {
+
+	t.Run("mergesStatementsOfSameAccount", func(t *testing.T) {
+		data := `<OFX><BANKMSGSRSV1>
+<STMTTRNRS><STMTRS><BANKACCTFROM><ACCTID>111</BANKACCTFROM>
+<LEDGERBAL><BALAMT>100.00<DTASOF>20240130</LEDGERBAL></STMTRS></STMTTRNRS>
+<STMTTRNRS><STMTRS><BANKACCTFROM><ACCTID>222</BANKACCTFROM>
+<LEDGERBAL><BALAMT>500.00<DTASOF>20240130</LEDGERBAL></STMTRS></STMTTRNRS>
+<STMTTRNRS><STMTRS><BANKACCTFROM><ACCTID>111</BANKACCTFROM>
+<LEDGERBAL><BALAMT>110.00<DTASOF>20240131</LEDGERBAL></STMTRS></STMTTRNRS>
+<STMTTRNRS><STMTRS><BANKACCTFROM><ACCTID>111</BANKACCTFROM>
+<LEDGERBAL><BALAMT>105.00<DTASOF>20240130</LEDGERBAL></STMTRS></STMTTRNRS>
+</BANKMSGSRSV1></OFX>`
+
+		statements, err := statement.Parse(statement.FormatOFX, []byte(data))
+
+		assert.Nil(t, err)
+		assert.Len(t, statements, 2)
+
+		// accounts keep the order they first appear in, and the last balance reported for a date wins
+		assert.Equal(t, "111", statements[0].AccountNumber)
+		assert.Equal(t, []statement.Balance{
+			{Date: time.Date(2024, time.January, 30, 0, 0, 0, 0, time.UTC), Amount: 105},
+			{Date: time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC), Amount: 110},
+		}, statements[0].Balances)
+
+		assert.Equal(t, "222", statements[1].AccountNumber)
+		assert.Equal(t, []statement.Balance{
+			{Date: time.Date(2024, time.January, 30, 0, 0, 0, 0, time.UTC), Amount: 500},
+		}, statements[1].Balances)
+	})
+
+	t.Run("unrecognizedFormat", func(t *testing.T) {
+		statements, err := statement.Parse("", []byte("date,amount\n2024-01-31,100.00\n"))
+
+		assert.Nil(t, statements)
+		assert.NotNil(t, err)
+		assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+	})
+
+	t.Run("unsupportedFormat", func(t *testing.T) {
+		statements, err := statement.Parse("qif", []byte(testOFXSGML))
+
+		assert.Nil(t, statements)
+		assert.NotNil(t, err)
+		assert.Equal(t, failure.CodeBadRequest, failure.GetCode(err))
+	})
+
+}
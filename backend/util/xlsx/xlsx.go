@@ -0,0 +1,285 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType is the content type of an XLSX workbook
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// maxSheetNameLength is the longest name a worksheet may have
+const maxSheetNameLength = 31
+
+// the styles defined in styles.xml, referred to by their index
+const (
+	styleDefault = iota
+	styleHeader
+	styleDate
+	styleAmount
+	styleTotal
+)
+
+// excelEpoch is day zero of the 1900 date system, accounting for Excel's fictitious 1900-02-29
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// Amount is a number written with two decimals and thousands separators
+type Amount float64
+
+// Total is an amount written in bold, for the totals of a sheet
+type Total float64
+
+// Workbook is an XLSX workbook, built in memory and written out in one go
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// Sheet is a single worksheet of a workbook
+type Sheet struct {
+	name   string
+	rows   []row
+	widths []float64
+}
+
+type row struct {
+	header bool
+	cells  []interface{}
+}
+
+// NewWorkbook creates a new, empty Workbook
+func NewWorkbook() *Workbook {
+	return &Workbook{sheets: make([]*Sheet, 0)}
+}
+
+// AddSheet adds a worksheet to the workbook. Names are shortened and stripped of the characters
+// worksheet names may not hold.
+func (w *Workbook) AddSheet(name string) *Sheet {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if len([]rune(name)) > maxSheetNameLength {
+		name = string([]rune(name)[:maxSheetNameLength])
+	}
+
+	sheet := &Sheet{name: name, rows: make([]row, 0)}
+	w.sheets = append(w.sheets, sheet)
+	return sheet
+}
+
+// AddHeader adds a row of column titles, written in bold
+func (s *Sheet) AddHeader(titles ...string) {
+	cells := make([]interface{}, 0, len(titles))
+	for _, title := range titles {
+		cells = append(cells, title)
+	}
+	s.rows = append(s.rows, row{header: true, cells: cells})
+}
+
+// AddRow adds a row of cells, which may be strings, numbers, Amounts, Totals, times or nil for empty cells
+func (s *Sheet) AddRow(cells ...interface{}) {
+	s.rows = append(s.rows, row{cells: cells})
+}
+
+// SetWidths sets the widths of the leading columns of the sheet, in characters
+func (s *Sheet) SetWidths(widths ...float64) {
+	s.widths = widths
+}
+
+// Write writes the workbook as an XLSX file
+func (w *Workbook) Write(out io.Writer) error {
+	zipWriter := zip.NewWriter(out)
+
+	files := []struct {
+		name    string
+		content func(io.Writer) error
+	}{
+		{"[Content_Types].xml", w.writeContentTypes},
+		{"_rels/.rels", writeString(packageRels)},
+		{"xl/workbook.xml", w.writeWorkbook},
+		{"xl/_rels/workbook.xml.rels", w.writeWorkbookRels},
+		{"xl/styles.xml", writeString(styles)},
+	}
+	for idx, sheet := range w.sheets {
+		files = append(files, struct {
+			name    string
+			content func(io.Writer) error
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", idx+1), sheet.write})
+	}
+
+	for _, file := range files {
+		fileWriter, err := zipWriter.Create(file.name)
+		if err != nil {
+			return err
+		}
+		err = file.content(fileWriter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return zipWriter.Close()
+}
+
+func writeString(content string) func(io.Writer) error {
+	return func(w io.Writer) error {
+		_, err := io.WriteString(w, content)
+		return err
+	}
+}
+
+func (w *Workbook) writeContentTypes(out io.Writer) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for idx := range w.sheets {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, idx+1)
+	}
+	b.WriteString(`</Types>`)
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func (w *Workbook) writeWorkbook(out io.Writer) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for idx, sheet := range w.sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.name), idx+1, idx+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func (w *Workbook) writeWorkbookRels(out io.Writer) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for idx := range w.sheets {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, idx+1, idx+1)
+	}
+	// the styles follow the sheets so that sheet relationship IDs match their positions
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(w.sheets)+1)
+	b.WriteString(`</Relationships>`)
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func (s *Sheet) write(out io.Writer) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	if len(s.widths) > 0 {
+		b.WriteString(`<cols>`)
+		for idx, width := range s.widths {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, idx+1, idx+1, formatNumber(width))
+		}
+		b.WriteString(`</cols>`)
+	}
+
+	b.WriteString(`<sheetData>`)
+	for rowIdx, row := range s.rows {
+		fmt.Fprintf(&b, `<r r="%d">`, rowIdx+1)
+		for colIdx, cell := range row.cells {
+			writeCell(&b, cellReference(colIdx, rowIdx), cell, row.header)
+		}
+		b.WriteString(`</r>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func writeCell(b *strings.Builder, ref string, cell interface{}, header bool) {
+	style := styleDefault
+	if header {
+		style = styleHeader
+	}
+
+	switch value := cell.(type) {
+	case nil:
+		return
+	case string:
+		fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escape(value))
+	case time.Time:
+		if value.IsZero() {
+			return
+		}
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, styleDate, formatNumber(toSerialDate(value)))
+	case Amount:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, styleAmount, formatNumber(float64(value)))
+	case Total:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, styleTotal, formatNumber(float64(value)))
+	case float64:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, formatNumber(value))
+	case int:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%d</v></c>`, ref, style, value)
+	default:
+		fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, escape(fmt.Sprint(value)))
+	}
+}
+
+// cellReference converts zero-based column and row indexes to an A1 reference
+func cellReference(col, row int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name + strconv.Itoa(row+1)
+}
+
+// toSerialDate converts a time to the number of days since the epoch of Excel's 1900 date system,
+// keeping the wall clock of the time's own location
+func toSerialDate(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	return wall.Sub(excelEpoch).Hours() / 24
+}
+
+func formatNumber(value float64) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "0"
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func escape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
+
+const packageRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles defines, in order, the default, header, date, amount and total cell styles
+const styles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="1" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
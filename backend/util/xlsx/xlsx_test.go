@@ -0,0 +1,203 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readWorkbookFiles writes the workbook and reads its files back by name
+func readWorkbookFiles(t *testing.T, workbook *Workbook) map[string]string {
+	var buf bytes.Buffer
+	err := workbook.Write(&buf)
+	assert.Nil(t, err)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.Nil(t, err)
+
+	files := make(map[string]string)
+	for _, file := range zipReader.File {
+		reader, err := file.Open()
+		assert.Nil(t, err)
+		content, err := io.ReadAll(reader)
+		assert.Nil(t, err)
+		reader.Close()
+		files[file.Name] = string(content)
+	}
+	return files
+}
+
+func TestCellReference(t *testing.T) {
+	testCases := []struct {
+		col      int
+		row      int
+		expected string
+	}{
+		{col: 0, row: 0, expected: "A1"},
+		{col: 1, row: 9, expected: "B10"},
+		{col: 25, row: 0, expected: "Z1"},
+		{col: 26, row: 0, expected: "AA1"},
+		{col: 27, row: 1, expected: "AB2"},
+		{col: 51, row: 0, expected: "AZ1"},
+		{col: 52, row: 0, expected: "BA1"},
+		{col: 701, row: 0, expected: "ZZ1"},
+		{col: 702, row: 99, expected: "AAA100"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.expected, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, cellReference(testCase.col, testCase.row))
+		})
+	}
+}
+
+func TestToSerialDate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		time     time.Time
+		expected float64
+	}{
+		{
+			name:     "epoch",
+			time:     time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC),
+			expected: 0,
+		},
+		{
+			name:     "afterFictitiousLeapDay",
+			time:     time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC),
+			expected: 61,
+		},
+		{
+			name:     "date",
+			time:     time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC),
+			expected: 45322,
+		},
+		{
+			name:     "timeOfDay",
+			time:     time.Date(2024, time.January, 31, 18, 0, 0, 0, time.UTC),
+			expected: 45322.75,
+		},
+		{
+			name:     "keepsWallClockOfLocation",
+			time:     time.Date(2024, time.January, 31, 0, 0, 0, 0, time.FixedZone("WIB", 7*3600)),
+			expected: 45322,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.InDelta(t, testCase.expected, toSerialDate(testCase.time), 1e-9)
+		})
+	}
+}
+
+func TestAddSheet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain",
+			input:    "Net Worth",
+			expected: "Net Worth",
+		},
+		{
+			name:     "stripsForbiddenCharacters",
+			input:    `[Bank]: A/B\C*D?`,
+			expected: "Bank ABCD",
+		},
+		{
+			name:     "truncated",
+			input:    strings.Repeat("a", maxSheetNameLength+10),
+			expected: strings.Repeat("a", maxSheetNameLength),
+		},
+		{
+			name:     "truncatedByRunes",
+			input:    strings.Repeat("é", maxSheetNameLength+1),
+			expected: strings.Repeat("é", maxSheetNameLength),
+		},
+		{
+			name:     "strippedBeforeTruncated",
+			input:    strings.Repeat("/", 5) + strings.Repeat("b", maxSheetNameLength),
+			expected: strings.Repeat("b", maxSheetNameLength),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			sheet := NewWorkbook().AddSheet(testCase.input)
+
+			assert.Equal(t, testCase.expected, sheet.name)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+
+	t.Run("escapesText", func(t *testing.T) {
+		workbook := NewWorkbook()
+		sheet := workbook.AddSheet(`Cash & "Savings"`)
+		sheet.AddHeader("Name <primary>")
+		sheet.AddRow("Tom & Jerry's <savings>", Amount(10.5), nil, time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC))
+
+		files := readWorkbookFiles(t, workbook)
+
+		assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Cash &amp; &#34;Savings&#34;" sheetId="1" r:id="rId1"/>`)
+		assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Name &lt;primary&gt;</t></is></c>`)
+		assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="A2" s="0" t="inlineStr"><is><t xml:space="preserve">Tom &amp; Jerry&#39;s &lt;savings&gt;</t></is></c>`)
+		assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="B2" s="3"><v>10.5</v></c>`)
+		assert.NotContains(t, files["xl/worksheets/sheet1.xml"], `r="C2"`)
+		assert.Contains(t, files["xl/worksheets/sheet1.xml"], `<c r="D2" s="2"><v>45322</v></c>`)
+
+		// every part must still be well-formed XML
+		for name, content := range files {
+			decoder := xml.NewDecoder(strings.NewReader(content))
+			for {
+				_, err := decoder.Token()
+				if err == io.EOF {
+					break
+				}
+				if !assert.Nil(t, err, name) {
+					break
+				}
+			}
+		}
+	})
+
+	t.Run("partsMatchSheetCount", func(t *testing.T) {
+		for _, sheetCount := range []int{0, 1, 3} {
+			t.Run(fmt.Sprintf("sheets%d", sheetCount), func(t *testing.T) {
+				workbook := NewWorkbook()
+				for idx := 0; idx < sheetCount; idx++ {
+					workbook.AddSheet(fmt.Sprintf("Sheet %d", idx+1)).AddRow("value")
+				}
+
+				files := readWorkbookFiles(t, workbook)
+
+				assert.Len(t, files, 5+sheetCount)
+				assert.Equal(t, sheetCount, strings.Count(files["[Content_Types].xml"], `/xl/worksheets/sheet`))
+				assert.Equal(t, sheetCount, strings.Count(files["xl/workbook.xml"], `<sheet `))
+				assert.Equal(t, sheetCount+1, strings.Count(files["xl/_rels/workbook.xml.rels"], `<Relationship `))
+
+				for idx := 1; idx <= sheetCount; idx++ {
+					assert.Contains(t, files, fmt.Sprintf("xl/worksheets/sheet%d.xml", idx))
+					assert.Contains(t, files["[Content_Types].xml"], fmt.Sprintf(`PartName="/xl/worksheets/sheet%d.xml"`, idx))
+					assert.Contains(t, files["xl/workbook.xml"], fmt.Sprintf(`sheetId="%d" r:id="rId%d"`, idx, idx))
+					assert.Contains(t, files["xl/_rels/workbook.xml.rels"], fmt.Sprintf(`Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"`, idx, idx))
+				}
+
+				// the styles take the relationship ID following the sheets
+				assert.Contains(t, files["xl/_rels/workbook.xml.rels"], fmt.Sprintf(`Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"`, sheetCount+1))
+			})
+		}
+	})
+
+}